@@ -0,0 +1,282 @@
+package anonymize
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/states"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+var (
+	// accountIDRe matches the AWS account IDs,
+	// also the ones on the ARNs
+	accountIDRe = regexp.MustCompile(`\b\d{12}\b`)
+
+	// ipRe matches the IPv4 addresses
+	// and CIDRs (ex: 10.0.0.0/16)
+	ipRe = regexp.MustCompile(`\b(\d{1,3}(?:\.\d{1,3}){3})(/\d{1,2})?\b`)
+
+	// domainRe matches the domain names of the common top
+	// level domains, the rest are not matched as those can
+	// not be told apart from other values (ex: t2.micro)
+	domainRe = regexp.MustCompile(`(?i)\b(?:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?\.)+(?:com|net|org|io|dev|app|cloud|co|info|biz|uk|fr|de|es|it|nl|be|ch)\b`)
+)
+
+// keepIPs are the IPs that are not internal data
+var keepIPs = map[string]struct{}{
+	"0.0.0.0":         struct{}{},
+	"127.0.0.1":       struct{}{},
+	"255.255.255.255": struct{}{},
+}
+
+// keepDomains are the domains, and their subdomains, of the cloud
+// providers used on the configurations (ex: the service principals
+// like ec2.amazonaws.com) and the ones of the pseudonyms
+var keepDomains = []string{
+	"amazonaws.com",
+	"amazon.com",
+	"googleapis.com",
+	"google.com",
+	"gserviceaccount.com",
+	"example.com",
+}
+
+// tagAttributes are the attributes with the tags of the resources
+var tagAttributes = map[string]struct{}{
+	"tags":     struct{}{},
+	"tags_all": struct{}{},
+	"labels":   struct{}{},
+}
+
+// Anonymizer replaces the internal data of the values with
+// pseudonyms, the same value has always the same pseudonym
+// so the references between the resources are kept
+type Anonymizer struct {
+	salt string
+}
+
+// New returns an Anonymizer with the salt of the pseudonyms,
+// so they can not be guessed from the values (ex: the account
+// IDs, which can be brute forced). If the salt is empty a random
+// one is generated, so the pseudonyms change on each run
+func New(salt string) (*Anonymizer, error) {
+	if salt == "" {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, errors.Wrap(err, "could not generate the salt")
+		}
+		salt = hex.EncodeToString(b)
+	}
+	return &Anonymizer{salt: salt}, nil
+}
+
+// HCLMiddleware returns the writer.Middleware of the HCL
+// that anonymizes the values written, see Value
+func (a *Anonymizer) HCLMiddleware() writer.Middleware {
+	return writer.InterceptWrite(func(key string, value interface{}) (interface{}, error) {
+		return a.Value(value), nil
+	})
+}
+
+// StateMiddleware returns the writer.Middleware of the TFState
+// that anonymizes the state of the resources written
+func (a *Anonymizer) StateMiddleware() writer.Middleware {
+	return writer.InterceptWrite(func(key string, value interface{}) (interface{}, error) {
+		r, ok := value.(provider.Resource)
+		if !ok {
+			return value, nil
+		}
+
+		obj := r.ResourceInstanceObject()
+		if obj == nil {
+			return value, nil
+		}
+
+		v, err := a.state(obj.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not anonymize %s", key)
+		}
+
+		// The obj is copied as it's
+		// the one of the resource
+		aobj := *obj
+		aobj.Value = v
+
+		return &resource{Resource: r, obj: &aobj}, nil
+	})
+}
+
+// Value returns a copy of the v, a configuration of the HCL,
+// with the strings anonymized (see String) and the values of
+// the tags replaced (see Tag)
+func (a *Anonymizer) Value(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case string:
+		return a.String(vv)
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(vv))
+		for k, e := range vv {
+			if tags, ok := e.(map[string]interface{}); ok && isTagAttribute(k) {
+				res[k] = a.tags(tags)
+				continue
+			}
+			res[k] = a.Value(e)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, 0, len(vv))
+		for _, e := range vv {
+			res = append(res, a.Value(e))
+		}
+		return res
+	case []map[string]interface{}:
+		res := make([]map[string]interface{}, 0, len(vv))
+		for _, e := range vv {
+			res = append(res, a.Value(e).(map[string]interface{}))
+		}
+		return res
+	default:
+		return v
+	}
+}
+
+// String returns the s with the AWS account IDs, the IPv4
+// addresses and the domain names replaced by pseudonyms
+func (a *Anonymizer) String(s string) string {
+	s = accountIDRe.ReplaceAllStringFunc(s, a.accountID)
+	s = ipRe.ReplaceAllStringFunc(s, a.ip)
+	s = domainRe.ReplaceAllStringFunc(s, a.domain)
+	return s
+}
+
+// Name returns the pseudonym of the name of the resource of the
+// type t with the id (ex: r1a2b3c4d), as the names are built from
+// the tags or the ID of the resources, which are internal data
+func (a *Anonymizer) Name(t, id string) string {
+	return fmt.Sprintf("r%s", hex.EncodeToString(a.hash("name", t+"."+id)[:4]))
+}
+
+// Tag returns the pseudonym of the tag value v (ex: tag-1a2b3c4d)
+func (a *Anonymizer) Tag(v string) string {
+	if v == "" {
+		return v
+	}
+	return fmt.Sprintf("tag-%s", hex.EncodeToString(a.hash("tag", v)[:4]))
+}
+
+// tags returns a copy of the tags with the values replaced
+func (a *Anonymizer) tags(tags map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(tags))
+	for k, v := range tags {
+		if s, ok := v.(string); ok {
+			res[k] = a.Tag(s)
+			continue
+		}
+		res[k] = a.Value(v)
+	}
+	return res
+}
+
+// accountID returns the pseudonym of the account ID id,
+// which is also a number of 12 digits
+func (a *Anonymizer) accountID(id string) string {
+	n := binary.BigEndian.Uint64(a.hash("account", id)) % 1e12
+	return fmt.Sprintf("%012d", n)
+}
+
+// ip returns the pseudonym of the IP, on the 10.0.0.0/8,
+// or of the CIDR, with the same prefix length
+func (a *Anonymizer) ip(s string) string {
+	m := ipRe.FindStringSubmatch(s)
+	ip := net.ParseIP(m[1]).To4()
+	if ip == nil {
+		return s
+	}
+	if _, ok := keepIPs[m[1]]; ok {
+		return s
+	}
+
+	h := a.hash("ip", m[1])
+	pip := net.IPv4(10, h[0], h[1], h[2]).To4()
+	if m[2] != "" {
+		bits, err := strconv.Atoi(strings.TrimPrefix(m[2], "/"))
+		if err != nil || bits > 32 {
+			return s
+		}
+		pip = pip.Mask(net.CIDRMask(bits, 32))
+	}
+
+	return pip.String() + m[2]
+}
+
+// domain returns the pseudonym of the domain d on the
+// example.com, unless it's one of the keepDomains
+func (a *Anonymizer) domain(d string) string {
+	ld := strings.ToLower(d)
+	for _, k := range keepDomains {
+		if ld == k || strings.HasSuffix(ld, "."+k) {
+			return d
+		}
+	}
+	return fmt.Sprintf("%s.example.com", hex.EncodeToString(a.hash("domain", ld)[:4]))
+}
+
+// hash returns the hash of the value v of the kind with the salt
+func (a *Anonymizer) hash(kind, v string) []byte {
+	h := sha256.Sum256([]byte(a.salt + "\x00" + kind + "\x00" + v))
+	return h[:]
+}
+
+// state returns the v, the state of a resource, with the strings
+// anonymized and the values of the tags replaced, like Value
+func (a *Anonymizer) state(v cty.Value) (cty.Value, error) {
+	return cty.Transform(v, func(p cty.Path, v cty.Value) (cty.Value, error) {
+		if v.IsNull() || !v.IsKnown() || v.Type() != cty.String {
+			return v, nil
+		}
+
+		// The values of the tags are on the
+		// path [..., GetAttr(tags), Index(key)]
+		if len(p) >= 2 {
+			if ga, ok := p[len(p)-2].(cty.GetAttrStep); ok && isTagAttribute(ga.Name) {
+				if _, ok := p[len(p)-1].(cty.IndexStep); ok {
+					return cty.StringVal(a.Tag(v.AsString())), nil
+				}
+			}
+		}
+
+		return cty.StringVal(a.String(v.AsString())), nil
+	})
+}
+
+// isTagAttribute checks if the k, also if it's
+// a map (prefixed with '=tc='), has the tags
+func isTagAttribute(k string) bool {
+	_, ok := tagAttributes[strings.TrimPrefix(k, "=tc=")]
+	return ok
+}
+
+// resource is a provider.Resource with
+// the state anonymized by the Anonymizer
+type resource struct {
+	provider.Resource
+
+	obj *states.ResourceInstanceObject
+}
+
+// ResourceInstanceObject returns the anonymized state
+func (r *resource) ResourceInstanceObject() *states.ResourceInstanceObject {
+	return r.obj
+}
@@ -0,0 +1,141 @@
+package anonymize_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/states"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/cycloidio/terracognita/anonymize"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+func TestString(t *testing.T) {
+	a, err := anonymize.New("salt")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		in   string
+		keep bool
+	}{
+		{name: "AccountID", in: "arn:aws:iam::123456789012:role/admin"},
+		{name: "IP", in: "192.168.1.20"},
+		{name: "CIDR", in: "172.31.0.0/16"},
+		{name: "Domain", in: "api.corp-internal.com"},
+		{name: "Email", in: "ops@corp.io"},
+		{name: "KeepWorld", in: "0.0.0.0/0", keep: true},
+		{name: "KeepService", in: "ec2.amazonaws.com", keep: true},
+		{name: "KeepInstanceType", in: "t2.micro", keep: true},
+		{name: "KeepReference", in: "${aws_vpc.main.id}", keep: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := a.String(tt.in)
+			if tt.keep {
+				assert.Equal(t, tt.in, out)
+				return
+			}
+			assert.NotEqual(t, tt.in, out)
+			assert.Equal(t, out, a.String(tt.in), "the pseudonyms have to be deterministic")
+		})
+	}
+
+	t.Run("Formats", func(t *testing.T) {
+		assert.Regexp(t, `^arn:aws:iam::\d{12}:role/admin$`, a.String("arn:aws:iam::123456789012:role/admin"))
+		assert.Regexp(t, `^10\.\d+\.\d+\.0/24$`, a.String("192.168.1.0/24"))
+		assert.Regexp(t, `^[0-9a-f]{8}\.example\.com$`, a.String("www.corp.com"))
+	})
+
+	t.Run("Salt", func(t *testing.T) {
+		o, err := anonymize.New("other")
+		require.NoError(t, err)
+		assert.NotEqual(t, a.String("192.168.1.20"), o.String("192.168.1.20"))
+	})
+
+	t.Run("RandomSalt", func(t *testing.T) {
+		r1, err := anonymize.New("")
+		require.NoError(t, err)
+		r2, err := anonymize.New("")
+		require.NoError(t, err)
+		assert.NotEqual(t, r1.String("192.168.1.20"), r2.String("192.168.1.20"))
+	})
+}
+
+func TestName(t *testing.T) {
+	a, err := anonymize.New("salt")
+	require.NoError(t, err)
+
+	n := a.Name("aws_instance", "i-123")
+	assert.Regexp(t, `^r[0-9a-f]{8}$`, n)
+	assert.Equal(t, n, a.Name("aws_instance", "i-123"), "the pseudonyms have to be deterministic")
+	assert.NotEqual(t, n, a.Name("aws_instance", "i-456"))
+}
+
+func TestValue(t *testing.T) {
+	a, err := anonymize.New("")
+	require.NoError(t, err)
+
+	cfg := map[string]interface{}{
+		"cidr_block": "10.1.0.0/16",
+		"port":       443,
+		"=tc=tags":   map[string]interface{}{"Name": "front", "owner": "alice@corp.com"},
+		"ingress": []interface{}{
+			map[string]interface{}{"cidr_blocks": []interface{}{"192.168.0.0/24"}},
+		},
+	}
+
+	exp := map[string]interface{}{
+		"cidr_block": a.String("10.1.0.0/16"),
+		"port":       443,
+		"=tc=tags":   map[string]interface{}{"Name": a.Tag("front"), "owner": a.Tag("alice@corp.com")},
+		"ingress": []interface{}{
+			map[string]interface{}{"cidr_blocks": []interface{}{a.String("192.168.0.0/24")}},
+		},
+	}
+
+	assert.Equal(t, exp, a.Value(cfg))
+	assert.Equal(t, "10.1.0.0/16", cfg["cidr_block"], "the value is not changed")
+}
+
+func TestStateMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a, err := anonymize.New("")
+	require.NoError(t, err)
+
+	var (
+		w   = mock.NewWriter(ctrl)
+		res = mock.NewResource(ctrl)
+		obj = &states.ResourceInstanceObject{
+			Value: cty.ObjectVal(map[string]cty.Value{
+				"id":         cty.StringVal("i-123"),
+				"private_ip": cty.StringVal("172.31.1.10"),
+				"tags":       cty.MapVal(map[string]cty.Value{"Name": cty.StringVal("front")}),
+			}),
+			Status: states.ObjectReady,
+		}
+	)
+
+	res.EXPECT().ResourceInstanceObject().Return(obj)
+	w.EXPECT().Write("aws_instance.front", gomock.Any()).DoAndReturn(func(key string, value interface{}) error {
+		r, ok := value.(provider.Resource)
+		require.True(t, ok)
+
+		v := r.ResourceInstanceObject().Value
+		assert.Equal(t, "i-123", v.GetAttr("id").AsString())
+		assert.Equal(t, a.String("172.31.1.10"), v.GetAttr("private_ip").AsString())
+		assert.Equal(t, a.Tag("front"), v.GetAttr("tags").Index(cty.StringVal("Name")).AsString())
+
+		return nil
+	})
+
+	require.NoError(t, a.StateMiddleware()(w).Write("aws_instance.front", res))
+	assert.Equal(t, "172.31.1.10", obj.Value.GetAttr("private_ip").AsString(), "the state of the resource is not changed")
+}
@@ -0,0 +1,5 @@
+// Package anonymize replaces the account IDs, IPs, domain
+// names and tag values of the HCL and TFState with
+// deterministic pseudonyms, so the outputs can be shared
+// without leaking internal data
+package anonymize
@@ -0,0 +1,3 @@
+// Package ansible has the Writer that generates an
+// Ansible inventory of the compute instances imported
+package ansible
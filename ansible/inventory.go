@@ -0,0 +1,244 @@
+package ansible
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// Format is the format of the inventory
+type Format int
+
+// List of all the Formats supported
+const (
+	// JSON is the format used by the
+	// dynamic inventory scripts
+	JSON Format = iota
+	// YAML is the format used by
+	// the 'yaml' inventory plugin
+	YAML
+)
+
+// host is an instance of the inventory
+type host struct {
+	Name   string
+	Vars   map[string]interface{}
+	Groups []string
+}
+
+// instance knows how to read the host from
+// the resource data of a compute instance
+type instance struct {
+	// address returns the IP to connect to
+	address func(d *schema.ResourceData) string
+	// groups returns the groups
+	// from the tags of the instance
+	groups func(d *schema.ResourceData) []string
+}
+
+var instances = map[string]instance{
+	"aws_instance": instance{
+		address: func(d *schema.ResourceData) string {
+			if ip := d.Get("public_ip").(string); ip != "" {
+				return ip
+			}
+			return d.Get("private_ip").(string)
+		},
+		groups: func(d *schema.ResourceData) []string {
+			return mapGroups("tag", d.Get("tags"))
+		},
+	},
+	"google_compute_instance": instance{
+		address: func(d *schema.ResourceData) string {
+			if ip, ok := d.GetOk("network_interface.0.access_config.0.nat_ip"); ok {
+				return ip.(string)
+			}
+			return d.Get("network_interface.0.network_ip").(string)
+		},
+		groups: func(d *schema.ResourceData) []string {
+			groups := mapGroups("label", d.Get("labels"))
+			if s, ok := d.Get("tags").(*schema.Set); ok {
+				for _, t := range s.List() {
+					groups = append(groups, groupName("tag", t.(string)))
+				}
+			}
+			return groups
+		},
+	},
+}
+
+// Writer is a Writer implementation that generates an Ansible
+// inventory of the compute instances, grouped by their tags.
+// The resources that are not compute instances are ignored
+type Writer struct {
+	*provider.Collector
+
+	format Format
+	writer io.Writer
+}
+
+// NewWriter returns a Writer initialization
+// that writes the inventory with the format
+func NewWriter(w io.Writer, f Format) *Writer {
+	types := make([]string, 0, len(instances))
+	for t := range instances {
+		types = append(types, t)
+	}
+
+	return &Writer{
+		Collector: provider.NewCollector("Ansible", types...),
+		format:    f,
+		writer:    w,
+	}
+}
+
+// Sync writes the inventory to the internal w
+func (w *Writer) Sync() error {
+	hosts := make([]host, 0, len(w.Keys()))
+	for _, k := range w.Keys() {
+		keys := strings.Split(k, ".")
+		r := w.Resource(k)
+		i := instances[keys[0]]
+		d := r.Data()
+
+		vars := map[string]interface{}{
+			"id": r.ID(),
+		}
+		if a := i.address(d); a != "" {
+			vars["ansible_host"] = a
+		}
+
+		groups := i.groups(d)
+		sort.Strings(groups)
+
+		hosts = append(hosts, host{
+			Name:   keys[1],
+			Vars:   vars,
+			Groups: groups,
+		})
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	switch w.format {
+	case JSON:
+		b, err = json.MarshalIndent(dynamicInventory(hosts), "", "  ")
+		b = append(b, '\n')
+	case YAML:
+		b, err = yaml.Marshal(yamlInventory(hosts))
+	default:
+		err = fmt.Errorf("invalid format %d", w.format)
+	}
+	if err != nil {
+		return errors.Wrap(err, "error while encoding the inventory")
+	}
+
+	_, err = w.writer.Write(b)
+	if err != nil {
+		return errors.Wrap(err, "error while writing the inventory")
+	}
+
+	return nil
+}
+
+// dynamicInventory returns the inventory with the structure
+// of the dynamic inventory scripts '--list' output
+func dynamicInventory(hosts []host) map[string]interface{} {
+	var (
+		hostvars = make(map[string]interface{})
+		groups   = make(map[string][]string)
+		all      = make([]string, 0, len(hosts))
+	)
+
+	for _, h := range hosts {
+		hostvars[h.Name] = h.Vars
+		all = append(all, h.Name)
+		for _, g := range h.Groups {
+			groups[g] = append(groups[g], h.Name)
+		}
+	}
+
+	inv := map[string]interface{}{
+		"_meta": map[string]interface{}{
+			"hostvars": hostvars,
+		},
+		"all": map[string]interface{}{
+			"hosts": all,
+		},
+	}
+
+	for g, hs := range groups {
+		inv[g] = map[string]interface{}{
+			"hosts": hs,
+		}
+	}
+
+	return inv
+}
+
+// yamlInventory returns the inventory with the
+// structure of the 'yaml' inventory plugin
+func yamlInventory(hosts []host) map[string]interface{} {
+	var (
+		allHosts = make(map[string]interface{})
+		children = make(map[string]interface{})
+	)
+
+	for _, h := range hosts {
+		allHosts[h.Name] = h.Vars
+		for _, g := range h.Groups {
+			if _, ok := children[g]; !ok {
+				children[g] = map[string]interface{}{
+					"hosts": make(map[string]interface{}),
+				}
+			}
+			children[g].(map[string]interface{})["hosts"].(map[string]interface{})[h.Name] = nil
+		}
+	}
+
+	all := map[string]interface{}{
+		"hosts": allHosts,
+	}
+	if len(children) != 0 {
+		all["children"] = children
+	}
+
+	return map[string]interface{}{
+		"all": all,
+	}
+}
+
+// mapGroups returns the groups of the tags m
+// with the format '<prefix>_<key>_<value>'
+func mapGroups(prefix string, m interface{}) []string {
+	tags, ok := m.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	groups := make([]string, 0, len(tags))
+	for k, v := range tags {
+		groups = append(groups, groupName(prefix, k, fmt.Sprintf("%v", v)))
+	}
+
+	return groups
+}
+
+var invalidGroupRe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// groupName joins the parts with '_' and replaces
+// the invalid characters of a group name
+func groupName(parts ...string) string {
+	return invalidGroupRe.ReplaceAllString(strings.Join(parts, "_"), "_")
+}
@@ -0,0 +1,152 @@
+package ansible_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cycloidio/terracognita/ansible"
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	tfr := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"public_ip":  &schema.Schema{Type: schema.TypeString, Computed: true},
+			"private_ip": &schema.Schema{Type: schema.TypeString, Optional: true},
+			"tags":       &schema.Schema{Type: schema.TypeMap, Optional: true},
+		},
+	}
+
+	resources := func(ctrl *gomock.Controller) (*mock.Resource, *mock.Resource, *mock.Resource) {
+		var (
+			r1 = mock.NewResource(ctrl)
+			r2 = mock.NewResource(ctrl)
+			r3 = mock.NewResource(ctrl)
+			d1 = tfr.Data(nil)
+			d2 = tfr.Data(nil)
+		)
+
+		d1.Set("public_ip", "1.2.3.4")
+		d1.Set("private_ip", "10.0.0.1")
+		d1.Set("tags", map[string]interface{}{"env": "prod"})
+		d2.Set("private_ip", "10.0.0.2")
+		d2.Set("tags", map[string]interface{}{"env": "prod", "role": "db-main"})
+
+		r1.EXPECT().ID().Return("i-1")
+		r1.EXPECT().Data().Return(d1)
+		r2.EXPECT().ID().Return("i-2")
+		r2.EXPECT().Data().Return(d2)
+
+		return r1, r2, r3
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		var (
+			ctrl       = gomock.NewController(t)
+			b          = &bytes.Buffer{}
+			aw         = ansible.NewWriter(b, ansible.JSON)
+			r1, r2, r3 = resources(ctrl)
+
+			inventory = `{
+  "_meta": {
+    "hostvars": {
+      "back": {
+        "ansible_host": "10.0.0.2",
+        "id": "i-2"
+      },
+      "front": {
+        "ansible_host": "1.2.3.4",
+        "id": "i-1"
+      }
+    }
+  },
+  "all": {
+    "hosts": [
+      "front",
+      "back"
+    ]
+  },
+  "tag_env_prod": {
+    "hosts": [
+      "front",
+      "back"
+    ]
+  },
+  "tag_role_db_main": {
+    "hosts": [
+      "back"
+    ]
+  }
+}
+`
+		)
+		defer ctrl.Finish()
+
+		require.NoError(t, aw.Write("aws_instance.front", r1))
+		require.NoError(t, aw.Write("aws_instance.back", r2))
+		require.NoError(t, aw.Write("aws_iam_user.john", r3))
+
+		ok, err := aw.Has("aws_iam_user.john")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, aw.Sync())
+		assert.Equal(t, inventory, b.String())
+	})
+	t.Run("YAML", func(t *testing.T) {
+		var (
+			ctrl       = gomock.NewController(t)
+			b          = &bytes.Buffer{}
+			aw         = ansible.NewWriter(b, ansible.YAML)
+			r1, r2, r3 = resources(ctrl)
+
+			inventory = `all:
+  children:
+    tag_env_prod:
+      hosts:
+        back: null
+        front: null
+    tag_role_db_main:
+      hosts:
+        back: null
+  hosts:
+    back:
+      ansible_host: 10.0.0.2
+      id: i-2
+    front:
+      ansible_host: 1.2.3.4
+      id: i-1
+`
+		)
+		defer ctrl.Finish()
+
+		require.NoError(t, aw.Write("aws_instance.front", r1))
+		require.NoError(t, aw.Write("aws_instance.back", r2))
+		require.NoError(t, aw.Write("aws_iam_user.john", r3))
+
+		require.NoError(t, aw.Sync())
+		assert.Equal(t, inventory, b.String())
+	})
+	t.Run("Errors", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			aw   = ansible.NewWriter(nil, ansible.JSON)
+			r    = mock.NewResource(ctrl)
+		)
+		defer ctrl.Finish()
+
+		assert.Equal(t, errcode.ErrWriterRequiredKey, errors.Cause(aw.Write("", r)))
+		assert.Equal(t, errcode.ErrWriterRequiredValue, errors.Cause(aw.Write("aws_instance.name", nil)))
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(aw.Write("aws_instance", r)))
+		assert.Equal(t, errcode.ErrWriterInvalidTypeValue, errors.Cause(aw.Write("aws_instance.name", "value")))
+
+		require.NoError(t, aw.Write("aws_instance.name", r))
+		assert.Equal(t, errcode.ErrWriterAlreadyExistsKey, errors.Cause(aw.Write("aws_instance.name", r)))
+	})
+}
@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// stoppedFor is the time after which
+// the stopped instances look unused
+const stoppedFor = 90 * 24 * time.Hour
+
+// stoppedAtRe matches the date on the reason of the last
+// transition of the instances, ex: 'User initiated (2019-10-01 12:00:00 GMT)'
+var stoppedAtRe = regexp.MustCompile(`\((\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}) GMT\)`)
+
+// unusedFns are the functions that return why the resources of
+// each ResourceType look unused from the attributes (get) of them,
+// the ones that need the state on the API are set on the listing
+// of them, see setUnused
+var unusedFns = map[ResourceType]func(get func(string) string) string{
+	SecurityGroup: func(get func(string) string) string {
+		// The default ones can not be deleted
+		if get("name") == "default" {
+			return ""
+		}
+		if get("ingress") == "" && get("egress") == "" {
+			return "has no ingress nor egress rules"
+		}
+		return ""
+	},
+}
+
+// stoppedReason returns why the instance stopped with the
+// transition reason looks unused at now, empty if it was
+// stopped less than stoppedFor ago or the date is unknown
+func stoppedReason(reason string, now time.Time) string {
+	m := stoppedAtRe.FindStringSubmatch(reason)
+	if m == nil {
+		return ""
+	}
+
+	at, err := time.Parse("2006-01-02 15:04:05", m[1])
+	if err != nil || now.Sub(at) < stoppedFor {
+		return ""
+	}
+
+	return fmt.Sprintf("stopped since %s", at.Format("2006-01-02"))
+}
+
+// setUnused sets the reason why the resource
+// with the id looks unused, see UnusedReason
+func (a *aws) setUnused(id, reason string) {
+	a.unusedMu.Lock()
+	defer a.unusedMu.Unlock()
+
+	if a.unused == nil {
+		a.unused = make(map[string]string)
+	}
+	a.unused[id] = reason
+}
+
+// UnusedReason returns why the resource of type t with the id
+// looks unused, from the state of it on the API when listed or
+// the attributes returned by the get, empty if it does not
+func (a *aws) UnusedReason(t, id string, get func(string) string) string {
+	a.unusedMu.Lock()
+	reason, ok := a.unused[id]
+	a.unusedMu.Unlock()
+	if ok {
+		return reason
+	}
+
+	rt, err := ResourceTypeString(t)
+	if err != nil {
+		return ""
+	}
+
+	fn, ok := unusedFns[rt]
+	if !ok {
+		return ""
+	}
+
+	return fn(get)
+}
@@ -1,20 +1,20 @@
 package main
 
 import (
-	"bytes"
-	"errors"
 	"flag"
 	"io"
-	"os"
-	"os/exec"
+
+	"github.com/cycloidio/terracognita/codegen"
 )
 
 var (
-	output string
+	output   string
+	manifest string
 )
 
 func init() {
 	flag.StringVar(&output, "output", "", "The output file of the generated code")
+	flag.StringVar(&manifest, "manifest", "", "The YAML (or JSON) manifest with the Functions to generate")
 }
 
 func main() {
@@ -24,57 +24,31 @@ func main() {
 		panic("The 'output' is required")
 	}
 
-	f, err := os.OpenFile(output, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if manifest == "" {
+		panic("The 'manifest' is required")
+	}
+
+	var functions []Function
+	err := codegen.LoadManifest(manifest, &functions)
 	if err != nil {
 		panic(err)
 	}
-	defer f.Close()
 
-	err = generate(f, functions)
+	err = codegen.GenerateFile(output, headerTmpl, functions, executors(functions))
 	if err != nil {
 		panic(err)
 	}
 }
 
 func generate(opt io.Writer, fns []Function) error {
-	var fnBuff = bytes.Buffer{}
-
-	// Adds the package definition
-	err := pkgTmpl.Execute(&fnBuff, nil)
-	if err != nil {
-		return err
-	}
-
-	// Adds the AWSReader interface
-	err = awsReaderTmpl.Execute(&fnBuff, fns)
-	if err != nil {
-		return err
-	}
+	return codegen.Generate(opt, headerTmpl, fns, executors(fns))
+}
 
-	// Adds the implementation of the functions
+// executors returns the fns as codegen.Executor
+func executors(fns []Function) []codegen.Executor {
+	execs := make([]codegen.Executor, 0, len(fns))
 	for _, fn := range fns {
-		err = fn.Execute(&fnBuff)
-		if err != nil {
-			return err
-		}
+		execs = append(execs, fn)
 	}
-
-	stderr := &bytes.Buffer{}
-
-	// Formats the output using goimports
-	cmd := exec.Command("goimports")
-	cmd.Stdin = &fnBuff
-	cmd.Stdout = opt
-	cmd.Stderr = stderr
-
-	err = cmd.Run()
-	if err != nil {
-		return err
-	}
-
-	if serr := stderr.String(); serr != "" {
-		return errors.New(serr)
-	}
-
-	return nil
+	return execs
 }
@@ -59,9 +59,11 @@ const (
 				input.{{.FilterByOwner}} = append(input.{{.FilterByOwner}}, c.accountID)
 			{{ end -}}
 
+			c.svc.mu.Lock()
 			if c.svc.{{.Service}} == nil {
 				c.svc.{{.Service}} = {{.Service}}.New(c.svc.session)
 			}
+			c.svc.mu.Unlock()
 
 			opt, err := c.svc.{{.Service}}.{{.Prefix}}{{.Entity}}WithContext(ctx, input)
 			if err != nil {
@@ -74,9 +76,11 @@ const (
 )
 
 var (
-	fnTmpl        *template.Template
-	pkgTmpl       *template.Template
-	awsReaderTmpl *template.Template
+	fnTmpl *template.Template
+
+	// headerTmpl is the package definition
+	// followed by the Reader interface
+	headerTmpl *template.Template
 )
 
 func init() {
@@ -87,12 +91,7 @@ func init() {
 		panic(err)
 	}
 
-	pkgTmpl, err = template.New("test").Parse(packageTmpl)
-	if err != nil {
-		panic(err)
-	}
-
-	awsReaderTmpl, err = template.New("test").Parse(arTmpl)
+	headerTmpl, err = template.New("test").Parse(packageTmpl + arTmpl)
 	if err != nil {
 		panic(err)
 	}
@@ -102,43 +101,43 @@ func init() {
 type Function struct {
 	// FnName is the name of the function
 	// if not defined "Get{{.Entity}i" is used
-	FnName string
+	FnName string `yaml:"fn_name"`
 
 	// Entity is the name of the entity, like
 	// CloudFrontOriginAccessIdentities, Instances etc
-	Entity string
+	Entity string `yaml:"entity"`
 
 	// Some functions on AWS have the "Describe" prefix
 	// or the "List" prefix, so it has to be specified
 	// which one to use
-	Prefix string
+	Prefix string `yaml:"prefix"`
 
 	// Service is the AWS service that it uses, basically the
 	// pkg name, so "ec2", "cloudfront" etc
-	Service string
+	Service string `yaml:"service"`
 
 	// Documentation is the documentation that will be added
 	// to the AWSReader function definition, as it's the
 	// only public part that could be seen on the godocs
-	Documentation string
+	Documentation string `yaml:"documentation"`
 
 	// Is the Output name that it has
-	FnOutput string
+	FnOutput string `yaml:"fn_output"`
 
 	// FnSignature is the signture it has to be used on the Interface
 	// AWSReader and the function implementation
-	FnSignature string
+	FnSignature string `yaml:"fn_signature"`
 
 	// NoGenerateFn avoids generating the function implementation as
 	// it's to different from the templates we use
 	// If true, it should be used with 'Signature' to add it to the
 	// AWSReader and have the custom implementation outside of the
 	// generated code
-	NoGenerateFn bool
+	NoGenerateFn bool `yaml:"no_generate_fn"`
 
 	// FilterByOwner adds the "{{.FilterByOwner}} = AccountID" to the input filter
 	// so this value has to be the correct name on the input
-	FilterByOwner string
+	FilterByOwner string `yaml:"filter_by_owner"`
 }
 
 // Name builds a name simply using "Get{{.Entity}}"
@@ -149,9 +149,11 @@ func TestTemplateExecute(t *testing.T) {
 			},
 			opt: `
 			func (c *connector) Signature {
+				c.svc.mu.Lock()
 				if c.svc.Service == nil {
 					c.svc.Service = Service.New(c.svc.session)
 				}
+				c.svc.mu.Unlock()
 
 				opt, err := c.svc.Service.PrefixEntityWithContext(ctx, input)
 				if err != nil {
@@ -177,9 +179,11 @@ func TestTemplateExecute(t *testing.T) {
 				}
 				input.OwnerField = append(input.OwnerField, c.accountID)
 
+				c.svc.mu.Lock()
 				if c.svc.Service == nil {
 					c.svc.Service = Service.New(c.svc.session)
 				}
+				c.svc.mu.Unlock()
 
 				opt, err := c.svc.Service.PrefixEntityWithContext(ctx, input)
 				if err != nil {
@@ -0,0 +1,113 @@
+package aws
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// consoleURLs are the functions that return the URL of the
+// resources of each ResourceType on the AWS console, with
+// the region and the id and attributes (get) of them
+var consoleURLs = map[ResourceType]func(region, id string, get func(string) string) string{
+	Instance: func(region, id string, get func(string) string) string {
+		return ec2ConsoleURL(region, "InstanceDetails:instanceId="+id)
+	},
+	EBSVolume: func(region, id string, get func(string) string) string {
+		return ec2ConsoleURL(region, "VolumeDetails:volumeId="+id)
+	},
+	SecurityGroup: func(region, id string, get func(string) string) string {
+		return ec2ConsoleURL(region, "SecurityGroup:groupId="+id)
+	},
+	LaunchTemplate: func(region, id string, get func(string) string) string {
+		return ec2ConsoleURL(region, "LaunchTemplateDetails:launchTemplateId="+id)
+	},
+	AutoscalingGroup: func(region, id string, get func(string) string) string {
+		return ec2ConsoleURL(region, "AutoScalingGroupDetails:id="+url.PathEscape(id))
+	},
+	LB: func(region, id string, get func(string) string) string {
+		return ec2ConsoleURL(region, "LoadBalancer:loadBalancerArn="+id)
+	},
+	VPC: func(region, id string, get func(string) string) string {
+		return vpcConsoleURL(region, "VpcDetails:VpcId="+id)
+	},
+	Subnet: func(region, id string, get func(string) string) string {
+		return vpcConsoleURL(region, "SubnetDetails:subnetId="+id)
+	},
+	RouteTable: func(region, id string, get func(string) string) string {
+		return vpcConsoleURL(region, "RouteTableDetails:RouteTableId="+id)
+	},
+	InternetGateway: func(region, id string, get func(string) string) string {
+		return vpcConsoleURL(region, "InternetGateway:internetGatewayId="+id)
+	},
+	NatGateway: func(region, id string, get func(string) string) string {
+		return vpcConsoleURL(region, "NatGatewayDetails:natGatewayId="+id)
+	},
+	DBInstance: func(region, id string, get func(string) string) string {
+		return fmt.Sprintf("https://%s.console.aws.amazon.com/rds/home?region=%s#database:id=%s;is-cluster=false", region, region, id)
+	},
+	RDSCluster: func(region, id string, get func(string) string) string {
+		return fmt.Sprintf("https://%s.console.aws.amazon.com/rds/home?region=%s#database:id=%s;is-cluster=true", region, region, id)
+	},
+	ElasticacheCluster: func(region, id string, get func(string) string) string {
+		return fmt.Sprintf("https://%s.console.aws.amazon.com/elasticache/home?region=%s#/memcached/%s", region, region, id)
+	},
+	S3Bucket: func(region, id string, get func(string) string) string {
+		return fmt.Sprintf("https://s3.console.aws.amazon.com/s3/buckets/%s?region=%s", id, region)
+	},
+	CloudfrontDistribution: func(region, id string, get func(string) string) string {
+		return "https://console.aws.amazon.com/cloudfront/v3/home#/distributions/" + id
+	},
+	IAMUser: func(region, id string, get func(string) string) string {
+		return "https://console.aws.amazon.com/iam/home#/users/" + url.PathEscape(id)
+	},
+	IAMRole: func(region, id string, get func(string) string) string {
+		return "https://console.aws.amazon.com/iam/home#/roles/" + url.PathEscape(id)
+	},
+	IAMGroup: func(region, id string, get func(string) string) string {
+		return "https://console.aws.amazon.com/iam/home#/groups/" + url.PathEscape(id)
+	},
+	IAMPolicy: func(region, id string, get func(string) string) string {
+		return "https://console.aws.amazon.com/iam/home#/policies/" + id
+	},
+	Route53Zone: func(region, id string, get func(string) string) string {
+		return "https://console.aws.amazon.com/route53/v2/hostedzones#ListRecordSets/" + id
+	},
+	CloudwatchLogGroup: func(region, id string, get func(string) string) string {
+		return fmt.Sprintf("https://%s.console.aws.amazon.com/cloudwatch/home?region=%s#logsV2:log-groups/log-group/%s", region, region, url.QueryEscape(id))
+	},
+	SecretsmanagerSecret: func(region, id string, get func(string) string) string {
+		return fmt.Sprintf("https://%s.console.aws.amazon.com/secretsmanager/secret?name=%s&region=%s", region, url.QueryEscape(get("name")), region)
+	},
+	SfnStateMachine: func(region, id string, get func(string) string) string {
+		return fmt.Sprintf("https://%s.console.aws.amazon.com/states/home?region=%s#/statemachines/view/%s", region, region, id)
+	},
+	EMRCluster: func(region, id string, get func(string) string) string {
+		return fmt.Sprintf("https://%s.console.aws.amazon.com/emr/home?region=%s#/clusterDetails/%s", region, region, id)
+	},
+}
+
+// ec2ConsoleURL returns the URL of the EC2 console of the region on the page
+func ec2ConsoleURL(region, page string) string {
+	return fmt.Sprintf("https://%s.console.aws.amazon.com/ec2/home?region=%s#%s", region, region, page)
+}
+
+// vpcConsoleURL returns the URL of the VPC console of the region on the page
+func vpcConsoleURL(region, page string) string {
+	return fmt.Sprintf("https://%s.console.aws.amazon.com/vpc/home?region=%s#%s", region, region, page)
+}
+
+// ConsoleURL returns the URL of the resource of type t with the
+// id on the AWS console, empty if the type has none
+func (a *aws) ConsoleURL(t, id string, get func(string) string) string {
+	rt, err := ResourceTypeString(t)
+	if err != nil {
+		return ""
+	}
+
+	fn, ok := consoleURLs[rt]
+	if !ok {
+		return ""
+	}
+
+	return fn(a.Region(), id, get)
+}
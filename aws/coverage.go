@@ -0,0 +1,175 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/cycloidio/terracognita/aws/reader"
+)
+
+// arnTypes are the types of the ARNs, as 'service:type' (see arnType),
+// with the ResourceTypes that import them
+var arnTypes = map[string][]ResourceType{
+	"acm:certificate":                    {AcmCertificate},
+	"appsync:apis":                       {AppsyncGraphqlAPI},
+	"athena:workgroup":                   {AthenaWorkgroup},
+	"autoscaling:autoScalingGroup":       {AutoscalingGroup},
+	"autoscaling:launchConfiguration":    {LaunchConfiguration},
+	"backup:backup-plan":                 {BackupPlan},
+	"backup:backup-vault":                {BackupVault},
+	"batch:compute-environment":          {BatchComputeEnvironment},
+	"batch:job-definition":               {BatchJobDefinition},
+	"batch:job-queue":                    {BatchJobQueue},
+	"cloudfront:distribution":            {CloudfrontDistribution},
+	"cloudfront:origin-access-identity":  {CloudfrontOriginAccessIdentity},
+	"cloudtrail:trail":                   {Cloudtrail},
+	"cloudwatch:alarm":                   {CloudwatchMetricAlarm},
+	"codebuild:project":                  {CodebuildProject},
+	"codedeploy:application":             {CodedeployApp},
+	"codedeploy:deploymentgroup":         {CodedeployDeploymentGroup},
+	"codepipeline":                       {Codepipeline},
+	"cognito-identity:identitypool":      {CognitoIdentityPool},
+	"cognito-idp:userpool":               {CognitoUserPool},
+	"config:config-rule":                 {ConfigConfigRule},
+	"ec2:instance":                       {Instance},
+	"ec2:internet-gateway":               {InternetGateway},
+	"ec2:launch-template":                {LaunchTemplate},
+	"ec2:natgateway":                     {NatGateway},
+	"ec2:network-acl":                    {NetworkACL, NetworkACLRule},
+	"ec2:route-table":                    {RouteTable, RouteTableAssociation},
+	"ec2:security-group":                 {SecurityGroup},
+	"ec2:subnet":                         {Subnet},
+	"ec2:transit-gateway":                {TransitGateway},
+	"ec2:transit-gateway-attachment":     {TransitGatewayVpcAttachment},
+	"ec2:transit-gateway-route-table":    {TransitGatewayRouteTable},
+	"ec2:volume":                         {EBSVolume},
+	"ec2:vpc":                            {VPC},
+	"ec2:vpc-endpoint":                   {VPCEndpoint},
+	"ec2:vpc-peering-connection":         {VPCPeeringConnection},
+	"elasticache:cluster":                {ElasticacheCluster},
+	"elasticache:parametergroup":         {ElasticacheParameterGroup},
+	"elasticache:replicationgroup":       {ElasticacheReplicationGroup},
+	"elasticache:subnetgroup":            {ElasticacheSubnetGroup},
+	"elasticbeanstalk:application":       {ElasticBeanstalkApplication},
+	"elasticbeanstalk:environment":       {ElasticBeanstalkEnvironment},
+	"elasticfilesystem:file-system":      {EFSFileSystem},
+	"elasticloadbalancing:listener":      {LBListener},
+	"elasticloadbalancing:listener-rule": {LBListenerRule},
+	"elasticloadbalancing:loadbalancer":  {ELB, LB},
+	"elasticloadbalancing:targetgroup":   {LBTargetGroup},
+	"elasticmapreduce:cluster":           {EMRCluster},
+	"globalaccelerator:accelerator":      {GlobalacceleratorAccelerator},
+	"events:rule":                        {CloudwatchEventRule},
+	"fsx:file-system":                    {FSxLustreFileSystem, FSxWindowsFileSystem},
+	"glue:database":                      {GlueCatalogDatabase},
+	"glue:job":                           {GlueJob},
+	"glue:table":                         {GlueCatalogTable},
+	"guardduty:detector":                 {GuarddutyDetector},
+	"iam:instance-profile":               {IAMInstanceProfile},
+	"iam:oidc-provider":                  {IAMOpenidConnectProvider},
+	"iam:policy":                         {IAMPolicy},
+	"iam:role":                           {IAMRole},
+	"iam:saml-provider":                  {IAMSAMLProvider},
+	"iam:server-certificate":             {IAMServerCertificate},
+	"iam:user":                           {IAMUser},
+	"kafka:cluster":                      {MSKCluster},
+	"kafka:configuration":                {MSKConfiguration},
+	"logs:log-group":                     {CloudwatchLogGroup},
+	"rds:cluster":                        {RDSCluster},
+	"rds:cluster-pg":                     {RDSClusterParameterGroup},
+	"rds:db":                             {DBInstance},
+	"rds:og":                             {DBOptionGroup},
+	"rds:pg":                             {DBParameterGroup},
+	"rds:subgrp":                         {DBSubnetGroup},
+	"redshift:cluster":                   {RedshiftCluster},
+	"redshift:parametergroup":            {RedshiftParameterGroup},
+	"redshift:subnetgroup":               {RedshiftSubnetGroup},
+	"route53:healthcheck":                {Route53HealthCheck},
+	"route53:hostedzone":                 {Route53Zone},
+	"route53resolver:resolver-endpoint":  {Route53ResolverEndpoint},
+	"route53resolver:resolver-rule":      {Route53ResolverRuleAssociation},
+	"s3":                                 {S3Bucket},
+	"sagemaker:endpoint":                 {SagemakerEndpoint},
+	"sagemaker:notebook-instance":        {SagemakerNotebookInstance},
+	"secretsmanager:secret":              {SecretsmanagerSecret},
+	"ssm:parameter":                      {SSMParameter},
+	"states:stateMachine":                {SfnStateMachine},
+}
+
+// CoverageReport is the result of a Coverage, the resources
+// are grouped by the type of their ARN (see arnType)
+type CoverageReport struct {
+	// Supported are the number of resources of each
+	// type with the ResourceTypes that import them
+	Supported map[string]int
+
+	// Unsupported are the number of resources
+	// of each type that can not be imported
+	Unsupported map[string]int
+
+	// ResourceTypes are the ResourceTypes
+	// of the Supported ones
+	ResourceTypes map[string][]ResourceType
+}
+
+// Coverage lists the resources of the account on the region with the
+// Resource Groups Tagging API and checks which ones can be imported.
+// Only the resources that are or have been tagged are listed by AWS
+func Coverage(ctx context.Context, accessKey, secretKey, sessionToken, region string) (*CoverageReport, error) {
+	awsr, err := reader.New(ctx, accessKey, secretKey, sessionToken, region, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize 'reader' because: %s", err)
+	}
+
+	report := &CoverageReport{
+		Supported:     make(map[string]int),
+		Unsupported:   make(map[string]int),
+		ResourceTypes: make(map[string][]ResourceType),
+	}
+
+	input := &resourcegroupstaggingapi.GetResourcesInput{}
+	for {
+		resources, err := awsr.GetTaggedResources(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range resources.ResourceTagMappingList {
+			t := arnType(*r.ResourceARN)
+			if rts := arnTypes[t]; len(rts) != 0 {
+				report.Supported[t]++
+				report.ResourceTypes[t] = rts
+			} else {
+				report.Unsupported[t]++
+			}
+		}
+
+		if resources.PaginationToken == nil || *resources.PaginationToken == "" {
+			break
+		}
+		input.PaginationToken = resources.PaginationToken
+	}
+
+	return report, nil
+}
+
+// arnType returns the type of the resource of the arn as 'service:type'
+// (ex: 'arn:aws:ec2:eu-west-1:123:instance/i-123' => 'ec2:instance'),
+// if the resource has no type it's only the service (ex: the S3 buckets)
+func arnType(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 {
+		return arn
+	}
+
+	service, res := parts[2], parts[5]
+	if i := strings.IndexAny(res, "/:"); i != -1 {
+		return service + ":" + res[:i]
+	}
+
+	// The resources without type have
+	// only the ID (ex: arn:aws:s3:::bucket)
+	return service
+}
@@ -0,0 +1,364 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"time"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/processcreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// Credentials are the AWS credentials
+// used to initialize the Provider
+type Credentials struct {
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+
+	// Refreshing, if set, retrieves the credentials
+	// again once they expire (ex: the role session of
+	// the SSO), so the long imports are not interrupted.
+	// The keys are the first ones retrieved by it
+	Refreshing *credentials.Credentials
+}
+
+// expiryWindow is how long before the expiration
+// the refreshing credentials are retrieved again, so
+// the requests in progress do not use expired ones
+const expiryWindow = 5 * time.Minute
+
+// newRefreshingCredentials returns the Credentials with the
+// keys of the first retrieve of the p, which refreshes them
+func newRefreshingCredentials(p credentials.Provider) (Credentials, error) {
+	c := credentials.NewCredentials(p)
+
+	v, err := c.Get()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return Credentials{
+		AccessKey:    v.AccessKeyID,
+		SecretKey:    v.SecretAccessKey,
+		SessionToken: v.SessionToken,
+		Refreshing:   c,
+	}, nil
+}
+
+// NewProcessCredentials returns the Credentials from the
+// command, which works the same as the 'credential_process'
+// of the AWS config
+// See: https://docs.aws.amazon.com/cli/latest/topic/config-vars.html#sourcing-credentials-from-external-processes
+func NewProcessCredentials(command string) (Credentials, error) {
+	c := processcreds.NewCredentials(command, func(p *processcreds.ProcessProvider) {
+		p.ExpiryWindow = expiryWindow
+	})
+
+	v, err := c.Get()
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "could not get the credentials from the process")
+	}
+
+	return Credentials{
+		AccessKey:    v.AccessKeyID,
+		SecretKey:    v.SecretAccessKey,
+		SessionToken: v.SessionToken,
+		Refreshing:   c,
+	}, nil
+}
+
+// NewProcessCredentialsNoInput returns the Credentials from the command
+// like NewProcessCredentials but without giving it the Stdin, so the
+// command fails instead of prompting (ex: for an MFA code) which is
+// what is expected when running on a CI
+func NewProcessCredentialsNoInput(ctx context.Context, command string) (Credentials, error) {
+	return newRefreshingCredentials(&processProvider{ctx: ctx, command: command})
+}
+
+// processProvider is the credentials.Provider of the
+// NewProcessCredentialsNoInput, which runs the command
+// again once the credentials returned by it expire
+type processProvider struct {
+	credentials.Expiry
+
+	ctx     context.Context
+	command string
+
+	// static is set when the credentials
+	// have no expiration, so they never expire
+	static bool
+}
+
+// Retrieve runs the command and returns the credentials of its output
+func (p *processProvider) Retrieve() (credentials.Value, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(p.ctx, "cmd.exe", "/C", p.command)
+	} else {
+		cmd = exec.CommandContext(p.ctx, "sh", "-c", p.command)
+	}
+	cmd.Env = os.Environ()
+	cmd.Stderr = os.Stderr
+
+	b, err := cmd.Output()
+	if err != nil {
+		return credentials.Value{}, errors.Wrap(err, "could not get the credentials from the process")
+	}
+
+	v, exp, err := parseProcessCredentials(b)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+
+	p.static = exp.IsZero()
+	if !p.static {
+		p.SetExpiration(exp, expiryWindow)
+	}
+
+	return v, nil
+}
+
+// IsExpired returns if the credentials have to be retrieved again
+func (p *processProvider) IsExpired() bool {
+	if p.static {
+		return false
+	}
+	return p.Expiry.IsExpired()
+}
+
+// parseProcessCredentials returns the credentials, and the expiration
+// of them if any, from the b output of a 'credential_process', which
+// has to be the version 1 of it
+func parseProcessCredentials(b []byte) (credentials.Value, time.Time, error) {
+	var out struct {
+		Version         int
+		AccessKeyID     string `json:"AccessKeyId"`
+		SecretAccessKey string
+		SessionToken    string
+		Expiration      *time.Time
+	}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return credentials.Value{}, time.Time{}, errors.Wrap(err, "could not decode the credentials from the process")
+	}
+
+	if out.Version != 1 {
+		return credentials.Value{}, time.Time{}, errors.Errorf("unsupported version %d of the credentials from the process", out.Version)
+	}
+
+	if out.AccessKeyID == "" || out.SecretAccessKey == "" {
+		return credentials.Value{}, time.Time{}, errors.New("missing AccessKeyId or SecretAccessKey on the credentials from the process")
+	}
+
+	var exp time.Time
+	if out.Expiration != nil {
+		exp = *out.Expiration
+	}
+
+	return credentials.Value{
+		AccessKeyID:     out.AccessKeyID,
+		SecretAccessKey: out.SecretAccessKey,
+		SessionToken:    out.SessionToken,
+		ProviderName:    processcreds.ProviderName,
+	}, exp, nil
+}
+
+// NewWebIdentityCredentials returns the Credentials of the roleARN assumed
+// with the OIDC token (ex: of the CI job, see oidc.Token), with the
+// sessionName. The request is not signed so it needs no credentials
+func NewWebIdentityCredentials(ctx context.Context, roleARN, sessionName, token, region string) (Credentials, error) {
+	sess, err := session.NewSession(&awsSDK.Config{
+		Region:      awsSDK.String(region),
+		Credentials: credentials.AnonymousCredentials,
+	})
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "could not create the STS session")
+	}
+
+	out, err := sts.New(sess).AssumeRoleWithWebIdentityWithContext(ctx, &sts.AssumeRoleWithWebIdentityInput{
+		RoleArn:          awsSDK.String(roleARN),
+		RoleSessionName:  awsSDK.String(sessionName),
+		WebIdentityToken: awsSDK.String(token),
+	})
+	if err != nil {
+		return Credentials{}, errors.Wrapf(err, "could not assume the role %s with the OIDC token", roleARN)
+	}
+
+	return Credentials{
+		AccessKey:    awsSDK.StringValue(out.Credentials.AccessKeyId),
+		SecretKey:    awsSDK.StringValue(out.Credentials.SecretAccessKey),
+		SessionToken: awsSDK.StringValue(out.Credentials.SessionToken),
+	}, nil
+}
+
+// ssoPortalURL is the URL of the AWS SSO portal used to exchange
+// the token for the credentials, it's a var so it can be changed
+// on the tests
+var ssoPortalURL = "https://portal.sso.%s.amazonaws.com/federation/credentials"
+
+// ssoRegionRe is the format of the regions, as they are
+// part of the host to which the SSO token is sent
+var ssoRegionRe = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d$`)
+
+// ssoCachedToken is the format of the
+// tokens cached by 'aws sso login'
+type ssoCachedToken struct {
+	StartURL    string    `json:"startUrl"`
+	Region      string    `json:"region"`
+	AccessToken string    `json:"accessToken"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// NewSSOCredentials returns the Credentials of the roleName on the accountID
+// using the token of the startURL cached by 'aws sso login' on ~/.aws/sso/cache.
+// They are retrieved again with the cached token once the role session expires
+func NewSSOCredentials(ctx context.Context, startURL, region, accountID, roleName string) (Credentials, error) {
+	if region != "" && !ssoRegionRe.MatchString(region) {
+		return Credentials{}, errors.Errorf("invalid SSO region %q", region)
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "could not find the home directory")
+	}
+
+	return newRefreshingCredentials(&ssoProvider{
+		ctx:       ctx,
+		cacheDir:  filepath.Join(home, ".aws", "sso", "cache"),
+		startURL:  startURL,
+		region:    region,
+		accountID: accountID,
+		roleName:  roleName,
+	})
+}
+
+// ssoProvider is the credentials.Provider of the NewSSOCredentials,
+// which exchanges the cached token for the credentials of the role
+type ssoProvider struct {
+	credentials.Expiry
+
+	ctx       context.Context
+	cacheDir  string
+	startURL  string
+	region    string
+	accountID string
+	roleName  string
+}
+
+// Retrieve returns the credentials of the role, the cache is read
+// each time so the token refreshed by 'aws sso login' is used
+func (p *ssoProvider) Retrieve() (credentials.Value, error) {
+	t, err := findSSOCachedToken(p.cacheDir, p.startURL)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+
+	region := p.region
+	if region == "" {
+		region = t.Region
+	}
+	if !ssoRegionRe.MatchString(region) {
+		return credentials.Value{}, errors.Errorf("invalid SSO region %q", region)
+	}
+
+	u, err := url.Parse(fmt.Sprintf(ssoPortalURL, region))
+	if err != nil {
+		return credentials.Value{}, errors.Wrap(err, "invalid SSO region")
+	}
+	u.RawQuery = url.Values{
+		"account_id": []string{p.accountID},
+		"role_name":  []string{p.roleName},
+	}.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return credentials.Value{}, errors.Wrap(err, "could not create the SSO request")
+	}
+	req.Header.Set("x-amz-sso_bearer_token", t.AccessToken)
+
+	res, err := http.DefaultClient.Do(req.WithContext(p.ctx))
+	if err != nil {
+		return credentials.Value{}, errors.Wrap(err, "could not get the SSO credentials")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return credentials.Value{}, errors.Wrapf(errcode.ErrAWSSSOCredentials, "with status %d", res.StatusCode)
+	}
+
+	var body struct {
+		RoleCredentials struct {
+			AccessKeyID     string `json:"accessKeyId"`
+			SecretAccessKey string `json:"secretAccessKey"`
+			SessionToken    string `json:"sessionToken"`
+			// Expiration is in milliseconds
+			Expiration int64 `json:"expiration"`
+		} `json:"roleCredentials"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return credentials.Value{}, errors.Wrap(err, "could not decode the SSO credentials")
+	}
+
+	p.SetExpiration(time.Unix(0, body.RoleCredentials.Expiration*int64(time.Millisecond)), expiryWindow)
+
+	return credentials.Value{
+		AccessKeyID:     body.RoleCredentials.AccessKeyID,
+		SecretAccessKey: body.RoleCredentials.SecretAccessKey,
+		SessionToken:    body.RoleCredentials.SessionToken,
+		ProviderName:    "SSOProvider",
+	}, nil
+}
+
+// findSSOCachedToken returns the valid token of
+// the startURL from the SSO cache on the dir
+func findSSOCachedToken(dir, startURL string) (*ssoCachedToken, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list the SSO cache")
+	}
+
+	var expired bool
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read %s", f)
+		}
+
+		var t ssoCachedToken
+		// The cache also has other files, like the
+		// client registration, so the ones that are
+		// not tokens are ignored
+		if err := json.Unmarshal(b, &t); err != nil || t.StartURL != startURL || t.AccessToken == "" {
+			continue
+		}
+
+		if time.Now().After(t.ExpiresAt) {
+			expired = true
+			continue
+		}
+
+		return &t, nil
+	}
+
+	if expired {
+		return nil, errors.Wrapf(errcode.ErrAWSSSOTokenExpired, "for %s, run 'aws sso login' to refresh it", startURL)
+	}
+
+	return nil, errors.Wrapf(errcode.ErrAWSSSOTokenNotFound, "for %s, run 'aws sso login' to create it", startURL)
+}
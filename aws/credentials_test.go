@@ -0,0 +1,311 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/processcreds"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+func TestParseProcessCredentials(t *testing.T) {
+	tests := []struct {
+		name  string
+		out   string
+		value credentials.Value
+		exp   time.Time
+		err   string
+	}{
+		{
+			name:  "Success",
+			out:   `{"Version": 1, "AccessKeyId": "access", "SecretAccessKey": "secret", "SessionToken": "token", "Expiration": "2026-10-15T12:00:00Z"}`,
+			value: credentials.Value{AccessKeyID: "access", SecretAccessKey: "secret", SessionToken: "token", ProviderName: processcreds.ProviderName},
+			exp:   time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "SuccessWithoutSessionToken",
+			out:   `{"Version": 1, "AccessKeyId": "access", "SecretAccessKey": "secret"}`,
+			value: credentials.Value{AccessKeyID: "access", SecretAccessKey: "secret", ProviderName: processcreds.ProviderName},
+		},
+		{
+			name: "ErrInvalidJSON",
+			out:  `Enter MFA code:`,
+			err:  "could not decode the credentials from the process",
+		},
+		{
+			name: "ErrUnsupportedVersion",
+			out:  `{"Version": 2, "AccessKeyId": "access", "SecretAccessKey": "secret"}`,
+			err:  "unsupported version 2 of the credentials from the process",
+		},
+		{
+			name: "ErrMissingSecretAccessKey",
+			out:  `{"Version": 1, "AccessKeyId": "access"}`,
+			err:  "missing AccessKeyId or SecretAccessKey on the credentials from the process",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, exp, err := parseProcessCredentials([]byte(tt.out))
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+			assert.True(t, tt.exp.Equal(exp))
+		})
+	}
+}
+
+func TestNewProcessCredentialsNoInput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command is a shell one")
+	}
+
+	// The command returns a different AccessKeyId each
+	// time it's run, and the expiration if any
+	counter := func(t *testing.T, expiration string) (string, func()) {
+		dir, err := ioutil.TempDir("", "terracognita-process")
+		require.NoError(t, err)
+
+		f := filepath.Join(dir, "count")
+		return fmt.Sprintf(`n=$(cat %[1]s 2>/dev/null || echo 0); n=$((n+1)); echo $n > %[1]s; echo "{\"Version\": 1, \"AccessKeyId\": \"access$n\", \"SecretAccessKey\": \"secret\"%[2]s}"`, f, expiration), func() { os.RemoveAll(dir) }
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		command, clean := counter(t, "")
+		defer clean()
+
+		creds, err := NewProcessCredentialsNoInput(context.Background(), command)
+		require.NoError(t, err)
+		assert.Equal(t, "access1", creds.AccessKey)
+		assert.Equal(t, "secret", creds.SecretKey)
+
+		// Without expiration they are not refreshed
+		v, err := creds.Refreshing.Get()
+		require.NoError(t, err)
+		assert.Equal(t, "access1", v.AccessKeyID)
+	})
+
+	t.Run("SuccessRefresh", func(t *testing.T) {
+		// It expires inside the expiryWindow
+		command, clean := counter(t, fmt.Sprintf(`, \"Expiration\": \"%s\"`, time.Now().Add(time.Minute).UTC().Format(time.RFC3339)))
+		defer clean()
+
+		creds, err := NewProcessCredentialsNoInput(context.Background(), command)
+		require.NoError(t, err)
+		assert.Equal(t, "access1", creds.AccessKey)
+
+		v, err := creds.Refreshing.Get()
+		require.NoError(t, err)
+		assert.Equal(t, "access2", v.AccessKeyID)
+	})
+
+	t.Run("ErrorPrompt", func(t *testing.T) {
+		// Without Stdin the read fails
+		_, err := NewProcessCredentialsNoInput(context.Background(), `read code && echo "$code"`)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "could not get the credentials from the process")
+	})
+}
+
+func TestFindSSOCachedToken(t *testing.T) {
+	const startURL = "https://example.awsapps.com/start"
+
+	var (
+		valid   = fmt.Sprintf(`{"startUrl": %q, "region": "eu-west-1", "accessToken": "token", "expiresAt": %q}`, startURL, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+		expired = fmt.Sprintf(`{"startUrl": %q, "region": "eu-west-1", "accessToken": "expired", "expiresAt": %q}`, startURL, time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+		other   = fmt.Sprintf(`{"startUrl": "https://other.awsapps.com/start", "region": "eu-west-1", "accessToken": "other", "expiresAt": %q}`, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+		client  = `{"clientId": "id", "clientSecret": "secret", "expiresAt": "2099-01-01T00:00:00Z"}`
+	)
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		token string
+		err   error
+	}{
+		{
+			name:  "Success",
+			files: map[string]string{"a.json": client, "b.json": other, "c.json": valid},
+			token: "token",
+		},
+		{
+			name:  "SuccessWithExpired",
+			files: map[string]string{"a.json": expired, "b.json": valid},
+			token: "token",
+		},
+		{
+			name:  "SuccessIgnoreInvalid",
+			files: map[string]string{"a.json": "{", "b.json": valid, "c.txt": valid},
+			token: "token",
+		},
+		{
+			name:  "ErrExpired",
+			files: map[string]string{"a.json": expired, "b.json": client},
+			err:   errcode.ErrAWSSSOTokenExpired,
+		},
+		{
+			name:  "ErrNotFound",
+			files: map[string]string{"a.json": other, "b.json": client},
+			err:   errcode.ErrAWSSSOTokenNotFound,
+		},
+		{
+			name: "ErrEmpty",
+			err:  errcode.ErrAWSSSOTokenNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "terracognita-sso-cache")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			for n, c := range tt.files {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(dir, n), []byte(c), 0600))
+			}
+
+			tk, err := findSSOCachedToken(dir, startURL)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, errors.Cause(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.token, tk.AccessToken)
+			assert.Equal(t, "eu-west-1", tk.Region)
+		})
+	}
+}
+
+func TestNewSSOCredentials(t *testing.T) {
+	const startURL = "https://example.awsapps.com/start"
+
+	home, err := ioutil.TempDir("", "terracognita-sso-home")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+
+	cache := filepath.Join(home, ".aws", "sso", "cache")
+	require.NoError(t, os.MkdirAll(cache, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(cache, "token.json"), []byte(fmt.Sprintf(`{"startUrl": %q, "region": "eu-west-1", "accessToken": "token", "expiresAt": %q}`, startURL, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))), 0600))
+
+	defer func(h string, c bool) {
+		os.Setenv("HOME", h)
+		homedir.DisableCache = c
+	}(os.Getenv("HOME"), homedir.DisableCache)
+	os.Setenv("HOME", home)
+	homedir.DisableCache = true
+
+	// portal returns the credentials with a different
+	// accessKeyId on each call, which expire after the exp
+	portal := func(t *testing.T, exp time.Duration) func() {
+		var n int
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/eu-west-1", r.URL.Path)
+			assert.Equal(t, "token", r.Header.Get("x-amz-sso_bearer_token"))
+			assert.Equal(t, "123456789012", r.URL.Query().Get("account_id"))
+			assert.Equal(t, "ReadOnly", r.URL.Query().Get("role_name"))
+			n++
+			fmt.Fprintf(w, `{"roleCredentials": {"accessKeyId": "access%d", "secretAccessKey": "secret", "sessionToken": "session", "expiration": %d}}`, n, time.Now().Add(exp).UnixNano()/int64(time.Millisecond))
+		}))
+
+		u := ssoPortalURL
+		ssoPortalURL = ts.URL + "/%s"
+
+		return func() {
+			ssoPortalURL = u
+			ts.Close()
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		defer portal(t, time.Hour)()
+
+		creds, err := NewSSOCredentials(context.Background(), startURL, "", "123456789012", "ReadOnly")
+		require.NoError(t, err)
+		assert.Equal(t, "access1", creds.AccessKey)
+		assert.Equal(t, "secret", creds.SecretKey)
+		assert.Equal(t, "session", creds.SessionToken)
+
+		v, err := creds.Refreshing.Get()
+		require.NoError(t, err)
+		assert.Equal(t, "access1", v.AccessKeyID)
+	})
+
+	t.Run("SuccessRefresh", func(t *testing.T) {
+		// It expires inside the expiryWindow
+		defer portal(t, time.Minute)()
+
+		creds, err := NewSSOCredentials(context.Background(), startURL, "eu-west-1", "123456789012", "ReadOnly")
+		require.NoError(t, err)
+		assert.Equal(t, "access1", creds.AccessKey)
+
+		v, err := creds.Refreshing.Get()
+		require.NoError(t, err)
+		assert.Equal(t, "access2", v.AccessKeyID)
+	})
+
+	t.Run("ErrorInvalidRegion", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("the token was sent to %s", r.URL)
+		}))
+		defer ts.Close()
+
+		defer func(u string) { ssoPortalURL = u }(ssoPortalURL)
+		ssoPortalURL = ts.URL + "/%s"
+
+		for _, r := range []string{"evil.example/x?", "attacker.com#", "eu-west-1.evil.com", "user@eu-west-1", "EU-WEST-1"} {
+			_, err := NewSSOCredentials(context.Background(), startURL, r, "123456789012", "ReadOnly")
+			require.Error(t, err, r)
+			assert.Contains(t, err.Error(), "invalid SSO region", r)
+		}
+	})
+
+	t.Run("ErrorInvalidCachedRegion", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("the token was sent to %s", r.URL)
+		}))
+		defer ts.Close()
+
+		defer func(u string) { ssoPortalURL = u }(ssoPortalURL)
+		ssoPortalURL = ts.URL + "/%s"
+
+		const evilURL = "https://evil.awsapps.com/start"
+		require.NoError(t, ioutil.WriteFile(filepath.Join(cache, "evil.json"), []byte(fmt.Sprintf(`{"startUrl": %q, "region": "attacker.com#", "accessToken": "token", "expiresAt": %q}`, evilURL, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))), 0600))
+
+		_, err := NewSSOCredentials(context.Background(), evilURL, "", "123456789012", "ReadOnly")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid SSO region")
+	})
+
+	t.Run("ErrorForbidden", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer ts.Close()
+
+		defer func(u string) { ssoPortalURL = u }(ssoPortalURL)
+		ssoPortalURL = ts.URL + "/%s"
+
+		_, err := NewSSOCredentials(context.Background(), startURL, "", "123456789012", "Admin")
+		assert.Equal(t, errcode.ErrAWSSSOCredentials, errors.Cause(err))
+	})
+}
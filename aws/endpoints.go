@@ -0,0 +1,166 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/appsync"
+	"github.com/aws/aws-sdk-go/service/athena"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/batch"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatchevents"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/aws/aws-sdk-go/service/codedeploy"
+	"github.com/aws/aws-sdk-go/service/codepipeline"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/configservice"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/emr"
+	"github.com/aws/aws-sdk-go/service/fsx"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/guardduty"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kafka"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/redshift"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53resolver"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/cycloidio/terracognita/util"
+)
+
+// endpointsIDs are the IDs of the endpoints of the AWS SDK of the
+// services read, by the name of the service on the 'endpoints' of
+// the Terraform provider, which are the names of the Endpoints
+var endpointsIDs = map[string]string{
+	"acm":                      acm.EndpointsID,
+	"appsync":                  appsync.EndpointsID,
+	"athena":                   athena.EndpointsID,
+	"autoscaling":              autoscaling.EndpointsID,
+	"batch":                    batch.EndpointsID,
+	"cloudfront":               cloudfront.EndpointsID,
+	"cloudtrail":               cloudtrail.EndpointsID,
+	"cloudwatch":               cloudwatch.EndpointsID,
+	"cloudwatchevents":         cloudwatchevents.EndpointsID,
+	"cloudwatchlogs":           cloudwatchlogs.EndpointsID,
+	"codebuild":                codebuild.EndpointsID,
+	"codedeploy":               codedeploy.EndpointsID,
+	"codepipeline":             codepipeline.EndpointsID,
+	"cognitoidentity":          cognitoidentity.EndpointsID,
+	"cognitoidp":               cognitoidentityprovider.EndpointsID,
+	"configservice":            configservice.EndpointsID,
+	"ec2":                      ec2.EndpointsID,
+	"efs":                      efs.EndpointsID,
+	"elasticache":              elasticache.EndpointsID,
+	"elb":                      elb.EndpointsID,
+	"emr":                      emr.EndpointsID,
+	"fsx":                      fsx.EndpointsID,
+	"globalaccelerator":        globalaccelerator.EndpointsID,
+	"glue":                     glue.EndpointsID,
+	"guardduty":                guardduty.EndpointsID,
+	"iam":                      iam.EndpointsID,
+	"kafka":                    kafka.EndpointsID,
+	"kms":                      kms.EndpointsID,
+	"organizations":            organizations.EndpointsID,
+	"rds":                      rds.EndpointsID,
+	"redshift":                 redshift.EndpointsID,
+	"resourcegroupstaggingapi": resourcegroupstaggingapi.EndpointsID,
+	"route53":                  route53.EndpointsID,
+	"route53resolver":          route53resolver.EndpointsID,
+	"s3":                       s3.EndpointsID,
+	"sagemaker":                sagemaker.EndpointsID,
+	"secretsmanager":           secretsmanager.EndpointsID,
+	"ses":                      ses.EndpointsID,
+	"stepfunctions":            sfn.EndpointsID,
+	"ssm":                      ssm.EndpointsID,
+	"sts":                      sts.EndpointsID,
+}
+
+// EndpointServices returns the sorted names of the
+// services which endpoint can be set on the Endpoints
+func EndpointServices() []string {
+	names := make([]string, 0, len(endpointsIDs))
+	for n := range endpointsIDs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateEndpoints validates that the
+// services of the eps are known
+func validateEndpoints(eps map[string]string) error {
+	for s := range eps {
+		if _, ok := endpointsIDs[s]; s != "" && !ok {
+			return fmt.Errorf("invalid endpoint service %q, the valid ones are: %s", s, strings.Join(EndpointServices(), ", "))
+		}
+	}
+	return nil
+}
+
+// serviceEndpoints returns the URLs of the eps by
+// the name of each service, the ones without one
+// have the one of all the services (the "" key), if set
+func serviceEndpoints(eps map[string]string) map[string]string {
+	res := make(map[string]string)
+	for s := range endpointsIDs {
+		if u, ok := eps[s]; ok {
+			res[s] = u
+		} else if u, ok := eps[""]; ok {
+			res[s] = u
+		}
+	}
+	return res
+}
+
+// readerConfig returns the configuration of the sessions of
+// the reader.Reader that resolves the eps of the services,
+// nil if there are none so the default one is used
+func readerConfig(eps map[string]string) *awsSDK.Config {
+	if len(eps) == 0 {
+		return nil
+	}
+
+	urls := make(map[string]string)
+	for s, u := range serviceEndpoints(eps) {
+		urls[endpointsIDs[s]] = u
+	}
+
+	return &awsSDK.Config{
+		DisableSSL: awsSDK.Bool(false),
+		MaxRetries: awsSDK.Int(3),
+		HTTPClient: util.HTTPClient(),
+		// The emulators (ex: LocalStack) do not
+		// support the S3 virtual hosted buckets
+		S3ForcePathStyle: awsSDK.Bool(true),
+		EndpointResolver: endpoints.ResolverFunc(func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+			if u, ok := urls[service]; ok {
+				return endpoints.ResolvedEndpoint{URL: u, SigningRegion: region}, nil
+			}
+			return endpoints.DefaultResolver().EndpointFor(service, region, opts...)
+		}),
+	}
+}
@@ -0,0 +1,232 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// vpcModuleSource and vpcModuleVersion are the ones of the
+// module of the Terraform Registry replacing the aws_vpc
+const (
+	vpcModuleSource  = "terraform-aws-modules/vpc/aws"
+	vpcModuleVersion = "~> 3.0"
+)
+
+// Modules returns the calls to the terraform-aws-modules/vpc of each
+// aws_vpc of the cfgs, which replace it and the aws_subnet,
+// aws_internet_gateway and aws_nat_gateway of it. The VPCs which
+// subnets can not be expressed with the module (ex: two public
+// subnets on the same availability zone) are kept as they are
+func (a *aws) Modules(cfgs map[string]map[string]interface{}) []provider.ModuleCall {
+	res := make([]provider.ModuleCall, 0)
+	for _, k := range keysOfType(cfgs, VPC.String()) {
+		if mc, ok := vpcModule(cfgs, k); ok {
+			res = append(res, mc)
+		}
+	}
+	return res
+}
+
+// vpcSubnet is an aws_subnet of a vpcModule
+type vpcSubnet struct {
+	key  string
+	az   string
+	cidr string
+}
+
+// vpcModule returns the call to the terraform-aws-modules/vpc of
+// the aws_vpc with the key vk, false if it can not be expressed
+func vpcModule(cfgs map[string]map[string]interface{}, vk string) (provider.ModuleCall, bool) {
+	vpc := cfgs[vk]
+	name := strings.TrimPrefix(vk, VPC.String()+".")
+	ref := interpolation(vk)
+
+	mc := provider.ModuleCall{
+		Name:    name,
+		Source:  vpcModuleSource,
+		Version: vpcModuleVersion,
+		Inputs: map[string]interface{}{
+			"name": name,
+			"cidr": vpc["cidr_block"],
+		},
+		Resources: map[string]string{vk: "aws_vpc.this[0]"},
+		Outputs:   map[string]string{vk + ".id": "vpc_id"},
+	}
+
+	var public, private []vpcSubnet
+	for _, k := range keysOfType(cfgs, Subnet.String()) {
+		cfg := cfgs[k]
+		if cfg["vpc_id"] != ref {
+			continue
+		}
+
+		az, _ := cfg["availability_zone"].(string)
+		cidr, _ := cfg["cidr_block"].(string)
+		if az == "" || cidr == "" {
+			return mc, false
+		}
+
+		s := vpcSubnet{key: k, az: az, cidr: cidr}
+		if p, _ := cfg["map_public_ip_on_launch"].(bool); p {
+			public = append(public, s)
+		} else {
+			private = append(private, s)
+		}
+	}
+
+	// The module places the subnets on the azs by the index,
+	// so each kind can have only one subnet on each of them
+	azs := subnetsAZs(append(append([]vpcSubnet{}, public...), private...))
+	for _, subnets := range [][]vpcSubnet{public, private} {
+		sort.SliceStable(subnets, func(i, j int) bool { return subnets[i].az < subnets[j].az })
+		for i, s := range subnets {
+			if s.az != azs[i] {
+				return mc, false
+			}
+		}
+	}
+
+	mc.Inputs["azs"] = stringsValue(azs)
+	for kind, subnets := range map[string][]vpcSubnet{"public": public, "private": private} {
+		cidrs := make([]string, 0, len(subnets))
+		for i, s := range subnets {
+			cidrs = append(cidrs, s.cidr)
+			mc.Resources[s.key] = fmt.Sprintf("aws_subnet.%s[%d]", kind, i)
+			mc.Outputs[s.key+".id"] = fmt.Sprintf("%s_subnets[%d]", kind, i)
+		}
+		mc.Inputs[kind+"_subnets"] = stringsValue(cidrs)
+	}
+
+	for _, attr := range []string{"enable_dns_hostnames", "enable_dns_support"} {
+		if v, ok := vpc[attr].(bool); ok {
+			mc.Inputs[attr] = v
+		}
+	}
+	if it, _ := vpc["instance_tenancy"].(string); it != "" && it != "default" {
+		mc.Inputs["instance_tenancy"] = it
+	}
+
+	if tags, ok := vpc["tags"].(map[string]interface{}); ok {
+		if n, _ := tags["Name"].(string); n != "" {
+			mc.Inputs["name"] = n
+		}
+		vt := make(map[string]interface{}, len(tags))
+		for k, v := range tags {
+			if k != "Name" {
+				vt[k] = v
+			}
+		}
+		if len(vt) != 0 {
+			mc.Inputs["vpc_tags"] = vt
+		}
+	}
+
+	igws := make([]string, 0)
+	for _, k := range keysOfType(cfgs, InternetGateway.String()) {
+		if cfgs[k]["vpc_id"] == ref {
+			igws = append(igws, k)
+		}
+	}
+	mc.Inputs["create_igw"] = len(igws) != 0
+	for _, k := range igws {
+		mc.Resources[k] = "aws_internet_gateway.this[0]"
+		mc.Outputs[k+".id"] = "igw_id"
+	}
+
+	vpcNATGateways(cfgs, public, mc)
+
+	return mc, true
+}
+
+// vpcNATGateways adds to the mc the aws_nat_gateway of the public
+// subnets if those are one on the first subnet (single_nat_gateway)
+// or one on each of them (one_nat_gateway_per_az), the other ones
+// are kept as they are and the module does not create any
+func vpcNATGateways(cfgs map[string]map[string]interface{}, public []vpcSubnet, mc provider.ModuleCall) {
+	nats := make([]string, len(public))
+	var count int
+	for _, k := range keysOfType(cfgs, NatGateway.String()) {
+		for i, s := range public {
+			if cfgs[k]["subnet_id"] == interpolation(s.key) && nats[i] == "" {
+				nats[i] = k
+				count++
+			}
+		}
+	}
+
+	single := count == 1 && nats[0] != ""
+	perAZ := count != 0 && count == len(public)
+
+	// The EIPs are not replaced, so
+	// the ones of the NATs are used
+	eips := make([]interface{}, 0, count)
+	for _, k := range nats[:count] {
+		if id, _ := cfgs[k]["allocation_id"].(string); id != "" {
+			eips = append(eips, id)
+		}
+	}
+
+	if (!single && !perAZ) || len(eips) != count {
+		mc.Inputs["enable_nat_gateway"] = false
+		return
+	}
+
+	mc.Inputs["enable_nat_gateway"] = true
+	mc.Inputs["single_nat_gateway"] = single
+	mc.Inputs["one_nat_gateway_per_az"] = perAZ && !single
+	mc.Inputs["reuse_nat_ips"] = true
+	mc.Inputs["external_nat_ip_ids"] = eips
+
+	for i, k := range nats[:count] {
+		mc.Resources[k] = fmt.Sprintf("aws_nat_gateway.this[%d]", i)
+		mc.Outputs[k+".id"] = fmt.Sprintf("natgw_ids[%d]", i)
+	}
+}
+
+// subnetsAZs returns the sorted availability zones of the subnets
+func subnetsAZs(subnets []vpcSubnet) []string {
+	set := make(map[string]struct{})
+	for _, s := range subnets {
+		set[s.az] = struct{}{}
+	}
+
+	azs := make([]string, 0, len(set))
+	for az := range set {
+		azs = append(azs, az)
+	}
+	sort.Strings(azs)
+
+	return azs
+}
+
+// keysOfType returns the sorted keys of
+// the cfgs of the resources of type t
+func keysOfType(cfgs map[string]map[string]interface{}, t string) []string {
+	res := make([]string, 0)
+	for k := range cfgs {
+		if strings.HasPrefix(k, t+".") {
+			res = append(res, k)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// interpolation returns the interpolation to the
+// ID of the resource of the key (ex: ${aws_vpc.main.id})
+func interpolation(key string) string {
+	return fmt.Sprintf("${%s.id}", key)
+}
+
+// stringsValue returns the ss as
+// the value of a list on the HCL
+func stringsValue(ss []string) []interface{} {
+	res := make([]interface{}, 0, len(ss))
+	for _, s := range ss {
+		res = append(res, s)
+	}
+	return res
+}
@@ -0,0 +1,222 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// iamServices are the IAM service prefixes of the
+// APIs used to list and read each ResourceType
+var iamServices = map[ResourceType][]string{
+	Instance:                            {"ec2"},
+	VPC:                                 {"ec2"},
+	SecurityGroup:                       {"ec2"},
+	Subnet:                              {"ec2"},
+	EBSVolume:                           {"ec2"},
+	ElasticacheCluster:                  {"elasticache"},
+	ElasticacheReplicationGroup:         {"elasticache"},
+	ElasticacheParameterGroup:           {"elasticache"},
+	ElasticacheSubnetGroup:              {"elasticache"},
+	ELB:                                 {"elasticloadbalancing"},
+	ALB:                                 {"elasticloadbalancing"},
+	LB:                                  {"elasticloadbalancing"},
+	LBListener:                          {"elasticloadbalancing"},
+	LBListenerRule:                      {"elasticloadbalancing"},
+	LBTargetGroup:                       {"elasticloadbalancing"},
+	LBTargetGroupAttachment:             {"elasticloadbalancing"},
+	DBInstance:                          {"rds"},
+	DBParameterGroup:                    {"rds"},
+	DBOptionGroup:                       {"rds"},
+	DBSubnetGroup:                       {"rds"},
+	RDSCluster:                          {"rds"},
+	RDSClusterInstance:                  {"rds"},
+	RDSClusterParameterGroup:            {"rds"},
+	S3Bucket:                            {"s3"},
+	S3BucketPublicAccessBlock:           {"s3"},
+	CloudfrontDistribution:              {"cloudfront"},
+	CloudfrontOriginAccessIdentity:      {"cloudfront"},
+	CloudfrontPublicKey:                 {"cloudfront"},
+	IAMAccountAlias:                     {"iam"},
+	IAMAccountPasswordPolicy:            {"iam"},
+	IAMGroup:                            {"iam"},
+	IAMGroupMembership:                  {"iam"},
+	IAMGroupPolicy:                      {"iam"},
+	IAMGroupPolicyAttachment:            {"iam"},
+	IAMInstanceProfile:                  {"iam"},
+	IAMOpenidConnectProvider:            {"iam"},
+	IAMPolicy:                           {"iam"},
+	IAMRole:                             {"iam"},
+	IAMRolePolicy:                       {"iam"},
+	IAMRolePolicyAttachment:             {"iam"},
+	IAMSAMLProvider:                     {"iam"},
+	IAMServerCertificate:                {"iam"},
+	IAMUser:                             {"iam"},
+	IAMUserGroupMembership:              {"iam"},
+	IAMUserPolicy:                       {"iam"},
+	IAMUserPolicyAttachment:             {"iam"},
+	Route53DelegationSet:                {"route53"},
+	Route53HealthCheck:                  {"route53"},
+	Route53QueryLog:                     {"route53"},
+	Route53Record:                       {"route53"},
+	Route53Zone:                         {"route53"},
+	Route53ZoneAssociation:              {"route53"},
+	Route53ResolverEndpoint:             {"route53resolver"},
+	Route53ResolverRuleAssociation:      {"route53resolver"},
+	SESActiveReceiptRuleSet:             {"ses"},
+	SESDomainIdentity:                   {"ses"},
+	SESDomainIdentityVerification:       {"ses"},
+	SESDomainDKIM:                       {"ses"},
+	SESDomainMailFrom:                   {"ses"},
+	SESReceiptFilter:                    {"ses"},
+	SESReceiptRule:                      {"ses"},
+	SESReceiptRuleSet:                   {"ses"},
+	SESConfigurationSet:                 {"ses"},
+	SESIdentityNotificationTopic:        {"ses"},
+	SESTemplate:                         {"ses"},
+	LaunchConfiguration:                 {"autoscaling"},
+	LaunchTemplate:                      {"ec2"},
+	AutoscalingGroup:                    {"autoscaling"},
+	AutoscalingPolicy:                   {"autoscaling"},
+	AutoscalingSchedule:                 {"autoscaling"},
+	TransitGateway:                      {"ec2"},
+	TransitGatewayVpcAttachment:         {"ec2"},
+	TransitGatewayRouteTable:            {"ec2"},
+	TransitGatewayRouteTableAssociation: {"ec2"},
+	TransitGatewayRouteTablePropagation: {"ec2"},
+	VPCPeeringConnection:                {"ec2"},
+	CloudwatchMetricAlarm:               {"cloudwatch"},
+	CloudwatchDashboard:                 {"cloudwatch"},
+	CloudwatchLogGroup:                  {"logs"},
+	CloudwatchLogMetricFilter:           {"logs"},
+	CloudwatchEventRule:                 {"events"},
+	CloudwatchEventTarget:               {"events"},
+	SfnStateMachine:                     {"states"},
+	AcmCertificate:                      {"acm"},
+	AcmCertificateValidation:            {"acm"},
+	SecretsmanagerSecret:                {"secretsmanager"},
+	SSMParameter:                        {"ssm"},
+	Codepipeline:                        {"codepipeline"},
+	CodebuildProject:                    {"codebuild"},
+	CodedeployApp:                       {"codedeploy"},
+	CodedeployDeploymentGroup:           {"codedeploy"},
+	GlueCatalogDatabase:                 {"glue"},
+	GlueCatalogTable:                    {"glue"},
+	GlueJob:                             {"glue"},
+	AthenaWorkgroup:                     {"athena"},
+	AthenaNamedQuery:                    {"athena"},
+	RedshiftCluster:                     {"redshift"},
+	RedshiftParameterGroup:              {"redshift"},
+	RedshiftSubnetGroup:                 {"redshift"},
+	MSKCluster:                          {"kafka"},
+	MSKConfiguration:                    {"kafka"},
+	Cloudtrail:                          {"cloudtrail"},
+	ConfigConfigurationRecorder:         {"config"},
+	ConfigConfigRule:                    {"config"},
+	GuarddutyDetector:                   {"guardduty"},
+	GuarddutyMember:                     {"guardduty"},
+	InternetGateway:                     {"ec2"},
+	NatGateway:                          {"ec2"},
+	RouteTable:                          {"ec2"},
+	RouteTableAssociation:               {"ec2"},
+	NetworkACL:                          {"ec2"},
+	NetworkACLRule:                      {"ec2"},
+	VPCEndpoint:                         {"ec2"},
+	EFSFileSystem:                       {"elasticfilesystem"},
+	EFSMountTarget:                      {"elasticfilesystem"},
+	FSxLustreFileSystem:                 {"fsx"},
+	FSxWindowsFileSystem:                {"fsx"},
+	CognitoUserPool:                     {"cognito-idp"},
+	CognitoUserPoolClient:               {"cognito-idp"},
+	CognitoUserPoolDomain:               {"cognito-idp"},
+	CognitoResourceServer:               {"cognito-idp"},
+	CognitoIdentityPool:                 {"cognito-identity"},
+	CognitoIdentityPoolRolesAttachment:  {"cognito-identity"},
+	AppsyncGraphqlAPI:                   {"appsync"},
+	AppsyncDatasource:                   {"appsync"},
+	AppsyncResolver:                     {"appsync"},
+	OrganizationsOrganization:           {"organizations"},
+	OrganizationsAccount:                {"organizations"},
+	OrganizationsOrganizationalUnit:     {"organizations"},
+	OrganizationsPolicy:                 {"organizations"},
+	OrganizationsPolicyAttachment:       {"organizations"},
+	BatchComputeEnvironment:             {"batch"},
+	BatchJobQueue:                       {"batch"},
+	BatchJobDefinition:                  {"batch"},
+	EMRCluster:                          {"elasticmapreduce"},
+	SagemakerNotebookInstance:           {"sagemaker"},
+	SagemakerEndpoint:                   {"sagemaker"},
+	GlobalacceleratorAccelerator:        {"globalaccelerator"},
+	GlobalacceleratorListener:           {"globalaccelerator"},
+	GlobalacceleratorEndpointGroup:      {"globalaccelerator"},
+	BackupVault:                         {"backup"},
+	BackupPlan:                          {"backup"},
+	BackupSelection:                     {"backup"},
+	ElasticBeanstalkApplication:         {"elasticbeanstalk"},
+	ElasticBeanstalkEnvironment:         {"elasticbeanstalk"},
+}
+
+// baseActions are the actions always needed, to
+// get the account ID and validate the region
+var baseActions = []string{
+	"ec2:DescribeRegions",
+	"sts:GetCallerIdentity",
+}
+
+// Policy is an IAM policy document
+type Policy struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement is a statement of a Policy
+type PolicyStatement struct {
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource string   `json:"Resource"`
+}
+
+// Permissions returns the read-only IAM Policy needed to import
+// the types, it allows the read actions (Describe*, Get* and List*)
+// of the services of them as the Terraform provider may use
+// any of them to read the resources
+func Permissions(types []string) (*Policy, error) {
+	services := make(map[string]struct{})
+	for _, t := range types {
+		rt, err := ResourceTypeString(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource type %q: %s", t, err)
+		}
+
+		for _, s := range iamServices[rt] {
+			services[s] = struct{}{}
+		}
+	}
+
+	actions := make([]string, 0, len(baseActions)+len(services)*3)
+	for s := range services {
+		for _, p := range []string{"Describe*", "Get*", "List*"} {
+			actions = append(actions, fmt.Sprintf("%s:%s", s, p))
+		}
+	}
+
+	// The base actions of the services already
+	// allowed are covered by the wildcards
+	for _, a := range baseActions {
+		if _, ok := services[strings.SplitN(a, ":", 2)[0]]; !ok {
+			actions = append(actions, a)
+		}
+	}
+	sort.Strings(actions)
+
+	return &Policy{
+		Version: "2012-10-17",
+		Statement: []PolicyStatement{
+			{
+				Effect:   "Allow",
+				Action:   actions,
+				Resource: "*",
+			},
+		},
+	}, nil
+}
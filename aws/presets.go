@@ -0,0 +1,72 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// presets are the curated resource types of the
+// common import scopes, used as the Include of the import
+var presets = map[string][]ResourceType{
+	"networking": {
+		VPC, Subnet, SecurityGroup, InternetGateway, NatGateway, RouteTable, RouteTableAssociation,
+		NetworkACL, NetworkACLRule, VPCEndpoint, VPCPeeringConnection,
+		TransitGateway, TransitGatewayVpcAttachment, TransitGatewayRouteTable,
+		TransitGatewayRouteTableAssociation, TransitGatewayRouteTablePropagation,
+		ELB, LB, LBListener, LBListenerRule, LBTargetGroup, LBTargetGroupAttachment,
+		Route53Zone, Route53ZoneAssociation, Route53Record, Route53HealthCheck,
+		Route53DelegationSet, Route53ResolverEndpoint, Route53ResolverRuleAssociation,
+		CloudfrontDistribution, CloudfrontOriginAccessIdentity,
+		GlobalacceleratorAccelerator, GlobalacceleratorListener, GlobalacceleratorEndpointGroup,
+	},
+	"compute": {
+		Instance, EBSVolume, LaunchConfiguration, LaunchTemplate,
+		AutoscalingGroup, AutoscalingPolicy, AutoscalingSchedule,
+		EFSFileSystem, EFSMountTarget, FSxLustreFileSystem, FSxWindowsFileSystem,
+		BatchComputeEnvironment, BatchJobQueue, BatchJobDefinition, EMRCluster,
+		ElasticBeanstalkApplication, ElasticBeanstalkEnvironment,
+	},
+	"security": {
+		SecurityGroup, NetworkACL, NetworkACLRule,
+		IAMAccountAlias, IAMAccountPasswordPolicy, IAMGroup, IAMGroupMembership,
+		IAMGroupPolicy, IAMGroupPolicyAttachment, IAMInstanceProfile,
+		IAMOpenidConnectProvider, IAMPolicy, IAMRole, IAMRolePolicy,
+		IAMRolePolicyAttachment, IAMSAMLProvider, IAMServerCertificate, IAMUser,
+		IAMUserGroupMembership, IAMUserPolicy, IAMUserPolicyAttachment,
+		AcmCertificate, AcmCertificateValidation, SecretsmanagerSecret,
+		Cloudtrail, ConfigConfigurationRecorder, ConfigConfigRule,
+		GuarddutyDetector, GuarddutyMember, BackupVault, BackupPlan, BackupSelection,
+	},
+	"serverless": {
+		SfnStateMachine, CloudwatchEventRule, CloudwatchEventTarget,
+		AppsyncGraphqlAPI, AppsyncDatasource, AppsyncResolver,
+		CognitoUserPool, CognitoUserPoolClient, CognitoUserPoolDomain,
+		CognitoResourceServer, CognitoIdentityPool, CognitoIdentityPoolRolesAttachment,
+		GlueCatalogDatabase, GlueCatalogTable, GlueJob, AthenaWorkgroup, AthenaNamedQuery,
+	},
+}
+
+// PresetNames returns the names of the presets sorted
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for n := range presets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preset returns the resource types of the preset name
+func Preset(name string) ([]string, error) {
+	rts, ok := presets[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid preset %q, the valid ones are: %s", name, strings.Join(PresetNames(), ", "))
+	}
+
+	types := make([]string, 0, len(rts))
+	for _, rt := range rts {
+		types = append(types, rt.String())
+	}
+	return types, nil
+}
@@ -0,0 +1,72 @@
+package aws
+
+import (
+	"context"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/cycloidio/terracognita/log"
+)
+
+// Probe checks if the region has no resources with a request to
+// each one of: the Resource Groups Tagging API, which has the
+// resources of most of the services that are or have been tagged,
+// the EC2 instances, the VPCs other than the default one and the
+// RDS instances. The regions with none of them are empty, as any
+// infrastructure has at least one of those
+func (a *aws) Probe(ctx context.Context) (bool, error) {
+	tagged, err := a.awsr.GetTaggedResources(ctx, &resourcegroupstaggingapi.GetResourcesInput{
+		ResourcesPerPage: awsSDK.Int64(1),
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(tagged.ResourceTagMappingList) != 0 || awsSDK.StringValue(tagged.PaginationToken) != "" {
+		return false, nil
+	}
+
+	// The terminated instances are
+	// listed for a while after
+	instances, err := a.awsr.GetInstances(ctx, &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{{
+			Name:   awsSDK.String("instance-state-name"),
+			Values: awsSDK.StringSlice([]string{"pending", "running", "stopping", "stopped"}),
+		}},
+		MaxResults: awsSDK.Int64(5),
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(instances.Reservations) != 0 || awsSDK.StringValue(instances.NextToken) != "" {
+		return false, nil
+	}
+
+	vpcs, err := a.awsr.GetVpcs(ctx, &ec2.DescribeVpcsInput{
+		Filters: []*ec2.Filter{{
+			Name:   awsSDK.String("isDefault"),
+			Values: awsSDK.StringSlice([]string{"false"}),
+		}},
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(vpcs.Vpcs) != 0 {
+		return false, nil
+	}
+
+	dbs, err := a.awsr.GetDBInstances(ctx, &rds.DescribeDBInstancesInput{
+		MaxRecords: awsSDK.Int64(20),
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(dbs.DBInstances) != 0 {
+		return false, nil
+	}
+
+	log.Get().Log("func", "aws.Probe", "msg", "the region has no resources", "region", a.Region())
+
+	return true, nil
+}
@@ -3,30 +3,123 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sync"
 
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/cycloidio/terracognita/aws/reader"
 	"github.com/cycloidio/terracognita/cache"
+	"github.com/cycloidio/terracognita/errcode"
 	"github.com/cycloidio/terracognita/filter"
 	"github.com/cycloidio/terracognita/log"
 	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/util"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/pkg/errors"
 	tfaws "github.com/terraform-providers/terraform-provider-aws/aws"
 )
 
+// Options are the optional configurations
+// of the AWS Provider
+type Options struct {
+	// SharedAsData imports the resources shared with
+	// the account (via RAM) as data sources instead
+	// of resources, as they are not owned by the account
+	SharedAsData bool
+
+	// SharedProviderAlias is the alias of the provider
+	// used on the shared data sources
+	SharedProviderAlias string
+
+	// Alias is the alias of the provider, used when
+	// multiple regions are imported at once so each
+	// resource uses the provider of the region
+	Alias string
+
+	// Rules is the representation of the rules of the
+	// security groups and network ACLs, one of RulesInline
+	// (the default) or RulesStandalone
+	Rules string
+
+	// Organizations imports the AWS Organizations resources
+	// (the organization, accounts, organizational units and
+	// service control policies), which need the credentials
+	// of the management account
+	Organizations bool
+
+	// Endpoints are the URLs of the endpoints of the services
+	// (ex: s3 => http://localhost:4566) by the name of them on
+	// the Terraform provider (see EndpointServices), the one
+	// with the empty name is used for all the services without
+	// one, so it can be used with emulators like LocalStack
+	Endpoints map[string]string
+
+	// Credentials, if set, are used instead of the keys
+	// so they are refreshed once they expire, see the
+	// Credentials.Refreshing
+	Credentials *credentials.Credentials
+}
+
+// List of the representations of the rules of
+// the security groups and network ACLs
+const (
+	// RulesInline writes the rules as the ingress and
+	// egress of the aws_security_group and aws_network_acl
+	// and skips the aws_security_group_rule and
+	// aws_network_acl_rule
+	RulesInline = "inline"
+	// RulesStandalone writes the rules as aws_security_group_rule
+	// and aws_network_acl_rule and removes the ingress and egress
+	// of the aws_security_group and aws_network_acl from the HCL
+	RulesStandalone = "standalone"
+)
+
 type aws struct {
 	awsr reader.Reader
 
+	opt Options
+
 	tfAWSClient interface{}
 	tfProvider  *schema.Provider
 
 	cache cache.Cache
+
+	// tagged are the ARNs of the resources with
+	// the tags of the key, see taggedARNs
+	tagged   map[string][]string
+	taggedMu sync.Mutex
+
+	// unused are the reasons why the resources, by
+	// the ID, look unused from the state of them on
+	// the API when listed, see UnusedReason
+	unused   map[string]string
+	unusedMu sync.Mutex
 }
 
-// NewProvider returns an AWS Provider
-func NewProvider(ctx context.Context, accessKey, secretKey, region string) (provider.Provider, error) {
+// NewProvider returns an AWS Provider, the sessionToken is
+// only required for temporary credentials
+func NewProvider(ctx context.Context, accessKey, secretKey, sessionToken, region string, opt Options) (provider.Provider, error) {
+	if opt.Rules != "" && opt.Rules != RulesInline && opt.Rules != RulesStandalone {
+		return nil, fmt.Errorf("invalid rules %q, the valid ones are: %s, %s", opt.Rules, RulesInline, RulesStandalone)
+	}
+	if err := validateEndpoints(opt.Endpoints); err != nil {
+		return nil, err
+	}
+
 	log.Get().Log("func", "reader.New", "msg", "configuring aws Reader")
-	awsr, err := reader.New(ctx, accessKey, secretKey, region, nil)
+	rcfg := readerConfig(opt.Endpoints)
+	if opt.Credentials != nil {
+		if rcfg == nil {
+			rcfg = &awsSDK.Config{
+				DisableSSL: awsSDK.Bool(false),
+				MaxRetries: awsSDK.Int(3),
+				HTTPClient: util.HTTPClient(),
+			}
+		}
+		rcfg.Credentials = opt.Credentials
+	}
+	awsr, err := reader.New(ctx, accessKey, secretKey, sessionToken, region, rcfg)
 	if err != nil {
 		return nil, fmt.Errorf("could not initialize 'reader' because: %s", err)
 	}
@@ -34,8 +127,13 @@ func NewProvider(ctx context.Context, accessKey, secretKey, region string) (prov
 	cfg := tfaws.Config{
 		AccessKey: accessKey,
 		SecretKey: secretKey,
+		Token:     sessionToken,
 		Region:    region,
 	}
+	if len(opt.Endpoints) != 0 {
+		cfg.Endpoints = serviceEndpoints(opt.Endpoints)
+		cfg.S3ForcePathStyle = true
+	}
 
 	log.Get().Log("func", "aws.NewProvider", "msg", "configuring TF Client")
 	awsClient, err := cfg.Client()
@@ -43,22 +141,103 @@ func NewProvider(ctx context.Context, accessKey, secretKey, region string) (prov
 		return nil, fmt.Errorf("could not initialize 'terraform/aws.Config.Client()' because: %s", err)
 	}
 
+	if opt.Credentials != nil {
+		if err := setTFCredentials(awsClient, opt.Credentials); err != nil {
+			return nil, fmt.Errorf("could not set the credentials of the 'terraform/aws' client because: %s", err)
+		}
+	}
+
+	if util.Auditing() {
+		if err := auditTFClient(awsClient); err != nil {
+			return nil, fmt.Errorf("could not audit the 'terraform/aws' client because: %s", err)
+		}
+	}
+
 	tfp := tfaws.Provider().(*schema.Provider)
 	tfp.SetMeta(awsClient)
 
 	return &aws{
 		awsr:        awsr,
+		opt:         opt,
 		tfAWSClient: awsClient,
 		tfProvider:  tfp,
 		cache:       cache.New(),
 	}, nil
 }
 
+// auditTFClient audits the HTTP client of the c, the
+// *tfaws.AWSClient, as the API clients of the TF provider
+// share the one of its session, which is not exported,
+// it's reached from the STS one
+func auditTFClient(c interface{}) error {
+	f, err := util.UnexportedField(c, "stsconn")
+	if err != nil {
+		return err
+	}
+
+	conn, ok := f.(*sts.STS)
+	if !ok || conn == nil {
+		return errors.Wrapf(errcode.ErrAuditNoClient, "expected the stsconn to be a *sts.STS, found %T", f)
+	}
+	util.AuditClient(conn.Config.HTTPClient)
+
+	return nil
+}
+
+// setTFCredentials makes the c, the *tfaws.AWSClient, use the creds.
+// The API clients of the TF provider share the credentials of its
+// session, which are built from the static keys, so the provider of
+// them is replaced by the creds, reached from the STS client
+func setTFCredentials(c interface{}, creds *credentials.Credentials) error {
+	f, err := util.UnexportedField(c, "stsconn")
+	if err != nil {
+		return err
+	}
+
+	conn, ok := f.(*sts.STS)
+	if !ok || conn == nil || conn.Config.Credentials == nil {
+		return errors.Wrapf(errcode.ErrAuditNoClient, "expected the stsconn to be a *sts.STS with credentials, found %T", f)
+	}
+
+	if err := util.SetUnexportedField(conn.Config.Credentials, "provider", credentials.Provider(&sharedProvider{creds: creds})); err != nil {
+		return err
+	}
+	conn.Config.Credentials.Expire()
+
+	return nil
+}
+
+// sharedProvider is a credentials.Provider of the creds, so
+// the refreshed ones are shared by the reader and the TF client
+type sharedProvider struct {
+	creds *credentials.Credentials
+}
+
+func (p *sharedProvider) Retrieve() (credentials.Value, error) { return p.creds.Get() }
+func (p *sharedProvider) IsExpired() bool                      { return p.creds.IsExpired() }
+
 func (a *aws) ResourceTypes() []string {
-	return ResourceTypeStrings()
+	types := ResourceTypeStrings()
+	if !a.opt.Organizations {
+		types = make([]string, 0, len(ResourceTypeValues()))
+		for _, rt := range ResourceTypeValues() {
+			if !hasResourceType(organizationsTypes, rt) {
+				types = append(types, rt.String())
+			}
+		}
+	}
+	return provider.WithRegisteredResourceTypes(a.String(), types)
 }
 
 func (a *aws) Resources(ctx context.Context, t string, f *filter.Filter) ([]provider.Resource, error) {
+	if fn, ok := provider.RegisteredResource(t); ok {
+		resources, err := fn(ctx, a, t, f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while reading from resource %q", t)
+		}
+		return resources, nil
+	}
+
 	rt, err := ResourceTypeString(t)
 	if err != nil {
 		return nil, err
@@ -69,11 +248,26 @@ func (a *aws) Resources(ctx context.Context, t string, f *filter.Filter) ([]prov
 		return nil, errors.Errorf("the resource %q it's not implemented", t)
 	}
 
+	if !a.opt.Organizations && hasResourceType(organizationsTypes, rt) {
+		return nil, errors.Errorf("the resource %q needs the Organizations option, as the credentials of the management account are required", t)
+	}
+
+	// The types without any resource with the
+	// tags are not even listed
+	ids, tagged := a.taggedIDs(ctx, rt, f.Tags)
+	if tagged && len(ids) == 0 {
+		return make([]provider.Resource, 0), nil
+	}
+
 	resources, err := rfn(ctx, a, t, f.Tags)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error while reading from resource %q", t)
 	}
 
+	if tagged {
+		resources = withIDs(resources, ids)
+	}
+
 	return resources, nil
 }
 
@@ -88,8 +282,135 @@ func (a *aws) TFProvider() *schema.Provider {
 func (a *aws) String() string { return "aws" }
 
 func (a *aws) Region() string { return a.awsr.GetRegion() }
+func (a *aws) Alias() string  { return a.opt.Alias }
+
+// SharedAlias returns the SharedProviderAlias
+// if the shared resources are data sources
+func (a *aws) SharedAlias() string {
+	if !a.opt.SharedAsData {
+		return ""
+	}
+	return a.opt.SharedProviderAlias
+}
 func (a *aws) TagKey() string { return "tags" }
+
+// SkippedTypes returns the types of the rules
+// that are written inline, see Options.Rules
+func (a *aws) SkippedTypes() []string {
+	if a.opt.Rules == RulesStandalone {
+		return nil
+	}
+	return []string{"aws_security_group_rule", "aws_network_acl_rule"}
+}
+
+// InlineAttributes returns the attributes of the rules
+// that are written standalone, see Options.Rules
+func (a *aws) InlineAttributes() []string {
+	if a.opt.Rules != RulesStandalone {
+		return nil
+	}
+	return []string{"aws_security_group.ingress", "aws_security_group.egress", "aws_network_acl.ingress", "aws_network_acl.egress"}
+}
+
+// typeAliases are the resource types deprecated
+// on the TF provider with the one that replaces them
+var typeAliases = map[string]string{
+	"aws_alb":                         "aws_lb",
+	"aws_alb_listener":                "aws_lb_listener",
+	"aws_alb_listener_certificate":    "aws_lb_listener_certificate",
+	"aws_alb_listener_rule":           "aws_lb_listener_rule",
+	"aws_alb_target_group":            "aws_lb_target_group",
+	"aws_alb_target_group_attachment": "aws_lb_target_group_attachment",
+}
+
+// TypeAliases returns the deprecated types of the
+// resources and the types replacing them
+func (a *aws) TypeAliases() map[string]string { return typeAliases }
+
+// references are the attributes which value is the ID
+// of other resource, with the types it can be, or
+// other attribute of it
+var references = map[string][]string{
+	"vpc_id":                         {"aws_vpc"},
+	"peer_vpc_id":                    {"aws_vpc"},
+	"subnet_ids":                     {"aws_subnet"},
+	"transit_gateway_id":             {"aws_ec2_transit_gateway"},
+	"transit_gateway_attachment_id":  {"aws_ec2_transit_gateway_vpc_attachment"},
+	"transit_gateway_route_table_id": {"aws_ec2_transit_gateway_route_table"},
+	"certificate_arn":                {"aws_acm_certificate"},
+	"validation_record_fqdns":        {"aws_route53_record.fqdn"},
+	"load_balancer_arn":              {"aws_lb"},
+	"listener_arn":                   {"aws_lb_listener", "aws_globalaccelerator_listener"},
+	"target_group_arn":               {"aws_lb_target_group"},
+	"target_id":                      {"aws_instance", "aws_organizations_account", "aws_organizations_organizational_unit"},
+	"security_groups":                {"aws_security_group"},
+	"subnets":                        {"aws_subnet"},
+	"security_group_ids":             {"aws_security_group"},
+	"role_arn":                       {"aws_iam_role.arn"},
+	"service_role":                   {"aws_iam_role.arn"},
+	"service_role_arn":               {"aws_iam_role.arn"},
+	"location":                       {"aws_s3_bucket"},
+	"app_name":                       {"aws_codedeploy_app.name"},
+	"workgroup":                      {"aws_athena_workgroup"},
+	"vpc_security_group_ids":         {"aws_security_group"},
+	"cluster_parameter_group_name":   {"aws_redshift_parameter_group"},
+	"cluster_subnet_group_name":      {"aws_redshift_subnet_group"},
+	"client_subnets":                 {"aws_subnet"},
+	"s3_bucket_name":                 {"aws_s3_bucket"},
+	"detector_id":                    {"aws_guardduty_detector"},
+	"subnet_id":                      {"aws_subnet"},
+	"route_table_id":                 {"aws_route_table"},
+	"route_table_ids":                {"aws_route_table"},
+	"network_acl_id":                 {"aws_network_acl"},
+	"gateway_id":                     {"aws_internet_gateway"},
+	"nat_gateway_id":                 {"aws_nat_gateway"},
+	"instance_id":                    {"aws_instance"},
+	"vpc_peering_connection_id":      {"aws_vpc_peering_connection"},
+	"file_system_id":                 {"aws_efs_file_system"},
+	"user_pool_id":                   {"aws_cognito_user_pool"},
+	"identity_pool_id":               {"aws_cognito_identity_pool"},
+	"api_id":                         {"aws_appsync_graphql_api"},
+	"parent_id":                      {"aws_organizations_organizational_unit"},
+	"policy_id":                      {"aws_organizations_policy"},
+	"compute_environments":           {"aws_batch_compute_environment.arn"},
+	"accelerator_arn":                {"aws_globalaccelerator_accelerator"},
+	"iam_role_arn":                   {"aws_iam_role.arn"},
+	"target_vault_name":              {"aws_backup_vault"},
+	"plan_id":                        {"aws_backup_plan"},
+	"application":                    {"aws_elastic_beanstalk_application"},
+}
+
+// References returns the attributes referencing
+// other resources with the types of them
+func (a *aws) References() map[string][]string { return references }
+
+// attributeFns are the functions that read the attributes
+// of the resources of the types that the TF provider does not
+var attributeFns = map[ResourceType]func(ctx context.Context, a *aws, id string) (map[string]string, error){
+	AppsyncGraphqlAPI:           appsyncGraphqlAPIAttributes,
+	ElasticBeanstalkEnvironment: elasticBeanstalkEnvironmentAttributes,
+}
+
+// ReadAttributes returns the attributes of the resource
+// of type t with the id that the TF provider does not read
+func (a *aws) ReadAttributes(ctx context.Context, t, id string) (map[string]string, error) {
+	rt, err := ResourceTypeString(t)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := attributeFns[rt]
+	if !ok {
+		return nil, nil
+	}
+
+	return fn(ctx, a, id)
+}
+
 func (a *aws) HasResourceType(t string) bool {
+	if _, ok := provider.RegisteredResource(t); ok {
+		return true
+	}
 	_, err := ResourceTypeString(t)
 	return err == nil
 }
@@ -0,0 +1,86 @@
+package aws
+
+import (
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tfaws "github.com/terraform-providers/terraform-provider-aws/aws"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/util"
+)
+
+func TestAuditTFClient(t *testing.T) {
+	defer util.SetAudit(util.Audit{})
+
+	cfg := tfaws.Config{
+		AccessKey:               "access",
+		SecretKey:               "secret",
+		Region:                  "eu-west-1",
+		SkipCredsValidation:     true,
+		SkipGetEC2Platforms:     true,
+		SkipRegionValidation:    true,
+		SkipRequestingAccountId: true,
+		SkipMetadataApiCheck:    true,
+	}
+	c, err := cfg.Client()
+	require.NoError(t, err)
+
+	var violation error
+	util.SetAudit(util.Audit{
+		ReadOnly:    true,
+		OnViolation: func(err error) { violation = err },
+	})
+
+	// It fails if the client is no longer on the
+	// field on a new version of the TF provider
+	require.NoError(t, auditTFClient(c))
+
+	f, err := util.UnexportedField(c, "stsconn")
+	require.NoError(t, err)
+
+	req, _ := f.(*sts.STS).AssumeRoleRequest(&sts.AssumeRoleInput{
+		RoleArn:         awssdk.String("arn:aws:iam::123456789012:role/admin"),
+		RoleSessionName: awssdk.String("terracognita"),
+	})
+	req.Retryer = client.DefaultRetryer{}
+
+	require.Error(t, req.Send())
+	assert.Equal(t, errcode.ErrAuditNotReadOnly, errors.Cause(violation))
+}
+
+func TestSetTFCredentials(t *testing.T) {
+	cfg := tfaws.Config{
+		AccessKey:               "access",
+		SecretKey:               "secret",
+		Region:                  "eu-west-1",
+		SkipCredsValidation:     true,
+		SkipGetEC2Platforms:     true,
+		SkipRegionValidation:    true,
+		SkipRequestingAccountId: true,
+		SkipMetadataApiCheck:    true,
+	}
+	c, err := cfg.Client()
+	require.NoError(t, err)
+
+	// It fails if the client is no longer on the
+	// field on a new version of the TF provider
+	require.NoError(t, setTFCredentials(c, credentials.NewStaticCredentials("refreshed", "secret", "session")))
+
+	// All the API clients share the credentials
+	// so any of them uses the ones set
+	f, err := util.UnexportedField(c, "ec2conn")
+	require.NoError(t, err)
+
+	v, err := f.(*ec2.EC2).Config.Credentials.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed", v.AccessKeyID)
+	assert.Equal(t, "session", v.SessionToken)
+}
@@ -4,30 +4,64 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+	"github.com/aws/aws-sdk-go/service/appsync/appsynciface"
+	"github.com/aws/aws-sdk-go/service/athena/athenaiface"
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/aws/aws-sdk-go/service/backup/backupiface"
+	"github.com/aws/aws-sdk-go/service/batch/batchiface"
 	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchevents/cloudwatcheventsiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/codebuild/codebuildiface"
+	"github.com/aws/aws-sdk-go/service/codedeploy/codedeployiface"
+	"github.com/aws/aws-sdk-go/service/codepipeline/codepipelineiface"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity/cognitoidentityiface"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
 	"github.com/aws/aws-sdk-go/service/configservice/configserviceiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/efs/efsiface"
+	"github.com/aws/aws-sdk-go/service/elasticbeanstalk/elasticbeanstalkiface"
 	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/emr/emriface"
+	"github.com/aws/aws-sdk-go/service/fsx/fsxiface"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator/globalacceleratoriface"
+	"github.com/aws/aws-sdk-go/service/glue/glueiface"
+	"github.com/aws/aws-sdk-go/service/guardduty/guarddutyiface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kafka/kafkaiface"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
 	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
+	"github.com/aws/aws-sdk-go/service/redshift/redshiftiface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/route53resolver/route53resolveriface"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager/s3manageriface"
+	"github.com/aws/aws-sdk-go/service/sagemaker/sagemakeriface"
+	"github.com/aws/aws-sdk-go/service/secretsmanager/secretsmanageriface"
 	"github.com/aws/aws-sdk-go/service/ses/sesiface"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+
+	"github.com/cycloidio/terracognita/util"
 )
 
-//go:generate go run ../cmd/ -output reader.go
+//go:generate go run ../cmd/ -output reader.go -manifest ../cmd/functions.yaml
 
 // New returns an object which also contains the accountID and the region to use.
 //
@@ -41,10 +75,10 @@ import (
 // See:
 //  * https://docs.aws.amazon.com/AWSEC2/latest/APIReference/errors-overview.html#CommonErrors
 //  * https://docs.aws.amazon.com/STS/latest/APIReference/CommonErrors.html
-func New(ctx context.Context, accessKey string, secretKey string, region string, config *aws.Config) (Reader, error) {
+func New(ctx context.Context, accessKey, secretKey, sessionToken, region string, config *aws.Config) (Reader, error) {
 	var c = connector{}
 
-	creds, ec2s, sts, err := configureAWS(accessKey, secretKey)
+	creds, ec2s, sts, err := configureAWS(accessKey, secretKey, sessionToken, config)
 	if err != nil {
 		return nil, err
 	}
@@ -84,30 +118,75 @@ func (c *connector) GetRegion() string {
 }
 
 type serviceConnector struct {
-	region          string
-	session         *session.Session
-	ec2             ec2iface.EC2API
-	elb             elbiface.ELBAPI
-	elbv2           elbv2iface.ELBV2API
-	rds             rdsiface.RDSAPI
-	s3              s3iface.S3API
-	s3downloader    s3manageriface.DownloaderAPI
-	elasticache     elasticacheiface.ElastiCacheAPI
-	configservice   configserviceiface.ConfigServiceAPI
-	cloudfront      cloudfrontiface.CloudFrontAPI
-	iam             iamiface.IAMAPI
-	ses             sesiface.SESAPI
-	route53         route53iface.Route53API
-	route53resolver route53resolveriface.Route53ResolverAPI
-	autoscaling     autoscalingiface.AutoScalingAPI
+	region  string
+	session *session.Session
+
+	// mu guards the creation of the clients of each
+	// service, which are created once on the first
+	// call and shared by the concurrent ones
+	mu sync.Mutex
+
+	ec2              ec2iface.EC2API
+	elb              elbiface.ELBAPI
+	elbv2            elbv2iface.ELBV2API
+	rds              rdsiface.RDSAPI
+	s3               s3iface.S3API
+	s3downloader     s3manageriface.DownloaderAPI
+	elasticache      elasticacheiface.ElastiCacheAPI
+	configservice    configserviceiface.ConfigServiceAPI
+	cloudfront       cloudfrontiface.CloudFrontAPI
+	iam              iamiface.IAMAPI
+	ses              sesiface.SESAPI
+	route53          route53iface.Route53API
+	route53resolver  route53resolveriface.Route53ResolverAPI
+	autoscaling      autoscalingiface.AutoScalingAPI
+	cloudwatch       cloudwatchiface.CloudWatchAPI
+	cloudwatchlogs   cloudwatchlogsiface.CloudWatchLogsAPI
+	cloudwatchevents cloudwatcheventsiface.CloudWatchEventsAPI
+	sfn              sfniface.SFNAPI
+	acm              acmiface.ACMAPI
+	secretsmanager   secretsmanageriface.SecretsManagerAPI
+	ssm              ssmiface.SSMAPI
+	codepipeline     codepipelineiface.CodePipelineAPI
+	codebuild        codebuildiface.CodeBuildAPI
+	codedeploy       codedeployiface.CodeDeployAPI
+	glue             glueiface.GlueAPI
+	athena           athenaiface.AthenaAPI
+	redshift         redshiftiface.RedshiftAPI
+	kafka            kafkaiface.KafkaAPI
+	cloudtrail       cloudtrailiface.CloudTrailAPI
+	guardduty        guarddutyiface.GuardDutyAPI
+	efs              efsiface.EFSAPI
+	fsx              fsxiface.FSxAPI
+	appsync          appsynciface.AppSyncAPI
+	organizations    organizationsiface.OrganizationsAPI
+	batch            batchiface.BatchAPI
+	emr              emriface.EMRAPI
+	sagemaker        sagemakeriface.SageMakerAPI
+	kms              kmsiface.KMSAPI
+	backup           backupiface.BackupAPI
+	elasticbeanstalk elasticbeanstalkiface.ElasticBeanstalkAPI
+
+	// globalaccelerator is created with the session
+	// as its API is only on the us-west-2 region
+	globalaccelerator globalacceleratoriface.GlobalAcceleratorAPI
+
+	cognitoidentity         cognitoidentityiface.CognitoIdentityAPI
+	cognitoidentityprovider cognitoidentityprovideriface.CognitoIdentityProviderAPI
+
+	resourcegroupstaggingapi resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
 }
 
-// configureAWS creates a new static credential with the passed accessKey and
-// secretKey and with it, a sessions which is used to create a EC2 client and
+// configureAWS creates a new static credential with the passed accessKey,
+// secretKey and token (for temporary credentials) and with it, a sessions which is used to create a EC2 client and
 // a Security Token Service client.
+// The EndpointResolver and S3ForcePathStyle of the config, if
+// set, are also used so the requests go to the same endpoints.
+// The Credentials of the config, if set, are used instead of the
+// static ones so the ones refreshed once expired can be used.
 // The only AWS error code that this function return is
 // * EmptyStaticCreds
-func configureAWS(accessKey string, secretKey string) (*credentials.Credentials, ec2iface.EC2API, stsiface.STSAPI, error) {
+func configureAWS(accessKey, secretKey, token string, config *aws.Config) (*credentials.Credentials, ec2iface.EC2API, stsiface.STSAPI, error) {
 	/* The default region is only used to (1) get the list of region and
 	 * (2) get the account ID associated with the credentials.
 	 *
@@ -116,21 +195,27 @@ func configureAWS(accessKey string, secretKey string) (*credentials.Credentials,
 	 * not try to establish any connections with AWS services.
 	 */
 	const defaultRegion string = "eu-west-1"
-	var token = ""
 
 	creds := credentials.NewStaticCredentials(accessKey, secretKey, token)
+	if config != nil && config.Credentials != nil {
+		creds = config.Credentials
+	}
 	_, err := creds.Get()
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	sess := session.Must(
-		session.NewSession(&aws.Config{
-			Region:      aws.String(defaultRegion),
-			DisableSSL:  aws.Bool(false),
-			MaxRetries:  aws.Int(3),
-			Credentials: creds,
-		}),
-	)
+	cfg := &aws.Config{
+		Region:      aws.String(defaultRegion),
+		DisableSSL:  aws.Bool(false),
+		MaxRetries:  aws.Int(3),
+		Credentials: creds,
+		HTTPClient:  util.HTTPClient(),
+	}
+	if config != nil {
+		cfg.EndpointResolver = config.EndpointResolver
+		cfg.S3ForcePathStyle = config.S3ForcePathStyle
+	}
+	sess := session.Must(session.NewSession(cfg))
 	return creds, ec2.New(sess), sts.New(sess), nil
 }
 
@@ -179,11 +264,15 @@ func (c *connector) setRegion(ctx context.Context, ec2 ec2iface.EC2API, region s
 func (c *connector) setService(config *aws.Config) {
 	if config != nil {
 		config.Credentials = c.creds
+		if config.HTTPClient == nil {
+			config.HTTPClient = util.HTTPClient()
+		}
 	} else {
 		config = &aws.Config{
 			DisableSSL:  aws.Bool(false),
 			MaxRetries:  aws.Int(3),
 			Credentials: c.creds,
+			HTTPClient:  util.HTTPClient(),
 		}
 	}
 
@@ -192,6 +281,8 @@ func (c *connector) setService(config *aws.Config) {
 	svc := &serviceConnector{
 		region:  c.region,
 		session: sess,
+
+		globalaccelerator: globalaccelerator.New(sess, aws.NewConfig().WithRegion("us-west-2")),
 	}
 	c.svc = svc
 }
@@ -3,19 +3,49 @@ package reader
 import (
 	"context"
 
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/appsync"
+	"github.com/aws/aws-sdk-go/service/athena"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/backup"
+	"github.com/aws/aws-sdk-go/service/batch"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatchevents"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/codebuild"
+	"github.com/aws/aws-sdk-go/service/codedeploy"
+	"github.com/aws/aws-sdk-go/service/codepipeline"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
 	"github.com/aws/aws-sdk-go/service/configservice"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/efs"
 	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticbeanstalk"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/emr"
+	"github.com/aws/aws-sdk-go/service/fsx"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/guardduty"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kafka"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/redshift"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/route53resolver"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
 	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/aws/aws-sdk-go/service/ssm"
 )
 
 // Code generated by github.com/cycloidio/terracognita/aws/cmd; DO NOT EDIT
@@ -84,6 +114,51 @@ type Reader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetLaunchTemplates(ctx context.Context, input *ec2.DescribeLaunchTemplatesInput) (*ec2.DescribeLaunchTemplatesOutput, error)
 
+	// GetTransitGateways returns all Transit Gateways based on the input given,
+	// including the ones shared with the Account ID.
+	// Returned values are commented in the interface doc comment block.
+	GetTransitGateways(ctx context.Context, input *ec2.DescribeTransitGatewaysInput) (*ec2.DescribeTransitGatewaysOutput, error)
+
+	// GetTransitGatewayVpcAttachments returns all Transit Gateway VPC attachments based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetTransitGatewayVpcAttachments(ctx context.Context, input *ec2.DescribeTransitGatewayVpcAttachmentsInput) (*ec2.DescribeTransitGatewayVpcAttachmentsOutput, error)
+
+	// GetTransitGatewayRouteTables returns all Transit Gateway route tables based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetTransitGatewayRouteTables(ctx context.Context, input *ec2.DescribeTransitGatewayRouteTablesInput) (*ec2.DescribeTransitGatewayRouteTablesOutput, error)
+
+	// GetTransitGatewayRouteTableAssociations returns all the associations of the Transit Gateway route table based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetTransitGatewayRouteTableAssociations(ctx context.Context, input *ec2.GetTransitGatewayRouteTableAssociationsInput) (*ec2.GetTransitGatewayRouteTableAssociationsOutput, error)
+
+	// GetTransitGatewayRouteTablePropagations returns all the propagations of the Transit Gateway route table based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetTransitGatewayRouteTablePropagations(ctx context.Context, input *ec2.GetTransitGatewayRouteTablePropagationsInput) (*ec2.GetTransitGatewayRouteTablePropagationsOutput, error)
+
+	// GetVpcPeeringConnections returns all VPC peering connections based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetVpcPeeringConnections(ctx context.Context, input *ec2.DescribeVpcPeeringConnectionsInput) (*ec2.DescribeVpcPeeringConnectionsOutput, error)
+
+	// GetInternetGateways returns all EC2 internet gateways based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetInternetGateways(ctx context.Context, input *ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error)
+
+	// GetNatGateways returns all EC2 NAT gateways based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetNatGateways(ctx context.Context, input *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error)
+
+	// GetRouteTables returns all EC2 route tables based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetRouteTables(ctx context.Context, input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
+
+	// GetNetworkAcls returns all EC2 network ACLs based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetNetworkAcls(ctx context.Context, input *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error)
+
+	// GetVpcEndpoints returns all EC2 VPC endpoints based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetVpcEndpoints(ctx context.Context, input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error)
+
 	// GetAutoScalingGroups returns all AutoScalingGroup belonging to the Account ID based on the input given.
 	// Returned values are commented in the interface doc comment block.
 	GetAutoScalingGroups(ctx context.Context, input *autoscaling.DescribeAutoScalingGroupsInput) (*autoscaling.DescribeAutoScalingGroupsOutput, error)
@@ -92,10 +167,30 @@ type Reader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetLaunchConfigurations(ctx context.Context, input *autoscaling.DescribeLaunchConfigurationsInput) (*autoscaling.DescribeLaunchConfigurationsOutput, error)
 
+	// GetAutoScalingPolicies returns all AutoScaling policies belonging to the Account ID based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetAutoScalingPolicies(ctx context.Context, input *autoscaling.DescribePoliciesInput) (*autoscaling.DescribePoliciesOutput, error)
+
+	// GetAutoScalingScheduledActions returns all AutoScaling scheduled actions belonging to the Account ID based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetAutoScalingScheduledActions(ctx context.Context, input *autoscaling.DescribeScheduledActionsInput) (*autoscaling.DescribeScheduledActionsOutput, error)
+
 	// GetElastiCacheClusters returns all Elasticache clusters based on the input given.
 	// Returned values are commented in the interface doc comment block.
 	GetElastiCacheClusters(ctx context.Context, input *elasticache.DescribeCacheClustersInput) (*elasticache.DescribeCacheClustersOutput, error)
 
+	// GetElastiCacheReplicationGroups returns all Elasticache replication groups based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetElastiCacheReplicationGroups(ctx context.Context, input *elasticache.DescribeReplicationGroupsInput) (*elasticache.DescribeReplicationGroupsOutput, error)
+
+	// GetElastiCacheParameterGroups returns all Elasticache parameter groups based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetElastiCacheParameterGroups(ctx context.Context, input *elasticache.DescribeCacheParameterGroupsInput) (*elasticache.DescribeCacheParameterGroupsOutput, error)
+
+	// GetElastiCacheSubnetGroups returns all Elasticache subnet groups based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetElastiCacheSubnetGroups(ctx context.Context, input *elasticache.DescribeCacheSubnetGroupsInput) (*elasticache.DescribeCacheSubnetGroupsOutput, error)
+
 	// GetElastiCacheTags returns a list of tags of Elasticache resources based on its ARN.
 	// Returned values are commented in the interface doc comment block.
 	GetElastiCacheTags(ctx context.Context, input *elasticache.ListTagsForResourceInput) (*elasticache.TagListMessage, error)
@@ -116,6 +211,22 @@ type Reader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetLoadBalancersV2Tags(ctx context.Context, input *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error)
 
+	// GetListeners returns a list of Listeners of the ELB (v2) based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetListeners(ctx context.Context, input *elbv2.DescribeListenersInput) (*elbv2.DescribeListenersOutput, error)
+
+	// GetListenerRules returns a list of Rules of the ELB (v2) Listeners based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetListenerRules(ctx context.Context, input *elbv2.DescribeRulesInput) (*elbv2.DescribeRulesOutput, error)
+
+	// GetTargetGroups returns a list of Target Groups of the ELB (v2) based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetTargetGroups(ctx context.Context, input *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error)
+
+	// GetTargetHealth returns the health of the Targets of the ELB (v2) Target Group on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetTargetHealth(ctx context.Context, input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error)
+
 	// GetDBInstances returns all DB instances based on the input given.
 	// Returned values are commented in the interface doc comment block.
 	GetDBInstances(ctx context.Context, input *rds.DescribeDBInstancesInput) (*rds.DescribeDBInstancesOutput, error)
@@ -124,6 +235,26 @@ type Reader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetDBInstancesTags(ctx context.Context, input *rds.ListTagsForResourceInput) (*rds.ListTagsForResourceOutput, error)
 
+	// GetDBClusters returns all DB clusters based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetDBClusters(ctx context.Context, input *rds.DescribeDBClustersInput) (*rds.DescribeDBClustersOutput, error)
+
+	// GetDBParameterGroups returns all DB parameter groups based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetDBParameterGroups(ctx context.Context, input *rds.DescribeDBParameterGroupsInput) (*rds.DescribeDBParameterGroupsOutput, error)
+
+	// GetDBClusterParameterGroups returns all DB cluster parameter groups based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetDBClusterParameterGroups(ctx context.Context, input *rds.DescribeDBClusterParameterGroupsInput) (*rds.DescribeDBClusterParameterGroupsOutput, error)
+
+	// GetDBOptionGroups returns all DB option groups based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetDBOptionGroups(ctx context.Context, input *rds.DescribeOptionGroupsInput) (*rds.DescribeOptionGroupsOutput, error)
+
+	// GetDBSubnetGroups returns all DB subnet groups based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetDBSubnetGroups(ctx context.Context, input *rds.DescribeDBSubnetGroupsInput) (*rds.DescribeDBSubnetGroupsOutput, error)
+
 	// ListBuckets returns all S3 buckets based on the input given and specifically
 	// filtering by Location as ListBuckets does not do it by itself
 	// Returned values are commented in the interface doc comment block.
@@ -133,6 +264,10 @@ type Reader interface {
 	// Returned values are commented in the interface doc comment block.
 	GetBucketTags(ctx context.Context, input *s3.GetBucketTaggingInput) (*s3.GetBucketTaggingOutput, error)
 
+	// GetBucketPublicAccessBlock returns the public access block configuration of the S3 bucket based on the input given.
+	// Returned values are commented in the interface doc comment block.
+	GetBucketPublicAccessBlock(ctx context.Context, input *s3.GetPublicAccessBlockInput) (*s3.GetPublicAccessBlockOutput, error)
+
 	// ListObjects returns a list of all S3 objects in a bucket based on the input given.
 	// Returned values are commented in the interface doc comment block.
 	ListObjects(ctx context.Context, input *s3.ListObjectsInput) (*s3.ListObjectsOutput, error)
@@ -291,14 +426,1693 @@ type Reader interface {
 	// GetResolverRuleAssociations returns the Route53Resolver ResolverRuleAssociations on the given input
 	// Returned values are commented in the interface doc comment block.
 	GetResolverRuleAssociations(ctx context.Context, input *route53resolver.ListResolverRuleAssociationsInput) (*route53resolver.ListResolverRuleAssociationsOutput, error)
+
+	// GetMetricAlarms returns all the CloudWatch metric alarms on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetMetricAlarms(ctx context.Context, input *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error)
+
+	// GetDashboards returns all the CloudWatch dashboards on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetDashboards(ctx context.Context, input *cloudwatch.ListDashboardsInput) (*cloudwatch.ListDashboardsOutput, error)
+
+	// GetLogGroups returns all the CloudWatch log groups on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetLogGroups(ctx context.Context, input *cloudwatchlogs.DescribeLogGroupsInput) (*cloudwatchlogs.DescribeLogGroupsOutput, error)
+
+	// GetMetricFilters returns all the CloudWatch log metric filters on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetMetricFilters(ctx context.Context, input *cloudwatchlogs.DescribeMetricFiltersInput) (*cloudwatchlogs.DescribeMetricFiltersOutput, error)
+
+	// GetCloudWatchEventRules returns all the CloudWatch event rules on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCloudWatchEventRules(ctx context.Context, input *cloudwatchevents.ListRulesInput) (*cloudwatchevents.ListRulesOutput, error)
+
+	// GetCloudWatchEventTargets returns all the CloudWatch event targets of the rule on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCloudWatchEventTargets(ctx context.Context, input *cloudwatchevents.ListTargetsByRuleInput) (*cloudwatchevents.ListTargetsByRuleOutput, error)
+
+	// GetCertificates returns all the ACM certificates on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCertificates(ctx context.Context, input *acm.ListCertificatesInput) (*acm.ListCertificatesOutput, error)
+
+	// GetCertificate returns the ACM certificate on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCertificate(ctx context.Context, input *acm.DescribeCertificateInput) (*acm.DescribeCertificateOutput, error)
+
+	// GetSecrets returns all the Secrets Manager secrets, without the values, on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetSecrets(ctx context.Context, input *secretsmanager.ListSecretsInput) (*secretsmanager.ListSecretsOutput, error)
+
+	// GetSSMParameters returns all the SSM parameters, without the values, on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetSSMParameters(ctx context.Context, input *ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error)
+
+	// GetPipelines returns all the CodePipeline pipelines on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetPipelines(ctx context.Context, input *codepipeline.ListPipelinesInput) (*codepipeline.ListPipelinesOutput, error)
+
+	// GetCodeBuildProjects returns the names of all the CodeBuild projects on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCodeBuildProjects(ctx context.Context, input *codebuild.ListProjectsInput) (*codebuild.ListProjectsOutput, error)
+
+	// GetCodeDeployApplications returns the names of all the CodeDeploy applications on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCodeDeployApplications(ctx context.Context, input *codedeploy.ListApplicationsInput) (*codedeploy.ListApplicationsOutput, error)
+
+	// GetCodeDeployDeploymentGroups returns the names of the CodeDeploy deployment groups of the application on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCodeDeployDeploymentGroups(ctx context.Context, input *codedeploy.ListDeploymentGroupsInput) (*codedeploy.ListDeploymentGroupsOutput, error)
+
+	// GetGlueDatabases returns all the Glue Data Catalog databases on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetGlueDatabases(ctx context.Context, input *glue.GetDatabasesInput) (*glue.GetDatabasesOutput, error)
+
+	// GetGlueTables returns the Glue Data Catalog tables of the database on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetGlueTables(ctx context.Context, input *glue.GetTablesInput) (*glue.GetTablesOutput, error)
+
+	// GetGlueJobs returns all the Glue jobs on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetGlueJobs(ctx context.Context, input *glue.GetJobsInput) (*glue.GetJobsOutput, error)
+
+	// GetWorkGroups returns all the Athena workgroups on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetWorkGroups(ctx context.Context, input *athena.ListWorkGroupsInput) (*athena.ListWorkGroupsOutput, error)
+
+	// GetNamedQueries returns the IDs of the Athena named queries on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetNamedQueries(ctx context.Context, input *athena.ListNamedQueriesInput) (*athena.ListNamedQueriesOutput, error)
+
+	// GetRedshiftClusters returns all the Redshift clusters on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetRedshiftClusters(ctx context.Context, input *redshift.DescribeClustersInput) (*redshift.DescribeClustersOutput, error)
+
+	// GetRedshiftParameterGroups returns all the Redshift parameter groups on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetRedshiftParameterGroups(ctx context.Context, input *redshift.DescribeClusterParameterGroupsInput) (*redshift.DescribeClusterParameterGroupsOutput, error)
+
+	// GetRedshiftSubnetGroups returns all the Redshift subnet groups on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetRedshiftSubnetGroups(ctx context.Context, input *redshift.DescribeClusterSubnetGroupsInput) (*redshift.DescribeClusterSubnetGroupsOutput, error)
+
+	// GetMSKClusters returns all the MSK (Kafka) clusters on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetMSKClusters(ctx context.Context, input *kafka.ListClustersInput) (*kafka.ListClustersOutput, error)
+
+	// GetMSKConfigurations returns all the MSK (Kafka) configurations on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetMSKConfigurations(ctx context.Context, input *kafka.ListConfigurationsInput) (*kafka.ListConfigurationsOutput, error)
+
+	// GetTrails returns all the CloudTrail trails on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetTrails(ctx context.Context, input *cloudtrail.DescribeTrailsInput) (*cloudtrail.DescribeTrailsOutput, error)
+
+	// GetConfigurationRecorders returns all the AWS Config configuration recorders on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetConfigurationRecorders(ctx context.Context, input *configservice.DescribeConfigurationRecordersInput) (*configservice.DescribeConfigurationRecordersOutput, error)
+
+	// GetConfigRules returns all the AWS Config rules on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetConfigRules(ctx context.Context, input *configservice.DescribeConfigRulesInput) (*configservice.DescribeConfigRulesOutput, error)
+
+	// GetGuardDutyDetectors returns the IDs of all the GuardDuty detectors on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetGuardDutyDetectors(ctx context.Context, input *guardduty.ListDetectorsInput) (*guardduty.ListDetectorsOutput, error)
+
+	// GetGuardDutyMembers returns the member accounts of the GuardDuty detector on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetGuardDutyMembers(ctx context.Context, input *guardduty.ListMembersInput) (*guardduty.ListMembersOutput, error)
+
+	// GetCognitoUserPools returns the Cognito user pools on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCognitoUserPools(ctx context.Context, input *cognitoidentityprovider.ListUserPoolsInput) (*cognitoidentityprovider.ListUserPoolsOutput, error)
+
+	// GetCognitoUserPool returns the Cognito user pool on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCognitoUserPool(ctx context.Context, input *cognitoidentityprovider.DescribeUserPoolInput) (*cognitoidentityprovider.DescribeUserPoolOutput, error)
+
+	// GetCognitoUserPoolClients returns the clients of the Cognito user pool on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCognitoUserPoolClients(ctx context.Context, input *cognitoidentityprovider.ListUserPoolClientsInput) (*cognitoidentityprovider.ListUserPoolClientsOutput, error)
+
+	// GetCognitoResourceServers returns the resource servers of the Cognito user pool on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCognitoResourceServers(ctx context.Context, input *cognitoidentityprovider.ListResourceServersInput) (*cognitoidentityprovider.ListResourceServersOutput, error)
+
+	// GetCognitoIdentityPools returns the Cognito identity pools on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCognitoIdentityPools(ctx context.Context, input *cognitoidentity.ListIdentityPoolsInput) (*cognitoidentity.ListIdentityPoolsOutput, error)
+
+	// GetCognitoIdentityPoolRoles returns the roles of the Cognito identity pool on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetCognitoIdentityPoolRoles(ctx context.Context, input *cognitoidentity.GetIdentityPoolRolesInput) (*cognitoidentity.GetIdentityPoolRolesOutput, error)
+
+	// GetAppsyncGraphqlApis returns all the AppSync GraphQL APIs on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetAppsyncGraphqlApis(ctx context.Context, input *appsync.ListGraphqlApisInput) (*appsync.ListGraphqlApisOutput, error)
+
+	// GetAppsyncIntrospectionSchema returns the schema of the AppSync GraphQL API on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetAppsyncIntrospectionSchema(ctx context.Context, input *appsync.GetIntrospectionSchemaInput) (*appsync.GetIntrospectionSchemaOutput, error)
+
+	// GetAppsyncDataSources returns the data sources of the AppSync GraphQL API on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetAppsyncDataSources(ctx context.Context, input *appsync.ListDataSourcesInput) (*appsync.ListDataSourcesOutput, error)
+
+	// GetAppsyncTypes returns the types of the AppSync GraphQL API on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetAppsyncTypes(ctx context.Context, input *appsync.ListTypesInput) (*appsync.ListTypesOutput, error)
+
+	// GetAppsyncResolvers returns the resolvers of the type of the AppSync GraphQL API on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetAppsyncResolvers(ctx context.Context, input *appsync.ListResolversInput) (*appsync.ListResolversOutput, error)
+
+	// GetEFSFileSystems returns all the EFS file systems on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetEFSFileSystems(ctx context.Context, input *efs.DescribeFileSystemsInput) (*efs.DescribeFileSystemsOutput, error)
+
+	// GetEFSMountTargets returns the mount targets of the EFS file system on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetEFSMountTargets(ctx context.Context, input *efs.DescribeMountTargetsInput) (*efs.DescribeMountTargetsOutput, error)
+
+	// GetFSxFileSystems returns all the FSx file systems on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetFSxFileSystems(ctx context.Context, input *fsx.DescribeFileSystemsInput) (*fsx.DescribeFileSystemsOutput, error)
+
+	// GetTaggedResources returns the ARNs and tags of the resources, that are or have been tagged, on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetTaggedResources(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) (*resourcegroupstaggingapi.GetResourcesOutput, error)
+
+	// GetStateMachines returns all the Step Functions state machines on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetStateMachines(ctx context.Context, input *sfn.ListStateMachinesInput) (*sfn.ListStateMachinesOutput, error)
+
+	// GetOrganization returns the Organization of the account on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetOrganization(ctx context.Context, input *organizations.DescribeOrganizationInput) (*organizations.DescribeOrganizationOutput, error)
+
+	// GetOrganizationsAccounts returns the accounts of the Organization on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetOrganizationsAccounts(ctx context.Context, input *organizations.ListAccountsInput) (*organizations.ListAccountsOutput, error)
+
+	// GetOrganizationsRoots returns the roots of the Organization on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetOrganizationsRoots(ctx context.Context, input *organizations.ListRootsInput) (*organizations.ListRootsOutput, error)
+
+	// GetOrganizationsOrganizationalUnits returns the organizational units of the parent on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetOrganizationsOrganizationalUnits(ctx context.Context, input *organizations.ListOrganizationalUnitsForParentInput) (*organizations.ListOrganizationalUnitsForParentOutput, error)
+
+	// GetOrganizationsPolicies returns the policies of the Organization on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetOrganizationsPolicies(ctx context.Context, input *organizations.ListPoliciesInput) (*organizations.ListPoliciesOutput, error)
+
+	// GetOrganizationsTargetsForPolicy returns the targets of the policy on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetOrganizationsTargetsForPolicy(ctx context.Context, input *organizations.ListTargetsForPolicyInput) (*organizations.ListTargetsForPolicyOutput, error)
+
+	// GetBatchComputeEnvironments returns the Batch compute environments on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetBatchComputeEnvironments(ctx context.Context, input *batch.DescribeComputeEnvironmentsInput) (*batch.DescribeComputeEnvironmentsOutput, error)
+
+	// GetBatchJobQueues returns the Batch job queues on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetBatchJobQueues(ctx context.Context, input *batch.DescribeJobQueuesInput) (*batch.DescribeJobQueuesOutput, error)
+
+	// GetBatchJobDefinitions returns the Batch job definitions on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetBatchJobDefinitions(ctx context.Context, input *batch.DescribeJobDefinitionsInput) (*batch.DescribeJobDefinitionsOutput, error)
+
+	// GetEMRClusters returns the EMR clusters on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetEMRClusters(ctx context.Context, input *emr.ListClustersInput) (*emr.ListClustersOutput, error)
+
+	// GetSagemakerNotebookInstances returns the SageMaker notebook instances on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetSagemakerNotebookInstances(ctx context.Context, input *sagemaker.ListNotebookInstancesInput) (*sagemaker.ListNotebookInstancesOutput, error)
+
+	// GetSagemakerEndpoints returns the SageMaker endpoints on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetSagemakerEndpoints(ctx context.Context, input *sagemaker.ListEndpointsInput) (*sagemaker.ListEndpointsOutput, error)
+
+	// GetGlobalacceleratorAccelerators returns the Global Accelerator accelerators on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetGlobalacceleratorAccelerators(ctx context.Context, input *globalaccelerator.ListAcceleratorsInput) (*globalaccelerator.ListAcceleratorsOutput, error)
+
+	// GetGlobalacceleratorListeners returns the listeners of the accelerator on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetGlobalacceleratorListeners(ctx context.Context, input *globalaccelerator.ListListenersInput) (*globalaccelerator.ListListenersOutput, error)
+
+	// GetGlobalacceleratorEndpointGroups returns the endpoint groups of the listener on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetGlobalacceleratorEndpointGroups(ctx context.Context, input *globalaccelerator.ListEndpointGroupsInput) (*globalaccelerator.ListEndpointGroupsOutput, error)
+
+	// GetKMSAliases returns the KMS aliases on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetKMSAliases(ctx context.Context, input *kms.ListAliasesInput) (*kms.ListAliasesOutput, error)
+
+	// GetBackupVaults returns the AWS Backup vaults on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetBackupVaults(ctx context.Context, input *backup.ListBackupVaultsInput) (*backup.ListBackupVaultsOutput, error)
+
+	// GetBackupPlans returns the AWS Backup plans on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetBackupPlans(ctx context.Context, input *backup.ListBackupPlansInput) (*backup.ListBackupPlansOutput, error)
+
+	// GetBackupSelections returns the selections of the AWS Backup plan on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetBackupSelections(ctx context.Context, input *backup.ListBackupSelectionsInput) (*backup.ListBackupSelectionsOutput, error)
+
+	// GetElasticBeanstalkApplications returns the Elastic Beanstalk applications on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetElasticBeanstalkApplications(ctx context.Context, input *elasticbeanstalk.DescribeApplicationsInput) (*elasticbeanstalk.DescribeApplicationsOutput, error)
+
+	// GetElasticBeanstalkEnvironments returns the Elastic Beanstalk environments on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetElasticBeanstalkEnvironments(ctx context.Context, input *elasticbeanstalk.DescribeEnvironmentsInput) (*elasticbeanstalk.EnvironmentDescriptionsMessage, error)
+
+	// GetElasticBeanstalkConfigurationSettings returns the option settings of the Elastic Beanstalk environment on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetElasticBeanstalkConfigurationSettings(ctx context.Context, input *elasticbeanstalk.DescribeConfigurationSettingsInput) (*elasticbeanstalk.DescribeConfigurationSettingsOutput, error)
+
+	// GetElasticBeanstalkConfigurationOptions returns the options, with their default values, of the Elastic Beanstalk environment on the given input
+	// Returned values are commented in the interface doc comment block.
+	GetElasticBeanstalkConfigurationOptions(ctx context.Context, input *elasticbeanstalk.DescribeConfigurationOptionsInput) (*elasticbeanstalk.DescribeConfigurationOptionsOutput, error)
 }
 
 func (c *connector) GetInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeInstancesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetVpcs(ctx context.Context, input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeVpcsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetImages(ctx context.Context, input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeImagesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetOwnImages(ctx context.Context, input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+
+	if input == nil {
+		input = &ec2.DescribeImagesInput{}
+	}
+	input.Owners = append(input.Owners, c.accountID)
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeImagesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+	c.svc.mu.Lock()
 	if c.svc.ec2 == nil {
 		c.svc.ec2 = ec2.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeSecurityGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeSubnetsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetVolumes(ctx context.Context, input *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeVolumesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeSnapshotsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetOwnSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error) {
+
+	if input == nil {
+		input = &ec2.DescribeSnapshotsInput{}
+	}
+	input.OwnerIds = append(input.OwnerIds, c.accountID)
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeSnapshotsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetLaunchTemplates(ctx context.Context, input *ec2.DescribeLaunchTemplatesInput) (*ec2.DescribeLaunchTemplatesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeLaunchTemplatesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetTransitGateways(ctx context.Context, input *ec2.DescribeTransitGatewaysInput) (*ec2.DescribeTransitGatewaysOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeTransitGatewaysWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetTransitGatewayVpcAttachments(ctx context.Context, input *ec2.DescribeTransitGatewayVpcAttachmentsInput) (*ec2.DescribeTransitGatewayVpcAttachmentsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeTransitGatewayVpcAttachmentsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetTransitGatewayRouteTables(ctx context.Context, input *ec2.DescribeTransitGatewayRouteTablesInput) (*ec2.DescribeTransitGatewayRouteTablesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeTransitGatewayRouteTablesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetTransitGatewayRouteTableAssociations(ctx context.Context, input *ec2.GetTransitGatewayRouteTableAssociationsInput) (*ec2.GetTransitGatewayRouteTableAssociationsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.GetTransitGatewayRouteTableAssociationsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetTransitGatewayRouteTablePropagations(ctx context.Context, input *ec2.GetTransitGatewayRouteTablePropagationsInput) (*ec2.GetTransitGatewayRouteTablePropagationsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.GetTransitGatewayRouteTablePropagationsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetVpcPeeringConnections(ctx context.Context, input *ec2.DescribeVpcPeeringConnectionsInput) (*ec2.DescribeVpcPeeringConnectionsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeVpcPeeringConnectionsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetInternetGateways(ctx context.Context, input *ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeInternetGatewaysWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetNatGateways(ctx context.Context, input *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeNatGatewaysWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetRouteTables(ctx context.Context, input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeRouteTablesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetNetworkAcls(ctx context.Context, input *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeNetworkAclsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetVpcEndpoints(ctx context.Context, input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ec2 == nil {
+		c.svc.ec2 = ec2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ec2.DescribeVpcEndpointsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetAutoScalingGroups(ctx context.Context, input *autoscaling.DescribeAutoScalingGroupsInput) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.autoscaling == nil {
+		c.svc.autoscaling = autoscaling.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.autoscaling.DescribeAutoScalingGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetLaunchConfigurations(ctx context.Context, input *autoscaling.DescribeLaunchConfigurationsInput) (*autoscaling.DescribeLaunchConfigurationsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.autoscaling == nil {
+		c.svc.autoscaling = autoscaling.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.autoscaling.DescribeLaunchConfigurationsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetAutoScalingPolicies(ctx context.Context, input *autoscaling.DescribePoliciesInput) (*autoscaling.DescribePoliciesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.autoscaling == nil {
+		c.svc.autoscaling = autoscaling.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.autoscaling.DescribePoliciesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetAutoScalingScheduledActions(ctx context.Context, input *autoscaling.DescribeScheduledActionsInput) (*autoscaling.DescribeScheduledActionsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.autoscaling == nil {
+		c.svc.autoscaling = autoscaling.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.autoscaling.DescribeScheduledActionsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetElastiCacheClusters(ctx context.Context, input *elasticache.DescribeCacheClustersInput) (*elasticache.DescribeCacheClustersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elasticache == nil {
+		c.svc.elasticache = elasticache.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elasticache.DescribeCacheClustersWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetElastiCacheReplicationGroups(ctx context.Context, input *elasticache.DescribeReplicationGroupsInput) (*elasticache.DescribeReplicationGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elasticache == nil {
+		c.svc.elasticache = elasticache.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elasticache.DescribeReplicationGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetElastiCacheParameterGroups(ctx context.Context, input *elasticache.DescribeCacheParameterGroupsInput) (*elasticache.DescribeCacheParameterGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elasticache == nil {
+		c.svc.elasticache = elasticache.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elasticache.DescribeCacheParameterGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetElastiCacheSubnetGroups(ctx context.Context, input *elasticache.DescribeCacheSubnetGroupsInput) (*elasticache.DescribeCacheSubnetGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elasticache == nil {
+		c.svc.elasticache = elasticache.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elasticache.DescribeCacheSubnetGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetElastiCacheTags(ctx context.Context, input *elasticache.ListTagsForResourceInput) (*elasticache.TagListMessage, error) {
+	c.svc.mu.Lock()
+	if c.svc.elasticache == nil {
+		c.svc.elasticache = elasticache.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elasticache.ListTagsForResourceWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetLoadBalancers(ctx context.Context, input *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elb == nil {
+		c.svc.elb = elb.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elb.DescribeLoadBalancersWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetLoadBalancersTags(ctx context.Context, input *elb.DescribeTagsInput) (*elb.DescribeTagsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elb == nil {
+		c.svc.elb = elb.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elb.DescribeTagsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetLoadBalancersV2(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elbv2 == nil {
+		c.svc.elbv2 = elbv2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elbv2.DescribeLoadBalancersWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetLoadBalancersV2Tags(ctx context.Context, input *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elbv2 == nil {
+		c.svc.elbv2 = elbv2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elbv2.DescribeTagsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetListeners(ctx context.Context, input *elbv2.DescribeListenersInput) (*elbv2.DescribeListenersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elbv2 == nil {
+		c.svc.elbv2 = elbv2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elbv2.DescribeListenersWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetListenerRules(ctx context.Context, input *elbv2.DescribeRulesInput) (*elbv2.DescribeRulesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elbv2 == nil {
+		c.svc.elbv2 = elbv2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elbv2.DescribeRulesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetTargetGroups(ctx context.Context, input *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elbv2 == nil {
+		c.svc.elbv2 = elbv2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elbv2.DescribeTargetGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetTargetHealth(ctx context.Context, input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elbv2 == nil {
+		c.svc.elbv2 = elbv2.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.elbv2.DescribeTargetHealthWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetDBInstances(ctx context.Context, input *rds.DescribeDBInstancesInput) (*rds.DescribeDBInstancesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.rds == nil {
+		c.svc.rds = rds.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.rds.DescribeDBInstancesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetDBInstancesTags(ctx context.Context, input *rds.ListTagsForResourceInput) (*rds.ListTagsForResourceOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.rds == nil {
+		c.svc.rds = rds.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.rds.ListTagsForResourceWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetDBClusters(ctx context.Context, input *rds.DescribeDBClustersInput) (*rds.DescribeDBClustersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.rds == nil {
+		c.svc.rds = rds.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.rds.DescribeDBClustersWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetDBParameterGroups(ctx context.Context, input *rds.DescribeDBParameterGroupsInput) (*rds.DescribeDBParameterGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.rds == nil {
+		c.svc.rds = rds.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.rds.DescribeDBParameterGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetDBClusterParameterGroups(ctx context.Context, input *rds.DescribeDBClusterParameterGroupsInput) (*rds.DescribeDBClusterParameterGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.rds == nil {
+		c.svc.rds = rds.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.rds.DescribeDBClusterParameterGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetDBOptionGroups(ctx context.Context, input *rds.DescribeOptionGroupsInput) (*rds.DescribeOptionGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.rds == nil {
+		c.svc.rds = rds.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.rds.DescribeOptionGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetDBSubnetGroups(ctx context.Context, input *rds.DescribeDBSubnetGroupsInput) (*rds.DescribeDBSubnetGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.rds == nil {
+		c.svc.rds = rds.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.rds.DescribeDBSubnetGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetBucketTags(ctx context.Context, input *s3.GetBucketTaggingInput) (*s3.GetBucketTaggingOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.s3 == nil {
+		c.svc.s3 = s3.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.s3.GetBucketTaggingWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetBucketPublicAccessBlock(ctx context.Context, input *s3.GetPublicAccessBlockInput) (*s3.GetPublicAccessBlockOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.s3 == nil {
+		c.svc.s3 = s3.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.s3.GetPublicAccessBlockWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) ListObjects(ctx context.Context, input *s3.ListObjectsInput) (*s3.ListObjectsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.s3 == nil {
+		c.svc.s3 = s3.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.s3.ListObjectsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetObjectsTags(ctx context.Context, input *s3.GetObjectTaggingInput) (*s3.GetObjectTaggingOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.s3 == nil {
+		c.svc.s3 = s3.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.s3.GetObjectTaggingWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetRecordedResourceCounts(ctx context.Context, input *configservice.GetDiscoveredResourceCountsInput) (*configservice.GetDiscoveredResourceCountsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.configservice == nil {
+		c.svc.configservice = configservice.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.configservice.GetDiscoveredResourceCountsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetCloudFrontDistributions(ctx context.Context, input *cloudfront.ListDistributionsInput) (*cloudfront.ListDistributionsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cloudfront == nil {
+		c.svc.cloudfront = cloudfront.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.cloudfront.ListDistributionsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetCloudFrontPublicKeys(ctx context.Context, input *cloudfront.ListPublicKeysInput) (*cloudfront.ListPublicKeysOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cloudfront == nil {
+		c.svc.cloudfront = cloudfront.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.cloudfront.ListPublicKeysWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetCloudFrontOriginAccessIdentities(ctx context.Context, input *cloudfront.ListCloudFrontOriginAccessIdentitiesInput) (*cloudfront.ListCloudFrontOriginAccessIdentitiesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cloudfront == nil {
+		c.svc.cloudfront = cloudfront.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.cloudfront.ListCloudFrontOriginAccessIdentitiesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetAccessKeys(ctx context.Context, input *iam.ListAccessKeysInput) (*iam.ListAccessKeysOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListAccessKeysWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetAccountAliases(ctx context.Context, input *iam.ListAccountAliasesInput) (*iam.ListAccountAliasesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListAccountAliasesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetAccountPasswordPolicy(ctx context.Context, input *iam.GetAccountPasswordPolicyInput) (*iam.GetAccountPasswordPolicyOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.GetAccountPasswordPolicyWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetGroups(ctx context.Context, input *iam.ListGroupsInput) (*iam.ListGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetGroupPolicies(ctx context.Context, input *iam.ListGroupPoliciesInput) (*iam.ListGroupPoliciesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListGroupPoliciesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetAttachedGroupPolicies(ctx context.Context, input *iam.ListAttachedGroupPoliciesInput) (*iam.ListAttachedGroupPoliciesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListAttachedGroupPoliciesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetInstanceProfiles(ctx context.Context, input *iam.ListInstanceProfilesInput) (*iam.ListInstanceProfilesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListInstanceProfilesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetOpenIDConnectProviders(ctx context.Context, input *iam.ListOpenIDConnectProvidersInput) (*iam.ListOpenIDConnectProvidersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListOpenIDConnectProvidersWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetPolicies(ctx context.Context, input *iam.ListPoliciesInput) (*iam.ListPoliciesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListPoliciesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetRoles(ctx context.Context, input *iam.ListRolesInput) (*iam.ListRolesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListRolesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetRolePolicies(ctx context.Context, input *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListRolePoliciesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetAttachedRolePolicies(ctx context.Context, input *iam.ListAttachedRolePoliciesInput) (*iam.ListAttachedRolePoliciesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListAttachedRolePoliciesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetSAMLProviders(ctx context.Context, input *iam.ListSAMLProvidersInput) (*iam.ListSAMLProvidersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListSAMLProvidersWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetServerCertificates(ctx context.Context, input *iam.ListServerCertificatesInput) (*iam.ListServerCertificatesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListServerCertificatesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetUsers(ctx context.Context, input *iam.ListUsersInput) (*iam.ListUsersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListUsersWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetUserPolicies(ctx context.Context, input *iam.ListUserPoliciesInput) (*iam.ListUserPoliciesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListUserPoliciesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetAttachedUserPolicies(ctx context.Context, input *iam.ListAttachedUserPoliciesInput) (*iam.ListAttachedUserPoliciesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.ListAttachedUserPoliciesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetSSHPublicKey(ctx context.Context, input *iam.GetSSHPublicKeyInput) (*iam.GetSSHPublicKeyOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.iam == nil {
+		c.svc.iam = iam.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.iam.GetSSHPublicKeyWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetActiveReceiptRuleSet(ctx context.Context, input *ses.DescribeActiveReceiptRuleSetInput) (*ses.DescribeActiveReceiptRuleSetOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ses == nil {
+		c.svc.ses = ses.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ses.DescribeActiveReceiptRuleSetWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetIdentities(ctx context.Context, input *ses.ListIdentitiesInput) (*ses.ListIdentitiesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ses == nil {
+		c.svc.ses = ses.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ses.ListIdentitiesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetReceiptFilters(ctx context.Context, input *ses.ListReceiptFiltersInput) (*ses.ListReceiptFiltersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ses == nil {
+		c.svc.ses = ses.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ses.ListReceiptFiltersWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetConfigurationSets(ctx context.Context, input *ses.ListConfigurationSetsInput) (*ses.ListConfigurationSetsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ses == nil {
+		c.svc.ses = ses.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ses.ListConfigurationSetsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetIdentityNotificationAttributes(ctx context.Context, input *ses.GetIdentityNotificationAttributesInput) (*ses.GetIdentityNotificationAttributesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ses == nil {
+		c.svc.ses = ses.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ses.GetIdentityNotificationAttributesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetTemplates(ctx context.Context, input *ses.ListTemplatesInput) (*ses.ListTemplatesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ses == nil {
+		c.svc.ses = ses.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.ses.ListTemplatesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetReusableDelegationSets(ctx context.Context, input *route53.ListReusableDelegationSetsInput) (*route53.ListReusableDelegationSetsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.route53 == nil {
+		c.svc.route53 = route53.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.route53.ListReusableDelegationSetsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetHealthChecks(ctx context.Context, input *route53.ListHealthChecksInput) (*route53.ListHealthChecksOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.route53 == nil {
+		c.svc.route53 = route53.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.route53.ListHealthChecksWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetQueryLoggingConfigs(ctx context.Context, input *route53.ListQueryLoggingConfigsInput) (*route53.ListQueryLoggingConfigsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.route53 == nil {
+		c.svc.route53 = route53.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.route53.ListQueryLoggingConfigsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.route53 == nil {
+		c.svc.route53 = route53.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.route53.ListResourceRecordSetsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetHostedZones(ctx context.Context, input *route53.ListHostedZonesInput) (*route53.ListHostedZonesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.route53 == nil {
+		c.svc.route53 = route53.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.route53.ListHostedZonesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetVPCAssociationAuthorizations(ctx context.Context, input *route53.ListVPCAssociationAuthorizationsInput) (*route53.ListVPCAssociationAuthorizationsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.route53 == nil {
+		c.svc.route53 = route53.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.route53.ListVPCAssociationAuthorizationsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetResolverEndpoints(ctx context.Context, input *route53resolver.ListResolverEndpointsInput) (*route53resolver.ListResolverEndpointsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.route53resolver == nil {
+		c.svc.route53resolver = route53resolver.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.route53resolver.ListResolverEndpointsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetResolverRules(ctx context.Context, input *route53resolver.ListResolverRulesInput) (*route53resolver.ListResolverRulesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.route53resolver == nil {
+		c.svc.route53resolver = route53resolver.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.route53resolver.ListResolverRulesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetResolverRuleAssociations(ctx context.Context, input *route53resolver.ListResolverRuleAssociationsInput) (*route53resolver.ListResolverRuleAssociationsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.route53resolver == nil {
+		c.svc.route53resolver = route53resolver.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.route53resolver.ListResolverRuleAssociationsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetMetricAlarms(ctx context.Context, input *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cloudwatch == nil {
+		c.svc.cloudwatch = cloudwatch.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.cloudwatch.DescribeAlarmsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetDashboards(ctx context.Context, input *cloudwatch.ListDashboardsInput) (*cloudwatch.ListDashboardsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cloudwatch == nil {
+		c.svc.cloudwatch = cloudwatch.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.cloudwatch.ListDashboardsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetLogGroups(ctx context.Context, input *cloudwatchlogs.DescribeLogGroupsInput) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cloudwatchlogs == nil {
+		c.svc.cloudwatchlogs = cloudwatchlogs.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.cloudwatchlogs.DescribeLogGroupsWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetMetricFilters(ctx context.Context, input *cloudwatchlogs.DescribeMetricFiltersInput) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cloudwatchlogs == nil {
+		c.svc.cloudwatchlogs = cloudwatchlogs.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.cloudwatchlogs.DescribeMetricFiltersWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetCloudWatchEventRules(ctx context.Context, input *cloudwatchevents.ListRulesInput) (*cloudwatchevents.ListRulesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cloudwatchevents == nil {
+		c.svc.cloudwatchevents = cloudwatchevents.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.cloudwatchevents.ListRulesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetCloudWatchEventTargets(ctx context.Context, input *cloudwatchevents.ListTargetsByRuleInput) (*cloudwatchevents.ListTargetsByRuleOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cloudwatchevents == nil {
+		c.svc.cloudwatchevents = cloudwatchevents.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.cloudwatchevents.ListTargetsByRuleWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetCertificates(ctx context.Context, input *acm.ListCertificatesInput) (*acm.ListCertificatesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.acm == nil {
+		c.svc.acm = acm.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
+
+	opt, err := c.svc.acm.ListCertificatesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return opt, nil
+}
+
+func (c *connector) GetCertificate(ctx context.Context, input *acm.DescribeCertificateInput) (*acm.DescribeCertificateOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.acm == nil {
+		c.svc.acm = acm.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ec2.DescribeInstancesWithContext(ctx, input)
+	opt, err := c.svc.acm.DescribeCertificateWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -306,12 +2120,14 @@ func (c *connector) GetInstances(ctx context.Context, input *ec2.DescribeInstanc
 	return opt, nil
 }
 
-func (c *connector) GetVpcs(ctx context.Context, input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error) {
-	if c.svc.ec2 == nil {
-		c.svc.ec2 = ec2.New(c.svc.session)
+func (c *connector) GetSecrets(ctx context.Context, input *secretsmanager.ListSecretsInput) (*secretsmanager.ListSecretsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.secretsmanager == nil {
+		c.svc.secretsmanager = secretsmanager.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ec2.DescribeVpcsWithContext(ctx, input)
+	opt, err := c.svc.secretsmanager.ListSecretsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -319,12 +2135,14 @@ func (c *connector) GetVpcs(ctx context.Context, input *ec2.DescribeVpcsInput) (
 	return opt, nil
 }
 
-func (c *connector) GetImages(ctx context.Context, input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
-	if c.svc.ec2 == nil {
-		c.svc.ec2 = ec2.New(c.svc.session)
+func (c *connector) GetSSMParameters(ctx context.Context, input *ssm.DescribeParametersInput) (*ssm.DescribeParametersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.ssm == nil {
+		c.svc.ssm = ssm.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ec2.DescribeImagesWithContext(ctx, input)
+	opt, err := c.svc.ssm.DescribeParametersWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -332,17 +2150,14 @@ func (c *connector) GetImages(ctx context.Context, input *ec2.DescribeImagesInpu
 	return opt, nil
 }
 
-func (c *connector) GetOwnImages(ctx context.Context, input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
-
-	if input == nil {
-		input = &ec2.DescribeImagesInput{}
-	}
-	input.Owners = append(input.Owners, c.accountID)
-	if c.svc.ec2 == nil {
-		c.svc.ec2 = ec2.New(c.svc.session)
+func (c *connector) GetPipelines(ctx context.Context, input *codepipeline.ListPipelinesInput) (*codepipeline.ListPipelinesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.codepipeline == nil {
+		c.svc.codepipeline = codepipeline.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ec2.DescribeImagesWithContext(ctx, input)
+	opt, err := c.svc.codepipeline.ListPipelinesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -350,12 +2165,14 @@ func (c *connector) GetOwnImages(ctx context.Context, input *ec2.DescribeImagesI
 	return opt, nil
 }
 
-func (c *connector) GetSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
-	if c.svc.ec2 == nil {
-		c.svc.ec2 = ec2.New(c.svc.session)
+func (c *connector) GetCodeBuildProjects(ctx context.Context, input *codebuild.ListProjectsInput) (*codebuild.ListProjectsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.codebuild == nil {
+		c.svc.codebuild = codebuild.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ec2.DescribeSecurityGroupsWithContext(ctx, input)
+	opt, err := c.svc.codebuild.ListProjectsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -363,12 +2180,14 @@ func (c *connector) GetSecurityGroups(ctx context.Context, input *ec2.DescribeSe
 	return opt, nil
 }
 
-func (c *connector) GetSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
-	if c.svc.ec2 == nil {
-		c.svc.ec2 = ec2.New(c.svc.session)
+func (c *connector) GetCodeDeployApplications(ctx context.Context, input *codedeploy.ListApplicationsInput) (*codedeploy.ListApplicationsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.codedeploy == nil {
+		c.svc.codedeploy = codedeploy.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ec2.DescribeSubnetsWithContext(ctx, input)
+	opt, err := c.svc.codedeploy.ListApplicationsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -376,12 +2195,14 @@ func (c *connector) GetSubnets(ctx context.Context, input *ec2.DescribeSubnetsIn
 	return opt, nil
 }
 
-func (c *connector) GetVolumes(ctx context.Context, input *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
-	if c.svc.ec2 == nil {
-		c.svc.ec2 = ec2.New(c.svc.session)
+func (c *connector) GetCodeDeployDeploymentGroups(ctx context.Context, input *codedeploy.ListDeploymentGroupsInput) (*codedeploy.ListDeploymentGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.codedeploy == nil {
+		c.svc.codedeploy = codedeploy.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ec2.DescribeVolumesWithContext(ctx, input)
+	opt, err := c.svc.codedeploy.ListDeploymentGroupsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -389,12 +2210,14 @@ func (c *connector) GetVolumes(ctx context.Context, input *ec2.DescribeVolumesIn
 	return opt, nil
 }
 
-func (c *connector) GetSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error) {
-	if c.svc.ec2 == nil {
-		c.svc.ec2 = ec2.New(c.svc.session)
+func (c *connector) GetGlueDatabases(ctx context.Context, input *glue.GetDatabasesInput) (*glue.GetDatabasesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.glue == nil {
+		c.svc.glue = glue.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ec2.DescribeSnapshotsWithContext(ctx, input)
+	opt, err := c.svc.glue.GetDatabasesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -402,17 +2225,29 @@ func (c *connector) GetSnapshots(ctx context.Context, input *ec2.DescribeSnapsho
 	return opt, nil
 }
 
-func (c *connector) GetOwnSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error) {
+func (c *connector) GetGlueTables(ctx context.Context, input *glue.GetTablesInput) (*glue.GetTablesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.glue == nil {
+		c.svc.glue = glue.New(c.svc.session)
+	}
+	c.svc.mu.Unlock()
 
-	if input == nil {
-		input = &ec2.DescribeSnapshotsInput{}
+	opt, err := c.svc.glue.GetTablesWithContext(ctx, input)
+	if err != nil {
+		return nil, err
 	}
-	input.OwnerIds = append(input.OwnerIds, c.accountID)
-	if c.svc.ec2 == nil {
-		c.svc.ec2 = ec2.New(c.svc.session)
+
+	return opt, nil
+}
+
+func (c *connector) GetGlueJobs(ctx context.Context, input *glue.GetJobsInput) (*glue.GetJobsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.glue == nil {
+		c.svc.glue = glue.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ec2.DescribeSnapshotsWithContext(ctx, input)
+	opt, err := c.svc.glue.GetJobsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -420,12 +2255,14 @@ func (c *connector) GetOwnSnapshots(ctx context.Context, input *ec2.DescribeSnap
 	return opt, nil
 }
 
-func (c *connector) GetLaunchTemplates(ctx context.Context, input *ec2.DescribeLaunchTemplatesInput) (*ec2.DescribeLaunchTemplatesOutput, error) {
-	if c.svc.ec2 == nil {
-		c.svc.ec2 = ec2.New(c.svc.session)
+func (c *connector) GetWorkGroups(ctx context.Context, input *athena.ListWorkGroupsInput) (*athena.ListWorkGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.athena == nil {
+		c.svc.athena = athena.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ec2.DescribeLaunchTemplatesWithContext(ctx, input)
+	opt, err := c.svc.athena.ListWorkGroupsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -433,12 +2270,14 @@ func (c *connector) GetLaunchTemplates(ctx context.Context, input *ec2.DescribeL
 	return opt, nil
 }
 
-func (c *connector) GetAutoScalingGroups(ctx context.Context, input *autoscaling.DescribeAutoScalingGroupsInput) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
-	if c.svc.autoscaling == nil {
-		c.svc.autoscaling = autoscaling.New(c.svc.session)
+func (c *connector) GetNamedQueries(ctx context.Context, input *athena.ListNamedQueriesInput) (*athena.ListNamedQueriesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.athena == nil {
+		c.svc.athena = athena.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.autoscaling.DescribeAutoScalingGroupsWithContext(ctx, input)
+	opt, err := c.svc.athena.ListNamedQueriesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -446,12 +2285,14 @@ func (c *connector) GetAutoScalingGroups(ctx context.Context, input *autoscaling
 	return opt, nil
 }
 
-func (c *connector) GetLaunchConfigurations(ctx context.Context, input *autoscaling.DescribeLaunchConfigurationsInput) (*autoscaling.DescribeLaunchConfigurationsOutput, error) {
-	if c.svc.autoscaling == nil {
-		c.svc.autoscaling = autoscaling.New(c.svc.session)
+func (c *connector) GetRedshiftClusters(ctx context.Context, input *redshift.DescribeClustersInput) (*redshift.DescribeClustersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.redshift == nil {
+		c.svc.redshift = redshift.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.autoscaling.DescribeLaunchConfigurationsWithContext(ctx, input)
+	opt, err := c.svc.redshift.DescribeClustersWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -459,12 +2300,14 @@ func (c *connector) GetLaunchConfigurations(ctx context.Context, input *autoscal
 	return opt, nil
 }
 
-func (c *connector) GetElastiCacheClusters(ctx context.Context, input *elasticache.DescribeCacheClustersInput) (*elasticache.DescribeCacheClustersOutput, error) {
-	if c.svc.elasticache == nil {
-		c.svc.elasticache = elasticache.New(c.svc.session)
+func (c *connector) GetRedshiftParameterGroups(ctx context.Context, input *redshift.DescribeClusterParameterGroupsInput) (*redshift.DescribeClusterParameterGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.redshift == nil {
+		c.svc.redshift = redshift.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.elasticache.DescribeCacheClustersWithContext(ctx, input)
+	opt, err := c.svc.redshift.DescribeClusterParameterGroupsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -472,12 +2315,14 @@ func (c *connector) GetElastiCacheClusters(ctx context.Context, input *elasticac
 	return opt, nil
 }
 
-func (c *connector) GetElastiCacheTags(ctx context.Context, input *elasticache.ListTagsForResourceInput) (*elasticache.TagListMessage, error) {
-	if c.svc.elasticache == nil {
-		c.svc.elasticache = elasticache.New(c.svc.session)
+func (c *connector) GetRedshiftSubnetGroups(ctx context.Context, input *redshift.DescribeClusterSubnetGroupsInput) (*redshift.DescribeClusterSubnetGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.redshift == nil {
+		c.svc.redshift = redshift.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.elasticache.ListTagsForResourceWithContext(ctx, input)
+	opt, err := c.svc.redshift.DescribeClusterSubnetGroupsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -485,12 +2330,14 @@ func (c *connector) GetElastiCacheTags(ctx context.Context, input *elasticache.L
 	return opt, nil
 }
 
-func (c *connector) GetLoadBalancers(ctx context.Context, input *elb.DescribeLoadBalancersInput) (*elb.DescribeLoadBalancersOutput, error) {
-	if c.svc.elb == nil {
-		c.svc.elb = elb.New(c.svc.session)
+func (c *connector) GetMSKClusters(ctx context.Context, input *kafka.ListClustersInput) (*kafka.ListClustersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.kafka == nil {
+		c.svc.kafka = kafka.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.elb.DescribeLoadBalancersWithContext(ctx, input)
+	opt, err := c.svc.kafka.ListClustersWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -498,12 +2345,14 @@ func (c *connector) GetLoadBalancers(ctx context.Context, input *elb.DescribeLoa
 	return opt, nil
 }
 
-func (c *connector) GetLoadBalancersTags(ctx context.Context, input *elb.DescribeTagsInput) (*elb.DescribeTagsOutput, error) {
-	if c.svc.elb == nil {
-		c.svc.elb = elb.New(c.svc.session)
+func (c *connector) GetMSKConfigurations(ctx context.Context, input *kafka.ListConfigurationsInput) (*kafka.ListConfigurationsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.kafka == nil {
+		c.svc.kafka = kafka.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.elb.DescribeTagsWithContext(ctx, input)
+	opt, err := c.svc.kafka.ListConfigurationsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -511,12 +2360,14 @@ func (c *connector) GetLoadBalancersTags(ctx context.Context, input *elb.Describ
 	return opt, nil
 }
 
-func (c *connector) GetLoadBalancersV2(ctx context.Context, input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
-	if c.svc.elbv2 == nil {
-		c.svc.elbv2 = elbv2.New(c.svc.session)
+func (c *connector) GetTrails(ctx context.Context, input *cloudtrail.DescribeTrailsInput) (*cloudtrail.DescribeTrailsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cloudtrail == nil {
+		c.svc.cloudtrail = cloudtrail.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.elbv2.DescribeLoadBalancersWithContext(ctx, input)
+	opt, err := c.svc.cloudtrail.DescribeTrailsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -524,12 +2375,14 @@ func (c *connector) GetLoadBalancersV2(ctx context.Context, input *elbv2.Describ
 	return opt, nil
 }
 
-func (c *connector) GetLoadBalancersV2Tags(ctx context.Context, input *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
-	if c.svc.elbv2 == nil {
-		c.svc.elbv2 = elbv2.New(c.svc.session)
+func (c *connector) GetConfigurationRecorders(ctx context.Context, input *configservice.DescribeConfigurationRecordersInput) (*configservice.DescribeConfigurationRecordersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.configservice == nil {
+		c.svc.configservice = configservice.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.elbv2.DescribeTagsWithContext(ctx, input)
+	opt, err := c.svc.configservice.DescribeConfigurationRecordersWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -537,12 +2390,14 @@ func (c *connector) GetLoadBalancersV2Tags(ctx context.Context, input *elbv2.Des
 	return opt, nil
 }
 
-func (c *connector) GetDBInstances(ctx context.Context, input *rds.DescribeDBInstancesInput) (*rds.DescribeDBInstancesOutput, error) {
-	if c.svc.rds == nil {
-		c.svc.rds = rds.New(c.svc.session)
+func (c *connector) GetConfigRules(ctx context.Context, input *configservice.DescribeConfigRulesInput) (*configservice.DescribeConfigRulesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.configservice == nil {
+		c.svc.configservice = configservice.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.rds.DescribeDBInstancesWithContext(ctx, input)
+	opt, err := c.svc.configservice.DescribeConfigRulesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -550,12 +2405,14 @@ func (c *connector) GetDBInstances(ctx context.Context, input *rds.DescribeDBIns
 	return opt, nil
 }
 
-func (c *connector) GetDBInstancesTags(ctx context.Context, input *rds.ListTagsForResourceInput) (*rds.ListTagsForResourceOutput, error) {
-	if c.svc.rds == nil {
-		c.svc.rds = rds.New(c.svc.session)
+func (c *connector) GetGuardDutyDetectors(ctx context.Context, input *guardduty.ListDetectorsInput) (*guardduty.ListDetectorsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.guardduty == nil {
+		c.svc.guardduty = guardduty.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.rds.ListTagsForResourceWithContext(ctx, input)
+	opt, err := c.svc.guardduty.ListDetectorsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -563,12 +2420,14 @@ func (c *connector) GetDBInstancesTags(ctx context.Context, input *rds.ListTagsF
 	return opt, nil
 }
 
-func (c *connector) GetBucketTags(ctx context.Context, input *s3.GetBucketTaggingInput) (*s3.GetBucketTaggingOutput, error) {
-	if c.svc.s3 == nil {
-		c.svc.s3 = s3.New(c.svc.session)
+func (c *connector) GetGuardDutyMembers(ctx context.Context, input *guardduty.ListMembersInput) (*guardduty.ListMembersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.guardduty == nil {
+		c.svc.guardduty = guardduty.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.s3.GetBucketTaggingWithContext(ctx, input)
+	opt, err := c.svc.guardduty.ListMembersWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -576,12 +2435,14 @@ func (c *connector) GetBucketTags(ctx context.Context, input *s3.GetBucketTaggin
 	return opt, nil
 }
 
-func (c *connector) ListObjects(ctx context.Context, input *s3.ListObjectsInput) (*s3.ListObjectsOutput, error) {
-	if c.svc.s3 == nil {
-		c.svc.s3 = s3.New(c.svc.session)
+func (c *connector) GetCognitoUserPools(ctx context.Context, input *cognitoidentityprovider.ListUserPoolsInput) (*cognitoidentityprovider.ListUserPoolsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cognitoidentityprovider == nil {
+		c.svc.cognitoidentityprovider = cognitoidentityprovider.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.s3.ListObjectsWithContext(ctx, input)
+	opt, err := c.svc.cognitoidentityprovider.ListUserPoolsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -589,12 +2450,14 @@ func (c *connector) ListObjects(ctx context.Context, input *s3.ListObjectsInput)
 	return opt, nil
 }
 
-func (c *connector) GetObjectsTags(ctx context.Context, input *s3.GetObjectTaggingInput) (*s3.GetObjectTaggingOutput, error) {
-	if c.svc.s3 == nil {
-		c.svc.s3 = s3.New(c.svc.session)
+func (c *connector) GetCognitoUserPool(ctx context.Context, input *cognitoidentityprovider.DescribeUserPoolInput) (*cognitoidentityprovider.DescribeUserPoolOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cognitoidentityprovider == nil {
+		c.svc.cognitoidentityprovider = cognitoidentityprovider.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.s3.GetObjectTaggingWithContext(ctx, input)
+	opt, err := c.svc.cognitoidentityprovider.DescribeUserPoolWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -602,12 +2465,14 @@ func (c *connector) GetObjectsTags(ctx context.Context, input *s3.GetObjectTaggi
 	return opt, nil
 }
 
-func (c *connector) GetRecordedResourceCounts(ctx context.Context, input *configservice.GetDiscoveredResourceCountsInput) (*configservice.GetDiscoveredResourceCountsOutput, error) {
-	if c.svc.configservice == nil {
-		c.svc.configservice = configservice.New(c.svc.session)
+func (c *connector) GetCognitoUserPoolClients(ctx context.Context, input *cognitoidentityprovider.ListUserPoolClientsInput) (*cognitoidentityprovider.ListUserPoolClientsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cognitoidentityprovider == nil {
+		c.svc.cognitoidentityprovider = cognitoidentityprovider.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.configservice.GetDiscoveredResourceCountsWithContext(ctx, input)
+	opt, err := c.svc.cognitoidentityprovider.ListUserPoolClientsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -615,12 +2480,14 @@ func (c *connector) GetRecordedResourceCounts(ctx context.Context, input *config
 	return opt, nil
 }
 
-func (c *connector) GetCloudFrontDistributions(ctx context.Context, input *cloudfront.ListDistributionsInput) (*cloudfront.ListDistributionsOutput, error) {
-	if c.svc.cloudfront == nil {
-		c.svc.cloudfront = cloudfront.New(c.svc.session)
+func (c *connector) GetCognitoResourceServers(ctx context.Context, input *cognitoidentityprovider.ListResourceServersInput) (*cognitoidentityprovider.ListResourceServersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cognitoidentityprovider == nil {
+		c.svc.cognitoidentityprovider = cognitoidentityprovider.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.cloudfront.ListDistributionsWithContext(ctx, input)
+	opt, err := c.svc.cognitoidentityprovider.ListResourceServersWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -628,12 +2495,14 @@ func (c *connector) GetCloudFrontDistributions(ctx context.Context, input *cloud
 	return opt, nil
 }
 
-func (c *connector) GetCloudFrontPublicKeys(ctx context.Context, input *cloudfront.ListPublicKeysInput) (*cloudfront.ListPublicKeysOutput, error) {
-	if c.svc.cloudfront == nil {
-		c.svc.cloudfront = cloudfront.New(c.svc.session)
+func (c *connector) GetCognitoIdentityPools(ctx context.Context, input *cognitoidentity.ListIdentityPoolsInput) (*cognitoidentity.ListIdentityPoolsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cognitoidentity == nil {
+		c.svc.cognitoidentity = cognitoidentity.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.cloudfront.ListPublicKeysWithContext(ctx, input)
+	opt, err := c.svc.cognitoidentity.ListIdentityPoolsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -641,12 +2510,14 @@ func (c *connector) GetCloudFrontPublicKeys(ctx context.Context, input *cloudfro
 	return opt, nil
 }
 
-func (c *connector) GetCloudFrontOriginAccessIdentities(ctx context.Context, input *cloudfront.ListCloudFrontOriginAccessIdentitiesInput) (*cloudfront.ListCloudFrontOriginAccessIdentitiesOutput, error) {
-	if c.svc.cloudfront == nil {
-		c.svc.cloudfront = cloudfront.New(c.svc.session)
+func (c *connector) GetCognitoIdentityPoolRoles(ctx context.Context, input *cognitoidentity.GetIdentityPoolRolesInput) (*cognitoidentity.GetIdentityPoolRolesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.cognitoidentity == nil {
+		c.svc.cognitoidentity = cognitoidentity.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.cloudfront.ListCloudFrontOriginAccessIdentitiesWithContext(ctx, input)
+	opt, err := c.svc.cognitoidentity.GetIdentityPoolRolesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -654,12 +2525,14 @@ func (c *connector) GetCloudFrontOriginAccessIdentities(ctx context.Context, inp
 	return opt, nil
 }
 
-func (c *connector) GetAccessKeys(ctx context.Context, input *iam.ListAccessKeysInput) (*iam.ListAccessKeysOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetAppsyncGraphqlApis(ctx context.Context, input *appsync.ListGraphqlApisInput) (*appsync.ListGraphqlApisOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.appsync == nil {
+		c.svc.appsync = appsync.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListAccessKeysWithContext(ctx, input)
+	opt, err := c.svc.appsync.ListGraphqlApisWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -667,12 +2540,14 @@ func (c *connector) GetAccessKeys(ctx context.Context, input *iam.ListAccessKeys
 	return opt, nil
 }
 
-func (c *connector) GetAccountAliases(ctx context.Context, input *iam.ListAccountAliasesInput) (*iam.ListAccountAliasesOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetAppsyncIntrospectionSchema(ctx context.Context, input *appsync.GetIntrospectionSchemaInput) (*appsync.GetIntrospectionSchemaOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.appsync == nil {
+		c.svc.appsync = appsync.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListAccountAliasesWithContext(ctx, input)
+	opt, err := c.svc.appsync.GetIntrospectionSchemaWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -680,12 +2555,14 @@ func (c *connector) GetAccountAliases(ctx context.Context, input *iam.ListAccoun
 	return opt, nil
 }
 
-func (c *connector) GetAccountPasswordPolicy(ctx context.Context, input *iam.GetAccountPasswordPolicyInput) (*iam.GetAccountPasswordPolicyOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetAppsyncDataSources(ctx context.Context, input *appsync.ListDataSourcesInput) (*appsync.ListDataSourcesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.appsync == nil {
+		c.svc.appsync = appsync.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.GetAccountPasswordPolicyWithContext(ctx, input)
+	opt, err := c.svc.appsync.ListDataSourcesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -693,12 +2570,14 @@ func (c *connector) GetAccountPasswordPolicy(ctx context.Context, input *iam.Get
 	return opt, nil
 }
 
-func (c *connector) GetGroups(ctx context.Context, input *iam.ListGroupsInput) (*iam.ListGroupsOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetAppsyncTypes(ctx context.Context, input *appsync.ListTypesInput) (*appsync.ListTypesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.appsync == nil {
+		c.svc.appsync = appsync.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListGroupsWithContext(ctx, input)
+	opt, err := c.svc.appsync.ListTypesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -706,12 +2585,14 @@ func (c *connector) GetGroups(ctx context.Context, input *iam.ListGroupsInput) (
 	return opt, nil
 }
 
-func (c *connector) GetGroupPolicies(ctx context.Context, input *iam.ListGroupPoliciesInput) (*iam.ListGroupPoliciesOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetAppsyncResolvers(ctx context.Context, input *appsync.ListResolversInput) (*appsync.ListResolversOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.appsync == nil {
+		c.svc.appsync = appsync.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListGroupPoliciesWithContext(ctx, input)
+	opt, err := c.svc.appsync.ListResolversWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -719,12 +2600,14 @@ func (c *connector) GetGroupPolicies(ctx context.Context, input *iam.ListGroupPo
 	return opt, nil
 }
 
-func (c *connector) GetAttachedGroupPolicies(ctx context.Context, input *iam.ListAttachedGroupPoliciesInput) (*iam.ListAttachedGroupPoliciesOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetEFSFileSystems(ctx context.Context, input *efs.DescribeFileSystemsInput) (*efs.DescribeFileSystemsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.efs == nil {
+		c.svc.efs = efs.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListAttachedGroupPoliciesWithContext(ctx, input)
+	opt, err := c.svc.efs.DescribeFileSystemsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -732,12 +2615,14 @@ func (c *connector) GetAttachedGroupPolicies(ctx context.Context, input *iam.Lis
 	return opt, nil
 }
 
-func (c *connector) GetInstanceProfiles(ctx context.Context, input *iam.ListInstanceProfilesInput) (*iam.ListInstanceProfilesOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetEFSMountTargets(ctx context.Context, input *efs.DescribeMountTargetsInput) (*efs.DescribeMountTargetsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.efs == nil {
+		c.svc.efs = efs.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListInstanceProfilesWithContext(ctx, input)
+	opt, err := c.svc.efs.DescribeMountTargetsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -745,12 +2630,14 @@ func (c *connector) GetInstanceProfiles(ctx context.Context, input *iam.ListInst
 	return opt, nil
 }
 
-func (c *connector) GetOpenIDConnectProviders(ctx context.Context, input *iam.ListOpenIDConnectProvidersInput) (*iam.ListOpenIDConnectProvidersOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetFSxFileSystems(ctx context.Context, input *fsx.DescribeFileSystemsInput) (*fsx.DescribeFileSystemsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.fsx == nil {
+		c.svc.fsx = fsx.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListOpenIDConnectProvidersWithContext(ctx, input)
+	opt, err := c.svc.fsx.DescribeFileSystemsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -758,12 +2645,14 @@ func (c *connector) GetOpenIDConnectProviders(ctx context.Context, input *iam.Li
 	return opt, nil
 }
 
-func (c *connector) GetPolicies(ctx context.Context, input *iam.ListPoliciesInput) (*iam.ListPoliciesOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetTaggedResources(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.resourcegroupstaggingapi == nil {
+		c.svc.resourcegroupstaggingapi = resourcegroupstaggingapi.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListPoliciesWithContext(ctx, input)
+	opt, err := c.svc.resourcegroupstaggingapi.GetResourcesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -771,12 +2660,14 @@ func (c *connector) GetPolicies(ctx context.Context, input *iam.ListPoliciesInpu
 	return opt, nil
 }
 
-func (c *connector) GetRoles(ctx context.Context, input *iam.ListRolesInput) (*iam.ListRolesOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetStateMachines(ctx context.Context, input *sfn.ListStateMachinesInput) (*sfn.ListStateMachinesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.sfn == nil {
+		c.svc.sfn = sfn.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListRolesWithContext(ctx, input)
+	opt, err := c.svc.sfn.ListStateMachinesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -784,12 +2675,14 @@ func (c *connector) GetRoles(ctx context.Context, input *iam.ListRolesInput) (*i
 	return opt, nil
 }
 
-func (c *connector) GetRolePolicies(ctx context.Context, input *iam.ListRolePoliciesInput) (*iam.ListRolePoliciesOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetOrganization(ctx context.Context, input *organizations.DescribeOrganizationInput) (*organizations.DescribeOrganizationOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.organizations == nil {
+		c.svc.organizations = organizations.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListRolePoliciesWithContext(ctx, input)
+	opt, err := c.svc.organizations.DescribeOrganizationWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -797,12 +2690,14 @@ func (c *connector) GetRolePolicies(ctx context.Context, input *iam.ListRolePoli
 	return opt, nil
 }
 
-func (c *connector) GetAttachedRolePolicies(ctx context.Context, input *iam.ListAttachedRolePoliciesInput) (*iam.ListAttachedRolePoliciesOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetOrganizationsAccounts(ctx context.Context, input *organizations.ListAccountsInput) (*organizations.ListAccountsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.organizations == nil {
+		c.svc.organizations = organizations.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListAttachedRolePoliciesWithContext(ctx, input)
+	opt, err := c.svc.organizations.ListAccountsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -810,12 +2705,14 @@ func (c *connector) GetAttachedRolePolicies(ctx context.Context, input *iam.List
 	return opt, nil
 }
 
-func (c *connector) GetSAMLProviders(ctx context.Context, input *iam.ListSAMLProvidersInput) (*iam.ListSAMLProvidersOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetOrganizationsRoots(ctx context.Context, input *organizations.ListRootsInput) (*organizations.ListRootsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.organizations == nil {
+		c.svc.organizations = organizations.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListSAMLProvidersWithContext(ctx, input)
+	opt, err := c.svc.organizations.ListRootsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -823,12 +2720,14 @@ func (c *connector) GetSAMLProviders(ctx context.Context, input *iam.ListSAMLPro
 	return opt, nil
 }
 
-func (c *connector) GetServerCertificates(ctx context.Context, input *iam.ListServerCertificatesInput) (*iam.ListServerCertificatesOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetOrganizationsOrganizationalUnits(ctx context.Context, input *organizations.ListOrganizationalUnitsForParentInput) (*organizations.ListOrganizationalUnitsForParentOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.organizations == nil {
+		c.svc.organizations = organizations.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListServerCertificatesWithContext(ctx, input)
+	opt, err := c.svc.organizations.ListOrganizationalUnitsForParentWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -836,12 +2735,14 @@ func (c *connector) GetServerCertificates(ctx context.Context, input *iam.ListSe
 	return opt, nil
 }
 
-func (c *connector) GetUsers(ctx context.Context, input *iam.ListUsersInput) (*iam.ListUsersOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetOrganizationsPolicies(ctx context.Context, input *organizations.ListPoliciesInput) (*organizations.ListPoliciesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.organizations == nil {
+		c.svc.organizations = organizations.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListUsersWithContext(ctx, input)
+	opt, err := c.svc.organizations.ListPoliciesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -849,12 +2750,14 @@ func (c *connector) GetUsers(ctx context.Context, input *iam.ListUsersInput) (*i
 	return opt, nil
 }
 
-func (c *connector) GetUserPolicies(ctx context.Context, input *iam.ListUserPoliciesInput) (*iam.ListUserPoliciesOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetOrganizationsTargetsForPolicy(ctx context.Context, input *organizations.ListTargetsForPolicyInput) (*organizations.ListTargetsForPolicyOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.organizations == nil {
+		c.svc.organizations = organizations.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListUserPoliciesWithContext(ctx, input)
+	opt, err := c.svc.organizations.ListTargetsForPolicyWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -862,12 +2765,14 @@ func (c *connector) GetUserPolicies(ctx context.Context, input *iam.ListUserPoli
 	return opt, nil
 }
 
-func (c *connector) GetAttachedUserPolicies(ctx context.Context, input *iam.ListAttachedUserPoliciesInput) (*iam.ListAttachedUserPoliciesOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetBatchComputeEnvironments(ctx context.Context, input *batch.DescribeComputeEnvironmentsInput) (*batch.DescribeComputeEnvironmentsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.batch == nil {
+		c.svc.batch = batch.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.ListAttachedUserPoliciesWithContext(ctx, input)
+	opt, err := c.svc.batch.DescribeComputeEnvironmentsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -875,12 +2780,14 @@ func (c *connector) GetAttachedUserPolicies(ctx context.Context, input *iam.List
 	return opt, nil
 }
 
-func (c *connector) GetSSHPublicKey(ctx context.Context, input *iam.GetSSHPublicKeyInput) (*iam.GetSSHPublicKeyOutput, error) {
-	if c.svc.iam == nil {
-		c.svc.iam = iam.New(c.svc.session)
+func (c *connector) GetBatchJobQueues(ctx context.Context, input *batch.DescribeJobQueuesInput) (*batch.DescribeJobQueuesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.batch == nil {
+		c.svc.batch = batch.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.iam.GetSSHPublicKeyWithContext(ctx, input)
+	opt, err := c.svc.batch.DescribeJobQueuesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -888,12 +2795,14 @@ func (c *connector) GetSSHPublicKey(ctx context.Context, input *iam.GetSSHPublic
 	return opt, nil
 }
 
-func (c *connector) GetActiveReceiptRuleSet(ctx context.Context, input *ses.DescribeActiveReceiptRuleSetInput) (*ses.DescribeActiveReceiptRuleSetOutput, error) {
-	if c.svc.ses == nil {
-		c.svc.ses = ses.New(c.svc.session)
+func (c *connector) GetBatchJobDefinitions(ctx context.Context, input *batch.DescribeJobDefinitionsInput) (*batch.DescribeJobDefinitionsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.batch == nil {
+		c.svc.batch = batch.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ses.DescribeActiveReceiptRuleSetWithContext(ctx, input)
+	opt, err := c.svc.batch.DescribeJobDefinitionsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -901,12 +2810,14 @@ func (c *connector) GetActiveReceiptRuleSet(ctx context.Context, input *ses.Desc
 	return opt, nil
 }
 
-func (c *connector) GetIdentities(ctx context.Context, input *ses.ListIdentitiesInput) (*ses.ListIdentitiesOutput, error) {
-	if c.svc.ses == nil {
-		c.svc.ses = ses.New(c.svc.session)
+func (c *connector) GetEMRClusters(ctx context.Context, input *emr.ListClustersInput) (*emr.ListClustersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.emr == nil {
+		c.svc.emr = emr.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ses.ListIdentitiesWithContext(ctx, input)
+	opt, err := c.svc.emr.ListClustersWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -914,12 +2825,14 @@ func (c *connector) GetIdentities(ctx context.Context, input *ses.ListIdentities
 	return opt, nil
 }
 
-func (c *connector) GetReceiptFilters(ctx context.Context, input *ses.ListReceiptFiltersInput) (*ses.ListReceiptFiltersOutput, error) {
-	if c.svc.ses == nil {
-		c.svc.ses = ses.New(c.svc.session)
+func (c *connector) GetSagemakerNotebookInstances(ctx context.Context, input *sagemaker.ListNotebookInstancesInput) (*sagemaker.ListNotebookInstancesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.sagemaker == nil {
+		c.svc.sagemaker = sagemaker.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ses.ListReceiptFiltersWithContext(ctx, input)
+	opt, err := c.svc.sagemaker.ListNotebookInstancesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -927,12 +2840,14 @@ func (c *connector) GetReceiptFilters(ctx context.Context, input *ses.ListReceip
 	return opt, nil
 }
 
-func (c *connector) GetConfigurationSets(ctx context.Context, input *ses.ListConfigurationSetsInput) (*ses.ListConfigurationSetsOutput, error) {
-	if c.svc.ses == nil {
-		c.svc.ses = ses.New(c.svc.session)
+func (c *connector) GetSagemakerEndpoints(ctx context.Context, input *sagemaker.ListEndpointsInput) (*sagemaker.ListEndpointsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.sagemaker == nil {
+		c.svc.sagemaker = sagemaker.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ses.ListConfigurationSetsWithContext(ctx, input)
+	opt, err := c.svc.sagemaker.ListEndpointsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -940,12 +2855,14 @@ func (c *connector) GetConfigurationSets(ctx context.Context, input *ses.ListCon
 	return opt, nil
 }
 
-func (c *connector) GetIdentityNotificationAttributes(ctx context.Context, input *ses.GetIdentityNotificationAttributesInput) (*ses.GetIdentityNotificationAttributesOutput, error) {
-	if c.svc.ses == nil {
-		c.svc.ses = ses.New(c.svc.session)
+func (c *connector) GetGlobalacceleratorAccelerators(ctx context.Context, input *globalaccelerator.ListAcceleratorsInput) (*globalaccelerator.ListAcceleratorsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.globalaccelerator == nil {
+		c.svc.globalaccelerator = globalaccelerator.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ses.GetIdentityNotificationAttributesWithContext(ctx, input)
+	opt, err := c.svc.globalaccelerator.ListAcceleratorsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -953,12 +2870,14 @@ func (c *connector) GetIdentityNotificationAttributes(ctx context.Context, input
 	return opt, nil
 }
 
-func (c *connector) GetTemplates(ctx context.Context, input *ses.ListTemplatesInput) (*ses.ListTemplatesOutput, error) {
-	if c.svc.ses == nil {
-		c.svc.ses = ses.New(c.svc.session)
+func (c *connector) GetGlobalacceleratorListeners(ctx context.Context, input *globalaccelerator.ListListenersInput) (*globalaccelerator.ListListenersOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.globalaccelerator == nil {
+		c.svc.globalaccelerator = globalaccelerator.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.ses.ListTemplatesWithContext(ctx, input)
+	opt, err := c.svc.globalaccelerator.ListListenersWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -966,12 +2885,14 @@ func (c *connector) GetTemplates(ctx context.Context, input *ses.ListTemplatesIn
 	return opt, nil
 }
 
-func (c *connector) GetReusableDelegationSets(ctx context.Context, input *route53.ListReusableDelegationSetsInput) (*route53.ListReusableDelegationSetsOutput, error) {
-	if c.svc.route53 == nil {
-		c.svc.route53 = route53.New(c.svc.session)
+func (c *connector) GetGlobalacceleratorEndpointGroups(ctx context.Context, input *globalaccelerator.ListEndpointGroupsInput) (*globalaccelerator.ListEndpointGroupsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.globalaccelerator == nil {
+		c.svc.globalaccelerator = globalaccelerator.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.route53.ListReusableDelegationSetsWithContext(ctx, input)
+	opt, err := c.svc.globalaccelerator.ListEndpointGroupsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -979,12 +2900,14 @@ func (c *connector) GetReusableDelegationSets(ctx context.Context, input *route5
 	return opt, nil
 }
 
-func (c *connector) GetHealthChecks(ctx context.Context, input *route53.ListHealthChecksInput) (*route53.ListHealthChecksOutput, error) {
-	if c.svc.route53 == nil {
-		c.svc.route53 = route53.New(c.svc.session)
+func (c *connector) GetKMSAliases(ctx context.Context, input *kms.ListAliasesInput) (*kms.ListAliasesOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.kms == nil {
+		c.svc.kms = kms.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.route53.ListHealthChecksWithContext(ctx, input)
+	opt, err := c.svc.kms.ListAliasesWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -992,12 +2915,14 @@ func (c *connector) GetHealthChecks(ctx context.Context, input *route53.ListHeal
 	return opt, nil
 }
 
-func (c *connector) GetQueryLoggingConfigs(ctx context.Context, input *route53.ListQueryLoggingConfigsInput) (*route53.ListQueryLoggingConfigsOutput, error) {
-	if c.svc.route53 == nil {
-		c.svc.route53 = route53.New(c.svc.session)
+func (c *connector) GetBackupVaults(ctx context.Context, input *backup.ListBackupVaultsInput) (*backup.ListBackupVaultsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.backup == nil {
+		c.svc.backup = backup.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.route53.ListQueryLoggingConfigsWithContext(ctx, input)
+	opt, err := c.svc.backup.ListBackupVaultsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -1005,12 +2930,14 @@ func (c *connector) GetQueryLoggingConfigs(ctx context.Context, input *route53.L
 	return opt, nil
 }
 
-func (c *connector) GetResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
-	if c.svc.route53 == nil {
-		c.svc.route53 = route53.New(c.svc.session)
+func (c *connector) GetBackupPlans(ctx context.Context, input *backup.ListBackupPlansInput) (*backup.ListBackupPlansOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.backup == nil {
+		c.svc.backup = backup.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.route53.ListResourceRecordSetsWithContext(ctx, input)
+	opt, err := c.svc.backup.ListBackupPlansWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -1018,12 +2945,14 @@ func (c *connector) GetResourceRecordSets(ctx context.Context, input *route53.Li
 	return opt, nil
 }
 
-func (c *connector) GetHostedZones(ctx context.Context, input *route53.ListHostedZonesInput) (*route53.ListHostedZonesOutput, error) {
-	if c.svc.route53 == nil {
-		c.svc.route53 = route53.New(c.svc.session)
+func (c *connector) GetBackupSelections(ctx context.Context, input *backup.ListBackupSelectionsInput) (*backup.ListBackupSelectionsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.backup == nil {
+		c.svc.backup = backup.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.route53.ListHostedZonesWithContext(ctx, input)
+	opt, err := c.svc.backup.ListBackupSelectionsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -1031,12 +2960,14 @@ func (c *connector) GetHostedZones(ctx context.Context, input *route53.ListHoste
 	return opt, nil
 }
 
-func (c *connector) GetVPCAssociationAuthorizations(ctx context.Context, input *route53.ListVPCAssociationAuthorizationsInput) (*route53.ListVPCAssociationAuthorizationsOutput, error) {
-	if c.svc.route53 == nil {
-		c.svc.route53 = route53.New(c.svc.session)
+func (c *connector) GetElasticBeanstalkApplications(ctx context.Context, input *elasticbeanstalk.DescribeApplicationsInput) (*elasticbeanstalk.DescribeApplicationsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elasticbeanstalk == nil {
+		c.svc.elasticbeanstalk = elasticbeanstalk.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.route53.ListVPCAssociationAuthorizationsWithContext(ctx, input)
+	opt, err := c.svc.elasticbeanstalk.DescribeApplicationsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -1044,12 +2975,14 @@ func (c *connector) GetVPCAssociationAuthorizations(ctx context.Context, input *
 	return opt, nil
 }
 
-func (c *connector) GetResolverEndpoints(ctx context.Context, input *route53resolver.ListResolverEndpointsInput) (*route53resolver.ListResolverEndpointsOutput, error) {
-	if c.svc.route53resolver == nil {
-		c.svc.route53resolver = route53resolver.New(c.svc.session)
+func (c *connector) GetElasticBeanstalkEnvironments(ctx context.Context, input *elasticbeanstalk.DescribeEnvironmentsInput) (*elasticbeanstalk.EnvironmentDescriptionsMessage, error) {
+	c.svc.mu.Lock()
+	if c.svc.elasticbeanstalk == nil {
+		c.svc.elasticbeanstalk = elasticbeanstalk.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.route53resolver.ListResolverEndpointsWithContext(ctx, input)
+	opt, err := c.svc.elasticbeanstalk.DescribeEnvironmentsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -1057,12 +2990,14 @@ func (c *connector) GetResolverEndpoints(ctx context.Context, input *route53reso
 	return opt, nil
 }
 
-func (c *connector) GetResolverRules(ctx context.Context, input *route53resolver.ListResolverRulesInput) (*route53resolver.ListResolverRulesOutput, error) {
-	if c.svc.route53resolver == nil {
-		c.svc.route53resolver = route53resolver.New(c.svc.session)
+func (c *connector) GetElasticBeanstalkConfigurationSettings(ctx context.Context, input *elasticbeanstalk.DescribeConfigurationSettingsInput) (*elasticbeanstalk.DescribeConfigurationSettingsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elasticbeanstalk == nil {
+		c.svc.elasticbeanstalk = elasticbeanstalk.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.route53resolver.ListResolverRulesWithContext(ctx, input)
+	opt, err := c.svc.elasticbeanstalk.DescribeConfigurationSettingsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -1070,12 +3005,14 @@ func (c *connector) GetResolverRules(ctx context.Context, input *route53resolver
 	return opt, nil
 }
 
-func (c *connector) GetResolverRuleAssociations(ctx context.Context, input *route53resolver.ListResolverRuleAssociationsInput) (*route53resolver.ListResolverRuleAssociationsOutput, error) {
-	if c.svc.route53resolver == nil {
-		c.svc.route53resolver = route53resolver.New(c.svc.session)
+func (c *connector) GetElasticBeanstalkConfigurationOptions(ctx context.Context, input *elasticbeanstalk.DescribeConfigurationOptionsInput) (*elasticbeanstalk.DescribeConfigurationOptionsOutput, error) {
+	c.svc.mu.Lock()
+	if c.svc.elasticbeanstalk == nil {
+		c.svc.elasticbeanstalk = elasticbeanstalk.New(c.svc.session)
 	}
+	c.svc.mu.Unlock()
 
-	opt, err := c.svc.route53resolver.ListResolverRuleAssociationsWithContext(ctx, input)
+	opt, err := c.svc.elasticbeanstalk.DescribeConfigurationOptionsWithContext(ctx, input)
 	if err != nil {
 		return nil, err
 	}
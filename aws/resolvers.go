@@ -0,0 +1,174 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// resolvers are the resolvers of the IDs of the
+// attributes of the resources by the name of them
+var resolvers = map[string]provider.ResolverFunc{
+	"ami":               resolveAMI,
+	"image_id":          resolveAMI,
+	"kms_key_id":        resolveKMSKey,
+	"kms_key_arn":       resolveKMSKey,
+	"kms_master_key_id": resolveKMSKey,
+	"account_id":        resolveAccount,
+	"owner_id":          resolveAccount,
+	"peer_owner_id":     resolveAccount,
+	"source_account":    resolveAccount,
+}
+
+// Resolvers returns the resolvers of the AMIs,
+// KMS keys and accounts IDs of the resources
+func (a *aws) Resolvers() map[string]provider.ResolverFunc { return resolvers }
+
+// accountIDRe matches the IDs of the accounts
+var accountIDRe = regexp.MustCompile(`^\d{12}$`)
+
+// resolveAMI resolves the id of an AMI with the name of it,
+// looked up by the name and the owner with the aws_ami
+func resolveAMI(ctx context.Context, p provider.Provider, attr, id string) (*provider.Resolution, error) {
+	if !strings.HasPrefix(id, "ami-") {
+		return nil, nil
+	}
+
+	a := p.(*aws)
+	images, err := a.awsr.GetImages(ctx, &ec2.DescribeImagesInput{
+		ImageIds: awsSDK.StringSlice([]string{id}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(images.Images) == 0 {
+		return nil, nil
+	}
+
+	i := images.Images[0]
+	name := awsSDK.StringValue(i.Name)
+	owner := awsSDK.StringValue(i.OwnerId)
+	if name == "" {
+		return nil, nil
+	}
+
+	by := owner
+	if alias := awsSDK.StringValue(i.ImageOwnerAlias); alias != "" {
+		by = alias
+	}
+
+	return &provider.Resolution{
+		Comment:  fmt.Sprintf("the AMI %s of %s", name, by),
+		DataType: "aws_ami",
+		DataName: name,
+		Data: map[string]interface{}{
+			"owners": []interface{}{owner},
+			"filter": []interface{}{
+				map[string]interface{}{
+					"name":   "name",
+					"values": []interface{}{name},
+				},
+			},
+		},
+		DataAttribute: "id",
+	}, nil
+}
+
+// resolveKMSKey resolves the id, or ARN, of a KMS key with the
+// first alias of it, looked up by the alias with the aws_kms_key
+func resolveKMSKey(ctx context.Context, p provider.Provider, attr, id string) (*provider.Resolution, error) {
+	// The aliases are already readable
+	if strings.HasPrefix(id, "alias/") || strings.Contains(id, ":alias/") {
+		return nil, nil
+	}
+
+	a := p.(*aws)
+	aliases, err := a.awsr.GetKMSAliases(ctx, &kms.ListAliasesInput{
+		KeyId: awsSDK.String(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(aliases.Aliases))
+	for _, al := range aliases.Aliases {
+		names = append(names, awsSDK.StringValue(al.AliasName))
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	sort.Strings(names)
+
+	// The ARNs are on the arn of the data
+	// source and the key IDs on the id
+	attribute := "id"
+	if strings.HasPrefix(id, "arn:") {
+		attribute = "arn"
+	}
+
+	return &provider.Resolution{
+		Comment:  fmt.Sprintf("the KMS key %s", names[0]),
+		DataType: "aws_kms_key",
+		DataName: strings.TrimPrefix(names[0], "alias/"),
+		Data: map[string]interface{}{
+			"key_id": names[0],
+		},
+		DataAttribute: attribute,
+	}, nil
+}
+
+// resolveAccount resolves the id of an account, the one used with
+// the IAM alias of it and the aws_caller_identity and the others
+// with the name of them on the Organization, if it can be listed
+func resolveAccount(ctx context.Context, p provider.Provider, attr, id string) (*provider.Resolution, error) {
+	if !accountIDRe.MatchString(id) {
+		return nil, nil
+	}
+
+	a := p.(*aws)
+	if id == a.awsr.GetAccountID() {
+		name := "the current account"
+		aliases, err := a.awsr.GetAccountAliases(ctx, &iam.ListAccountAliasesInput{})
+		if err == nil && len(aliases.AccountAliases) != 0 {
+			name = fmt.Sprintf("the current account %s", awsSDK.StringValue(aliases.AccountAliases[0]))
+		}
+
+		return &provider.Resolution{
+			Comment:       name,
+			DataType:      "aws_caller_identity",
+			DataName:      "current",
+			Data:          map[string]interface{}{},
+			DataAttribute: "account_id",
+		}, nil
+	}
+
+	input := &organizations.ListAccountsInput{}
+	for {
+		accounts, err := a.awsr.GetOrganizationsAccounts(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ac := range accounts.Accounts {
+			if awsSDK.StringValue(ac.Id) == id {
+				return &provider.Resolution{
+					Comment: fmt.Sprintf("the account %s of the Organization", awsSDK.StringValue(ac.Name)),
+				}, nil
+			}
+		}
+
+		if awsSDK.StringValue(accounts.NextToken) == "" {
+			return nil, nil
+		}
+		input.NextToken = accounts.NextToken
+	}
+}
@@ -4,14 +4,38 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/appsync"
+	"github.com/aws/aws-sdk-go/service/backup"
+	"github.com/aws/aws-sdk-go/service/batch"
+	"github.com/aws/aws-sdk-go/service/cloudwatchevents"
+	"github.com/aws/aws-sdk-go/service/codedeploy"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/elasticbeanstalk"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/emr"
+	"github.com/aws/aws-sdk-go/service/fsx"
+	"github.com/aws/aws-sdk-go/service/globalaccelerator"
+	"github.com/aws/aws-sdk-go/service/glue"
+	"github.com/aws/aws-sdk-go/service/guardduty"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sagemaker"
 	"github.com/aws/aws-sdk-go/service/ses"
+	"github.com/cycloidio/terracognita/log"
 	"github.com/cycloidio/terracognita/provider"
 	"github.com/cycloidio/terracognita/tag"
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
 )
 
 // ResourceType is the type used to define all the Resources
@@ -36,10 +60,25 @@ const (
 	// but works
 	//EBSSnapshot
 	ElasticacheCluster
+	ElasticacheReplicationGroup
+	ElasticacheParameterGroup
+	ElasticacheSubnetGroup
 	ELB
 	ALB
+	LB                      // lb
+	LBListener              // lb_listener
+	LBListenerRule          // lb_listener_rule
+	LBTargetGroup           // lb_target_group
+	LBTargetGroupAttachment // lb_target_group_attachment
 	DBInstance
+	DBParameterGroup
+	DBOptionGroup
+	DBSubnetGroup
+	RDSCluster
+	RDSClusterInstance
+	RDSClusterParameterGroup
 	S3Bucket
+	S3BucketPublicAccessBlock
 	//S3BucketObject
 	CloudfrontDistribution
 	CloudfrontOriginAccessIdentity
@@ -91,6 +130,83 @@ const (
 	LaunchConfiguration
 	LaunchTemplate
 	AutoscalingGroup
+	AutoscalingPolicy
+	AutoscalingSchedule
+	TransitGateway                      // ec2_transit_gateway
+	TransitGatewayVpcAttachment         // ec2_transit_gateway_vpc_attachment
+	TransitGatewayRouteTable            // ec2_transit_gateway_route_table
+	TransitGatewayRouteTableAssociation // ec2_transit_gateway_route_table_association
+	TransitGatewayRouteTablePropagation // ec2_transit_gateway_route_table_propagation
+	VPCPeeringConnection                // vpc_peering_connection
+	CloudwatchMetricAlarm
+	CloudwatchDashboard
+	CloudwatchLogGroup
+	CloudwatchLogMetricFilter
+	CloudwatchEventRule
+	CloudwatchEventTarget
+	SfnStateMachine
+	AcmCertificate
+	AcmCertificateValidation
+	SecretsmanagerSecret
+	SSMParameter
+	Codepipeline              // codepipeline
+	CodebuildProject          // codebuild_project
+	CodedeployApp             // codedeploy_app
+	CodedeployDeploymentGroup // codedeploy_deployment_group
+	GlueCatalogDatabase
+	GlueCatalogTable
+	GlueJob
+	AthenaWorkgroup
+	AthenaNamedQuery
+	RedshiftCluster
+	RedshiftParameterGroup
+	RedshiftSubnetGroup
+	MSKCluster       // msk_cluster
+	MSKConfiguration // msk_configuration
+	Cloudtrail
+	ConfigConfigurationRecorder
+	ConfigConfigRule
+	GuarddutyDetector
+	GuarddutyMember
+	InternetGateway
+	NatGateway
+	RouteTable
+	RouteTableAssociation
+	NetworkACL           // network_acl
+	NetworkACLRule       // network_acl_rule
+	VPCEndpoint          // vpc_endpoint
+	EFSFileSystem        // efs_file_system
+	EFSMountTarget       // efs_mount_target
+	FSxLustreFileSystem  // fsx_lustre_file_system
+	FSxWindowsFileSystem // fsx_windows_file_system
+	CognitoUserPool
+	CognitoUserPoolClient
+	CognitoUserPoolDomain
+	CognitoResourceServer
+	CognitoIdentityPool
+	CognitoIdentityPoolRolesAttachment
+	AppsyncGraphqlAPI // appsync_graphql_api
+	AppsyncDatasource
+	AppsyncResolver
+	OrganizationsOrganization
+	OrganizationsAccount
+	OrganizationsOrganizationalUnit
+	OrganizationsPolicy
+	OrganizationsPolicyAttachment
+	BatchComputeEnvironment
+	BatchJobQueue
+	BatchJobDefinition
+	EMRCluster // emr_cluster
+	SagemakerNotebookInstance
+	SagemakerEndpoint
+	GlobalacceleratorAccelerator
+	GlobalacceleratorListener
+	GlobalacceleratorEndpointGroup
+	BackupVault
+	BackupPlan
+	BackupSelection
+	ElasticBeanstalkApplication
+	ElasticBeanstalkEnvironment
 )
 
 type rtFn func(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error)
@@ -104,56 +220,148 @@ var (
 		Subnet:        subnets,
 		EBSVolume:     ebsVolumes,
 		//EBSSnapshot:         ebsSnapshots,
-		ElasticacheCluster: elasticacheClusters,
-		ELB:                elbs,
-		ALB:                albs,
-		DBInstance:         dbInstances,
-		S3Bucket:           s3Buckets,
+		ElasticacheCluster:          elasticacheClusters,
+		ElasticacheReplicationGroup: elasticacheReplicationGroups,
+		ElasticacheParameterGroup:   elasticacheParameterGroups,
+		ElasticacheSubnetGroup:      elasticacheSubnetGroups,
+		ELB:                         elbs,
+		ALB:                         albs,
+		LB:                          albs,
+		LBListener:                  lbListeners,
+		LBListenerRule:              lbListenerRules,
+		LBTargetGroup:               lbTargetGroups,
+		LBTargetGroupAttachment:     lbTargetGroupAttachments,
+		DBInstance:                  dbInstances,
+		DBParameterGroup:            dbParameterGroups,
+		DBOptionGroup:               dbOptionGroups,
+		DBSubnetGroup:               dbSubnetGroups,
+		RDSCluster:                  rdsClusters,
+		RDSClusterInstance:          rdsClusterInstances,
+		RDSClusterParameterGroup:    rdsClusterParameterGroups,
+		S3Bucket:                    s3Buckets,
+		S3BucketPublicAccessBlock:   s3BucketPublicAccessBlocks,
 		//S3BucketObject:      s3_bucket_objects,
 		CloudfrontDistribution:         cloudfrontDistributions,
 		CloudfrontOriginAccessIdentity: cloudfrontOriginAccessIdentities,
 		CloudfrontPublicKey:            cloudfrontPublicKeys,
 		//IAMAccessKey:                   iamAccessKeys,
-		IAMAccountAlias:                iamAccountAliases,
-		IAMAccountPasswordPolicy:       iamAccountPasswordPolicy,
-		IAMGroup:                       cacheIAMGroups,
-		IAMGroupMembership:             iamGroupMemberships,
-		IAMGroupPolicy:                 iamGroupPolicies,
-		IAMGroupPolicyAttachment:       iamGroupPolicyAttachments,
-		IAMInstanceProfile:             iamInstanceProfiles,
-		IAMOpenidConnectProvider:       iamOpenidConnectProviders,
-		IAMPolicy:                      iamPolicies,
-		IAMRole:                        cacheIAMRoles,
-		IAMRolePolicy:                  iamRolePolicies,
-		IAMRolePolicyAttachment:        iamRolePolicyAttachments,
-		IAMSAMLProvider:                iamSAMLProviders,
-		IAMServerCertificate:           iamServerCertificates,
-		IAMUser:                        cacheIAMUsers,
-		IAMUserGroupMembership:         iamUserGroupMemberships,
-		IAMUserPolicy:                  iamUserPolicies,
-		IAMUserPolicyAttachment:        iamUserPolicyAttachments,
-		Route53DelegationSet:           route53DelegationSets,
-		Route53HealthCheck:             route53HealthChecks,
-		Route53QueryLog:                route53QueryLogs,
-		Route53Record:                  route53Records,
-		Route53Zone:                    cacheRoute53Zones,
-		Route53ZoneAssociation:         route53ZoneAssociations,
-		Route53ResolverEndpoint:        route53ResolverEndpoints,
-		Route53ResolverRuleAssociation: route53ResolverRuleAssociation,
-		SESActiveReceiptRuleSet:        sesActiveReceiptRuleSets,
-		SESDomainIdentity:              cacheSESDomainIdentities,
-		SESDomainIdentityVerification:  sesDomainGeneral,
-		SESDomainDKIM:                  sesDomainGeneral,
-		SESDomainMailFrom:              sesDomainGeneral,
-		SESReceiptFilter:               sesReceiptFilters,
-		SESReceiptRule:                 sesReceiptRules,
-		SESReceiptRuleSet:              sesReceiptRuleSets,
-		SESConfigurationSet:            sesConfigurationSets,
-		SESIdentityNotificationTopic:   sesIdentityNotificationTopics,
-		SESTemplate:                    sesTemplates,
-		LaunchConfiguration:            launchConfigurations,
-		LaunchTemplate:                 launchtemplates,
-		AutoscalingGroup:               autoscalinggroups,
+		IAMAccountAlias:                     iamAccountAliases,
+		IAMAccountPasswordPolicy:            iamAccountPasswordPolicy,
+		IAMGroup:                            cacheIAMGroups,
+		IAMGroupMembership:                  iamGroupMemberships,
+		IAMGroupPolicy:                      iamGroupPolicies,
+		IAMGroupPolicyAttachment:            iamGroupPolicyAttachments,
+		IAMInstanceProfile:                  iamInstanceProfiles,
+		IAMOpenidConnectProvider:            iamOpenidConnectProviders,
+		IAMPolicy:                           iamPolicies,
+		IAMRole:                             cacheIAMRoles,
+		IAMRolePolicy:                       iamRolePolicies,
+		IAMRolePolicyAttachment:             iamRolePolicyAttachments,
+		IAMSAMLProvider:                     iamSAMLProviders,
+		IAMServerCertificate:                iamServerCertificates,
+		IAMUser:                             cacheIAMUsers,
+		IAMUserGroupMembership:              iamUserGroupMemberships,
+		IAMUserPolicy:                       iamUserPolicies,
+		IAMUserPolicyAttachment:             iamUserPolicyAttachments,
+		Route53DelegationSet:                route53DelegationSets,
+		Route53HealthCheck:                  route53HealthChecks,
+		Route53QueryLog:                     route53QueryLogs,
+		Route53Record:                       route53Records,
+		Route53Zone:                         cacheRoute53Zones,
+		Route53ZoneAssociation:              route53ZoneAssociations,
+		Route53ResolverEndpoint:             route53ResolverEndpoints,
+		Route53ResolverRuleAssociation:      route53ResolverRuleAssociation,
+		SESActiveReceiptRuleSet:             sesActiveReceiptRuleSets,
+		SESDomainIdentity:                   cacheSESDomainIdentities,
+		SESDomainIdentityVerification:       sesDomainGeneral,
+		SESDomainDKIM:                       sesDomainGeneral,
+		SESDomainMailFrom:                   sesDomainGeneral,
+		SESReceiptFilter:                    sesReceiptFilters,
+		SESReceiptRule:                      sesReceiptRules,
+		SESReceiptRuleSet:                   sesReceiptRuleSets,
+		SESConfigurationSet:                 sesConfigurationSets,
+		SESIdentityNotificationTopic:        sesIdentityNotificationTopics,
+		SESTemplate:                         sesTemplates,
+		LaunchConfiguration:                 launchConfigurations,
+		LaunchTemplate:                      launchtemplates,
+		AutoscalingGroup:                    autoscalinggroups,
+		AutoscalingPolicy:                   autoscalingPolicies,
+		AutoscalingSchedule:                 autoscalingSchedules,
+		TransitGateway:                      transitGateways,
+		TransitGatewayVpcAttachment:         transitGatewayVpcAttachments,
+		TransitGatewayRouteTable:            transitGatewayRouteTables,
+		TransitGatewayRouteTableAssociation: transitGatewayRouteTableAssociations,
+		TransitGatewayRouteTablePropagation: transitGatewayRouteTablePropagations,
+		VPCPeeringConnection:                vpcPeeringConnections,
+		CloudwatchMetricAlarm:               cloudwatchMetricAlarms,
+		CloudwatchDashboard:                 cloudwatchDashboards,
+		CloudwatchLogGroup:                  cloudwatchLogGroups,
+		CloudwatchLogMetricFilter:           cloudwatchLogMetricFilters,
+		CloudwatchEventRule:                 cloudwatchEventRules,
+		CloudwatchEventTarget:               cloudwatchEventTargets,
+		SfnStateMachine:                     sfnStateMachines,
+		AcmCertificate:                      acmCertificates,
+		AcmCertificateValidation:            acmCertificateValidations,
+		SecretsmanagerSecret:                secretsmanagerSecrets,
+		SSMParameter:                        ssmParameters,
+		Codepipeline:                        codepipelines,
+		CodebuildProject:                    codebuildProjects,
+		CodedeployApp:                       codedeployApps,
+		CodedeployDeploymentGroup:           codedeployDeploymentGroups,
+		GlueCatalogDatabase:                 glueCatalogDatabases,
+		GlueCatalogTable:                    glueCatalogTables,
+		GlueJob:                             glueJobs,
+		AthenaWorkgroup:                     athenaWorkgroups,
+		AthenaNamedQuery:                    athenaNamedQueries,
+		RedshiftCluster:                     redshiftClusters,
+		RedshiftParameterGroup:              redshiftParameterGroups,
+		RedshiftSubnetGroup:                 redshiftSubnetGroups,
+		MSKCluster:                          mskClusters,
+		MSKConfiguration:                    mskConfigurations,
+		Cloudtrail:                          cloudtrails,
+		ConfigConfigurationRecorder:         configConfigurationRecorders,
+		ConfigConfigRule:                    configConfigRules,
+		GuarddutyDetector:                   guarddutyDetectors,
+		GuarddutyMember:                     guarddutyMembers,
+		InternetGateway:                     internetGateways,
+		NatGateway:                          natGateways,
+		RouteTable:                          routeTables,
+		RouteTableAssociation:               routeTableAssociations,
+		NetworkACL:                          networkACLs,
+		NetworkACLRule:                      networkACLRules,
+		VPCEndpoint:                         vpcEndpoints,
+		EFSFileSystem:                       efsFileSystems,
+		EFSMountTarget:                      efsMountTargets,
+		FSxLustreFileSystem:                 fsxLustreFileSystems,
+		FSxWindowsFileSystem:                fsxWindowsFileSystems,
+		CognitoUserPool:                     cognitoUserPools,
+		CognitoUserPoolClient:               cognitoUserPoolClients,
+		CognitoUserPoolDomain:               cognitoUserPoolDomains,
+		CognitoResourceServer:               cognitoResourceServers,
+		CognitoIdentityPool:                 cognitoIdentityPools,
+		CognitoIdentityPoolRolesAttachment:  cognitoIdentityPoolRolesAttachments,
+		AppsyncGraphqlAPI:                   appsyncGraphqlAPIs,
+		AppsyncDatasource:                   appsyncDatasources,
+		AppsyncResolver:                     appsyncResolvers,
+		OrganizationsOrganization:           organizationsOrganizations,
+		OrganizationsAccount:                organizationsAccounts,
+		OrganizationsOrganizationalUnit:     organizationsOrganizationalUnits,
+		OrganizationsPolicy:                 organizationsPolicies,
+		OrganizationsPolicyAttachment:       organizationsPolicyAttachments,
+		BatchComputeEnvironment:             batchComputeEnvironments,
+		BatchJobQueue:                       batchJobQueues,
+		BatchJobDefinition:                  batchJobDefinitions,
+		EMRCluster:                          emrClusters,
+		SagemakerNotebookInstance:           sagemakerNotebookInstances,
+		SagemakerEndpoint:                   sagemakerEndpoints,
+		GlobalacceleratorAccelerator:        globalacceleratorAccelerators,
+		GlobalacceleratorListener:           globalacceleratorListeners,
+		GlobalacceleratorEndpointGroup:      globalacceleratorEndpointGroups,
+		BackupVault:                         backupVaults,
+		BackupPlan:                          backupPlans,
+		BackupSelection:                     backupSelections,
+		ElasticBeanstalkApplication:         elasticBeanstalkApplications,
+		ElasticBeanstalkEnvironment:         elasticBeanstalkEnvironments,
 	}
 )
 
@@ -161,6 +369,24 @@ func initializeResource(a *aws, ID, t string) (provider.Resource, error) {
 	return provider.NewResource(ID, t, a), nil
 }
 
+// initializeOwnedResource initializes the resource owned by the ownerID, if
+// it's not the Account ID it means it has been shared (via RAM) so it'll
+// be initialized as a data source if it's configured on the Options
+func initializeOwnedResource(a *aws, ID, t, ownerID string) (provider.Resource, error) {
+	if !a.opt.SharedAsData || ownerID == "" || ownerID == a.awsr.GetAccountID() {
+		return initializeResource(a, ID, t)
+	}
+
+	log.Get().Log("func", "aws.initializeOwnedResource", "msg", "shared resource as data source", "resource", t, "id", ID, "owner", ownerID)
+
+	var alias string
+	if a.opt.SharedProviderAlias != "" {
+		alias = fmt.Sprintf("%s.%s", a.String(), a.opt.SharedProviderAlias)
+	}
+
+	return provider.NewDataResource(ID, t, a, alias), nil
+}
+
 func instances(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
 	var input = &ec2.DescribeInstancesInput{
 		Filters: toEC2Filters(tags),
@@ -179,6 +405,12 @@ func instances(ctx context.Context, a *aws, resourceType string, tags []tag.Tag)
 				return nil, err
 			}
 			resources = append(resources, r)
+
+			if vv.State != nil && awsSDK.StringValue(vv.State.Name) == ec2.InstanceStateNameStopped {
+				if reason := stoppedReason(awsSDK.StringValue(vv.StateTransitionReason), time.Now()); reason != "" {
+					a.setUnused(*vv.InstanceId, reason)
+				}
+			}
 		}
 	}
 
@@ -197,7 +429,7 @@ func vpcs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]p
 
 	resources := make([]provider.Resource, 0)
 	for _, v := range vpcs.Vpcs {
-		r, err := initializeResource(a, *v.VpcId, resourceType)
+		r, err := initializeOwnedResource(a, *v.VpcId, resourceType, awsSDK.StringValue(v.OwnerId))
 		if err != nil {
 			return nil, err
 		}
@@ -263,7 +495,7 @@ func subnets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) (
 
 	resources := make([]provider.Resource, 0)
 	for _, v := range subnets.Subnets {
-		r, err := initializeResource(a, *v.SubnetId, resourceType)
+		r, err := initializeOwnedResource(a, *v.SubnetId, resourceType, awsSDK.StringValue(v.OwnerId))
 		if err != nil {
 			return nil, err
 		}
@@ -290,6 +522,10 @@ func ebsVolumes(ctx context.Context, a *aws, resourceType string, tags []tag.Tag
 			return nil, err
 		}
 		resources = append(resources, r)
+
+		if awsSDK.StringValue(v.State) == ec2.VolumeStateAvailable {
+			a.setUnused(*v.VolumeId, "not attached to any instance")
+		}
 	}
 
 	return resources, nil
@@ -325,6 +561,12 @@ func elasticacheClusters(ctx context.Context, a *aws, resourceType string, tags
 
 	resources := make([]provider.Resource, 0)
 	for _, v := range cacheClusters.CacheClusters {
+		// The members of a replication group are
+		// managed by the aws_elasticache_replication_group
+		if v.ReplicationGroupId != nil {
+			continue
+		}
+
 		r, err := initializeResource(a, *v.CacheClusterId, resourceType)
 		if err != nil {
 			return nil, err
@@ -335,15 +577,15 @@ func elasticacheClusters(ctx context.Context, a *aws, resourceType string, tags
 	return resources, nil
 }
 
-func elbs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	lbs, err := a.awsr.GetLoadBalancers(ctx, nil)
+func elasticacheReplicationGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetElastiCacheReplicationGroups(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, v := range lbs.LoadBalancerDescriptions {
-		r, err := initializeResource(a, *v.LoadBalancerName, resourceType)
+	for _, v := range groups.ReplicationGroups {
+		r, err := initializeResource(a, *v.ReplicationGroupId, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -353,15 +595,21 @@ func elbs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]p
 	return resources, nil
 }
 
-func albs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	lbs, err := a.awsr.GetLoadBalancersV2(ctx, nil)
+func elasticacheParameterGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetElastiCacheParameterGroups(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, v := range lbs.LoadBalancers {
-		r, err := initializeResource(a, *v.LoadBalancerArn, resourceType)
+	for _, v := range groups.CacheParameterGroups {
+		// The default parameter groups are
+		// created by AWS and can not be modified
+		if strings.HasPrefix(*v.CacheParameterGroupName, "default.") {
+			continue
+		}
+
+		r, err := initializeResource(a, *v.CacheParameterGroupName, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -371,15 +619,20 @@ func albs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]p
 	return resources, nil
 }
 
-func dbInstances(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	dbs, err := a.awsr.GetDBInstances(ctx, nil)
+func elasticacheSubnetGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetElastiCacheSubnetGroups(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, v := range dbs.DBInstances {
-		r, err := initializeResource(a, *v.DBInstanceIdentifier, resourceType)
+	for _, v := range groups.CacheSubnetGroups {
+		// The default subnet group is created by AWS
+		if *v.CacheSubnetGroupName == "default" {
+			continue
+		}
+
+		r, err := initializeResource(a, *v.CacheSubnetGroupName, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -389,15 +642,15 @@ func dbInstances(ctx context.Context, a *aws, resourceType string, tags []tag.Ta
 	return resources, nil
 }
 
-func s3Buckets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	buckets, err := a.awsr.ListBuckets(ctx, nil)
+func elbs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	lbs, err := a.awsr.GetLoadBalancers(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, v := range buckets.Buckets {
-		r, err := initializeResource(a, *v.Name, resourceType)
+	for _, v := range lbs.LoadBalancerDescriptions {
+		r, err := initializeResource(a, *v.LoadBalancerName, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -407,15 +660,15 @@ func s3Buckets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag)
 	return resources, nil
 }
 
-func cloudfrontDistributions(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	distributions, err := a.awsr.GetCloudFrontDistributions(ctx, nil)
+func albs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	lbs, err := a.awsr.GetLoadBalancersV2(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range distributions.DistributionList.Items {
-		r, err := initializeResource(a, *i.Id, resourceType)
+	for _, v := range lbs.LoadBalancers {
+		r, err := initializeResource(a, *v.LoadBalancerArn, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -425,33 +678,40 @@ func cloudfrontDistributions(ctx context.Context, a *aws, resourceType string, t
 	return resources, nil
 }
 
-func cloudfrontOriginAccessIdentities(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	identitys, err := a.awsr.GetCloudFrontOriginAccessIdentities(ctx, nil)
+// getLBListenerARNs returns the ARNs of the
+// Listeners of all the ELB (v2)
+func getLBListenerARNs(ctx context.Context, a *aws) ([]string, error) {
+	lbs, err := a.awsr.GetLoadBalancersV2(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resources := make([]provider.Resource, 0)
-	for _, i := range identitys.CloudFrontOriginAccessIdentityList.Items {
-		r, err := initializeResource(a, *i.Id, resourceType)
+	arns := make([]string, 0)
+	for _, v := range lbs.LoadBalancers {
+		listeners, err := a.awsr.GetListeners(ctx, &elbv2.DescribeListenersInput{
+			LoadBalancerArn: v.LoadBalancerArn,
+		})
 		if err != nil {
 			return nil, err
 		}
-		resources = append(resources, r)
+
+		for _, l := range listeners.Listeners {
+			arns = append(arns, *l.ListenerArn)
+		}
 	}
 
-	return resources, nil
+	return arns, nil
 }
 
-func cloudfrontPublicKeys(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	publicKeys, err := a.awsr.GetCloudFrontPublicKeys(ctx, nil)
+func lbListeners(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	arns, err := getLBListenerARNs(ctx, a)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range publicKeys.PublicKeyList.Items {
-		r, err := initializeResource(a, *i.Id, resourceType)
+	for _, arn := range arns {
+		r, err := initializeResource(a, arn, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -461,88 +721,48 @@ func cloudfrontPublicKeys(ctx context.Context, a *aws, resourceType string, tags
 	return resources, nil
 }
 
-//func iamAccessKeys(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-//// I could get all the users and do the same filtering by user but it
-//// seems that it does not need it and returns all the AccessKeys
-//accessKeys, err := a.awsr.GetAccessKeys(ctx, nil)
-//if err != nil {
-//return nil, err
-//}
-
-//resources := make([]provider.Resource, 0)
-//for _, i := range accessKeys.AccessKeyMetadata {
-//r, err := initializeResource(a, *i.AccessKeyId, resourceType)
-//if err != nil {
-//return nil, err
-//}
-//err = r.Data().Set("user", i.UserName)
-//if err != nil {
-//return nil, err
-//}
-//resources = append(resources, r)
-//}
-
-//return resources, nil
-//}
-
-func iamAccountAliases(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	accountAliases, err := a.awsr.GetAccountAliases(ctx, nil)
+func lbListenerRules(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	arns, err := getLBListenerARNs(ctx, a)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range accountAliases.AccountAliases {
-		r, err := initializeResource(a, *i, resourceType)
+	for _, arn := range arns {
+		rules, err := a.awsr.GetListenerRules(ctx, &elbv2.DescribeRulesInput{
+			ListenerArn: awsSDK.String(arn),
+		})
 		if err != nil {
 			return nil, err
 		}
-		resources = append(resources, r)
-	}
-
-	return resources, nil
-}
-
-func iamAccountPasswordPolicy(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	// As it's for the full account we'll tell TF to fetch it directly with a "" id
-	r, err := initializeResource(a, "iam-account-password-policy", resourceType)
-	if err != nil {
-		return nil, err
-	}
-	return []provider.Resource{r}, nil
-}
 
-func iamGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	groups, err := a.awsr.GetGroups(ctx, nil)
-	if err != nil {
-		return nil, err
-	}
+		for _, v := range rules.Rules {
+			// The default rule is the default_action
+			// of the aws_lb_listener
+			if v.IsDefault != nil && *v.IsDefault {
+				continue
+			}
 
-	resources := make([]provider.Resource, 0)
-	for _, i := range groups.Groups {
-		r, err := initializeResource(a, *i.GroupName, resourceType)
-		if err != nil {
-			return nil, err
+			r, err := initializeResource(a, *v.RuleArn, resourceType)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r)
 		}
-		resources = append(resources, r)
 	}
 
 	return resources, nil
 }
 
-func iamGroupMemberships(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	groupNames, err := getIAMGroupNames(ctx, a, IAMGroup.String(), tags)
+func lbTargetGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetTargetGroups(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range groupNames {
-		r, err := initializeResource(a, NoID, resourceType)
-		if err != nil {
-			return nil, err
-		}
-		err = r.Data().Set("group", i)
+	for _, v := range groups.TargetGroups {
+		r, err := initializeResource(a, *v.TargetGroupArn, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -552,29 +772,65 @@ func iamGroupMemberships(ctx context.Context, a *aws, resourceType string, tags
 	return resources, nil
 }
 
-func iamGroupPolicies(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	groupNames, err := getIAMGroupNames(ctx, a, IAMGroup.String(), tags)
+func lbTargetGroupAttachments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetTargetGroups(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, gn := range groupNames {
-		input := &iam.ListGroupPoliciesInput{
-			GroupName: awsSDK.String(gn),
-		}
-		groupPolicies, err := a.awsr.GetGroupPolicies(ctx, input)
+	for _, g := range groups.TargetGroups {
+		health, err := a.awsr.GetTargetHealth(ctx, &elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: g.TargetGroupArn,
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		for _, i := range groupPolicies.PolicyNames {
-			// It needs the ID to be "GN:PN"
-			// https://github.com/terraform-providers/terraform-provider-aws/blob/master/aws/resource_aws_iam_group_policy.go#L134:6
-			r, err := initializeResource(a, fmt.Sprintf("%s:%s", gn, *i), resourceType)
+		for _, v := range health.TargetHealthDescriptions {
+			if v.Target == nil {
+				continue
+			}
+
+			// The same target can be registered on multiple ports
+			// (ex: the dynamic ports of ECS) or AZs, which are
+			// different attachments so they are part of the ID
+			id := fmt.Sprintf("%s-%s", *g.TargetGroupArn, *v.Target.Id)
+			if v.Target.Port != nil {
+				id = fmt.Sprintf("%s-%d", id, *v.Target.Port)
+			}
+			if v.Target.AvailabilityZone != nil {
+				id = fmt.Sprintf("%s-%s", id, *v.Target.AvailabilityZone)
+			}
+
+			r, err := initializeResource(a, id, resourceType)
 			if err != nil {
 				return nil, err
 			}
+
+			// The aws_lb_target_group_attachment it's not importable
+			// so the Read needs the target group and the target to find it
+			err = r.Data().Set("target_group_arn", g.TargetGroupArn)
+			if err != nil {
+				return nil, err
+			}
+			err = r.Data().Set("target_id", v.Target.Id)
+			if err != nil {
+				return nil, err
+			}
+			if v.Target.Port != nil {
+				err = r.Data().Set("port", int(*v.Target.Port))
+				if err != nil {
+					return nil, err
+				}
+			}
+			if v.Target.AvailabilityZone != nil {
+				err = r.Data().Set("availability_zone", v.Target.AvailabilityZone)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			resources = append(resources, r)
 		}
 	}
@@ -582,43 +838,45 @@ func iamGroupPolicies(ctx context.Context, a *aws, resourceType string, tags []t
 	return resources, nil
 }
 
-func iamGroupPolicyAttachments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	groupNames, err := getIAMGroupNames(ctx, a, IAMGroup.String(), tags)
+func dbInstances(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	dbs, err := a.awsr.GetDBInstances(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, gn := range groupNames {
-		input := &iam.ListAttachedGroupPoliciesInput{
-			GroupName: awsSDK.String(gn),
+	for _, v := range dbs.DBInstances {
+		// The instances of a cluster are
+		// imported as aws_rds_cluster_instance
+		if v.DBClusterIdentifier != nil {
+			continue
 		}
-		groupPolicies, err := a.awsr.GetAttachedGroupPolicies(ctx, input)
+
+		r, err := initializeResource(a, *v.DBInstanceIdentifier, resourceType)
 		if err != nil {
 			return nil, err
 		}
-
-		for _, i := range groupPolicies.AttachedPolicies {
-			r, err := initializeResource(a, fmt.Sprintf("%s/%s", gn, *i.PolicyArn), resourceType)
-			if err != nil {
-				return nil, err
-			}
-			resources = append(resources, r)
-		}
+		resources = append(resources, r)
 	}
 
 	return resources, nil
 }
 
-func iamInstanceProfiles(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	instanceProfiles, err := a.awsr.GetInstanceProfiles(ctx, nil)
+func dbParameterGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetDBParameterGroups(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range instanceProfiles.InstanceProfiles {
-		r, err := initializeResource(a, *i.InstanceProfileName, resourceType)
+	for _, v := range groups.DBParameterGroups {
+		// The default parameter groups are
+		// created by AWS and can not be modified
+		if strings.HasPrefix(*v.DBParameterGroupName, "default.") {
+			continue
+		}
+
+		r, err := initializeResource(a, *v.DBParameterGroupName, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -628,15 +886,21 @@ func iamInstanceProfiles(ctx context.Context, a *aws, resourceType string, tags
 	return resources, nil
 }
 
-func iamOpenidConnectProviders(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	openIDConnectProviders, err := a.awsr.GetOpenIDConnectProviders(ctx, nil)
+func dbOptionGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetDBOptionGroups(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range openIDConnectProviders.OpenIDConnectProviderList {
-		r, err := initializeResource(a, *i.Arn, resourceType)
+	for _, v := range groups.OptionGroupsList {
+		// The default option groups are
+		// created by AWS and can not be modified
+		if strings.HasPrefix(*v.OptionGroupName, "default:") {
+			continue
+		}
+
+		r, err := initializeResource(a, *v.OptionGroupName, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -646,18 +910,20 @@ func iamOpenidConnectProviders(ctx context.Context, a *aws, resourceType string,
 	return resources, nil
 }
 
-func iamPolicies(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	input := &iam.ListPoliciesInput{
-		Scope: awsSDK.String("Local"),
-	}
-	policies, err := a.awsr.GetPolicies(ctx, input)
+func dbSubnetGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetDBSubnetGroups(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range policies.Policies {
-		r, err := initializeResource(a, *i.Arn, resourceType)
+	for _, v := range groups.DBSubnetGroups {
+		// The default subnet group is created by AWS
+		if *v.DBSubnetGroupName == "default" {
+			continue
+		}
+
+		r, err := initializeResource(a, *v.DBSubnetGroupName, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -667,15 +933,15 @@ func iamPolicies(ctx context.Context, a *aws, resourceType string, tags []tag.Ta
 	return resources, nil
 }
 
-func iamRoles(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	roles, err := a.awsr.GetRoles(ctx, nil)
+func rdsClusters(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	clusters, err := a.awsr.GetDBClusters(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range roles.Roles {
-		r, err := initializeResource(a, *i.RoleName, resourceType)
+	for _, v := range clusters.DBClusters {
+		r, err := initializeResource(a, *v.DBClusterIdentifier, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -685,71 +951,61 @@ func iamRoles(ctx context.Context, a *aws, resourceType string, tags []tag.Tag)
 	return resources, nil
 }
 
-func iamRolePolicies(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	roleNames, err := getIAMRoleNames(ctx, a, IAMRole.String(), tags)
+func rdsClusterInstances(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	dbs, err := a.awsr.GetDBInstances(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, rn := range roleNames {
-		input := &iam.ListRolePoliciesInput{
-			RoleName: awsSDK.String(rn),
+	for _, v := range dbs.DBInstances {
+		if v.DBClusterIdentifier == nil {
+			continue
 		}
-		rolePolicies, err := a.awsr.GetRolePolicies(ctx, input)
+
+		r, err := initializeResource(a, *v.DBInstanceIdentifier, resourceType)
 		if err != nil {
 			return nil, err
 		}
-
-		for _, i := range rolePolicies.PolicyNames {
-			r, err := initializeResource(a, fmt.Sprintf("%s:%s", rn, *i), resourceType)
-			if err != nil {
-				return nil, err
-			}
-			resources = append(resources, r)
-		}
+		resources = append(resources, r)
 	}
 
 	return resources, nil
 }
 
-func iamRolePolicyAttachments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	roleNames, err := getIAMRoleNames(ctx, a, IAMRole.String(), tags)
+func rdsClusterParameterGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetDBClusterParameterGroups(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, rn := range roleNames {
-		input := &iam.ListAttachedRolePoliciesInput{
-			RoleName: awsSDK.String(rn),
+	for _, v := range groups.DBClusterParameterGroups {
+		// The default parameter groups are
+		// created by AWS and can not be modified
+		if strings.HasPrefix(*v.DBClusterParameterGroupName, "default.") {
+			continue
 		}
-		rolePolicies, err := a.awsr.GetAttachedRolePolicies(ctx, input)
+
+		r, err := initializeResource(a, *v.DBClusterParameterGroupName, resourceType)
 		if err != nil {
 			return nil, err
 		}
-
-		for _, i := range rolePolicies.AttachedPolicies {
-			r, err := initializeResource(a, fmt.Sprintf("%s/%s", rn, *i.PolicyArn), resourceType)
-			if err != nil {
-				return nil, err
-			}
-			resources = append(resources, r)
-		}
+		resources = append(resources, r)
 	}
 
 	return resources, nil
 }
 
-func iamSAMLProviders(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	samalProviders, err := a.awsr.GetSAMLProviders(ctx, nil)
+func s3Buckets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	buckets, err := a.awsr.ListBuckets(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range samalProviders.SAMLProviderList {
-		r, err := initializeResource(a, *i.Arn, resourceType)
+	for _, v := range buckets.Buckets {
+		r, err := initializeResource(a, *v.Name, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -759,15 +1015,26 @@ func iamSAMLProviders(ctx context.Context, a *aws, resourceType string, tags []t
 	return resources, nil
 }
 
-func iamServerCertificates(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	serverCertificates, err := a.awsr.GetServerCertificates(ctx, nil)
+func s3BucketPublicAccessBlocks(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	buckets, err := a.awsr.ListBuckets(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range serverCertificates.ServerCertificateMetadataList {
-		r, err := initializeResource(a, *i.ServerCertificateName, resourceType)
+	for _, v := range buckets.Buckets {
+		_, err := a.awsr.GetBucketPublicAccessBlock(ctx, &s3.GetPublicAccessBlockInput{
+			Bucket: v.Name,
+		})
+		if err != nil {
+			// Not all the buckets have a public access block
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NoSuchPublicAccessBlockConfiguration" {
+				continue
+			}
+			return nil, err
+		}
+
+		r, err := initializeResource(a, *v.Name, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -777,15 +1044,15 @@ func iamServerCertificates(ctx context.Context, a *aws, resourceType string, tag
 	return resources, nil
 }
 
-func iamUsers(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	users, err := a.awsr.GetUsers(ctx, nil)
+func cloudfrontDistributions(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	distributions, err := a.awsr.GetCloudFrontDistributions(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range users.Users {
-		r, err := initializeResource(a, *i.UserName, resourceType)
+	for _, i := range distributions.DistributionList.Items {
+		r, err := initializeResource(a, *i.Id, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -795,21 +1062,15 @@ func iamUsers(ctx context.Context, a *aws, resourceType string, tags []tag.Tag)
 	return resources, nil
 }
 
-func iamUserGroupMemberships(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	userNames, err := getIAMUserNames(ctx, a, IAMUser.String(), tags)
-	if err != nil {
-		return nil, err
-	}
-
-	groupNames, err := getIAMGroupNames(ctx, a, IAMGroup.String(), tags)
+func cloudfrontOriginAccessIdentities(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	identitys, err := a.awsr.GetCloudFrontOriginAccessIdentities(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, un := range userNames {
-		// The format expected by TF is <user-name>/<group-name1>/...
-		r, err := initializeResource(a, strings.Join(append([]string{un}, groupNames...), "/"), resourceType)
+	for _, i := range identitys.CloudFrontOriginAccessIdentityList.Items {
+		r, err := initializeResource(a, *i.Id, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -819,71 +1080,57 @@ func iamUserGroupMemberships(ctx context.Context, a *aws, resourceType string, t
 	return resources, nil
 }
 
-func iamUserPolicies(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	userNames, err := getIAMUserNames(ctx, a, IAMUser.String(), tags)
+func cloudfrontPublicKeys(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	publicKeys, err := a.awsr.GetCloudFrontPublicKeys(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, un := range userNames {
-		input := &iam.ListUserPoliciesInput{
-			UserName: awsSDK.String(un),
-		}
-		userPolicies, err := a.awsr.GetUserPolicies(ctx, input)
+	for _, i := range publicKeys.PublicKeyList.Items {
+		r, err := initializeResource(a, *i.Id, resourceType)
 		if err != nil {
 			return nil, err
 		}
-
-		for _, i := range userPolicies.PolicyNames {
-			r, err := initializeResource(a, fmt.Sprintf("%s:%s", un, *i), resourceType)
-			if err != nil {
-				return nil, err
-			}
-			resources = append(resources, r)
-		}
+		resources = append(resources, r)
 	}
 
 	return resources, nil
 }
 
-func iamUserPolicyAttachments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	userNames, err := getIAMUserNames(ctx, a, IAMUser.String(), tags)
-	if err != nil {
-		return nil, err
-	}
-
-	resources := make([]provider.Resource, 0)
-	for _, un := range userNames {
-		input := &iam.ListAttachedUserPoliciesInput{
-			UserName: awsSDK.String(un),
-		}
-		userPolicies, err := a.awsr.GetAttachedUserPolicies(ctx, input)
-		if err != nil {
-			return nil, err
-		}
+//func iamAccessKeys(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+//// I could get all the users and do the same filtering by user but it
+//// seems that it does not need it and returns all the AccessKeys
+//accessKeys, err := a.awsr.GetAccessKeys(ctx, nil)
+//if err != nil {
+//return nil, err
+//}
 
-		for _, i := range userPolicies.AttachedPolicies {
-			r, err := initializeResource(a, fmt.Sprintf("%s/%s", un, *i.PolicyArn), resourceType)
-			if err != nil {
-				return nil, err
-			}
-			resources = append(resources, r)
-		}
-	}
+//resources := make([]provider.Resource, 0)
+//for _, i := range accessKeys.AccessKeyMetadata {
+//r, err := initializeResource(a, *i.AccessKeyId, resourceType)
+//if err != nil {
+//return nil, err
+//}
+//err = r.Data().Set("user", i.UserName)
+//if err != nil {
+//return nil, err
+//}
+//resources = append(resources, r)
+//}
 
-	return resources, nil
-}
+//return resources, nil
+//}
 
-func route53DelegationSets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	r53DelegationSets, err := a.awsr.GetReusableDelegationSets(ctx, nil)
+func iamAccountAliases(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	accountAliases, err := a.awsr.GetAccountAliases(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range r53DelegationSets.DelegationSets {
-		r, err := initializeResource(a, *i.Id, resourceType)
+	for _, i := range accountAliases.AccountAliases {
+		r, err := initializeResource(a, *i, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -893,33 +1140,24 @@ func route53DelegationSets(ctx context.Context, a *aws, resourceType string, tag
 	return resources, nil
 }
 
-func route53HealthChecks(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	r53HealthChecks, err := a.awsr.GetHealthChecks(ctx, nil)
+func iamAccountPasswordPolicy(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	// As it's for the full account we'll tell TF to fetch it directly with a "" id
+	r, err := initializeResource(a, "iam-account-password-policy", resourceType)
 	if err != nil {
 		return nil, err
 	}
-
-	resources := make([]provider.Resource, 0)
-	for _, i := range r53HealthChecks.HealthChecks {
-		r, err := initializeResource(a, *i.Id, resourceType)
-		if err != nil {
-			return nil, err
-		}
-		resources = append(resources, r)
-	}
-
-	return resources, nil
+	return []provider.Resource{r}, nil
 }
 
-func route53QueryLogs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	r53QueryLogs, err := a.awsr.GetQueryLoggingConfigs(ctx, nil)
+func iamGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetGroups(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range r53QueryLogs.QueryLoggingConfigs {
-		r, err := initializeResource(a, *i.Id, resourceType)
+	for _, i := range groups.Groups {
+		r, err := initializeResource(a, *i.GroupName, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -929,15 +1167,19 @@ func route53QueryLogs(ctx context.Context, a *aws, resourceType string, tags []t
 	return resources, nil
 }
 
-func route53Zones(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	r53Zones, err := a.awsr.GetHostedZones(ctx, nil)
+func iamGroupMemberships(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groupNames, err := getIAMGroupNames(ctx, a, IAMGroup.String(), tags)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range r53Zones.HostedZones {
-		r, err := initializeResource(a, *i.Id, resourceType)
+	for _, i := range groupNames {
+		r, err := initializeResource(a, NoID, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		err = r.Data().Set("group", i)
 		if err != nil {
 			return nil, err
 		}
@@ -947,28 +1189,26 @@ func route53Zones(ctx context.Context, a *aws, resourceType string, tags []tag.T
 	return resources, nil
 }
 
-func route53Records(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	zones, err := getRoute53ZoneIDs(ctx, a, Route53Zone.String(), tags)
+func iamGroupPolicies(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groupNames, err := getIAMGroupNames(ctx, a, IAMGroup.String(), tags)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, z := range zones {
-		input := &route53.ListResourceRecordSetsInput{
-			HostedZoneId: awsSDK.String(z),
+	for _, gn := range groupNames {
+		input := &iam.ListGroupPoliciesInput{
+			GroupName: awsSDK.String(gn),
 		}
-		r53Records, err := a.awsr.GetResourceRecordSets(ctx, input)
+		groupPolicies, err := a.awsr.GetGroupPolicies(ctx, input)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, i := range r53Records.ResourceRecordSets {
-			id := []string{z, strings.ToLower(*i.Name), *i.Type}
-			if i.SetIdentifier != nil {
-				id = append(id, *i.SetIdentifier)
-			}
-			r, err := initializeResource(a, strings.Join(id, "_"), resourceType)
+		for _, i := range groupPolicies.PolicyNames {
+			// It needs the ID to be "GN:PN"
+			// https://github.com/terraform-providers/terraform-provider-aws/blob/master/aws/resource_aws_iam_group_policy.go#L134:6
+			r, err := initializeResource(a, fmt.Sprintf("%s:%s", gn, *i), resourceType)
 			if err != nil {
 				return nil, err
 			}
@@ -979,24 +1219,24 @@ func route53Records(ctx context.Context, a *aws, resourceType string, tags []tag
 	return resources, nil
 }
 
-func route53ZoneAssociations(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	zones, err := getRoute53ZoneIDs(ctx, a, Route53Zone.String(), tags)
+func iamGroupPolicyAttachments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groupNames, err := getIAMGroupNames(ctx, a, IAMGroup.String(), tags)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, z := range zones {
-		input := &route53.ListVPCAssociationAuthorizationsInput{
-			HostedZoneId: awsSDK.String(z),
+	for _, gn := range groupNames {
+		input := &iam.ListAttachedGroupPoliciesInput{
+			GroupName: awsSDK.String(gn),
 		}
-		r53ZoneAssociations, err := a.awsr.GetVPCAssociationAuthorizations(ctx, input)
+		groupPolicies, err := a.awsr.GetAttachedGroupPolicies(ctx, input)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, i := range r53ZoneAssociations.VPCs {
-			r, err := initializeResource(a, fmt.Sprintf("%s:%s", z, *i.VPCId), resourceType)
+		for _, i := range groupPolicies.AttachedPolicies {
+			r, err := initializeResource(a, fmt.Sprintf("%s/%s", gn, *i.PolicyArn), resourceType)
 			if err != nil {
 				return nil, err
 			}
@@ -1007,15 +1247,15 @@ func route53ZoneAssociations(ctx context.Context, a *aws, resourceType string, t
 	return resources, nil
 }
 
-func route53ResolverEndpoints(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	r53ResolverEndpoints, err := a.awsr.GetResolverEndpoints(ctx, nil)
+func iamInstanceProfiles(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	instanceProfiles, err := a.awsr.GetInstanceProfiles(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range r53ResolverEndpoints.ResolverEndpoints {
-		r, err := initializeResource(a, *i.Id, resourceType)
+	for _, i := range instanceProfiles.InstanceProfiles {
+		r, err := initializeResource(a, *i.InstanceProfileName, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -1025,15 +1265,15 @@ func route53ResolverEndpoints(ctx context.Context, a *aws, resourceType string,
 	return resources, nil
 }
 
-func route53ResolverRuleAssociation(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	r53ResolverRuleAssociations, err := a.awsr.GetResolverRuleAssociations(ctx, nil)
+func iamOpenidConnectProviders(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	openIDConnectProviders, err := a.awsr.GetOpenIDConnectProviders(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range r53ResolverRuleAssociations.ResolverRuleAssociations {
-		r, err := initializeResource(a, *i.Id, resourceType)
+	for _, i := range openIDConnectProviders.OpenIDConnectProviderList {
+		r, err := initializeResource(a, *i.Arn, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -1043,33 +1283,18 @@ func route53ResolverRuleAssociation(ctx context.Context, a *aws, resourceType st
 	return resources, nil
 }
 
-func sesActiveReceiptRuleSets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	sesActiveReceiptRuleSets, err := a.awsr.GetActiveReceiptRuleSet(ctx, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if sesActiveReceiptRuleSets.Metadata == nil {
-		return nil, nil
-	}
-
-	r, err := initializeResource(a, *sesActiveReceiptRuleSets.Metadata.Name, resourceType)
-	if err != nil {
-		return nil, err
+func iamPolicies(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	input := &iam.ListPoliciesInput{
+		Scope: awsSDK.String("Local"),
 	}
-
-	return []provider.Resource{r}, nil
-}
-
-func sesDomainIdentities(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	sesDomainIdentities, err := a.awsr.GetIdentities(ctx, nil)
+	policies, err := a.awsr.GetPolicies(ctx, input)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range sesDomainIdentities.Identities {
-		r, err := initializeResource(a, *i, resourceType)
+	for _, i := range policies.Policies {
+		r, err := initializeResource(a, *i.Arn, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -1079,15 +1304,15 @@ func sesDomainIdentities(ctx context.Context, a *aws, resourceType string, tags
 	return resources, nil
 }
 
-func sesDomainGeneral(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	domainNames, err := getSESDomainIdentityDomains(ctx, a, SESDomainIdentity.String(), tags)
+func iamRoles(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	roles, err := a.awsr.GetRoles(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range domainNames {
-		r, err := initializeResource(a, i, resourceType)
+	for _, i := range roles.Roles {
+		r, err := initializeResource(a, *i.RoleName, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -1097,69 +1322,107 @@ func sesDomainGeneral(ctx context.Context, a *aws, resourceType string, tags []t
 	return resources, nil
 }
 
-func sesReceiptFilters(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	sesReceiptFilters, err := a.awsr.GetReceiptFilters(ctx, nil)
+func iamRolePolicies(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	roleNames, err := getIAMRoleNames(ctx, a, IAMRole.String(), tags)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range sesReceiptFilters.Filters {
-		r, err := initializeResource(a, *i.Name, resourceType)
+	for _, rn := range roleNames {
+		input := &iam.ListRolePoliciesInput{
+			RoleName: awsSDK.String(rn),
+		}
+		rolePolicies, err := a.awsr.GetRolePolicies(ctx, input)
 		if err != nil {
 			return nil, err
 		}
-		resources = append(resources, r)
+
+		for _, i := range rolePolicies.PolicyNames {
+			r, err := initializeResource(a, fmt.Sprintf("%s:%s", rn, *i), resourceType)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r)
+		}
 	}
 
 	return resources, nil
 }
 
-func sesReceiptRules(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	sesActiveReceiptRuleSets, err := a.awsr.GetActiveReceiptRuleSet(ctx, nil)
+func iamRolePolicyAttachments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	roleNames, err := getIAMRoleNames(ctx, a, IAMRole.String(), tags)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range sesActiveReceiptRuleSets.Rules {
-		r, err := initializeResource(a, fmt.Sprintf("%s:%s", *sesActiveReceiptRuleSets.Metadata.Name, *i.Name), resourceType)
+	for _, rn := range roleNames {
+		input := &iam.ListAttachedRolePoliciesInput{
+			RoleName: awsSDK.String(rn),
+		}
+		rolePolicies, err := a.awsr.GetAttachedRolePolicies(ctx, input)
 		if err != nil {
 			return nil, err
 		}
-		resources = append(resources, r)
-	}
 
-	return resources, nil
-}
+		for _, i := range rolePolicies.AttachedPolicies {
+			r, err := initializeResource(a, fmt.Sprintf("%s/%s", rn, *i.PolicyArn), resourceType)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r)
+		}
+	}
 
-func sesReceiptRuleSets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	sesActiveReceiptRuleSets, err := a.awsr.GetActiveReceiptRuleSet(ctx, nil)
+	return resources, nil
+}
+
+func iamSAMLProviders(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	samalProviders, err := a.awsr.GetSAMLProviders(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if sesActiveReceiptRuleSets.Metadata == nil {
-		return nil, nil
+	resources := make([]provider.Resource, 0)
+	for _, i := range samalProviders.SAMLProviderList {
+		r, err := initializeResource(a, *i.Arn, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
 	}
 
-	r, err := initializeResource(a, *sesActiveReceiptRuleSets.Metadata.Name, resourceType)
+	return resources, nil
+}
+
+func iamServerCertificates(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	serverCertificates, err := a.awsr.GetServerCertificates(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return []provider.Resource{r}, nil
+	resources := make([]provider.Resource, 0)
+	for _, i := range serverCertificates.ServerCertificateMetadataList {
+		r, err := initializeResource(a, *i.ServerCertificateName, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
 }
 
-func sesConfigurationSets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	sesConfigurationSets, err := a.awsr.GetConfigurationSets(ctx, nil)
+func iamUsers(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	users, err := a.awsr.GetUsers(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range sesConfigurationSets.ConfigurationSets {
-		r, err := initializeResource(a, *i.Name, resourceType)
+	for _, i := range users.Users {
+		r, err := initializeResource(a, *i.UserName, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -1169,40 +1432,76 @@ func sesConfigurationSets(ctx context.Context, a *aws, resourceType string, tags
 	return resources, nil
 }
 
-func sesIdentityNotificationTopics(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	domainNames, err := getSESDomainIdentityDomains(ctx, a, SESDomainIdentity.String(), tags)
+func iamUserGroupMemberships(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	userNames, err := getIAMUserNames(ctx, a, IAMUser.String(), tags)
+	if err != nil {
+		return nil, err
+	}
+
+	groupNames, err := getIAMGroupNames(ctx, a, IAMGroup.String(), tags)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, d := range domainNames {
-		// We could just pass domainNames as Identities
-		// but then we would not not which NotificationAttributes
-		// is of each identity so we have to do it one by one
-		input := &ses.GetIdentityNotificationAttributesInput{
-			Identities: []*string{&d},
+	for _, un := range userNames {
+		// The format expected by TF is <user-name>/<group-name1>/...
+		r, err := initializeResource(a, strings.Join(append([]string{un}, groupNames...), "/"), resourceType)
+		if err != nil {
+			return nil, err
 		}
+		resources = append(resources, r)
+	}
 
-		sesIdentityNotificationTopics, err := a.awsr.GetIdentityNotificationAttributes(ctx, input)
+	return resources, nil
+}
+
+func iamUserPolicies(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	userNames, err := getIAMUserNames(ctx, a, IAMUser.String(), tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, un := range userNames {
+		input := &iam.ListUserPoliciesInput{
+			UserName: awsSDK.String(un),
+		}
+		userPolicies, err := a.awsr.GetUserPolicies(ctx, input)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, i := range sesIdentityNotificationTopics.NotificationAttributes {
-			var notType string
-			if i.BounceTopic != nil {
-				notType = ses.NotificationTypeBounce
-			} else if i.ComplaintTopic != nil {
-				notType = ses.NotificationTypeComplaint
-			} else if i.DeliveryTopic != nil {
-				notType = ses.NotificationTypeDelivery
-			} else {
-				// We need the topic, if fore some reason we do not have
-				// it we have to continue to the next one
-				continue
+		for _, i := range userPolicies.PolicyNames {
+			r, err := initializeResource(a, fmt.Sprintf("%s:%s", un, *i), resourceType)
+			if err != nil {
+				return nil, err
 			}
-			r, err := initializeResource(a, fmt.Sprintf("%s|%s", d, notType), resourceType)
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+func iamUserPolicyAttachments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	userNames, err := getIAMUserNames(ctx, a, IAMUser.String(), tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, un := range userNames {
+		input := &iam.ListAttachedUserPoliciesInput{
+			UserName: awsSDK.String(un),
+		}
+		userPolicies, err := a.awsr.GetAttachedUserPolicies(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range userPolicies.AttachedPolicies {
+			r, err := initializeResource(a, fmt.Sprintf("%s/%s", un, *i.PolicyArn), resourceType)
 			if err != nil {
 				return nil, err
 			}
@@ -1213,15 +1512,15 @@ func sesIdentityNotificationTopics(ctx context.Context, a *aws, resourceType str
 	return resources, nil
 }
 
-func sesTemplates(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	sesTemplates, err := a.awsr.GetTemplates(ctx, nil)
+func route53DelegationSets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	r53DelegationSets, err := a.awsr.GetReusableDelegationSets(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range sesTemplates.TemplatesMetadata {
-		r, err := initializeResource(a, *i.Name, resourceType)
+	for _, i := range r53DelegationSets.DelegationSets {
+		r, err := initializeResource(a, *i.Id, resourceType)
 		if err != nil {
 			return nil, err
 		}
@@ -1231,78 +1530,2960 @@ func sesTemplates(ctx context.Context, a *aws, resourceType string, tags []tag.T
 	return resources, nil
 }
 
-func launchConfigurations(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	launchConfigurations, err := a.awsr.GetLaunchConfigurations(ctx, nil)
-
+func route53HealthChecks(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	r53HealthChecks, err := a.awsr.GetHealthChecks(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range launchConfigurations.LaunchConfigurations {
-
-		r, err := initializeResource(a, *i.LaunchConfigurationName, resourceType)
+	for _, i := range r53HealthChecks.HealthChecks {
+		r, err := initializeResource(a, *i.Id, resourceType)
 		if err != nil {
 			return nil, err
 		}
-
 		resources = append(resources, r)
 	}
 
 	return resources, nil
 }
 
-func launchtemplates(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	launchtemplates, err := a.awsr.GetLaunchTemplates(ctx, nil)
-
+func route53QueryLogs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	r53QueryLogs, err := a.awsr.GetQueryLoggingConfigs(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range launchtemplates.LaunchTemplates {
-
-		r, err := initializeResource(a, *i.LaunchTemplateId, resourceType)
+	for _, i := range r53QueryLogs.QueryLoggingConfigs {
+		r, err := initializeResource(a, *i.Id, resourceType)
 		if err != nil {
 			return nil, err
 		}
-
 		resources = append(resources, r)
 	}
 
 	return resources, nil
 }
 
-func autoscalinggroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
-	autoscalinggroups, err := a.awsr.GetAutoScalingGroups(ctx, nil)
+func route53Zones(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	r53Zones, err := a.awsr.GetHostedZones(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range r53Zones.HostedZones {
+		r, err := initializeResource(a, *i.Id, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
 
+func route53Records(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	zones, err := getRoute53ZoneIDs(ctx, a, Route53Zone.String(), tags)
 	if err != nil {
 		return nil, err
 	}
 
 	resources := make([]provider.Resource, 0)
-	for _, i := range autoscalinggroups.AutoScalingGroups {
+	for _, z := range zones {
+		input := &route53.ListResourceRecordSetsInput{
+			HostedZoneId: awsSDK.String(z),
+		}
+		r53Records, err := a.awsr.GetResourceRecordSets(ctx, input)
+		if err != nil {
+			return nil, err
+		}
 
-		r, err := initializeResource(a, *i.AutoScalingGroupName, resourceType)
+		for _, i := range r53Records.ResourceRecordSets {
+			id := []string{z, strings.ToLower(*i.Name), *i.Type}
+			if i.SetIdentifier != nil {
+				id = append(id, *i.SetIdentifier)
+			}
+			r, err := initializeResource(a, strings.Join(id, "_"), resourceType)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+func route53ZoneAssociations(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	zones, err := getRoute53ZoneIDs(ctx, a, Route53Zone.String(), tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, z := range zones {
+		input := &route53.ListVPCAssociationAuthorizationsInput{
+			HostedZoneId: awsSDK.String(z),
+		}
+		r53ZoneAssociations, err := a.awsr.GetVPCAssociationAuthorizations(ctx, input)
 		if err != nil {
 			return nil, err
 		}
 
-		resources = append(resources, r)
+		for _, i := range r53ZoneAssociations.VPCs {
+			r, err := initializeResource(a, fmt.Sprintf("%s:%s", z, *i.VPCId), resourceType)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r)
+		}
 	}
 
 	return resources, nil
 }
 
-func toEC2Filters(tags []tag.Tag) []*ec2.Filter {
-	if len(tags) == 0 {
-		return nil
+func route53ResolverEndpoints(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	r53ResolverEndpoints, err := a.awsr.GetResolverEndpoints(ctx, nil)
+	if err != nil {
+		return nil, err
 	}
-	filters := make([]*ec2.Filter, 0, len(tags))
 
-	for _, t := range tags {
-		filters = append(filters, t.ToEC2Filter())
+	resources := make([]provider.Resource, 0)
+	for _, i := range r53ResolverEndpoints.ResolverEndpoints {
+		r, err := initializeResource(a, *i.Id, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
 	}
 
-	return filters
+	return resources, nil
+}
+
+func route53ResolverRuleAssociation(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	r53ResolverRuleAssociations, err := a.awsr.GetResolverRuleAssociations(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range r53ResolverRuleAssociations.ResolverRuleAssociations {
+		r, err := initializeResource(a, *i.Id, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func sesActiveReceiptRuleSets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	sesActiveReceiptRuleSets, err := a.awsr.GetActiveReceiptRuleSet(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if sesActiveReceiptRuleSets.Metadata == nil {
+		return nil, nil
+	}
+
+	r, err := initializeResource(a, *sesActiveReceiptRuleSets.Metadata.Name, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	return []provider.Resource{r}, nil
+}
+
+func sesDomainIdentities(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	sesDomainIdentities, err := a.awsr.GetIdentities(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range sesDomainIdentities.Identities {
+		r, err := initializeResource(a, *i, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func sesDomainGeneral(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	domainNames, err := getSESDomainIdentityDomains(ctx, a, SESDomainIdentity.String(), tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range domainNames {
+		r, err := initializeResource(a, i, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func sesReceiptFilters(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	sesReceiptFilters, err := a.awsr.GetReceiptFilters(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range sesReceiptFilters.Filters {
+		r, err := initializeResource(a, *i.Name, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func sesReceiptRules(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	sesActiveReceiptRuleSets, err := a.awsr.GetActiveReceiptRuleSet(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range sesActiveReceiptRuleSets.Rules {
+		r, err := initializeResource(a, fmt.Sprintf("%s:%s", *sesActiveReceiptRuleSets.Metadata.Name, *i.Name), resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func sesReceiptRuleSets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	sesActiveReceiptRuleSets, err := a.awsr.GetActiveReceiptRuleSet(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if sesActiveReceiptRuleSets.Metadata == nil {
+		return nil, nil
+	}
+
+	r, err := initializeResource(a, *sesActiveReceiptRuleSets.Metadata.Name, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	return []provider.Resource{r}, nil
+}
+
+func sesConfigurationSets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	sesConfigurationSets, err := a.awsr.GetConfigurationSets(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range sesConfigurationSets.ConfigurationSets {
+		r, err := initializeResource(a, *i.Name, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func sesIdentityNotificationTopics(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	domainNames, err := getSESDomainIdentityDomains(ctx, a, SESDomainIdentity.String(), tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, d := range domainNames {
+		// We could just pass domainNames as Identities
+		// but then we would not not which NotificationAttributes
+		// is of each identity so we have to do it one by one
+		input := &ses.GetIdentityNotificationAttributesInput{
+			Identities: []*string{&d},
+		}
+
+		sesIdentityNotificationTopics, err := a.awsr.GetIdentityNotificationAttributes(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range sesIdentityNotificationTopics.NotificationAttributes {
+			var notType string
+			if i.BounceTopic != nil {
+				notType = ses.NotificationTypeBounce
+			} else if i.ComplaintTopic != nil {
+				notType = ses.NotificationTypeComplaint
+			} else if i.DeliveryTopic != nil {
+				notType = ses.NotificationTypeDelivery
+			} else {
+				// We need the topic, if fore some reason we do not have
+				// it we have to continue to the next one
+				continue
+			}
+			r, err := initializeResource(a, fmt.Sprintf("%s|%s", d, notType), resourceType)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+func sesTemplates(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	sesTemplates, err := a.awsr.GetTemplates(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range sesTemplates.TemplatesMetadata {
+		r, err := initializeResource(a, *i.Name, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func launchConfigurations(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	launchConfigurations, err := a.awsr.GetLaunchConfigurations(ctx, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range launchConfigurations.LaunchConfigurations {
+
+		r, err := initializeResource(a, *i.LaunchConfigurationName, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func launchtemplates(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	launchtemplates, err := a.awsr.GetLaunchTemplates(ctx, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range launchtemplates.LaunchTemplates {
+
+		r, err := initializeResource(a, *i.LaunchTemplateId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func autoscalinggroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	autoscalinggroups, err := a.awsr.GetAutoScalingGroups(ctx, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range autoscalinggroups.AutoScalingGroups {
+
+		r, err := initializeResource(a, *i.AutoScalingGroupName, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func autoscalingPolicies(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	policies, err := a.awsr.GetAutoScalingPolicies(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range policies.ScalingPolicies {
+		r, err := initializeResource(a, fmt.Sprintf("%s/%s", *i.AutoScalingGroupName, *i.PolicyName), resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func autoscalingSchedules(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	actions, err := a.awsr.GetAutoScalingScheduledActions(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range actions.ScheduledUpdateGroupActions {
+		r, err := initializeResource(a, fmt.Sprintf("%s/%s", *i.AutoScalingGroupName, *i.ScheduledActionName), resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func transitGateways(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	var input = &ec2.DescribeTransitGatewaysInput{
+		Filters: toEC2Filters(tags),
+	}
+
+	tgws, err := a.awsr.GetTransitGateways(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range tgws.TransitGateways {
+		r, err := initializeOwnedResource(a, *v.TransitGatewayId, resourceType, awsSDK.StringValue(v.OwnerId))
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func transitGatewayVpcAttachments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	var input = &ec2.DescribeTransitGatewayVpcAttachmentsInput{
+		Filters: toEC2Filters(tags),
+	}
+
+	attachments, err := a.awsr.GetTransitGatewayVpcAttachments(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range attachments.TransitGatewayVpcAttachments {
+		if awsSDK.StringValue(v.State) == ec2.TransitGatewayAttachmentStateDeleted {
+			continue
+		}
+
+		r, err := initializeResource(a, *v.TransitGatewayAttachmentId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+// getTransitGatewayRouteTables returns the route tables
+// of the Transit Gateways which are not deleted
+func getTransitGatewayRouteTables(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]*ec2.TransitGatewayRouteTable, error) {
+	var input = &ec2.DescribeTransitGatewayRouteTablesInput{
+		Filters: toEC2Filters(tags),
+	}
+
+	rts, err := a.awsr.GetTransitGatewayRouteTables(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	routeTables := make([]*ec2.TransitGatewayRouteTable, 0)
+	for _, v := range rts.TransitGatewayRouteTables {
+		if awsSDK.StringValue(v.State) == ec2.TransitGatewayRouteTableStateDeleted {
+			continue
+		}
+		routeTables = append(routeTables, v)
+	}
+
+	return routeTables, nil
+}
+
+func transitGatewayRouteTables(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	rts, err := getTransitGatewayRouteTables(ctx, a, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range rts {
+		// The default route table is created with
+		// the Transit Gateway and can not be managed
+		if awsSDK.BoolValue(v.DefaultAssociationRouteTable) || awsSDK.BoolValue(v.DefaultPropagationRouteTable) {
+			continue
+		}
+
+		r, err := initializeResource(a, *v.TransitGatewayRouteTableId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func transitGatewayRouteTableAssociations(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	rts, err := getTransitGatewayRouteTables(ctx, a, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range rts {
+		// The associations to the default route table are defined
+		// on the aws_ec2_transit_gateway_vpc_attachment
+		if awsSDK.BoolValue(v.DefaultAssociationRouteTable) {
+			continue
+		}
+
+		associations, err := a.awsr.GetTransitGatewayRouteTableAssociations(ctx, &ec2.GetTransitGatewayRouteTableAssociationsInput{
+			TransitGatewayRouteTableId: v.TransitGatewayRouteTableId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, as := range associations.Associations {
+			r, err := initializeResource(a, fmt.Sprintf("%s_%s", *v.TransitGatewayRouteTableId, *as.TransitGatewayAttachmentId), resourceType)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+func transitGatewayRouteTablePropagations(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	rts, err := getTransitGatewayRouteTables(ctx, a, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range rts {
+		// The propagations to the default route table are defined
+		// on the aws_ec2_transit_gateway_vpc_attachment
+		if awsSDK.BoolValue(v.DefaultPropagationRouteTable) {
+			continue
+		}
+
+		propagations, err := a.awsr.GetTransitGatewayRouteTablePropagations(ctx, &ec2.GetTransitGatewayRouteTablePropagationsInput{
+			TransitGatewayRouteTableId: v.TransitGatewayRouteTableId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range propagations.TransitGatewayRouteTablePropagations {
+			r, err := initializeResource(a, fmt.Sprintf("%s_%s", *v.TransitGatewayRouteTableId, *p.TransitGatewayAttachmentId), resourceType)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+func vpcPeeringConnections(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	var input = &ec2.DescribeVpcPeeringConnectionsInput{
+		Filters: toEC2Filters(tags),
+	}
+
+	pcs, err := a.awsr.GetVpcPeeringConnections(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range pcs.VpcPeeringConnections {
+		// Only the requester of the peering connection
+		// manages it, the accepter has to use the
+		// aws_vpc_peering_connection_accepter
+		if awsSDK.StringValue(v.RequesterVpcInfo.OwnerId) != a.awsr.GetAccountID() {
+			continue
+		}
+
+		switch awsSDK.StringValue(v.Status.Code) {
+		case ec2.VpcPeeringConnectionStateReasonCodeDeleted, ec2.VpcPeeringConnectionStateReasonCodeRejected,
+			ec2.VpcPeeringConnectionStateReasonCodeFailed, ec2.VpcPeeringConnectionStateReasonCodeExpired:
+			continue
+		}
+
+		r, err := initializeResource(a, *v.VpcPeeringConnectionId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func internetGateways(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	var input = &ec2.DescribeInternetGatewaysInput{
+		Filters: toEC2Filters(tags),
+	}
+
+	igws, err := a.awsr.GetInternetGateways(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range igws.InternetGateways {
+		r, err := initializeOwnedResource(a, *v.InternetGatewayId, resourceType, awsSDK.StringValue(v.OwnerId))
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func natGateways(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	var input = &ec2.DescribeNatGatewaysInput{
+		Filter: toEC2Filters(tags),
+	}
+
+	ngws, err := a.awsr.GetNatGateways(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range ngws.NatGateways {
+		switch awsSDK.StringValue(v.State) {
+		case ec2.NatGatewayStateDeleting, ec2.NatGatewayStateDeleted, ec2.NatGatewayStateFailed:
+			continue
+		}
+
+		r, err := initializeResource(a, *v.NatGatewayId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+// getRouteTables returns the route tables
+// that are not the main ones of the VPCs
+func getRouteTables(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]*ec2.RouteTable, error) {
+	var input = &ec2.DescribeRouteTablesInput{
+		Filters: toEC2Filters(tags),
+	}
+
+	rts, err := a.awsr.GetRouteTables(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	routeTables := make([]*ec2.RouteTable, 0)
+	for _, v := range rts.RouteTables {
+		// The main route table is created with the
+		// VPC so it can not be an aws_route_table
+		var main bool
+		for _, as := range v.Associations {
+			if awsSDK.BoolValue(as.Main) {
+				main = true
+				break
+			}
+		}
+		if main {
+			continue
+		}
+		routeTables = append(routeTables, v)
+	}
+
+	return routeTables, nil
+}
+
+func routeTables(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	rts, err := getRouteTables(ctx, a, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range rts {
+		r, err := initializeOwnedResource(a, *v.RouteTableId, resourceType, awsSDK.StringValue(v.OwnerId))
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func routeTableAssociations(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	rts, err := getRouteTables(ctx, a, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range rts {
+		for _, as := range v.Associations {
+			if as.SubnetId == nil {
+				continue
+			}
+
+			// The import ID is 'SUBNET_ID/ROUTE_TABLE_ID'
+			r, err := initializeResource(a, fmt.Sprintf("%s/%s", *as.SubnetId, *v.RouteTableId), resourceType)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+// getNetworkACLs returns the network ACLs
+// that are not the default ones of the VPCs
+func getNetworkACLs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]*ec2.NetworkAcl, error) {
+	var input = &ec2.DescribeNetworkAclsInput{
+		Filters: toEC2Filters(tags),
+	}
+
+	acls, err := a.awsr.GetNetworkAcls(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	networkACLs := make([]*ec2.NetworkAcl, 0)
+	for _, v := range acls.NetworkAcls {
+		// The default network ACL is created with the
+		// VPC so it can not be an aws_network_acl
+		if awsSDK.BoolValue(v.IsDefault) {
+			continue
+		}
+		networkACLs = append(networkACLs, v)
+	}
+
+	return networkACLs, nil
+}
+
+func networkACLs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	acls, err := getNetworkACLs(ctx, a, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range acls {
+		r, err := initializeOwnedResource(a, *v.NetworkAclId, resourceType, awsSDK.StringValue(v.OwnerId))
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+// networkACLDefaultRuleNumber is the number of the
+// default rule (deny all) of the network ACLs
+const networkACLDefaultRuleNumber = 32767
+
+func networkACLRules(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	acls, err := getNetworkACLs(ctx, a, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range acls {
+		for _, e := range v.Entries {
+			if awsSDK.Int64Value(e.RuleNumber) == networkACLDefaultRuleNumber {
+				continue
+			}
+
+			// The ID is the one the TF provider
+			// generates when creating the rule
+			id := fmt.Sprintf("nacl-%d", hashcode.String(fmt.Sprintf("%s-%d-%t-%s-", *v.NetworkAclId, *e.RuleNumber, *e.Egress, *e.Protocol)))
+			r, err := initializeResource(a, id, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			// The aws_network_acl_rule it's not importable so
+			// the Read needs the network ACL and the rule to find it
+			err = r.Data().Set("network_acl_id", v.NetworkAclId)
+			if err != nil {
+				return nil, err
+			}
+			err = r.Data().Set("rule_number", int(*e.RuleNumber))
+			if err != nil {
+				return nil, err
+			}
+			err = r.Data().Set("egress", e.Egress)
+			if err != nil {
+				return nil, err
+			}
+			err = r.Data().Set("protocol", e.Protocol)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+func vpcEndpoints(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	var input = &ec2.DescribeVpcEndpointsInput{
+		Filters: toEC2Filters(tags),
+	}
+
+	endpoints, err := a.awsr.GetVpcEndpoints(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, v := range endpoints.VpcEndpoints {
+		// The ones managed by other AWS services
+		// are created and deleted by them
+		if awsSDK.BoolValue(v.RequesterManaged) {
+			continue
+		}
+
+		// The states are lowercased on the API
+		// but capitalized on the SDK
+		switch strings.ToLower(awsSDK.StringValue(v.State)) {
+		case strings.ToLower(ec2.StateDeleting), strings.ToLower(ec2.StateDeleted), strings.ToLower(ec2.StateRejected),
+			strings.ToLower(ec2.StateFailed), strings.ToLower(ec2.StateExpired):
+			continue
+		}
+
+		r, err := initializeOwnedResource(a, *v.VpcEndpointId, resourceType, awsSDK.StringValue(v.OwnerId))
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func toEC2Filters(tags []tag.Tag) []*ec2.Filter {
+	if len(tags) == 0 {
+		return nil
+	}
+	filters := make([]*ec2.Filter, 0, len(tags))
+
+	for _, t := range tags {
+		filters = append(filters, t.ToEC2Filter())
+	}
+
+	return filters
+}
+
+func cloudwatchMetricAlarms(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	alarms, err := a.awsr.GetMetricAlarms(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range alarms.MetricAlarms {
+		r, err := initializeResource(a, *i.AlarmName, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func cloudwatchDashboards(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	dashboards, err := a.awsr.GetDashboards(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range dashboards.DashboardEntries {
+		r, err := initializeResource(a, *i.DashboardName, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func cloudwatchLogGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetLogGroups(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range groups.LogGroups {
+		r, err := initializeResource(a, *i.LogGroupName, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func cloudwatchLogMetricFilters(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	filters, err := a.awsr.GetMetricFilters(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range filters.MetricFilters {
+		r, err := initializeResource(a, *i.FilterName, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		// The aws_cloudwatch_log_metric_filter it's not importable
+		// so the Read needs the name and the log group to find it
+		err = r.Data().Set("name", i.FilterName)
+		if err != nil {
+			return nil, err
+		}
+		err = r.Data().Set("log_group_name", i.LogGroupName)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+// getCloudwatchEventRuleNames returns the names of the event rules
+// that are not managed by other AWS services
+func getCloudwatchEventRuleNames(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]string, error) {
+	rules, err := a.awsr.GetCloudWatchEventRules(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	for _, i := range rules.Rules {
+		// The rules managed by other services
+		// can not be modified by the user
+		if i.ManagedBy != nil {
+			continue
+		}
+
+		names = append(names, *i.Name)
+	}
+
+	return names, nil
+}
+
+func cloudwatchEventRules(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	names, err := getCloudwatchEventRuleNames(ctx, a, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, n := range names {
+		r, err := initializeResource(a, n, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func cloudwatchEventTargets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	names, err := getCloudwatchEventRuleNames(ctx, a, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, n := range names {
+		targets, err := a.awsr.GetCloudWatchEventTargets(ctx, &cloudwatchevents.ListTargetsByRuleInput{
+			Rule: awsSDK.String(n),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range targets.Targets {
+			r, err := initializeResource(a, fmt.Sprintf("%s/%s", n, *i.Id), resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+func sfnStateMachines(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	machines, err := a.awsr.GetStateMachines(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range machines.StateMachines {
+		r, err := initializeResource(a, *i.StateMachineArn, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func acmCertificates(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	certificates, err := a.awsr.GetCertificates(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range certificates.CertificateSummaryList {
+		r, err := initializeResource(a, *i.CertificateArn, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func acmCertificateValidations(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	certificates, err := a.awsr.GetCertificates(ctx, &acm.ListCertificatesInput{
+		CertificateStatuses: awsSDK.StringSlice([]string{acm.CertificateStatusIssued}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range certificates.CertificateSummaryList {
+		certificate, err := a.awsr.GetCertificate(ctx, &acm.DescribeCertificateInput{
+			CertificateArn: i.CertificateArn,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Only the certificates issued by AWS
+		// with DNS validation are validated
+		if awsSDK.StringValue(certificate.Certificate.Type) != acm.CertificateTypeAmazonIssued {
+			continue
+		}
+
+		fqdns := make([]string, 0)
+		for _, o := range certificate.Certificate.DomainValidationOptions {
+			if awsSDK.StringValue(o.ValidationMethod) != acm.ValidationMethodDns || o.ResourceRecord == nil {
+				fqdns = nil
+				break
+			}
+			fqdns = append(fqdns, strings.TrimSuffix(*o.ResourceRecord.Name, "."))
+		}
+		if len(fqdns) == 0 {
+			continue
+		}
+
+		r, err := initializeResource(a, *i.CertificateArn, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		// The aws_acm_certificate_validation it's not importable
+		// so the Read needs the certificate and the records
+		err = r.Data().Set("certificate_arn", i.CertificateArn)
+		if err != nil {
+			return nil, err
+		}
+		err = r.Data().Set("validation_record_fqdns", fqdns)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func secretsmanagerSecrets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	secrets, err := a.awsr.GetSecrets(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range secrets.SecretList {
+		r, err := initializeResource(a, *i.ARN, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func ssmParameters(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	parameters, err := a.awsr.GetSSMParameters(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range parameters.Parameters {
+		r, err := initializeResource(a, *i.Name, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func codepipelines(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	pipelines, err := a.awsr.GetPipelines(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range pipelines.Pipelines {
+		r, err := initializeResource(a, *i.Name, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func codebuildProjects(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	projects, err := a.awsr.GetCodeBuildProjects(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range projects.Projects {
+		r, err := initializeResource(a, *i, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func codedeployApps(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	apps, err := a.awsr.GetCodeDeployApplications(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range apps.Applications {
+		r, err := initializeResource(a, *i, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func codedeployDeploymentGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	apps, err := a.awsr.GetCodeDeployApplications(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, app := range apps.Applications {
+		groups, err := a.awsr.GetCodeDeployDeploymentGroups(ctx, &codedeploy.ListDeploymentGroupsInput{
+			ApplicationName: app,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range groups.DeploymentGroups {
+			r, err := initializeResource(a, fmt.Sprintf("%s:%s", *app, *i), resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+// getGlueDatabaseNames returns the names of the
+// databases of the Glue Data Catalog of the account
+func getGlueDatabaseNames(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]string, error) {
+	databases, err := a.awsr.GetGlueDatabases(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+	for _, i := range databases.DatabaseList {
+		names = append(names, *i.Name)
+	}
+
+	return names, nil
+}
+
+func glueCatalogDatabases(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	names, err := getGlueDatabaseNames(ctx, a, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, n := range names {
+		r, err := initializeResource(a, fmt.Sprintf("%s:%s", a.awsr.GetAccountID(), n), resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func glueCatalogTables(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	names, err := getGlueDatabaseNames(ctx, a, resourceType, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, n := range names {
+		tables, err := a.awsr.GetGlueTables(ctx, &glue.GetTablesInput{
+			DatabaseName: awsSDK.String(n),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range tables.TableList {
+			r, err := initializeResource(a, fmt.Sprintf("%s:%s:%s", a.awsr.GetAccountID(), n, *i.Name), resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+func glueJobs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	jobs, err := a.awsr.GetGlueJobs(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range jobs.Jobs {
+		r, err := initializeResource(a, *i.Name, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func athenaWorkgroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	workgroups, err := a.awsr.GetWorkGroups(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range workgroups.WorkGroups {
+		// The primary workgroup is created
+		// by AWS and can not be deleted
+		if *i.Name == "primary" {
+			continue
+		}
+
+		r, err := initializeResource(a, *i.Name, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func athenaNamedQueries(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	queries, err := a.awsr.GetNamedQueries(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range queries.NamedQueryIds {
+		r, err := initializeResource(a, *i, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func redshiftClusters(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	clusters, err := a.awsr.GetRedshiftClusters(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range clusters.Clusters {
+		r, err := initializeResource(a, *i.ClusterIdentifier, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func redshiftParameterGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetRedshiftParameterGroups(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range groups.ParameterGroups {
+		// The default parameter groups are
+		// created by AWS and can not be modified
+		if strings.HasPrefix(*i.ParameterGroupName, "default.") {
+			continue
+		}
+
+		r, err := initializeResource(a, *i.ParameterGroupName, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func redshiftSubnetGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	groups, err := a.awsr.GetRedshiftSubnetGroups(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range groups.ClusterSubnetGroups {
+		// The default subnet group is created by AWS
+		if *i.ClusterSubnetGroupName == "default" {
+			continue
+		}
+
+		r, err := initializeResource(a, *i.ClusterSubnetGroupName, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func mskClusters(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	clusters, err := a.awsr.GetMSKClusters(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range clusters.ClusterInfoList {
+		r, err := initializeResource(a, *i.ClusterArn, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func mskConfigurations(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	configurations, err := a.awsr.GetMSKConfigurations(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range configurations.Configurations {
+		r, err := initializeResource(a, *i.Arn, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func cloudtrails(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	trails, err := a.awsr.GetTrails(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range trails.TrailList {
+		// The multi region trails are on all the
+		// regions but only managed on the home one
+		if i.HomeRegion != nil && *i.HomeRegion != a.Region() {
+			continue
+		}
+
+		r, err := initializeResource(a, *i.Name, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func configConfigurationRecorders(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	recorders, err := a.awsr.GetConfigurationRecorders(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range recorders.ConfigurationRecorders {
+		r, err := initializeResource(a, *i.Name, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func configConfigRules(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	rules, err := a.awsr.GetConfigRules(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range rules.ConfigRules {
+		// The rules created by other services
+		// (ex: Security Hub) are managed by them
+		if i.CreatedBy != nil {
+			continue
+		}
+
+		r, err := initializeResource(a, *i.ConfigRuleName, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func guarddutyDetectors(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	detectors, err := a.awsr.GetGuardDutyDetectors(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range detectors.DetectorIds {
+		r, err := initializeResource(a, *i, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func guarddutyMembers(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	detectors, err := a.awsr.GetGuardDutyDetectors(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, d := range detectors.DetectorIds {
+		members, err := a.awsr.GetGuardDutyMembers(ctx, &guardduty.ListMembersInput{
+			DetectorId: d,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range members.Members {
+			r, err := initializeResource(a, fmt.Sprintf("%s:%s", *d, *i.AccountId), resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+func efsFileSystems(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	fss, err := a.awsr.GetEFSFileSystems(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range fss.FileSystems {
+		switch awsSDK.StringValue(i.LifeCycleState) {
+		case efs.LifeCycleStateDeleting, efs.LifeCycleStateDeleted:
+			continue
+		}
+
+		r, err := initializeResource(a, *i.FileSystemId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func efsMountTargets(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	fss, err := a.awsr.GetEFSFileSystems(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, fs := range fss.FileSystems {
+		mts, err := a.awsr.GetEFSMountTargets(ctx, &efs.DescribeMountTargetsInput{
+			FileSystemId: fs.FileSystemId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range mts.MountTargets {
+			switch awsSDK.StringValue(i.LifeCycleState) {
+			case efs.LifeCycleStateDeleting, efs.LifeCycleStateDeleted:
+				continue
+			}
+
+			r, err := initializeResource(a, *i.MountTargetId, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+// getFSxFileSystems returns the FSx file systems of
+// the fsType (ex: LUSTRE) that are not being deleted
+func getFSxFileSystems(ctx context.Context, a *aws, fsType string) ([]*fsx.FileSystem, error) {
+	fss, err := a.awsr.GetFSxFileSystems(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	fileSystems := make([]*fsx.FileSystem, 0)
+	for _, i := range fss.FileSystems {
+		if awsSDK.StringValue(i.FileSystemType) != fsType {
+			continue
+		}
+
+		switch awsSDK.StringValue(i.Lifecycle) {
+		case fsx.FileSystemLifecycleDeleting, fsx.FileSystemLifecycleFailed:
+			continue
+		}
+
+		fileSystems = append(fileSystems, i)
+	}
+
+	return fileSystems, nil
+}
+
+func fsxLustreFileSystems(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	fss, err := getFSxFileSystems(ctx, a, fsx.FileSystemTypeLustre)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range fss {
+		r, err := initializeResource(a, *i.FileSystemId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func fsxWindowsFileSystems(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	fss, err := getFSxFileSystems(ctx, a, fsx.FileSystemTypeWindows)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range fss {
+		r, err := initializeResource(a, *i.FileSystemId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+// cognitoMaxResults is the maximum number of results
+// of the Cognito lists, which is required on them
+const cognitoMaxResults = 50
+
+// getCognitoUserPools returns all the Cognito user pools
+func getCognitoUserPools(ctx context.Context, a *aws) ([]*cognitoidentityprovider.UserPoolDescriptionType, error) {
+	input := &cognitoidentityprovider.ListUserPoolsInput{
+		MaxResults: awsSDK.Int64(cognitoMaxResults),
+	}
+
+	userPools := make([]*cognitoidentityprovider.UserPoolDescriptionType, 0)
+	for {
+		ups, err := a.awsr.GetCognitoUserPools(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		userPools = append(userPools, ups.UserPools...)
+
+		if awsSDK.StringValue(ups.NextToken) == "" {
+			break
+		}
+		input.NextToken = ups.NextToken
+	}
+
+	return userPools, nil
+}
+
+func cognitoUserPools(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	ups, err := getCognitoUserPools(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range ups {
+		r, err := initializeResource(a, *i.Id, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func cognitoUserPoolClients(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	ups, err := getCognitoUserPools(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, up := range ups {
+		input := &cognitoidentityprovider.ListUserPoolClientsInput{
+			UserPoolId: up.Id,
+			MaxResults: awsSDK.Int64(cognitoMaxResults),
+		}
+
+		for {
+			clients, err := a.awsr.GetCognitoUserPoolClients(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, i := range clients.UserPoolClients {
+				// The import ID is 'USER_POOL_ID/CLIENT_ID'
+				r, err := initializeResource(a, fmt.Sprintf("%s/%s", *up.Id, *i.ClientId), resourceType)
+				if err != nil {
+					return nil, err
+				}
+
+				resources = append(resources, r)
+			}
+
+			if awsSDK.StringValue(clients.NextToken) == "" {
+				break
+			}
+			input.NextToken = clients.NextToken
+		}
+	}
+
+	return resources, nil
+}
+
+func cognitoUserPoolDomains(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	ups, err := getCognitoUserPools(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, up := range ups {
+		// The domain is only on the
+		// description of the user pool
+		d, err := a.awsr.GetCognitoUserPool(ctx, &cognitoidentityprovider.DescribeUserPoolInput{
+			UserPoolId: up.Id,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, domain := range []*string{d.UserPool.Domain, d.UserPool.CustomDomain} {
+			if awsSDK.StringValue(domain) == "" {
+				continue
+			}
+
+			r, err := initializeResource(a, *domain, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+	}
+
+	return resources, nil
+}
+
+func cognitoResourceServers(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	ups, err := getCognitoUserPools(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, up := range ups {
+		input := &cognitoidentityprovider.ListResourceServersInput{
+			UserPoolId: up.Id,
+			MaxResults: awsSDK.Int64(cognitoMaxResults),
+		}
+
+		for {
+			servers, err := a.awsr.GetCognitoResourceServers(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, i := range servers.ResourceServers {
+				// The ID is 'USER_POOL_ID|IDENTIFIER'
+				r, err := initializeResource(a, fmt.Sprintf("%s|%s", *up.Id, *i.Identifier), resourceType)
+				if err != nil {
+					return nil, err
+				}
+
+				resources = append(resources, r)
+			}
+
+			if awsSDK.StringValue(servers.NextToken) == "" {
+				break
+			}
+			input.NextToken = servers.NextToken
+		}
+	}
+
+	return resources, nil
+}
+
+// getCognitoIdentityPools returns all the Cognito identity pools
+func getCognitoIdentityPools(ctx context.Context, a *aws) ([]*cognitoidentity.IdentityPoolShortDescription, error) {
+	input := &cognitoidentity.ListIdentityPoolsInput{
+		MaxResults: awsSDK.Int64(cognitoMaxResults),
+	}
+
+	identityPools := make([]*cognitoidentity.IdentityPoolShortDescription, 0)
+	for {
+		ips, err := a.awsr.GetCognitoIdentityPools(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		identityPools = append(identityPools, ips.IdentityPools...)
+
+		if awsSDK.StringValue(ips.NextToken) == "" {
+			break
+		}
+		input.NextToken = ips.NextToken
+	}
+
+	return identityPools, nil
+}
+
+func cognitoIdentityPools(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	ips, err := getCognitoIdentityPools(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range ips {
+		r, err := initializeResource(a, *i.IdentityPoolId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func cognitoIdentityPoolRolesAttachments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	ips, err := getCognitoIdentityPools(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range ips {
+		roles, err := a.awsr.GetCognitoIdentityPoolRoles(ctx, &cognitoidentity.GetIdentityPoolRolesInput{
+			IdentityPoolId: i.IdentityPoolId,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(roles.Roles) == 0 {
+			continue
+		}
+
+		r, err := initializeResource(a, *i.IdentityPoolId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		// The aws_cognito_identity_pool_roles_attachment it's not
+		// importable so the Read needs the identity pool to find it
+		err = r.Data().Set("identity_pool_id", i.IdentityPoolId)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+// getAppsyncGraphqlAPIs returns all the AppSync GraphQL APIs
+func getAppsyncGraphqlAPIs(ctx context.Context, a *aws) ([]*appsync.GraphqlApi, error) {
+	input := &appsync.ListGraphqlApisInput{}
+
+	apis := make([]*appsync.GraphqlApi, 0)
+	for {
+		gas, err := a.awsr.GetAppsyncGraphqlApis(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		apis = append(apis, gas.GraphqlApis...)
+
+		if awsSDK.StringValue(gas.NextToken) == "" {
+			break
+		}
+		input.NextToken = gas.NextToken
+	}
+
+	return apis, nil
+}
+
+func appsyncGraphqlAPIs(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	apis, err := getAppsyncGraphqlAPIs(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range apis {
+		r, err := initializeResource(a, *i.ApiId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+// appsyncGraphqlAPIAttributes returns the schema of the
+// GraphQL API with the id, which the TF provider does not read
+func appsyncGraphqlAPIAttributes(ctx context.Context, a *aws, id string) (map[string]string, error) {
+	s, err := a.awsr.GetAppsyncIntrospectionSchema(ctx, &appsync.GetIntrospectionSchemaInput{
+		ApiId:  awsSDK.String(id),
+		Format: awsSDK.String(appsync.OutputTypeSdl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.Schema) == 0 {
+		return nil, nil
+	}
+
+	return map[string]string{"schema": string(s.Schema)}, nil
+}
+
+func appsyncDatasources(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	apis, err := getAppsyncGraphqlAPIs(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, api := range apis {
+		input := &appsync.ListDataSourcesInput{
+			ApiId: api.ApiId,
+		}
+
+		for {
+			dss, err := a.awsr.GetAppsyncDataSources(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, i := range dss.DataSources {
+				// The ID is 'API_ID-NAME'
+				r, err := initializeResource(a, fmt.Sprintf("%s-%s", *api.ApiId, *i.Name), resourceType)
+				if err != nil {
+					return nil, err
+				}
+
+				resources = append(resources, r)
+			}
+
+			if awsSDK.StringValue(dss.NextToken) == "" {
+				break
+			}
+			input.NextToken = dss.NextToken
+		}
+	}
+
+	return resources, nil
+}
+
+// getAppsyncTypes returns all the types of the AppSync GraphQL API
+func getAppsyncTypes(ctx context.Context, a *aws, apiID *string) ([]*appsync.Type, error) {
+	input := &appsync.ListTypesInput{
+		ApiId:  apiID,
+		Format: awsSDK.String(appsync.TypeDefinitionFormatSdl),
+	}
+
+	types := make([]*appsync.Type, 0)
+	for {
+		ts, err := a.awsr.GetAppsyncTypes(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, ts.Types...)
+
+		if awsSDK.StringValue(ts.NextToken) == "" {
+			break
+		}
+		input.NextToken = ts.NextToken
+	}
+
+	return types, nil
+}
+
+func appsyncResolvers(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	apis, err := getAppsyncGraphqlAPIs(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, api := range apis {
+		// The resolvers are listed by type
+		types, err := getAppsyncTypes(ctx, a, api.ApiId)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range types {
+			input := &appsync.ListResolversInput{
+				ApiId:    api.ApiId,
+				TypeName: t.Name,
+			}
+
+			for {
+				rs, err := a.awsr.GetAppsyncResolvers(ctx, input)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, i := range rs.Resolvers {
+					// The ID is 'API_ID-TYPE_NAME-FIELD_NAME'
+					r, err := initializeResource(a, fmt.Sprintf("%s-%s-%s", *api.ApiId, *i.TypeName, *i.FieldName), resourceType)
+					if err != nil {
+						return nil, err
+					}
+
+					resources = append(resources, r)
+				}
+
+				if awsSDK.StringValue(rs.NextToken) == "" {
+					break
+				}
+				input.NextToken = rs.NextToken
+			}
+		}
+	}
+
+	return resources, nil
+}
+
+// organizationsTypes are the types of AWS Organizations, which
+// need the credentials of the management account so they are
+// only imported with the Organizations of the Options
+var organizationsTypes = []ResourceType{
+	OrganizationsOrganization,
+	OrganizationsAccount,
+	OrganizationsOrganizationalUnit,
+	OrganizationsPolicy,
+	OrganizationsPolicyAttachment,
+}
+
+// hasResourceType checks if the rt is on the rts
+func hasResourceType(rts []ResourceType, rt ResourceType) bool {
+	for _, r := range rts {
+		if r == rt {
+			return true
+		}
+	}
+	return false
+}
+
+// isOrganizationsNotInUse checks if the err is because
+// the account is not a member of an Organization
+func isOrganizationsNotInUse(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == organizations.ErrCodeAWSOrganizationsNotInUseException
+}
+
+func organizationsOrganizations(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	o, err := a.awsr.GetOrganization(ctx, &organizations.DescribeOrganizationInput{})
+	if err != nil {
+		if isOrganizationsNotInUse(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	r, err := initializeResource(a, *o.Organization.Id, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	return []provider.Resource{r}, nil
+}
+
+func organizationsAccounts(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	o, err := a.awsr.GetOrganization(ctx, &organizations.DescribeOrganizationInput{})
+	if err != nil {
+		if isOrganizationsNotInUse(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	input := &organizations.ListAccountsInput{}
+
+	resources := make([]provider.Resource, 0)
+	for {
+		accounts, err := a.awsr.GetOrganizationsAccounts(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range accounts.Accounts {
+			// The management account is the one
+			// that creates the organization
+			if awsSDK.StringValue(i.Id) == awsSDK.StringValue(o.Organization.MasterAccountId) {
+				continue
+			}
+
+			r, err := initializeResource(a, *i.Id, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+
+		if awsSDK.StringValue(accounts.NextToken) == "" {
+			break
+		}
+		input.NextToken = accounts.NextToken
+	}
+
+	return resources, nil
+}
+
+// getOrganizationsOrganizationalUnits returns all the organizational
+// units of the parent and of their children, recursively
+func getOrganizationsOrganizationalUnits(ctx context.Context, a *aws, parent string) ([]*organizations.OrganizationalUnit, error) {
+	input := &organizations.ListOrganizationalUnitsForParentInput{
+		ParentId: awsSDK.String(parent),
+	}
+
+	ous := make([]*organizations.OrganizationalUnit, 0)
+	for {
+		res, err := a.awsr.GetOrganizationsOrganizationalUnits(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ou := range res.OrganizationalUnits {
+			children, err := getOrganizationsOrganizationalUnits(ctx, a, *ou.Id)
+			if err != nil {
+				return nil, err
+			}
+			ous = append(ous, ou)
+			ous = append(ous, children...)
+		}
+
+		if awsSDK.StringValue(res.NextToken) == "" {
+			break
+		}
+		input.NextToken = res.NextToken
+	}
+
+	return ous, nil
+}
+
+func organizationsOrganizationalUnits(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	input := &organizations.ListRootsInput{}
+
+	resources := make([]provider.Resource, 0)
+	for {
+		roots, err := a.awsr.GetOrganizationsRoots(ctx, input)
+		if err != nil {
+			if isOrganizationsNotInUse(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		for _, root := range roots.Roots {
+			ous, err := getOrganizationsOrganizationalUnits(ctx, a, *root.Id)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, i := range ous {
+				r, err := initializeResource(a, *i.Id, resourceType)
+				if err != nil {
+					return nil, err
+				}
+
+				resources = append(resources, r)
+			}
+		}
+
+		if awsSDK.StringValue(roots.NextToken) == "" {
+			break
+		}
+		input.NextToken = roots.NextToken
+	}
+
+	return resources, nil
+}
+
+// getOrganizationsPolicies returns the service control policies,
+// without the AWS managed ones (ex: FullAWSAccess)
+func getOrganizationsPolicies(ctx context.Context, a *aws) ([]*organizations.PolicySummary, error) {
+	input := &organizations.ListPoliciesInput{
+		Filter: awsSDK.String(organizations.PolicyTypeServiceControlPolicy),
+	}
+
+	policies := make([]*organizations.PolicySummary, 0)
+	for {
+		ps, err := a.awsr.GetOrganizationsPolicies(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range ps.Policies {
+			if !awsSDK.BoolValue(p.AwsManaged) {
+				policies = append(policies, p)
+			}
+		}
+
+		if awsSDK.StringValue(ps.NextToken) == "" {
+			break
+		}
+		input.NextToken = ps.NextToken
+	}
+
+	return policies, nil
+}
+
+func organizationsPolicies(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	ps, err := getOrganizationsPolicies(ctx, a)
+	if err != nil {
+		if isOrganizationsNotInUse(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range ps {
+		r, err := initializeResource(a, *i.Id, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func organizationsPolicyAttachments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	ps, err := getOrganizationsPolicies(ctx, a)
+	if err != nil {
+		if isOrganizationsNotInUse(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, p := range ps {
+		input := &organizations.ListTargetsForPolicyInput{
+			PolicyId: p.Id,
+		}
+
+		for {
+			targets, err := a.awsr.GetOrganizationsTargetsForPolicy(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, i := range targets.Targets {
+				// The ID is 'TARGET_ID:POLICY_ID'
+				r, err := initializeResource(a, fmt.Sprintf("%s:%s", *i.TargetId, *p.Id), resourceType)
+				if err != nil {
+					return nil, err
+				}
+
+				resources = append(resources, r)
+			}
+
+			if awsSDK.StringValue(targets.NextToken) == "" {
+				break
+			}
+			input.NextToken = targets.NextToken
+		}
+	}
+
+	return resources, nil
+}
+
+func batchComputeEnvironments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	input := &batch.DescribeComputeEnvironmentsInput{}
+
+	resources := make([]provider.Resource, 0)
+	for {
+		ces, err := a.awsr.GetBatchComputeEnvironments(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range ces.ComputeEnvironments {
+			r, err := initializeResource(a, *i.ComputeEnvironmentName, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+
+		if awsSDK.StringValue(ces.NextToken) == "" {
+			break
+		}
+		input.NextToken = ces.NextToken
+	}
+
+	return resources, nil
+}
+
+func batchJobQueues(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	input := &batch.DescribeJobQueuesInput{}
+
+	resources := make([]provider.Resource, 0)
+	for {
+		jqs, err := a.awsr.GetBatchJobQueues(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range jqs.JobQueues {
+			r, err := initializeResource(a, *i.JobQueueArn, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+
+		if awsSDK.StringValue(jqs.NextToken) == "" {
+			break
+		}
+		input.NextToken = jqs.NextToken
+	}
+
+	return resources, nil
+}
+
+func batchJobDefinitions(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	// The INACTIVE are the deregistered revisions
+	input := &batch.DescribeJobDefinitionsInput{
+		Status: awsSDK.String("ACTIVE"),
+	}
+
+	resources := make([]provider.Resource, 0)
+	for {
+		jds, err := a.awsr.GetBatchJobDefinitions(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range jds.JobDefinitions {
+			r, err := initializeResource(a, *i.JobDefinitionArn, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+
+		if awsSDK.StringValue(jds.NextToken) == "" {
+			break
+		}
+		input.NextToken = jds.NextToken
+	}
+
+	return resources, nil
+}
+
+func emrClusters(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	// The terminated clusters are listed for 2 months
+	// so only the active ones are imported
+	input := &emr.ListClustersInput{
+		ClusterStates: awsSDK.StringSlice([]string{
+			emr.ClusterStateStarting,
+			emr.ClusterStateBootstrapping,
+			emr.ClusterStateRunning,
+			emr.ClusterStateWaiting,
+		}),
+	}
+
+	resources := make([]provider.Resource, 0)
+	for {
+		cs, err := a.awsr.GetEMRClusters(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range cs.Clusters {
+			r, err := initializeResource(a, *i.Id, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+
+		if awsSDK.StringValue(cs.Marker) == "" {
+			break
+		}
+		input.Marker = cs.Marker
+	}
+
+	return resources, nil
+}
+
+func sagemakerNotebookInstances(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	input := &sagemaker.ListNotebookInstancesInput{}
+
+	resources := make([]provider.Resource, 0)
+	for {
+		nis, err := a.awsr.GetSagemakerNotebookInstances(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range nis.NotebookInstances {
+			r, err := initializeResource(a, *i.NotebookInstanceName, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+
+		if awsSDK.StringValue(nis.NextToken) == "" {
+			break
+		}
+		input.NextToken = nis.NextToken
+	}
+
+	return resources, nil
+}
+
+func sagemakerEndpoints(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	input := &sagemaker.ListEndpointsInput{}
+
+	resources := make([]provider.Resource, 0)
+	for {
+		es, err := a.awsr.GetSagemakerEndpoints(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range es.Endpoints {
+			r, err := initializeResource(a, *i.EndpointName, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+
+		if awsSDK.StringValue(es.NextToken) == "" {
+			break
+		}
+		input.NextToken = es.NextToken
+	}
+
+	return resources, nil
+}
+
+// getGlobalacceleratorAccelerators returns all the Global Accelerator accelerators
+func getGlobalacceleratorAccelerators(ctx context.Context, a *aws) ([]*globalaccelerator.Accelerator, error) {
+	input := &globalaccelerator.ListAcceleratorsInput{}
+
+	accelerators := make([]*globalaccelerator.Accelerator, 0)
+	for {
+		as, err := a.awsr.GetGlobalacceleratorAccelerators(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		accelerators = append(accelerators, as.Accelerators...)
+
+		if awsSDK.StringValue(as.NextToken) == "" {
+			break
+		}
+		input.NextToken = as.NextToken
+	}
+
+	return accelerators, nil
+}
+
+func globalacceleratorAccelerators(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	accelerators, err := getGlobalacceleratorAccelerators(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range accelerators {
+		r, err := initializeResource(a, *i.AcceleratorArn, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+// getGlobalacceleratorListeners returns all the listeners of all the accelerators
+func getGlobalacceleratorListeners(ctx context.Context, a *aws) ([]*globalaccelerator.Listener, error) {
+	accelerators, err := getGlobalacceleratorAccelerators(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	listeners := make([]*globalaccelerator.Listener, 0)
+	for _, acc := range accelerators {
+		input := &globalaccelerator.ListListenersInput{
+			AcceleratorArn: acc.AcceleratorArn,
+		}
+
+		for {
+			ls, err := a.awsr.GetGlobalacceleratorListeners(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+
+			listeners = append(listeners, ls.Listeners...)
+
+			if awsSDK.StringValue(ls.NextToken) == "" {
+				break
+			}
+			input.NextToken = ls.NextToken
+		}
+	}
+
+	return listeners, nil
+}
+
+func globalacceleratorListeners(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	listeners, err := getGlobalacceleratorListeners(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range listeners {
+		r, err := initializeResource(a, *i.ListenerArn, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func globalacceleratorEndpointGroups(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	listeners, err := getGlobalacceleratorListeners(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, l := range listeners {
+		input := &globalaccelerator.ListEndpointGroupsInput{
+			ListenerArn: l.ListenerArn,
+		}
+
+		for {
+			egs, err := a.awsr.GetGlobalacceleratorEndpointGroups(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, i := range egs.EndpointGroups {
+				r, err := initializeResource(a, *i.EndpointGroupArn, resourceType)
+				if err != nil {
+					return nil, err
+				}
+
+				resources = append(resources, r)
+			}
+
+			if awsSDK.StringValue(egs.NextToken) == "" {
+				break
+			}
+			input.NextToken = egs.NextToken
+		}
+	}
+
+	return resources, nil
+}
+
+func backupVaults(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	input := &backup.ListBackupVaultsInput{}
+
+	resources := make([]provider.Resource, 0)
+	for {
+		vs, err := a.awsr.GetBackupVaults(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range vs.BackupVaultList {
+			r, err := initializeResource(a, *i.BackupVaultName, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+
+		if awsSDK.StringValue(vs.NextToken) == "" {
+			break
+		}
+		input.NextToken = vs.NextToken
+	}
+
+	return resources, nil
+}
+
+// getBackupPlans returns all the AWS Backup plans
+func getBackupPlans(ctx context.Context, a *aws) ([]*backup.PlansListMember, error) {
+	input := &backup.ListBackupPlansInput{}
+
+	plans := make([]*backup.PlansListMember, 0)
+	for {
+		ps, err := a.awsr.GetBackupPlans(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		plans = append(plans, ps.BackupPlansList...)
+
+		if awsSDK.StringValue(ps.NextToken) == "" {
+			break
+		}
+		input.NextToken = ps.NextToken
+	}
+
+	return plans, nil
+}
+
+func backupPlans(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	plans, err := getBackupPlans(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range plans {
+		r, err := initializeResource(a, *i.BackupPlanId, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func backupSelections(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	plans, err := getBackupPlans(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, p := range plans {
+		input := &backup.ListBackupSelectionsInput{
+			BackupPlanId: p.BackupPlanId,
+		}
+
+		for {
+			ss, err := a.awsr.GetBackupSelections(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, i := range ss.BackupSelectionsList {
+				// The aws_backup_selection import expects
+				// the ID to be <plan-id>|<selection-id>
+				r, err := initializeResource(a, fmt.Sprintf("%s|%s", *i.BackupPlanId, *i.SelectionId), resourceType)
+				if err != nil {
+					return nil, err
+				}
+
+				resources = append(resources, r)
+			}
+
+			if awsSDK.StringValue(ss.NextToken) == "" {
+				break
+			}
+			input.NextToken = ss.NextToken
+		}
+	}
+
+	return resources, nil
+}
+
+func elasticBeanstalkApplications(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	apps, err := a.awsr.GetElasticBeanstalkApplications(ctx, &elasticbeanstalk.DescribeApplicationsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]provider.Resource, 0)
+	for _, i := range apps.Applications {
+		r, err := initializeResource(a, *i.ApplicationName, resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, r)
+	}
+
+	return resources, nil
+}
+
+func elasticBeanstalkEnvironments(ctx context.Context, a *aws, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	input := &elasticbeanstalk.DescribeEnvironmentsInput{
+		IncludeDeleted: awsSDK.Bool(false),
+	}
+
+	resources := make([]provider.Resource, 0)
+	for {
+		envs, err := a.awsr.GetElasticBeanstalkEnvironments(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range envs.Environments {
+			status := awsSDK.StringValue(i.Status)
+			if status == elasticbeanstalk.EnvironmentStatusTerminating || status == elasticbeanstalk.EnvironmentStatusTerminated {
+				continue
+			}
+
+			r, err := initializeResource(a, *i.EnvironmentId, resourceType)
+			if err != nil {
+				return nil, err
+			}
+
+			resources = append(resources, r)
+		}
+
+		if awsSDK.StringValue(envs.NextToken) == "" {
+			break
+		}
+		input.NextToken = envs.NextToken
+	}
+
+	return resources, nil
+}
+
+// elasticBeanstalkEnvironmentAttributes returns the option settings of the
+// environment that are not the default ones as its 'setting', as the TF
+// provider only reads the ones already on the state and leaves all the
+// others (mostly defaults) on the computed 'all_settings'
+func elasticBeanstalkEnvironmentAttributes(ctx context.Context, a *aws, id string) (map[string]string, error) {
+	envs, err := a.awsr.GetElasticBeanstalkEnvironments(ctx, &elasticbeanstalk.DescribeEnvironmentsInput{
+		EnvironmentIds: []*string{awsSDK.String(id)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(envs.Environments) == 0 {
+		return nil, nil
+	}
+	env := envs.Environments[0]
+
+	opts, err := a.awsr.GetElasticBeanstalkConfigurationOptions(ctx, &elasticbeanstalk.DescribeConfigurationOptionsInput{
+		ApplicationName: env.ApplicationName,
+		EnvironmentName: env.EnvironmentName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := make(map[string]string)
+	for _, o := range opts.Options {
+		defaults[fmt.Sprintf("%s:%s", awsSDK.StringValue(o.Namespace), awsSDK.StringValue(o.Name))] = awsSDK.StringValue(o.DefaultValue)
+	}
+
+	sts, err := a.awsr.GetElasticBeanstalkConfigurationSettings(ctx, &elasticbeanstalk.DescribeConfigurationSettingsInput{
+		ApplicationName: env.ApplicationName,
+		EnvironmentName: env.EnvironmentName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make([]*elasticbeanstalk.ConfigurationOptionSetting, 0)
+	for _, cs := range sts.ConfigurationSettings {
+		settings = append(settings, cs.OptionSettings...)
+	}
+
+	hash := a.tfProvider.ResourcesMap[ElasticBeanstalkEnvironment.String()].Schema["setting"].Set
+
+	return elasticBeanstalkSettingsAttributes(hash, settings, defaults), nil
+}
+
+// elasticBeanstalkSettingsAttributes returns the attributes of the 'setting'
+// with the settings that have not the value of the defaults, by the
+// 'namespace:name', with the index of each one calculated by the hash of
+// the TF provider, as it normalizes the values (ex: the lists of subnets)
+func elasticBeanstalkSettingsAttributes(hash schema.SchemaSetFunc, settings []*elasticbeanstalk.ConfigurationOptionSetting, defaults map[string]string) map[string]string {
+	attrs := make(map[string]string)
+	var count int
+	for _, o := range settings {
+		ns, name, value := awsSDK.StringValue(o.Namespace), awsSDK.StringValue(o.OptionName), awsSDK.StringValue(o.Value)
+
+		// The CloudFormation parameters are set by
+		// Elastic Beanstalk and can not be configured
+		if ns == "" || name == "" || value == "" || ns == "aws:cloudformation:template:parameter" {
+			continue
+		}
+
+		if d, ok := defaults[fmt.Sprintf("%s:%s", ns, name)]; ok && d == value {
+			continue
+		}
+
+		resource := awsSDK.StringValue(o.ResourceName)
+		key := fmt.Sprintf("setting.%d", hash(map[string]interface{}{
+			"namespace": ns,
+			"name":      name,
+			"value":     value,
+			"resource":  resource,
+		}))
+		attrs[key+".namespace"] = ns
+		attrs[key+".name"] = name
+		attrs[key+".value"] = value
+		if resource != "" {
+			attrs[key+".resource"] = resource
+		}
+		count++
+	}
+
+	if count == 0 {
+		return nil
+	}
+	attrs["setting.#"] = fmt.Sprintf("%d", count)
+
+	return attrs
 }
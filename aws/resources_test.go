@@ -0,0 +1,98 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elasticbeanstalk"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tfaws "github.com/terraform-providers/terraform-provider-aws/aws"
+
+	"github.com/cycloidio/terracognita/aws/reader"
+	"github.com/cycloidio/terracognita/cache"
+)
+
+func TestElasticBeanstalkSettingsAttributes(t *testing.T) {
+	s := tfaws.Provider().(*schema.Provider).ResourcesMap["aws_elastic_beanstalk_environment"].Schema["setting"]
+
+	settings := []*elasticbeanstalk.ConfigurationOptionSetting{
+		{Namespace: awsSDK.String("aws:ec2:vpc"), OptionName: awsSDK.String("Subnets"), Value: awsSDK.String("subnet-b,subnet-a")},
+		{Namespace: awsSDK.String("aws:elasticbeanstalk:application:environment"), OptionName: awsSDK.String("CONFIG"), Value: awsSDK.String(`{"b": 1,  "a": 2}`)},
+		{Namespace: awsSDK.String("aws:autoscaling:asg"), OptionName: awsSDK.String("MinSize"), Value: awsSDK.String("1")},
+		{Namespace: awsSDK.String("aws:cloudformation:template:parameter"), OptionName: awsSDK.String("InstancePort"), Value: awsSDK.String("80")},
+	}
+	defaults := map[string]string{"aws:autoscaling:asg:MinSize": "1"}
+
+	// The indexes have to be the ones of the settings of the
+	// HCL, with the values normalized (sorted and compacted)
+	subnets := s.Set(map[string]interface{}{"namespace": "aws:ec2:vpc", "name": "Subnets", "value": "subnet-a,subnet-b"})
+	config := s.Set(map[string]interface{}{"namespace": "aws:elasticbeanstalk:application:environment", "name": "CONFIG", "value": `{"a":2,"b":1}`})
+
+	assert.Equal(t, map[string]string{
+		"setting.#": "2",
+		fmt.Sprintf("setting.%d.namespace", subnets): "aws:ec2:vpc",
+		fmt.Sprintf("setting.%d.name", subnets):      "Subnets",
+		fmt.Sprintf("setting.%d.value", subnets):     "subnet-b,subnet-a",
+		fmt.Sprintf("setting.%d.namespace", config):  "aws:elasticbeanstalk:application:environment",
+		fmt.Sprintf("setting.%d.name", config):       "CONFIG",
+		fmt.Sprintf("setting.%d.value", config):      `{"b": 1,  "a": 2}`,
+	}, elasticBeanstalkSettingsAttributes(s.Set, settings, defaults))
+}
+
+// targetsReader is a reader.Reader with
+// only the Target Groups and their targets
+type targetsReader struct {
+	reader.Reader
+
+	groups  []*elbv2.TargetGroup
+	targets map[string][]*elbv2.TargetHealthDescription
+}
+
+func (r targetsReader) GetTargetGroups(ctx context.Context, input *elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	return &elbv2.DescribeTargetGroupsOutput{TargetGroups: r.groups}, nil
+}
+
+func (r targetsReader) GetTargetHealth(ctx context.Context, input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	return &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: r.targets[*input.TargetGroupArn]}, nil
+}
+
+func TestLBTargetGroupAttachments(t *testing.T) {
+	const arn = "arn:aws:elasticloadbalancing:eu-west-1:123456789012:targetgroup/ecs/73e2d6bc24d8a067"
+
+	a := &aws{
+		awsr: targetsReader{
+			groups: []*elbv2.TargetGroup{{TargetGroupArn: awsSDK.String(arn)}},
+			targets: map[string][]*elbv2.TargetHealthDescription{
+				arn: {
+					// The dynamic ports of ECS register
+					// the same instance on several ports
+					{Target: &elbv2.TargetDescription{Id: awsSDK.String("i-123"), Port: awsSDK.Int64(32768)}},
+					{Target: &elbv2.TargetDescription{Id: awsSDK.String("i-123"), Port: awsSDK.Int64(32769)}},
+					{Target: &elbv2.TargetDescription{Id: awsSDK.String("10.0.0.1"), Port: awsSDK.Int64(80), AvailabilityZone: awsSDK.String("all")}},
+				},
+			},
+		},
+		tfProvider: tfaws.Provider().(*schema.Provider),
+		cache:      cache.New(),
+	}
+
+	resources, err := lbTargetGroupAttachments(context.Background(), a, "aws_lb_target_group_attachment", nil)
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+
+	assert.Equal(t, arn+"-i-123-32768", resources[0].ID())
+	assert.Equal(t, arn+"-i-123-32769", resources[1].ID())
+	assert.Equal(t, arn+"-10.0.0.1-80-all", resources[2].ID())
+
+	for i, p := range []int{32768, 32769, 80} {
+		assert.Equal(t, arn, resources[i].Data().Get("target_group_arn"))
+		assert.Equal(t, p, resources[i].Data().Get("port"))
+	}
+	assert.Equal(t, "i-123", resources[1].Data().Get("target_id"))
+	assert.Equal(t, "all", resources[2].Data().Get("availability_zone"))
+}
@@ -6,11 +6,11 @@ import (
 	"fmt"
 )
 
-const _ResourceTypeName = "aws_instanceaws_vpcaws_security_groupaws_subnetaws_ebs_volumeaws_elasticache_clusteraws_elbaws_albaws_db_instanceaws_s3_bucketaws_cloudfront_distributionaws_cloudfront_origin_access_identityaws_cloudfront_public_keyaws_iam_account_aliasaws_iam_account_password_policyaws_iam_groupaws_iam_group_membershipaws_iam_group_policyaws_iam_group_policy_attachmentaws_iam_instance_profileaws_iam_openid_connect_provideraws_iam_policyaws_iam_roleaws_iam_role_policyaws_iam_role_policy_attachmentaws_iam_saml_provideraws_iam_server_certificateaws_iam_useraws_iam_user_group_membershipaws_iam_user_policyaws_iam_user_policy_attachmentaws_route53_delegation_setaws_route53_health_checkaws_route53_query_logaws_route53_recordaws_route53_zoneaws_route53_zone_associationaws_route53_resolver_endpointaws_route53_resolver_rule_associationaws_ses_active_receipt_rule_setaws_ses_domain_identityaws_ses_domain_identity_verificationaws_ses_domain_dkimaws_ses_domain_mail_fromaws_ses_receipt_filteraws_ses_receipt_ruleaws_ses_receipt_rule_setaws_ses_configuration_setaws_ses_identity_notification_topicaws_ses_templateaws_launch_configurationaws_launch_templateaws_autoscaling_group"
+const _ResourceTypeName = "aws_instanceaws_vpcaws_security_groupaws_subnetaws_ebs_volumeaws_elasticache_clusteraws_elasticache_replication_groupaws_elasticache_parameter_groupaws_elasticache_subnet_groupaws_elbaws_albaws_lbaws_lb_listeneraws_lb_listener_ruleaws_lb_target_groupaws_lb_target_group_attachmentaws_db_instanceaws_db_parameter_groupaws_db_option_groupaws_db_subnet_groupaws_rds_clusteraws_rds_cluster_instanceaws_rds_cluster_parameter_groupaws_s3_bucketaws_s3_bucket_public_access_blockaws_cloudfront_distributionaws_cloudfront_origin_access_identityaws_cloudfront_public_keyaws_iam_account_aliasaws_iam_account_password_policyaws_iam_groupaws_iam_group_membershipaws_iam_group_policyaws_iam_group_policy_attachmentaws_iam_instance_profileaws_iam_openid_connect_provideraws_iam_policyaws_iam_roleaws_iam_role_policyaws_iam_role_policy_attachmentaws_iam_saml_provideraws_iam_server_certificateaws_iam_useraws_iam_user_group_membershipaws_iam_user_policyaws_iam_user_policy_attachmentaws_route53_delegation_setaws_route53_health_checkaws_route53_query_logaws_route53_recordaws_route53_zoneaws_route53_zone_associationaws_route53_resolver_endpointaws_route53_resolver_rule_associationaws_ses_active_receipt_rule_setaws_ses_domain_identityaws_ses_domain_identity_verificationaws_ses_domain_dkimaws_ses_domain_mail_fromaws_ses_receipt_filteraws_ses_receipt_ruleaws_ses_receipt_rule_setaws_ses_configuration_setaws_ses_identity_notification_topicaws_ses_templateaws_launch_configurationaws_launch_templateaws_autoscaling_groupaws_autoscaling_policyaws_autoscaling_scheduleaws_ec2_transit_gatewayaws_ec2_transit_gateway_vpc_attachmentaws_ec2_transit_gateway_route_tableaws_ec2_transit_gateway_route_table_associationaws_ec2_transit_gateway_route_table_propagationaws_vpc_peering_connectionaws_cloudwatch_metric_alarmaws_cloudwatch_dashboardaws_cloudwatch_log_groupaws_cloudwatch_log_metric_filteraws_cloudwatch_event_ruleaws_cloudwatch_event_targetaws_sfn_state_machineaws_acm_certificateaws_acm_certificate_validationaws_secretsmanager_secretaws_ssm_parameteraws_codepipelineaws_codebuild_projectaws_codedeploy_appaws_codedeploy_deployment_groupaws_glue_catalog_databaseaws_glue_catalog_tableaws_glue_jobaws_athena_workgroupaws_athena_named_queryaws_redshift_clusteraws_redshift_parameter_groupaws_redshift_subnet_groupaws_msk_clusteraws_msk_configurationaws_cloudtrailaws_config_configuration_recorderaws_config_config_ruleaws_guardduty_detectoraws_guardduty_memberaws_internet_gatewayaws_nat_gatewayaws_route_tableaws_route_table_associationaws_network_aclaws_network_acl_ruleaws_vpc_endpointaws_efs_file_systemaws_efs_mount_targetaws_fsx_lustre_file_systemaws_fsx_windows_file_systemaws_cognito_user_poolaws_cognito_user_pool_clientaws_cognito_user_pool_domainaws_cognito_resource_serveraws_cognito_identity_poolaws_cognito_identity_pool_roles_attachmentaws_appsync_graphql_apiaws_appsync_datasourceaws_appsync_resolveraws_organizations_organizationaws_organizations_accountaws_organizations_organizational_unitaws_organizations_policyaws_organizations_policy_attachmentaws_batch_compute_environmentaws_batch_job_queueaws_batch_job_definitionaws_emr_clusteraws_sagemaker_notebook_instanceaws_sagemaker_endpointaws_globalaccelerator_acceleratoraws_globalaccelerator_listeneraws_globalaccelerator_endpoint_groupaws_backup_vaultaws_backup_planaws_backup_selectionaws_elastic_beanstalk_applicationaws_elastic_beanstalk_environment"
 
-var _ResourceTypeIndex = [...]uint16{0, 12, 19, 37, 47, 61, 84, 91, 98, 113, 126, 153, 190, 215, 236, 267, 280, 304, 324, 355, 379, 410, 424, 436, 455, 485, 506, 532, 544, 573, 592, 622, 648, 672, 693, 711, 727, 755, 784, 821, 852, 875, 911, 930, 954, 976, 996, 1020, 1045, 1080, 1096, 1120, 1139, 1160}
+var _ResourceTypeIndex = [...]uint16{0, 12, 19, 37, 47, 61, 84, 117, 148, 176, 183, 190, 196, 211, 231, 250, 280, 295, 317, 336, 355, 370, 394, 425, 438, 471, 498, 535, 560, 581, 612, 625, 649, 669, 700, 724, 755, 769, 781, 800, 830, 851, 877, 889, 918, 937, 967, 993, 1017, 1038, 1056, 1072, 1100, 1129, 1166, 1197, 1220, 1256, 1275, 1299, 1321, 1341, 1365, 1390, 1425, 1441, 1465, 1484, 1505, 1527, 1551, 1574, 1612, 1647, 1694, 1741, 1767, 1794, 1818, 1842, 1874, 1899, 1926, 1947, 1966, 1996, 2021, 2038, 2054, 2075, 2093, 2124, 2149, 2171, 2183, 2203, 2225, 2245, 2273, 2298, 2313, 2334, 2348, 2381, 2403, 2425, 2445, 2465, 2480, 2495, 2522, 2537, 2557, 2573, 2592, 2612, 2638, 2665, 2686, 2714, 2742, 2769, 2794, 2836, 2859, 2881, 2901, 2931, 2956, 2993, 3017, 3052, 3081, 3100, 3124, 3139, 3170, 3192, 3225, 3255, 3291, 3307, 3322, 3342, 3375, 3408}
 
-const _ResourceTypeLowerName = "aws_instanceaws_vpcaws_security_groupaws_subnetaws_ebs_volumeaws_elasticache_clusteraws_elbaws_albaws_db_instanceaws_s3_bucketaws_cloudfront_distributionaws_cloudfront_origin_access_identityaws_cloudfront_public_keyaws_iam_account_aliasaws_iam_account_password_policyaws_iam_groupaws_iam_group_membershipaws_iam_group_policyaws_iam_group_policy_attachmentaws_iam_instance_profileaws_iam_openid_connect_provideraws_iam_policyaws_iam_roleaws_iam_role_policyaws_iam_role_policy_attachmentaws_iam_saml_provideraws_iam_server_certificateaws_iam_useraws_iam_user_group_membershipaws_iam_user_policyaws_iam_user_policy_attachmentaws_route53_delegation_setaws_route53_health_checkaws_route53_query_logaws_route53_recordaws_route53_zoneaws_route53_zone_associationaws_route53_resolver_endpointaws_route53_resolver_rule_associationaws_ses_active_receipt_rule_setaws_ses_domain_identityaws_ses_domain_identity_verificationaws_ses_domain_dkimaws_ses_domain_mail_fromaws_ses_receipt_filteraws_ses_receipt_ruleaws_ses_receipt_rule_setaws_ses_configuration_setaws_ses_identity_notification_topicaws_ses_templateaws_launch_configurationaws_launch_templateaws_autoscaling_group"
+const _ResourceTypeLowerName = "aws_instanceaws_vpcaws_security_groupaws_subnetaws_ebs_volumeaws_elasticache_clusteraws_elasticache_replication_groupaws_elasticache_parameter_groupaws_elasticache_subnet_groupaws_elbaws_albaws_lbaws_lb_listeneraws_lb_listener_ruleaws_lb_target_groupaws_lb_target_group_attachmentaws_db_instanceaws_db_parameter_groupaws_db_option_groupaws_db_subnet_groupaws_rds_clusteraws_rds_cluster_instanceaws_rds_cluster_parameter_groupaws_s3_bucketaws_s3_bucket_public_access_blockaws_cloudfront_distributionaws_cloudfront_origin_access_identityaws_cloudfront_public_keyaws_iam_account_aliasaws_iam_account_password_policyaws_iam_groupaws_iam_group_membershipaws_iam_group_policyaws_iam_group_policy_attachmentaws_iam_instance_profileaws_iam_openid_connect_provideraws_iam_policyaws_iam_roleaws_iam_role_policyaws_iam_role_policy_attachmentaws_iam_saml_provideraws_iam_server_certificateaws_iam_useraws_iam_user_group_membershipaws_iam_user_policyaws_iam_user_policy_attachmentaws_route53_delegation_setaws_route53_health_checkaws_route53_query_logaws_route53_recordaws_route53_zoneaws_route53_zone_associationaws_route53_resolver_endpointaws_route53_resolver_rule_associationaws_ses_active_receipt_rule_setaws_ses_domain_identityaws_ses_domain_identity_verificationaws_ses_domain_dkimaws_ses_domain_mail_fromaws_ses_receipt_filteraws_ses_receipt_ruleaws_ses_receipt_rule_setaws_ses_configuration_setaws_ses_identity_notification_topicaws_ses_templateaws_launch_configurationaws_launch_templateaws_autoscaling_groupaws_autoscaling_policyaws_autoscaling_scheduleaws_ec2_transit_gatewayaws_ec2_transit_gateway_vpc_attachmentaws_ec2_transit_gateway_route_tableaws_ec2_transit_gateway_route_table_associationaws_ec2_transit_gateway_route_table_propagationaws_vpc_peering_connectionaws_cloudwatch_metric_alarmaws_cloudwatch_dashboardaws_cloudwatch_log_groupaws_cloudwatch_log_metric_filteraws_cloudwatch_event_ruleaws_cloudwatch_event_targetaws_sfn_state_machineaws_acm_certificateaws_acm_certificate_validationaws_secretsmanager_secretaws_ssm_parameteraws_codepipelineaws_codebuild_projectaws_codedeploy_appaws_codedeploy_deployment_groupaws_glue_catalog_databaseaws_glue_catalog_tableaws_glue_jobaws_athena_workgroupaws_athena_named_queryaws_redshift_clusteraws_redshift_parameter_groupaws_redshift_subnet_groupaws_msk_clusteraws_msk_configurationaws_cloudtrailaws_config_configuration_recorderaws_config_config_ruleaws_guardduty_detectoraws_guardduty_memberaws_internet_gatewayaws_nat_gatewayaws_route_tableaws_route_table_associationaws_network_aclaws_network_acl_ruleaws_vpc_endpointaws_efs_file_systemaws_efs_mount_targetaws_fsx_lustre_file_systemaws_fsx_windows_file_systemaws_cognito_user_poolaws_cognito_user_pool_clientaws_cognito_user_pool_domainaws_cognito_resource_serveraws_cognito_identity_poolaws_cognito_identity_pool_roles_attachmentaws_appsync_graphql_apiaws_appsync_datasourceaws_appsync_resolveraws_organizations_organizationaws_organizations_accountaws_organizations_organizational_unitaws_organizations_policyaws_organizations_policy_attachmentaws_batch_compute_environmentaws_batch_job_queueaws_batch_job_definitionaws_emr_clusteraws_sagemaker_notebook_instanceaws_sagemaker_endpointaws_globalaccelerator_acceleratoraws_globalaccelerator_listeneraws_globalaccelerator_endpoint_groupaws_backup_vaultaws_backup_planaws_backup_selectionaws_elastic_beanstalk_applicationaws_elastic_beanstalk_environment"
 
 func (i ResourceType) String() string {
 	i -= 1
@@ -20,7 +20,7 @@ func (i ResourceType) String() string {
 	return _ResourceTypeName[_ResourceTypeIndex[i]:_ResourceTypeIndex[i+1]]
 }
 
-var _ResourceTypeValues = []ResourceType{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53}
+var _ResourceTypeValues = []ResourceType{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81, 82, 83, 84, 85, 86, 87, 88, 89, 90, 91, 92, 93, 94, 95, 96, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122, 123, 124, 125, 126, 127, 128, 129, 130, 131, 132, 133, 134, 135, 136, 137, 138, 139, 140, 141, 142, 143, 144, 145}
 
 var _ResourceTypeNameToValueMap = map[string]ResourceType{
 	_ResourceTypeName[0:12]:           1,
@@ -35,100 +35,284 @@ var _ResourceTypeNameToValueMap = map[string]ResourceType{
 	_ResourceTypeLowerName[47:61]:     5,
 	_ResourceTypeName[61:84]:          6,
 	_ResourceTypeLowerName[61:84]:     6,
-	_ResourceTypeName[84:91]:          7,
-	_ResourceTypeLowerName[84:91]:     7,
-	_ResourceTypeName[91:98]:          8,
-	_ResourceTypeLowerName[91:98]:     8,
-	_ResourceTypeName[98:113]:         9,
-	_ResourceTypeLowerName[98:113]:    9,
-	_ResourceTypeName[113:126]:        10,
-	_ResourceTypeLowerName[113:126]:   10,
-	_ResourceTypeName[126:153]:        11,
-	_ResourceTypeLowerName[126:153]:   11,
-	_ResourceTypeName[153:190]:        12,
-	_ResourceTypeLowerName[153:190]:   12,
-	_ResourceTypeName[190:215]:        13,
-	_ResourceTypeLowerName[190:215]:   13,
-	_ResourceTypeName[215:236]:        14,
-	_ResourceTypeLowerName[215:236]:   14,
-	_ResourceTypeName[236:267]:        15,
-	_ResourceTypeLowerName[236:267]:   15,
-	_ResourceTypeName[267:280]:        16,
-	_ResourceTypeLowerName[267:280]:   16,
-	_ResourceTypeName[280:304]:        17,
-	_ResourceTypeLowerName[280:304]:   17,
-	_ResourceTypeName[304:324]:        18,
-	_ResourceTypeLowerName[304:324]:   18,
-	_ResourceTypeName[324:355]:        19,
-	_ResourceTypeLowerName[324:355]:   19,
-	_ResourceTypeName[355:379]:        20,
-	_ResourceTypeLowerName[355:379]:   20,
-	_ResourceTypeName[379:410]:        21,
-	_ResourceTypeLowerName[379:410]:   21,
-	_ResourceTypeName[410:424]:        22,
-	_ResourceTypeLowerName[410:424]:   22,
-	_ResourceTypeName[424:436]:        23,
-	_ResourceTypeLowerName[424:436]:   23,
-	_ResourceTypeName[436:455]:        24,
-	_ResourceTypeLowerName[436:455]:   24,
-	_ResourceTypeName[455:485]:        25,
-	_ResourceTypeLowerName[455:485]:   25,
-	_ResourceTypeName[485:506]:        26,
-	_ResourceTypeLowerName[485:506]:   26,
-	_ResourceTypeName[506:532]:        27,
-	_ResourceTypeLowerName[506:532]:   27,
-	_ResourceTypeName[532:544]:        28,
-	_ResourceTypeLowerName[532:544]:   28,
-	_ResourceTypeName[544:573]:        29,
-	_ResourceTypeLowerName[544:573]:   29,
-	_ResourceTypeName[573:592]:        30,
-	_ResourceTypeLowerName[573:592]:   30,
-	_ResourceTypeName[592:622]:        31,
-	_ResourceTypeLowerName[592:622]:   31,
-	_ResourceTypeName[622:648]:        32,
-	_ResourceTypeLowerName[622:648]:   32,
-	_ResourceTypeName[648:672]:        33,
-	_ResourceTypeLowerName[648:672]:   33,
-	_ResourceTypeName[672:693]:        34,
-	_ResourceTypeLowerName[672:693]:   34,
-	_ResourceTypeName[693:711]:        35,
-	_ResourceTypeLowerName[693:711]:   35,
-	_ResourceTypeName[711:727]:        36,
-	_ResourceTypeLowerName[711:727]:   36,
-	_ResourceTypeName[727:755]:        37,
-	_ResourceTypeLowerName[727:755]:   37,
-	_ResourceTypeName[755:784]:        38,
-	_ResourceTypeLowerName[755:784]:   38,
-	_ResourceTypeName[784:821]:        39,
-	_ResourceTypeLowerName[784:821]:   39,
-	_ResourceTypeName[821:852]:        40,
-	_ResourceTypeLowerName[821:852]:   40,
-	_ResourceTypeName[852:875]:        41,
-	_ResourceTypeLowerName[852:875]:   41,
-	_ResourceTypeName[875:911]:        42,
-	_ResourceTypeLowerName[875:911]:   42,
-	_ResourceTypeName[911:930]:        43,
-	_ResourceTypeLowerName[911:930]:   43,
-	_ResourceTypeName[930:954]:        44,
-	_ResourceTypeLowerName[930:954]:   44,
-	_ResourceTypeName[954:976]:        45,
-	_ResourceTypeLowerName[954:976]:   45,
-	_ResourceTypeName[976:996]:        46,
-	_ResourceTypeLowerName[976:996]:   46,
-	_ResourceTypeName[996:1020]:       47,
-	_ResourceTypeLowerName[996:1020]:  47,
-	_ResourceTypeName[1020:1045]:      48,
-	_ResourceTypeLowerName[1020:1045]: 48,
-	_ResourceTypeName[1045:1080]:      49,
-	_ResourceTypeLowerName[1045:1080]: 49,
-	_ResourceTypeName[1080:1096]:      50,
-	_ResourceTypeLowerName[1080:1096]: 50,
-	_ResourceTypeName[1096:1120]:      51,
-	_ResourceTypeLowerName[1096:1120]: 51,
-	_ResourceTypeName[1120:1139]:      52,
-	_ResourceTypeLowerName[1120:1139]: 52,
-	_ResourceTypeName[1139:1160]:      53,
-	_ResourceTypeLowerName[1139:1160]: 53,
+	_ResourceTypeName[84:117]:         7,
+	_ResourceTypeLowerName[84:117]:    7,
+	_ResourceTypeName[117:148]:        8,
+	_ResourceTypeLowerName[117:148]:   8,
+	_ResourceTypeName[148:176]:        9,
+	_ResourceTypeLowerName[148:176]:   9,
+	_ResourceTypeName[176:183]:        10,
+	_ResourceTypeLowerName[176:183]:   10,
+	_ResourceTypeName[183:190]:        11,
+	_ResourceTypeLowerName[183:190]:   11,
+	_ResourceTypeName[190:196]:        12,
+	_ResourceTypeLowerName[190:196]:   12,
+	_ResourceTypeName[196:211]:        13,
+	_ResourceTypeLowerName[196:211]:   13,
+	_ResourceTypeName[211:231]:        14,
+	_ResourceTypeLowerName[211:231]:   14,
+	_ResourceTypeName[231:250]:        15,
+	_ResourceTypeLowerName[231:250]:   15,
+	_ResourceTypeName[250:280]:        16,
+	_ResourceTypeLowerName[250:280]:   16,
+	_ResourceTypeName[280:295]:        17,
+	_ResourceTypeLowerName[280:295]:   17,
+	_ResourceTypeName[295:317]:        18,
+	_ResourceTypeLowerName[295:317]:   18,
+	_ResourceTypeName[317:336]:        19,
+	_ResourceTypeLowerName[317:336]:   19,
+	_ResourceTypeName[336:355]:        20,
+	_ResourceTypeLowerName[336:355]:   20,
+	_ResourceTypeName[355:370]:        21,
+	_ResourceTypeLowerName[355:370]:   21,
+	_ResourceTypeName[370:394]:        22,
+	_ResourceTypeLowerName[370:394]:   22,
+	_ResourceTypeName[394:425]:        23,
+	_ResourceTypeLowerName[394:425]:   23,
+	_ResourceTypeName[425:438]:        24,
+	_ResourceTypeLowerName[425:438]:   24,
+	_ResourceTypeName[438:471]:        25,
+	_ResourceTypeLowerName[438:471]:   25,
+	_ResourceTypeName[471:498]:        26,
+	_ResourceTypeLowerName[471:498]:   26,
+	_ResourceTypeName[498:535]:        27,
+	_ResourceTypeLowerName[498:535]:   27,
+	_ResourceTypeName[535:560]:        28,
+	_ResourceTypeLowerName[535:560]:   28,
+	_ResourceTypeName[560:581]:        29,
+	_ResourceTypeLowerName[560:581]:   29,
+	_ResourceTypeName[581:612]:        30,
+	_ResourceTypeLowerName[581:612]:   30,
+	_ResourceTypeName[612:625]:        31,
+	_ResourceTypeLowerName[612:625]:   31,
+	_ResourceTypeName[625:649]:        32,
+	_ResourceTypeLowerName[625:649]:   32,
+	_ResourceTypeName[649:669]:        33,
+	_ResourceTypeLowerName[649:669]:   33,
+	_ResourceTypeName[669:700]:        34,
+	_ResourceTypeLowerName[669:700]:   34,
+	_ResourceTypeName[700:724]:        35,
+	_ResourceTypeLowerName[700:724]:   35,
+	_ResourceTypeName[724:755]:        36,
+	_ResourceTypeLowerName[724:755]:   36,
+	_ResourceTypeName[755:769]:        37,
+	_ResourceTypeLowerName[755:769]:   37,
+	_ResourceTypeName[769:781]:        38,
+	_ResourceTypeLowerName[769:781]:   38,
+	_ResourceTypeName[781:800]:        39,
+	_ResourceTypeLowerName[781:800]:   39,
+	_ResourceTypeName[800:830]:        40,
+	_ResourceTypeLowerName[800:830]:   40,
+	_ResourceTypeName[830:851]:        41,
+	_ResourceTypeLowerName[830:851]:   41,
+	_ResourceTypeName[851:877]:        42,
+	_ResourceTypeLowerName[851:877]:   42,
+	_ResourceTypeName[877:889]:        43,
+	_ResourceTypeLowerName[877:889]:   43,
+	_ResourceTypeName[889:918]:        44,
+	_ResourceTypeLowerName[889:918]:   44,
+	_ResourceTypeName[918:937]:        45,
+	_ResourceTypeLowerName[918:937]:   45,
+	_ResourceTypeName[937:967]:        46,
+	_ResourceTypeLowerName[937:967]:   46,
+	_ResourceTypeName[967:993]:        47,
+	_ResourceTypeLowerName[967:993]:   47,
+	_ResourceTypeName[993:1017]:       48,
+	_ResourceTypeLowerName[993:1017]:  48,
+	_ResourceTypeName[1017:1038]:      49,
+	_ResourceTypeLowerName[1017:1038]: 49,
+	_ResourceTypeName[1038:1056]:      50,
+	_ResourceTypeLowerName[1038:1056]: 50,
+	_ResourceTypeName[1056:1072]:      51,
+	_ResourceTypeLowerName[1056:1072]: 51,
+	_ResourceTypeName[1072:1100]:      52,
+	_ResourceTypeLowerName[1072:1100]: 52,
+	_ResourceTypeName[1100:1129]:      53,
+	_ResourceTypeLowerName[1100:1129]: 53,
+	_ResourceTypeName[1129:1166]:      54,
+	_ResourceTypeLowerName[1129:1166]: 54,
+	_ResourceTypeName[1166:1197]:      55,
+	_ResourceTypeLowerName[1166:1197]: 55,
+	_ResourceTypeName[1197:1220]:      56,
+	_ResourceTypeLowerName[1197:1220]: 56,
+	_ResourceTypeName[1220:1256]:      57,
+	_ResourceTypeLowerName[1220:1256]: 57,
+	_ResourceTypeName[1256:1275]:      58,
+	_ResourceTypeLowerName[1256:1275]: 58,
+	_ResourceTypeName[1275:1299]:      59,
+	_ResourceTypeLowerName[1275:1299]: 59,
+	_ResourceTypeName[1299:1321]:      60,
+	_ResourceTypeLowerName[1299:1321]: 60,
+	_ResourceTypeName[1321:1341]:      61,
+	_ResourceTypeLowerName[1321:1341]: 61,
+	_ResourceTypeName[1341:1365]:      62,
+	_ResourceTypeLowerName[1341:1365]: 62,
+	_ResourceTypeName[1365:1390]:      63,
+	_ResourceTypeLowerName[1365:1390]: 63,
+	_ResourceTypeName[1390:1425]:      64,
+	_ResourceTypeLowerName[1390:1425]: 64,
+	_ResourceTypeName[1425:1441]:      65,
+	_ResourceTypeLowerName[1425:1441]: 65,
+	_ResourceTypeName[1441:1465]:      66,
+	_ResourceTypeLowerName[1441:1465]: 66,
+	_ResourceTypeName[1465:1484]:      67,
+	_ResourceTypeLowerName[1465:1484]: 67,
+	_ResourceTypeName[1484:1505]:      68,
+	_ResourceTypeLowerName[1484:1505]: 68,
+	_ResourceTypeName[1505:1527]:      69,
+	_ResourceTypeLowerName[1505:1527]: 69,
+	_ResourceTypeName[1527:1551]:      70,
+	_ResourceTypeLowerName[1527:1551]: 70,
+	_ResourceTypeName[1551:1574]:      71,
+	_ResourceTypeLowerName[1551:1574]: 71,
+	_ResourceTypeName[1574:1612]:      72,
+	_ResourceTypeLowerName[1574:1612]: 72,
+	_ResourceTypeName[1612:1647]:      73,
+	_ResourceTypeLowerName[1612:1647]: 73,
+	_ResourceTypeName[1647:1694]:      74,
+	_ResourceTypeLowerName[1647:1694]: 74,
+	_ResourceTypeName[1694:1741]:      75,
+	_ResourceTypeLowerName[1694:1741]: 75,
+	_ResourceTypeName[1741:1767]:      76,
+	_ResourceTypeLowerName[1741:1767]: 76,
+	_ResourceTypeName[1767:1794]:      77,
+	_ResourceTypeLowerName[1767:1794]: 77,
+	_ResourceTypeName[1794:1818]:      78,
+	_ResourceTypeLowerName[1794:1818]: 78,
+	_ResourceTypeName[1818:1842]:      79,
+	_ResourceTypeLowerName[1818:1842]: 79,
+	_ResourceTypeName[1842:1874]:      80,
+	_ResourceTypeLowerName[1842:1874]: 80,
+	_ResourceTypeName[1874:1899]:      81,
+	_ResourceTypeLowerName[1874:1899]: 81,
+	_ResourceTypeName[1899:1926]:      82,
+	_ResourceTypeLowerName[1899:1926]: 82,
+	_ResourceTypeName[1926:1947]:      83,
+	_ResourceTypeLowerName[1926:1947]: 83,
+	_ResourceTypeName[1947:1966]:      84,
+	_ResourceTypeLowerName[1947:1966]: 84,
+	_ResourceTypeName[1966:1996]:      85,
+	_ResourceTypeLowerName[1966:1996]: 85,
+	_ResourceTypeName[1996:2021]:      86,
+	_ResourceTypeLowerName[1996:2021]: 86,
+	_ResourceTypeName[2021:2038]:      87,
+	_ResourceTypeLowerName[2021:2038]: 87,
+	_ResourceTypeName[2038:2054]:      88,
+	_ResourceTypeLowerName[2038:2054]: 88,
+	_ResourceTypeName[2054:2075]:      89,
+	_ResourceTypeLowerName[2054:2075]: 89,
+	_ResourceTypeName[2075:2093]:      90,
+	_ResourceTypeLowerName[2075:2093]: 90,
+	_ResourceTypeName[2093:2124]:      91,
+	_ResourceTypeLowerName[2093:2124]: 91,
+	_ResourceTypeName[2124:2149]:      92,
+	_ResourceTypeLowerName[2124:2149]: 92,
+	_ResourceTypeName[2149:2171]:      93,
+	_ResourceTypeLowerName[2149:2171]: 93,
+	_ResourceTypeName[2171:2183]:      94,
+	_ResourceTypeLowerName[2171:2183]: 94,
+	_ResourceTypeName[2183:2203]:      95,
+	_ResourceTypeLowerName[2183:2203]: 95,
+	_ResourceTypeName[2203:2225]:      96,
+	_ResourceTypeLowerName[2203:2225]: 96,
+	_ResourceTypeName[2225:2245]:      97,
+	_ResourceTypeLowerName[2225:2245]: 97,
+	_ResourceTypeName[2245:2273]:      98,
+	_ResourceTypeLowerName[2245:2273]: 98,
+	_ResourceTypeName[2273:2298]:      99,
+	_ResourceTypeLowerName[2273:2298]: 99,
+	_ResourceTypeName[2298:2313]:      100,
+	_ResourceTypeLowerName[2298:2313]: 100,
+	_ResourceTypeName[2313:2334]:      101,
+	_ResourceTypeLowerName[2313:2334]: 101,
+	_ResourceTypeName[2334:2348]:      102,
+	_ResourceTypeLowerName[2334:2348]: 102,
+	_ResourceTypeName[2348:2381]:      103,
+	_ResourceTypeLowerName[2348:2381]: 103,
+	_ResourceTypeName[2381:2403]:      104,
+	_ResourceTypeLowerName[2381:2403]: 104,
+	_ResourceTypeName[2403:2425]:      105,
+	_ResourceTypeLowerName[2403:2425]: 105,
+	_ResourceTypeName[2425:2445]:      106,
+	_ResourceTypeLowerName[2425:2445]: 106,
+	_ResourceTypeName[2445:2465]:      107,
+	_ResourceTypeLowerName[2445:2465]: 107,
+	_ResourceTypeName[2465:2480]:      108,
+	_ResourceTypeLowerName[2465:2480]: 108,
+	_ResourceTypeName[2480:2495]:      109,
+	_ResourceTypeLowerName[2480:2495]: 109,
+	_ResourceTypeName[2495:2522]:      110,
+	_ResourceTypeLowerName[2495:2522]: 110,
+	_ResourceTypeName[2522:2537]:      111,
+	_ResourceTypeLowerName[2522:2537]: 111,
+	_ResourceTypeName[2537:2557]:      112,
+	_ResourceTypeLowerName[2537:2557]: 112,
+	_ResourceTypeName[2557:2573]:      113,
+	_ResourceTypeLowerName[2557:2573]: 113,
+	_ResourceTypeName[2573:2592]:      114,
+	_ResourceTypeLowerName[2573:2592]: 114,
+	_ResourceTypeName[2592:2612]:      115,
+	_ResourceTypeLowerName[2592:2612]: 115,
+	_ResourceTypeName[2612:2638]:      116,
+	_ResourceTypeLowerName[2612:2638]: 116,
+	_ResourceTypeName[2638:2665]:      117,
+	_ResourceTypeLowerName[2638:2665]: 117,
+	_ResourceTypeName[2665:2686]:      118,
+	_ResourceTypeLowerName[2665:2686]: 118,
+	_ResourceTypeName[2686:2714]:      119,
+	_ResourceTypeLowerName[2686:2714]: 119,
+	_ResourceTypeName[2714:2742]:      120,
+	_ResourceTypeLowerName[2714:2742]: 120,
+	_ResourceTypeName[2742:2769]:      121,
+	_ResourceTypeLowerName[2742:2769]: 121,
+	_ResourceTypeName[2769:2794]:      122,
+	_ResourceTypeLowerName[2769:2794]: 122,
+	_ResourceTypeName[2794:2836]:      123,
+	_ResourceTypeLowerName[2794:2836]: 123,
+	_ResourceTypeName[2836:2859]:      124,
+	_ResourceTypeLowerName[2836:2859]: 124,
+	_ResourceTypeName[2859:2881]:      125,
+	_ResourceTypeLowerName[2859:2881]: 125,
+	_ResourceTypeName[2881:2901]:      126,
+	_ResourceTypeLowerName[2881:2901]: 126,
+	_ResourceTypeName[2901:2931]:      127,
+	_ResourceTypeLowerName[2901:2931]: 127,
+	_ResourceTypeName[2931:2956]:      128,
+	_ResourceTypeLowerName[2931:2956]: 128,
+	_ResourceTypeName[2956:2993]:      129,
+	_ResourceTypeLowerName[2956:2993]: 129,
+	_ResourceTypeName[2993:3017]:      130,
+	_ResourceTypeLowerName[2993:3017]: 130,
+	_ResourceTypeName[3017:3052]:      131,
+	_ResourceTypeLowerName[3017:3052]: 131,
+	_ResourceTypeName[3052:3081]:      132,
+	_ResourceTypeLowerName[3052:3081]: 132,
+	_ResourceTypeName[3081:3100]:      133,
+	_ResourceTypeLowerName[3081:3100]: 133,
+	_ResourceTypeName[3100:3124]:      134,
+	_ResourceTypeLowerName[3100:3124]: 134,
+	_ResourceTypeName[3124:3139]:      135,
+	_ResourceTypeLowerName[3124:3139]: 135,
+	_ResourceTypeName[3139:3170]:      136,
+	_ResourceTypeLowerName[3139:3170]: 136,
+	_ResourceTypeName[3170:3192]:      137,
+	_ResourceTypeLowerName[3170:3192]: 137,
+	_ResourceTypeName[3192:3225]:      138,
+	_ResourceTypeLowerName[3192:3225]: 138,
+	_ResourceTypeName[3225:3255]:      139,
+	_ResourceTypeLowerName[3225:3255]: 139,
+	_ResourceTypeName[3255:3291]:      140,
+	_ResourceTypeLowerName[3255:3291]: 140,
+	_ResourceTypeName[3291:3307]:      141,
+	_ResourceTypeLowerName[3291:3307]: 141,
+	_ResourceTypeName[3307:3322]:      142,
+	_ResourceTypeLowerName[3307:3322]: 142,
+	_ResourceTypeName[3322:3342]:      143,
+	_ResourceTypeLowerName[3322:3342]: 143,
+	_ResourceTypeName[3342:3375]:      144,
+	_ResourceTypeLowerName[3342:3375]: 144,
+	_ResourceTypeName[3375:3408]:      145,
+	_ResourceTypeLowerName[3375:3408]: 145,
 }
 
 var _ResourceTypeNames = []string{
@@ -138,53 +322,145 @@ var _ResourceTypeNames = []string{
 	_ResourceTypeName[37:47],
 	_ResourceTypeName[47:61],
 	_ResourceTypeName[61:84],
-	_ResourceTypeName[84:91],
-	_ResourceTypeName[91:98],
-	_ResourceTypeName[98:113],
-	_ResourceTypeName[113:126],
-	_ResourceTypeName[126:153],
-	_ResourceTypeName[153:190],
-	_ResourceTypeName[190:215],
-	_ResourceTypeName[215:236],
-	_ResourceTypeName[236:267],
-	_ResourceTypeName[267:280],
-	_ResourceTypeName[280:304],
-	_ResourceTypeName[304:324],
-	_ResourceTypeName[324:355],
-	_ResourceTypeName[355:379],
-	_ResourceTypeName[379:410],
-	_ResourceTypeName[410:424],
-	_ResourceTypeName[424:436],
-	_ResourceTypeName[436:455],
-	_ResourceTypeName[455:485],
-	_ResourceTypeName[485:506],
-	_ResourceTypeName[506:532],
-	_ResourceTypeName[532:544],
-	_ResourceTypeName[544:573],
-	_ResourceTypeName[573:592],
-	_ResourceTypeName[592:622],
-	_ResourceTypeName[622:648],
-	_ResourceTypeName[648:672],
-	_ResourceTypeName[672:693],
-	_ResourceTypeName[693:711],
-	_ResourceTypeName[711:727],
-	_ResourceTypeName[727:755],
-	_ResourceTypeName[755:784],
-	_ResourceTypeName[784:821],
-	_ResourceTypeName[821:852],
-	_ResourceTypeName[852:875],
-	_ResourceTypeName[875:911],
-	_ResourceTypeName[911:930],
-	_ResourceTypeName[930:954],
-	_ResourceTypeName[954:976],
-	_ResourceTypeName[976:996],
-	_ResourceTypeName[996:1020],
-	_ResourceTypeName[1020:1045],
-	_ResourceTypeName[1045:1080],
-	_ResourceTypeName[1080:1096],
-	_ResourceTypeName[1096:1120],
-	_ResourceTypeName[1120:1139],
-	_ResourceTypeName[1139:1160],
+	_ResourceTypeName[84:117],
+	_ResourceTypeName[117:148],
+	_ResourceTypeName[148:176],
+	_ResourceTypeName[176:183],
+	_ResourceTypeName[183:190],
+	_ResourceTypeName[190:196],
+	_ResourceTypeName[196:211],
+	_ResourceTypeName[211:231],
+	_ResourceTypeName[231:250],
+	_ResourceTypeName[250:280],
+	_ResourceTypeName[280:295],
+	_ResourceTypeName[295:317],
+	_ResourceTypeName[317:336],
+	_ResourceTypeName[336:355],
+	_ResourceTypeName[355:370],
+	_ResourceTypeName[370:394],
+	_ResourceTypeName[394:425],
+	_ResourceTypeName[425:438],
+	_ResourceTypeName[438:471],
+	_ResourceTypeName[471:498],
+	_ResourceTypeName[498:535],
+	_ResourceTypeName[535:560],
+	_ResourceTypeName[560:581],
+	_ResourceTypeName[581:612],
+	_ResourceTypeName[612:625],
+	_ResourceTypeName[625:649],
+	_ResourceTypeName[649:669],
+	_ResourceTypeName[669:700],
+	_ResourceTypeName[700:724],
+	_ResourceTypeName[724:755],
+	_ResourceTypeName[755:769],
+	_ResourceTypeName[769:781],
+	_ResourceTypeName[781:800],
+	_ResourceTypeName[800:830],
+	_ResourceTypeName[830:851],
+	_ResourceTypeName[851:877],
+	_ResourceTypeName[877:889],
+	_ResourceTypeName[889:918],
+	_ResourceTypeName[918:937],
+	_ResourceTypeName[937:967],
+	_ResourceTypeName[967:993],
+	_ResourceTypeName[993:1017],
+	_ResourceTypeName[1017:1038],
+	_ResourceTypeName[1038:1056],
+	_ResourceTypeName[1056:1072],
+	_ResourceTypeName[1072:1100],
+	_ResourceTypeName[1100:1129],
+	_ResourceTypeName[1129:1166],
+	_ResourceTypeName[1166:1197],
+	_ResourceTypeName[1197:1220],
+	_ResourceTypeName[1220:1256],
+	_ResourceTypeName[1256:1275],
+	_ResourceTypeName[1275:1299],
+	_ResourceTypeName[1299:1321],
+	_ResourceTypeName[1321:1341],
+	_ResourceTypeName[1341:1365],
+	_ResourceTypeName[1365:1390],
+	_ResourceTypeName[1390:1425],
+	_ResourceTypeName[1425:1441],
+	_ResourceTypeName[1441:1465],
+	_ResourceTypeName[1465:1484],
+	_ResourceTypeName[1484:1505],
+	_ResourceTypeName[1505:1527],
+	_ResourceTypeName[1527:1551],
+	_ResourceTypeName[1551:1574],
+	_ResourceTypeName[1574:1612],
+	_ResourceTypeName[1612:1647],
+	_ResourceTypeName[1647:1694],
+	_ResourceTypeName[1694:1741],
+	_ResourceTypeName[1741:1767],
+	_ResourceTypeName[1767:1794],
+	_ResourceTypeName[1794:1818],
+	_ResourceTypeName[1818:1842],
+	_ResourceTypeName[1842:1874],
+	_ResourceTypeName[1874:1899],
+	_ResourceTypeName[1899:1926],
+	_ResourceTypeName[1926:1947],
+	_ResourceTypeName[1947:1966],
+	_ResourceTypeName[1966:1996],
+	_ResourceTypeName[1996:2021],
+	_ResourceTypeName[2021:2038],
+	_ResourceTypeName[2038:2054],
+	_ResourceTypeName[2054:2075],
+	_ResourceTypeName[2075:2093],
+	_ResourceTypeName[2093:2124],
+	_ResourceTypeName[2124:2149],
+	_ResourceTypeName[2149:2171],
+	_ResourceTypeName[2171:2183],
+	_ResourceTypeName[2183:2203],
+	_ResourceTypeName[2203:2225],
+	_ResourceTypeName[2225:2245],
+	_ResourceTypeName[2245:2273],
+	_ResourceTypeName[2273:2298],
+	_ResourceTypeName[2298:2313],
+	_ResourceTypeName[2313:2334],
+	_ResourceTypeName[2334:2348],
+	_ResourceTypeName[2348:2381],
+	_ResourceTypeName[2381:2403],
+	_ResourceTypeName[2403:2425],
+	_ResourceTypeName[2425:2445],
+	_ResourceTypeName[2445:2465],
+	_ResourceTypeName[2465:2480],
+	_ResourceTypeName[2480:2495],
+	_ResourceTypeName[2495:2522],
+	_ResourceTypeName[2522:2537],
+	_ResourceTypeName[2537:2557],
+	_ResourceTypeName[2557:2573],
+	_ResourceTypeName[2573:2592],
+	_ResourceTypeName[2592:2612],
+	_ResourceTypeName[2612:2638],
+	_ResourceTypeName[2638:2665],
+	_ResourceTypeName[2665:2686],
+	_ResourceTypeName[2686:2714],
+	_ResourceTypeName[2714:2742],
+	_ResourceTypeName[2742:2769],
+	_ResourceTypeName[2769:2794],
+	_ResourceTypeName[2794:2836],
+	_ResourceTypeName[2836:2859],
+	_ResourceTypeName[2859:2881],
+	_ResourceTypeName[2881:2901],
+	_ResourceTypeName[2901:2931],
+	_ResourceTypeName[2931:2956],
+	_ResourceTypeName[2956:2993],
+	_ResourceTypeName[2993:3017],
+	_ResourceTypeName[3017:3052],
+	_ResourceTypeName[3052:3081],
+	_ResourceTypeName[3081:3100],
+	_ResourceTypeName[3100:3124],
+	_ResourceTypeName[3124:3139],
+	_ResourceTypeName[3139:3170],
+	_ResourceTypeName[3170:3192],
+	_ResourceTypeName[3192:3225],
+	_ResourceTypeName[3225:3255],
+	_ResourceTypeName[3255:3291],
+	_ResourceTypeName[3291:3307],
+	_ResourceTypeName[3307:3322],
+	_ResourceTypeName[3322:3342],
+	_ResourceTypeName[3342:3375],
+	_ResourceTypeName[3375:3408],
 }
 
 // ResourceTypeString retrieves an enum value from the enum constants string name.
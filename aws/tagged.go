@@ -0,0 +1,184 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	awsSDK "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/tag"
+)
+
+// taggedTypes are the ResourceTypes which resources are pre-selected
+// with the Resource Groups Tagging API when there are tags to filter
+// by, with the function returning the ID of the resource of the ARN.
+// The ones not listed are filtered after being read, as always
+var taggedTypes = map[ResourceType]func(arn string) (string, bool){
+	S3Bucket:             arnResource("s3", ""),
+	DBInstance:           arnResource("rds:db", ":"),
+	RDSCluster:           arnResource("rds:cluster", ":"),
+	ElasticacheCluster:   arnResource("elasticache:cluster", ":"),
+	EFSFileSystem:        arnResource("elasticfilesystem:file-system", "/"),
+	CloudwatchLogGroup:   arnResource("logs:log-group", ":"),
+	ELB:                  elbARN,
+	LB:                   lbARN,
+	LBTargetGroup:        arnIs("elasticloadbalancing:targetgroup"),
+	LBListener:           arnIs("elasticloadbalancing:listener"),
+	LBListenerRule:       arnIs("elasticloadbalancing:listener-rule"),
+	SecretsmanagerSecret: arnIs("secretsmanager:secret"),
+	SfnStateMachine:      arnIs("states:stateMachine"),
+}
+
+// taggedIDs returns the IDs of the resources of the rt with the tags,
+// listed with the Resource Groups Tagging API, so only those are read.
+// It returns false if the rt is not one of the taggedTypes, there are
+// no tags or the Tagging API can not be used, so the resources are
+// filtered by the tags once read, as the rest
+func (a *aws) taggedIDs(ctx context.Context, rt ResourceType, tags []tag.Tag) (map[string]struct{}, bool) {
+	if len(tags) == 0 {
+		return nil, false
+	}
+	fn, ok := taggedTypes[rt]
+	if !ok {
+		return nil, false
+	}
+
+	arns, err := a.taggedARNs(ctx, tags)
+	if err != nil {
+		log.Get().Log("func", "aws.taggedIDs", "msg", "could not list the tagged resources", "error", err)
+		return nil, false
+	}
+
+	ids := make(map[string]struct{})
+	for _, arn := range arns {
+		if id, ok := fn(arn); ok {
+			ids[id] = struct{}{}
+		}
+	}
+
+	return ids, true
+}
+
+// withIDs returns the resources with the ids
+func withIDs(resources []provider.Resource, ids map[string]struct{}) []provider.Resource {
+	res := make([]provider.Resource, 0, len(ids))
+	for _, r := range resources {
+		if _, ok := ids[r.ID()]; ok {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// taggedARNs returns the ARNs of the resources of the region with the
+// tags, listed only once for all the types with the same tags
+func (a *aws) taggedARNs(ctx context.Context, tags []tag.Tag) ([]string, error) {
+	key := tagsKey(tags)
+
+	a.taggedMu.Lock()
+	defer a.taggedMu.Unlock()
+
+	if arns, ok := a.tagged[key]; ok {
+		return arns, nil
+	}
+
+	input := &resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters: toTagFilters(tags),
+	}
+
+	arns := make([]string, 0)
+	for {
+		resources, err := a.awsr.GetTaggedResources(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range resources.ResourceTagMappingList {
+			arns = append(arns, awsSDK.StringValue(r.ResourceARN))
+		}
+
+		if resources.PaginationToken == nil || *resources.PaginationToken == "" {
+			break
+		}
+		input.PaginationToken = resources.PaginationToken
+	}
+
+	if a.tagged == nil {
+		a.tagged = make(map[string][]string)
+	}
+	a.tagged[key] = arns
+
+	return arns, nil
+}
+
+// toTagFilters returns the tags as the filters
+// of the Tagging API, like toEC2Filters
+func toTagFilters(tags []tag.Tag) []*resourcegroupstaggingapi.TagFilter {
+	filters := make([]*resourcegroupstaggingapi.TagFilter, 0, len(tags))
+	for _, t := range tags {
+		filters = append(filters, &resourcegroupstaggingapi.TagFilter{
+			Key:    awsSDK.String(t.Name),
+			Values: []*string{awsSDK.String(t.Value)},
+		})
+	}
+	return filters
+}
+
+// tagsKey returns the key of the tags on the cache of the taggedARNs
+func tagsKey(tags []tag.Tag) string {
+	parts := make([]string, 0, len(tags))
+	for _, t := range tags {
+		parts = append(parts, t.Name+"="+t.Value)
+	}
+	return strings.Join(parts, ",")
+}
+
+// arnResource returns the function of the taggedTypes of the ARNs of
+// the type t (see arnType) which ID is the resource after the sep,
+// or all of it if sep is empty (ex: arn:aws:rds:r:1:db:ID)
+func arnResource(t, sep string) func(string) (string, bool) {
+	return func(arn string) (string, bool) {
+		if arnType(arn) != t {
+			return "", false
+		}
+
+		parts := strings.SplitN(arn, ":", 6)
+		id := parts[5]
+		if sep != "" {
+			id = id[strings.Index(id, sep)+1:]
+		}
+
+		// The log groups have ':*' at the end
+		return strings.TrimSuffix(id, ":*"), true
+	}
+}
+
+// arnIs returns the function of the taggedTypes of the ARNs
+// of the type t (see arnType) which ID is the ARN
+func arnIs(t string) func(string) (string, bool) {
+	return func(arn string) (string, bool) {
+		return arn, arnType(arn) == t
+	}
+}
+
+// elbARN returns the name of the classic load
+// balancer of the ARN (loadbalancer/NAME)
+func elbARN(arn string) (string, bool) {
+	id, ok := arnResource("elasticloadbalancing:loadbalancer", "/")(arn)
+	if !ok || strings.Contains(id, "/") {
+		return "", false
+	}
+	return id, true
+}
+
+// lbARN returns the ARN of the application or network
+// load balancer of the ARN (loadbalancer/app/NAME/ID)
+func lbARN(arn string) (string, bool) {
+	id, ok := arnResource("elasticloadbalancing:loadbalancer", "/")(arn)
+	if !ok || !strings.Contains(id, "/") {
+		return "", false
+	}
+	return arn, true
+}
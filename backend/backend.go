@@ -0,0 +1,115 @@
+package backend
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultKey is the key of the TFState on the
+// s3 and azurerm backends if none is configured
+const DefaultKey = "terraform.tfstate"
+
+// required are the configurations required by each type
+// of backend, the ones with '|' require one of them
+var required = map[string][]string{
+	"s3":      {"bucket", "key", "region"},
+	"gcs":     {"bucket"},
+	"azurerm": {"resource_group_name", "storage_account_name", "container_name", "key"},
+	"remote":  {"organization", "workspaces.name|workspaces.prefix"},
+}
+
+// Backend is a Terraform backend
+type Backend struct {
+	// Type is the type of the backend,
+	// one of: s3, gcs, azurerm, remote
+	Type string
+
+	// Config is the configuration of the backend, the keys
+	// with '.' are of nested blocks (ex: workspaces.name)
+	Config map[string]string
+}
+
+// Parse parses the backend of the type t with the configs, with
+// the format KEY=VALUE (ex: bucket=my-states), the same as the
+// '-backend-config' of 'terraform init'. The region is used
+// if the s3 one has no region configured
+func Parse(t string, configs []string, region string) (Backend, error) {
+	if _, ok := required[t]; !ok {
+		return Backend{}, fmt.Errorf("invalid backend %q, the valid types are: azurerm, gcs, remote, s3", t)
+	}
+
+	b := Backend{Type: t, Config: make(map[string]string)}
+	for _, c := range configs {
+		kv := strings.SplitN(c, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return Backend{}, fmt.Errorf("invalid backend config %q, the expected format is KEY=VALUE", c)
+		}
+		b.Config[kv[0]] = kv[1]
+	}
+
+	switch t {
+	case "s3":
+		if _, ok := b.Config["region"]; !ok && region != "" {
+			b.Config["region"] = region
+		}
+		fallthrough
+	case "azurerm":
+		if _, ok := b.Config["key"]; !ok {
+			b.Config["key"] = DefaultKey
+		}
+	}
+
+	for _, r := range required[t] {
+		var found bool
+		for _, k := range strings.Split(r, "|") {
+			if _, ok := b.Config[k]; ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return Backend{}, fmt.Errorf("the backend config %q is required with the backend %q", strings.Replace(r, "|", " or ", -1), t)
+		}
+	}
+
+	return b, nil
+}
+
+// HCL returns the 'terraform' block with the backend
+func (b Backend) HCL() string {
+	attrs := make([]string, 0)
+	blocks := make(map[string][]string)
+	for k := range b.Config {
+		if parts := strings.SplitN(k, ".", 2); len(parts) == 2 {
+			blocks[parts[0]] = append(blocks[parts[0]], parts[1])
+			continue
+		}
+		attrs = append(attrs, k)
+	}
+	sort.Strings(attrs)
+
+	names := make([]string, 0, len(blocks))
+	for n := range blocks {
+		names = append(names, n)
+		sort.Strings(blocks[n])
+	}
+	sort.Strings(names)
+
+	buff := &bytes.Buffer{}
+	fmt.Fprintf(buff, "terraform {\n  backend %q {\n", b.Type)
+	for _, k := range attrs {
+		fmt.Fprintf(buff, "    %s = %q\n", k, b.Config[k])
+	}
+	for _, n := range names {
+		fmt.Fprintf(buff, "\n    %s {\n", n)
+		for _, k := range blocks[n] {
+			fmt.Fprintf(buff, "      %s = %q\n", k, b.Config[n+"."+k])
+		}
+		fmt.Fprintf(buff, "    }\n")
+	}
+	fmt.Fprintf(buff, "  }\n}\n")
+
+	return buff.String()
+}
@@ -0,0 +1,66 @@
+package backend_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("SuccessS3", func(t *testing.T) {
+		b, err := backend.Parse("s3", []string{"bucket=my-states"}, "eu-west-1")
+		require.NoError(t, err)
+		assert.Equal(t, backend.Backend{
+			Type:   "s3",
+			Config: map[string]string{"bucket": "my-states", "key": "terraform.tfstate", "region": "eu-west-1"},
+		}, b)
+	})
+
+	t.Run("SuccessRemote", func(t *testing.T) {
+		b, err := backend.Parse("remote", []string{"organization=cycloid", "workspaces.prefix=app-"}, "")
+		require.NoError(t, err)
+		assert.Equal(t, backend.Backend{
+			Type:   "remote",
+			Config: map[string]string{"organization": "cycloid", "workspaces.prefix": "app-"},
+		}, b)
+	})
+
+	t.Run("ErrorType", func(t *testing.T) {
+		_, err := backend.Parse("consul", nil, "")
+		assert.EqualError(t, err, `invalid backend "consul", the valid types are: azurerm, gcs, remote, s3`)
+	})
+
+	t.Run("ErrorFormat", func(t *testing.T) {
+		_, err := backend.Parse("gcs", []string{"bucket"}, "")
+		assert.EqualError(t, err, `invalid backend config "bucket", the expected format is KEY=VALUE`)
+	})
+
+	t.Run("ErrorRequired", func(t *testing.T) {
+		_, err := backend.Parse("azurerm", []string{"resource_group_name=rg", "storage_account_name=sa"}, "")
+		assert.EqualError(t, err, `the backend config "container_name" is required with the backend "azurerm"`)
+
+		_, err = backend.Parse("remote", []string{"organization=cycloid"}, "")
+		assert.EqualError(t, err, `the backend config "workspaces.name or workspaces.prefix" is required with the backend "remote"`)
+	})
+}
+
+func TestBackendHCL(t *testing.T) {
+	b := backend.Backend{
+		Type:   "remote",
+		Config: map[string]string{"organization": "cycloid", "hostname": "app.terraform.io", "workspaces.name": "prod"},
+	}
+
+	assert.Equal(t, `terraform {
+  backend "remote" {
+    hostname = "app.terraform.io"
+    organization = "cycloid"
+
+    workspaces {
+      name = "prod"
+    }
+  }
+}
+`, b.HCL())
+}
@@ -0,0 +1,4 @@
+// Package backend has the Terraform backends that
+// can be configured on the generated HCL, so it's
+// ready to be used with the state stored on them
+package backend
@@ -0,0 +1,3 @@
+// Package cdktf has the Writer that generates
+// CDK for Terraform (cdktf) code instead of HCL
+package cdktf
@@ -0,0 +1,503 @@
+package cdktf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/hcl"
+	"github.com/cycloidio/terracognita/log"
+)
+
+// Language is the language of the
+// generated cdktf code
+type Language string
+
+// List of all the supported Languages
+const (
+	TypeScript Language = "typescript"
+	Python     Language = "python"
+)
+
+// stackName is the name of the generated stack
+const stackName = "terracognita"
+
+// cdktfCore is the provider used for the
+// constructs imported from the cdktf library
+const cdktfCore = "cdktf"
+
+// Writer is a Writer implementation that stores the
+// configuration the same way the hcl.Writer does
+// but it writes it as cdktf code on the Sync
+type Writer struct {
+	*hcl.Writer
+
+	lang   Language
+	writer io.Writer
+}
+
+// NewWriter returns a Writer initialization
+// that writes the code in lang to w
+func NewWriter(w io.Writer, lang Language) *Writer {
+	return &Writer{
+		Writer: hcl.NewWriter(nil),
+		lang:   lang,
+		writer: w,
+	}
+}
+
+// construct is a resource, data source, variable
+// or aliased provider already converted to cdktf,
+// the ones with a variable are assigned to it so
+// they can be referenced (ex: the providers)
+type construct struct {
+	provider string
+	class    string
+	id       string
+	variable string
+	config   map[string]interface{}
+}
+
+// reference is a value that references
+// a variable of the code, so it's not quoted
+type reference string
+
+// Sync writes the content of the Config to the
+// internal w as cdktf code of the Language
+func (w *Writer) Sync() error {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "writer.Write(cdktf)")
+
+	constructs := w.constructs()
+
+	buff := &bytes.Buffer{}
+	switch w.lang {
+	case TypeScript:
+		writeTypeScript(buff, constructs)
+	case Python:
+		writePython(buff, constructs)
+	default:
+		return errors.Errorf("the language %q is not supported", w.lang)
+	}
+
+	logger.Log("msg", "writing cdktf code", "language", w.lang)
+
+	_, err := io.Copy(w.writer, buff)
+	if err != nil {
+		return errors.Wrap(err, "error while writing the cdktf code")
+	}
+
+	return nil
+}
+
+// constructs returns all the variables, resources and data sources of
+// the Config sorted by type and name with unique IDs on the stack
+func (w *Writer) constructs() []construct {
+	res := make([]construct, 0)
+	ids := make(map[string]struct{})
+
+	// The variables are part of the cdktf core
+	// and the name is the key of the block
+	if vars, ok := w.Config["variable"].(map[string]map[string]interface{}); ok {
+		names := make([]string, 0, len(vars))
+		for n := range vars {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+
+		for _, n := range names {
+			ids[n] = struct{}{}
+			res = append(res, construct{
+				provider: cdktfCore,
+				class:    "TerraformVariable",
+				id:       n,
+				config:   vars[n],
+			})
+		}
+	}
+
+	// The aliased providers are objects on cdktf, assigned to
+	// a variable so the resources and data sources using them
+	// reference it on the 'provider' (ex: aws.shared => awsShared)
+	refs := make(map[string]string)
+	if blocks, ok := w.Config["provider"].(map[string]map[string]interface{}); ok {
+		types := make([]string, 0, len(blocks))
+		for t := range blocks {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+
+		for _, t := range types {
+			aliases := make([]string, 0, len(blocks[t]))
+			for a := range blocks[t] {
+				aliases = append(aliases, a)
+			}
+			sort.Strings(aliases)
+
+			for _, a := range aliases {
+				cfg, _ := blocks[t][a].(map[string]interface{})
+
+				id := fmt.Sprintf("%s_%s", t, a)
+				ids[id] = struct{}{}
+
+				config := make(map[string]interface{}, len(cfg)+1)
+				for k, v := range cfg {
+					config[k] = v
+				}
+				config["alias"] = a
+
+				c := construct{
+					provider: t,
+					class:    camelCase(t, true) + "Provider",
+					id:       id,
+					variable: variableName(w.lang, id),
+					config:   config,
+				}
+				refs[fmt.Sprintf("%s.%s", t, a)] = c.variable
+
+				res = append(res, c)
+			}
+		}
+	}
+
+	for _, block := range []string{"data", "resource"} {
+		blocks, ok := w.Config[block].(map[string]map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		types := make([]string, 0, len(blocks))
+		for t := range blocks {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+
+		for _, t := range types {
+			names := make([]string, 0, len(blocks[t]))
+			for n := range blocks[t] {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+
+			for _, n := range names {
+				cfg, _ := blocks[t][n].(map[string]interface{})
+
+				id := n
+				if _, ok := ids[id]; ok {
+					id = fmt.Sprintf("%s_%s", t, n)
+				}
+				ids[id] = struct{}{}
+
+				c := construct{
+					provider: strings.SplitN(t, "_", 2)[0],
+					class:    className(block, t),
+					id:       id,
+					config:   cfg,
+				}
+
+				// The provider is a reference to the provider object on
+				// cdktf, if it was not written it's removed. The cfg is
+				// copied as it's the one of the Config
+				if p, ok := cfg["provider"].(string); ok {
+					c.config = make(map[string]interface{}, len(cfg))
+					for k, v := range cfg {
+						c.config[k] = v
+					}
+
+					if ref, ok := refs[p]; ok {
+						c.config["provider"] = reference(ref)
+					} else {
+						delete(c.config, "provider")
+					}
+				}
+
+				res = append(res, c)
+			}
+		}
+	}
+
+	return res
+}
+
+// variableName returns the name of the variable of
+// the construct with the id on the lang, camel
+// case on TypeScript and snake case on Python
+func variableName(lang Language, id string) string {
+	if lang == TypeScript {
+		return camelCase(id, false)
+	}
+	return id
+}
+
+// className returns the class used by cdktf for the resource type t,
+// which is the type without the provider in camel case and prefixed
+// with 'Data' and the provider for the data sources
+// ex: aws_iam_role => IamRole, data aws_subnet => DataAwsSubnet
+func className(block, t string) string {
+	if block == "data" {
+		return "Data" + camelCase(t, true)
+	}
+
+	parts := strings.SplitN(t, "_", 2)
+	if len(parts) == 1 {
+		return camelCase(t, true)
+	}
+
+	return camelCase(parts[1], true)
+}
+
+// camelCase converts the snake case s to camel case,
+// with the first letter upper case if upper
+func camelCase(s string, upper bool) string {
+	var b strings.Builder
+	for i, p := range strings.Split(s, "_") {
+		if p == "" {
+			continue
+		}
+		if i == 0 && !upper {
+			b.WriteString(p)
+			continue
+		}
+		b.WriteString(strings.ToUpper(p[:1]) + p[1:])
+	}
+	return b.String()
+}
+
+// providers returns the sorted list of providers
+// with the sorted classes used from each one
+func providers(cs []construct) ([]string, map[string][]string) {
+	classes := make(map[string][]string)
+	seen := make(map[string]struct{})
+	for _, c := range cs {
+		if _, ok := seen[c.provider+c.class]; ok {
+			continue
+		}
+		seen[c.provider+c.class] = struct{}{}
+		classes[c.provider] = append(classes[c.provider], c.class)
+	}
+
+	names := make([]string, 0, len(classes))
+	for p := range classes {
+		sort.Strings(classes[p])
+		names = append(names, p)
+	}
+	sort.Strings(names)
+
+	return names, classes
+}
+
+func writeTypeScript(w io.Writer, cs []construct) {
+	names, classes := providers(cs)
+
+	fmt.Fprintln(w, "import { Construct } from 'constructs';")
+	fmt.Fprintf(w, "import { %s } from 'cdktf';\n", strings.Join(append([]string{"App", "TerraformStack"}, classes[cdktfCore]...), ", "))
+
+	for _, p := range names {
+		if p == cdktfCore {
+			continue
+		}
+		fmt.Fprintf(w, "import { %s } from './.gen/providers/%s';\n", strings.Join(classes[p], ", "), p)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "class TerracognitaStack extends TerraformStack {")
+	fmt.Fprintln(w, "  constructor(scope: Construct, name: string) {")
+	fmt.Fprintln(w, "    super(scope, name);")
+
+	for _, c := range cs {
+		fmt.Fprintln(w)
+		if c.variable != "" {
+			fmt.Fprintf(w, "    const %s = new %s(this, %s, ", c.variable, c.class, quote(c.id))
+		} else {
+			fmt.Fprintf(w, "    new %s(this, %s, ", c.class, quote(c.id))
+		}
+		writeTypeScriptValue(w, c.config, "    ", false)
+		fmt.Fprintln(w, ");")
+	}
+
+	fmt.Fprintln(w, "  }")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "const app = new App();")
+	fmt.Fprintf(w, "new TerracognitaStack(app, %s);\n", quote(stackName))
+	fmt.Fprintln(w, "app.synth();")
+}
+
+// writeTypeScriptValue writes the v as a TypeScript literal, the keys
+// of the objects are converted to camel case unless raw is set, which
+// is for the values of the TF maps
+func writeTypeScriptValue(w io.Writer, v interface{}, indent string, raw bool) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		if len(vv) == 0 {
+			fmt.Fprint(w, "{}")
+			return
+		}
+		fmt.Fprintln(w, "{")
+		for _, k := range sortedKeys(vv) {
+			key, isMap := attributeKey(k)
+			if raw {
+				key = quote(k)
+			} else {
+				key = camelCase(key, false)
+			}
+			fmt.Fprintf(w, "%s  %s: ", indent, key)
+			writeTypeScriptValue(w, vv[k], indent+"  ", isMap)
+			fmt.Fprintln(w, ",")
+		}
+		fmt.Fprintf(w, "%s}", indent)
+	case []interface{}:
+		if len(vv) == 0 {
+			fmt.Fprint(w, "[]")
+			return
+		}
+		fmt.Fprintln(w, "[")
+		for _, e := range vv {
+			fmt.Fprintf(w, "%s  ", indent)
+			writeTypeScriptValue(w, e, indent+"  ", raw)
+			fmt.Fprintln(w, ",")
+		}
+		fmt.Fprintf(w, "%s]", indent)
+	case reference:
+		fmt.Fprint(w, string(vv))
+	case nil:
+		fmt.Fprint(w, "null")
+	default:
+		fmt.Fprint(w, literal(vv))
+	}
+}
+
+func writePython(w io.Writer, cs []construct) {
+	fmt.Fprintln(w, "#!/usr/bin/env python")
+	names, classes := providers(cs)
+
+	fmt.Fprintln(w, "from constructs import Construct")
+	fmt.Fprintf(w, "from cdktf import %s\n", strings.Join(append([]string{"App", "TerraformStack"}, classes[cdktfCore]...), ", "))
+
+	for _, p := range names {
+		if p == cdktfCore {
+			continue
+		}
+		fmt.Fprintf(w, "from imports.%s import %s\n", p, strings.Join(classes[p], ", "))
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "class TerracognitaStack(TerraformStack):")
+	fmt.Fprintln(w, "    def __init__(self, scope: Construct, ns: str):")
+	fmt.Fprintln(w, "        super().__init__(scope, ns)")
+
+	for _, c := range cs {
+		fmt.Fprintln(w)
+		if c.variable != "" {
+			fmt.Fprintf(w, "        %s = %s(self, %s", c.variable, c.class, quote(c.id))
+		} else {
+			fmt.Fprintf(w, "        %s(self, %s", c.class, quote(c.id))
+		}
+		for _, k := range sortedKeys(c.config) {
+			key, isMap := attributeKey(k)
+			fmt.Fprintf(w, ",\n            %s=", key)
+			writePythonValue(w, c.config[k], "            ", isMap)
+		}
+		fmt.Fprintln(w, ")")
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "app = App()")
+	fmt.Fprintf(w, "TerracognitaStack(app, %s)\n", quote(stackName))
+	fmt.Fprintln(w, "app.synth()")
+}
+
+// writePythonValue writes the v as a Python literal
+func writePythonValue(w io.Writer, v interface{}, indent string, raw bool) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		if len(vv) == 0 {
+			fmt.Fprint(w, "{}")
+			return
+		}
+		fmt.Fprintln(w, "{")
+		for _, k := range sortedKeys(vv) {
+			key, isMap := attributeKey(k)
+			if raw {
+				key = k
+			}
+			fmt.Fprintf(w, "%s    %s: ", indent, quote(key))
+			writePythonValue(w, vv[k], indent+"    ", isMap)
+			fmt.Fprintln(w, ",")
+		}
+		fmt.Fprintf(w, "%s}", indent)
+	case []interface{}:
+		if len(vv) == 0 {
+			fmt.Fprint(w, "[]")
+			return
+		}
+		fmt.Fprintln(w, "[")
+		for _, e := range vv {
+			fmt.Fprintf(w, "%s    ", indent)
+			writePythonValue(w, e, indent+"    ", raw)
+			fmt.Fprintln(w, ",")
+		}
+		fmt.Fprintf(w, "%s]", indent)
+	case bool:
+		if vv {
+			fmt.Fprint(w, "True")
+		} else {
+			fmt.Fprint(w, "False")
+		}
+	case reference:
+		fmt.Fprint(w, string(vv))
+	case nil:
+		fmt.Fprint(w, "None")
+	default:
+		fmt.Fprint(w, literal(vv))
+	}
+}
+
+// attributeKey returns the key without the '=tc=' prefix
+// that the TF maps have and if it was a map or not
+// See: provider.mergeFullConfig
+func attributeKey(k string) (string, bool) {
+	if strings.HasPrefix(k, "=tc=") {
+		return strings.TrimPrefix(k, "=tc="), true
+	}
+	return k, false
+}
+
+// sortedKeys returns the keys of m sorted
+// without the '=tc=' prefix
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ki, _ := attributeKey(keys[i])
+		kj, _ := attributeKey(keys[j])
+		return ki < kj
+	})
+	return keys
+}
+
+// literal returns the JSON representation of the v
+// which is valid on TypeScript and Python for strings
+// and numbers
+func literal(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return quote(fmt.Sprintf("%v", v))
+	}
+	return string(b)
+}
+
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
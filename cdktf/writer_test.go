@@ -0,0 +1,202 @@
+package cdktf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cycloidio/terracognita/cdktf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, w *cdktf.Writer) {
+	err := w.Write("aws_instance.front", map[string]interface{}{
+		"ami":           "ami-123",
+		"instance_type": "t2.micro",
+		"ebs_optimized": false,
+		"=tc=tags": map[string]interface{}{
+			"Name": "front",
+		},
+		"root_block_device": []interface{}{
+			map[string]interface{}{
+				"volume_size": 8,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	err = w.Write("data.aws_subnet.shared", map[string]interface{}{
+		"id":       "subnet-123",
+		"provider": "aws.shared",
+	})
+	require.NoError(t, err)
+
+	err = w.Write("provider.aws.shared", map[string]interface{}{
+		"region": "eu-west-1",
+	})
+	require.NoError(t, err)
+}
+
+func TestSync(t *testing.T) {
+	t.Run("TypeScript", func(t *testing.T) {
+		var (
+			b  = &bytes.Buffer{}
+			w  = cdktf.NewWriter(b, cdktf.TypeScript)
+			ts = `import { Construct } from 'constructs';
+import { App, TerraformStack } from 'cdktf';
+import { AwsProvider, DataAwsSubnet, Instance } from './.gen/providers/aws';
+
+class TerracognitaStack extends TerraformStack {
+  constructor(scope: Construct, name: string) {
+    super(scope, name);
+
+    const awsShared = new AwsProvider(this, "aws_shared", {
+      alias: "shared",
+      region: "eu-west-1",
+    });
+
+    new DataAwsSubnet(this, "shared", {
+      id: "subnet-123",
+      provider: awsShared,
+    });
+
+    new Instance(this, "front", {
+      ami: "ami-123",
+      ebsOptimized: false,
+      instanceType: "t2.micro",
+      rootBlockDevice: [
+        {
+          volumeSize: 8,
+        },
+      ],
+      tags: {
+        "Name": "front",
+      },
+    });
+  }
+}
+
+const app = new App();
+new TerracognitaStack(app, "terracognita");
+app.synth();
+`
+		)
+
+		writeConfig(t, w)
+
+		err := w.Sync()
+		require.NoError(t, err)
+		assert.Equal(t, ts, b.String())
+	})
+	t.Run("Python", func(t *testing.T) {
+		var (
+			b  = &bytes.Buffer{}
+			w  = cdktf.NewWriter(b, cdktf.Python)
+			py = `#!/usr/bin/env python
+from constructs import Construct
+from cdktf import App, TerraformStack
+from imports.aws import AwsProvider, DataAwsSubnet, Instance
+
+
+class TerracognitaStack(TerraformStack):
+    def __init__(self, scope: Construct, ns: str):
+        super().__init__(scope, ns)
+
+        aws_shared = AwsProvider(self, "aws_shared",
+            alias="shared",
+            region="eu-west-1")
+
+        DataAwsSubnet(self, "shared",
+            id="subnet-123",
+            provider=aws_shared)
+
+        Instance(self, "front",
+            ami="ami-123",
+            ebs_optimized=False,
+            instance_type="t2.micro",
+            root_block_device=[
+                {
+                    "volume_size": 8,
+                },
+            ],
+            tags={
+                "Name": "front",
+            })
+
+
+app = App()
+TerracognitaStack(app, "terracognita")
+app.synth()
+`
+		)
+
+		writeConfig(t, w)
+
+		err := w.Sync()
+		require.NoError(t, err)
+		assert.Equal(t, py, b.String())
+	})
+	t.Run("Variable", func(t *testing.T) {
+		var (
+			b  = &bytes.Buffer{}
+			w  = cdktf.NewWriter(b, cdktf.TypeScript)
+			ts = `import { Construct } from 'constructs';
+import { App, TerraformStack, TerraformVariable } from 'cdktf';
+import { DbInstance } from './.gen/providers/aws';
+
+class TerracognitaStack extends TerraformStack {
+  constructor(scope: Construct, name: string) {
+    super(scope, name);
+
+    new TerraformVariable(this, "aws_db_instance_db_password", {
+      description: "The password",
+    });
+
+    new DbInstance(this, "db", {
+      password: "${var.aws_db_instance_db_password}",
+    });
+  }
+}
+
+const app = new App();
+new TerracognitaStack(app, "terracognita");
+app.synth();
+`
+		)
+
+		err := w.Write("aws_db_instance.db", map[string]interface{}{
+			"password": "${var.aws_db_instance_db_password}",
+		})
+		require.NoError(t, err)
+
+		err = w.Write("variable.aws_db_instance_db_password", map[string]interface{}{
+			"description": "The password",
+		})
+		require.NoError(t, err)
+
+		err = w.Sync()
+		require.NoError(t, err)
+		assert.Equal(t, ts, b.String())
+	})
+	t.Run("ConfigNotModified", func(t *testing.T) {
+		var (
+			b   = &bytes.Buffer{}
+			w   = cdktf.NewWriter(b, cdktf.TypeScript)
+			cfg = map[string]interface{}{
+				"id":       "subnet-123",
+				"provider": "aws.unknown",
+			}
+		)
+
+		err := w.Write("data.aws_subnet.shared", cfg)
+		require.NoError(t, err)
+
+		err = w.Sync()
+		require.NoError(t, err)
+		assert.NotContains(t, b.String(), "provider:")
+		assert.Equal(t, map[string]interface{}{
+			"id":       "subnet-123",
+			"provider": "aws.unknown",
+		}, cfg)
+	})
+}
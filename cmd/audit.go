@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/cycloidio/terracognita/util"
+	"github.com/pkg/errors"
+)
+
+var (
+	// auditErr is the error of the first call to the
+	// APIs that was not read only and auditCancel the
+	// cancel of the import running, if any
+	auditErr    error
+	auditCancel context.CancelFunc
+	auditMu     sync.Mutex
+)
+
+// setAudit configures the audit of the calls to the
+// APIs of the providers with the 'assert-read-only'
+// and 'audit-log' of the get
+func setAudit(getBool func(string) bool, get func(string) string) error {
+	a := util.Audit{
+		ReadOnly: getBool("assert-read-only"),
+		// The SDKs may retry the call or the import ignore
+		// the error, so the import is canceled on the first
+		// one and it's returned by withAuditViolation
+		OnViolation: func(err error) {
+			auditMu.Lock()
+			defer auditMu.Unlock()
+
+			if auditErr == nil {
+				auditErr = err
+			}
+			if auditCancel != nil {
+				auditCancel()
+			}
+		},
+	}
+
+	if al := get("audit-log"); al != "" {
+		f, err := os.OpenFile(al, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+		if err != nil {
+			return fmt.Errorf("could not OpenFile %s because: %s", al, err)
+		}
+		a.Log = f
+	}
+
+	util.SetAudit(a)
+
+	return nil
+}
+
+// setAuditCancel sets the cancel of the
+// import to call on the first violation
+func setAuditCancel(cancel context.CancelFunc) {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	auditCancel = cancel
+}
+
+// withAuditViolation returns the error of the call that was
+// not read only, if any, instead of the err of the import. As
+// the import was canceled the outputs are closed so the ones
+// written are flushed (ex: the --tfstate-encrypt)
+func withAuditViolation(err error) error {
+	auditMu.Lock()
+	aerr := auditErr
+	auditMu.Unlock()
+
+	if aerr == nil {
+		return err
+	}
+
+	if cerr := closeOutputs(); cerr != nil {
+		return errors.Wrapf(aerr, "could not close the outputs because: %s", cerr)
+	}
+
+	return aerr
+}
@@ -4,16 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	kitlog "github.com/go-kit/kit/log"
 
 	"github.com/cycloidio/terracognita/aws"
 	"github.com/cycloidio/terracognita/filter"
-	"github.com/cycloidio/terracognita/hcl"
 	"github.com/cycloidio/terracognita/log"
 	"github.com/cycloidio/terracognita/provider"
-	"github.com/cycloidio/terracognita/state"
+	"github.com/cycloidio/terracognita/stack"
 	"github.com/cycloidio/terracognita/tag"
 	"github.com/cycloidio/terracognita/writer"
 	"github.com/spf13/cobra"
@@ -27,19 +29,25 @@ var (
 		Use:   "aws",
 		Short: "Terracognita reads from AWS and generates hcl resources and/or terraform state",
 		Long:  "Terracognita reads from AWS and generates hcl resources and/or terraform state",
-		PreRun: func(cmd *cobra.Command, args []string) {
-			preRunEOutput(cmd, args)
-			viper.BindPFlag("access-key", cmd.Flags().Lookup("access-key"))
-			viper.BindPFlag("secret-key", cmd.Flags().Lookup("secret-key"))
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			bindAWSCredentialsFlags(cmd)
 			viper.BindPFlag("region", cmd.Flags().Lookup("region"))
 			viper.BindPFlag("tags", cmd.Flags().Lookup("tags"))
+			viper.BindPFlag("shared-as-data", cmd.Flags().Lookup("shared-as-data"))
+			viper.BindPFlag("shared-provider-alias", cmd.Flags().Lookup("shared-provider-alias"))
+			viper.BindPFlag("cloudformation-report", cmd.Flags().Lookup("cloudformation-report"))
+			viper.BindPFlag("rules", cmd.Flags().Lookup("rules"))
+			viper.BindPFlag("organizations", cmd.Flags().Lookup("organizations"))
+			viper.BindPFlag("registry-modules", cmd.Flags().Lookup("registry-modules"))
+			viper.BindPFlag("endpoint-url", cmd.Flags().Lookup("endpoint-url"))
+			return preRunEOutput(cmd, args)
 		},
 		PostRunE: postRunEOutput,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := log.Get()
 			logger = kitlog.With(logger, "func", "cmd.aws.RunE")
 			// Validate required flags
-			if err := requiredStringFlags("access-key", "secret-key", "region"); err != nil {
+			if err := requiredStringFlags("region"); err != nil {
 				return err
 			}
 
@@ -55,38 +63,147 @@ var (
 
 			ctx := context.Background()
 
-			awsP, err := aws.NewProvider(ctx, viper.GetString("access-key"), viper.GetString("secret-key"), viper.GetString("region"))
+			creds, err := awsCredentials(ctx, viper.GetString)
+			if err != nil {
+				return err
+			}
+
+			eps, err := endpoints(true)
+			if err != nil {
+				return err
+			}
+
+			regions := strings.Split(viper.GetString("region"), ",")
+			if viper.GetBool("registry-modules") {
+				if len(regions) > 1 {
+					return errors.New("the flag --registry-modules can not be used with multiple regions")
+				}
+				if viper.GetString("stacks") != "" {
+					return errors.New("the flag --registry-modules can not be used with --stacks")
+				}
+				if f := viper.GetString("hcl-format"); f != "" && f != "hcl" {
+					return fmt.Errorf("the --hcl-format %q can not be used with --registry-modules, only 'hcl' can", f)
+				}
+			}
+			awsPs := make([]provider.Provider, 0, len(regions))
+			for _, r := range regions {
+				r = strings.TrimSpace(r)
+				opt := aws.Options{
+					SharedAsData:        viper.GetBool("shared-as-data"),
+					SharedProviderAlias: viper.GetString("shared-provider-alias"),
+					Rules:               viper.GetString("rules"),
+					Organizations:       viper.GetBool("organizations"),
+					Endpoints:           eps,
+					Credentials:         creds.Refreshing,
+				}
+
+				// With multiple regions each one is imported
+				// with an aliased provider (ex: us-east-1 => aws.us_east_1)
+				if len(regions) > 1 {
+					opt.Alias = strings.Replace(r, "-", "_", -1)
+				}
+
+				p, err := aws.NewProvider(ctx, creds.AccessKey, creds.SecretKey, creds.SessionToken, r, opt)
+				if err != nil {
+					return err
+				}
+				awsPs = append(awsPs, p)
+			}
+
+			// viper does not split the StringArray
+			// so it's read from the flag
+			fs, err := cmd.Flags().GetStringArray("filter")
+			if err != nil {
+				return err
+			}
+			rules, err := filter.ParseRules(fs)
+			if err != nil {
+				return err
+			}
+
+			pinclude, err := presetInclude(aws.Preset)
 			if err != nil {
 				return err
 			}
 
 			f := &filter.Filter{
 				Tags:    tags,
-				Include: include,
+				Include: pinclude,
 				Exclude: exclude,
+				Rules:   rules,
+			}
+
+			if err := mergeIgnoreFile(f, aws.ResourceTypeStrings()); err != nil {
+				return err
+			}
+
+			if nr := viper.GetString("name-regex"); nr != "" {
+				f.NameRegex, err = regexp.Compile(nr)
+				if err != nil {
+					return fmt.Errorf("invalid --name-regex: %s", err)
+				}
+			}
+
+			if isWatch() {
+				if len(awsPs) > 1 {
+					return errors.New("the flag --watch can not be used with multiple regions")
+				}
+				return runWatch(ctx, awsPs[0], f)
 			}
 
 			var hclW, stateW writer.Writer
 
 			if hclOut != nil {
 				logger.Log("msg", "initialzing HCL writer")
-				hclW = hcl.NewWriter(hclOut)
+				hclW, err = newHCLWriter(hclOut)
+				if err != nil {
+					return err
+				}
+			} else if stacks != nil {
+				logger.Log("msg", "initialzing stacks HCL writer")
+				hclW = stacks.HCLWriter()
 			}
 
-			if stateOut != nil {
+			if w := newStateWriter(); w != nil {
 				logger.Log("msg", "initialzing TFState writer")
-				stateW = state.NewWriter(stateOut)
+				stateW = w
+			}
+
+			var cfnReport *stack.ReportWriter
+			if viper.GetString("cloudformation-report") != "" {
+				if hclW == nil {
+					return errors.New("the flag --cloudformation-report requires --hcl or --stacks")
+				}
+				if viper.GetBool("skip-managed") {
+					return errors.New("the flag --cloudformation-report can not be used with --skip-managed")
+				}
+				cfnReport = stack.NewReportWriter(hclW, stack.CloudFormationTag)
+				hclW = cfnReport
 			}
 
 			logger.Log("msg", "importing")
 
 			fmt.Fprintf(logsOut, "Starting Terracognita with version %s\n", Version)
 			logger.Log("msg", "starting terracognita", "version", Version)
-			err = provider.Import(ctx, awsP, hclW, stateW, f, logsOut)
+			ictx, cancel := importContext(ctx)
+			defer cancel()
+
+			opt := importOptions()
+			opt.RegistryModules = viper.GetBool("registry-modules")
+			start := time.Now()
+			err = withAuditViolation(provider.ImportProviders(ictx, awsPs, hclW, stateW, f, opt, logsOut))
+			notifyImport(ctx, "aws", opt.Summary, start, err)
+			recordImport(cmd, "aws", opt.Summary, f, start, err)
 			if err != nil {
 				return fmt.Errorf("could not import from AWS: %+v", err)
 			}
 
+			if cfnReport != nil {
+				if err := writeCloudFormationReport(cfnReport, viper.GetString("cloudformation-report")); err != nil {
+					return err
+				}
+			}
+
 			return nil
 		},
 	}
@@ -94,12 +211,42 @@ var (
 
 func init() {
 	awsCmd.AddCommand(awsResourcesCmd)
+	awsCmd.AddCommand(awsCoverageCmd)
+	awsCmd.AddCommand(awsPermissionsCmd)
+	awsCmd.AddCommand(awsQueryCmd)
+	awsCmd.AddCommand(awsPresetsCmd)
+	awsCmd.AddCommand(awsRefreshCmd)
 
 	// Required flags
-	awsCmd.Flags().String("access-key", "", "Access Key (required)")
-	awsCmd.Flags().String("secret-key", "", "Secret Key (required)")
-	awsCmd.Flags().String("region", "", "Region to search in, for now * it's not supported (required)")
+	awsCmd.Flags().String("region", "", "Region to search in, for now * it's not supported, multiple regions can be separated by comma and each one is imported with an aliased provider (ex: us-east-1,eu-west-1) (required)")
+
+	// Credentials flags
+	awsCredentialsFlags(awsCmd)
 
 	// Filter flags
 	awsCmd.Flags().StringSliceVarP(&tags, "tags", "t", []string{}, "List of tags to filter with format 'NAME:VALUE'")
+
+	// Optional flags
+	awsCmd.Flags().Bool("shared-as-data", false, "Import the resources shared with the account (via RAM), like subnets or transit gateways, as data sources instead of resources")
+	awsCmd.Flags().String("shared-provider-alias", "", "Provider alias used on the data sources of the shared resources (ex: shared => aws.shared)")
+	awsCmd.Flags().String("cloudformation-report", "", "JSON output file with the CloudFormation stacks and the HCL resources of each one")
+	awsCmd.Flags().String("rules", aws.RulesInline, "Representation of the rules of the security groups and network ACLs, one of: inline (the ingress and egress of the aws_security_group and aws_network_acl), standalone (aws_security_group_rule and aws_network_acl_rule)")
+	awsCmd.Flags().Bool("organizations", false, "Import the AWS Organizations resources (organization, accounts, organizational units and service control policies), which need the credentials of the management account")
+	endpointsFlag(awsCmd, true)
+	awsCmd.Flags().Bool("registry-modules", false, "Experimental: write the VPCs, with their subnets, internet and NAT gateways, as calls to the terraform-aws-modules/vpc module of the Terraform Registry instead of the resources")
+}
+
+// writeCloudFormationReport writes the report of the r to the file
+func writeCloudFormationReport(r *stack.ReportWriter, file string) error {
+	f, err := os.OpenFile(file, os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("could not open the --cloudformation-report %s because: %s", file, err)
+	}
+	defer f.Close()
+
+	if err := r.Report(f); err != nil {
+		return fmt.Errorf("could not write the --cloudformation-report because: %s", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cycloidio/terracognita/aws"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	awsCoverageCmd = &cobra.Command{
+		Use:   "coverage",
+		Short: "Report of the AWS resources of the account that can not be imported",
+		Long:  "Lists the resources of the account on the region with the AWS Resource Groups Tagging API, only the ones that are or have been tagged, and reports the types that are not supported by Terracognita",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			bindAWSCredentialsFlags(cmd)
+			viper.BindPFlag("region", cmd.Flags().Lookup("region"))
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requiredStringFlags("region"); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+
+			creds, err := awsCredentials(ctx, viper.GetString)
+			if err != nil {
+				return err
+			}
+
+			report, err := aws.Coverage(ctx, creds.AccessKey, creds.SecretKey, creds.SessionToken, viper.GetString("region"))
+			if err != nil {
+				return fmt.Errorf("could not check the coverage of AWS: %+v", err)
+			}
+
+			writeCoverage(os.Stdout, report)
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	awsCoverageCmd.Flags().String("region", "", "Region to search in (required)")
+	awsCredentialsFlags(awsCoverageCmd)
+}
+
+// writeCoverage writes the report to the w, first the
+// unsupported types so they can be prioritized
+func writeCoverage(w io.Writer, report *aws.CoverageReport) {
+	var supported, unsupported int
+
+	fmt.Fprintln(w, "Unsupported:")
+	for _, t := range sortedCounts(report.Unsupported) {
+		fmt.Fprintf(w, "  %s (%d)\n", t, report.Unsupported[t])
+		unsupported += report.Unsupported[t]
+	}
+
+	fmt.Fprintln(w, "Supported:")
+	for _, t := range sortedCounts(report.Supported) {
+		rts := make([]string, 0, len(report.ResourceTypes[t]))
+		for _, rt := range report.ResourceTypes[t] {
+			rts = append(rts, rt.String())
+		}
+		fmt.Fprintf(w, "  %s (%d): %s\n", t, report.Supported[t], strings.Join(rts, ", "))
+		supported += report.Supported[t]
+	}
+
+	if total := supported + unsupported; total != 0 {
+		fmt.Fprintf(w, "Coverage: %d of %d resources (%d%%)\n", supported, total, supported*100/total)
+	}
+}
+
+// sortedCounts returns the keys of the counts sorted
+// by the count, the higher first, and then by the key
+func sortedCounts(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/cycloidio/terracognita/aws"
+	"github.com/spf13/cobra"
+)
+
+var (
+	awsPermissionsCmd = &cobra.Command{
+		Use:   "permissions",
+		Short: "Read-only IAM policy needed to import the AWS Resources",
+		Long:  "Prints the read-only IAM policy needed to import the AWS resources of the --include and --exclude, or all of them if not set",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			types, err := selectedTypes(aws.ResourceTypeStrings())
+			if err != nil {
+				return err
+			}
+
+			p, err := aws.Permissions(types)
+			if err != nil {
+				return err
+			}
+
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(p); err != nil {
+				return fmt.Errorf("could not write the IAM policy: %s", err)
+			}
+
+			return nil
+		},
+	}
+)
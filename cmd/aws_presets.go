@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cycloidio/terracognita/aws"
+	"github.com/spf13/cobra"
+)
+
+var (
+	awsPresetsCmd = &cobra.Command{
+		Use:   "presets",
+		Short: "List of the AWS presets and the Resources of each one",
+		Long:  "Prints the AWS presets, usable with --preset, and the resources each one includes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, n := range aws.PresetNames() {
+				types, err := aws.Preset(n)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%s: %s\n", n, strings.Join(types, ", "))
+			}
+			return nil
+		},
+	}
+)
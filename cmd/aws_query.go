@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/cycloidio/terracognita/aws"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	awsQueryCmd = &cobra.Command{
+		Use:   "query",
+		Short: "Interactive shell to query the AWS resources and import them",
+		Long:  "Interactive shell to query the AWS resources of the region (ex: 'show aws_instance where tags.env=prod'), which are read once for each type, and import the results of the last query with 'import HCL [TFSTATE]'",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			bindAWSCredentialsFlags(cmd)
+			viper.BindPFlag("region", cmd.Flags().Lookup("region"))
+			viper.BindPFlag("endpoint-url", cmd.Flags().Lookup("endpoint-url"))
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requiredStringFlags("region"); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+
+			creds, err := awsCredentials(ctx, viper.GetString)
+			if err != nil {
+				return err
+			}
+
+			eps, err := endpoints(true)
+			if err != nil {
+				return err
+			}
+
+			p, err := aws.NewProvider(ctx, creds.AccessKey, creds.SecretKey, creds.SessionToken, viper.GetString("region"), aws.Options{
+				Endpoints:   eps,
+				Credentials: creds.Refreshing,
+			})
+			if err != nil {
+				return err
+			}
+
+			return runQuery(ctx, p)
+		},
+	}
+)
+
+func init() {
+	awsQueryCmd.Flags().String("region", "", "Region to search in (required)")
+	awsCredentialsFlags(awsQueryCmd)
+	endpointsFlag(awsQueryCmd, true)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/cycloidio/terracognita/aws"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	awsRefreshCmd = &cobra.Command{
+		Use:   "refresh",
+		Short: "Reads again the AWS resources of a TFState and updates them",
+		Long:  "Reads again only the AWS resources of the --tfstate generated before and updates the attributes of them on it, and on the --hcl, keeping the same addresses. The resources not found anymore are removed",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			bindAWSCredentialsFlags(cmd)
+			viper.BindPFlag("region", cmd.Flags().Lookup("region"))
+			viper.BindPFlag("rules", cmd.Flags().Lookup("rules"))
+			viper.BindPFlag("endpoint-url", cmd.Flags().Lookup("endpoint-url"))
+			return preRunRefresh(cmd, args)
+		},
+		PostRunE: postRunEOutput,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requiredStringFlags("region"); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+
+			creds, err := awsCredentials(ctx, viper.GetString)
+			if err != nil {
+				return err
+			}
+
+			eps, err := endpoints(true)
+			if err != nil {
+				return err
+			}
+
+			p, err := aws.NewProvider(ctx, creds.AccessKey, creds.SecretKey, creds.SessionToken, viper.GetString("region"), aws.Options{
+				Rules:       viper.GetString("rules"),
+				Endpoints:   eps,
+				Credentials: creds.Refreshing,
+			})
+			if err != nil {
+				return err
+			}
+
+			return runRefresh(ctx, cmd, p)
+		},
+	}
+)
+
+func init() {
+	awsRefreshCmd.Flags().String("region", "", "Region of the resources (required)")
+	awsRefreshCmd.Flags().String("rules", aws.RulesInline, "Representation of the rules of the security groups and network ACLs used on the import, one of: inline, standalone")
+	awsCredentialsFlags(awsRefreshCmd)
+	endpointsFlag(awsRefreshCmd, true)
+}
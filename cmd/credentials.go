@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cycloidio/terracognita/aws"
+	"github.com/cycloidio/terracognita/google"
+	"github.com/cycloidio/terracognita/oidc"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// awsCredentialsFlags defines the flags of the AWS credentials on the cmd
+func awsCredentialsFlags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.String("access-key", "", "Access Key (required if no --credential-process, --sso-start-url or --oidc)")
+	fs.String("secret-key", "", "Secret Key (required if no --credential-process, --sso-start-url or --oidc)")
+	fs.String("session-token", "", "Session Token for temporary credentials")
+	fs.String("credential-process", "", "Command that returns the credentials, the same as the 'credential_process' of the AWS config")
+	fs.String("sso-start-url", "", "AWS SSO start URL, the token cached by 'aws sso login' is used")
+	fs.String("sso-region", "", "AWS SSO region, by default the one of the cached token")
+	fs.String("sso-account-id", "", "AWS SSO account ID (required with --sso-start-url)")
+	fs.String("sso-role-name", "", "AWS SSO role name (required with --sso-start-url)")
+	oidcFlags(cmd, "sts.amazonaws.com")
+	fs.String("role-arn", "", "ARN of the role assumed with the OIDC token (required with --oidc)")
+	fs.String("role-session-name", "terracognita", "Session name of the role assumed with the OIDC token")
+}
+
+// bindAWSCredentialsFlags binds the flags defined
+// by awsCredentialsFlags on the cmd to viper
+func bindAWSCredentialsFlags(cmd *cobra.Command) {
+	for _, f := range []string{"access-key", "secret-key", "session-token", "credential-process", "sso-start-url", "sso-region", "sso-account-id", "sso-role-name", "oidc", "oidc-audience", "role-arn", "role-session-name"} {
+		viper.BindPFlag(f, cmd.Flags().Lookup(f))
+	}
+}
+
+// awsCredentials returns the AWS Credentials from the configuration
+// get, which can be a credential process, an AWS SSO role, a role
+// assumed with the OIDC token of the CI or the static keys, checked
+// in that order
+func awsCredentials(ctx context.Context, get func(string) string) (aws.Credentials, error) {
+	switch {
+	case get("credential-process") != "":
+		if viper.GetBool("no-input") {
+			return aws.NewProcessCredentialsNoInput(ctx, get("credential-process"))
+		}
+		return aws.NewProcessCredentials(get("credential-process"))
+	case get("sso-start-url") != "":
+		if err := requiredKeys(get, "sso-account-id", "sso-role-name"); err != nil {
+			return aws.Credentials{}, err
+		}
+		return aws.NewSSOCredentials(ctx, get("sso-start-url"), get("sso-region"), get("sso-account-id"), get("sso-role-name"))
+	case get("oidc") != "":
+		if err := requiredKeys(get, "role-arn"); err != nil {
+			return aws.Credentials{}, err
+		}
+		token, err := oidc.Token(ctx, get("oidc"), get("oidc-audience"))
+		if err != nil {
+			return aws.Credentials{}, err
+		}
+		// The STS is global, so with multiple
+		// regions the first one is used
+		region := strings.TrimSpace(strings.Split(get("region"), ",")[0])
+		if region == "" {
+			region = "us-east-1"
+		}
+		sessionName := get("role-session-name")
+		if sessionName == "" {
+			sessionName = "terracognita"
+		}
+		return aws.NewWebIdentityCredentials(ctx, get("role-arn"), sessionName, token, region)
+	default:
+		if err := requiredKeys(get, "access-key", "secret-key"); err != nil {
+			return aws.Credentials{}, err
+		}
+		return aws.Credentials{
+			AccessKey:    get("access-key"),
+			SecretKey:    get("secret-key"),
+			SessionToken: get("session-token"),
+		}, nil
+	}
+}
+
+// oidcFlags defines the flags of the OIDC token of
+// the CI on the cmd, with the default audience of it
+func oidcFlags(cmd *cobra.Command, audience string) {
+	fs := cmd.Flags()
+	fs.String("oidc", "", "CI of the OIDC token exchanged for the credentials, one of: github (GitHub Actions, with the 'id-token: write' permission), gitlab (GitLab CI, with the token on the id_tokens as TERRACOGNITA_ID_TOKEN)")
+	fs.String("oidc-audience", audience, "Audience of the OIDC token requested to GitHub Actions, on GitLab CI it's the one of the id_tokens")
+}
+
+// googleCredentialsFlags defines the flags of the Workload
+// Identity Federation of the Google credentials on the cmd
+func googleCredentialsFlags(cmd *cobra.Command) {
+	oidcFlags(cmd, "")
+	cmd.Flags().String("workload-identity-provider", "", "Workload Identity Federation provider the OIDC token is exchanged with, with format 'projects/NUMBER/locations/global/workloadIdentityPools/POOL/providers/PROVIDER' (required with --oidc)")
+}
+
+// bindGoogleCredentialsFlags binds the flags defined
+// by googleCredentialsFlags on the cmd to viper
+func bindGoogleCredentialsFlags(cmd *cobra.Command) {
+	for _, f := range []string{"oidc", "oidc-audience", "workload-identity-provider"} {
+		viper.BindPFlag(f, cmd.Flags().Lookup(f))
+	}
+}
+
+// googleToken returns the federated token exchanged for the OIDC
+// token of the CI if the configuration get has one, if not the
+// credentials (or the Application Default Credentials) are used
+// so it's empty. The default audience of the OIDC token is the
+// one of the Workload Identity Federation provider
+func googleToken(ctx context.Context, get func(string) string) (string, error) {
+	if get("oidc") == "" {
+		return "", nil
+	}
+	if err := requiredKeys(get, "workload-identity-provider"); err != nil {
+		return "", err
+	}
+
+	audience := get("oidc-audience")
+	if audience == "" {
+		audience = google.WorkloadIdentityAudience(get("workload-identity-provider"))
+	}
+
+	token, err := oidc.Token(ctx, get("oidc"), audience)
+	if err != nil {
+		return "", err
+	}
+
+	return google.FederatedToken(ctx, get("workload-identity-provider"), token)
+}
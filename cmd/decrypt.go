@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cycloidio/terracognita/encrypt"
+)
+
+var (
+	decryptCmd = &cobra.Command{
+		Use:   "decrypt FILE",
+		Short: "Decrypts a TFState encrypted with --tfstate-encrypt",
+		Long:  "Decrypts a TFState encrypted with --tfstate-encrypt and writes it to the Stdout, the same --tfstate-encrypt and --tfstate-passphrase used to encrypt it are needed",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := tfstateKeyer()
+			if err != nil {
+				return err
+			}
+
+			b, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("could not read %s because: %s", args[0], err)
+			}
+
+			plain, err := encrypt.Decrypt(k, b)
+			if err != nil {
+				return fmt.Errorf("could not decrypt %s because: %s", args[0], err)
+			}
+
+			_, err = os.Stdout.Write(plain)
+			return err
+		},
+	}
+)
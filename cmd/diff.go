@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cycloidio/terracognita/diff"
+)
+
+var (
+	diffCmd = &cobra.Command{
+		Use:   "diff OLD_TFSTATE NEW_TFSTATE",
+		Short: "Shows the resources added, removed and changed between two TFStates",
+		Long:  "Shows the resources added, removed and changed between two TFStates generated with --tfstate as a changelog, to compare with the live infrastructure run an import with --tfstate first",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			old, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("could not open %s because: %s", args[0], err)
+			}
+			defer old.Close()
+
+			new, err := os.Open(args[1])
+			if err != nil {
+				return fmt.Errorf("could not open %s because: %s", args[1], err)
+			}
+			defer new.Close()
+
+			d, err := diff.States(old, new)
+			if err != nil {
+				return fmt.Errorf("could not diff %s and %s because: %s", args[0], args[1], err)
+			}
+
+			d.Write(os.Stdout)
+
+			return nil
+		},
+	}
+)
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// endpointsFlag defines the --endpoint-url flag on the cmd, if all
+// an URL without service is used for all the services
+func endpointsFlag(cmd *cobra.Command, all bool) {
+	usage := "Endpoint of a service with format 'SERVICE=URL' (ex: storage=http://localhost:4443/storage/v1/), to use emulators"
+	if all {
+		usage = "Endpoint of a service with format 'SERVICE=URL' (ex: s3=http://localhost:4566) or of all of them with only the URL, to use emulators like LocalStack"
+	}
+	cmd.Flags().StringSlice("endpoint-url", []string{}, usage)
+}
+
+// endpoints returns the URLs of the --endpoint-url by the name of the
+// service, the one of all the services (if all) has the empty name
+func endpoints(all bool) (map[string]string, error) {
+	values := viper.GetStringSlice("endpoint-url")
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	eps := make(map[string]string, len(values))
+	for _, v := range values {
+		var s, u string
+		if ps := strings.SplitN(v, "=", 2); len(ps) == 2 && !strings.Contains(ps[0], "://") {
+			s, u = ps[0], ps[1]
+		} else if all {
+			u = v
+		} else {
+			return nil, fmt.Errorf("invalid format for --endpoint-url %q, the expected format is 'SERVICE=URL'", v)
+		}
+
+		if pu, err := url.Parse(u); err != nil || pu.Scheme == "" || pu.Host == "" {
+			return nil, fmt.Errorf("invalid URL of --endpoint-url %q, it has to be absolute (ex: http://localhost:4566)", v)
+		}
+		if _, ok := eps[s]; ok {
+			return nil, fmt.Errorf("the --endpoint-url %q is repeated", v)
+		}
+		eps[s] = u
+	}
+
+	return eps, nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/spf13/viper"
+)
+
+// errorJSON is the error written with --error-format json
+type errorJSON struct {
+	Error struct {
+		Class   string `json:"class"`
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// ExitError writes the err returned by the RootCmd with the
+// --error-format and returns the exit code of the process
+// for it, so the wrappers can branch on the class of it
+func ExitError(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	if viper.GetString("error-format") == "json" {
+		writeErrorJSON(os.Stderr, err)
+	} else {
+		fmt.Println(err)
+	}
+
+	return errcode.ExitCode(err)
+}
+
+// writeErrorJSON writes the err as JSON to w
+func writeErrorJSON(w io.Writer, err error) {
+	var ej errorJSON
+	ej.Error.Class = errcode.Class(err)
+	ej.Error.Code = errcode.ExitCode(err)
+	ej.Error.Message = err.Error()
+
+	_ = json.NewEncoder(w).Encode(ej)
+}
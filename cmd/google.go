@@ -3,7 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	kitlog "github.com/go-kit/kit/log"
 	"github.com/pkg/errors"
@@ -12,10 +14,8 @@ import (
 
 	"github.com/cycloidio/terracognita/filter"
 	"github.com/cycloidio/terracognita/google"
-	"github.com/cycloidio/terracognita/hcl"
 	"github.com/cycloidio/terracognita/log"
 	"github.com/cycloidio/terracognita/provider"
-	"github.com/cycloidio/terracognita/state"
 	"github.com/cycloidio/terracognita/tag"
 	"github.com/cycloidio/terracognita/writer"
 )
@@ -25,20 +25,40 @@ var (
 		Use:   "google",
 		Short: "Terracognita reads from GCP and generates hcl resources and/or terraform state",
 		Long:  "Terracognita reads from GCP and generates hcl resources and/or terraform state",
-		PreRun: func(cmd *cobra.Command, args []string) {
-			preRunEOutput(cmd, args)
+		PreRunE: func(cmd *cobra.Command, args []string) error {
 			viper.BindPFlag("credentials", cmd.Flags().Lookup("credentials"))
+			viper.BindPFlag("impersonate-service-account", cmd.Flags().Lookup("impersonate-service-account"))
+			bindGoogleCredentialsFlags(cmd)
 			viper.BindPFlag("project", cmd.Flags().Lookup("project"))
 			viper.BindPFlag("region", cmd.Flags().Lookup("region"))
+			viper.BindPFlag("organization", cmd.Flags().Lookup("organization"))
 			viper.BindPFlag("labels", cmd.Flags().Lookup("labels"))
 			viper.BindPFlag("max-results", cmd.Flags().Lookup("max-results"))
+			viper.BindPFlag("asset-inventory", cmd.Flags().Lookup("asset-inventory"))
+			viper.BindPFlag("endpoint-url", cmd.Flags().Lookup("endpoint-url"))
+			return preRunEOutput(cmd, args)
 		},
 		PostRunE: postRunEOutput,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := log.Get()
 			logger = kitlog.With(logger, "func", "cmd.google.RunE")
 			// Validate required flags
-			if err := requiredStringFlags("region", "project", "credentials"); err != nil {
+			if err := requiredStringFlags("region", "project"); err != nil {
+				return err
+			}
+
+			// The credentials are only optional when impersonating
+			// as then the Application Default Credentials can be used,
+			// with --oidc as the federated token is used or with
+			// --endpoint-url as the requests are not authenticated
+			if viper.GetString("impersonate-service-account") == "" && viper.GetString("oidc") == "" && len(viper.GetStringSlice("endpoint-url")) == 0 {
+				if err := requiredStringFlags("credentials"); err != nil {
+					return err
+				}
+			}
+
+			eps, err := endpoints(false)
+			if err != nil {
 				return err
 			}
 
@@ -54,40 +74,95 @@ var (
 
 			ctx := context.Background()
 
+			token, err := googleToken(ctx, viper.GetString)
+			if err != nil {
+				return err
+			}
+
 			googleP, err := google.NewProvider(
 				ctx,
 				viper.GetUint64("max-results"),
 				viper.GetString("project"),
 				viper.GetString("region"),
+				viper.GetString("organization"),
 				viper.GetString("credentials"),
+				viper.GetString("impersonate-service-account"),
+				token,
+				viper.GetString("asset-inventory"),
+				eps,
 			)
 			if err != nil {
 				return err
 			}
 
+			// viper does not split the StringArray
+			// so it's read from the flag
+			fs, err := cmd.Flags().GetStringArray("filter")
+			if err != nil {
+				return err
+			}
+			rules, err := filter.ParseRules(fs)
+			if err != nil {
+				return err
+			}
+
+			pinclude, err := presetInclude(google.Preset)
+			if err != nil {
+				return err
+			}
+
 			f := &filter.Filter{
 				Tags:    tags,
-				Include: include,
+				Include: pinclude,
 				Exclude: exclude,
+				Rules:   rules,
+			}
+
+			if err := mergeIgnoreFile(f, google.ResourceTypeStrings()); err != nil {
+				return err
+			}
+
+			if nr := viper.GetString("name-regex"); nr != "" {
+				f.NameRegex, err = regexp.Compile(nr)
+				if err != nil {
+					return errors.Wrap(err, "invalid --name-regex")
+				}
+			}
+
+			if isWatch() {
+				return runWatch(ctx, googleP, f)
 			}
 
 			var hclW, stateW writer.Writer
 
 			if hclOut != nil {
 				logger.Log("msg", "initialzing HCL writer")
-				hclW = hcl.NewWriter(hclOut)
+				hclW, err = newHCLWriter(hclOut)
+				if err != nil {
+					return err
+				}
+			} else if stacks != nil {
+				logger.Log("msg", "initialzing stacks HCL writer")
+				hclW = stacks.HCLWriter()
 			}
 
-			if stateOut != nil {
+			if w := newStateWriter(); w != nil {
 				logger.Log("msg", "initialzing TFState writer")
-				stateW = state.NewWriter(stateOut)
+				stateW = w
 			}
 
 			logger.Log("msg", "importing")
 
 			fmt.Fprintf(logsOut, "Starting Terracognita with version %s\n", Version)
 			logger.Log("msg", "starting terracognita", "version", Version)
-			err = provider.Import(ctx, googleP, hclW, stateW, f, logsOut)
+			ictx, cancel := importContext(ctx)
+			defer cancel()
+
+			opt := importOptions()
+			start := time.Now()
+			err = withAuditViolation(provider.Import(ictx, googleP, hclW, stateW, f, opt, logsOut))
+			notifyImport(ctx, "google", opt.Summary, start, err)
+			recordImport(cmd, "google", opt.Summary, f, start, err)
 			if err != nil {
 				return errors.Wrap(err, "could not import from google")
 			}
@@ -99,9 +174,13 @@ var (
 
 func init() {
 	googleCmd.AddCommand(googleResourcesCmd)
+	googleCmd.AddCommand(googlePermissionsCmd)
+	googleCmd.AddCommand(googleQueryCmd)
+	googleCmd.AddCommand(googlePresetsCmd)
+	googleCmd.AddCommand(googleRefreshCmd)
 
 	// Required flags
-	googleCmd.Flags().String("credentials", "", "path to the JSON credential (required)")
+	googleCmd.Flags().String("credentials", "", "path to the JSON credential (required if no --impersonate-service-account or --oidc)")
 	googleCmd.Flags().String("project", "", "project (required)")
 	googleCmd.Flags().String("region", "", "region (required)")
 
@@ -109,5 +188,10 @@ func init() {
 	googleCmd.Flags().StringSliceVarP(&tags, "labels", "t", []string{}, "List of labels to filter with format 'NAME:VALUE'")
 
 	// Optional flags
+	googleCmd.Flags().String("organization", "", "ID of the organization to also import the organization level resources (ex: service perimeters) of")
+	googleCmd.Flags().String("impersonate-service-account", "", "email of the service account to impersonate with the credentials, or the federated token with --oidc")
+	googleCredentialsFlags(googleCmd)
 	googleCmd.Flags().Uint64("max-results", 500, "max results to fetch when pagination is used")
+	endpointsFlag(googleCmd, false)
+	googleCmd.Flags().String("asset-inventory", "", "GCS URI (gs://BUCKET/OBJECT) to export the Cloud Asset Inventory of the project to, only the types present on it are listed")
 }
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cycloidio/terracognita/google"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	googlePermissionsCmd = &cobra.Command{
+		Use:   "permissions",
+		Short: "Read-only IAM role needed to import the Google Resources",
+		Long:  "Prints the read-only IAM custom role, to create with 'gcloud iam roles create --file', needed to import the Google resources of the --include and --exclude, or all of them if not set",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			types, err := selectedTypes(google.ResourceTypeStrings())
+			if err != nil {
+				return err
+			}
+
+			r, err := google.Permissions(types)
+			if err != nil {
+				return err
+			}
+
+			b, err := yaml.Marshal(r)
+			if err != nil {
+				return fmt.Errorf("could not write the IAM role: %s", err)
+			}
+			os.Stdout.Write(b)
+
+			return nil
+		},
+	}
+)
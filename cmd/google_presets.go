@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cycloidio/terracognita/google"
+	"github.com/spf13/cobra"
+)
+
+var (
+	googlePresetsCmd = &cobra.Command{
+		Use:   "presets",
+		Short: "List of the Google presets and the Resources of each one",
+		Long:  "Prints the Google presets, usable with --preset, and the resources each one includes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, n := range google.PresetNames() {
+				types, err := google.Preset(n)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%s: %s\n", n, strings.Join(types, ", "))
+			}
+			return nil
+		},
+	}
+)
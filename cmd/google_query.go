@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/cycloidio/terracognita/google"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	googleQueryCmd = &cobra.Command{
+		Use:   "query",
+		Short: "Interactive shell to query the Google resources and import them",
+		Long:  "Interactive shell to query the Google resources of the project (ex: 'show google_compute_instance where labels.env=prod'), which are read once for each type, and import the results of the last query with 'import HCL [TFSTATE]'",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			viper.BindPFlag("credentials", cmd.Flags().Lookup("credentials"))
+			viper.BindPFlag("impersonate-service-account", cmd.Flags().Lookup("impersonate-service-account"))
+			bindGoogleCredentialsFlags(cmd)
+			viper.BindPFlag("project", cmd.Flags().Lookup("project"))
+			viper.BindPFlag("region", cmd.Flags().Lookup("region"))
+			viper.BindPFlag("max-results", cmd.Flags().Lookup("max-results"))
+			viper.BindPFlag("endpoint-url", cmd.Flags().Lookup("endpoint-url"))
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requiredStringFlags("region", "project"); err != nil {
+				return err
+			}
+
+			// Without credentials the requests to the
+			// --endpoint-url are not authenticated, and
+			// with --oidc the federated token is used
+			if viper.GetString("impersonate-service-account") == "" && viper.GetString("oidc") == "" && len(viper.GetStringSlice("endpoint-url")) == 0 {
+				if err := requiredStringFlags("credentials"); err != nil {
+					return err
+				}
+			}
+
+			eps, err := endpoints(false)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+
+			token, err := googleToken(ctx, viper.GetString)
+			if err != nil {
+				return err
+			}
+
+			p, err := google.NewProvider(
+				ctx,
+				viper.GetUint64("max-results"),
+				viper.GetString("project"),
+				viper.GetString("region"),
+				"",
+				viper.GetString("credentials"),
+				viper.GetString("impersonate-service-account"),
+				token,
+				"",
+				eps,
+			)
+			if err != nil {
+				return err
+			}
+
+			return runQuery(ctx, p)
+		},
+	}
+)
+
+func init() {
+	googleQueryCmd.Flags().String("credentials", "", "path to the JSON credential (required if no --impersonate-service-account or --oidc)")
+	googleQueryCmd.Flags().String("project", "", "project (required)")
+	googleQueryCmd.Flags().String("region", "", "region (required)")
+	googleQueryCmd.Flags().String("impersonate-service-account", "", "email of the service account to impersonate with the credentials, or the federated token with --oidc")
+	googleCredentialsFlags(googleQueryCmd)
+	googleQueryCmd.Flags().Uint64("max-results", 500, "max results to fetch when pagination is used")
+	endpointsFlag(googleQueryCmd, false)
+}
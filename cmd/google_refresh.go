@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/cycloidio/terracognita/google"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	googleRefreshCmd = &cobra.Command{
+		Use:   "refresh",
+		Short: "Reads again the Google resources of a TFState and updates them",
+		Long:  "Reads again only the Google resources of the --tfstate generated before and updates the attributes of them on it, and on the --hcl, keeping the same addresses. The resources not found anymore are removed",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			viper.BindPFlag("credentials", cmd.Flags().Lookup("credentials"))
+			viper.BindPFlag("impersonate-service-account", cmd.Flags().Lookup("impersonate-service-account"))
+			bindGoogleCredentialsFlags(cmd)
+			viper.BindPFlag("project", cmd.Flags().Lookup("project"))
+			viper.BindPFlag("region", cmd.Flags().Lookup("region"))
+			viper.BindPFlag("max-results", cmd.Flags().Lookup("max-results"))
+			viper.BindPFlag("endpoint-url", cmd.Flags().Lookup("endpoint-url"))
+			return preRunRefresh(cmd, args)
+		},
+		PostRunE: postRunEOutput,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := requiredStringFlags("region", "project"); err != nil {
+				return err
+			}
+
+			// Without credentials the requests to the
+			// --endpoint-url are not authenticated, and
+			// with --oidc the federated token is used
+			if viper.GetString("impersonate-service-account") == "" && viper.GetString("oidc") == "" && len(viper.GetStringSlice("endpoint-url")) == 0 {
+				if err := requiredStringFlags("credentials"); err != nil {
+					return err
+				}
+			}
+
+			eps, err := endpoints(false)
+			if err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+
+			token, err := googleToken(ctx, viper.GetString)
+			if err != nil {
+				return err
+			}
+
+			p, err := google.NewProvider(
+				ctx,
+				viper.GetUint64("max-results"),
+				viper.GetString("project"),
+				viper.GetString("region"),
+				"",
+				viper.GetString("credentials"),
+				viper.GetString("impersonate-service-account"),
+				token,
+				"",
+				eps,
+			)
+			if err != nil {
+				return err
+			}
+
+			return runRefresh(ctx, cmd, p)
+		},
+	}
+)
+
+func init() {
+	googleRefreshCmd.Flags().String("credentials", "", "path to the JSON credential (required if no --impersonate-service-account or --oidc)")
+	googleRefreshCmd.Flags().String("project", "", "project (required)")
+	googleRefreshCmd.Flags().String("region", "", "region (required)")
+	googleRefreshCmd.Flags().String("impersonate-service-account", "", "email of the service account to impersonate with the credentials, or the federated token with --oidc")
+	googleCredentialsFlags(googleRefreshCmd)
+	googleRefreshCmd.Flags().Uint64("max-results", 500, "max results to fetch when pagination is used")
+	endpointsFlag(googleRefreshCmd, false)
+}
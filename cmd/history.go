@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/history"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// sensitiveFlags are the flags which values
+// are not recorded on the history
+var sensitiveFlags = map[string]struct{}{
+	"access-key":         struct{}{},
+	"secret-key":         struct{}{},
+	"session-token":      struct{}{},
+	"credential-process": struct{}{},
+	"git-token":          struct{}{},
+	"tfstate-passphrase": struct{}{},
+	"anonymize-salt":     struct{}{},
+	"backend-config":     struct{}{},
+	"slack-webhook":      struct{}{},
+	"notify-webhook":     struct{}{},
+	"webhook":            struct{}{},
+}
+
+var (
+	// importRuns are the history.Run of the imports recorded
+	// on the postRunEOutput, once the outputs are closed
+	importRuns []history.Run
+
+	historyCmd = &cobra.Command{
+		Use:   "history",
+		Short: "Lists the imports recorded on the --history-dir",
+		Long:  "Lists the imports recorded on the --history-dir, with who ran them and when, the number of resources imported and the status of them. With --format json the parameters, filters, counts and hashes of the outputs of each one are written",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			viper.BindPFlag("provider", cmd.Flags().Lookup("provider"))
+			viper.BindPFlag("since", cmd.Flags().Lookup("since"))
+			viper.BindPFlag("limit", cmd.Flags().Lookup("limit"))
+			viper.BindPFlag("format", cmd.Flags().Lookup("format"))
+
+			if err := requiredStringFlags("history-dir"); err != nil {
+				return err
+			}
+			if f := viper.GetString("format"); f != "table" && f != "json" {
+				return fmt.Errorf("invalid --format %q, the valid ones are: table, json", f)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q := history.Query{
+				Provider: viper.GetString("provider"),
+				Limit:    viper.GetInt("limit"),
+			}
+			if s := viper.GetDuration("since"); s > 0 {
+				q.Since = time.Now().Add(-s)
+			}
+
+			runs, err := history.New(viper.GetString("history-dir")).List(q)
+			if err != nil {
+				return err
+			}
+
+			if viper.GetString("format") == "json" {
+				return json.NewEncoder(os.Stdout).Encode(runs)
+			}
+
+			history.Write(os.Stdout, runs)
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	historyCmd.Flags().String("provider", "", "Provider of the imports listed (ex: aws)")
+	historyCmd.Flags().Duration("since", 0, "Lists only the imports started on this last duration (ex: 168h)")
+	historyCmd.Flags().Int("limit", 0, "Maximum number of imports listed, the most recent ones")
+	historyCmd.Flags().String("format", "table", "Format of the list, one of: table, json")
+}
+
+// recordImport records the import of the p with the cmd and the f on
+// the --history-dir, if set. If the import failed the run is recorded
+// now, if not it's recorded on the postRunEOutput with the hashes of
+// the outputs, the errors of it are only written to the logsOut so
+// they do not hide the err of the import
+func recordImport(cmd *cobra.Command, p string, s *provider.Summary, f *filter.Filter, start time.Time, err error) {
+	dir := viper.GetString("history-dir")
+	if dir == "" {
+		return
+	}
+
+	r := history.NewRun(p, cmd.CommandPath(), *s, start, err)
+	r.Version = Version
+	r.Filters = history.NewFilters(f)
+	cmd.Flags().Visit(func(fl *pflag.Flag) {
+		if _, ok := sensitiveFlags[fl.Name]; ok {
+			r.Flags[fl.Name] = history.Redacted
+			return
+		}
+		r.Flags[fl.Name] = fl.Value.String()
+	})
+
+	if err == nil {
+		importRuns = append(importRuns, r)
+		return
+	}
+
+	for _, o := range outputFiles() {
+		r.Outputs = append(r.Outputs, history.Output{Path: o})
+	}
+	if herr := history.New(dir).Append(r); herr != nil {
+		fmt.Fprintf(logsOut, "Could not record the import on the history: %s\n", herr)
+	}
+}
+
+// appendImportRuns appends the importRuns to the
+// --history-dir with the hashes of the outputs
+func appendImportRuns() error {
+	defer func() { importRuns = nil }()
+
+	h := history.New(viper.GetString("history-dir"))
+	for _, r := range importRuns {
+		if err := r.SetOutputs(outputFiles()); err != nil {
+			return fmt.Errorf("could not record the import on the history: %s", err)
+		}
+		if err := h.Append(r); err != nil {
+			return fmt.Errorf("could not record the import on the history: %s", err)
+		}
+	}
+	return nil
+}
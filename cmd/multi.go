@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/stack"
+	"github.com/cycloidio/terracognita/tag"
+)
+
+// multiConfig is the --config of the multi command
+type multiConfig struct {
+	Imports []multiImport `yaml:"imports"`
+}
+
+// multiImport is an import of the multiConfig, the Config
+// has the same keys as the flags of the provider, as the
+// configuration of the jobs of the server
+type multiImport struct {
+	// Name is the name of the stack of the
+	// import, the Provider if not set
+	Name     string            `yaml:"name"`
+	Provider string            `yaml:"provider"`
+	Config   map[string]string `yaml:"config"`
+
+	Include   []string `yaml:"include"`
+	Exclude   []string `yaml:"exclude"`
+	Tags      []string `yaml:"tags"`
+	Filters   []string `yaml:"filters"`
+	NameRegex string   `yaml:"name_regex"`
+}
+
+// multiImports are the imports of the --config
+var multiImports []multiImport
+
+var (
+	multiCmd = &cobra.Command{
+		Use:   "multi",
+		Short: "Imports from multiple providers on the same --stacks",
+		Long:  "Imports from each provider of the --config, one after the other, on the same --stacks with a stack for each import following the --layout (ex: with modules-live a module for each one). The config of each import has the same keys as the flags of the provider",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			viper.BindPFlag("config", cmd.Flags().Lookup("config"))
+			viper.BindPFlag("region", cmd.Flags().Lookup("region"))
+
+			if err := requiredStringFlags("config", "stacks"); err != nil {
+				return err
+			}
+			// Those outputs are shared by all the imports
+			// and can only be written by one of them
+			for _, o := range []string{"pulumi-manifest", "crossplane", "export", "graph", "inventory-export"} {
+				if viper.GetString(o) != "" || len(viper.GetStringSlice(o)) != 0 {
+					return fmt.Errorf("the flag --%s can not be used with multi", o)
+				}
+			}
+
+			l, err := stack.ParseLayout(viper.GetString("layout"))
+			if err != nil {
+				return fmt.Errorf("invalid --layout: %s", err)
+			}
+			if !strings.Contains(l.HCL, ".Stack") || !strings.Contains(l.TFState, ".Stack") {
+				return fmt.Errorf("the --layout of multi has to have the {{.Stack}} on the hcl and tfstate, as each import is a stack")
+			}
+			if l.Components != "" {
+				return fmt.Errorf("the --layout with components can not be used with multi, as each import would write the Terraform Stacks")
+			}
+
+			multiImports, err = readMultiConfig(viper.GetString("config"))
+			if err != nil {
+				return err
+			}
+
+			return preRunEOutput(cmd, args)
+		},
+		PostRunE: postRunEOutput,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			fmt.Fprintf(logsOut, "Starting Terracognita with version %s\n", Version)
+			for _, mi := range multiImports {
+				fmt.Fprintf(logsOut, "Importing %s from %s\n", mi.Name, mi.Provider)
+				if err := runMultiImport(ctx, cmd, mi); err != nil {
+					return fmt.Errorf("could not import %s from %s: %+v", mi.Name, mi.Provider, err)
+				}
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	multiCmd.Flags().String("region", "", "Region of the bucket of the 's3:BUCKET' --stacks-backend")
+	multiCmd.Flags().String("config", "", "YAML (or JSON) file with the 'imports', each one with the 'provider', the 'name' of the stack and the 'config' with the same keys as the flags of the provider (ex: region), and the 'include', 'exclude', 'tags', 'filters' and 'name_regex' (required), the 'config' can also overwrite the ca-bundle, http-proxy, https-proxy and no-proxy of each provider")
+}
+
+// readMultiConfig returns the imports of the
+// file validating the providers and names
+func readMultiConfig(file string) ([]multiImport, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the --config %s because: %s", file, err)
+	}
+
+	var cfg multiConfig
+	if err := yaml.UnmarshalStrict(b, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid --config %s: %s", file, err)
+	}
+	if len(cfg.Imports) == 0 {
+		return nil, fmt.Errorf("invalid --config %s: there are no imports", file)
+	}
+
+	names := make(map[string]struct{}, len(cfg.Imports))
+	for i, mi := range cfg.Imports {
+		sp, ok := serverProviders[mi.Provider]
+		if !ok {
+			return nil, fmt.Errorf("invalid --config %s: the provider %q of the import %d is not one of: %s", file, mi.Provider, i+1, strings.Join(multiProviders(), ", "))
+		}
+		if mi.Name == "" {
+			mi.Name = mi.Provider
+		}
+		if !namePrefixRe.MatchString(mi.Name) {
+			return nil, fmt.Errorf("invalid --config %s: the name %q has to start with a letter or underscore and have only letters, digits, underscores and dashes", file, mi.Name)
+		}
+		if _, ok := names[mi.Name]; ok {
+			return nil, fmt.Errorf("invalid --config %s: the name %q is used by more than one import, set a different 'name' to each one", file, mi.Name)
+		}
+		names[mi.Name] = struct{}{}
+
+		for _, r := range sp.Required {
+			if mi.Config[r] == "" {
+				return nil, fmt.Errorf("invalid --config %s: the config %q of the import %s is required", file, r, mi.Name)
+			}
+		}
+		cfg.Imports[i] = mi
+	}
+
+	return cfg.Imports, nil
+}
+
+// multiProviders returns the sorted names
+// of the providers of the multi imports
+func multiProviders() []string {
+	names := make([]string, 0, len(serverProviders))
+	for n := range serverProviders {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runMultiImport runs the import of the mi on the
+// stack of the name of it on the --stacks
+func runMultiImport(ctx context.Context, cmd *cobra.Command, mi multiImport) error {
+	sp := serverProviders[mi.Provider]
+
+	// The network of the flags can be overwritten
+	// by the config of each import
+	err := setNetwork(func(k string) string {
+		if v := mi.Config[k]; v != "" {
+			return v
+		}
+		return viper.GetString(k)
+	})
+	if err != nil {
+		return err
+	}
+
+	p, err := sp.New(ctx, mi.Config)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]tag.Tag, 0, len(mi.Tags))
+	for _, t := range mi.Tags {
+		values := strings.Split(t, ":")
+		if len(values) != 2 {
+			return fmt.Errorf("invalid format for the tags %q, the expected format is 'NAME:VALUE'", t)
+		}
+		tags = append(tags, tag.Tag{Name: values[0], Value: values[1]})
+	}
+
+	rules, err := filter.ParseRules(mi.Filters)
+	if err != nil {
+		return err
+	}
+
+	f := &filter.Filter{
+		Tags:    tags,
+		Include: mi.Include,
+		Exclude: mi.Exclude,
+		Rules:   rules,
+	}
+	if err := mergeIgnoreFile(f, sp.ResourceTypes()); err != nil {
+		return err
+	}
+	if mi.NameRegex != "" {
+		f.NameRegex, err = regexp.Compile(mi.NameRegex)
+		if err != nil {
+			return fmt.Errorf("invalid name_regex: %s", err)
+		}
+	}
+
+	// All the resources of the import
+	// are on the stack of the name
+	stacks, err = newStacks(func(string) string { return mi.Name }, "", viper.GetString("region"))
+	if err != nil {
+		return err
+	}
+	closeOut = append(closeOut, stacks)
+
+	ictx, cancel := importContext(ctx)
+	defer cancel()
+
+	opt := importOptions()
+	start := time.Now()
+	err = withAuditViolation(provider.Import(ictx, p, stacks.HCLWriter(), newStateWriter(), f, opt, logsOut))
+	notifyImport(ctx, mi.Provider, opt.Summary, start, err)
+	recordImport(cmd, mi.Provider, opt.Summary, f, start, err)
+
+	return err
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cycloidio/terracognita/util"
+)
+
+// setNetwork configures the HTTP clients of
+// the providers with the network of the get
+func setNetwork(get func(string) string) error {
+	err := util.SetNetwork(util.Network{
+		CABundle:   get("ca-bundle"),
+		HTTPProxy:  get("http-proxy"),
+		HTTPSProxy: get("https-proxy"),
+		NoProxy:    get("no-proxy"),
+	})
+	if err != nil {
+		return fmt.Errorf("invalid network configuration: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/query"
+	"github.com/cycloidio/terracognita/state"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// runQuery runs the query.Shell of the p on the Stdin
+func runQuery(ctx context.Context, p provider.Provider) error {
+	s := query.NewShell(
+		p.ResourceTypes(),
+		func(ctx context.Context, t string) ([]provider.Resource, error) {
+			return query.Load(ctx, p, t)
+		},
+		queryImporter(p),
+	)
+
+	fmt.Fprintf(os.Stdout, "Querying %s, use 'help' to see the commands\n", p)
+	return s.Run(ctx, os.Stdin, os.Stdout)
+}
+
+// queryImporter returns the query.Importer that imports
+// from the p with the same options of the import
+func queryImporter(p provider.Provider) query.Importer {
+	return func(ctx context.Context, f *filter.Filter, hclFile, tfstateFile string) error {
+		var hclW, stateW writer.Writer
+
+		if hclFile != "" {
+			fh, err := os.OpenFile(hclFile, os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+			if err != nil {
+				return fmt.Errorf("could not OpenFile %s because: %s", hclFile, err)
+			}
+			defer fh.Close()
+
+			hclW, err = newHCLWriter(fh)
+			if err != nil {
+				return err
+			}
+		}
+
+		if tfstateFile != "" {
+			ft, err := os.OpenFile(tfstateFile, os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+			if err != nil {
+				return fmt.Errorf("could not OpenFile %s because: %s", tfstateFile, err)
+			}
+			defer ft.Close()
+
+			stateW = state.NewWriter(ft)
+		}
+
+		ictx, cancel := importContext(ctx)
+		defer cancel()
+
+		return withAuditViolation(provider.Import(ictx, p, hclW, stateW, f, importOptions(), logsOut))
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/refresh"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// refreshed is the refresh.Refresh of the --tfstate,
+// read before it's truncated by the preRunEOutput
+var refreshed *refresh.Refresh
+
+// preRunRefresh reads the resources of the --tfstate to refresh
+// and then initializes the outputs as the import
+func preRunRefresh(cmd *cobra.Command, args []string) error {
+	if err := requiredStringFlags("tfstate"); err != nil {
+		return err
+	}
+	if viper.GetString("stacks") != "" {
+		return errors.New("the flag --stacks can not be used with refresh")
+	}
+	if viper.GetString("compare-dir") != "" {
+		return errors.New("the flag --compare-dir can not be used with refresh")
+	}
+	if viper.GetString("tfstate-encrypt") != "" || viper.GetString("sops") != "" {
+		return errors.New("the flags --tfstate-encrypt and --sops can not be used with refresh")
+	}
+
+	file := viper.GetString("tfstate")
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("could not open %s because: %s", file, err)
+	}
+	defer f.Close()
+
+	refreshed, err = refresh.Read(f)
+	if err != nil {
+		return fmt.Errorf("could not read %s because: %s", file, err)
+	}
+	if len(refreshed.Resources) == 0 {
+		return fmt.Errorf("there are no resources to refresh on %s", file)
+	}
+
+	return preRunEOutput(cmd, args)
+}
+
+// runRefresh imports again from the p the resources of the --tfstate
+// with the same names and writes them to the --hcl and --tfstate
+func runRefresh(ctx context.Context, cmd *cobra.Command, p provider.Provider) error {
+	var (
+		hclW writer.Writer
+		err  error
+	)
+
+	if hclOut != nil {
+		hclW, err = newHCLWriter(hclOut)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(logsOut, "Refreshing %d resources with version %s\n", len(refreshed.Resources), Version)
+
+	ictx, cancel := importContext(ctx)
+	defer cancel()
+
+	opt := importOptions()
+	opt.Names = refreshed.Names()
+	opt.StateMiddlewares = append(opt.StateMiddlewares, refreshed.Middleware())
+
+	f := refreshed.Filter()
+	start := time.Now()
+	err = withAuditViolation(provider.Import(ictx, p, hclW, newStateWriter(), f, opt, logsOut))
+	notifyImport(ctx, p.String(), opt.Summary, start, err)
+	recordImport(cmd, p.String(), opt.Summary, f, start, err)
+	if err != nil {
+		return fmt.Errorf("could not refresh from %s: %+v", p, err)
+	}
+
+	// The resources not found are not written
+	// so they are removed from the outputs
+	for _, r := range refreshed.Missing() {
+		fmt.Fprintf(logsOut, "The resource %s was not found and has been removed\n", r)
+	}
+
+	return nil
+}
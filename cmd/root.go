@@ -1,48 +1,185 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"text/template"
+	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/cycloidio/terracognita/anonymize"
+	"github.com/cycloidio/terracognita/ansible"
+	"github.com/cycloidio/terracognita/backend"
+	"github.com/cycloidio/terracognita/cdktf"
+	"github.com/cycloidio/terracognita/compare"
+	"github.com/cycloidio/terracognita/crossplane"
+	"github.com/cycloidio/terracognita/encrypt"
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/findings"
+	"github.com/cycloidio/terracognita/gitpush"
+	"github.com/cycloidio/terracognita/graph"
+	"github.com/cycloidio/terracognita/hcl"
+	"github.com/cycloidio/terracognita/inventory"
+	"github.com/cycloidio/terracognita/lockfile"
 	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/notify"
+	"github.com/cycloidio/terracognita/policy"
+	"github.com/cycloidio/terracognita/progress"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/pulumi"
+	"github.com/cycloidio/terracognita/sops"
+	"github.com/cycloidio/terracognita/stack"
+	"github.com/cycloidio/terracognita/state"
+	"github.com/cycloidio/terracognita/tfimport"
+	"github.com/cycloidio/terracognita/tftest"
+	"github.com/cycloidio/terracognita/verify"
+	"github.com/cycloidio/terracognita/writer"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
 )
 
 var (
 	hclOut           io.Writer
 	stateOut         io.Writer
+	pulumiOut        io.Writer
+	crossplaneOut    io.Writer
+	stacks           *stack.Stacks
+	exportWs         []writer.Writer
 	closeOut         []io.Closer
 	include, exclude []string
 	logsOut          io.Writer
+	hclHeader        string
+	hclBackend       string
+	checker          *policy.Checker
+	scanner          *findings.Scanner
+	findingsOut      io.Writer
+	anonymizer       *anonymize.Anonymizer
+	comparer         *compare.Comparer
+	lifecycles       map[string]provider.Lifecycle
 
 	// RootCmd it's the entry command for the cmd on terracognita
 	RootCmd = &cobra.Command{
 		Use:   "terracognita",
 		Short: "Reads from Providers and generates a Terraform configuration",
-		Long:  "Reads from Providers and generates a Terraform configuration, all the flags can be used also with ENV (ex: --access-key == ACCESS_KEY)",
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		Long:  "Reads from Providers and generates a Terraform configuration, all the flags can be used also with ENV prefixed with TC_ (ex: --access-key == TC_ACCESS_KEY) or without it (ex: --access-key == ACCESS_KEY)",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := setFlagsFromEnv(cmd.Flags()); err != nil {
+				return err
+			}
+
+			switch viper.GetString("error-format") {
+			case "text":
+			case "json":
+				// The error is written as JSON by ExitError
+				// so it's not written by cobra with the usage
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			default:
+				return fmt.Errorf("invalid --error-format %q, the valid ones are: text, json", viper.GetString("error-format"))
+			}
+
+			opt := log.Options{
+				Format: viper.GetString("log-format"),
+				Level:  viper.GetString("log-level"),
+				TFLogs: viper.GetBool("debug"),
+			}
+			if err := log.ValidateOptions(opt); err != nil {
+				return err
+			}
+
 			// Initialize the logs by setting by default the logs
 			// to Stdout, but if 'v' or 'd' is defined the logger
 			// will be initialized and structured logs will be used
-			// and if 'd' it's defined TF_LOG will be used too
-			if viper.GetBool("verbose") || viper.GetBool("debug") {
+			// and if 'd' it's defined TF_LOG will be used too.
+			// With 'log-file' the structured logs are written
+			// to it and the default logs to Stdout
+			if lf := viper.GetString("log-file"); lf != "" {
+				f, err := os.OpenFile(lf, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+				if err != nil {
+					return fmt.Errorf("could not OpenFile %s because: %s", lf, err)
+				}
+				logsOut = os.Stdout
+				opt.Out = f
+			} else if viper.GetBool("verbose") || viper.GetBool("debug") {
 				logsOut = ioutil.Discard
-				log.Init(os.Stdout, viper.GetBool("debug"))
+				opt.Out = os.Stdout
 			} else {
 				logsOut = os.Stdout
-				log.Init(ioutil.Discard, false)
+				opt.Out = ioutil.Discard
+				opt.TFLogs = false
+			}
+
+			if viper.GetBool("quiet") {
+				logsOut = ioutil.Discard
 			}
+			log.InitWithOptions(opt)
+
+			if err := setAudit(viper.GetBool, viper.GetString); err != nil {
+				return err
+			}
+
+			return setNetwork(viper.GetString)
 		},
 	}
 )
 
+// namePrefixRe matches the valid --name-prefix, the
+// start of a valid identifier of the HCL
+var namePrefixRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// envPrefix is the prefix of the ENV of the flags
+const envPrefix = "TC_"
+
+// flagEnv returns the ENV of the flag name
+// (ex: --access-key is TC_ACCESS_KEY)
+func flagEnv(name string) string {
+	return envPrefix + strings.ToUpper(strings.Replace(name, "-", "_", -1))
+}
+
+// setFlagsFromEnv sets the flags of fs that have not been
+// set from the CLI with the value of their ENV (see flagEnv), so
+// all the flags, even the ones not read with viper, can be set
+// on a CI without arguments. The lists are separated by commas.
+// The flags have precedence over the TC_ ENV, and it over the
+// ENV without prefix of viper.AutomaticEnv
+func setFlagsFromEnv(fs *pflag.FlagSet) error {
+	var err error
+	fs.VisitAll(func(f *pflag.Flag) {
+		if err != nil || f.Changed {
+			return
+		}
+		v, ok := os.LookupEnv(flagEnv(f.Name))
+		if !ok {
+			return
+		}
+		if serr := fs.Set(f.Name, v); serr != nil {
+			err = fmt.Errorf("invalid value %q of %s: %s", v, flagEnv(f.Name), serr)
+		}
+	})
+
+	return err
+}
+
 func requiredStringFlags(names ...string) error {
+	return requiredKeys(viper.GetString, names...)
+}
+
+// requiredKeys validates that all the names
+// have a value on get
+func requiredKeys(get func(string) string, names ...string) error {
 	for _, n := range names {
-		if viper.GetString(n) == "" {
+		if get(n) == "" {
 			return fmt.Errorf("the flag %q is required", n)
 		}
 	}
@@ -51,7 +188,275 @@ func requiredStringFlags(names ...string) error {
 }
 
 func preRunEOutput(cmd *cobra.Command, args []string) error {
-	// Initializes/Validates the HCL and TFSTATE flags
+	// The --compare-dir is read before opening
+	// the outputs as those can be on it
+	comparer = nil
+	if cd := viper.GetString("compare-dir"); cd != "" {
+		if viper.GetString("hcl") == "" {
+			return fmt.Errorf("the flag --compare-dir requires --hcl")
+		}
+		if f := viper.GetString("hcl-format"); f != "" && f != "hcl" {
+			return fmt.Errorf("the --hcl-format %q can not be used with --compare-dir, only 'hcl' can", f)
+		}
+		var err error
+		comparer, err = compare.Read(cd)
+		if err != nil {
+			return fmt.Errorf("could not read the --compare-dir %s because: %s", cd, err)
+		}
+	}
+
+	if viper.GetString("tfstate-encrypt") != "" && viper.GetString("sops") != "" {
+		return fmt.Errorf("the flag --tfstate-encrypt can not be used with --sops")
+	}
+	if v := viper.GetString("state-version"); v != "" {
+		if err := state.NewWriter(nil).SetVersion(v); err != nil {
+			return fmt.Errorf("invalid --state-version: %s", err)
+		}
+	}
+
+	hclHeader = ""
+	if hf := viper.GetString("hcl-header"); hf != "" || viper.GetBool("hcl-annotate") || viper.GetBool("hcl-console-urls") {
+		if f := viper.GetString("hcl-format"); f != "" && f != "hcl" {
+			return fmt.Errorf("the --hcl-format %q can not be used with --hcl-header, --hcl-annotate or --hcl-console-urls, only 'hcl' can", f)
+		}
+		if hf != "" {
+			h, err := renderHCLHeader(hf, providerName(cmd))
+			if err != nil {
+				return err
+			}
+			hclHeader = h
+		}
+	}
+
+	hclBackend = ""
+	if bt := viper.GetString("backend"); bt != "" {
+		if f := viper.GetString("hcl-format"); f != "" && f != "hcl" {
+			return fmt.Errorf("the --hcl-format %q can not be used with --backend, only 'hcl' can", f)
+		}
+		if viper.GetString("stacks") != "" {
+			return fmt.Errorf("the flag --backend can not be used with --stacks, use --stacks-backend")
+		}
+		// With multiple regions the first
+		// one is the one of the s3 bucket
+		region := strings.Split(viper.GetString("region"), ",")[0]
+		// viper does not split the StringArray
+		// so it's read from the flag
+		configs, err := cmd.Flags().GetStringArray("backend-config")
+		if err != nil {
+			return err
+		}
+		b, err := backend.Parse(bt, configs, region)
+		if err != nil {
+			return fmt.Errorf("invalid --backend: %s", err)
+		}
+		hclBackend = b.HCL()
+	}
+
+	if viper.GetBool("hcl-lock") {
+		if viper.GetString("hcl") == "" {
+			return fmt.Errorf("the flag --hcl-lock requires --hcl, the lock file is written next to it")
+		}
+		if f := viper.GetString("hcl-format"); f != "" && f != "hcl" {
+			return fmt.Errorf("the --hcl-format %q can not be used with --hcl-lock, only 'hcl' can", f)
+		}
+	}
+
+	if n := viper.GetInt("externalize-large-attributes"); n != 0 {
+		if n < 0 {
+			return fmt.Errorf("the flag --externalize-large-attributes can not be negative")
+		}
+		if viper.GetString("hcl") == "" {
+			return fmt.Errorf("the flag --externalize-large-attributes requires --hcl, the files are written next to it")
+		}
+		if viper.GetString("stacks") != "" {
+			return fmt.Errorf("the flag --externalize-large-attributes can not be used with --stacks")
+		}
+		if f := viper.GetString("hcl-format"); f != "" && f != "hcl" {
+			return fmt.Errorf("the --hcl-format %q can not be used with --externalize-large-attributes, only 'hcl' can", f)
+		}
+	}
+
+	if viper.GetBool("with-dependencies") && viper.GetInt("dependencies-depth") < 1 {
+		return fmt.Errorf("the flag --dependencies-depth has to be at least 1")
+	}
+
+	if viper.GetInt("circuit-breaker") < 0 {
+		return fmt.Errorf("the flag --circuit-breaker can not be negative")
+	}
+
+	if viper.GetInt("max-per-type") < 0 || viper.GetInt("sample") < 0 {
+		return fmt.Errorf("the flags --max-per-type and --sample can not be negative")
+	}
+	if viper.GetInt("max-per-type") > 0 && viper.GetInt("sample") > 0 {
+		return fmt.Errorf("the flags --max-per-type and --sample can not be used together")
+	}
+
+	if r := viper.GetString("resolve"); r != "" {
+		if r != provider.ResolveComment && r != provider.ResolveData {
+			return fmt.Errorf("invalid --resolve %q, it has to be one of: %s, %s", r, provider.ResolveComment, provider.ResolveData)
+		}
+		if f := viper.GetString("hcl-format"); f != "" && f != "hcl" {
+			return fmt.Errorf("the --hcl-format %q can not be used with --resolve, only 'hcl' can", f)
+		}
+	}
+
+	lifecycles = nil
+	if lf := viper.GetString("lifecycle"); lf != "" {
+		lcs, err := readLifecycles(lf)
+		if err != nil {
+			return err
+		}
+		lifecycles = lcs
+	}
+
+	checker = nil
+	if pp := viper.GetString("policy"); pp != "" {
+		if viper.GetString("hcl") == "" && viper.GetString("stacks") == "" {
+			return fmt.Errorf("the flag --policy requires --hcl or --stacks")
+		}
+		a, err := policy.ParseAction(viper.GetString("policy-action"))
+		if err != nil {
+			return fmt.Errorf("invalid --policy-action: %s", err)
+		}
+		checker, err = policy.New(viper.GetString("opa-bin"), pp, a)
+		if err != nil {
+			return fmt.Errorf("could not use the --policy: %s", err)
+		}
+	}
+
+	if ff := viper.GetString("findings"); ff != "" {
+		if viper.GetString("hcl") == "" && viper.GetString("stacks") == "" {
+			return fmt.Errorf("the flag --findings requires --hcl or --stacks")
+		}
+		if f := viper.GetString("findings-format"); f != "sarif" && f != "json" {
+			return fmt.Errorf("invalid --findings-format %q, the valid ones are sarif and json", f)
+		}
+	}
+
+	anonymizer = nil
+	if viper.GetBool("anonymize") {
+		// Those outputs are written from the data of
+		// the resources and not from the HCL or TFState
+		for _, o := range []string{"pulumi-manifest", "crossplane", "export", "graph", "inventory-export"} {
+			if viper.GetString(o) != "" || len(viper.GetStringSlice(o)) != 0 {
+				return fmt.Errorf("the flag --anonymize can not be used with --%s, only the HCL and TFState are anonymized", o)
+			}
+		}
+		if viper.GetBool("verify") {
+			return fmt.Errorf("the flag --anonymize can not be used with --verify")
+		}
+		a, err := anonymize.New(viper.GetString("anonymize-salt"))
+		if err != nil {
+			return err
+		}
+		anonymizer = a
+	}
+
+	if np := viper.GetString("name-prefix"); np != "" && !namePrefixRe.MatchString(np) {
+		return fmt.Errorf("invalid --name-prefix %q, it has to start with a letter or underscore and have only letters, digits, underscores and dashes", np)
+	}
+
+	if viper.GetString("stacks") != "" {
+		if viper.GetString("hcl") != "" || viper.GetString("tfstate") != "" {
+			return fmt.Errorf("the flag --stacks can not be used with --hcl or --tfstate")
+		}
+		if f := viper.GetString("hcl-format"); f != "" && f != "hcl" {
+			return fmt.Errorf("the --hcl-format %q can not be used with --stacks, only 'hcl' can", f)
+		}
+		if viper.GetString("tfstate-encrypt") != "" {
+			return fmt.Errorf("the flag --tfstate-encrypt can not be used with --stacks")
+		}
+		if viper.GetString("sops") != "" {
+			return fmt.Errorf("the flag --sops can not be used with --stacks")
+		}
+		if viper.GetString("state-version") != "" {
+			return fmt.Errorf("the flag --state-version can not be used with --stacks")
+		}
+	}
+
+	if viper.GetString("git-push") != "" && isWatch() {
+		return fmt.Errorf("the flag --git-push can not be used with --watch")
+	}
+	if mr := viper.GetString("git-merge-request"); mr != "" {
+		if viper.GetString("git-push") == "" {
+			return fmt.Errorf("the flag --git-merge-request requires --git-push")
+		}
+		if mr != gitpush.GitHub && mr != gitpush.GitLab {
+			return fmt.Errorf("invalid --git-merge-request %q, the valid ones are: %s, %s", mr, gitpush.GitHub, gitpush.GitLab)
+		}
+		if viper.GetString("git-token") == "" {
+			return fmt.Errorf("the flag --git-token is required with --git-merge-request")
+		}
+	}
+
+	var output bool
+	for _, o := range []string{"hcl", "tfstate", "stacks", "pulumi-manifest", "crossplane", "graph", "inventory-export"} {
+		if viper.GetString(o) != "" {
+			output = true
+		}
+	}
+	if !output && len(viper.GetStringSlice("export")) == 0 && !isWatch() {
+		return fmt.Errorf("one of --hcl, --tfstate, --stacks, --pulumi-manifest, --crossplane, --export, --graph or --inventory-export are required")
+	}
+
+	// The outputs are opened, and truncated, once
+	// all the flags are valid so an invalid run
+	// does not remove the ones of the previous one
+	var (
+		stateKeyer  encrypt.Keyer
+		sopsKey     sops.Key
+		stacksGroup stack.Group
+		stacksTag   string
+		gf          graph.Format
+	)
+	if viper.GetString("tfstate-encrypt") != "" {
+		k, err := tfstateKeyer()
+		if err != nil {
+			return err
+		}
+		stateKeyer = k
+	} else if viper.GetString("sops") != "" {
+		k, err := sops.ParseKey(viper.GetString("sops"))
+		if err != nil {
+			return fmt.Errorf("invalid --sops: %s", err)
+		}
+		sopsKey = k
+	}
+	if viper.GetString("stacks") != "" {
+		// The resources not on a CloudFormation
+		// stack are grouped by type
+		by := viper.GetString("stacks-by")
+		if by == "cloudformation" {
+			by, stacksTag = "type", stack.CloudFormationTag
+		}
+
+		g, err := stack.ParseGroup(by)
+		if err != nil {
+			return fmt.Errorf("invalid --stacks-by: %s", err)
+		}
+		stacksGroup = g
+	}
+	if viper.GetString("graph") != "" {
+		switch f := viper.GetString("graph-format"); f {
+		case "", "dot":
+			gf = graph.DOT
+		case "mermaid":
+			gf = graph.Mermaid
+		default:
+			return fmt.Errorf("invalid --graph-format %q", f)
+		}
+	}
+	for _, e := range viper.GetStringSlice("export") {
+		ef := strings.SplitN(e, "=", 2)
+		if len(ef) != 2 || ef[1] == "" {
+			return fmt.Errorf("invalid --export %q, expected the format FORMAT=FILE", e)
+		}
+		// It's only to validate the format
+		if _, err := newExportWriter(ef[0], ef[1], nil); err != nil {
+			return err
+		}
+	}
+
 	closeOut = make([]io.Closer, 0)
 	if viper.GetString("hcl") != "" {
 		f, err := os.OpenFile(viper.GetString("hcl"), os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
@@ -67,22 +472,724 @@ func preRunEOutput(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("could not OpenFile %s because: %s", viper.GetString("tfstate"), err)
 		}
 		stateOut = f
+
+		// The encrypted writer has to be closed
+		// before the file as it writes on the Close
+		if viper.GetString("tfstate-encrypt") != "" {
+			ew := encrypt.NewWriter(f, stateKeyer)
+			stateOut = ew
+			closeOut = append(closeOut, ew)
+		} else if viper.GetString("sops") != "" {
+			sw := sops.NewWriter(f, viper.GetString("sops-bin"), sopsKey, "json")
+			stateOut = sw
+			closeOut = append(closeOut, sw)
+		}
+		closeOut = append(closeOut, f)
+	}
+
+	scanner, findingsOut = nil, nil
+	if ff := viper.GetString("findings"); ff != "" {
+		f, err := os.OpenFile(ff, os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return fmt.Errorf("could not OpenFile %s because: %s", ff, err)
+		}
+		scanner, findingsOut = findings.NewScanner(), f
+		closeOut = append(closeOut, f)
+	}
+
+	stacks = nil
+	if viper.GetString("stacks") != "" {
+		// With multiple regions the first
+		// one is where the bucket is
+		region := strings.Split(viper.GetString("region"), ",")[0]
+		var err error
+		stacks, err = newStacks(stacksGroup, stacksTag, region)
+		if err != nil {
+			return err
+		}
+		stacks.SetDeployment(deploymentInputs(cmd.Name()))
+		closeOut = append(closeOut, stacks)
+	}
+
+	if viper.GetString("pulumi-manifest") != "" {
+		f, err := os.OpenFile(viper.GetString("pulumi-manifest"), os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return fmt.Errorf("could not OpenFile %s because: %s", viper.GetString("pulumi-manifest"), err)
+		}
+		pulumiOut = f
+		closeOut = append(closeOut, f)
+	}
+
+	if viper.GetString("crossplane") != "" {
+		f, err := os.OpenFile(viper.GetString("crossplane"), os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return fmt.Errorf("could not OpenFile %s because: %s", viper.GetString("crossplane"), err)
+		}
+		crossplaneOut = f
 		closeOut = append(closeOut, f)
 	}
 
-	if len(closeOut) == 0 {
-		return fmt.Errorf("one of --hcl or --tfstate are required")
+	exportWs = make([]writer.Writer, 0)
+	for _, e := range viper.GetStringSlice("export") {
+		ef := strings.SplitN(e, "=", 2)
+
+		f, err := os.OpenFile(ef[1], os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return fmt.Errorf("could not OpenFile %s because: %s", ef[1], err)
+		}
+		closeOut = append(closeOut, f)
+
+		w, err := newExportWriter(ef[0], ef[1], f)
+		if err != nil {
+			return err
+		}
+		exportWs = append(exportWs, w)
 	}
+
+	if viper.GetString("graph") != "" {
+		f, err := os.OpenFile(viper.GetString("graph"), os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			return fmt.Errorf("could not OpenFile %s because: %s", viper.GetString("graph"), err)
+		}
+		closeOut = append(closeOut, f)
+		exportWs = append(exportWs, graph.NewWriter(f, gf))
+	}
+
+	if ie := viper.GetString("inventory-export"); ie != "" {
+		switch filepath.Ext(ie) {
+		case ".db", ".sqlite", ".sqlite3":
+			// The statements are run by sqlite3 on
+			// the Close, so the file is not opened
+			s := inventory.NewSQLite(viper.GetString("sqlite-bin"), ie)
+			closeOut = append(closeOut, s)
+			exportWs = append(exportWs, inventory.NewWriter(s, inventory.SQL))
+		case ".json":
+			f, err := os.OpenFile(ie, os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+			if err != nil {
+				return fmt.Errorf("could not OpenFile %s because: %s", ie, err)
+			}
+			closeOut = append(closeOut, f)
+			exportWs = append(exportWs, inventory.NewWriter(f, inventory.JSON))
+		default:
+			f, err := os.OpenFile(ie, os.O_APPEND|os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+			if err != nil {
+				return fmt.Errorf("could not OpenFile %s because: %s", ie, err)
+			}
+			closeOut = append(closeOut, f)
+			exportWs = append(exportWs, inventory.NewWriter(f, inventory.CSV))
+		}
+	}
+
 	return nil
 }
 
-func postRunEOutput(cmd *cobra.Command, args []string) error {
-	// Closes all the opened files
-	for _, c := range closeOut {
+// newStacks returns the Stacks of the --stacks grouped by the g, or
+// by the tag if set, with the --stacks-backend on the region
+func newStacks(g stack.Group, tag, region string) (*stack.Stacks, error) {
+	b, err := stack.ParseBackend(viper.GetString("stacks-backend"), region)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --stacks-backend: %s", err)
+	}
+	s, err := stack.New(viper.GetString("stacks"), g, b)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the --stacks: %s", err)
+	}
+	if tag != "" {
+		s.ByTag(tag)
+	}
+
+	l, err := stack.ParseLayout(viper.GetString("layout"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --layout: %s", err)
+	}
+	// The modules of the Live and the components
+	// do not receive the aliased providers
+	if (l.Live != "" || l.Components != "") && len(strings.Split(viper.GetString("region"), ",")) > 1 {
+		return nil, fmt.Errorf("the --layout with a live or components can not be used with multiple regions")
+	}
+	if l.Components != "" && viper.GetString("stacks-backend") != "" {
+		return nil, fmt.Errorf("the flag --stacks-backend can not be used with the --layout with components, the state is managed by the deployments")
+	}
+	if err := s.SetLayout(l, viper.GetString("layout-env")); err != nil {
+		return nil, fmt.Errorf("invalid --layout: %s", err)
+	}
+	s.SetHeader(hclHeader)
+
+	return s, nil
+}
+
+// deploymentInputs returns the inputs of the deployment of the
+// Terraform Stacks of the provider, which configure it
+func deploymentInputs(provider string) map[string]string {
+	switch provider {
+	case "aws":
+		return map[string]string{"region": viper.GetString("region")}
+	case "google":
+		return map[string]string{"project": viper.GetString("project"), "region": viper.GetString("region")}
+	default:
+		return nil
+	}
+}
+
+// tfstateKeyer returns the encrypt.Keyer of the --tfstate-encrypt,
+// 'passphrase' uses the --tfstate-passphrase and 'aws-kms:KEY_ID'
+// the AWS KMS key with the default credentials of the SDK
+func tfstateKeyer() (encrypt.Keyer, error) {
+	switch e := viper.GetString("tfstate-encrypt"); {
+	case e == "passphrase":
+		p := viper.GetString("tfstate-passphrase")
+		if p == "" {
+			return nil, fmt.Errorf("the flag %q is required with --tfstate-encrypt passphrase", "tfstate-passphrase")
+		}
+		return encrypt.NewPassphrase(p), nil
+	case strings.HasPrefix(e, "aws-kms:"):
+		keyID := strings.TrimPrefix(e, "aws-kms:")
+
+		// The region of the key is on the ARN,
+		// if not the one of the SDK config is used
+		cfg := awssdk.NewConfig()
+		if arn := strings.Split(keyID, ":"); len(arn) > 3 && arn[0] == "arn" {
+			cfg = cfg.WithRegion(arn[3])
+		}
+		sess, err := session.NewSessionWithOptions(session.Options{
+			Config:            *cfg,
+			SharedConfigState: session.SharedConfigEnable,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not create the AWS session for --tfstate-encrypt: %s", err)
+		}
+		return encrypt.NewAWSKMS(keyID, kms.New(sess)), nil
+	default:
+		return nil, fmt.Errorf("invalid --tfstate-encrypt %q, the expected values are 'passphrase' or 'aws-kms:KEY_ID'", e)
+	}
+}
+
+// headerData is the data of the template of the
+// --hcl-header, with the metadata of the run
+type headerData struct {
+	Version  string
+	Provider string
+	Region   string
+	Date     string
+}
+
+// providerName returns the name of the provider of the cmd,
+// which is the command of it or the parent of the subcommand
+// (ex: 'aws refresh' => aws)
+func providerName(cmd *cobra.Command) string {
+	for cmd.HasParent() && cmd.Parent() != RootCmd {
+		cmd = cmd.Parent()
+	}
+	return cmd.Name()
+}
+
+// renderHCLHeader returns the --hcl-header template of the
+// file executed with the metadata of the run of the provider
+func renderHCLHeader(file, provider string) (string, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("could not read the --hcl-header %s because: %s", file, err)
+	}
+
+	tmpl, err := template.New("header").Parse(string(b))
+	if err != nil {
+		return "", fmt.Errorf("invalid --hcl-header template: %s", err)
+	}
+
+	buff := &strings.Builder{}
+	err = tmpl.Execute(buff, headerData{
+		Version:  Version,
+		Provider: provider,
+		Region:   viper.GetString("region"),
+		Date:     time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not execute the --hcl-header template: %s", err)
+	}
+
+	return buff.String(), nil
+}
+
+// readLifecycles returns the provider.Lifecycle of each type
+// of the --lifecycle file, YAML (or JSON) with the types as keys
+// (ex: 'aws_db_instance: {prevent_destroy: true}')
+func readLifecycles(file string) (map[string]provider.Lifecycle, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the --lifecycle %s because: %s", file, err)
+	}
+
+	var lcs map[string]provider.Lifecycle
+	if err := yaml.UnmarshalStrict(b, &lcs); err != nil {
+		return nil, fmt.Errorf("invalid --lifecycle %s: %s", file, err)
+	}
+
+	return lcs, nil
+}
+
+// newHCLWriter returns the writer.Writer for the
+// configured --hcl-format that writes to w
+func newHCLWriter(w io.Writer) (writer.Writer, error) {
+	switch f := viper.GetString("hcl-format"); f {
+	case "", "hcl":
+		hw := hcl.NewWriter(w)
+		hw.SetHeader(hclHeader)
+		hw.SetTerraform(hclBackend)
+		return hw, nil
+	case "cdktf-typescript":
+		return cdktf.NewWriter(w, cdktf.TypeScript), nil
+	case "cdktf-python":
+		return cdktf.NewWriter(w, cdktf.Python), nil
+	case "pulumi-yaml":
+		return pulumi.NewYAMLWriter(w), nil
+	default:
+		return nil, fmt.Errorf("invalid --hcl-format %q", f)
+	}
+}
+
+// importOptions returns the provider.ImportOptions
+// from the flags
+func importOptions() provider.ImportOptions {
+	opt := provider.ImportOptions{
+		Strict:       viper.GetBool("strict"),
+		IgnoreErrors: viper.GetStringSlice("ignore-errors"),
+		MinimalHCL:   viper.GetBool("minimal-hcl"),
+		RawUserData:  viper.GetBool("raw-user-data"),
+		ValidateHCL:  viper.GetBool("validate-hcl"),
+		SkipManaged:  viper.GetBool("skip-managed"),
+		Cleanup:      viper.GetBool("cleanup-candidates"),
+		NamePrefix:   viper.GetString("name-prefix"),
+		Annotate:     viper.GetBool("hcl-annotate"),
+		ConsoleURLs:  viper.GetBool("hcl-console-urls"),
+		Lifecycles:   lifecycles,
+		Progress:     progress.NewBar(logsOut),
+		Summary:      &provider.Summary{},
+		Probe:        viper.GetBool("skip-empty"),
+		Resolve:      viper.GetString("resolve"),
+
+		ResourceTimeout:   viper.GetDuration("resource-timeout"),
+		CircuitBreaker:    viper.GetInt("circuit-breaker"),
+		ExcludeAttributes: viper.GetStringSlice("exclude-attributes"),
+		DependentsOf:      viper.GetString("dependents-of"),
+	}
+	if viper.GetBool("quiet") {
+		opt.Progress = progress.NewQuiet()
+	}
+	if n := viper.GetInt("externalize-large-attributes"); n > 0 {
+		opt.ExternalizeSize = n
+		opt.ExternalizeDir = filepath.Dir(viper.GetString("hcl"))
+	}
+	if n := viper.GetInt("max-per-type"); n > 0 {
+		opt.MaxPerType = n
+	}
+	if n := viper.GetInt("sample"); n > 0 {
+		seed := viper.GetInt64("sample-seed")
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		opt.MaxPerType = n
+		opt.Sample = rand.New(rand.NewSource(seed))
+	}
+	if viper.GetBool("with-dependencies") {
+		opt.DependenciesDepth = viper.GetInt("dependencies-depth")
+	}
+	if stacks != nil {
+		opt.Stack = stacks.Root
+	}
+	// The resources excluded by the checker
+	// are not scanned as it's the outermost
+	if checker != nil {
+		opt.HCLMiddlewares = append(opt.HCLMiddlewares, checker.Middleware())
+	}
+	if scanner != nil {
+		opt.HCLMiddlewares = append(opt.HCLMiddlewares, scanner.Middleware())
+	}
+	// The comparer is before the anonymizer so it
+	// compares the values of the resources
+	if comparer != nil {
+		opt.Names = comparer.Names()
+		opt.HCLMiddlewares = append(opt.HCLMiddlewares, comparer.Middleware())
+	}
+	// The anonymizer is the innermost so the checker
+	// and the scanner have the values of the resources
+	if anonymizer != nil {
+		opt.HCLMiddlewares = append(opt.HCLMiddlewares, anonymizer.HCLMiddleware())
+		opt.StateMiddlewares = append(opt.StateMiddlewares, anonymizer.StateMiddleware())
+		opt.Name = anonymizer.Name
+	}
+	return opt
+}
+
+// importContext returns the ctx limited by the --timeout, if set,
+// and canceled on the first call that is not read only (see setAudit)
+func importContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	var cancel context.CancelFunc
+	if t := viper.GetDuration("timeout"); t > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	setAuditCancel(cancel)
+	return ctx, cancel
+}
+
+// closeOutputs closes all the opened files
+// of the closeOut, only once
+func closeOutputs() error {
+	cs := closeOut
+	closeOut = nil
+	for _, c := range cs {
 		if err := c.Close(); err != nil {
 			return err
 		}
 	}
+	return nil
+}
+
+// outputFiles returns the files, and the directory
+// of the --stacks, written by the import
+func outputFiles() []string {
+	outputs := make([]string, 0)
+	for _, o := range []string{"hcl", "tfstate", "stacks", "pulumi-manifest", "crossplane", "graph", "inventory-export", "findings"} {
+		if f := viper.GetString(o); f != "" {
+			outputs = append(outputs, f)
+		}
+	}
+	for _, e := range viper.GetStringSlice("export") {
+		if ef := strings.SplitN(e, "=", 2); len(ef) == 2 {
+			outputs = append(outputs, ef[1])
+		}
+	}
+	return outputs
+}
+
+// notifyImport sends the Report of the import of the p that started at
+// start to the --notify-webhook, the errors of it are only written to the
+// logsOut so they do not hide the err of the import
+func notifyImport(ctx context.Context, p string, s *provider.Summary, start time.Time, err error) {
+	urls := viper.GetStringSlice("notify-webhook")
+	if len(urls) == 0 {
+		return
+	}
+
+	r := notify.NewReport(p, *s, outputFiles(), start, err)
+	for _, u := range urls {
+		if nerr := notify.NewWebhook(u).Notify(ctx, r); nerr != nil {
+			fmt.Fprintf(logsOut, "Could not send the notification: %s\n", nerr)
+		}
+	}
+}
+
+// presetInclude returns the --include with the types of each
+// --preset, read from the presets of the provider with the fn
+func presetInclude(fn func(name string) ([]string, error)) ([]string, error) {
+	res := append([]string{}, include...)
+	added := make(map[string]struct{}, len(include))
+	for _, t := range include {
+		added[t] = struct{}{}
+	}
+
+	for _, n := range viper.GetStringSlice("preset") {
+		types, err := fn(n)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --preset: %s", err)
+		}
+		// The presets can share types (ex: aws_network_acl
+		// is on networking and security) so those are added once
+		for _, t := range types {
+			if _, ok := added[t]; ok {
+				continue
+			}
+			added[t] = struct{}{}
+			res = append(res, t)
+		}
+	}
+	return res, nil
+}
+
+// mergeIgnoreFile merges the patterns of the --ignore-file
+// matching the types into the f, the default one is only
+// read if it exists on the working directory
+func mergeIgnoreFile(f *filter.Filter, types []string) error {
+	file := viper.GetString("ignore-file")
+	if file == "" {
+		return nil
+	}
+
+	fi, err := os.Open(file)
+	if os.IsNotExist(err) && file == filter.IgnoreFile {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("could not open %s because: %s", file, err)
+	}
+	defer fi.Close()
+
+	ig, err := filter.ParseIgnore(fi)
+	if err != nil {
+		return fmt.Errorf("could not read %s because: %s", file, err)
+	}
+	f.MergeIgnore(ig, types)
+
+	return nil
+}
+
+// selectedTypes returns the types of all that are selected
+// by the --include and --exclude, all of them if not set
+func selectedTypes(all []string) ([]string, error) {
+	f := &filter.Filter{
+		Include: include,
+		Exclude: exclude,
+	}
+	if err := f.Expand(all); err != nil {
+		return nil, err
+	}
+
+	types := f.Include
+	if len(types) == 0 {
+		types = all
+	}
+
+	res := make([]string, 0, len(types))
+	for _, t := range types {
+		if !f.IsExcluded(t) {
+			res = append(res, t)
+		}
+	}
+
+	return res, nil
+}
+
+// newExportWriter returns the writer.Writer of the --export
+// format that writes to w, the file is used to know the encoding
+func newExportWriter(format, file string, w io.Writer) (writer.Writer, error) {
+	switch format {
+	case "ansible-inventory":
+		f := ansible.JSON
+		if ext := filepath.Ext(file); ext == ".yml" || ext == ".yaml" {
+			f = ansible.YAML
+		}
+		return ansible.NewWriter(w, f), nil
+	case "terraform-import":
+		f := tfimport.Script
+		switch filepath.Ext(file) {
+		case ".csv":
+			f = tfimport.CSV
+		case ".tf":
+			f = tfimport.HCL
+		}
+		return tfimport.NewWriter(w, f), nil
+	case "terraform-test":
+		return tftest.NewWriter(w), nil
+	default:
+		return nil, fmt.Errorf("invalid --export format %q", format)
+	}
+}
+
+// newStateWriter returns the writer.Writer for the TFState, the --stacks, the Pulumi
+// manifest, the Crossplane manifests and/or the --export, or nil if none of them is set
+func newStateWriter() writer.Writer {
+	ws := make([]writer.Writer, 0, 4+len(exportWs))
+	if stateOut != nil {
+		sw := state.NewWriter(stateOut)
+		if v := viper.GetString("state-version"); v != "" {
+			// It's validated on the preRunEOutput
+			_ = sw.SetVersion(v)
+		}
+		ws = append(ws, sw)
+	}
+	if stacks != nil {
+		ws = append(ws, stacks.StateWriter())
+	}
+	if pulumiOut != nil {
+		ws = append(ws, pulumi.NewManifestWriter(pulumiOut))
+	}
+	if crossplaneOut != nil {
+		ws = append(ws, crossplane.NewWriter(crossplaneOut))
+	}
+	ws = append(ws, exportWs...)
+
+	switch len(ws) {
+	case 0:
+		return nil
+	case 1:
+		return ws[0]
+	default:
+		return writer.NewMultiWriter(ws...)
+	}
+}
+
+func postRunEOutput(cmd *cobra.Command, args []string) error {
+	if checker != nil {
+		checker.Report(logsOut)
+	}
+
+	if comparer != nil {
+		comparer.Report(logsOut)
+	}
+
+	if scanner != nil {
+		var err error
+		if viper.GetString("findings-format") == "json" {
+			err = scanner.WriteJSON(findingsOut)
+		} else {
+			err = scanner.WriteSARIF(findingsOut, Version)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(logsOut, "Found %d misconfigurations, written to %s\n", len(scanner.Findings()), viper.GetString("findings"))
+	}
+
+	if err := closeOutputs(); err != nil {
+		return err
+	}
+
+	if err := appendImportRuns(); err != nil {
+		return err
+	}
+
+	if viper.GetBool("hcl-lock") {
+		if err := writeLockFile(providerName(cmd)); err != nil {
+			return err
+		}
+	}
+
+	if viper.GetBool("verify") {
+		if err := verifyPlan(providerName(cmd)); err != nil {
+			return err
+		}
+	}
+
+	if viper.GetString("git-push") != "" {
+		return gitPush(providerName(cmd))
+	}
+
+	return nil
+}
+
+// gitPush commits the outputFiles to the --git-branch of the
+// --git-push repository and opens the --git-merge-request of it
+func gitPush(provider string) error {
+	ctx := context.Background()
+	opt := gitpush.Options{
+		Repository: viper.GetString("git-push"),
+		Branch:     viper.GetString("git-branch"),
+		Base:       viper.GetString("git-base"),
+		Path:       viper.GetString("git-path"),
+		Message:    viper.GetString("git-message"),
+		Token:      viper.GetString("git-token"),
+	}
+	if opt.Message == "" {
+		opt.Message = fmt.Sprintf("Import the %s resources with terracognita %s", provider, Version)
+	}
+
+	res, err := gitpush.Push(ctx, viper.GetString("git-bin"), opt, outputFiles())
+	if err != nil {
+		return fmt.Errorf("could not push to --git-push: %s", err)
+	}
+	if !res.Changed {
+		fmt.Fprintf(logsOut, "The branch %s of %s has no changes\n", opt.Branch, opt.Repository)
+		return nil
+	}
+	fmt.Fprintf(logsOut, "Pushed the commit %s to the branch %s of %s\n", res.Commit, opt.Branch, opt.Repository)
+
+	if mr := viper.GetString("git-merge-request"); mr != "" {
+		u, err := gitpush.OpenMergeRequest(ctx, gitpush.MergeRequest{
+			Platform: mr,
+			APIURL:   viper.GetString("git-api-url"),
+			Title:    opt.Message,
+			Body:     "Generated by terracognita, review the changes before merging them.",
+		}, opt)
+		if err != nil {
+			return fmt.Errorf("could not open the --git-merge-request: %s", err)
+		}
+		if u == "" {
+			fmt.Fprintf(logsOut, "The merge request of the branch %s is already open\n", opt.Branch)
+		} else {
+			fmt.Fprintf(logsOut, "Opened the merge request %s\n", u)
+		}
+	}
+
+	return nil
+}
+
+// verifyEnvs are the flags of each provider
+// with the ENV used by the TF provider for them
+var verifyEnvs = map[string]map[string]string{
+	"aws": map[string]string{
+		"access-key":    "AWS_ACCESS_KEY_ID",
+		"secret-key":    "AWS_SECRET_ACCESS_KEY",
+		"session-token": "AWS_SESSION_TOKEN",
+		"region":        "AWS_DEFAULT_REGION",
+	},
+	"google": map[string]string{
+		"credentials":                 "GOOGLE_APPLICATION_CREDENTIALS",
+		"project":                     "GOOGLE_PROJECT",
+		"region":                      "GOOGLE_REGION",
+		"impersonate-service-account": "GOOGLE_IMPERSONATE_SERVICE_ACCOUNT",
+	},
+}
+
+// writeLockFile writes the .terraform.lock.hcl of the
+// provider on the directory of the --hcl
+func writeLockFile(provider string) error {
+	fmt.Fprintf(logsOut, "Writing the lock file ...")
+	l, err := lockfile.Get(context.Background(), provider)
+	if err != nil {
+		return fmt.Errorf("could not get the lock of the provider because: %s", err)
+	}
+
+	file := filepath.Join(filepath.Dir(viper.GetString("hcl")), lockfile.Filename)
+	f, err := os.OpenFile(file, os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("could not OpenFile %s because: %s", file, err)
+	}
+	defer f.Close()
+
+	if err := lockfile.Write(f, []*lockfile.Lock{l}); err != nil {
+		return err
+	}
+	fmt.Fprintf(logsOut, "\rWriting the lock file Done!\n")
+
+	return nil
+}
+
+// verifyPlan runs a 'terraform plan' with the generated HCL and
+// TFState of the provider and reports if the plan is empty, with
+// --strict it fails if it's not
+func verifyPlan(provider string) error {
+	if viper.GetString("hcl") == "" || viper.GetString("tfstate") == "" {
+		return fmt.Errorf("the flags --hcl and --tfstate are required with --verify")
+	}
+	if viper.GetString("tfstate-encrypt") != "" || viper.GetString("sops") != "" {
+		return fmt.Errorf("the encrypted --tfstate can not be verified")
+	}
+	if f := viper.GetString("hcl-format"); f != "" && f != "hcl" {
+		return fmt.Errorf("the --hcl-format %q can not be verified, only 'hcl' can", f)
+	}
+
+	env := make([]string, 0)
+	for f, e := range verifyEnvs[provider] {
+		if v := viper.GetString(f); v != "" {
+			env = append(env, fmt.Sprintf("%s=%s", e, v))
+		}
+	}
+
+	fmt.Fprintf(logsOut, "Verifying with terraform plan ...")
+	res, err := verify.Plan(context.Background(), viper.GetString("terraform-bin"), viper.GetString("hcl"), viper.GetString("tfstate"), env)
+	if err != nil {
+		return fmt.Errorf("could not verify the plan: %s", err)
+	}
+
+	if res.Empty {
+		fmt.Fprintf(logsOut, "\rVerifying with terraform plan Done! The plan is empty\n")
+		return nil
+	}
+
+	fmt.Fprintf(logsOut, "\rVerifying with terraform plan Done! The plan has changes:\n%s\n", res.Plan)
+	if viper.GetBool("strict") {
+		return fmt.Errorf("the plan of the generated HCL and TFState is not empty")
+	}
 
 	return nil
 }
@@ -92,22 +1199,288 @@ func init() {
 	RootCmd.AddCommand(awsCmd)
 	RootCmd.AddCommand(googleCmd)
 	RootCmd.AddCommand(versionCmd)
+	RootCmd.AddCommand(serveCmd)
+	RootCmd.AddCommand(decryptCmd)
+	RootCmd.AddCommand(diffCmd)
+	RootCmd.AddCommand(historyCmd)
+	RootCmd.AddCommand(multiCmd)
+	RootCmd.AddCommand(validateOutputCmd)
 
 	RootCmd.PersistentFlags().String("hcl", "", "HCL output file")
 	_ = viper.BindPFlag("hcl", RootCmd.PersistentFlags().Lookup("hcl"))
 
+	RootCmd.PersistentFlags().String("hcl-format", "hcl", "Format of the --hcl output, one of: hcl, cdktf-typescript, cdktf-python, pulumi-yaml")
+	_ = viper.BindPFlag("hcl-format", RootCmd.PersistentFlags().Lookup("hcl-format"))
+
+	RootCmd.PersistentFlags().String("hcl-header", "", "File with a Go template written as a comment at the top of the generated .tf files (ex: a license), with the {{.Version}}, {{.Provider}}, {{.Region}} and {{.Date}} of the run")
+	_ = viper.BindPFlag("hcl-header", RootCmd.PersistentFlags().Lookup("hcl-header"))
+
+	RootCmd.PersistentFlags().Bool("hcl-annotate", false, "Write a comment before each resource of the HCL with the provider, region and ID it was imported from and the date of the import")
+	_ = viper.BindPFlag("hcl-annotate", RootCmd.PersistentFlags().Lookup("hcl-annotate"))
+
+	RootCmd.PersistentFlags().Bool("hcl-console-urls", false, "Write a comment before each resource of the HCL with the URL of it on the console of the cloud, for the resource types that have one")
+	_ = viper.BindPFlag("hcl-console-urls", RootCmd.PersistentFlags().Lookup("hcl-console-urls"))
+
+	RootCmd.PersistentFlags().Bool("hcl-lock", false, "Write the .terraform.lock.hcl next to the --hcl, with the version of the provider the HCL is generated for and the hashes of its packages, so 'terraform init' is reproducible")
+	_ = viper.BindPFlag("hcl-lock", RootCmd.PersistentFlags().Lookup("hcl-lock"))
+
+	RootCmd.PersistentFlags().Int("externalize-large-attributes", 0, "Write the attributes of the HCL larger than this size in bytes (ex: dashboard JSON or user_data) to the 'files' directory next to the --hcl, and read them with file() or filebase64()")
+	_ = viper.BindPFlag("externalize-large-attributes", RootCmd.PersistentFlags().Lookup("externalize-large-attributes"))
+
+	RootCmd.PersistentFlags().String("compare-dir", "", "Directory with an existing Terraform configuration (.tf files and terraform.tfstate) to compare with, the resources on the terraform.tfstate are imported with the same names and written to the --hcl only with the attributes that differ and a comment with the existing values, and not written if none differ")
+	_ = viper.BindPFlag("compare-dir", RootCmd.PersistentFlags().Lookup("compare-dir"))
+
+	RootCmd.PersistentFlags().String("resolve", "", "Resolve the raw IDs of the attributes of the resources (ex: the AMIs, KMS keys and accounts of AWS) to what they are, one of: comment (a comment on the resources with them), data (the IDs are replaced with references to data sources that look them up)")
+	_ = viper.BindPFlag("resolve", RootCmd.PersistentFlags().Lookup("resolve"))
+
+	RootCmd.PersistentFlags().String("backend", "", "Backend written on the 'terraform' block of the HCL, one of: s3, gcs, azurerm, remote, configured with --backend-config")
+	_ = viper.BindPFlag("backend", RootCmd.PersistentFlags().Lookup("backend"))
+
+	RootCmd.PersistentFlags().StringArray("backend-config", []string{}, "Configuration of the --backend with the format KEY=VALUE (ex: bucket=my-states), the nested ones with '.' (ex: workspaces.name=prod), it can be used multiple times")
+
 	RootCmd.PersistentFlags().String("tfstate", "", "TFState output file")
 	_ = viper.BindPFlag("tfstate", RootCmd.PersistentFlags().Lookup("tfstate"))
 
-	RootCmd.PersistentFlags().StringSliceVarP(&include, "include", "i", []string{}, "List of resources to import, this names are the ones on TF (ex: aws_instance). If not set then means that all the resources will be imported")
+	RootCmd.PersistentFlags().String("tfstate-encrypt", "", "Encrypt the --tfstate with AES-256-GCM and a key from a 'passphrase' (see --tfstate-passphrase) or an AWS KMS key with 'aws-kms:KEY_ID', it's decrypted with the 'decrypt' command")
+	_ = viper.BindPFlag("tfstate-encrypt", RootCmd.PersistentFlags().Lookup("tfstate-encrypt"))
+
+	RootCmd.PersistentFlags().String("tfstate-passphrase", "", "Passphrase of the --tfstate-encrypt passphrase, better set with the TFSTATE_PASSPHRASE ENV")
+	_ = viper.BindPFlag("tfstate-passphrase", RootCmd.PersistentFlags().Lookup("tfstate-passphrase"))
+
+	RootCmd.PersistentFlags().String("sops", "", "Encrypt the --tfstate, the output with the values read from the provider, with SOPS and an 'age:RECIPIENT', 'aws-kms:KEY_ARN' or 'gcp-kms:KEY_RESOURCE_ID' key, it's decrypted with 'sops --decrypt'")
+	_ = viper.BindPFlag("sops", RootCmd.PersistentFlags().Lookup("sops"))
+
+	RootCmd.PersistentFlags().String("state-version", "", "Terraform version that uses the --tfstate (ex: 0.11, 0.12, 1.5), which defines the format of it: the legacy version 3 before 0.12, the version 4 with the provider addresses of the registry from 0.13 (default 0.12)")
+	_ = viper.BindPFlag("state-version", RootCmd.PersistentFlags().Lookup("state-version"))
+
+	RootCmd.PersistentFlags().String("sops-bin", sops.DefaultBinary, "SOPS binary used by --sops")
+	_ = viper.BindPFlag("sops-bin", RootCmd.PersistentFlags().Lookup("sops-bin"))
+
+	RootCmd.PersistentFlags().String("stacks", "", "Directory to split the HCL and TFState into stacks, each one on a subdirectory with a 'main.tf' and 'terraform.tfstate' (see --stacks-by), it can not be used with --hcl or --tfstate")
+	_ = viper.BindPFlag("stacks", RootCmd.PersistentFlags().Lookup("stacks"))
+
+	RootCmd.PersistentFlags().String("stacks-by", "type", "Grouping of the resources of the --stacks, one of: type (ex: aws_instance), service (ex: aws_iam_user is on iam), cloudformation (the AWS CloudFormation stack of them, the rest by type)")
+	_ = viper.BindPFlag("stacks-by", RootCmd.PersistentFlags().Lookup("stacks-by"))
+
+	RootCmd.PersistentFlags().String("stacks-backend", "local", "Backend configured on each of the --stacks, one of: local, s3:BUCKET (with the --region) or gcs:BUCKET")
+	_ = viper.BindPFlag("stacks-backend", RootCmd.PersistentFlags().Lookup("stacks-backend"))
+
+	RootCmd.PersistentFlags().String("layout", stack.DefaultLayout, "Structure of the files of the --stacks, one of: "+strings.Join(stack.LayoutNames(), ", ")+" or a YAML file with the templates of the 'hcl', 'tfstate' and 'live' paths of each resource")
+	_ = viper.BindPFlag("layout", RootCmd.PersistentFlags().Lookup("layout"))
+
+	RootCmd.PersistentFlags().String("layout-env", "default", "Environment of the --layout, used on the paths of the 'envs' one (ex: envs/prod/)")
+	_ = viper.BindPFlag("layout-env", RootCmd.PersistentFlags().Lookup("layout-env"))
+
+	RootCmd.PersistentFlags().String("pulumi-manifest", "", "Pulumi import manifest output file, to be used with 'pulumi import --file'")
+	_ = viper.BindPFlag("pulumi-manifest", RootCmd.PersistentFlags().Lookup("pulumi-manifest"))
+
+	RootCmd.PersistentFlags().String("crossplane", "", "Crossplane managed resources YAML output file")
+	_ = viper.BindPFlag("crossplane", RootCmd.PersistentFlags().Lookup("crossplane"))
+
+	RootCmd.PersistentFlags().StringSlice("export", []string{}, "Export the resources to FILE with the format FORMAT=FILE, the supported formats are: ansible-inventory (YAML if the FILE is .yml/.yaml, JSON otherwise), terraform-import (the 'terraform import' of each resource as a shell script, CSV if the FILE is .csv or 'import' blocks if it's .tf), terraform-test (a .tftest.hcl asserting the key attributes of the resources)")
+	_ = viper.BindPFlag("export", RootCmd.PersistentFlags().Lookup("export"))
+
+	RootCmd.PersistentFlags().Bool("minimal-hcl", false, "Write to the HCL only the required attributes and the ones with non default values")
+	_ = viper.BindPFlag("minimal-hcl", RootCmd.PersistentFlags().Lookup("minimal-hcl"))
+
+	RootCmd.PersistentFlags().StringSlice("exclude-attributes", []string{}, "List of attributes removed from the HCL (but kept on the TFState) with the format TYPE.ATTRIBUTE and glob patterns (ex: *.arn, aws_instance.private_dns), the required attributes are never removed")
+	_ = viper.BindPFlag("exclude-attributes", RootCmd.PersistentFlags().Lookup("exclude-attributes"))
+
+	RootCmd.PersistentFlags().String("lifecycle", "", "YAML (or JSON) file with the lifecycle (prevent_destroy, create_before_destroy and ignore_changes) written to the HCL of the resources of each type (ex: 'aws_autoscaling_group: {ignore_changes: [desired_capacity]}')")
+	_ = viper.BindPFlag("lifecycle", RootCmd.PersistentFlags().Lookup("lifecycle"))
+
+	RootCmd.PersistentFlags().Bool("validate-hcl", false, "Validate the HCL with the schema of the resources before writing it, with --strict it fails if any is invalid")
+	_ = viper.BindPFlag("validate-hcl", RootCmd.PersistentFlags().Lookup("validate-hcl"))
+
+	RootCmd.PersistentFlags().String("name-prefix", "", "Prefix of the names of all the resources on the HCL and TFState (ex: imported_), to avoid collisions when merged into an existing Terraform configuration")
+	_ = viper.BindPFlag("name-prefix", RootCmd.PersistentFlags().Lookup("name-prefix"))
+
+	RootCmd.PersistentFlags().Bool("anonymize", false, "Replace the account IDs, IPs, domain names, tag values and names of the resources of the HCL and TFState with pseudonyms, to share them without leaking internal data")
+	_ = viper.BindPFlag("anonymize", RootCmd.PersistentFlags().Lookup("anonymize"))
+
+	RootCmd.PersistentFlags().String("anonymize-salt", "", "Salt of the pseudonyms of --anonymize, so they can not be guessed from the values, if not set a random one is used so the pseudonyms change on each run")
+	_ = viper.BindPFlag("anonymize-salt", RootCmd.PersistentFlags().Lookup("anonymize-salt"))
+
+	RootCmd.PersistentFlags().Bool("skip-managed", false, "Skip the resources managed by other IaC (ex: tagged with 'aws:cloudformation:stack-name' or 'managed-by=terraform') and report them")
+	_ = viper.BindPFlag("skip-managed", RootCmd.PersistentFlags().Lookup("skip-managed"))
+
+	RootCmd.PersistentFlags().Bool("cleanup-candidates", false, "Report the resources imported that look unused (ex: EBS volumes not attached, instances stopped for months or security groups without rules) as candidates to be cleaned up")
+	_ = viper.BindPFlag("cleanup-candidates", RootCmd.PersistentFlags().Lookup("cleanup-candidates"))
+
+	RootCmd.PersistentFlags().String("policy", "", "Rego policy file or directory to evaluate each resource with OPA before writing it, the 'deny' rules of the package 'terracognita' report the violations (requires --hcl or --stacks)")
+	_ = viper.BindPFlag("policy", RootCmd.PersistentFlags().Lookup("policy"))
+
+	RootCmd.PersistentFlags().String("policy-action", string(policy.Warn), "What to do with the resources that violate the --policy, one of: warn, exclude, fail")
+	_ = viper.BindPFlag("policy-action", RootCmd.PersistentFlags().Lookup("policy-action"))
+
+	RootCmd.PersistentFlags().String("opa-bin", policy.DefaultBinary, "OPA binary used by --policy")
+	_ = viper.BindPFlag("opa-bin", RootCmd.PersistentFlags().Lookup("opa-bin"))
+
+	RootCmd.PersistentFlags().String("findings", "", "Findings output file of the misconfigurations found on the resources imported by the built-in rules (ex: public S3 buckets), it requires --hcl or --stacks")
+	_ = viper.BindPFlag("findings", RootCmd.PersistentFlags().Lookup("findings"))
+
+	RootCmd.PersistentFlags().String("findings-format", "sarif", "Format of the --findings output, one of: sarif, json")
+	_ = viper.BindPFlag("findings-format", RootCmd.PersistentFlags().Lookup("findings-format"))
+
+	RootCmd.PersistentFlags().Bool("verify", false, "Run 'terraform init' and 'terraform plan' with the --hcl and --tfstate on a temporal workspace and report if the plan is empty, with --strict it fails if it's not")
+	_ = viper.BindPFlag("verify", RootCmd.PersistentFlags().Lookup("verify"))
+
+	RootCmd.PersistentFlags().String("terraform-bin", verify.DefaultBinary, "Terraform binary used by --verify")
+	_ = viper.BindPFlag("terraform-bin", RootCmd.PersistentFlags().Lookup("terraform-bin"))
+
+	RootCmd.PersistentFlags().Bool("raw-user-data", false, "Keep the user data as base64 (user_data_base64) instead of decoding it to plain text")
+	_ = viper.BindPFlag("raw-user-data", RootCmd.PersistentFlags().Lookup("raw-user-data"))
+
+	RootCmd.PersistentFlags().Bool("strict", false, "Fail if any resource could not be read instead of skipping it")
+	_ = viper.BindPFlag("strict", RootCmd.PersistentFlags().Lookup("strict"))
+
+	RootCmd.PersistentFlags().StringSlice("ignore-errors", []string{}, "Resource types and/or error classes (not-found, access-denied, timeout, read) which read errors are skipped with --strict")
+	_ = viper.BindPFlag("ignore-errors", RootCmd.PersistentFlags().Lookup("ignore-errors"))
+
+	RootCmd.PersistentFlags().String("graph", "", "Dependency graph of the resources output file")
+	_ = viper.BindPFlag("graph", RootCmd.PersistentFlags().Lookup("graph"))
+
+	RootCmd.PersistentFlags().String("graph-format", "dot", "Format of the --graph output, one of: dot, mermaid")
+	_ = viper.BindPFlag("graph-format", RootCmd.PersistentFlags().Lookup("graph-format"))
+
+	RootCmd.PersistentFlags().String("inventory-export", "", "Inventory of the resources (type, ID, name, region, tags and key attributes) output file, SQLite if the file is .db/.sqlite/.sqlite3, JSON with the references between the resources if it's .json, CSV otherwise")
+	_ = viper.BindPFlag("inventory-export", RootCmd.PersistentFlags().Lookup("inventory-export"))
+
+	RootCmd.PersistentFlags().String("sqlite-bin", inventory.DefaultBinary, "SQLite binary used by --inventory-export")
+	_ = viper.BindPFlag("sqlite-bin", RootCmd.PersistentFlags().Lookup("sqlite-bin"))
+
+	RootCmd.PersistentFlags().StringSliceVarP(&include, "include", "i", []string{}, "List of resources to import, this names are the ones on TF (ex: aws_instance) or glob patterns of them (ex: aws_iam_*, !aws_iam_user). If not set then means that all the resources will be imported")
 	_ = viper.BindPFlag("include", RootCmd.PersistentFlags().Lookup("include"))
 
-	RootCmd.PersistentFlags().StringSliceVarP(&exclude, "exclude", "e", []string{}, "List of resources to not import, this names are the ones on TF (ex: aws_instance). If not set then means that none the resources will be excluded")
+	RootCmd.PersistentFlags().StringSlice("preset", []string{}, "List of presets of common import scopes added to the --include, one of networking, compute, security or serverless (see the 'presets' command of each provider)")
+	_ = viper.BindPFlag("preset", RootCmd.PersistentFlags().Lookup("preset"))
+
+	RootCmd.PersistentFlags().StringSliceVarP(&exclude, "exclude", "e", []string{}, "List of resources to not import, this names are the ones on TF (ex: aws_instance) or glob patterns of them (ex: aws_iam_*). If not set then means that none the resources will be excluded")
 	_ = viper.BindPFlag("exclude", RootCmd.PersistentFlags().Lookup("exclude"))
 
+	RootCmd.PersistentFlags().String("ignore-file", filter.IgnoreFile, "File with the resource types and the resources ('TYPE.NAME', matching the ID or the name) to never import, one on each line with glob patterns, read if it exists on the working directory, empty to not read any")
+	_ = viper.BindPFlag("ignore-file", RootCmd.PersistentFlags().Lookup("ignore-file"))
+
+	RootCmd.PersistentFlags().StringArray("filter", []string{}, "Filter of the resources of a type by an attribute with the format 'TYPE: ATTRIBUTE=VALUE' (also '!=' and '~' for glob patterns), it can be used multiple times (ex: 'aws_instance: tags.env=prod')")
+	_ = viper.BindPFlag("filter", RootCmd.PersistentFlags().Lookup("filter"))
+
+	RootCmd.PersistentFlags().String("name-regex", "", "Regular expression to filter the resources by the ID or name of them (ex: ^prod-)")
+	_ = viper.BindPFlag("name-regex", RootCmd.PersistentFlags().Lookup("name-regex"))
+
+	RootCmd.PersistentFlags().Bool("with-dependencies", false, "Also import the resources referenced by the imported ones even if those are not on the filters (ex: the security groups and VPC of an instance), up to the --dependencies-depth")
+	_ = viper.BindPFlag("with-dependencies", RootCmd.PersistentFlags().Lookup("with-dependencies"))
+
+	RootCmd.PersistentFlags().Int("dependencies-depth", 3, "Maximum depth of the references followed by --with-dependencies (ex: 1 only imports the resources referenced by the ones on the filters)")
+	_ = viper.BindPFlag("dependencies-depth", RootCmd.PersistentFlags().Lookup("dependencies-depth"))
+
+	RootCmd.PersistentFlags().String("dependents-of", "", "Resource with the format 'TYPE:ID' (ex: aws_vpc:vpc-123) to only import it and the resources depending on it, the ones referencing it or other of its dependents")
+	_ = viper.BindPFlag("dependents-of", RootCmd.PersistentFlags().Lookup("dependents-of"))
+
+	RootCmd.PersistentFlags().Duration("watch", 0, "Interval to periodically scan the provider and notify the new and removed resources instead of importing them (ex: 1h)")
+	_ = viper.BindPFlag("watch", RootCmd.PersistentFlags().Lookup("watch"))
+
+	RootCmd.PersistentFlags().String("watch-snapshot", "", "File to store the resources found on the last scan of --watch, so changes are detected between restarts")
+	_ = viper.BindPFlag("watch-snapshot", RootCmd.PersistentFlags().Lookup("watch-snapshot"))
+
+	RootCmd.PersistentFlags().StringSlice("notify-webhook", []string{}, "List of URLs to POST a JSON (compatible with the Slack Incoming Webhooks) with the summary, failures, duration and outputs when the import finishes")
+	_ = viper.BindPFlag("notify-webhook", RootCmd.PersistentFlags().Lookup("notify-webhook"))
+
+	RootCmd.PersistentFlags().String("git-push", "", "URL of a Git repository to commit the outputs to, on the --git-branch, once the import finishes (ex: git@github.com:org/infra.git)")
+	_ = viper.BindPFlag("git-push", RootCmd.PersistentFlags().Lookup("git-push"))
+
+	RootCmd.PersistentFlags().String("git-branch", gitpush.DefaultBranch, "Branch of the --git-push the outputs are committed to, created from the --git-base if it does not exist")
+	_ = viper.BindPFlag("git-branch", RootCmd.PersistentFlags().Lookup("git-branch"))
+
+	RootCmd.PersistentFlags().String("git-base", gitpush.DefaultBase, "Base branch of the --git-branch and target of the --git-merge-request")
+	_ = viper.BindPFlag("git-base", RootCmd.PersistentFlags().Lookup("git-base"))
+
+	RootCmd.PersistentFlags().String("git-path", "", "Directory of the --git-push repository where the outputs are written, the root if not set")
+	_ = viper.BindPFlag("git-path", RootCmd.PersistentFlags().Lookup("git-path"))
+
+	RootCmd.PersistentFlags().String("git-message", "", "Message of the commit of the --git-push, and title of the --git-merge-request")
+	_ = viper.BindPFlag("git-message", RootCmd.PersistentFlags().Lookup("git-message"))
+
+	RootCmd.PersistentFlags().String("git-token", "", "Access token of GitHub or GitLab used to push to the HTTPS --git-push and to open the --git-merge-request")
+	_ = viper.BindPFlag("git-token", RootCmd.PersistentFlags().Lookup("git-token"))
+
+	RootCmd.PersistentFlags().String("git-merge-request", "", "Open a merge request of the --git-branch to the --git-base on the platform, one of: github, gitlab")
+	_ = viper.BindPFlag("git-merge-request", RootCmd.PersistentFlags().Lookup("git-merge-request"))
+
+	RootCmd.PersistentFlags().String("git-api-url", "", "URL of the API of the --git-merge-request for GitHub Enterprise or self-hosted GitLab (ex: https://gitlab.example.com/api/v4)")
+	_ = viper.BindPFlag("git-api-url", RootCmd.PersistentFlags().Lookup("git-api-url"))
+
+	RootCmd.PersistentFlags().String("git-bin", gitpush.DefaultBinary, "Git binary used by --git-push")
+	_ = viper.BindPFlag("git-bin", RootCmd.PersistentFlags().Lookup("git-bin"))
+
+	RootCmd.PersistentFlags().StringSlice("webhook", []string{}, "List of URLs to POST a JSON with the changes detected by --watch")
+	_ = viper.BindPFlag("webhook", RootCmd.PersistentFlags().Lookup("webhook"))
+
+	RootCmd.PersistentFlags().StringSlice("slack-webhook", []string{}, "List of Slack Incoming Webhook URLs to send the changes detected by --watch")
+	_ = viper.BindPFlag("slack-webhook", RootCmd.PersistentFlags().Lookup("slack-webhook"))
+
+	RootCmd.PersistentFlags().Duration("timeout", 0, "Maximum time of the import, after it the import fails (ex: 2h)")
+	_ = viper.BindPFlag("timeout", RootCmd.PersistentFlags().Lookup("timeout"))
+
+	RootCmd.PersistentFlags().Duration("resource-timeout", 0, "Maximum time to read each resource, the ones not read on time are skipped as the 'timeout' error class, or fail the import with --strict (ex: 2m)")
+	_ = viper.BindPFlag("resource-timeout", RootCmd.PersistentFlags().Lookup("resource-timeout"))
+
+	RootCmd.PersistentFlags().Int("circuit-breaker", 0, "Number of consecutive errors of permissions or of the API of the provider (5xx) on the resources of a service (ex: elasticache) after which the rest of the resources of it are failed without being read, and the service is reported once at the end")
+	_ = viper.BindPFlag("circuit-breaker", RootCmd.PersistentFlags().Lookup("circuit-breaker"))
+
+	RootCmd.PersistentFlags().Int("max-per-type", 0, "Maximum number of resources of each type imported, the first ones sorted by the ID so the same ones are always imported, the rest are skipped and not read")
+	_ = viper.BindPFlag("max-per-type", RootCmd.PersistentFlags().Lookup("max-per-type"))
+
+	RootCmd.PersistentFlags().Int("sample", 0, "Number of resources of each type imported chosen at random, to generate representative example configurations of large accounts, the rest are skipped and not read")
+	_ = viper.BindPFlag("sample", RootCmd.PersistentFlags().Lookup("sample"))
+
+	RootCmd.PersistentFlags().Int64("sample-seed", 0, "Seed of the random --sample, the same seed imports the same resources if those did not change, if not set it's random")
+	_ = viper.BindPFlag("sample-seed", RootCmd.PersistentFlags().Lookup("sample-seed"))
+
+	RootCmd.PersistentFlags().Bool("skip-empty", false, "Detect with a few requests the empty regions of AWS, with multiple --region, and zones of Google and skip them instead of listing each type on them")
+	_ = viper.BindPFlag("skip-empty", RootCmd.PersistentFlags().Lookup("skip-empty"))
+
+	RootCmd.PersistentFlags().String("ca-bundle", "", "PEM file with the certificates of the CAs trusted by the HTTP clients of all the providers instead of the ones of the system (ex: of a corporate proxy that intercepts the TLS)")
+	_ = viper.BindPFlag("ca-bundle", RootCmd.PersistentFlags().Lookup("ca-bundle"))
+
+	RootCmd.PersistentFlags().String("http-proxy", "", "URL of the proxy of the HTTP requests of all the providers, if not set the HTTP_PROXY is used")
+	_ = viper.BindPFlag("http-proxy", RootCmd.PersistentFlags().Lookup("http-proxy"))
+
+	RootCmd.PersistentFlags().String("https-proxy", "", "URL of the proxy of the HTTPS requests of all the providers, if not set the HTTPS_PROXY is used")
+	_ = viper.BindPFlag("https-proxy", RootCmd.PersistentFlags().Lookup("https-proxy"))
+
+	RootCmd.PersistentFlags().String("no-proxy", "", "Comma separated hosts, domains and CIDRs requested without the --http-proxy and --https-proxy, if not set the NO_PROXY is used")
+	_ = viper.BindPFlag("no-proxy", RootCmd.PersistentFlags().Lookup("no-proxy"))
+
+	RootCmd.PersistentFlags().Bool("assert-read-only", false, "Fails on the first call to the APIs of the providers that is not read only (ex: a Create or Delete), before sending it. It's best-effort as it's checked by the method and the name of the operation")
+	_ = viper.BindPFlag("assert-read-only", RootCmd.PersistentFlags().Lookup("assert-read-only"))
+
+	RootCmd.PersistentFlags().String("audit-log", "", "File to write, as JSON lines, each call done to the APIs of the providers")
+	_ = viper.BindPFlag("audit-log", RootCmd.PersistentFlags().Lookup("audit-log"))
+
 	RootCmd.PersistentFlags().BoolP("verbose", "v", false, "Activate the verbose mode")
 	_ = viper.BindPFlag("verbose", RootCmd.PersistentFlags().Lookup("verbose"))
 
+	RootCmd.PersistentFlags().BoolP("quiet", "q", false, "Do not write the progress nor the messages of the import, only the errors")
+	_ = viper.BindPFlag("quiet", RootCmd.PersistentFlags().Lookup("quiet"))
+
+	RootCmd.PersistentFlags().String("log-level", "debug", "Minimum level of the structured logs, one of: debug, info, warn, error")
+	_ = viper.BindPFlag("log-level", RootCmd.PersistentFlags().Lookup("log-level"))
+
+	RootCmd.PersistentFlags().String("log-format", "logfmt", "Format of the structured logs, one of: logfmt, json")
+	_ = viper.BindPFlag("log-format", RootCmd.PersistentFlags().Lookup("log-format"))
+
+	RootCmd.PersistentFlags().String("log-file", "", "File to append the structured logs to, instead of the Stdout with --verbose")
+	_ = viper.BindPFlag("log-file", RootCmd.PersistentFlags().Lookup("log-file"))
+
+	RootCmd.PersistentFlags().String("error-format", "text", "Format of the error that ends the import, one of: text, json (written to the Stderr with the class and the exit code of it)")
+	_ = viper.BindPFlag("error-format", RootCmd.PersistentFlags().Lookup("error-format"))
+
+	RootCmd.PersistentFlags().String("history-dir", "", "Directory of the append-only history of the imports, with the parameters, filters, counts and hashes of the outputs of each one, listed with the 'history' command")
+	_ = viper.BindPFlag("history-dir", RootCmd.PersistentFlags().Lookup("history-dir"))
+
+	RootCmd.PersistentFlags().Bool("no-input", false, "Never wait for an input, the commands that would prompt for it fail instead (ex: the --credential-process asking for an MFA code), to run on a CI")
+	_ = viper.BindPFlag("no-input", RootCmd.PersistentFlags().Lookup("no-input"))
+
 	RootCmd.PersistentFlags().BoolP("debug", "d", false, "Activate the debug mode wich includes TF logs via TF_LOG=TRACE|DEBUG|INFO|WARN|ERROR configuration https://www.terraform.io/docs/internals/debugging.html")
 	_ = viper.BindPFlag("debug", RootCmd.PersistentFlags().Lookup("debug"))
 }
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cycloidio/terracognita/aws"
+	"github.com/cycloidio/terracognita/google"
+	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/server"
+)
+
+var (
+	// serverProviders are all the providers that can
+	// be used from the server, the keys of the config
+	// are the same as the flags of each subcommand
+	serverProviders = map[string]server.Provider{
+		"aws": server.Provider{
+			Required:      []string{"region"},
+			ResourceTypes: aws.ResourceTypeStrings,
+			New: func(ctx context.Context, cfg map[string]string) (provider.Provider, error) {
+				creds, err := awsCredentials(ctx, func(k string) string {
+					// The clients of the API can only use their own
+					// credentials and not the ones of the server: the
+					// credential-process would let them run commands
+					// on it, the oidc use its identity and the sso-*
+					// the session cached by its 'aws sso login'. On
+					// Google the impersonate-service-account requires
+					// the credentials for the same reason
+					if k == "credential-process" || k == "oidc" || strings.HasPrefix(k, "sso-") {
+						return ""
+					}
+					return cfg[k]
+				})
+				if err != nil {
+					return nil, err
+				}
+				return aws.NewProvider(ctx, creds.AccessKey, creds.SecretKey, creds.SessionToken, cfg["region"], aws.Options{
+					SharedAsData:        cfg["shared-as-data"] == "true",
+					SharedProviderAlias: cfg["shared-provider-alias"],
+				})
+			},
+		},
+		"google": server.Provider{
+			Required:      []string{"project", "region"},
+			ResourceTypes: google.ResourceTypeStrings,
+			New: func(ctx context.Context, cfg map[string]string) (provider.Provider, error) {
+				maxResults := uint64(500)
+				if mr, ok := cfg["max-results"]; ok {
+					v, err := strconv.ParseUint(mr, 10, 64)
+					if err != nil {
+						return nil, fmt.Errorf("invalid max-results: %s", err)
+					}
+					maxResults = v
+				}
+				// Without the credentials the Application Default
+				// Credentials of the server would be used, also to
+				// impersonate the impersonate-service-account
+				if cfg["credentials"] == "" {
+					return nil, fmt.Errorf("the config %q is required", "credentials")
+				}
+				credentials, err := serverCredentials(viper.GetString("credentials-dir"), cfg["credentials"])
+				if err != nil {
+					return nil, err
+				}
+				return google.NewProvider(ctx, maxResults, cfg["project"], cfg["region"], cfg["organization"], credentials, cfg["impersonate-service-account"], "", cfg["asset-inventory"], nil)
+			},
+		},
+	}
+
+	serveCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Starts an HTTP server with a REST API to run imports as jobs",
+		Long:  "Starts an HTTP server with a REST API to start imports, check the progress of them and download the generated HCL and TFState",
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlag("address", cmd.Flags().Lookup("address"))
+			viper.BindPFlag("workers", cmd.Flags().Lookup("workers"))
+			viper.BindPFlag("queue-size", cmd.Flags().Lookup("queue-size"))
+			viper.BindPFlag("token", cmd.Flags().Lookup("token"))
+			viper.BindPFlag("credentials-dir", cmd.Flags().Lookup("credentials-dir"))
+			viper.BindPFlag("jobs-ttl", cmd.Flags().Lookup("jobs-ttl"))
+			viper.BindPFlag("max-jobs", cmd.Flags().Lookup("max-jobs"))
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger := log.Get()
+			logger = kitlog.With(logger, "func", "cmd.serve.RunE")
+
+			// The API runs imports with the credentials
+			// of the server, so it's always authenticated
+			token := viper.GetString("token")
+			if token == "" {
+				b := make([]byte, 32)
+				if _, err := rand.Read(b); err != nil {
+					return fmt.Errorf("could not generate the token because: %s", err)
+				}
+				token = hex.EncodeToString(b)
+				fmt.Fprintf(logsOut, "Generated the token of the API, the UI is on http://%s/#token=%s\n", viper.GetString("address"), token)
+			}
+
+			s := server.New(serverProviders, server.Options{
+				Workers:   viper.GetInt("workers"),
+				QueueSize: viper.GetInt("queue-size"),
+				Token:     token,
+				JobsTTL:   viper.GetDuration("jobs-ttl"),
+				MaxJobs:   viper.GetInt("max-jobs"),
+			})
+			defer s.Close()
+
+			fmt.Fprintf(logsOut, "Starting Terracognita server with version %s on %s\n", Version, viper.GetString("address"))
+			logger.Log("msg", "starting terracognita server", "version", Version, "address", viper.GetString("address"))
+
+			return http.ListenAndServe(viper.GetString("address"), s)
+		},
+	}
+)
+
+func init() {
+	serveCmd.Flags().String("address", "127.0.0.1:8080", "Address in which the server will listen")
+	serveCmd.Flags().Int("workers", 1, "Number of imports that can run at the same time")
+	serveCmd.Flags().Int("queue-size", 100, "Number of imports that can be waiting to be run")
+	serveCmd.Flags().String("token", "", "Token required on the 'Authorization: Bearer' header of the requests, if not set a random one is generated and printed")
+	serveCmd.Flags().String("credentials-dir", "", "Directory of the Google credentials files that can be used by the jobs, by the name of the file on the 'credentials' config, which is required. If not set the Google jobs can not be run")
+	serveCmd.Flags().Duration("jobs-ttl", 24*time.Hour, "How long the finished jobs, and their outputs, are kept")
+	serveCmd.Flags().Int("max-jobs", 100, "Number of finished jobs kept, the oldest ones are removed first")
+}
+
+// serverCredentials returns the path of the credentials file
+// with the name on the dir, as the clients of the API can only
+// use the files of it and not any file of the server
+func serverCredentials(dir, name string) (string, error) {
+	if dir == "" {
+		return "", errors.New("the config \"credentials\" can not be used as the server has no --credentials-dir")
+	}
+
+	if name != filepath.Base(name) || name == "." || name == ".." {
+		return "", fmt.Errorf("the config \"credentials\" has to be the name of a file on the --credentials-dir, found %q", name)
+	}
+
+	return filepath.Join(dir, name), nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cycloidio/terracognita/validate"
+)
+
+var (
+	validateOutputCmd = &cobra.Command{
+		Use:   "validate-output DIR",
+		Short: "Checks offline that the HCL and TFState generated on the DIR are consistent",
+		Long:  "Checks offline, without Terraform nor the providers, that the HCL and TFState generated on the DIR (and the directories in it, like the --stacks) are consistent: each resource of the TFStates has a block and each block is on them, the addresses are not repeated and all the references are declared. It fails if any issue is found",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if info, err := os.Stat(args[0]); err != nil || !info.IsDir() {
+				return fmt.Errorf("the %s is not a directory", args[0])
+			}
+
+			res, err := validate.Dir(args[0])
+			if err != nil {
+				return err
+			}
+
+			res.Write(os.Stdout)
+
+			if !res.IsValid() {
+				return fmt.Errorf("the output on %s has %d issues", args[0], len(res.Issues))
+			}
+
+			return nil
+		},
+	}
+)
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/watch"
+)
+
+// isWatch checks if the --watch mode is enabled
+func isWatch() bool {
+	return viper.GetDuration("watch") > 0
+}
+
+// runWatch watches the p until the process is interrupted
+func runWatch(ctx context.Context, p provider.Provider, f *filter.Filter) error {
+	opt := watch.Options{
+		Interval: viper.GetDuration("watch"),
+		Snapshot: viper.GetString("watch-snapshot"),
+	}
+
+	for _, u := range viper.GetStringSlice("webhook") {
+		opt.Notifiers = append(opt.Notifiers, watch.NewWebhook(u))
+	}
+
+	for _, u := range viper.GetStringSlice("slack-webhook") {
+		opt.Notifiers = append(opt.Notifiers, watch.NewSlack(u))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	go func() {
+		select {
+		case <-sig:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	fmt.Fprintf(logsOut, "Watching %s every %s\n", p, opt.Interval)
+	err := watch.Watch(ctx, p, f, opt, logsOut)
+	if errors.Cause(err) == context.Canceled {
+		return nil
+	}
+
+	return err
+}
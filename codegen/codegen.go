@@ -0,0 +1,93 @@
+package codegen
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Executor is a function of the manifest
+// which writes its generated code
+type Executor interface {
+	Execute(w io.Writer) error
+}
+
+// Template is a template executed with a data,
+// implemented by text/template and html/template
+type Template interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// LoadManifest reads the manifest on the path, YAML or JSON
+// as YAML is a superset of it, to the fns which has to be a
+// pointer to the slice of the functions of the provider.
+// The unknown attributes are an error
+func LoadManifest(path string, fns interface{}) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read the manifest %s", path)
+	}
+
+	if err := yaml.UnmarshalStrict(b, fns); err != nil {
+		return errors.Wrapf(err, "invalid manifest %s", path)
+	}
+
+	return nil
+}
+
+// Generate writes to w the header executed with the data followed
+// by the code of each of the fns, formatted with goimports
+func Generate(w io.Writer, header Template, data interface{}, fns []Executor) error {
+	var buff bytes.Buffer
+
+	if err := header.Execute(&buff, data); err != nil {
+		return errors.Wrap(err, "unable to execute the header template")
+	}
+
+	for i, fn := range fns {
+		if err := fn.Execute(&buff); err != nil {
+			return errors.Wrapf(err, "unable to execute the function %d", i)
+		}
+	}
+
+	return format(w, &buff)
+}
+
+// GenerateFile is the same as Generate but
+// it writes to the file on the path
+func GenerateFile(path string, header Template, data interface{}, fns []Executor) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "unable to open the file %s", path)
+	}
+	defer f.Close()
+
+	return Generate(f, header, data, fns)
+}
+
+// format formats the src with goimports, which
+// also adds the imports of the generated code,
+// and writes the result to w
+func format(w io.Writer, src io.Reader) error {
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.Command("goimports")
+	cmd.Stdin = src
+	cmd.Stdout = w
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "unable to run goimports command: %s", stderr.String())
+	}
+
+	if serr := stderr.String(); serr != "" {
+		return errors.New(serr)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package codegen_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/codegen"
+)
+
+type function struct {
+	Name string `yaml:"name"`
+	Get  bool   `yaml:"get"`
+}
+
+func TestLoadManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		fns      []function
+		err      bool
+	}{
+		{
+			name:     "YAML",
+			manifest: "- name: Instance\n  get: true\n- name: Disk\n",
+			fns:      []function{{Name: "Instance", Get: true}, {Name: "Disk"}},
+		},
+		{
+			name:     "JSON",
+			manifest: `[{"name": "Instance"}]`,
+			fns:      []function{{Name: "Instance"}},
+		},
+		{
+			name:     "UnknownAttribute",
+			manifest: "- name: Instance\n  list: true\n",
+			err:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "terracognita-codegen")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "functions.yaml")
+			require.NoError(t, ioutil.WriteFile(path, []byte(tt.manifest), 0644))
+
+			var fns []function
+			err = codegen.LoadManifest(path, &fns)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.fns, fns)
+		})
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		var fns []function
+		assert.Error(t, codegen.LoadManifest("./not-found.yaml", &fns))
+	})
+}
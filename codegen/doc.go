@@ -0,0 +1,6 @@
+// Package codegen is the code generator shared by the readers of the
+// providers (aws/cmd and google/cmd). The functions of each reader are
+// defined on a declarative YAML manifest and each provider only has the
+// templates of its SDK style, the manifest loading, the interpolation
+// and the formatting of the generated files are done here
+package codegen
@@ -0,0 +1,451 @@
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/pkg/errors"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/cycloidio/terracognita/refresh"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// TFState is the file of the TFState
+// on the directory of the configuration
+const TFState = "terraform.tfstate"
+
+// Resource is a resource of the existing configuration,
+// on the TFState and on a block of the .tf files
+type Resource struct {
+	Type string
+	Name string
+	ID   string
+
+	// File is the .tf file with the block
+	File string
+
+	// attributes are the ones of the block with
+	// the values that can not be evaluated
+	// (ex: references) as unknown
+	attributes map[string]interface{}
+}
+
+// Address returns the address of the r
+// on the HCL (ex: aws_instance.front)
+func (r Resource) Address() string {
+	return fmt.Sprintf("%s.%s", r.Type, r.Name)
+}
+
+// String returns the address and the ID of the r
+func (r Resource) String() string {
+	return fmt.Sprintf("%s (%s)", r.Address(), r.ID)
+}
+
+// unknown is the value of the attributes
+// that can not be compared
+type unknown struct{}
+
+// Comparer has the Resources of an existing configuration
+// to compare with the imported ones, see Middleware
+type Comparer struct {
+	Dir       string
+	Resources []Resource
+
+	mu        sync.Mutex
+	resources map[string]Resource
+	comments  map[string]string
+	changed   map[string]struct{}
+	unchanged map[string]struct{}
+}
+
+// Read returns the Comparer of the configuration of the dir, with
+// the managed resources of the TFState that have a block on the .tf
+// files, sorted by the address. As on the refresh, the ones on
+// modules, with an index or without ID are not compared
+func Read(dir string) (*Comparer, error) {
+	file := filepath.Join(dir, TFState)
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not open %s", file)
+	}
+	defer f.Close()
+
+	rf, err := refresh.Read(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read %s", file)
+	}
+
+	blocks, err := readBlocks(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Comparer{
+		Dir:       dir,
+		Resources: make([]Resource, 0),
+		resources: make(map[string]Resource),
+		comments:  make(map[string]string),
+		changed:   make(map[string]struct{}),
+		unchanged: make(map[string]struct{}),
+	}
+	for _, r := range rf.Resources {
+		b, ok := blocks[r.Address()]
+		if !ok {
+			continue
+		}
+
+		cr := Resource{Type: r.Type, Name: r.Name, ID: r.ID, File: b.File, attributes: b.attributes}
+		c.Resources = append(c.Resources, cr)
+		c.resources[cr.Address()] = cr
+	}
+
+	return c, nil
+}
+
+// readBlocks returns the resources of the .tf files
+// of the dir by the address of them, only with the File
+// and the attributes
+func readBlocks(dir string) (map[string]Resource, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list the .tf files of %s", dir)
+	}
+	sort.Strings(files)
+
+	res := make(map[string]Resource)
+	p := hclparse.NewParser()
+	for _, file := range files {
+		f, diags := p.ParseHCLFile(file)
+		if diags.HasErrors() {
+			return nil, errors.Errorf("could not parse %s: %s", file, diags.Error())
+		}
+
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, b := range body.Blocks {
+			if b.Type != "resource" || len(b.Labels) != 2 {
+				continue
+			}
+
+			r := Resource{Type: b.Labels[0], Name: b.Labels[1], File: filepath.Base(file)}
+			r.attributes, _ = blockAttributes(b.Body)
+			res[r.Address()] = r
+		}
+	}
+
+	return res, nil
+}
+
+// blockAttributes returns the attributes of the body, with the nested
+// blocks as lists, and if all of them are known. The ones that can not
+// be evaluated without context (ex: references or functions) are unknown
+// and the nested blocks with any unknown attribute are also unknown
+func blockAttributes(body *hclsyntax.Body) (map[string]interface{}, bool) {
+	attrs := make(map[string]interface{}, len(body.Attributes)+len(body.Blocks))
+	known := true
+	for n, a := range body.Attributes {
+		v, diags := a.Expr.Value(nil)
+		if diags.HasErrors() || !v.IsWhollyKnown() {
+			attrs[n] = unknown{}
+			known = false
+			continue
+		}
+		if v.IsNull() {
+			continue
+		}
+
+		b, err := ctyjson.Marshal(v, v.Type())
+		if err != nil {
+			attrs[n] = unknown{}
+			known = false
+			continue
+		}
+
+		var i interface{}
+		_ = json.Unmarshal(b, &i)
+		attrs[n] = i
+	}
+
+	for _, b := range body.Blocks {
+		// The meta blocks are not attributes
+		// of the resource and the dynamic
+		// ones can not be evaluated
+		switch b.Type {
+		case "lifecycle", "connection", "provisioner":
+			continue
+		case "dynamic":
+			attrs[b.Labels[0]] = unknown{}
+			known = false
+			continue
+		}
+
+		if _, ok := attrs[b.Type].(unknown); ok {
+			continue
+		}
+
+		ba, ok := blockAttributes(b.Body)
+		if !ok {
+			attrs[b.Type] = unknown{}
+			known = false
+			continue
+		}
+
+		l, _ := attrs[b.Type].([]interface{})
+		attrs[b.Type] = append(l, ba)
+	}
+
+	return attrs, known
+}
+
+// Names returns the names of the Resources by 'TYPE.ID', so the
+// imported ones have the address of the existing blocks, see
+// provider.ImportOptions.Names
+func (c *Comparer) Names() map[string]string {
+	names := make(map[string]string, len(c.Resources))
+	for _, r := range c.Resources {
+		names[fmt.Sprintf("%s.%s", r.Type, r.ID)] = r.Name
+	}
+	return names
+}
+
+// Middleware returns the writer.Middleware of the HCL that compares
+// the resources with the Resources of the same address. The ones
+// without differences are not written and the ones with differences
+// only with the attributes that differ and a comment with the
+// existing values of them, the rest are written as they are
+func (c *Comparer) Middleware() writer.Middleware {
+	return func(w writer.Writer) writer.Writer {
+		return &compareWriter{Writer: w, comparer: c}
+	}
+}
+
+// compareWriter is the writer of the Middleware
+type compareWriter struct {
+	writer.Writer
+
+	comparer *Comparer
+}
+
+// Write writes the differences of the value with the
+// resource of the key, if any, and the comment of them
+func (w *compareWriter) Write(key string, value interface{}) error {
+	c := w.comparer
+
+	if strings.HasPrefix(key, "comment.") {
+		k := strings.TrimPrefix(key, "comment.")
+
+		c.mu.Lock()
+		_, unchanged := c.unchanged[k]
+		cmt, changed := c.comments[k]
+		c.mu.Unlock()
+
+		// The comments (ex: the annotations) of the
+		// resources not written are skipped and the
+		// ones of the changed are kept with the one
+		// of the differences
+		if unchanged {
+			return nil
+		}
+		if s, ok := value.(string); ok && changed {
+			value = s + "\n" + cmt
+		}
+		return w.Writer.Write(key, value)
+	}
+
+	r, ok := c.resources[key]
+	cfg, isCfg := value.(map[string]interface{})
+	if !ok || !isCfg {
+		return w.Writer.Write(key, value)
+	}
+
+	diff, lines := differences(r.attributes, cfg)
+
+	c.mu.Lock()
+	if len(diff) == 0 {
+		c.unchanged[key] = struct{}{}
+		c.mu.Unlock()
+		return nil
+	}
+	cmt := fmt.Sprintf("Differs from the %s of %s, the existing values are:\n%s", key, r.File, strings.Join(lines, "\n"))
+	c.changed[key] = struct{}{}
+	c.comments[key] = cmt
+	c.mu.Unlock()
+
+	if err := w.Writer.Write(key, diff); err != nil {
+		return err
+	}
+	return w.Writer.Write("comment."+key, cmt)
+}
+
+// differences returns the attributes of the cfg that differ from the
+// existing ones and the lines with the existing values of them. The
+// unknown ones are not compared and the ones not set on the existing
+// are only different if the cfg has a value that is not empty
+func differences(existing, cfg map[string]interface{}) (map[string]interface{}, []string) {
+	keys := make([]string, 0, len(cfg))
+	for k := range cfg {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.TrimPrefix(keys[i], "=tc=") < strings.TrimPrefix(keys[j], "=tc=")
+	})
+
+	diff := make(map[string]interface{})
+	lines := make([]string, 0)
+	for _, k := range keys {
+		// The TF maps have the '=tc=' prefix
+		// on the configuration, see provider.HCLConfig
+		n := strings.TrimPrefix(k, "=tc=")
+
+		ev, ok := existing[n]
+		if _, isUnknown := ev.(unknown); isUnknown {
+			continue
+		}
+
+		nv, comparable := normalize(cfg[k])
+		if !comparable {
+			continue
+		}
+
+		if !ok {
+			if isEmpty(nv) {
+				continue
+			}
+			diff[k] = cfg[k]
+			lines = append(lines, fmt.Sprintf("%s is not set", n))
+			continue
+		}
+
+		// The nested blocks are lists on the existing
+		// but can be only one on the configuration
+		ev, _ = normalize(ev)
+		if l, ok := ev.([]interface{}); ok && len(l) == 1 {
+			if m, ok := nv.(map[string]interface{}); ok {
+				nv = []interface{}{m}
+			}
+		}
+
+		if reflect.DeepEqual(ev, nv) {
+			continue
+		}
+
+		diff[k] = cfg[k]
+		b, _ := json.Marshal(existing[n])
+		lines = append(lines, fmt.Sprintf("%s = %s", n, b))
+	}
+
+	return diff, lines
+}
+
+// normalize returns the v with the types of JSON, so the values of
+// the configuration and the existing ones are the same, the JSON
+// documents decoded and the lists sorted, as the sets have no order.
+// The values with interpolations (ex: variables) are not comparable
+func normalize(v interface{}) (interface{}, bool) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+
+	var i interface{}
+	if err := json.Unmarshal(b, &i); err != nil {
+		return nil, false
+	}
+
+	return normalizeValue(i)
+}
+
+// normalizeValue is the normalize of
+// a value decoded from JSON
+func normalizeValue(v interface{}) (interface{}, bool) {
+	switch vv := v.(type) {
+	case string:
+		// The interpolations are escaped on the
+		// configuration, see provider.normalizeInterpolation
+		s := strings.Replace(vv, "$${", "${", -1)
+		if strings.Contains(s, "${") {
+			return nil, false
+		}
+
+		if ts := strings.TrimSpace(s); strings.HasPrefix(ts, "{") || strings.HasPrefix(ts, "[") {
+			var doc interface{}
+			if err := json.Unmarshal([]byte(ts), &doc); err == nil {
+				return normalizeValue(doc)
+			}
+		}
+		return s, true
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(vv))
+		for k, e := range vv {
+			ne, ok := normalizeValue(e)
+			if !ok {
+				return nil, false
+			}
+			res[strings.TrimPrefix(k, "=tc=")] = ne
+		}
+		return res, true
+	case []interface{}:
+		res := make([]interface{}, 0, len(vv))
+		for _, e := range vv {
+			ne, ok := normalizeValue(e)
+			if !ok {
+				return nil, false
+			}
+			res = append(res, ne)
+		}
+		sort.SliceStable(res, func(i, j int) bool {
+			bi, _ := json.Marshal(res[i])
+			bj, _ := json.Marshal(res[j])
+			return string(bi) < string(bj)
+		})
+		return res, true
+	default:
+		return v, true
+	}
+}
+
+// isEmpty checks if the v is an empty
+// value, list or map, or nil
+func isEmpty(v interface{}) bool {
+	switch vv := v.(type) {
+	case nil:
+		return true
+	case string:
+		return vv == ""
+	case []interface{}:
+		return len(vv) == 0
+	case map[string]interface{}:
+		return len(vv) == 0
+	}
+	return false
+}
+
+// Report writes to the w the number of Resources changed and
+// unchanged and the ones that were not imported, as they do
+// not exist anymore or they have been filtered
+func (c *Comparer) Report(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fmt.Fprintf(w, "Compared with %d resources of %s: %d changed and %d unchanged\n", len(c.Resources), c.Dir, len(c.changed), len(c.unchanged))
+	for _, r := range c.Resources {
+		_, changed := c.changed[r.Address()]
+		_, unchanged := c.unchanged[r.Address()]
+		if !changed && !unchanged {
+			fmt.Fprintf(w, "The resource %s of %s was not imported\n", r, r.File)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package compare_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/compare"
+	"github.com/cycloidio/terracognita/mock"
+)
+
+const (
+	config = `
+resource "aws_instance" "front" {
+  ami           = "ami-1"
+  instance_type = "t2.micro"
+  subnet_id     = aws_subnet.main.id
+
+  tags = {
+    Name = "front"
+  }
+
+  root_block_device {
+    volume_size = 8
+  }
+
+  lifecycle {
+    ignore_changes = [ami]
+  }
+}
+
+resource "aws_instance" "back" {
+  ami           = "ami-1"
+  instance_type = "t2.micro"
+}
+
+resource "aws_subnet" "main" {
+  cidr_block = "10.0.1.0/24"
+}
+`
+
+	tfstate = `{
+  "version": 4,
+  "terraform_version": "0.13.5",
+  "serial": 1,
+  "lineage": "lineage",
+  "outputs": {},
+  "resources": [
+    {"mode": "managed", "type": "aws_instance", "name": "front", "instances": [{"attributes": {"id": "i-1"}}]},
+    {"mode": "managed", "type": "aws_instance", "name": "back", "instances": [{"attributes": {"id": "i-2"}}]},
+    {"mode": "managed", "type": "aws_s3_bucket", "name": "logs", "instances": [{"attributes": {"id": "logs"}}]}
+  ]
+}`
+)
+
+func tfdir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "terracognita-compare")
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(config), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, compare.TFState), []byte(tfstate), 0644))
+
+	return dir
+}
+
+func TestRead(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		dir := tfdir(t)
+		defer os.RemoveAll(dir)
+
+		c, err := compare.Read(dir)
+		require.NoError(t, err)
+
+		require.Len(t, c.Resources, 2)
+		assert.Equal(t, "aws_instance.back", c.Resources[0].Address())
+		assert.Equal(t, "main.tf", c.Resources[0].File)
+		assert.Equal(t, "aws_instance.front", c.Resources[1].Address())
+		assert.Equal(t, map[string]string{
+			"aws_instance.i-1": "front",
+			"aws_instance.i-2": "back",
+		}, c.Names())
+	})
+	t.Run("ErrorNoTFState", func(t *testing.T) {
+		dir := tfdir(t)
+		defer os.RemoveAll(dir)
+		require.NoError(t, os.Remove(filepath.Join(dir, compare.TFState)))
+
+		_, err := compare.Read(dir)
+		assert.Error(t, err)
+	})
+	t.Run("ErrorInvalidHCL", func(t *testing.T) {
+		dir := tfdir(t)
+		defer os.RemoveAll(dir)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "invalid.tf"), []byte(`resource "aws_instance" {`), 0644))
+
+		_, err := compare.Read(dir)
+		assert.Error(t, err)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := tfdir(t)
+	defer os.RemoveAll(dir)
+
+	c, err := compare.Read(dir)
+	require.NoError(t, err)
+
+	var (
+		w  = mock.NewWriter(ctrl)
+		mw = c.Middleware()(w)
+	)
+
+	t.Run("Changed", func(t *testing.T) {
+		w.EXPECT().Write("aws_instance.front", map[string]interface{}{
+			"instance_type": "t2.large",
+			"=tc=tags":      map[string]interface{}{"Name": "web"},
+		}).Return(nil)
+		w.EXPECT().Write("comment.aws_instance.front", "Differs from the aws_instance.front of main.tf, the existing values are:\ninstance_type = \"t2.micro\"\ntags = {\"Name\":\"front\"}").Return(nil)
+
+		err := mw.Write("aws_instance.front", map[string]interface{}{
+			"ami":               "ami-1",
+			"instance_type":     "t2.large",
+			"subnet_id":         "subnet-1",
+			"=tc=tags":          map[string]interface{}{"Name": "web"},
+			"root_block_device": []interface{}{map[string]interface{}{"volume_size": 8}},
+			"security_groups":   []interface{}{},
+		})
+		require.NoError(t, err)
+	})
+	t.Run("ChangedAnnotation", func(t *testing.T) {
+		w.EXPECT().Write("comment.aws_instance.front", "Imported from aws with ID i-1\nDiffers from the aws_instance.front of main.tf, the existing values are:\ninstance_type = \"t2.micro\"\ntags = {\"Name\":\"front\"}").Return(nil)
+
+		require.NoError(t, mw.Write("comment.aws_instance.front", "Imported from aws with ID i-1"))
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		err := mw.Write("aws_instance.back", map[string]interface{}{
+			"ami":           "ami-1",
+			"instance_type": "t2.micro",
+		})
+		require.NoError(t, err)
+		require.NoError(t, mw.Write("comment.aws_instance.back", "Imported from aws with ID i-2"))
+	})
+	t.Run("NotSet", func(t *testing.T) {
+		c, err := compare.Read(dir)
+		require.NoError(t, err)
+
+		w.EXPECT().Write("aws_instance.back", map[string]interface{}{"monitoring": true}).Return(nil)
+		w.EXPECT().Write("comment.aws_instance.back", "Differs from the aws_instance.back of main.tf, the existing values are:\nmonitoring is not set").Return(nil)
+
+		err = c.Middleware()(w).Write("aws_instance.back", map[string]interface{}{
+			"ami":           "ami-1",
+			"instance_type": "t2.micro",
+			"monitoring":    true,
+		})
+		require.NoError(t, err)
+	})
+	t.Run("NotExisting", func(t *testing.T) {
+		cfg := map[string]interface{}{"bucket": "logs"}
+		w.EXPECT().Write("aws_s3_bucket.logs", cfg).Return(nil)
+
+		require.NoError(t, mw.Write("aws_s3_bucket.logs", cfg))
+	})
+
+	var b bytes.Buffer
+	c.Report(&b)
+	assert.Equal(t, "Compared with 2 resources of "+c.Dir+": 1 changed and 1 unchanged\n", b.String())
+}
+
+func TestReport(t *testing.T) {
+	dir := tfdir(t)
+	defer os.RemoveAll(dir)
+
+	c, err := compare.Read(dir)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	c.Report(&b)
+	assert.Equal(t, "Compared with 2 resources of "+c.Dir+": 0 changed and 0 unchanged\nThe resource aws_instance.back (i-2) of main.tf was not imported\nThe resource aws_instance.front (i-1) of main.tf was not imported\n", b.String())
+}
@@ -0,0 +1,4 @@
+// Package compare matches the imported resources with the ones
+// of an existing Terraform configuration, by the ID of them on
+// the TFState, so only the attributes that differ are written
+package compare
@@ -0,0 +1,4 @@
+// Package crossplane has the Writer that generates
+// the Crossplane managed resources manifests to
+// adopt the resources with Crossplane
+package crossplane
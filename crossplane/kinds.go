@@ -0,0 +1,85 @@
+package crossplane
+
+import (
+	"fmt"
+	"strings"
+)
+
+// version is the version of the
+// managed resources that is used
+const version = "v1beta1"
+
+var (
+	// providers maps the TF provider to
+	// the domain of the Crossplane provider
+	providers = map[string]string{
+		"aws":    "aws.upbound.io",
+		"google": "gcp.upbound.io",
+	}
+
+	// kinds are the group and kind of the resources
+	// that do not follow the '<service>.<provider>'
+	// and '<Kind>' convention from the TF type
+	kinds = map[string][2]string{
+		"aws_instance":                    {"ec2", "Instance"},
+		"aws_vpc":                         {"ec2", "VPC"},
+		"aws_security_group":              {"ec2", "SecurityGroup"},
+		"aws_subnet":                      {"ec2", "Subnet"},
+		"aws_launch_template":             {"ec2", "LaunchTemplate"},
+		"aws_ec2_transit_gateway":         {"ec2", "TransitGateway"},
+		"aws_launch_configuration":        {"autoscaling", "LaunchConfiguration"},
+		"aws_elb":                         {"elb", "ELB"},
+		"aws_alb":                         {"elbv2", "LB"},
+		"aws_lb":                          {"elbv2", "LB"},
+		"aws_db_instance":                 {"rds", "Instance"},
+		"aws_ebs_volume":                  {"ec2", "EBSVolume"},
+		"aws_iam_openid_connect_provider": {"iam", "OpenIDConnectProvider"},
+		"aws_iam_saml_provider":           {"iam", "SAMLProvider"},
+	}
+)
+
+// APIVersionKind returns the apiVersion and kind of the
+// Crossplane managed resource of the TF type t
+// ex: aws_iam_role => iam.aws.upbound.io/v1beta1, Role
+func APIVersionKind(t string) (string, string) {
+	parts := strings.SplitN(t, "_", 3)
+
+	domain, ok := providers[parts[0]]
+	if !ok {
+		domain = fmt.Sprintf("%s.upbound.io", parts[0])
+	}
+
+	var group, kind string
+	if gk, ok := kinds[t]; ok {
+		group, kind = gk[0], gk[1]
+	} else {
+		switch len(parts) {
+		case 1:
+			group, kind = parts[0], parts[0]
+		case 2:
+			group, kind = parts[1], parts[1]
+		default:
+			group, kind = parts[1], parts[2]
+		}
+		kind = camelCase(kind, true)
+	}
+
+	return fmt.Sprintf("%s.%s/%s", group, domain, version), kind
+}
+
+// camelCase converts the snake case s to camel case,
+// with the first letter upper case if upper
+func camelCase(s string, upper bool) string {
+	var b strings.Builder
+	for i, p := range strings.Split(s, "_") {
+		if p == "" {
+			continue
+		}
+		if i == 0 && !upper {
+			b.WriteString(p)
+			continue
+		}
+		b.WriteString(strings.ToUpper(p[:1]) + p[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package crossplane
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// externalNameAnnotation is the annotation used by Crossplane
+// to know the ID of the resource on the cloud provider
+const externalNameAnnotation = "crossplane.io/external-name"
+
+// Writer is a Writer implementation that generates
+// the Crossplane managed resources YAML manifests
+type Writer struct {
+	*provider.Collector
+
+	writer io.Writer
+}
+
+// NewWriter returns a Writer initialization
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		Collector: provider.NewCollector("Crossplane"),
+		writer:    w,
+	}
+}
+
+// Sync writes all the managed resources to the
+// internal w as a multi document YAML
+func (w *Writer) Sync() error {
+	buff := &bytes.Buffer{}
+	for i, k := range w.Keys() {
+		b, err := yaml.Marshal(manifest(strings.Split(k, ".")[1], w.Resource(k)))
+		if err != nil {
+			return errors.Wrapf(err, "error while encoding %s", k)
+		}
+
+		if i != 0 {
+			buff.WriteString("---\n")
+		}
+		buff.Write(b)
+	}
+
+	_, err := io.Copy(w.writer, buff)
+	if err != nil {
+		return errors.Wrap(err, "error while writing the manifests")
+	}
+
+	return nil
+}
+
+// manifest returns the managed resource of the r with the name
+func manifest(name string, r provider.Resource) yaml.MapSlice {
+	apiVersion, kind := APIVersionKind(r.Type())
+
+	forProvider := properties(provider.HCLConfig(r))
+
+	// The AWS managed resources require the
+	// region, which on TF is on the provider
+	if r.Provider().String() == "aws" && r.Provider().Region() != "" {
+		forProvider = append(yaml.MapSlice{{Key: "region", Value: r.Provider().Region()}}, forProvider...)
+	}
+
+	return yaml.MapSlice{
+		{Key: "apiVersion", Value: apiVersion},
+		{Key: "kind", Value: kind},
+		{Key: "metadata", Value: yaml.MapSlice{
+			{Key: "name", Value: objectName(name)},
+			{Key: "annotations", Value: yaml.MapSlice{
+				{Key: externalNameAnnotation, Value: r.ID()},
+			}},
+		}},
+		{Key: "spec", Value: yaml.MapSlice{
+			{Key: "forProvider", Value: forProvider},
+		}},
+	}
+}
+
+var invalidNameRe = regexp.MustCompile(`[^a-z0-9\-.]+`)
+
+// objectName converts the name to a valid
+// Kubernetes object name
+func objectName(name string) string {
+	return strings.Trim(invalidNameRe.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+}
+
+// properties returns the cfg with the keys in camel case
+// and sorted, the TF maps (prefixed with '=tc=') are
+// kept as they are
+func properties(cfg map[string]interface{}) yaml.MapSlice {
+	keys := make([]string, 0, len(cfg))
+	for k := range cfg {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.TrimPrefix(keys[i], "=tc=") < strings.TrimPrefix(keys[j], "=tc=")
+	})
+
+	res := make(yaml.MapSlice, 0, len(keys))
+	for _, k := range keys {
+		if strings.HasPrefix(k, "=tc=") {
+			res = append(res, yaml.MapItem{Key: strings.TrimPrefix(k, "=tc="), Value: cfg[k]})
+			continue
+		}
+		res = append(res, yaml.MapItem{Key: camelCase(k, false), Value: value(cfg[k])})
+	}
+
+	return res
+}
+
+// value converts the nested blocks of v to properties
+func value(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		return properties(vv)
+	case []interface{}:
+		res := make([]interface{}, 0, len(vv))
+		for _, e := range vv {
+			res = append(res, value(e))
+		}
+		return res
+	default:
+		return v
+	}
+}
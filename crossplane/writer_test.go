@@ -0,0 +1,126 @@
+package crossplane_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cycloidio/terracognita/crossplane"
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIVersionKind(t *testing.T) {
+	tests := []struct {
+		Type       string
+		APIVersion string
+		Kind       string
+	}{
+		{Type: "aws_iam_role", APIVersion: "iam.aws.upbound.io/v1beta1", Kind: "Role"},
+		{Type: "aws_instance", APIVersion: "ec2.aws.upbound.io/v1beta1", Kind: "Instance"},
+		{Type: "aws_s3_bucket", APIVersion: "s3.aws.upbound.io/v1beta1", Kind: "Bucket"},
+		{Type: "google_compute_instance_template", APIVersion: "compute.gcp.upbound.io/v1beta1", Kind: "InstanceTemplate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Type, func(t *testing.T) {
+			apiVersion, kind := crossplane.APIVersionKind(tt.Type)
+			assert.Equal(t, tt.APIVersion, apiVersion)
+			assert.Equal(t, tt.Kind, kind)
+		})
+	}
+}
+
+func TestWriter(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			b    = &bytes.Buffer{}
+			cw   = crossplane.NewWriter(b)
+			p    = mock.NewProvider(ctrl)
+			r1   = mock.NewResource(ctrl)
+			r2   = mock.NewResource(ctrl)
+
+			tfr = &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"instance_type": &schema.Schema{Type: schema.TypeString, Optional: true},
+					"arn":           &schema.Schema{Type: schema.TypeString, Computed: true},
+				},
+			}
+			d1 = tfr.Data(nil)
+			d2 = tfr.Data(nil)
+
+			manifest = `apiVersion: ec2.aws.upbound.io/v1beta1
+kind: Instance
+metadata:
+  name: front-web
+  annotations:
+    crossplane.io/external-name: i-123
+spec:
+  forProvider:
+    region: eu-west-1
+    instanceType: t2.micro
+---
+apiVersion: ec2.aws.upbound.io/v1beta1
+kind: Instance
+metadata:
+  name: back
+  annotations:
+    crossplane.io/external-name: i-456
+spec:
+  forProvider:
+    region: eu-west-1
+    instanceType: m5.large
+`
+		)
+		defer ctrl.Finish()
+
+		d1.Set("instance_type", "t2.micro")
+		d2.Set("instance_type", "m5.large")
+
+		p.EXPECT().String().Return("aws").AnyTimes()
+		p.EXPECT().Region().Return("eu-west-1").AnyTimes()
+
+		r1.EXPECT().Type().Return("aws_instance")
+		r1.EXPECT().ID().Return("i-123")
+		r1.EXPECT().Data().Return(d1)
+		r1.EXPECT().TFResource().Return(tfr)
+		r1.EXPECT().Provider().Return(p).AnyTimes()
+
+		r2.EXPECT().Type().Return("aws_instance")
+		r2.EXPECT().ID().Return("i-456")
+		r2.EXPECT().Data().Return(d2)
+		r2.EXPECT().TFResource().Return(tfr)
+		r2.EXPECT().Provider().Return(p).AnyTimes()
+
+		require.NoError(t, cw.Write("aws_instance.Front_Web", r1))
+		require.NoError(t, cw.Write("aws_instance.back", r2))
+
+		ok, err := cw.Has("aws_instance.back")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, cw.Sync())
+		assert.Equal(t, manifest, b.String())
+	})
+	t.Run("Errors", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			cw   = crossplane.NewWriter(nil)
+			r    = mock.NewResource(ctrl)
+		)
+		defer ctrl.Finish()
+
+		assert.Equal(t, errcode.ErrWriterRequiredKey, errors.Cause(cw.Write("", r)))
+		assert.Equal(t, errcode.ErrWriterRequiredValue, errors.Cause(cw.Write("type.name", nil)))
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(cw.Write("type", r)))
+		assert.Equal(t, errcode.ErrWriterInvalidTypeValue, errors.Cause(cw.Write("type.name", "value")))
+
+		require.NoError(t, cw.Write("type.name", r))
+		assert.Equal(t, errcode.ErrWriterAlreadyExistsKey, errors.Cause(cw.Write("type.name", r)))
+	})
+}
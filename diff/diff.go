@@ -0,0 +1,260 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Resource is a resource of a TFState
+type Resource struct {
+	// Address is the address of the resource
+	// on the HCL (ex: aws_instance.front)
+	Address string `json:"address"`
+
+	Type string `json:"type"`
+	ID   string `json:"id"`
+
+	// attributes are the flattened
+	// attributes of the resource
+	attributes map[string]string
+}
+
+// Attribute is an attribute changed
+type Attribute struct {
+	Name string `json:"name"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// Change is a resource on both TFStates with
+// different attributes, the Address is the new one
+type Change struct {
+	Resource
+
+	Attributes []Attribute `json:"attributes"`
+}
+
+// Diff is the difference between two TFStates
+type Diff struct {
+	Added   []Resource `json:"added"`
+	Removed []Resource `json:"removed"`
+	Changed []Change   `json:"changed"`
+}
+
+// IsEmpty checks if the Diff has no changes
+func (d *Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// States returns the Diff from the old TFState to the new one. The
+// resources are matched by the type and ID, as the addresses can
+// be different between runs, or the address if they have no ID
+func States(old, new io.Reader) (*Diff, error) {
+	ors, err := readResources(old)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid old TFState")
+	}
+	nrs, err := readResources(new)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid new TFState")
+	}
+
+	d := &Diff{
+		Added:   make([]Resource, 0),
+		Removed: make([]Resource, 0),
+		Changed: make([]Change, 0),
+	}
+
+	for _, k := range sortedKeys(nrs) {
+		nr := nrs[k]
+		or, ok := ors[k]
+		if !ok {
+			d.Added = append(d.Added, nr)
+			continue
+		}
+
+		if attrs := changedAttributes(or.attributes, nr.attributes); len(attrs) != 0 {
+			d.Changed = append(d.Changed, Change{Resource: nr, Attributes: attrs})
+		}
+	}
+
+	for _, k := range sortedKeys(ors) {
+		if _, ok := nrs[k]; !ok {
+			d.Removed = append(d.Removed, ors[k])
+		}
+	}
+
+	return d, nil
+}
+
+// Write writes the d to the w as a changelog
+func (d *Diff) Write(w io.Writer) {
+	if d.IsEmpty() {
+		fmt.Fprintln(w, "No changes")
+		return
+	}
+
+	if len(d.Added) != 0 {
+		fmt.Fprintf(w, "Added (%d):\n", len(d.Added))
+		for _, r := range d.Added {
+			fmt.Fprintf(w, "  + %s\n", r)
+		}
+	}
+
+	if len(d.Removed) != 0 {
+		fmt.Fprintf(w, "Removed (%d):\n", len(d.Removed))
+		for _, r := range d.Removed {
+			fmt.Fprintf(w, "  - %s\n", r)
+		}
+	}
+
+	if len(d.Changed) != 0 {
+		fmt.Fprintf(w, "Changed (%d):\n", len(d.Changed))
+		for _, c := range d.Changed {
+			fmt.Fprintf(w, "  ~ %s\n", c.Resource)
+			for _, a := range c.Attributes {
+				fmt.Fprintf(w, "      %s: %q => %q\n", a.Name, a.Old, a.New)
+			}
+		}
+	}
+}
+
+// String returns the address and the ID of the r
+func (r Resource) String() string {
+	if r.ID == "" {
+		return r.Address
+	}
+	return fmt.Sprintf("%s (%s)", r.Address, r.ID)
+}
+
+// tfstate is the part of the TFState (version 4)
+// needed to compare the resources, it's read directly
+// to not depend on the Terraform version that wrote it
+type tfstate struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey   interface{}            `json:"index_key"`
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// readResources reads the managed resources of
+// the TFState of r by the key of them
+func readResources(r io.Reader) (map[string]Resource, error) {
+	var s tfstate
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+	if s.Version != 4 {
+		return nil, errors.Errorf("unsupported TFState version %d, only 4 is supported", s.Version)
+	}
+
+	res := make(map[string]Resource)
+	for _, rs := range s.Resources {
+		if rs.Mode != "managed" {
+			continue
+		}
+
+		for _, is := range rs.Instances {
+			attrs := make(map[string]string)
+			flatten(attrs, "", is.Attributes)
+
+			addr := fmt.Sprintf("%s.%s", rs.Type, rs.Name)
+			switch k := is.IndexKey.(type) {
+			case string:
+				addr = fmt.Sprintf("%s[%q]", addr, k)
+			case float64:
+				addr = fmt.Sprintf("%s[%d]", addr, int(k))
+			}
+			if rs.Module != "" {
+				addr = fmt.Sprintf("%s.%s", rs.Module, addr)
+			}
+
+			r := Resource{
+				Address:    addr,
+				Type:       rs.Type,
+				ID:         attrs["id"],
+				attributes: attrs,
+			}
+
+			key := r.Address
+			if r.ID != "" {
+				key = r.Type + "." + r.ID
+			}
+			res[key] = r
+		}
+	}
+
+	return res, nil
+}
+
+// flatten adds to the attrs the values of v with the
+// keys prefixed with the prefix, the nested ones with
+// '.' (ex: tags.Name or ebs_block_device.0.iops)
+func flatten(attrs map[string]string, prefix string, v interface{}) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range vv {
+			flatten(attrs, join(prefix, k), e)
+		}
+	case []interface{}:
+		for i, e := range vv {
+			flatten(attrs, join(prefix, fmt.Sprint(i)), e)
+		}
+	case nil:
+	default:
+		attrs[prefix] = fmt.Sprint(vv)
+	}
+}
+
+// join joins the prefix and the k with '.'
+func join(prefix, k string) string {
+	if prefix == "" {
+		return k
+	}
+	return strings.Join([]string{prefix, k}, ".")
+}
+
+// changedAttributes returns the sorted
+// attributes different on old and new
+func changedAttributes(old, new map[string]string) []Attribute {
+	names := make(map[string]struct{})
+	for k := range old {
+		names[k] = struct{}{}
+	}
+	for k := range new {
+		names[k] = struct{}{}
+	}
+
+	attrs := make([]Attribute, 0)
+	for k := range names {
+		if old[k] != new[k] {
+			attrs = append(attrs, Attribute{Name: k, Old: old[k], New: new[k]})
+		}
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Name < attrs[j].Name })
+
+	return attrs
+}
+
+// sortedKeys returns the keys of
+// the rs sorted alphabetically
+func sortedKeys(rs map[string]Resource) []string {
+	keys := make([]string, 0, len(rs))
+	for k := range rs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,88 @@
+package diff_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cycloidio/terracognita/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tfstate(resources ...string) string {
+	return fmt.Sprintf(`{
+  "version": 4,
+  "terraform_version": "0.13.5",
+  "serial": 1,
+  "lineage": "lineage",
+  "outputs": {},
+  "resources": [%s]
+}`, strings.Join(resources, ","))
+}
+
+func resource(t, name, attrs string) string {
+	return fmt.Sprintf(`{
+  "mode": "managed",
+  "type": %q,
+  "name": %q,
+  "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+  "instances": [{"schema_version": 0, "attributes": %s}]
+}`, t, name, attrs)
+}
+
+func TestStates(t *testing.T) {
+	old := tfstate(
+		resource("aws_instance", "front", `{"id": "i-1", "instance_type": "t2.micro", "tags": {"Name": "front"}}`),
+		resource("aws_instance", "back", `{"id": "i-2", "instance_type": "t2.micro"}`),
+	)
+	new := tfstate(
+		resource("aws_instance", "web", `{"id": "i-1", "instance_type": "t2.large", "tags": {"Name": "web"}}`),
+		resource("aws_s3_bucket", "logs", `{"id": "logs"}`),
+	)
+
+	d, err := diff.States(strings.NewReader(old), strings.NewReader(new))
+	require.NoError(t, err)
+
+	assert.False(t, d.IsEmpty())
+	require.Len(t, d.Added, 1)
+	assert.Equal(t, "aws_s3_bucket.logs", d.Added[0].Address)
+	require.Len(t, d.Removed, 1)
+	assert.Equal(t, "aws_instance.back", d.Removed[0].Address)
+	require.Len(t, d.Changed, 1)
+	assert.Equal(t, "aws_instance.web", d.Changed[0].Address)
+	assert.Equal(t, []diff.Attribute{
+		{Name: "instance_type", Old: "t2.micro", New: "t2.large"},
+		{Name: "tags.Name", Old: "front", New: "web"},
+	}, d.Changed[0].Attributes)
+
+	var b bytes.Buffer
+	d.Write(&b)
+	assert.Equal(t, `Added (1):
+  + aws_s3_bucket.logs (logs)
+Removed (1):
+  - aws_instance.back (i-2)
+Changed (1):
+  ~ aws_instance.web (i-1)
+      instance_type: "t2.micro" => "t2.large"
+      tags.Name: "front" => "web"
+`, b.String())
+}
+
+func TestStates_Equal(t *testing.T) {
+	s := tfstate(resource("aws_instance", "front", `{"id": "i-1", "instance_type": "t2.micro"}`))
+
+	d, err := diff.States(strings.NewReader(s), strings.NewReader(s))
+	require.NoError(t, err)
+	assert.True(t, d.IsEmpty())
+
+	var b bytes.Buffer
+	d.Write(&b)
+	assert.Equal(t, "No changes\n", b.String())
+}
+
+func TestStates_Invalid(t *testing.T) {
+	_, err := diff.States(strings.NewReader("invalid"), strings.NewReader(tfstate()))
+	assert.Error(t, err)
+}
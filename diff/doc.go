@@ -0,0 +1,4 @@
+// Package diff compares the TFStates of two runs and
+// reports the resources added, removed and changed
+// between them as a changelog
+package diff
@@ -0,0 +1,3 @@
+// Package encrypt encrypts the generated files (ex: the TFState)
+// so they are never written in plain text to the disk
+package encrypt
@@ -0,0 +1,210 @@
+package encrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// Version is the version of the Envelope format
+const Version = 1
+
+// List of the KDFs used to get the key of the Envelope
+const (
+	KDFScrypt = "scrypt"
+	KDFAWSKMS = "aws-kms"
+)
+
+// Envelope is the encrypted content with AES-256-GCM
+// and the information needed to get the key of it
+type Envelope struct {
+	Version int    `json:"terracognita_encrypted"`
+	KDF     string `json:"kdf"`
+
+	// Salt is the one of the scrypt
+	Salt []byte `json:"salt,omitempty"`
+
+	// KeyID and EncryptedKey are the KMS
+	// key and the data key encrypted with it
+	KeyID        string `json:"key_id,omitempty"`
+	EncryptedKey []byte `json:"encrypted_key,omitempty"`
+
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Keyer returns the key to encrypt and decrypt
+type Keyer interface {
+	// NewKey returns a new key for the e, which is
+	// updated with the information needed to get it
+	NewKey(e *Envelope) ([]byte, error)
+
+	// Key returns the key of the e
+	Key(e *Envelope) ([]byte, error)
+}
+
+// scrypt parameters recommended for
+// interactive logins, a 32 bytes key
+// is used to have AES-256
+const (
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+	keyLen  = 32
+	saltLen = 16
+)
+
+type passphrase struct {
+	passphrase string
+}
+
+// NewPassphrase returns a Keyer that derives the
+// keys from the p with scrypt and a random salt
+func NewPassphrase(p string) Keyer {
+	return &passphrase{passphrase: p}
+}
+
+func (p *passphrase) NewKey(e *Envelope) ([]byte, error) {
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.Wrap(err, "unable to generate the salt")
+	}
+
+	e.KDF = KDFScrypt
+	e.Salt = salt
+
+	return p.Key(e)
+}
+
+func (p *passphrase) Key(e *Envelope) ([]byte, error) {
+	if e.KDF != KDFScrypt {
+		return nil, errors.Wrapf(errcode.ErrEncryptInvalidKey, "the content is encrypted with %q, not a passphrase", e.KDF)
+	}
+
+	key, err := scrypt.Key([]byte(p.passphrase), e.Salt, scryptN, scryptR, scryptP, keyLen)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to derive the key")
+	}
+	return key, nil
+}
+
+type awsKMS struct {
+	keyID string
+	kms   kmsiface.KMSAPI
+}
+
+// NewAWSKMS returns a Keyer that generates the keys with the
+// AWS KMS key of keyID as data keys, stored encrypted on the
+// Envelope so the KMS is needed to decrypt them
+func NewAWSKMS(keyID string, k kmsiface.KMSAPI) Keyer {
+	return &awsKMS{keyID: keyID, kms: k}
+}
+
+func (a *awsKMS) NewKey(e *Envelope) ([]byte, error) {
+	out, err := a.kms.GenerateDataKey(&kms.GenerateDataKeyInput{
+		KeyId:   aws.String(a.keyID),
+		KeySpec: aws.String(kms.DataKeySpecAes256),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to generate the data key with %s", a.keyID)
+	}
+
+	e.KDF = KDFAWSKMS
+	e.KeyID = a.keyID
+	e.EncryptedKey = out.CiphertextBlob
+
+	return out.Plaintext, nil
+}
+
+func (a *awsKMS) Key(e *Envelope) ([]byte, error) {
+	if e.KDF != KDFAWSKMS {
+		return nil, errors.Wrapf(errcode.ErrEncryptInvalidKey, "the content is encrypted with %q, not an AWS KMS key", e.KDF)
+	}
+
+	out, err := a.kms.Decrypt(&kms.DecryptInput{
+		CiphertextBlob: e.EncryptedKey,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to decrypt the data key with %s", e.KeyID)
+	}
+
+	return out.Plaintext, nil
+}
+
+// Encrypt returns the Envelope, as JSON, of the
+// plain content encrypted with a new key of k
+func Encrypt(k Keyer, plain []byte) ([]byte, error) {
+	e := &Envelope{Version: Version}
+
+	key, err := k.NewKey(e)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	e.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, e.Nonce); err != nil {
+		return nil, errors.Wrap(err, "unable to generate the nonce")
+	}
+	e.Ciphertext = gcm.Seal(nil, e.Nonce, plain, nil)
+
+	return json.MarshalIndent(e, "", "  ")
+}
+
+// Decrypt returns the plain content of
+// the Envelope b with the key of the k
+func Decrypt(k Keyer, b []byte) ([]byte, error) {
+	var e Envelope
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, errors.Wrap(errcode.ErrEncryptInvalidContent, err.Error())
+	}
+	if e.Version != Version {
+		return nil, errors.Wrapf(errcode.ErrEncryptInvalidContent, "unsupported version %d", e.Version)
+	}
+
+	key, err := k.Key(&e)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := gcm.Open(nil, e.Nonce, e.Ciphertext, nil)
+	if err != nil {
+		return nil, errors.Wrap(errcode.ErrEncryptInvalidKey, err.Error())
+	}
+
+	return plain, nil
+}
+
+// newGCM returns the AES-GCM of the key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != keyLen {
+		return nil, errors.Wrapf(errcode.ErrEncryptInvalidKey, "the key has %d bytes instead of %d", len(key), keyLen)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the cipher: %s", err)
+	}
+
+	return cipher.NewGCM(block)
+}
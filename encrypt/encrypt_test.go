@@ -0,0 +1,92 @@
+package encrypt_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/encrypt"
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// fakeKMS encrypts the data keys by reversing them
+type fakeKMS struct {
+	kmsiface.KMSAPI
+}
+
+func (f fakeKMS) GenerateDataKey(in *kms.GenerateDataKeyInput) (*kms.GenerateDataKeyOutput, error) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	return &kms.GenerateDataKeyOutput{Plaintext: key, CiphertextBlob: reverse(key)}, nil
+}
+
+func (f fakeKMS) Decrypt(in *kms.DecryptInput) (*kms.DecryptOutput, error) {
+	return &kms.DecryptOutput{Plaintext: reverse(in.CiphertextBlob)}, nil
+}
+
+func reverse(b []byte) []byte {
+	res := make([]byte, len(b))
+	for i := range b {
+		res[len(b)-1-i] = b[i]
+	}
+	return res
+}
+
+func TestEncrypt(t *testing.T) {
+	plain := []byte(`{"version": 4}`)
+
+	t.Run("Passphrase", func(t *testing.T) {
+		b, err := encrypt.Encrypt(encrypt.NewPassphrase("secret"), plain)
+		require.NoError(t, err)
+		assert.NotContains(t, string(b), "version")
+
+		res, err := encrypt.Decrypt(encrypt.NewPassphrase("secret"), b)
+		require.NoError(t, err)
+		assert.Equal(t, plain, res)
+
+		_, err = encrypt.Decrypt(encrypt.NewPassphrase("other"), b)
+		assert.Equal(t, errcode.ErrEncryptInvalidKey, errors.Cause(err))
+	})
+
+	t.Run("AWSKMS", func(t *testing.T) {
+		k := encrypt.NewAWSKMS("alias/tfstate", fakeKMS{})
+		b, err := encrypt.Encrypt(k, plain)
+		require.NoError(t, err)
+
+		res, err := encrypt.Decrypt(k, b)
+		require.NoError(t, err)
+		assert.Equal(t, plain, res)
+
+		_, err = encrypt.Decrypt(encrypt.NewPassphrase("secret"), b)
+		assert.Equal(t, errcode.ErrEncryptInvalidKey, errors.Cause(err))
+	})
+
+	t.Run("InvalidContent", func(t *testing.T) {
+		_, err := encrypt.Decrypt(encrypt.NewPassphrase("secret"), plain)
+		assert.Equal(t, errcode.ErrEncryptInvalidContent, errors.Cause(err))
+	})
+}
+
+func TestWriter(t *testing.T) {
+	var (
+		out = &bytes.Buffer{}
+		k   = encrypt.NewPassphrase("secret")
+		w   = encrypt.NewWriter(out, k)
+	)
+
+	_, err := w.Write([]byte(`{"version": `))
+	require.NoError(t, err)
+	_, err = w.Write([]byte(`4}`))
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+
+	require.NoError(t, w.Close())
+
+	res, err := encrypt.Decrypt(k, out.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, `{"version": 4}`, string(res))
+}
@@ -0,0 +1,39 @@
+package encrypt
+
+import (
+	"bytes"
+	"io"
+)
+
+// Writer buffers the content written to it and
+// on the Close writes it encrypted to the w, so
+// the plain content is only on memory
+type Writer struct {
+	w   io.Writer
+	k   Keyer
+	buf bytes.Buffer
+}
+
+// NewWriter returns a Writer that writes to w
+// the content encrypted with a new key of k
+func NewWriter(w io.Writer, k Keyer) *Writer {
+	return &Writer{w: w, k: k}
+}
+
+// Write buffers the p to encrypt it on the Close
+func (w *Writer) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// Close encrypts the content written
+// and writes it to the io.Writer
+func (w *Writer) Close() error {
+	b, err := Encrypt(w.k, w.buf.Bytes())
+	if err != nil {
+		return err
+	}
+	w.buf.Reset()
+
+	_, err = w.w.Write(b)
+	return err
+}
@@ -0,0 +1,149 @@
+package errcode
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// List of the classes of the errors, so the
+// wrappers of the CLI can branch on them
+const (
+	// ClassAuth is when the credentials are
+	// not valid or have expired
+	ClassAuth = "auth"
+	// ClassPermission is when the credentials have
+	// no permissions to do the action
+	ClassPermission = "permission"
+	// ClassThrottled is when the cloud provider
+	// has limited the rate of the requests
+	ClassThrottled = "throttled"
+	// ClassUnsupported is when a resource
+	// type or provider is not supported
+	ClassUnsupported = "unsupported"
+	// ClassWrite is when the outputs
+	// could not be written
+	ClassWrite = "write"
+	// ClassUnavailable is when the API of the cloud
+	// provider failed (ex: 5xx) or is not available
+	ClassUnavailable = "unavailable"
+	// ClassUnknown is any other error
+	ClassUnknown = "unknown"
+)
+
+// exitCodes are the exit codes of the process
+// by the class of the error that ended it
+var exitCodes = map[string]int{
+	ClassUnknown:     1,
+	ClassAuth:        3,
+	ClassPermission:  4,
+	ClassThrottled:   5,
+	ClassUnsupported: 6,
+	ClassWrite:       7,
+	ClassUnavailable: 8,
+}
+
+// classErrors are the errors of each class
+var classErrors = []struct {
+	class string
+	errs  []error
+}{
+	{class: ClassAuth, errs: []error{ErrAWSSSOTokenNotFound, ErrAWSSSOTokenExpired, ErrAWSSSOCredentials, ErrOIDCTokenNotFound, ErrOIDCToken, ErrGoogleFederatedToken}},
+	{class: ClassUnsupported, errs: []error{ErrProviderResourceNotSupported, ErrServerProviderNotSupported}},
+	{class: ClassWrite, errs: []error{
+		ErrWriterRequiredKey, ErrWriterRequiredValue, ErrWriterInvalidKey, ErrWriterInvalidTypeValue,
+		ErrWriterAlreadyExistsKey, ErrSOPSFailed, ErrSQLiteFailed,
+	}},
+}
+
+// classMessages are the messages of the errors of
+// the cloud providers of each class, as those are
+// not always returned as the errors of the SDKs
+var classMessages = []struct {
+	class    string
+	messages []string
+}{
+	{class: ClassAuth, messages: []string{
+		"UnrecognizedClientException",
+		"InvalidClientTokenId",
+		"InvalidAccessKeyId",
+		"SignatureDoesNotMatch",
+		"AuthFailure",
+		"ExpiredToken",
+		"InvalidIdentityToken",
+		"EmptyStaticCreds",
+		"NoCredentialProviders",
+		"googleapi: Error 401",
+		"could not find default credentials",
+	}},
+	{class: ClassPermission, messages: []string{
+		"AccessDenied",
+		"UnauthorizedOperation",
+		"AuthorizationError",
+		"googleapi: Error 403",
+	}},
+	{class: ClassThrottled, messages: []string{
+		"Throttling",
+		"RequestLimitExceeded",
+		"TooManyRequestsException",
+		"SlowDown",
+		"googleapi: Error 429",
+		"rateLimitExceeded",
+	}},
+	{class: ClassUnavailable, messages: []string{
+		"InternalError",
+		"InternalFailure",
+		"InternalServerError",
+		"ServiceUnavailable",
+		"status code: 5",
+		"googleapi: Error 500",
+		"googleapi: Error 502",
+		"googleapi: Error 503",
+		"googleapi: Error 504",
+	}},
+}
+
+// Class returns the class of the err, first by the
+// cause of it and then by the message, as the errors
+// are not always wrapped (ex: fmt.Errorf)
+func Class(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	cause := errors.Cause(err)
+	for _, ce := range classErrors {
+		for _, e := range ce.errs {
+			if cause == e {
+				return ce.class
+			}
+		}
+	}
+
+	msg := err.Error()
+	for _, cm := range classMessages {
+		for _, m := range cm.messages {
+			if strings.Contains(msg, m) {
+				return cm.class
+			}
+		}
+	}
+	for _, ce := range classErrors {
+		for _, e := range ce.errs {
+			if strings.Contains(msg, e.Error()) {
+				return ce.class
+			}
+		}
+	}
+
+	return ClassUnknown
+}
+
+// ExitCode returns the exit code of the process
+// for the err by the Class of it, 0 if it's nil
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return exitCodes[Class(err)]
+}
@@ -0,0 +1,37 @@
+package errcode_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cycloidio/terracognita/errcode"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClass(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Err   error
+		Class string
+		Code  int
+	}{
+		{Name: "Nil", Err: nil, Class: "", Code: 0},
+		{Name: "Unknown", Err: errors.New("some error"), Class: errcode.ClassUnknown, Code: 1},
+		{Name: "AuthMessage", Err: errors.New("InvalidClientTokenId: The security token included in the request is invalid"), Class: errcode.ClassAuth, Code: 3},
+		{Name: "AuthCause", Err: pkgerrors.Wrap(errcode.ErrAWSSSOTokenExpired, "could not read"), Class: errcode.ClassAuth, Code: 3},
+		{Name: "Permission", Err: errors.New("googleapi: Error 403: Required permission"), Class: errcode.ClassPermission, Code: 4},
+		{Name: "Throttled", Err: errors.New("Throttling: Rate exceeded"), Class: errcode.ClassThrottled, Code: 5},
+		{Name: "UnsupportedCause", Err: pkgerrors.Wrapf(errcode.ErrProviderResourceNotSupported, "resource %q", "aws_x"), Class: errcode.ClassUnsupported, Code: 6},
+		{Name: "Unavailable", Err: errors.New("ServiceUnavailable: Service is unavailable\n\tstatus code: 503, request id: 123"), Class: errcode.ClassUnavailable, Code: 8},
+		{Name: "WriteMessage", Err: fmt.Errorf("could not write: %s", errcode.ErrSOPSFailed), Class: errcode.ClassWrite, Code: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			assert.Equal(t, tt.Class, errcode.Class(tt.Err))
+			assert.Equal(t, tt.Code, errcode.ExitCode(tt.Err))
+		})
+	}
+}
@@ -8,6 +8,8 @@ var (
 	ErrProviderResourceNotRead       = errors.New("the resource did not return an ID")
 	ErrProviderResourceDoNotMatchTag = errors.New("the resource does not match the required tags")
 	ErrProviderResourceAutogenerated = errors.New("the resource is autogenerated and should not be imported")
+	ErrProviderResourceInvalidConfig = errors.New("the configuration of the resource is not valid")
+	ErrProviderResourceTimeout       = errors.New("the resource was not read on time")
 
 	ErrCacheKeyNotFound        = errors.New("the key used to search was not found")
 	ErrCacheKeyAlreadyExisting = errors.New("the key already exists on the cache")
@@ -17,4 +19,52 @@ var (
 	ErrWriterInvalidKey       = errors.New("invalid key")
 	ErrWriterInvalidTypeValue = errors.New("invalid type of value")
 	ErrWriterAlreadyExistsKey = errors.New("the key already exists")
+	ErrWriterExcludedKey      = errors.New("the key has been excluded by the writer")
+
+	ErrServerProviderNotSupported = errors.New("the provider is not supported")
+	ErrServerRequiredConfig       = errors.New("the config is required")
+	ErrServerJobNotFound          = errors.New("the job was not found")
+	ErrServerJobNotFinished       = errors.New("the job has not finished")
+	ErrServerJobNoOutput          = errors.New("the job has not requested this output")
+	ErrServerQueueFull            = errors.New("the queue of jobs is full")
+	ErrServerUnauthorized         = errors.New("the token is not valid")
+
+	ErrAWSSSOTokenNotFound = errors.New("the SSO token was not found on the cache")
+	ErrAWSSSOTokenExpired  = errors.New("the SSO token has expired")
+	ErrAWSSSOCredentials   = errors.New("the SSO credentials could not be retrieved")
+
+	ErrOIDCTokenNotFound    = errors.New("the OIDC token was not found on the CI")
+	ErrOIDCToken            = errors.New("the OIDC token could not be retrieved")
+	ErrGoogleFederatedToken = errors.New("the OIDC token could not be exchanged for a federated token")
+
+	ErrWatchNotifyFailed = errors.New("the notification was not accepted")
+
+	ErrNotifyFailed = errors.New("the import notification was not accepted")
+
+	ErrVerifyTerraformNotFound = errors.New("the terraform binary was not found")
+	ErrVerifyFailed            = errors.New("the terraform command failed")
+
+	ErrPolicyOPANotFound = errors.New("the opa binary was not found")
+	ErrPolicyEvalFailed  = errors.New("the opa evaluation failed")
+	ErrPolicyViolation   = errors.New("the resource violates the policies")
+
+	ErrSOPSNotFound = errors.New("the sops binary was not found")
+	ErrSOPSFailed   = errors.New("the sops command failed")
+
+	ErrSQLiteNotFound = errors.New("the sqlite3 binary was not found")
+	ErrSQLiteFailed   = errors.New("the sqlite3 command failed")
+
+	ErrGitNotFound           = errors.New("the git binary was not found")
+	ErrGitFailed             = errors.New("the git command failed")
+	ErrGitMergeRequestFailed = errors.New("the merge request was not opened")
+
+	ErrHistoryInvalidRun = errors.New("the run of the history is not valid")
+
+	ErrLockfileHashes = errors.New("the hashes of the provider could not be retrieved")
+
+	ErrEncryptInvalidKey     = errors.New("the key is not valid for the encrypted content")
+	ErrEncryptInvalidContent = errors.New("the content is not encrypted by terracognita")
+
+	ErrAuditNotReadOnly = errors.New("the call to the API is not read only")
+	ErrAuditNoClient    = errors.New("the HTTP client to audit was not found")
 )
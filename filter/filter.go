@@ -2,7 +2,13 @@ package filter
 
 import (
 	"fmt"
+	"path"
+	"regexp"
+	"strings"
 
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
 	"github.com/cycloidio/terracognita/tag"
 )
 
@@ -10,11 +16,35 @@ import (
 // filters that can be used to filter
 // the results
 type Filter struct {
-	Tags    []tag.Tag
+	Tags []tag.Tag
+
+	// Include and Exclude are resource types, glob
+	// patterns of them (ex: aws_iam_*) or negative
+	// patterns (ex: !aws_iam_user), see Expand
 	Include []string
 	Exclude []string
 
+	// Targets is the list of specific resources to import
+	// with the format 'TYPE.ID' (ex: aws_instance.i-123),
+	// if defined only those resources will be imported
+	Targets []string
+
+	// Rules are the filters of the resources of
+	// a type by the value of their attributes
+	Rules []Rule
+
+	// NameRegex filters the resources by the ID or
+	// the name of them, if defined only the ones
+	// matching it will be imported
+	NameRegex *regexp.Regexp
+
+	// Ignore is the list of the resources that are
+	// not imported with the format 'TYPE.NAME' and
+	// glob patterns, see Ignore.Resources
+	Ignore []string
+
 	exclude map[string]struct{}
+	targets map[string]map[string]struct{}
 }
 
 // IsExcluded checks if the v is on the Exclude list
@@ -31,13 +61,173 @@ func (f *Filter) IsExcluded(v string) bool {
 	return ok
 }
 
+// IsTargeted checks if the resource of type t and id
+// is on the Targets list, if no Targets are defined
+// all the resources are targeted
+func (f *Filter) IsTargeted(t, id string) bool {
+	if len(f.Targets) == 0 {
+		return true
+	}
+
+	if f.targets == nil {
+		f.calculateTargetsMap()
+	}
+
+	_, ok := f.targets[t][id]
+	return ok
+}
+
+// IsMatched checks if the resource of type t matches
+// all the Rules of the type, the get returns the
+// value of the attributes of the resource
+func (f *Filter) IsMatched(t string, get func(attribute string) string) bool {
+	for _, r := range f.Rules {
+		if r.Type != t {
+			continue
+		}
+		if !r.Match(get(r.Attribute)) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNameMatched checks if any of the names (the ID and the
+// name of the resource) matches the NameRegex, if no NameRegex
+// is defined all the resources are matched
+func (f *Filter) IsNameMatched(names ...string) bool {
+	if f.NameRegex == nil {
+		return true
+	}
+
+	for _, n := range names {
+		if n != "" && f.NameRegex.MatchString(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// TargetTypes returns the list of the types
+// that are on the Targets list
+func (f *Filter) TargetTypes() []string {
+	if f.targets == nil {
+		f.calculateTargetsMap()
+	}
+
+	types := make([]string, 0, len(f.targets))
+	for _, t := range f.Targets {
+		rt := strings.SplitN(t, ".", 2)[0]
+		if _, ok := f.targets[rt]; ok && !hasString(types, rt) {
+			types = append(types, rt)
+		}
+	}
+
+	return types
+}
+
+// Expand replaces the patterns of the Include and Exclude
+// with the types matching them. The glob patterns (ex: aws_iam_*)
+// are replaced with the matching types and the negative ones
+// (ex: !aws_iam_user) remove the matching types from the previous
+// ones, or from all the types if the Include has only negative ones
+func (f *Filter) Expand(types []string) error {
+	include, err := expandPatterns(f.Include, types, "Include")
+	if err != nil {
+		return err
+	}
+	if len(f.Include) != 0 && len(include) == 0 {
+		return errors.Wrapf(errcode.ErrProviderResourceNotSupported, "no types left on Include filter %s", f.Include)
+	}
+
+	exclude, err := expandPatterns(f.Exclude, types, "Exclude")
+	if err != nil {
+		return err
+	}
+
+	f.Include = include
+	f.Exclude = exclude
+	f.exclude = nil
+
+	return nil
+}
+
+// expandPatterns returns the patterns expanded
+// with the types, the name is the one of the filter
+func expandPatterns(patterns, types []string, name string) ([]string, error) {
+	res := make([]string, 0, len(patterns))
+
+	onlyNegative := len(patterns) != 0
+	for _, p := range patterns {
+		if !strings.HasPrefix(p, "!") {
+			onlyNegative = false
+		}
+	}
+	if onlyNegative {
+		res = append(res, types...)
+	}
+
+	for _, p := range patterns {
+		negative := strings.HasPrefix(p, "!")
+		p = strings.TrimPrefix(p, "!")
+
+		// The types which are not a pattern are kept
+		// as they are, as the type may not be on the
+		// types but supported (ex: deprecated types)
+		matches := []string{p}
+		if isPattern(p) {
+			matches = matches[:0]
+			for _, t := range types {
+				if ok, err := path.Match(p, t); err != nil {
+					return nil, errors.Wrapf(err, "invalid pattern %s on %s filter", p, name)
+				} else if ok {
+					matches = append(matches, t)
+				}
+			}
+			if len(matches) == 0 {
+				return nil, errors.Wrapf(errcode.ErrProviderResourceNotSupported, "pattern %s on %s filter", p, name)
+			}
+		}
+
+		for _, m := range matches {
+			if negative {
+				res = removeString(res, m)
+			} else if !hasString(res, m) {
+				res = append(res, m)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// HasPatterns checks if the Include or Exclude
+// have patterns that have to be expanded
+func (f *Filter) HasPatterns() bool {
+	for _, p := range append(append([]string{}, f.Include...), f.Exclude...) {
+		if strings.HasPrefix(p, "!") || isPattern(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPattern checks if the p is a glob pattern
+func isPattern(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
 // String returns an stringification of the Filter
 func (f *Filter) String() string {
 	return fmt.Sprintf(`
 	Tags:    %s,
 	Include: %s,
 	Exclude: %s,
-`, f.Tags, f.Include, f.Exclude)
+	Targets: %s,
+	Rules:   %s,
+	Name:    %s,
+	Ignore:  %s,
+`, f.Tags, f.Include, f.Exclude, f.Targets, f.Rules, f.NameRegex, f.Ignore)
 }
 
 // calculateExludeMap makes a map of the Exclude so
@@ -51,3 +241,44 @@ func (f *Filter) calculateExludeMap() {
 
 	f.exclude = aux
 }
+
+// calculateTargetsMap makes a map of the Targets
+// grouped by type so it's easy to operate over them
+func (f *Filter) calculateTargetsMap() {
+	aux := make(map[string]map[string]struct{})
+
+	for _, t := range f.Targets {
+		// The type can not have a '.' but the ID can
+		// so we only split by the first one
+		parts := strings.SplitN(t, ".", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		if _, ok := aux[parts[0]]; !ok {
+			aux[parts[0]] = make(map[string]struct{})
+		}
+		aux[parts[0]][parts[1]] = struct{}{}
+	}
+
+	f.targets = aux
+}
+
+func hasString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(ss []string, s string) []string {
+	res := ss[:0]
+	for _, v := range ss {
+		if v != s {
+			res = append(res, v)
+		}
+	}
+	return res
+}
@@ -1,10 +1,14 @@
 package filter_test
 
 import (
+	"regexp"
 	"testing"
 
+	"github.com/cycloidio/terracognita/errcode"
 	"github.com/cycloidio/terracognita/filter"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsExcluded(t *testing.T) {
@@ -17,3 +21,80 @@ func TestIsExcluded(t *testing.T) {
 		assert.False(t, f.IsExcluded("c"))
 	})
 }
+
+func TestIsTargeted(t *testing.T) {
+	t.Run("True", func(t *testing.T) {
+		f := filter.Filter{Targets: []string{"aws_instance.i-1", "aws_iam_user.pepito.name"}}
+		assert.True(t, f.IsTargeted("aws_instance", "i-1"))
+		assert.True(t, f.IsTargeted("aws_iam_user", "pepito.name"))
+	})
+	t.Run("TrueWithNoTargets", func(t *testing.T) {
+		f := filter.Filter{}
+		assert.True(t, f.IsTargeted("aws_instance", "i-1"))
+	})
+	t.Run("False", func(t *testing.T) {
+		f := filter.Filter{Targets: []string{"aws_instance.i-1"}}
+		assert.False(t, f.IsTargeted("aws_instance", "i-2"))
+		assert.False(t, f.IsTargeted("aws_vpc", "i-1"))
+	})
+}
+
+func TestTargetTypes(t *testing.T) {
+	f := filter.Filter{Targets: []string{"aws_instance.i-1", "aws_vpc.vpc-1", "aws_instance.i-2", "invalid"}}
+	assert.Equal(t, []string{"aws_instance", "aws_vpc"}, f.TargetTypes())
+}
+
+func TestExpand(t *testing.T) {
+	types := []string{"aws_instance", "aws_iam_user", "aws_iam_role", "aws_iam_policy", "aws_vpc"}
+
+	t.Run("Glob", func(t *testing.T) {
+		f := filter.Filter{Include: []string{"aws_vpc", "aws_iam_*"}, Exclude: []string{"aws_iam_r*"}}
+		require.NoError(t, f.Expand(types))
+		assert.Equal(t, []string{"aws_vpc", "aws_iam_user", "aws_iam_role", "aws_iam_policy"}, f.Include)
+		assert.Equal(t, []string{"aws_iam_role"}, f.Exclude)
+		assert.True(t, f.IsExcluded("aws_iam_role"))
+	})
+	t.Run("Negative", func(t *testing.T) {
+		f := filter.Filter{Include: []string{"aws_iam_*", "!aws_iam_user"}}
+		require.NoError(t, f.Expand(types))
+		assert.Equal(t, []string{"aws_iam_role", "aws_iam_policy"}, f.Include)
+	})
+	t.Run("OnlyNegative", func(t *testing.T) {
+		f := filter.Filter{Include: []string{"!aws_iam_*"}}
+		require.NoError(t, f.Expand(types))
+		assert.Equal(t, []string{"aws_instance", "aws_vpc"}, f.Include)
+	})
+	t.Run("NotPattern", func(t *testing.T) {
+		f := filter.Filter{Include: []string{"aws_alb"}}
+		assert.False(t, f.HasPatterns())
+		require.NoError(t, f.Expand(types))
+		assert.Equal(t, []string{"aws_alb"}, f.Include)
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		f := filter.Filter{Include: []string{"aws_s3_*"}}
+		assert.True(t, f.HasPatterns())
+		err := f.Expand(types)
+		assert.Equal(t, errcode.ErrProviderResourceNotSupported, errors.Cause(err))
+	})
+	t.Run("NoTypesLeft", func(t *testing.T) {
+		f := filter.Filter{Include: []string{"aws_iam_*", "!aws_iam_*"}}
+		err := f.Expand(types)
+		assert.Equal(t, errcode.ErrProviderResourceNotSupported, errors.Cause(err))
+	})
+}
+
+func TestIsNameMatched(t *testing.T) {
+	t.Run("NoNameRegex", func(t *testing.T) {
+		f := filter.Filter{}
+		assert.True(t, f.IsNameMatched("i-1"))
+	})
+	t.Run("True", func(t *testing.T) {
+		f := filter.Filter{NameRegex: regexp.MustCompile("^prod-")}
+		assert.True(t, f.IsNameMatched("prod-front"))
+		assert.True(t, f.IsNameMatched("i-1", "prod-front"))
+	})
+	t.Run("False", func(t *testing.T) {
+		f := filter.Filter{NameRegex: regexp.MustCompile("^prod-")}
+		assert.False(t, f.IsNameMatched("i-1", "", "staging-front"))
+	})
+}
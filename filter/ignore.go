@@ -0,0 +1,113 @@
+package filter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// IgnoreFile is the file with the Ignore patterns
+// read from the working directory if it exists
+const IgnoreFile = ".terracognitaignore"
+
+// Ignore are the patterns of the types and the
+// resources that are never imported, read from
+// an IgnoreFile with ParseIgnore
+type Ignore struct {
+	// Types are resource types or glob
+	// patterns of them (ex: aws_iam_*)
+	Types []string
+
+	// Resources are the resources with the format
+	// 'TYPE.NAME', both can be glob patterns and the
+	// NAME matches the ID or the name of the resource
+	// (ex: aws_instance.i-123, aws_s3_bucket.logs-*)
+	Resources []string
+}
+
+// ParseIgnore parses the r with the format of a .gitignore,
+// a pattern on each line and the empty ones and the ones
+// starting with '#' are skipped. The patterns with a '.'
+// are Resources and the rest Types
+func ParseIgnore(r io.Reader) (Ignore, error) {
+	var ig Ignore
+
+	s := bufio.NewScanner(r)
+	for l := 1; s.Scan(); l++ {
+		p := strings.TrimSpace(s.Text())
+		if p == "" || strings.HasPrefix(p, "#") {
+			continue
+		}
+
+		// The negative patterns of the Exclude are
+		// not supported as with only negative ones
+		// all the other types would be excluded
+		if strings.HasPrefix(p, "!") {
+			return Ignore{}, fmt.Errorf("invalid pattern %q on line %d, the negative patterns are not supported", p, l)
+		}
+
+		if _, err := path.Match(p, ""); err != nil {
+			return Ignore{}, errors.Wrapf(err, "invalid pattern %q on line %d", p, l)
+		}
+
+		if i := strings.Index(p, "."); i == -1 {
+			ig.Types = append(ig.Types, p)
+		} else if i == 0 || i == len(p)-1 {
+			return Ignore{}, fmt.Errorf("invalid pattern %q on line %d, the expected format is 'TYPE' or 'TYPE.NAME'", p, l)
+		} else {
+			ig.Resources = append(ig.Resources, p)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return Ignore{}, errors.Wrap(err, "could not read the ignore patterns")
+	}
+
+	return ig, nil
+}
+
+// MergeIgnore adds the ig to the f, the Types to the Exclude and the
+// Resources to the Ignore. Only the Types matching any of the types
+// are added, so the same IgnoreFile can be used with all the providers
+func (f *Filter) MergeIgnore(ig Ignore, types []string) {
+	for _, p := range ig.Types {
+		for _, t := range types {
+			if ok, _ := path.Match(p, t); ok {
+				if !hasString(f.Exclude, p) {
+					f.Exclude = append(f.Exclude, p)
+				}
+				break
+			}
+		}
+	}
+	f.exclude = nil
+
+	for _, p := range ig.Resources {
+		if !hasString(f.Ignore, p) {
+			f.Ignore = append(f.Ignore, p)
+		}
+	}
+}
+
+// IsIgnored checks if the resource of type t with the names
+// (the ID and the name of it) matches any of the Ignore
+func (f *Filter) IsIgnored(t string, names ...string) bool {
+	for _, ig := range f.Ignore {
+		parts := strings.SplitN(ig, ".", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if ok, _ := path.Match(parts[0], t); !ok {
+			continue
+		}
+		for _, n := range names {
+			if ok, _ := path.Match(parts[1], n); ok && n != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
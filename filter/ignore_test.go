@@ -0,0 +1,65 @@
+package filter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/filter"
+)
+
+func TestParseIgnore(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ig, err := filter.ParseIgnore(strings.NewReader(`
+# IAM is managed by other team
+aws_iam_*
+google_project_iam_member
+
+aws_instance.i-123
+  aws_s3_bucket.logs-*
+*.test-*
+`))
+		require.NoError(t, err)
+		assert.Equal(t, filter.Ignore{
+			Types:     []string{"aws_iam_*", "google_project_iam_member"},
+			Resources: []string{"aws_instance.i-123", "aws_s3_bucket.logs-*", "*.test-*"},
+		}, ig)
+	})
+	t.Run("ErrorNegative", func(t *testing.T) {
+		_, err := filter.ParseIgnore(strings.NewReader("!aws_iam_user"))
+		assert.EqualError(t, err, `invalid pattern "!aws_iam_user" on line 1, the negative patterns are not supported`)
+	})
+	t.Run("ErrorFormat", func(t *testing.T) {
+		_, err := filter.ParseIgnore(strings.NewReader("aws_instance\naws_instance."))
+		assert.EqualError(t, err, `invalid pattern "aws_instance." on line 2, the expected format is 'TYPE' or 'TYPE.NAME'`)
+	})
+	t.Run("ErrorPattern", func(t *testing.T) {
+		_, err := filter.ParseIgnore(strings.NewReader("aws_[iam"))
+		assert.Error(t, err)
+	})
+}
+
+func TestMergeIgnore(t *testing.T) {
+	f := filter.Filter{Exclude: []string{"aws_vpc"}}
+	f.MergeIgnore(filter.Ignore{
+		Types:     []string{"aws_iam_*", "aws_vpc", "google_project_iam_member"},
+		Resources: []string{"aws_instance.i-123", "*.test-*"},
+	}, []string{"aws_iam_user", "aws_instance", "aws_vpc"})
+
+	assert.Equal(t, []string{"aws_vpc", "aws_iam_*"}, f.Exclude)
+	assert.Equal(t, []string{"aws_instance.i-123", "*.test-*"}, f.Ignore)
+	assert.True(t, f.IsExcluded("aws_vpc"))
+}
+
+func TestIsIgnored(t *testing.T) {
+	f := filter.Filter{Ignore: []string{"aws_instance.i-123", "aws_s3_bucket.logs-*", "*.test-*"}}
+
+	assert.True(t, f.IsIgnored("aws_instance", "i-123"))
+	assert.True(t, f.IsIgnored("aws_s3_bucket", "bucket", "logs-eu"))
+	assert.True(t, f.IsIgnored("aws_vpc", "vpc-1", "test-vpc"))
+	assert.False(t, f.IsIgnored("aws_instance", "i-456", ""))
+	assert.False(t, f.IsIgnored("aws_vpc", "logs-eu"))
+	assert.False(t, (&filter.Filter{}).IsIgnored("aws_instance", "i-123"))
+}
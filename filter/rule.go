@@ -0,0 +1,119 @@
+package filter
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Operator is the comparison of the Rule
+type Operator string
+
+// List of all the Operators
+const (
+	// Equal matches if the value is the same
+	Equal Operator = "="
+	// NotEqual matches if the value is not the same
+	NotEqual Operator = "!="
+	// Like matches if the value matches the glob pattern
+	Like Operator = "~"
+)
+
+// Rule is a filter of the resources of a Type by
+// the value of an Attribute, evaluated once read
+type Rule struct {
+	Type      string
+	Attribute string
+	Operator  Operator
+	Value     string
+}
+
+// ParseRule parses the r with the format 'TYPE: ATTRIBUTE OPERATOR VALUE'
+// (ex: 'aws_instance: tags.env=prod' or 'aws_s3_bucket: bucket~"logs-*"'),
+// the value can be quoted
+func ParseRule(r string) (Rule, error) {
+	parts := strings.SplitN(r, ":", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return Rule{}, fmt.Errorf("invalid filter %q, the expected format is 'TYPE: ATTRIBUTE=VALUE'", r)
+	}
+
+	rule := Rule{Type: strings.TrimSpace(parts[0])}
+	expr := strings.TrimSpace(parts[1])
+
+	// The first operator is the one used
+	// as the value may also have them
+	for i, c := range expr {
+		var op Operator
+		switch {
+		case strings.HasPrefix(expr[i:], string(NotEqual)):
+			op = NotEqual
+		case c == '=':
+			op = Equal
+		case c == '~':
+			op = Like
+		default:
+			continue
+		}
+
+		rule.Attribute = strings.TrimSpace(expr[:i])
+		rule.Operator = op
+		rule.Value = strings.TrimSpace(expr[i+len(op):])
+		break
+	}
+
+	if rule.Operator == "" || rule.Attribute == "" {
+		return Rule{}, fmt.Errorf("invalid filter %q, the expression has to be 'ATTRIBUTE=VALUE', 'ATTRIBUTE!=VALUE' or 'ATTRIBUTE~PATTERN'", r)
+	}
+
+	if strings.HasPrefix(rule.Value, `"`) {
+		v, err := strconv.Unquote(rule.Value)
+		if err != nil {
+			return Rule{}, errors.Wrapf(err, "invalid value of the filter %q", r)
+		}
+		rule.Value = v
+	}
+
+	if rule.Operator == Like {
+		if _, err := path.Match(rule.Value, ""); err != nil {
+			return Rule{}, errors.Wrapf(err, "invalid pattern of the filter %q", r)
+		}
+	}
+
+	return rule, nil
+}
+
+// ParseRules parses all the rs, see ParseRule
+func ParseRules(rs []string) ([]Rule, error) {
+	rules := make([]Rule, 0, len(rs))
+	for _, r := range rs {
+		rule, err := ParseRule(r)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Match checks if the v of the Attribute matches the Rule
+func (r Rule) Match(v string) bool {
+	switch r.Operator {
+	case Equal:
+		return v == r.Value
+	case NotEqual:
+		return v != r.Value
+	case Like:
+		ok, _ := path.Match(r.Value, v)
+		return ok
+	}
+	return false
+}
+
+// String returns the Rule with the
+// same format that it's parsed
+func (r Rule) String() string {
+	return fmt.Sprintf("%s: %s%s%q", r.Type, r.Attribute, r.Operator, r.Value)
+}
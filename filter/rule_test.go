@@ -0,0 +1,68 @@
+package filter_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		Name string
+		Rule string
+		Exp  filter.Rule
+	}{
+		{
+			Name: "Equal",
+			Rule: "aws_instance: tags.env=prod",
+			Exp:  filter.Rule{Type: "aws_instance", Attribute: "tags.env", Operator: filter.Equal, Value: "prod"},
+		},
+		{
+			Name: "NotEqual",
+			Rule: "aws_instance: instance_type != t2.micro",
+			Exp:  filter.Rule{Type: "aws_instance", Attribute: "instance_type", Operator: filter.NotEqual, Value: "t2.micro"},
+		},
+		{
+			Name: "LikeQuoted",
+			Rule: `aws_s3_bucket: bucket~"logs-*"`,
+			Exp:  filter.Rule{Type: "aws_s3_bucket", Attribute: "bucket", Operator: filter.Like, Value: "logs-*"},
+		},
+		{
+			Name: "OperatorOnValue",
+			Rule: `aws_s3_bucket: bucket="a~b=c"`,
+			Exp:  filter.Rule{Type: "aws_s3_bucket", Attribute: "bucket", Operator: filter.Equal, Value: "a~b=c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			r, err := filter.ParseRule(tt.Rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.Exp, r)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, r := range []string{"tags.env=prod", ": tags.env=prod", "aws_instance: tags.env", `aws_instance: name="prod`, "aws_instance: name~[a"} {
+			_, err := filter.ParseRule(r)
+			assert.Error(t, err, r)
+		}
+	})
+}
+
+func TestIsMatched(t *testing.T) {
+	rules, err := filter.ParseRules([]string{"aws_instance: tags.env=prod", "aws_instance: tags.Name~front-*"})
+	require.NoError(t, err)
+	f := filter.Filter{Rules: rules}
+
+	get := func(attrs map[string]string) func(string) string {
+		return func(k string) string { return attrs[k] }
+	}
+
+	assert.True(t, f.IsMatched("aws_instance", get(map[string]string{"tags.env": "prod", "tags.Name": "front-1"})))
+	assert.False(t, f.IsMatched("aws_instance", get(map[string]string{"tags.env": "prod", "tags.Name": "back-1"})))
+	assert.False(t, f.IsMatched("aws_instance", get(map[string]string{"tags.Name": "front-1"})))
+	assert.True(t, f.IsMatched("aws_vpc", get(map[string]string{})))
+}
@@ -0,0 +1,5 @@
+// Package findings has a built-in set of Rules of
+// common misconfigurations (ex: public S3 buckets)
+// evaluated on the resources imported, so the
+// Findings can be exported as SARIF or JSON
+package findings
@@ -0,0 +1,110 @@
+package findings_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/findings"
+	"github.com/cycloidio/terracognita/mock"
+)
+
+func TestScanner(t *testing.T) {
+	t.Run("Rules", func(t *testing.T) {
+		s := findings.NewScanner()
+
+		s.Check("aws_s3_bucket.public", map[string]interface{}{"acl": "public-read"})
+		s.Check("aws_s3_bucket.policy", map[string]interface{}{
+			"acl":    "private",
+			"policy": `{"Statement": [{"Effect": "Allow", "Principal": {"AWS": "*"}, "Action": "s3:GetObject"}]}`,
+		})
+		s.Check("aws_s3_bucket.private", map[string]interface{}{"acl": "private"})
+		s.Check("aws_security_group.open", map[string]interface{}{
+			"ingress": []interface{}{
+				map[string]interface{}{"from_port": 22, "to_port": 22, "cidr_blocks": []interface{}{"0.0.0.0/0"}},
+				map[string]interface{}{"from_port": 443, "to_port": 443, "cidr_blocks": []interface{}{"10.0.0.0/8"}},
+			},
+		})
+		s.Check("aws_security_group_rule.egress", map[string]interface{}{"type": "egress", "cidr_blocks": []interface{}{"0.0.0.0/0"}})
+		s.Check("google_compute_firewall.open", map[string]interface{}{"source_ranges": []interface{}{"0.0.0.0/0"}})
+		s.Check("aws_ebs_volume.plain", map[string]interface{}{"size": 8})
+		s.Check("aws_ebs_volume.encrypted", map[string]interface{}{"size": 8, "encrypted": true})
+		s.Check("data.aws_ebs_volume.plain", map[string]interface{}{"id": "vol-123"})
+		s.Check("variable.name", map[string]interface{}{"acl": "public-read"})
+
+		assert.Equal(t, []findings.Finding{
+			{RuleID: "TC003", Level: "warning", Key: "aws_ebs_volume.plain", Message: "the storage is not encrypted"},
+			{RuleID: "TC001", Level: "error", Key: "aws_s3_bucket.policy", Message: "the policy allows access to any principal"},
+			{RuleID: "TC001", Level: "error", Key: "aws_s3_bucket.public", Message: `the ACL "public-read" gives access to everyone`},
+			{RuleID: "TC002", Level: "warning", Key: "aws_security_group.open", Message: "the ingress on port 22 is open to 0.0.0.0/0"},
+			{RuleID: "TC002", Level: "warning", Key: "google_compute_firewall.open", Message: "the ingress is open to 0.0.0.0/0"},
+		}, s.Findings())
+	})
+
+	t.Run("Middleware", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var (
+			w   = mock.NewWriter(ctrl)
+			s   = findings.NewScanner()
+			cfg = map[string]interface{}{"acl": "public-read"}
+		)
+
+		w.EXPECT().Write("aws_s3_bucket.public", cfg).Return(nil)
+
+		require.NoError(t, s.Middleware()(w).Write("aws_s3_bucket.public", cfg))
+		assert.Len(t, s.Findings(), 1)
+	})
+
+	t.Run("WriteSARIF", func(t *testing.T) {
+		var (
+			s   = findings.NewScanner()
+			out = &bytes.Buffer{}
+		)
+
+		s.Check("aws_s3_bucket.public", map[string]interface{}{"acl": "public-read"})
+		require.NoError(t, s.WriteSARIF(out, "v1.0.0"))
+
+		var log struct {
+			Version string
+			Runs    []struct {
+				Tool struct {
+					Driver struct {
+						Version string
+						Rules   []struct{ ID string }
+					}
+				}
+				Results []struct {
+					RuleID    string
+					Locations []struct {
+						LogicalLocations []struct{ FullyQualifiedName string }
+					}
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+
+		assert.Equal(t, "2.1.0", log.Version)
+		require.Len(t, log.Runs, 1)
+		assert.Equal(t, "v1.0.0", log.Runs[0].Tool.Driver.Version)
+		assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(findings.Rules))
+		require.Len(t, log.Runs[0].Results, 1)
+		assert.Equal(t, "TC001", log.Runs[0].Results[0].RuleID)
+		assert.Equal(t, "aws_s3_bucket.public", log.Runs[0].Results[0].Locations[0].LogicalLocations[0].FullyQualifiedName)
+	})
+
+	t.Run("WriteJSON", func(t *testing.T) {
+		var (
+			s   = findings.NewScanner()
+			out = &bytes.Buffer{}
+		)
+
+		require.NoError(t, s.WriteJSON(out))
+		assert.Equal(t, "[]\n", out.String())
+	})
+}
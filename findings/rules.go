@@ -0,0 +1,214 @@
+package findings
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Rule is a misconfiguration checked
+// on the resources of the Types
+type Rule struct {
+	// ID is the unique identifier of the Rule
+	ID string
+
+	// Description is a short description of
+	// the misconfiguration checked by the Rule
+	Description string
+
+	// Level is the SARIF level of the
+	// Findings: error, warning or note
+	Level string
+
+	// Types are the resource types checked
+	Types []string
+
+	// Check returns the messages of the misconfigurations
+	// of the configuration, none if it's valid
+	Check func(cfg map[string]interface{}) []string
+}
+
+// Rules are the built-in Rules
+var Rules = []Rule{
+	{
+		ID:          "TC001",
+		Description: "S3 bucket publicly accessible",
+		Level:       "error",
+		Types:       []string{"aws_s3_bucket"},
+		Check:       publicBucket,
+	},
+	{
+		ID:          "TC002",
+		Description: "Ingress open to the world (0.0.0.0/0 or ::/0)",
+		Level:       "warning",
+		Types:       []string{"aws_security_group", "aws_security_group_rule", "google_compute_firewall"},
+		Check:       openIngress,
+	},
+	{
+		ID:          "TC003",
+		Description: "Storage not encrypted",
+		Level:       "warning",
+		Types:       []string{"aws_ebs_volume", "aws_db_instance", "aws_rds_cluster"},
+		Check:       unencrypted,
+	},
+}
+
+// publicACLs are the canned ACLs of the S3
+// buckets that give access to everyone
+var publicACLs = map[string]struct{}{
+	"public-read":        struct{}{},
+	"public-read-write":  struct{}{},
+	"authenticated-read": struct{}{},
+}
+
+// publicBucket checks the ACL and the policy of the
+// bucket, the policy is public if any of the Allow
+// statements has the '*' principal
+func publicBucket(cfg map[string]interface{}) []string {
+	var msgs []string
+	if acl := fmt.Sprint(get(cfg, "acl")); acl != "" {
+		if _, ok := publicACLs[acl]; ok {
+			msgs = append(msgs, fmt.Sprintf("the ACL %q gives access to everyone", acl))
+		}
+	}
+
+	if p, ok := get(cfg, "policy").(string); ok && p != "" {
+		var doc struct {
+			Statement []struct {
+				Effect    string
+				Principal interface{}
+			}
+		}
+		if json.Unmarshal([]byte(p), &doc) == nil {
+			for _, s := range doc.Statement {
+				if s.Effect == "Allow" && isWildcardPrincipal(s.Principal) {
+					msgs = append(msgs, "the policy allows access to any principal")
+					break
+				}
+			}
+		}
+	}
+
+	return msgs
+}
+
+// isWildcardPrincipal checks if the p of a
+// policy statement is '*' or {"AWS": "*"}
+func isWildcardPrincipal(p interface{}) bool {
+	switch pp := p.(type) {
+	case string:
+		return pp == "*"
+	case map[string]interface{}:
+		for _, v := range pp {
+			if isWildcardPrincipal(v) {
+				return true
+			}
+			if vs, ok := v.([]interface{}); ok {
+				for _, vv := range vs {
+					if isWildcardPrincipal(vv) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// worldCIDRs are the CIDRs of all the addresses
+var worldCIDRs = map[string]struct{}{
+	"0.0.0.0/0": struct{}{},
+	"::/0":      struct{}{},
+}
+
+// openIngress checks the ingress blocks of the security
+// groups, the ingress security group rules and the
+// ingress firewalls with the CIDRs of all the addresses
+func openIngress(cfg map[string]interface{}) []string {
+	var msgs []string
+
+	// The rules and firewalls are a single ingress
+	// and the security groups have the blocks
+	ingress := []interface{}{cfg}
+	if t := get(cfg, "type"); t != nil && t != "ingress" {
+		return nil
+	}
+	if d := get(cfg, "direction"); d != nil && d != "INGRESS" {
+		return nil
+	}
+	if i, ok := get(cfg, "ingress").([]interface{}); ok {
+		ingress = i
+	}
+
+	for _, i := range ingress {
+		b, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, k := range []string{"cidr_blocks", "ipv6_cidr_blocks", "source_ranges"} {
+			cidrs, _ := get(b, k).([]interface{})
+			for _, c := range cidrs {
+				if _, ok := worldCIDRs[fmt.Sprint(c)]; ok {
+					msgs = append(msgs, fmt.Sprintf("the ingress%s is open to %s", ports(b), c))
+				}
+			}
+		}
+	}
+
+	return msgs
+}
+
+// ports returns the ports of the
+// ingress b, if it has them
+func ports(b map[string]interface{}) string {
+	from, to := get(b, "from_port"), get(b, "to_port")
+	if from == nil && to == nil {
+		return ""
+	}
+	if fmt.Sprint(from) == fmt.Sprint(to) {
+		return fmt.Sprintf(" on port %v", from)
+	}
+	return fmt.Sprintf(" on ports %v-%v", from, to)
+}
+
+// unencrypted checks the encryption of the volumes
+// and databases, the attributes with a false value
+// are not on the configurations
+func unencrypted(cfg map[string]interface{}) []string {
+	for _, k := range []string{"encrypted", "storage_encrypted"} {
+		if fmt.Sprint(get(cfg, k)) == "true" {
+			return nil
+		}
+	}
+	return []string{"the storage is not encrypted"}
+}
+
+// get returns the value of the attribute k of the
+// cfg, also if it's a map (prefixed with '=tc=')
+func get(cfg map[string]interface{}, k string) interface{} {
+	if v, ok := cfg[k]; ok {
+		return v
+	}
+	return cfg["=tc="+k]
+}
+
+// hasType checks if the t is on the types
+func hasType(types []string, t string) bool {
+	for _, tt := range types {
+		if tt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// address returns the type and the name of the key of a
+// resource (ex: aws_instance.name), it's false if the key
+// is of other block (ex: data sources or variables)
+func address(key string) (string, string, bool) {
+	keys := strings.Split(key, ".")
+	if len(keys) != 2 || keys[0] == "variable" {
+		return "", "", false
+	}
+	return keys[0], keys[1], true
+}
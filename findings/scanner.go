@@ -0,0 +1,100 @@
+package findings
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// Finding is a misconfiguration found on a resource
+type Finding struct {
+	RuleID  string `json:"rule_id"`
+	Level   string `json:"level"`
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
+// Scanner checks the Rules on the
+// resources written to the HCL
+type Scanner struct {
+	rules []Rule
+
+	mx       sync.Mutex
+	findings []Finding
+}
+
+// NewScanner returns a Scanner of the rules,
+// if none are given the built-in Rules are used
+func NewScanner(rules ...Rule) *Scanner {
+	if len(rules) == 0 {
+		rules = Rules
+	}
+	return &Scanner{rules: rules}
+}
+
+// Middleware returns the writer.Middleware of the HCL that checks
+// the resources written, the writes are not changed
+func (s *Scanner) Middleware() writer.Middleware {
+	return writer.InterceptWrite(func(key string, value interface{}) (interface{}, error) {
+		s.Check(key, value)
+		return value, nil
+	})
+}
+
+// Check checks the Rules of the type of the key on the value,
+// if it's the configuration of a resource, and adds the Findings
+func (s *Scanner) Check(key string, value interface{}) {
+	cfg, ok := value.(map[string]interface{})
+	t, _, rok := address(key)
+	if !ok || !rok {
+		return
+	}
+
+	for _, r := range s.rules {
+		if !hasType(r.Types, t) {
+			continue
+		}
+		for _, m := range r.Check(cfg) {
+			s.add(Finding{RuleID: r.ID, Level: r.Level, Key: key, Message: m})
+		}
+	}
+}
+
+// Findings returns the Findings sorted by the key and rule
+func (s *Scanner) Findings() []Finding {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	res := append([]Finding{}, s.findings...)
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Key != res[j].Key {
+			return res[i].Key < res[j].Key
+		}
+		return res[i].RuleID < res[j].RuleID
+	})
+
+	return res
+}
+
+// WriteJSON writes the Findings as a JSON list to the w
+func (s *Scanner) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s.Findings()); err != nil {
+		return errors.Wrap(err, "unable to write the findings")
+	}
+	return nil
+}
+
+// add adds the f to the findings
+func (s *Scanner) add(f Finding) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.findings = append(s.findings, f)
+}
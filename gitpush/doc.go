@@ -0,0 +1,4 @@
+// Package gitpush commits the files generated by an import to a
+// branch of a Git repository and opens a merge request of it on
+// GitHub or GitLab, so the scheduled imports can be reviewed
+package gitpush
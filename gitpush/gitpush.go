@@ -0,0 +1,300 @@
+package gitpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// DefaultBinary is the git binary used
+// if none is given, searched on the PATH
+const DefaultBinary = "git"
+
+// List of the defaults of the Options
+const (
+	DefaultBranch      = "terracognita"
+	DefaultBase        = "main"
+	DefaultMessage     = "Import the resources with terracognita"
+	DefaultAuthorName  = "terracognita"
+	DefaultAuthorEmail = "terracognita@localhost"
+)
+
+// Options are the configurations of the Push
+type Options struct {
+	// Repository is the URL of the
+	// repository to clone and push to
+	Repository string
+
+	// Branch is the branch the files are committed to, it's
+	// created from the Base if it does not exist on the Repository
+	Branch string
+	Base   string
+
+	// Path is the directory of the Repository
+	// where the files are written, the root if empty
+	Path string
+
+	// Message is the message of the commit
+	// and the author of it
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+
+	// Token, if set, is used as the password of the HTTPS
+	// Repository (ex: a GitHub or GitLab access token)
+	Token string
+}
+
+// withDefaults returns the o with the
+// default values of the ones not set
+func (o Options) withDefaults() Options {
+	if o.Branch == "" {
+		o.Branch = DefaultBranch
+	}
+	if o.Base == "" {
+		o.Base = DefaultBase
+	}
+	if o.Message == "" {
+		o.Message = DefaultMessage
+	}
+	if o.AuthorName == "" {
+		o.AuthorName = DefaultAuthorName
+	}
+	if o.AuthorEmail == "" {
+		o.AuthorEmail = DefaultAuthorEmail
+	}
+	return o
+}
+
+// Result is the result of the Push
+type Result struct {
+	// Changed is false if the files were already
+	// on the Branch, so nothing was committed
+	Changed bool
+
+	// Commit is the hash of the commit pushed
+	Commit string
+}
+
+// Push clones the Branch, or the Base if it does not exist, of the
+// Repository with the bin, copies the files (or directories) to the
+// Path of it and commits and pushes them to the Branch if those
+// have changed
+func Push(ctx context.Context, bin string, opt Options, files []string) (*Result, error) {
+	if bin == "" {
+		bin = DefaultBinary
+	}
+	opt = opt.withDefaults()
+
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return nil, errors.Wrapf(errcode.ErrGitNotFound, "%s: %s", bin, err)
+	}
+
+	dir, err := ioutil.TempDir("", "terracognita-git")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the workspace")
+	}
+	defer os.RemoveAll(dir)
+
+	env, err := authEnv(opt.Repository, opt.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &git{bin: path, dir: dir, token: opt.Token, env: env}
+
+	if _, err := g.run(ctx, "init", "-q"); err != nil {
+		return nil, err
+	}
+	if _, err := g.run(ctx, "remote", "add", "origin", opt.Repository); err != nil {
+		return nil, err
+	}
+
+	// The Branch is updated if it already exists,
+	// if not it's created from the Base
+	if _, err := g.run(ctx, "fetch", "-q", "--depth", "1", "origin", opt.Branch); err != nil {
+		if _, err := g.run(ctx, "fetch", "-q", "--depth", "1", "origin", opt.Base); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := g.run(ctx, "checkout", "-q", "-B", opt.Branch, "FETCH_HEAD"); err != nil {
+		return nil, err
+	}
+
+	dst := filepath.Join(dir, opt.Path)
+	for _, f := range files {
+		if err := copyPath(f, filepath.Join(dst, filepath.Base(f))); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := g.run(ctx, "add", "-A"); err != nil {
+		return nil, err
+	}
+
+	changed, err := g.changed(ctx)
+	if err != nil {
+		return nil, err
+	} else if !changed {
+		return &Result{}, nil
+	}
+
+	_, err = g.run(ctx, "-c", "user.name="+opt.AuthorName, "-c", "user.email="+opt.AuthorEmail, "commit", "-q", "-m", opt.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := g.run(ctx, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := g.run(ctx, "push", "-q", "origin", "HEAD:refs/heads/"+opt.Branch); err != nil {
+		return nil, err
+	}
+
+	return &Result{Changed: true, Commit: strings.TrimSpace(commit)}, nil
+}
+
+// git runs the commands of the bin on the dir
+// with the env, which has the authentication
+type git struct {
+	bin   string
+	dir   string
+	token string
+	env   []string
+}
+
+// run runs the git command with the args and returns the
+// output of it, the errors have the output without the token
+func (g *git) run(ctx context.Context, args ...string) (string, error) {
+	var out, stderr bytes.Buffer
+
+	cmd := g.command(ctx, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", g.failed(args[0], err, stderr.String())
+	}
+
+	return out.String(), nil
+}
+
+// changed checks if there are changes on the index, 'git diff
+// --cached --quiet' exits with 1 if there are and with 0 if
+// not, any other exit code is an error
+func (g *git) changed(ctx context.Context) (bool, error) {
+	var stderr bytes.Buffer
+
+	cmd := g.command(ctx, "diff", "--cached", "--quiet")
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err == nil {
+		return false, nil
+	}
+
+	if ee, ok := err.(*exec.ExitError); ok {
+		if ws, ok := ee.Sys().(syscall.WaitStatus); ok && ws.ExitStatus() == 1 {
+			return true, nil
+		}
+	}
+
+	return false, g.failed("diff", err, stderr.String())
+}
+
+// command returns the git command with the args
+func (g *git) command(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, g.bin, args...)
+	cmd.Dir = g.dir
+	// The credentials are never asked, so
+	// it fails instead of waiting for them
+	cmd.Env = append(append(os.Environ(), "GIT_TERMINAL_PROMPT=0"), g.env...)
+	return cmd
+}
+
+// failed returns the err of the command with the
+// output msg of it without the token
+func (g *git) failed(cmd string, err error, msg string) error {
+	if g.token != "" {
+		msg = strings.Replace(msg, g.token, "***", -1)
+	}
+	return errors.Wrapf(errcode.ErrGitFailed, "%s: %s\n%s", cmd, err, msg)
+}
+
+// authEnv returns the environment to authenticate to the HTTPS repo
+// with the token as the password, sent as the 'Authorization' header
+// of the requests to the host of it so the token is not on the
+// arguments of the commands nor on the '.git/config'. The other URLs
+// (ex: SSH) or the ones with the user already have no environment
+func authEnv(repo, token string) ([]string, error) {
+	if token == "" || !strings.HasPrefix(repo, "https://") {
+		return nil, nil
+	}
+
+	u, err := url.Parse(repo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid repository %q", repo)
+	}
+	if u.User != nil {
+		return nil, nil
+	}
+
+	// GitLab requires this user for the tokens
+	// and GitHub accepts any of them
+	auth := base64.StdEncoding.EncodeToString([]byte("oauth2:" + token))
+
+	return []string{
+		"GIT_CONFIG_COUNT=1",
+		fmt.Sprintf("GIT_CONFIG_KEY_0=http.%s://%s/.extraHeader", u.Scheme, u.Host),
+		"GIT_CONFIG_VALUE_0=Authorization: Basic " + auth,
+	}, nil
+}
+
+// copyPath copies the src file, or directory
+// with all the files on it, to the dst
+func copyPath(src, dst string) error {
+	return filepath.Walk(src, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Wrapf(err, "unable to read %s", p)
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if fi.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return errors.Wrapf(err, "unable to create %s", filepath.Dir(target))
+		}
+
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return errors.Wrapf(err, "unable to read %s", p)
+		}
+		if err := ioutil.WriteFile(target, b, 0644); err != nil {
+			return errors.Wrapf(err, "unable to write %s", target)
+		}
+
+		return nil
+	})
+}
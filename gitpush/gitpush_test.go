@@ -0,0 +1,155 @@
+package gitpush_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/gitpush"
+)
+
+// bareRepository creates a bare repository with a
+// commit on the main branch and returns the path of it
+func bareRepository(t *testing.T) (string, func()) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("the git binary is required")
+	}
+
+	dir, err := ioutil.TempDir("", "terracognita-gitpush-test")
+	require.NoError(t, err)
+
+	repo := filepath.Join(dir, "repo.git")
+	work := filepath.Join(dir, "work")
+	for _, args := range [][]string{
+		{"init", "-q", "--bare", repo},
+		{"init", "-q", work},
+		{"-C", work, "checkout", "-q", "-b", "main"},
+		{"-C", work, "-c", "user.name=test", "-c", "user.email=test@localhost", "commit", "-q", "--allow-empty", "-m", "Initial"},
+		{"-C", work, "push", "-q", repo, "main"},
+	} {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	return repo, func() { os.RemoveAll(dir) }
+}
+
+// show returns the content of the file on the branch of the repo
+func show(t *testing.T, repo, branch, file string) string {
+	out, err := exec.Command("git", "--git-dir", repo, "show", branch+":"+file).CombinedOutput()
+	require.NoError(t, err, string(out))
+	return string(out)
+}
+
+// fakeGit creates a git binary that logs the arguments and the
+// GIT_CONFIG_* environment of each call to the log and exits with
+// the code of the 'diff', it returns the path of the binary and log
+func fakeGit(t *testing.T, diff int) (string, string, func()) {
+	dir, err := ioutil.TempDir("", "terracognita-gitpush-bin")
+	require.NoError(t, err)
+
+	bin := filepath.Join(dir, "git")
+	log := filepath.Join(dir, "log")
+	script := fmt.Sprintf(`#!/bin/sh
+echo "$@" >> %[1]s
+env | grep '^GIT_CONFIG_' | sort >> %[1]s
+if [ "$1" = "diff" ]; then
+  exit %[2]d
+fi
+`, log, diff)
+	require.NoError(t, ioutil.WriteFile(bin, []byte(script), 0755))
+
+	return bin, log, func() { os.RemoveAll(dir) }
+}
+
+func TestPush(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		repo, clean := bareRepository(t)
+		defer clean()
+
+		dir, err := ioutil.TempDir("", "terracognita-gitpush-files")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		hcl := filepath.Join(dir, "main.tf")
+		require.NoError(t, ioutil.WriteFile(hcl, []byte(`resource "aws_vpc" "main" {}`), 0644))
+		stacks := filepath.Join(dir, "stacks")
+		require.NoError(t, os.MkdirAll(filepath.Join(stacks, "network"), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(stacks, "network", "main.tf"), []byte(`resource "aws_subnet" "main" {}`), 0644))
+
+		opt := gitpush.Options{Repository: repo, Path: "imports"}
+
+		res, err := gitpush.Push(ctx, "", opt, []string{hcl, stacks})
+		require.NoError(t, err)
+		assert.True(t, res.Changed)
+		assert.NotEmpty(t, res.Commit)
+
+		assert.Equal(t, `resource "aws_vpc" "main" {}`, show(t, repo, gitpush.DefaultBranch, "imports/main.tf"))
+		assert.Equal(t, `resource "aws_subnet" "main" {}`, show(t, repo, gitpush.DefaultBranch, "imports/stacks/network/main.tf"))
+
+		// The second one updates the branch,
+		// which has already the same files
+		res, err = gitpush.Push(ctx, "", opt, []string{hcl, stacks})
+		require.NoError(t, err)
+		assert.False(t, res.Changed)
+
+		require.NoError(t, ioutil.WriteFile(hcl, []byte(`resource "aws_vpc" "other" {}`), 0644))
+		res, err = gitpush.Push(ctx, "", opt, []string{hcl})
+		require.NoError(t, err)
+		assert.True(t, res.Changed)
+		assert.Equal(t, `resource "aws_vpc" "other" {}`, show(t, repo, gitpush.DefaultBranch, "imports/main.tf"))
+	})
+
+	t.Run("SuccessToken", func(t *testing.T) {
+		bin, log, clean := fakeGit(t, 1)
+		defer clean()
+
+		opt := gitpush.Options{Repository: "https://git.example.com/org/repo.git", Token: "secret"}
+
+		res, err := gitpush.Push(ctx, bin, opt, nil)
+		require.NoError(t, err)
+		assert.True(t, res.Changed)
+
+		b, err := ioutil.ReadFile(log)
+		require.NoError(t, err)
+
+		calls := string(b)
+		assert.Contains(t, calls, "remote add origin https://git.example.com/org/repo.git\n")
+		assert.Contains(t, calls, "GIT_CONFIG_KEY_0=http.https://git.example.com/.extraHeader\n")
+		assert.Contains(t, calls, "GIT_CONFIG_VALUE_0=Authorization: Basic "+base64.StdEncoding.EncodeToString([]byte("oauth2:secret"))+"\n")
+		assert.NotContains(t, calls, "secret")
+	})
+
+	t.Run("ErrorDiff", func(t *testing.T) {
+		bin, _, clean := fakeGit(t, 128)
+		defer clean()
+
+		_, err := gitpush.Push(ctx, bin, gitpush.Options{Repository: "repo"}, nil)
+		assert.Equal(t, errcode.ErrGitFailed, errors.Cause(err))
+	})
+
+	t.Run("ErrorFailed", func(t *testing.T) {
+		repo, clean := bareRepository(t)
+		defer clean()
+
+		_, err := gitpush.Push(ctx, "", gitpush.Options{Repository: repo, Base: "not-found"}, nil)
+		assert.Equal(t, errcode.ErrGitFailed, errors.Cause(err))
+	})
+
+	t.Run("ErrorNotFound", func(t *testing.T) {
+		_, err := gitpush.Push(ctx, "/not/found/git", gitpush.Options{Repository: "repo"}, nil)
+		assert.Equal(t, errcode.ErrGitNotFound, errors.Cause(err))
+	})
+}
@@ -0,0 +1,162 @@
+package gitpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// List of the platforms
+// of the merge requests
+const (
+	GitHub = "github"
+	GitLab = "gitlab"
+)
+
+// MergeRequest is the merge request, or pull
+// request on GitHub, of the Branch to the Base
+type MergeRequest struct {
+	// Platform is one of GitHub or GitLab
+	Platform string
+
+	// APIURL is the URL of the API of the Platform, if
+	// not set it's https://api.github.com for GitHub and
+	// https://HOST/api/v4 for GitLab, with the host
+	// of the Repository
+	APIURL string
+
+	Title string
+	Body  string
+}
+
+// OpenMergeRequest opens the mr of the Branch of the opt to the Base,
+// with the Token of it, and returns the URL of it. If there is
+// already one open the URL is empty, as it's updated by the Push
+func OpenMergeRequest(ctx context.Context, mr MergeRequest, opt Options) (string, error) {
+	opt = opt.withDefaults()
+
+	host, project, err := repositoryPath(opt.Repository)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		req     *http.Request
+		exists  int
+		urlAttr string
+	)
+	switch mr.Platform {
+	case GitHub:
+		api := mr.APIURL
+		if api == "" {
+			api = "https://api.github.com"
+		}
+		req, err = jsonRequest(ctx, fmt.Sprintf("%s/repos/%s/pulls", strings.TrimSuffix(api, "/"), project), map[string]string{
+			"title": mr.Title,
+			"body":  mr.Body,
+			"head":  opt.Branch,
+			"base":  opt.Base,
+		})
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+		req.Header.Set("Authorization", "token "+opt.Token)
+		exists, urlAttr = http.StatusUnprocessableEntity, "html_url"
+	case GitLab:
+		api := mr.APIURL
+		if api == "" {
+			api = fmt.Sprintf("https://%s/api/v4", host)
+		}
+		req, err = jsonRequest(ctx, fmt.Sprintf("%s/projects/%s/merge_requests", strings.TrimSuffix(api, "/"), url.PathEscape(project)), map[string]string{
+			"title":         mr.Title,
+			"description":   mr.Body,
+			"source_branch": opt.Branch,
+			"target_branch": opt.Base,
+		})
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("PRIVATE-TOKEN", opt.Token)
+		exists, urlAttr = http.StatusConflict, "web_url"
+	default:
+		return "", errors.Errorf("invalid merge request platform %q, the valid ones are: %s, %s", mr.Platform, GitHub, GitLab)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not open the merge request on %s", mr.Platform)
+	}
+	defer res.Body.Close()
+
+	var body map[string]interface{}
+	_ = json.NewDecoder(res.Body).Decode(&body)
+
+	if res.StatusCode == exists && alreadyExists(body) {
+		return "", nil
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return "", errors.Wrapf(errcode.ErrGitMergeRequestFailed, "on %s with status %d: %v", mr.Platform, res.StatusCode, body["message"])
+	}
+
+	u, _ := body[urlAttr].(string)
+	return u, nil
+}
+
+// jsonRequest returns the POST request to the
+// u with the body encoded as JSON
+func jsonRequest(ctx context.Context, u string, body interface{}) (*http.Request, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode the merge request")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create the request to %s", u)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return req.WithContext(ctx), nil
+}
+
+// alreadyExists checks if the error body of the API is
+// because there is already a merge request of the branch
+func alreadyExists(body map[string]interface{}) bool {
+	b, _ := json.Marshal(body)
+	return strings.Contains(strings.ToLower(string(b)), "already exists")
+}
+
+// repositoryPath returns the host and the path, without the
+// .git, of the repo URL, HTTPS (https://HOST/PATH.git), SSH
+// (ssh://git@HOST/PATH.git) or SCP like (git@HOST:PATH.git)
+func repositoryPath(repo string) (string, string, error) {
+	var host, path string
+	if strings.Contains(repo, "://") {
+		u, err := url.Parse(repo)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "invalid repository %q", repo)
+		}
+		host, path = u.Hostname(), u.Path
+	} else if i := strings.Index(repo, ":"); i != -1 {
+		host, path = repo[:i], repo[i+1:]
+		if j := strings.Index(host, "@"); j != -1 {
+			host = host[j+1:]
+		}
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || path == "" {
+		return "", "", errors.Errorf("invalid repository %q, the host and path of it are required", repo)
+	}
+
+	return host, path, nil
+}
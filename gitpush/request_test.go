@@ -0,0 +1,85 @@
+package gitpush_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/gitpush"
+)
+
+func TestOpenMergeRequest(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("SuccessGitHub", func(t *testing.T) {
+		var body map[string]string
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/owner/repo/pulls", r.URL.Path)
+			assert.Equal(t, "token abc", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"html_url":"https://github.com/owner/repo/pull/1"}`))
+		}))
+		defer s.Close()
+
+		u, err := gitpush.OpenMergeRequest(ctx, gitpush.MergeRequest{Platform: gitpush.GitHub, APIURL: s.URL, Title: "Import"}, gitpush.Options{
+			Repository: "git@github.com:owner/repo.git",
+			Token:      "abc",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://github.com/owner/repo/pull/1", u)
+		assert.Equal(t, map[string]string{"title": "Import", "body": "", "head": gitpush.DefaultBranch, "base": gitpush.DefaultBase}, body)
+	})
+
+	t.Run("SuccessGitLab", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/projects/group%2Fsub%2Frepo/merge_requests", r.URL.EscapedPath())
+			assert.Equal(t, "abc", r.Header.Get("PRIVATE-TOKEN"))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"web_url":"https://gitlab.com/group/sub/repo/-/merge_requests/1"}`))
+		}))
+		defer s.Close()
+
+		u, err := gitpush.OpenMergeRequest(ctx, gitpush.MergeRequest{Platform: gitpush.GitLab, APIURL: s.URL}, gitpush.Options{
+			Repository: "https://gitlab.com/group/sub/repo.git",
+			Token:      "abc",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://gitlab.com/group/sub/repo/-/merge_requests/1", u)
+	})
+
+	t.Run("SuccessAlreadyExists", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message":["Another open merge request already exists for this source branch: !1"]}`))
+		}))
+		defer s.Close()
+
+		u, err := gitpush.OpenMergeRequest(ctx, gitpush.MergeRequest{Platform: gitpush.GitLab, APIURL: s.URL}, gitpush.Options{Repository: "https://gitlab.com/group/repo"})
+		require.NoError(t, err)
+		assert.Empty(t, u)
+	})
+
+	t.Run("ErrorFailed", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"Bad credentials"}`))
+		}))
+		defer s.Close()
+
+		_, err := gitpush.OpenMergeRequest(ctx, gitpush.MergeRequest{Platform: gitpush.GitHub, APIURL: s.URL}, gitpush.Options{Repository: "https://github.com/owner/repo"})
+		assert.Equal(t, errcode.ErrGitMergeRequestFailed, errors.Cause(err))
+	})
+
+	t.Run("ErrorPlatform", func(t *testing.T) {
+		_, err := gitpush.OpenMergeRequest(ctx, gitpush.MergeRequest{Platform: "bitbucket"}, gitpush.Options{Repository: "https://bitbucket.org/owner/repo"})
+		assert.Error(t, err)
+	})
+}
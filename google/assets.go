@@ -0,0 +1,133 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/api/cloudasset/v1"
+)
+
+// assetTypes are the types of the Cloud Asset Inventory of
+// the ResourceTypes, the ones without it (ex: the SQL users)
+// are not on the inventory and are always listed
+var assetTypes = map[ResourceType]string{
+	ComputeNetwork:              "compute.googleapis.com/Network",
+	ComputeFirewall:             "compute.googleapis.com/Firewall",
+	ComputeInstance:             "compute.googleapis.com/Instance",
+	StorageBucket:               "storage.googleapis.com/Bucket",
+	ComputeHealthCheck:          "compute.googleapis.com/HealthCheck",
+	ComputeInstanceGroup:        "compute.googleapis.com/InstanceGroup",
+	ComputeBackendService:       "compute.googleapis.com/BackendService",
+	ComputeBackendBucket:        "compute.googleapis.com/BackendBucket",
+	ComputeSSLCertificate:       "compute.googleapis.com/SslCertificate",
+	ComputeURLMap:               "compute.googleapis.com/UrlMap",
+	ComputeTargetHTTPProxy:      "compute.googleapis.com/TargetHttpProxy",
+	ComputeTargetHTTPSProxy:     "compute.googleapis.com/TargetHttpsProxy",
+	ComputeGlobalForwardingRule: "compute.googleapis.com/GlobalForwardingRule",
+	ComputeForwardingRule:       "compute.googleapis.com/ForwardingRule",
+	ComputeGlobalAddress:        "compute.googleapis.com/GlobalAddress",
+	ComputeDisk:                 "compute.googleapis.com/Disk",
+	ComputeRouter:               "compute.googleapis.com/Router",
+	ComputeRouterNat:            "compute.googleapis.com/Router",
+	SQLDatabaseInstance:         "sqladmin.googleapis.com/Instance",
+	SpannerInstance:             "spanner.googleapis.com/Instance",
+	SpannerDatabase:             "spanner.googleapis.com/Database",
+	CloudSchedulerJob:           "cloudscheduler.googleapis.com/Job",
+	DataflowJob:                 "dataflow.googleapis.com/Job",
+	DataprocCluster:             "dataproc.googleapis.com/Cluster",
+	ComposerEnvironment:         "composer.googleapis.com/Environment",
+	ServiceAccount:              "iam.googleapis.com/ServiceAccount",
+	Project:                     "cloudresourcemanager.googleapis.com/Project",
+	ProjectService:              "serviceusage.googleapis.com/Service",
+}
+
+// assetsPollInterval is the interval between
+// the checks of the export of the assets
+const assetsPollInterval = 2 * time.Second
+
+// asset is an entry of the exported inventory,
+// only the fields used are decoded
+type asset struct {
+	Name      string `json:"name"`
+	AssetType string `json:"asset_type"`
+}
+
+// ExportAssetTypes exports the Cloud Asset Inventory of the project
+// to the uri (gs://BUCKET/OBJECT), which is overwritten, and returns
+// the number of assets of each asset type on it. The bulk export is
+// done with a single request instead of one list per type and zone
+func (r *GCPReader) ExportAssetTypes(ctx context.Context, uri string) (map[string]int, error) {
+	bucket, object, err := parseGCSURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := r.cloudasset.V1.ExportAssets("projects/"+r.project, &cloudasset.ExportAssetsRequest{
+		ContentType: "RESOURCE",
+		OutputConfig: &cloudasset.OutputConfig{
+			GcsDestination: &cloudasset.GcsDestination{Uri: uri},
+		},
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to export the cloudasset Assets of the project %s from google APIs", r.project)
+	}
+
+	for !op.Done {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(assetsPollInterval):
+		}
+
+		name := op.Name
+		op, err = r.cloudasset.Operations.Get(name).Context(ctx).Do()
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get the cloudasset Operation %s from google APIs", name)
+		}
+	}
+	if op.Error != nil {
+		return nil, errors.Errorf("unable to export the cloudasset Assets of the project %s: %s", r.project, op.Error.Message)
+	}
+
+	res, err := r.storage.Objects.Get(bucket, object).Context(ctx).Download()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to download the exported assets %s", uri)
+	}
+	defer res.Body.Close()
+
+	return decodeAssetTypes(res.Body)
+}
+
+// decodeAssetTypes returns the number of assets of each
+// type of the newline delimited JSON export on r
+func decodeAssetTypes(r io.Reader) (map[string]int, error) {
+	types := make(map[string]int)
+
+	dec := json.NewDecoder(r)
+	for {
+		var a asset
+		if err := dec.Decode(&a); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "invalid exported assets")
+		}
+		types[a.AssetType]++
+	}
+
+	return types, nil
+}
+
+// parseGCSURI returns the bucket and
+// object of the uri gs://BUCKET/OBJECT
+func parseGCSURI(uri string) (string, string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(uri, "gs://"), "/", 2)
+	if !strings.HasPrefix(uri, "gs://") || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("invalid URI %q, the expected format is 'gs://BUCKET/OBJECT'", uri)
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,29 @@
+package google
+
+// unusedFns are the functions that return why the resources
+// of each ResourceType look unused from the attributes (get)
+var unusedFns = map[ResourceType]func(get func(string) string) string{
+	ComputeDisk: func(get func(string) string) string {
+		if get("users") == "" {
+			return "not attached to any instance"
+		}
+		return ""
+	},
+}
+
+// UnusedReason returns why the resource of type t with the id
+// looks unused from the attributes returned by the get, empty
+// if it does not
+func (g *google) UnusedReason(t, id string, get func(string) string) string {
+	rt, err := ResourceTypeString(t)
+	if err != nil {
+		return ""
+	}
+
+	fn, ok := unusedFns[rt]
+	if !ok {
+		return ""
+	}
+
+	return fn(get)
+}
@@ -1,63 +1,63 @@
 package main
 
 import (
-	"bytes"
+	"flag"
 	"io"
-	"os"
-	"os/exec"
 
 	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/codegen"
 )
 
-var functions = []Function{
-	Function{Resource: "Instance", Zone: true},
-	Function{Resource: "Firewall", Zone: false},
-	Function{Resource: "Network", Zone: false},
-	Function{Resource: "InstanceGroup", Zone: true},
-	Function{Resource: "BackendService", Zone: false},
-	Function{Resource: "HealthCheck", Zone: false},
-	Function{Resource: "UrlMap", Zone: false, Name: "URLMaps"},
-	Function{Resource: "TargetHttpProxy", Zone: false, Name: "TargetHTTPProxies", ServiceName: "TargetHttpProxies"},
-	Function{Resource: "TargetHttpsProxy", Zone: false, Name: "TargetHTTPSProxies", ServiceName: "TargetHttpsProxies"},
-	Function{Resource: "SslCertificate", Zone: false, Name: "SSLCertificates"},
-	Function{Resource: "ForwardingRule", Zone: false, Name: "GlobalForwardingRules", ServiceName: "GlobalForwardingRules"},
-	Function{Resource: "ForwardingRule", Region: true},
-	Function{Resource: "Disk", Zone: true},
-	Function{Resource: "Bucket", NoFilter: true, API: "storage", ResourceList: "Buckets"},
-	Function{Resource: "DatabaseInstance", Name: "StorageInstances", API: "sqladmin", ResourceList: "InstancesListResponse", ServiceName: "Instances"},
+var manifest string
+
+func init() {
+	flag.StringVar(&manifest, "manifest", "./cmd/functions.yaml", "The YAML (or JSON) manifest with the Functions to generate")
 }
 
-func main() {
-	f, err := os.OpenFile("./reader_generated.go", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
-	if err != nil {
-		panic(err)
+// loadFunctions reads the Functions
+// from the manifest on the path
+func loadFunctions(path string) ([]Function, error) {
+	var fns []Function
+	if err := codegen.LoadManifest(path, &fns); err != nil {
+		return nil, err
 	}
-	defer f.Close()
 
-	if err := generate(f, functions); err != nil {
-		panic(err)
+	for i, fn := range fns {
+		if fn.Resource == "" {
+			return nil, errors.Errorf("invalid manifest %s: the function %d has no resource", path, i)
+		}
 	}
+
+	return fns, nil
 }
 
-func generate(opt io.Writer, fns []Function) error {
-	var fnBuff = bytes.Buffer{}
+func main() {
+	flag.Parse()
 
-	if err := pkgTmpl.Execute(&fnBuff, nil); err != nil {
-		return errors.Wrap(err, "unable to execute package template")
+	functions, err := loadFunctions(manifest)
+	if err != nil {
+		panic(err)
 	}
 
-	for _, function := range functions {
-		if err := function.Execute(&fnBuff); err != nil {
-			return errors.Wrapf(err, "unable to execute function template for: %s", function.Resource)
-		}
+	fns := make([]codegen.Executor, 0, len(functions))
+	tests := make([]codegen.Executor, 0, len(functions))
+	for _, fn := range functions {
+		fns = append(fns, fn)
+		tests = append(tests, testFunction(fn))
 	}
 
-	// format
-	cmd := exec.Command("goimports")
-	cmd.Stdin = &fnBuff
-	cmd.Stdout = opt
-	if err := cmd.Run(); err != nil {
-		return errors.Wrap(err, "unable to run goimports command")
+	if err := codegen.GenerateFile("./reader_generated.go", pkgTmpl, nil, fns); err != nil {
+		panic(err)
+	}
+	if err := codegen.GenerateFile("./reader_generated_test.go", testPkgTmpl, nil, tests); err != nil {
+		panic(err)
 	}
-	return nil
 }
+
+// testFunction is a Function which
+// generated code is the test of it
+type testFunction Function
+
+// Execute uses the Function ExecuteTest
+func (f testFunction) Execute(w io.Writer) error { return Function(f).ExecuteTest(w) }
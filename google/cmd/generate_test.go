@@ -0,0 +1,67 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFunctions(t *testing.T) {
+	t.Run("Manifest", func(t *testing.T) {
+		fns, err := loadFunctions("./functions.yaml")
+		require.NoError(t, err)
+		require.NotEmpty(t, fns)
+		assert.Equal(t, Function{Resource: "Instance", Zone: true, Get: true}, fns[0])
+	})
+
+	tests := []struct {
+		name     string
+		manifest string
+		fns      []Function
+		err      bool
+	}{
+		{
+			name:     "YAML",
+			manifest: "- resource: Bucket\n  no_filter: true\n  api: storage\n  resource_list: Buckets\n",
+			fns:      []Function{Function{Resource: "Bucket", NoFilter: true, API: "storage", ResourceList: "Buckets"}},
+		},
+		{
+			name:     "JSON",
+			manifest: `[{"resource": "Router", "region": true}]`,
+			fns:      []Function{Function{Resource: "Router", Region: true}},
+		},
+		{
+			name:     "UnknownAttribute",
+			manifest: "- resource: Disk\n  zones: true\n",
+			err:      true,
+		},
+		{
+			name:     "NoResource",
+			manifest: "- zone: true\n",
+			err:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "terracognita-google-cmd")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "functions.yaml")
+			require.NoError(t, ioutil.WriteFile(path, []byte(tt.manifest), 0644))
+
+			fns, err := loadFunctions(path)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.fns, fns)
+		})
+	}
+}
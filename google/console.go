@@ -0,0 +1,75 @@
+package google
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// consoleURLs are the functions that return the path of the resources
+// of each ResourceType on the Google Cloud console, with the name
+// and the zone (if any) of them from the id and attributes (get)
+var consoleURLs = map[ResourceType]func(id string, get func(string) string) string{
+	ComputeInstance: func(id string, get func(string) string) string {
+		zone, name := zonedName(id)
+		return fmt.Sprintf("compute/instancesDetail/zones/%s/instances/%s", zone, name)
+	},
+	ComputeDisk: func(id string, get func(string) string) string {
+		zone, name := zonedName(id)
+		return fmt.Sprintf("compute/disksDetail/zones/%s/disks/%s", zone, name)
+	},
+	ComputeInstanceGroup: func(id string, get func(string) string) string {
+		zone, name := zonedName(id)
+		return fmt.Sprintf("compute/instanceGroups/details/%s/%s", zone, name)
+	},
+	ComputeNetwork: func(id string, get func(string) string) string {
+		return "networking/networks/details/" + lastSegment(id)
+	},
+	ComputeFirewall: func(id string, get func(string) string) string {
+		return "networking/firewalls/details/" + lastSegment(id)
+	},
+	StorageBucket: func(id string, get func(string) string) string {
+		return "storage/browser/" + lastSegment(id)
+	},
+	SQLDatabaseInstance: func(id string, get func(string) string) string {
+		return fmt.Sprintf("sql/instances/%s/overview", lastSegment(id))
+	},
+	SpannerInstance: func(id string, get func(string) string) string {
+		return fmt.Sprintf("spanner/instances/%s/details/databases", lastSegment(id))
+	},
+	ServiceAccount: func(id string, get func(string) string) string {
+		return "iam-admin/serviceaccounts/details/" + url.PathEscape(lastSegment(id))
+	},
+}
+
+// zonedName returns the zone and the name
+// of the id with the format '[PROJECT/]ZONE/NAME'
+func zonedName(id string) (string, string) {
+	parts := strings.Split(id, "/")
+	if len(parts) < 2 {
+		return "", id
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
+
+// lastSegment returns the last segment of the id, as some of
+// them are the self links (ex: projects/PROJECT/instances/NAME)
+func lastSegment(id string) string {
+	return id[strings.LastIndex(id, "/")+1:]
+}
+
+// ConsoleURL returns the URL of the resource of type t with the
+// id on the Google Cloud console, empty if the type has none
+func (g *google) ConsoleURL(t, id string, get func(string) string) string {
+	rt, err := ResourceTypeString(t)
+	if err != nil {
+		return ""
+	}
+
+	fn, ok := consoleURLs[rt]
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("https://console.cloud.google.com/%s?project=%s", fn(id, get), url.QueryEscape(g.Project()))
+}
@@ -0,0 +1,49 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConsoleURLs(t *testing.T) {
+	get := func(string) string { return "" }
+
+	tests := []struct {
+		Name string
+		Type ResourceType
+		ID   string
+		Path string
+	}{
+		{
+			Name: "Zoned",
+			Type: ComputeInstance,
+			ID:   "project/europe-west1-b/front",
+			Path: "compute/instancesDetail/zones/europe-west1-b/instances/front",
+		},
+		{
+			Name: "ZonedWithoutProject",
+			Type: ComputeDisk,
+			ID:   "europe-west1-b/data",
+			Path: "compute/disksDetail/zones/europe-west1-b/disks/data",
+		},
+		{
+			Name: "SelfLink",
+			Type: SpannerInstance,
+			ID:   "projects/project/instances/main",
+			Path: "spanner/instances/main/details/databases",
+		},
+		{
+			Name: "Name",
+			Type: StorageBucket,
+			ID:   "assets",
+			Path: "storage/browser/assets",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			assert.Equal(t, tt.Path, consoleURLs[tt.Type](tt.ID, get))
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/iamcredentials/v1"
+	"google.golang.org/api/option"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// impersonateScopes are the scopes requested
+// for the impersonated service account
+var impersonateScopes = []string{"https://www.googleapis.com/auth/cloud-platform"}
+
+// stsTokenURL is the URL of the Security Token Service
+// that exchanges the OIDC tokens for federated tokens,
+// it's a var so it can be changed on the tests
+var stsTokenURL = "https://sts.googleapis.com/v1/token"
+
+// ImpersonateServiceAccount returns the TokenSource of the access tokens
+// of the serviceAccount generated with the credentials, which have to
+// have the 'roles/iam.serviceAccountTokenCreator' on it. If the
+// credentials are empty the Application Default Credentials are used
+func ImpersonateServiceAccount(ctx context.Context, credentials, serviceAccount string) (oauth2.TokenSource, error) {
+	opts := make([]option.ClientOption, 0, 1)
+	if credentials != "" {
+		opts = append(opts, option.WithCredentialsFile(credentials))
+	}
+
+	return impersonateServiceAccount(ctx, serviceAccount, opts...)
+}
+
+// ImpersonateServiceAccountWithToken returns the TokenSource of the access
+// tokens of the serviceAccount generated with the access token, like a
+// federated token (see FederatedToken), which has to have the
+// 'roles/iam.workloadIdentityUser' or the
+// 'roles/iam.serviceAccountTokenCreator' on it. The tokens are
+// only generated while the access token is valid
+func ImpersonateServiceAccountWithToken(ctx context.Context, token, serviceAccount string) (oauth2.TokenSource, error) {
+	return impersonateServiceAccount(ctx, serviceAccount, option.WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})))
+}
+
+// impersonateServiceAccount returns the TokenSource of the access tokens
+// of the serviceAccount generated with the client opts, a new one is
+// generated when the last one expires so the long imports do not fail.
+// The first one is generated to validate the impersonation
+func impersonateServiceAccount(ctx context.Context, serviceAccount string, opts ...option.ClientOption) (oauth2.TokenSource, error) {
+	svc, err := iamcredentials.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create iamcredentials service")
+	}
+
+	ts := oauth2.ReuseTokenSource(nil, &impersonateTokenSource{
+		ctx:            ctx,
+		svc:            svc,
+		serviceAccount: serviceAccount,
+	})
+	if _, err := ts.Token(); err != nil {
+		return nil, err
+	}
+
+	return ts, nil
+}
+
+// impersonateTokenSource generates the access
+// tokens of the serviceAccount with the svc
+type impersonateTokenSource struct {
+	ctx            context.Context
+	svc            *iamcredentials.Service
+	serviceAccount string
+}
+
+// Token generates a new access token of the serviceAccount
+func (i *impersonateTokenSource) Token() (*oauth2.Token, error) {
+	res, err := i.svc.Projects.ServiceAccounts.GenerateAccessToken(
+		"projects/-/serviceAccounts/"+i.serviceAccount,
+		&iamcredentials.GenerateAccessTokenRequest{
+			Scope: impersonateScopes,
+		},
+	).Context(i.ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to impersonate the service account %s", i.serviceAccount)
+	}
+
+	exp, err := time.Parse(time.RFC3339, res.ExpireTime)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid expire time of the token of the service account %s", i.serviceAccount)
+	}
+
+	return &oauth2.Token{AccessToken: res.AccessToken, Expiry: exp}, nil
+}
+
+// WorkloadIdentityAudience returns the default audience of the OIDC
+// tokens of the workloadIdentityProvider, with the format
+// 'projects/NUMBER/locations/global/workloadIdentityPools/POOL/providers/PROVIDER'
+func WorkloadIdentityAudience(workloadIdentityProvider string) string {
+	return "https://iam.googleapis.com/" + strings.TrimPrefix(workloadIdentityProvider, "//iam.googleapis.com/")
+}
+
+// FederatedToken returns the federated access token exchanged for
+// the OIDC token (ex: of the CI job, see oidc.Token) with the Workload
+// Identity Federation of the workloadIdentityProvider, with the format
+// 'projects/NUMBER/locations/global/workloadIdentityPools/POOL/providers/PROVIDER'
+func FederatedToken(ctx context.Context, workloadIdentityProvider, token string) (string, error) {
+	form := url.Values{
+		"grant_type":           []string{"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"audience":             []string{"//iam.googleapis.com/" + strings.TrimPrefix(workloadIdentityProvider, "//iam.googleapis.com/")},
+		"scope":                []string{strings.Join(impersonateScopes, " ")},
+		"requested_token_type": []string{"urn:ietf:params:oauth:token-type:access_token"},
+		"subject_token":        []string{token},
+		"subject_token_type":   []string{"urn:ietf:params:oauth:token-type:jwt"},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, stsTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", errors.Wrap(err, "could not create the STS request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", errors.Wrap(err, "could not exchange the OIDC token")
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil && res.StatusCode == http.StatusOK {
+		return "", errors.Wrap(err, "could not decode the federated token")
+	}
+
+	if res.StatusCode != http.StatusOK || body.AccessToken == "" {
+		return "", errors.Wrapf(errcode.ErrGoogleFederatedToken, "with status %d: %s %s", res.StatusCode, body.Error, body.ErrorDescription)
+	}
+
+	return body.AccessToken, nil
+}
@@ -0,0 +1,123 @@
+package google
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+func TestFederatedToken(t *testing.T) {
+	const wip = "projects/123/locations/global/workloadIdentityPools/ci/providers/github"
+
+	t.Run("Success", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "//iam.googleapis.com/"+wip, r.PostForm.Get("audience"))
+			assert.Equal(t, "id-token", r.PostForm.Get("subject_token"))
+			assert.Equal(t, "urn:ietf:params:oauth:token-type:jwt", r.PostForm.Get("subject_token_type"))
+			w.Write([]byte(`{"access_token":"federated-token","token_type":"Bearer","expires_in":3600}`))
+		}))
+		defer ts.Close()
+
+		defer func(u string) { stsTokenURL = u }(stsTokenURL)
+		stsTokenURL = ts.URL
+
+		token, err := FederatedToken(context.Background(), wip, "id-token")
+		require.NoError(t, err)
+		assert.Equal(t, "federated-token", token)
+	})
+
+	t.Run("ErrorInvalidToken", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant","error_description":"The audience does not match"}`))
+		}))
+		defer ts.Close()
+
+		defer func(u string) { stsTokenURL = u }(stsTokenURL)
+		stsTokenURL = ts.URL
+
+		_, err := FederatedToken(context.Background(), wip, "id-token")
+		assert.Equal(t, errcode.ErrGoogleFederatedToken, pkgerrors.Cause(err))
+		assert.Contains(t, err.Error(), "The audience does not match")
+	})
+}
+
+func TestImpersonateServiceAccount(t *testing.T) {
+	const sa = "import@project.iam.gserviceaccount.com"
+
+	// server returns the tokens with the expire times, which are
+	// relative to now, in order and the number of requests done
+	server := func(t *testing.T, expires ...time.Duration) (*httptest.Server, *int) {
+		var calls int
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/projects/-/serviceAccounts/"+sa+":generateAccessToken", r.URL.Path)
+			require.True(t, calls < len(expires), "unexpected request")
+
+			fmt.Fprintf(w, `{"accessToken":"token-%d","expireTime":%q}`, calls, time.Now().Add(expires[calls]).UTC().Format(time.RFC3339))
+			calls++
+		}))
+		return ts, &calls
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		srv, calls := server(t, time.Hour)
+		defer srv.Close()
+
+		ts, err := impersonateServiceAccount(context.Background(), sa, option.WithEndpoint(srv.URL+"/"), option.WithoutAuthentication())
+		require.NoError(t, err)
+
+		tk, err := ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token-0", tk.AccessToken)
+		assert.Equal(t, 1, *calls)
+	})
+
+	t.Run("SuccessRefresh", func(t *testing.T) {
+		// The first one is already expired
+		// as it's on the expiry delta
+		srv, calls := server(t, time.Second, time.Hour)
+		defer srv.Close()
+
+		ts, err := impersonateServiceAccount(context.Background(), sa, option.WithEndpoint(srv.URL+"/"), option.WithoutAuthentication())
+		require.NoError(t, err)
+
+		tk, err := ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", tk.AccessToken)
+
+		tk, err = ts.Token()
+		require.NoError(t, err)
+		assert.Equal(t, "token-1", tk.AccessToken)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("ErrorForbidden", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"code":403,"message":"Permission 'iam.serviceAccounts.getAccessToken' denied"}}`))
+		}))
+		defer srv.Close()
+
+		_, err := impersonateServiceAccount(context.Background(), sa, option.WithEndpoint(srv.URL+"/"), option.WithoutAuthentication())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "iam.serviceAccounts.getAccessToken")
+	})
+}
+
+func TestWorkloadIdentityAudience(t *testing.T) {
+	const wip = "projects/123/locations/global/workloadIdentityPools/ci/providers/github"
+
+	assert.Equal(t, "https://iam.googleapis.com/"+wip, WorkloadIdentityAudience(wip))
+	assert.Equal(t, "https://iam.googleapis.com/"+wip, WorkloadIdentityAudience("//iam.googleapis.com/"+wip))
+}
@@ -0,0 +1,72 @@
+package google
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tfgoogle "github.com/terraform-providers/terraform-provider-google/google"
+	"google.golang.org/api/option"
+)
+
+// basePaths returns the base paths of the cfg of the
+// services which endpoint can be set, by the name of
+// them, nil if the Terraform provider does not use it
+func basePaths(cfg *tfgoogle.Config) map[string]*string {
+	return map[string]*string{
+		"accesscontextmanager": &cfg.AccessContextManagerBasePath,
+		"cloudasset":           nil,
+		"cloudbuild":           &cfg.CloudBuildBasePath,
+		"cloudresourcemanager": &cfg.ResourceManagerBasePath,
+		"cloudscheduler":       &cfg.CloudSchedulerBasePath,
+		"composer":             &cfg.ComposerBasePath,
+		"compute":              &cfg.ComputeBasePath,
+		"dataflow":             &cfg.DataflowBasePath,
+		"dataproc":             &cfg.DataprocBasePath,
+		"iam":                  &cfg.IAMBasePath,
+		"serviceusage":         &cfg.ServiceUsageBasePath,
+		"spanner":              &cfg.SpannerBasePath,
+		"sqladmin":             &cfg.SQLBasePath,
+		"storage":              &cfg.StorageBasePath,
+	}
+}
+
+// EndpointServices returns the sorted names of the
+// services which endpoint can be set on the NewProvider
+func EndpointServices() []string {
+	bps := basePaths(&tfgoogle.Config{})
+	names := make([]string, 0, len(bps))
+	for n := range bps {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setEndpoints sets the base paths of the cfg to the
+// endpoints, it fails if any of the services is unknown
+func setEndpoints(cfg *tfgoogle.Config, endpoints map[string]string) error {
+	bps := basePaths(cfg)
+	for s, u := range endpoints {
+		bp, ok := bps[s]
+		if !ok {
+			return fmt.Errorf("invalid endpoint service %q, the valid ones are: %s", s, strings.Join(EndpointServices(), ", "))
+		}
+		if bp != nil {
+			*bp = u
+		}
+	}
+	return nil
+}
+
+// serviceOptions returns the opts of the service with
+// the endpoint of it, if it's on the endpoints
+func serviceOptions(service string, endpoints map[string]string, opts []option.ClientOption) []option.ClientOption {
+	u, ok := endpoints[service]
+	if !ok {
+		return opts
+	}
+	// The opts are copied as they are
+	// shared by all the services
+	return append(append(make([]option.ClientOption, 0, len(opts)+1), opts...), option.WithEndpoint(u))
+}
@@ -0,0 +1,39 @@
+package google
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tfgoogle "github.com/terraform-providers/terraform-provider-google/google"
+	"google.golang.org/api/option"
+)
+
+func TestSetEndpoints(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var cfg tfgoogle.Config
+		tfgoogle.ConfigureBasePaths(&cfg)
+
+		err := setEndpoints(&cfg, map[string]string{
+			"storage":    "http://localhost:4443/storage/v1/",
+			"cloudasset": "http://localhost:8080/",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "http://localhost:4443/storage/v1/", cfg.StorageBasePath)
+		assert.Equal(t, tfgoogle.ComputeDefaultBasePath, cfg.ComputeBasePath)
+	})
+	t.Run("ErrorService", func(t *testing.T) {
+		err := setEndpoints(&tfgoogle.Config{}, map[string]string{"gcs": "http://localhost:4443/"})
+		assert.EqualError(t, err, `invalid endpoint service "gcs", the valid ones are: accesscontextmanager, cloudasset, cloudbuild, cloudresourcemanager, cloudscheduler, composer, compute, dataflow, dataproc, iam, serviceusage, spanner, sqladmin, storage`)
+	})
+}
+
+func TestNewGcpReaderEndpoints(t *testing.T) {
+	r, err := NewGcpReader(context.Background(), 10, "project", "region", "", map[string]string{"storage": "http://localhost:4443/storage/v1/"}, option.WithoutAuthentication())
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://localhost:4443/storage/v1/", r.storage.BasePath)
+	assert.NotEqual(t, "http://localhost:4443/storage/v1/", r.compute.BasePath)
+}
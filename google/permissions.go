@@ -0,0 +1,87 @@
+package google
+
+import (
+	"fmt"
+	"sort"
+)
+
+// iamPermissions are the IAM permissions needed
+// to list and read each ResourceType
+var iamPermissions = map[ResourceType][]string{
+	ComputeNetwork:                       {"compute.networks.list", "compute.networks.get"},
+	ComputeFirewall:                      {"compute.firewalls.list", "compute.firewalls.get"},
+	ComputeInstance:                      {"compute.regions.get", "compute.instances.list", "compute.instances.get"},
+	StorageBucket:                        {"storage.buckets.list", "storage.buckets.get"},
+	ComputeHealthCheck:                   {"compute.healthChecks.list", "compute.healthChecks.get"},
+	ComputeInstanceGroup:                 {"compute.regions.get", "compute.instanceGroups.list", "compute.instanceGroups.get"},
+	ComputeBackendService:                {"compute.backendServices.list", "compute.backendServices.get"},
+	ComputeBackendBucket:                 {"compute.backendBuckets.list", "compute.backendBuckets.get"},
+	ComputeSSLCertificate:                {"compute.sslCertificates.list", "compute.sslCertificates.get"},
+	ComputeURLMap:                        {"compute.urlMaps.list", "compute.urlMaps.get"},
+	ComputeTargetHTTPProxy:               {"compute.targetHttpProxies.list", "compute.targetHttpProxies.get"},
+	ComputeTargetHTTPSProxy:              {"compute.targetHttpsProxies.list", "compute.targetHttpsProxies.get"},
+	ComputeGlobalForwardingRule:          {"compute.globalForwardingRules.list", "compute.globalForwardingRules.get"},
+	ComputeForwardingRule:                {"compute.forwardingRules.list", "compute.forwardingRules.get"},
+	ComputeGlobalAddress:                 {"compute.globalAddresses.list", "compute.globalAddresses.get"},
+	ComputeDisk:                          {"compute.regions.get", "compute.disks.list", "compute.disks.get"},
+	ComputeRouter:                        {"compute.routers.list", "compute.routers.get"},
+	ComputeRouterNat:                     {"compute.routers.list", "compute.routers.get"},
+	SQLDatabaseInstance:                  {"cloudsql.instances.list", "cloudsql.instances.get"},
+	SQLDatabase:                          {"cloudsql.instances.list", "cloudsql.databases.list", "cloudsql.databases.get"},
+	SQLUser:                              {"cloudsql.instances.list", "cloudsql.users.list"},
+	SpannerInstance:                      {"spanner.instances.list", "spanner.instances.get"},
+	SpannerDatabase:                      {"spanner.instances.list", "spanner.databases.list", "spanner.databases.get", "spanner.databases.getDdl"},
+	CloudbuildTrigger:                    {"cloudbuild.builds.list", "cloudbuild.builds.get"},
+	CloudSchedulerJob:                    {"cloudscheduler.jobs.list", "cloudscheduler.jobs.get"},
+	DataflowJob:                          {"dataflow.jobs.list", "dataflow.jobs.get"},
+	DataprocCluster:                      {"dataproc.clusters.list", "dataproc.clusters.get"},
+	ComposerEnvironment:                  {"composer.environments.list", "composer.environments.get"},
+	ServiceAccount:                       {"iam.serviceAccounts.list", "iam.serviceAccounts.get"},
+	ServiceAccountIAMMember:              {"iam.serviceAccounts.list", "iam.serviceAccounts.getIamPolicy"},
+	ProjectOrganizationPolicy:            {"orgpolicy.policies.list", "orgpolicy.policy.get"},
+	Project:                              {"resourcemanager.projects.get"},
+	ProjectService:                       {"serviceusage.services.list", "serviceusage.services.get"},
+	OrganizationPolicy:                   {"orgpolicy.policies.list", "orgpolicy.policy.get"},
+	AccessContextManagerAccessPolicy:     {"accesscontextmanager.policies.list", "accesscontextmanager.policies.get"},
+	AccessContextManagerAccessLevel:      {"accesscontextmanager.policies.list", "accesscontextmanager.accessLevels.list", "accesscontextmanager.accessLevels.get"},
+	AccessContextManagerServicePerimeter: {"accesscontextmanager.policies.list", "accesscontextmanager.servicePerimeters.list", "accesscontextmanager.servicePerimeters.get"},
+}
+
+// Role is an IAM custom role definition, with
+// the format of 'gcloud iam roles create --file'
+type Role struct {
+	Title               string   `yaml:"title"`
+	Description         string   `yaml:"description"`
+	Stage               string   `yaml:"stage"`
+	IncludedPermissions []string `yaml:"includedPermissions"`
+}
+
+// Permissions returns the read-only IAM custom Role needed to import
+// the types. The organization level ones (ex: OrganizationPolicy)
+// need the Role to be granted on the organization
+func Permissions(types []string) (*Role, error) {
+	perms := make(map[string]struct{})
+	for _, t := range types {
+		rt, err := ResourceTypeString(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource type %q: %s", t, err)
+		}
+
+		for _, p := range iamPermissions[rt] {
+			perms[p] = struct{}{}
+		}
+	}
+
+	res := make([]string, 0, len(perms))
+	for p := range perms {
+		res = append(res, p)
+	}
+	sort.Strings(res)
+
+	return &Role{
+		Title:               "Terracognita",
+		Description:         "Read-only permissions to import the resources with Terracognita",
+		Stage:               "GA",
+		IncludedPermissions: res,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package google
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// presets are the curated resource types of the
+// common import scopes, used as the Include of the import
+var presets = map[string][]ResourceType{
+	"networking": {
+		ComputeNetwork, ComputeFirewall, ComputeRouter, ComputeRouterNat,
+		ComputeGlobalAddress, ComputeForwardingRule, ComputeGlobalForwardingRule,
+		ComputeHealthCheck, ComputeBackendService, ComputeBackendBucket,
+		ComputeURLMap, ComputeTargetHTTPProxy, ComputeTargetHTTPSProxy, ComputeSSLCertificate,
+	},
+	"compute": {
+		ComputeInstance, ComputeInstanceGroup, ComputeDisk,
+	},
+	"security": {
+		ComputeFirewall, ComputeSSLCertificate, ServiceAccount, ServiceAccountIAMMember,
+		ProjectOrganizationPolicy, OrganizationPolicy,
+		AccessContextManagerAccessPolicy, AccessContextManagerAccessLevel, AccessContextManagerServicePerimeter,
+	},
+	"serverless": {
+		CloudSchedulerJob, CloudbuildTrigger,
+	},
+}
+
+// PresetNames returns the names of the presets sorted
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for n := range presets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preset returns the resource types of the preset name
+func Preset(name string) ([]string, error) {
+	rts, ok := presets[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid preset %q, the valid ones are: %s", name, strings.Join(PresetNames(), ", "))
+	}
+
+	types := make([]string, 0, len(rts))
+	for _, rt := range rts {
+		types = append(types, rt.String())
+	}
+	return types, nil
+}
@@ -3,53 +3,202 @@ package google
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 
+	"github.com/cycloidio/terracognita/errcode"
 	"github.com/cycloidio/terracognita/filter"
 	"github.com/cycloidio/terracognita/log"
 	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/util"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/pkg/errors"
 	tfgoogle "github.com/terraform-providers/terraform-provider-google/google"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
 )
 
 type google struct {
 	tfGoogleClient interface{}
 	tfProvider     *schema.Provider
 	gcpr           *GCPReader
+
+	// assetTypes are the number of assets of each
+	// type of the Cloud Asset Inventory, if exported
+	assetTypes map[string]int
 }
 
-// NewProvider returns a Gooogle Provider
-func NewProvider(ctx context.Context, maxResults uint64, project, region, credentials string) (provider.Provider, error) {
+// NewProvider returns a Gooogle Provider, if impersonate is set
+// the credentials are used to impersonate that service account.
+// If the token, an access token (ex: a federated one, see
+// FederatedToken), is set it's used instead of the credentials.
+// The organization level resources are only imported if the
+// organization is set, if not only the project ones are.
+// If the assets (gs://BUCKET/OBJECT) is set the Cloud Asset
+// Inventory of the project is exported to it and only the
+// types present on it are listed.
+// The endpoints are the base paths of the services by the name
+// of them (see EndpointServices), to use emulators (ex: fake GCS),
+// without credentials nor impersonate the requests are not
+// authenticated
+func NewProvider(ctx context.Context, maxResults uint64, project, region, organization, credentials, impersonate, token, assets string, endpoints map[string]string) (provider.Provider, error) {
 	cfg := tfgoogle.Config{
-		Credentials: credentials,
-		Project:     project,
-		Region:      region,
+		Project: project,
+		Region:  region,
+	}
+
+	var (
+		opt option.ClientOption
+		ts  oauth2.TokenSource
+	)
+	if len(endpoints) != 0 && credentials == "" && impersonate == "" && token == "" {
+		log.Get().Log("func", "google.NewProvider", "msg", "using the endpoints without authentication")
+		// The TF client needs a token to not
+		// look for the default credentials
+		cfg.AccessToken = "unauthenticated"
+		opt = option.WithoutAuthentication()
+	} else if impersonate != "" {
+		log.Get().Log("func", "google.NewProvider", "msg", "impersonating service account", "service-account", impersonate)
+		var err error
+		if token != "" {
+			ts, err = ImpersonateServiceAccountWithToken(ctx, token, impersonate)
+		} else {
+			ts, err = ImpersonateServiceAccount(ctx, credentials, impersonate)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// The TF client is initialized with the current
+		// token and then it uses the ts to refresh it
+		t, err := ts.Token()
+		if err != nil {
+			return nil, err
+		}
+		cfg.AccessToken = t.AccessToken
+		opt = option.WithTokenSource(ts)
+	} else if token != "" {
+		log.Get().Log("func", "google.NewProvider", "msg", "using the access token")
+		cfg.AccessToken = token
+		opt = option.WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))
+	} else {
+		cfg.Credentials = credentials
+		opt = option.WithCredentialsFile(credentials)
 	}
 
 	tfgoogle.ConfigureBasePaths(&cfg)
+	if err := setEndpoints(&cfg, endpoints); err != nil {
+		return nil, err
+	}
 	log.Get().Log("func", "google.NewProvider", "msg", "loading TF client")
 	if err := cfg.LoadAndValidate(); err != nil {
 		return nil, fmt.Errorf("could not initialize 'terraform/google.Config.LoadAndValidate()' because: %s", err)
 	}
 
+	if ts != nil {
+		if err := setTFTokenSource(&cfg, ts); err != nil {
+			return nil, fmt.Errorf("could not set the token source of the 'terraform/google' client because: %s", err)
+		}
+	}
+
+	if util.Auditing() {
+		if err := auditTFClient(&cfg); err != nil {
+			return nil, fmt.Errorf("could not audit the 'terraform/google' client because: %s", err)
+		}
+	}
+
 	tfp := tfgoogle.Provider().(*schema.Provider)
 	tfp.SetMeta(&cfg)
 
 	log.Get().Log("func", "google.NewProvider", "msg", "loading GCP client")
-	reader, err := NewGcpReader(ctx, maxResults, project, region, credentials)
+	reader, err := NewGcpReader(ctx, maxResults, project, region, organization, endpoints, opt)
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize GCPReader: %v", err)
 	}
 
+	var assetTypes map[string]int
+	if assets != "" {
+		log.Get().Log("func", "google.NewProvider", "msg", "exporting the assets inventory", "uri", assets)
+		assetTypes, err = reader.ExportAssetTypes(ctx, assets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &google{
 		tfGoogleClient: &cfg,
 		tfProvider:     tfp,
 		gcpr:           reader,
+		assetTypes:     assetTypes,
 	}, nil
 }
 
+// auditTFClient audits the HTTP client of the cfg, which is
+// not exported and shared by the API clients of the TF provider
+func auditTFClient(cfg *tfgoogle.Config) error {
+	f, err := util.UnexportedField(cfg, "client")
+	if err != nil {
+		return err
+	}
+
+	c, ok := f.(*http.Client)
+	if !ok || c == nil {
+		return errors.Wrapf(errcode.ErrAuditNoClient, "expected the client to be a *http.Client, found %T", f)
+	}
+	util.AuditClient(c)
+
+	return nil
+}
+
+// setTFTokenSource sets the ts as the source of the tokens of the HTTP
+// client of the cfg, which is not exported and shared by the API
+// clients of the TF provider, as the TF provider only supports
+// static access tokens
+func setTFTokenSource(cfg *tfgoogle.Config, ts oauth2.TokenSource) error {
+	f, err := util.UnexportedField(cfg, "client")
+	if err != nil {
+		return err
+	}
+
+	c, ok := f.(*http.Client)
+	if !ok || c == nil {
+		return errors.Errorf("expected the client to be a *http.Client, found %T", f)
+	}
+	c.Transport = &oauth2.Transport{Source: ts}
+
+	return nil
+}
+
+// references are the attributes which value is the
+// self link of other resource, with the types it can
+// be and the attribute of it
+var references = map[string][]string{
+	"network":            {"google_compute_network.self_link"},
+	"private_network":    {"google_compute_network.self_link"},
+	"instances":          {"google_compute_instance.self_link"},
+	"group":              {"google_compute_instance_group.self_link"},
+	"health_checks":      {"google_compute_health_check.self_link"},
+	"bucket_name":        {"google_storage_bucket.name"},
+	"default_service":    {"google_compute_backend_service.self_link", "google_compute_backend_bucket.self_link"},
+	"service":            {"google_compute_backend_service.self_link", "google_compute_backend_bucket.self_link"},
+	"url_map":            {"google_compute_url_map.self_link"},
+	"ssl_certificates":   {"google_compute_ssl_certificate.self_link"},
+	"target":             {"google_compute_target_http_proxy.self_link", "google_compute_target_https_proxy.self_link"},
+	"service_account_id": {"google_service_account.name"},
+	"access_levels":      {"google_access_context_manager_access_level.name"},
+	"router":             {"google_compute_router.name"},
+	"instance":           {"google_sql_database_instance.name", "google_spanner_instance.name"},
+}
+
+// References returns the attributes referencing
+// other resources with the types of them
+func (g *google) References() map[string][]string { return references }
+
 func (g *google) HasResourceType(t string) bool {
+	if _, ok := provider.RegisteredResource(t); ok {
+		return true
+	}
 	_, err := ResourceTypeString(t)
 	return err == nil
 }
@@ -60,10 +209,18 @@ func (g *google) String() string  { return "google" }
 func (g *google) TagKey() string  { return "labels" }
 
 func (g *google) ResourceTypes() []string {
-	return ResourceTypeStrings()
+	return provider.WithRegisteredResourceTypes(g.String(), ResourceTypeStrings())
 }
 
 func (g *google) Resources(ctx context.Context, t string, f *filter.Filter) ([]provider.Resource, error) {
+	if fn, ok := provider.RegisteredResource(t); ok {
+		resources, err := fn(ctx, g, t, f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error while reading from resource %q", t)
+		}
+		return resources, nil
+	}
+
 	rt, err := ResourceTypeString(t)
 	if err != nil {
 		return nil, err
@@ -74,6 +231,13 @@ func (g *google) Resources(ctx context.Context, t string, f *filter.Filter) ([]p
 		return nil, errors.Errorf("the resource %q it's not implemented", t)
 	}
 
+	// The types not on the inventory have no
+	// resources so they are not listed
+	if at, ok := assetTypes[rt]; ok && g.assetTypes != nil && g.assetTypes[at] == 0 {
+		log.Get().Log("func", "google.Resources", "msg", "skipping the type without assets", "resource", t)
+		return nil, nil
+	}
+
 	resources, err := rfn(ctx, g, t, f.Tags)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error while reading from resource %q", t)
@@ -82,6 +246,19 @@ func (g *google) Resources(ctx context.Context, t string, f *filter.Filter) ([]p
 	return resources, nil
 }
 
+// Probe sets the zones of the region to only the ones with
+// resources, see GCPReader.ProbeZones. The region is never
+// empty as the project and global resources are not probed
+func (g *google) Probe(ctx context.Context) (bool, error) {
+	zones, err := g.gcpr.ProbeZones(ctx)
+	if err != nil {
+		return false, err
+	}
+	log.Get().Log("func", "google.Probe", "msg", "probed the zones with resources", "zones", strings.Join(zones, ","))
+
+	return false, nil
+}
+
 func (g *google) TFClient() interface{} {
 	return g.tfGoogleClient
 }
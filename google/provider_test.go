@@ -0,0 +1,74 @@
+package google
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tfgoogle "github.com/terraform-providers/terraform-provider-google/google"
+	"golang.org/x/oauth2"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/util"
+)
+
+func TestAuditTFClient(t *testing.T) {
+	defer util.SetAudit(util.Audit{})
+
+	cfg := tfgoogle.Config{
+		AccessToken: "token",
+		Project:     "project",
+		Region:      "europe-west1",
+	}
+	tfgoogle.ConfigureBasePaths(&cfg)
+	require.NoError(t, cfg.LoadAndValidate())
+
+	var violation error
+	util.SetAudit(util.Audit{
+		ReadOnly:    true,
+		OnViolation: func(err error) { violation = err },
+	})
+
+	// It fails if the client is no longer on the
+	// field on a new version of the TF provider
+	require.NoError(t, auditTFClient(&cfg))
+
+	f, err := util.UnexportedField(&cfg, "client")
+	require.NoError(t, err)
+
+	res, err := f.(*http.Client).Post("https://cloudresourcemanager.googleapis.com/v1/projects/project:setIamPolicy", "application/json", nil)
+	if res != nil {
+		res.Body.Close()
+	}
+	require.Error(t, err)
+	assert.Equal(t, errcode.ErrAuditNotReadOnly, errors.Cause(violation))
+}
+
+func TestSetTFTokenSource(t *testing.T) {
+	cfg := tfgoogle.Config{
+		AccessToken: "token",
+		Project:     "project",
+		Region:      "europe-west1",
+	}
+	tfgoogle.ConfigureBasePaths(&cfg)
+	require.NoError(t, cfg.LoadAndValidate())
+
+	// It fails if the client is no longer on the
+	// field on a new version of the TF provider
+	require.NoError(t, setTFTokenSource(&cfg, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "refreshed"})))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer refreshed", r.Header.Get("Authorization"))
+	}))
+	defer ts.Close()
+
+	f, err := util.UnexportedField(&cfg, "client")
+	require.NoError(t, err)
+
+	res, err := f.(*http.Client).Get(ts.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+}
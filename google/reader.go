@@ -2,60 +2,172 @@ package google
 
 import (
 	"context"
+	"net/http"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 
+	"google.golang.org/api/accesscontextmanager/v1"
+	"google.golang.org/api/cloudasset/v1"
+	"google.golang.org/api/cloudbuild/v1"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/cloudscheduler/v1"
+	"google.golang.org/api/composer/v1"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/dataflow/v1b3"
+	"google.golang.org/api/dataproc/v1"
+	iam "google.golang.org/api/iam/v1"
 	"google.golang.org/api/option"
+	"google.golang.org/api/serviceusage/v1"
+	"google.golang.org/api/spanner/v1"
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
 	"google.golang.org/api/storage/v1"
+	htransport "google.golang.org/api/transport/http"
+
+	"github.com/cycloidio/terracognita/util"
 )
 
 //go:generate go run ./cmd
 
+// cloudPlatformScope is the OAuth2 scope of the HTTP
+// client shared by all the services, which gives
+// access to all of them
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
 // GCPReader is the middleware between TC and GCP
 type GCPReader struct {
-	compute    *compute.Service
-	storage    *storage.Service
-	sqladmin   *sqladmin.Service
-	project    string
-	region     string
-	zones      []string
-	maxResults uint64
+	compute      *compute.Service
+	storage      *storage.Service
+	sqladmin     *sqladmin.Service
+	iam          *iam.Service
+	acm          *accesscontextmanager.Service
+	crm          *cloudresourcemanager.Service
+	cloudasset   *cloudasset.Service
+	spanner      *spanner.Service
+	cloudbuild   *cloudbuild.Service
+	serviceusage *serviceusage.Service
+	scheduler    *cloudscheduler.Service
+	dataflow     *dataflow.Service
+	dataproc     *dataproc.Service
+	composer     *composer.Service
+	project      string
+	region       string
+	organization string
+	maxResults   uint64
+
+	// zonesMu guards the zones, which are
+	// fetched once and shared by the
+	// concurrent calls
+	zonesMu   sync.Mutex
+	zones     []string
+	zonesRead bool
 }
 
 // NewGcpReader returns a GCPReader with a catalog of services
-// ready to be used
-func NewGcpReader(ctx context.Context, maxResults uint64, project, region, credentials string) (*GCPReader, error) {
+// ready to be used, authenticated with the opts. The organization
+// is optional and only needed for the organization level resources.
+// The endpoints are the base paths of the services, by the name of
+// them (see EndpointServices), to use other than the Google ones
+// (ex: emulators). All the services share the same authenticated HTTP
+// client, on top of the util.HTTPClient transport, so the connections
+// are reused
+func NewGcpReader(ctx context.Context, maxResults uint64, project, region, organization string, endpoints map[string]string, opts ...option.ClientOption) (*GCPReader, error) {
 	if maxResults > 500 {
 		return nil, errors.New("max-results must be between 0 and 500, inclusive")
 	}
-	comp, err := compute.NewService(ctx, option.WithCredentialsFile(credentials))
+	// The scope is the first one so the
+	// opts can overwrite it
+	tr, err := htransport.NewTransport(ctx, util.HTTPClient().Transport, append([]option.ClientOption{option.WithScopes(cloudPlatformScope)}, opts...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the HTTP transport")
+	}
+	opts = append(opts, option.WithHTTPClient(&http.Client{Transport: tr}))
+
+	comp, err := compute.NewService(ctx, serviceOptions("compute", endpoints, opts)...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create compute service")
 	}
-	storage, err := storage.NewService(ctx, option.WithCredentialsFile(credentials))
+	storage, err := storage.NewService(ctx, serviceOptions("storage", endpoints, opts)...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create storage service")
 	}
-	sql, err := sqladmin.NewService(ctx, option.WithCredentialsFile(credentials))
+	sql, err := sqladmin.NewService(ctx, serviceOptions("sqladmin", endpoints, opts)...)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create sqladmin service")
 	}
+	iamService, err := iam.NewService(ctx, serviceOptions("iam", endpoints, opts)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create iam service")
+	}
+	acm, err := accesscontextmanager.NewService(ctx, serviceOptions("accesscontextmanager", endpoints, opts)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create accesscontextmanager service")
+	}
+	crm, err := cloudresourcemanager.NewService(ctx, serviceOptions("cloudresourcemanager", endpoints, opts)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create cloudresourcemanager service")
+	}
+	ca, err := cloudasset.NewService(ctx, serviceOptions("cloudasset", endpoints, opts)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create cloudasset service")
+	}
+	span, err := spanner.NewService(ctx, serviceOptions("spanner", endpoints, opts)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create spanner service")
+	}
+	cb, err := cloudbuild.NewService(ctx, serviceOptions("cloudbuild", endpoints, opts)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create cloudbuild service")
+	}
+	su, err := serviceusage.NewService(ctx, serviceOptions("serviceusage", endpoints, opts)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create serviceusage service")
+	}
+	cs, err := cloudscheduler.NewService(ctx, serviceOptions("cloudscheduler", endpoints, opts)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create cloudscheduler service")
+	}
+	df, err := dataflow.NewService(ctx, serviceOptions("dataflow", endpoints, opts)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create dataflow service")
+	}
+	dp, err := dataproc.NewService(ctx, serviceOptions("dataproc", endpoints, opts)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create dataproc service")
+	}
+	cp, err := composer.NewService(ctx, serviceOptions("composer", endpoints, opts)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create composer service")
+	}
 	return &GCPReader{
-		compute:    comp,
-		storage:    storage,
-		sqladmin:   sql,
-		project:    project,
-		region:     region,
-		zones:      []string{},
-		maxResults: maxResults,
+		compute:      comp,
+		storage:      storage,
+		sqladmin:     sql,
+		iam:          iamService,
+		acm:          acm,
+		crm:          crm,
+		cloudasset:   ca,
+		spanner:      span,
+		cloudbuild:   cb,
+		serviceusage: su,
+		scheduler:    cs,
+		dataflow:     df,
+		dataproc:     dp,
+		composer:     cp,
+		project:      project,
+		region:       region,
+		organization: organization,
+		zones:        []string{},
+		maxResults:   maxResults,
 	}, nil
 }
 
 func (r *GCPReader) getZones() ([]string, error) {
-	if len(r.zones) > 0 {
+	r.zonesMu.Lock()
+	defer r.zonesMu.Unlock()
+
+	if r.zonesRead {
 		return r.zones, nil
 	}
 	rs := compute.NewRegionsService(r.compute)
@@ -63,14 +175,441 @@ func (r *GCPReader) getZones() ([]string, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to fetch information for region %s", r.region)
 	}
-	// zones are URL format, e.g:
-	// https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-c
-	// Need to split them
+	r.zones = regionZones(region)
+	r.zonesRead = true
+	return r.zones, nil
+}
+
+// regionZones returns the names of the zones of the region,
+// which are URL format, e.g:
+// https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-c
+func regionZones(region *compute.Region) []string {
 	zones := make([]string, 0, len(region.Zones))
 	for _, URL := range region.Zones {
 		tmp := strings.Split(URL, "/")
 		zones = append(zones, tmp[len(tmp)-1])
 	}
+	return zones
+}
+
+// zonalQuotas are the metrics of the quotas of the
+// region used by the zonal resources (see getZones)
+var zonalQuotas = map[string]struct{}{
+	"INSTANCES":          struct{}{},
+	"INSTANCE_GROUPS":    struct{}{},
+	"CPUS":               struct{}{},
+	"DISKS_TOTAL_GB":     struct{}{},
+	"SSD_TOTAL_GB":       struct{}{},
+	"LOCAL_SSD_TOTAL_GB": struct{}{},
+}
+
+// ProbeZones sets the zones of the region to only the ones with
+// zonal resources, so the empty ones are not listed, and returns
+// them. If the quotas of the region have no usage none of the zones
+// has resources, if not the zones are the ones of the aggregated
+// lists of the instances, instance groups and disks, which are
+// a few requests for all the zones instead of one for each one
+func (r *GCPReader) ProbeZones(ctx context.Context) ([]string, error) {
+	rs := compute.NewRegionsService(r.compute)
+	region, err := rs.Get(r.project, r.region).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to fetch information for region %s", r.region)
+	}
+
+	var used bool
+	for _, q := range region.Quotas {
+		if _, ok := zonalQuotas[q.Metric]; ok && q.Usage > 0 {
+			used = true
+			break
+		}
+	}
+
+	zones := make([]string, 0)
+	if used {
+		found, err := r.zonesWithResources(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, z := range regionZones(region) {
+			if _, ok := found[z]; ok {
+				zones = append(zones, z)
+			}
+		}
+	}
+
+	r.zonesMu.Lock()
+	defer r.zonesMu.Unlock()
+
 	r.zones = zones
+	r.zonesRead = true
+
+	return zones, nil
+}
+
+// zonesWithResources returns the names of the zones of all
+// the regions with any instance, instance group or disk
+func (r *GCPReader) zonesWithResources(ctx context.Context) (map[string]struct{}, error) {
+	zones := make(map[string]struct{})
+	add := func(scope string, n int) {
+		// The scopes are 'zones/NAME' and
+		// the empty ones have a warning
+		if n != 0 {
+			zones[strings.TrimPrefix(scope, "zones/")] = struct{}{}
+		}
+	}
+
+	if err := compute.NewInstancesService(r.compute).AggregatedList(r.project).
+		MaxResults(int64(r.maxResults)).
+		Pages(ctx, func(list *compute.InstanceAggregatedList) error {
+			for scope, l := range list.Items {
+				add(scope, len(l.Instances))
+			}
+			return nil
+		}); err != nil {
+		return nil, errors.Wrap(err, "unable to list the aggregated compute Instance from google APIs")
+	}
+
+	if err := compute.NewInstanceGroupsService(r.compute).AggregatedList(r.project).
+		MaxResults(int64(r.maxResults)).
+		Pages(ctx, func(list *compute.InstanceGroupAggregatedList) error {
+			for scope, l := range list.Items {
+				add(scope, len(l.InstanceGroups))
+			}
+			return nil
+		}); err != nil {
+		return nil, errors.Wrap(err, "unable to list the aggregated compute InstanceGroup from google APIs")
+	}
+
+	if err := compute.NewDisksService(r.compute).AggregatedList(r.project).
+		MaxResults(int64(r.maxResults)).
+		Pages(ctx, func(list *compute.DiskAggregatedList) error {
+			for scope, l := range list.Items {
+				add(scope, len(l.Disks))
+			}
+			return nil
+		}); err != nil {
+		return nil, errors.Wrap(err, "unable to list the aggregated compute Disk from google APIs")
+	}
+
 	return zones, nil
 }
+
+// ListSQLDatabases returns a list of the SQL Databases of
+// the SQL instance within a project
+func (r *GCPReader) ListSQLDatabases(ctx context.Context, instance string) ([]sqladmin.Database, error) {
+	service := sqladmin.NewDatabasesService(r.sqladmin)
+
+	list, err := service.List(r.project, instance).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list sqladmin Database of the instance %s from google APIs", instance)
+	}
+
+	resources := make([]sqladmin.Database, 0, len(list.Items))
+	for _, res := range list.Items {
+		resources = append(resources, *res)
+	}
+
+	return resources, nil
+}
+
+// ListSQLUsers returns a list of the SQL Users of
+// the SQL instance within a project
+func (r *GCPReader) ListSQLUsers(ctx context.Context, instance string) ([]sqladmin.User, error) {
+	service := sqladmin.NewUsersService(r.sqladmin)
+
+	list, err := service.List(r.project, instance).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to list sqladmin User of the instance %s from google APIs", instance)
+	}
+
+	resources := make([]sqladmin.User, 0, len(list.Items))
+	for _, res := range list.Items {
+		resources = append(resources, *res)
+	}
+
+	return resources, nil
+}
+
+// ListServiceAccounts returns a list of the
+// Service Accounts within a project
+func (r *GCPReader) ListServiceAccounts(ctx context.Context) ([]iam.ServiceAccount, error) {
+	service := iam.NewProjectsServiceAccountsService(r.iam)
+
+	resources := make([]iam.ServiceAccount, 0)
+	if err := service.List("projects/"+r.project).PageSize(int64(r.maxResults)).Pages(ctx, func(list *iam.ListServiceAccountsResponse) error {
+		for _, res := range list.Accounts {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "unable to list iam ServiceAccount from google APIs")
+	}
+
+	return resources, nil
+}
+
+// ListServiceAccountIAMBindings returns a list of the IAM
+// Bindings of the Service Account with the name
+func (r *GCPReader) ListServiceAccountIAMBindings(ctx context.Context, name string) ([]iam.Binding, error) {
+	service := iam.NewProjectsServiceAccountsService(r.iam)
+
+	policy, err := service.GetIamPolicy(name).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get iam Policy of the service account %s from google APIs", name)
+	}
+
+	resources := make([]iam.Binding, 0, len(policy.Bindings))
+	for _, res := range policy.Bindings {
+		resources = append(resources, *res)
+	}
+
+	return resources, nil
+}
+
+// ListAccessPolicies returns a list of the Access
+// Context Manager Access Policies of the organization
+func (r *GCPReader) ListAccessPolicies(ctx context.Context) ([]accesscontextmanager.AccessPolicy, error) {
+	service := accesscontextmanager.NewAccessPoliciesService(r.acm)
+
+	resources := make([]accesscontextmanager.AccessPolicy, 0)
+	if err := service.List().Parent("organizations/"+r.organization).PageSize(int64(r.maxResults)).Pages(ctx, func(list *accesscontextmanager.ListAccessPoliciesResponse) error {
+		for _, res := range list.AccessPolicies {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "unable to list accesscontextmanager AccessPolicy of the organization %s from google APIs", r.organization)
+	}
+
+	return resources, nil
+}
+
+// ListAccessLevels returns a list of the Access Context
+// Manager Access Levels of the Access Policy
+func (r *GCPReader) ListAccessLevels(ctx context.Context, policy string) ([]accesscontextmanager.AccessLevel, error) {
+	service := accesscontextmanager.NewAccessPoliciesAccessLevelsService(r.acm)
+
+	resources := make([]accesscontextmanager.AccessLevel, 0)
+	if err := service.List(policy).PageSize(int64(r.maxResults)).Pages(ctx, func(list *accesscontextmanager.ListAccessLevelsResponse) error {
+		for _, res := range list.AccessLevels {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "unable to list accesscontextmanager AccessLevel of the policy %s from google APIs", policy)
+	}
+
+	return resources, nil
+}
+
+// ListServicePerimeters returns a list of the Access Context
+// Manager Service Perimeters of the Access Policy
+func (r *GCPReader) ListServicePerimeters(ctx context.Context, policy string) ([]accesscontextmanager.ServicePerimeter, error) {
+	service := accesscontextmanager.NewAccessPoliciesServicePerimetersService(r.acm)
+
+	resources := make([]accesscontextmanager.ServicePerimeter, 0)
+	if err := service.List(policy).PageSize(int64(r.maxResults)).Pages(ctx, func(list *accesscontextmanager.ListServicePerimetersResponse) error {
+		for _, res := range list.ServicePerimeters {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "unable to list accesscontextmanager ServicePerimeter of the policy %s from google APIs", policy)
+	}
+
+	return resources, nil
+}
+
+// ListOrganizationPolicies returns a list of the
+// Organization Policies set on the organization
+func (r *GCPReader) ListOrganizationPolicies(ctx context.Context) ([]cloudresourcemanager.OrgPolicy, error) {
+	service := cloudresourcemanager.NewOrganizationsService(r.crm)
+
+	resources := make([]cloudresourcemanager.OrgPolicy, 0)
+	if err := service.ListOrgPolicies("organizations/"+r.organization, &cloudresourcemanager.ListOrgPoliciesRequest{}).Pages(ctx, func(list *cloudresourcemanager.ListOrgPoliciesResponse) error {
+		for _, res := range list.Policies {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "unable to list cloudresourcemanager OrgPolicy of the organization %s from google APIs", r.organization)
+	}
+
+	return resources, nil
+}
+
+// ListProjectOrganizationPolicies returns a list of
+// the Organization Policies set on the project
+func (r *GCPReader) ListProjectOrganizationPolicies(ctx context.Context) ([]cloudresourcemanager.OrgPolicy, error) {
+	service := cloudresourcemanager.NewProjectsService(r.crm)
+
+	resources := make([]cloudresourcemanager.OrgPolicy, 0)
+	if err := service.ListOrgPolicies("projects/"+r.project, &cloudresourcemanager.ListOrgPoliciesRequest{}).Pages(ctx, func(list *cloudresourcemanager.ListOrgPoliciesResponse) error {
+		for _, res := range list.Policies {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "unable to list cloudresourcemanager OrgPolicy of the project %s from google APIs", r.project)
+	}
+
+	return resources, nil
+}
+
+// ListProjectServices returns a list of
+// the Services (APIs) enabled on the project
+func (r *GCPReader) ListProjectServices(ctx context.Context) ([]serviceusage.GoogleApiServiceusageV1Service, error) {
+	service := serviceusage.NewServicesService(r.serviceusage)
+
+	resources := make([]serviceusage.GoogleApiServiceusageV1Service, 0)
+	if err := service.List("projects/"+r.project).Filter("state:ENABLED").Pages(ctx, func(list *serviceusage.ListServicesResponse) error {
+		for _, res := range list.Services {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "unable to list serviceusage Service of the project %s from google APIs", r.project)
+	}
+
+	return resources, nil
+}
+
+// ListSpannerInstances returns a list of the
+// Spanner Instances within a project
+func (r *GCPReader) ListSpannerInstances(ctx context.Context, filter string) ([]spanner.Instance, error) {
+	service := spanner.NewProjectsInstancesService(r.spanner)
+
+	resources := make([]spanner.Instance, 0)
+	if err := service.List("projects/"+r.project).Filter(filter).PageSize(int64(r.maxResults)).Pages(ctx, func(list *spanner.ListInstancesResponse) error {
+		for _, res := range list.Instances {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "unable to list spanner Instance from google APIs")
+	}
+
+	return resources, nil
+}
+
+// ListSpannerDatabases returns a list of the Spanner
+// Databases of the Spanner instance with the name
+func (r *GCPReader) ListSpannerDatabases(ctx context.Context, instance string) ([]spanner.Database, error) {
+	service := spanner.NewProjectsInstancesDatabasesService(r.spanner)
+
+	resources := make([]spanner.Database, 0)
+	if err := service.List(instance).PageSize(int64(r.maxResults)).Pages(ctx, func(list *spanner.ListDatabasesResponse) error {
+		for _, res := range list.Databases {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "unable to list spanner Database of the instance %s from google APIs", instance)
+	}
+
+	return resources, nil
+}
+
+// ListBuildTriggers returns a list of the
+// Cloud Build Triggers within a project
+func (r *GCPReader) ListBuildTriggers(ctx context.Context) ([]cloudbuild.BuildTrigger, error) {
+	service := cloudbuild.NewProjectsTriggersService(r.cloudbuild)
+
+	resources := make([]cloudbuild.BuildTrigger, 0)
+	if err := service.List(r.project).PageSize(int64(r.maxResults)).Pages(ctx, func(list *cloudbuild.ListBuildTriggersResponse) error {
+		for _, res := range list.Triggers {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "unable to list cloudbuild BuildTrigger from google APIs")
+	}
+
+	return resources, nil
+}
+
+// ListSchedulerJobs returns a list of the
+// Cloud Scheduler Jobs within a project and region
+func (r *GCPReader) ListSchedulerJobs(ctx context.Context) ([]cloudscheduler.Job, error) {
+	service := cloudscheduler.NewProjectsLocationsJobsService(r.scheduler)
+
+	resources := make([]cloudscheduler.Job, 0)
+	if err := service.List("projects/"+r.project+"/locations/"+r.region).PageSize(int64(r.maxResults)).Pages(ctx, func(list *cloudscheduler.ListJobsResponse) error {
+		for _, res := range list.Jobs {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "unable to list cloudscheduler Job from google APIs")
+	}
+
+	return resources, nil
+}
+
+// ListDataflowJobs returns a list of the active
+// Dataflow Jobs within a project and region
+func (r *GCPReader) ListDataflowJobs(ctx context.Context) ([]dataflow.Job, error) {
+	service := dataflow.NewProjectsLocationsJobsService(r.dataflow)
+
+	resources := make([]dataflow.Job, 0)
+	if err := service.List(r.project, r.region).Filter("ACTIVE").PageSize(int64(r.maxResults)).Pages(ctx, func(list *dataflow.ListJobsResponse) error {
+		for _, res := range list.Jobs {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "unable to list dataflow Job from google APIs")
+	}
+
+	return resources, nil
+}
+
+// GetDataflowJob returns the Dataflow Job with the id
+// within a project and region, with all its information
+// as the list only has the summary of them
+func (r *GCPReader) GetDataflowJob(ctx context.Context, id string) (*dataflow.Job, error) {
+	service := dataflow.NewProjectsLocationsJobsService(r.dataflow)
+
+	job, err := service.Get(r.project, r.region, id).View("JOB_VIEW_ALL").Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get dataflow Job %s from google APIs", id)
+	}
+
+	return job, nil
+}
+
+// ListDataprocClusters returns a list of the
+// Dataproc Clusters within a project and region
+func (r *GCPReader) ListDataprocClusters(ctx context.Context) ([]dataproc.Cluster, error) {
+	service := dataproc.NewProjectsRegionsClustersService(r.dataproc)
+
+	resources := make([]dataproc.Cluster, 0)
+	if err := service.List(r.project, r.region).PageSize(int64(r.maxResults)).Pages(ctx, func(list *dataproc.ListClustersResponse) error {
+		for _, res := range list.Clusters {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "unable to list dataproc Cluster from google APIs")
+	}
+
+	return resources, nil
+}
+
+// ListComposerEnvironments returns a list of the
+// Composer Environments within a project and region
+func (r *GCPReader) ListComposerEnvironments(ctx context.Context) ([]composer.Environment, error) {
+	service := composer.NewProjectsLocationsEnvironmentsService(r.composer)
+
+	resources := make([]composer.Environment, 0)
+	if err := service.List("projects/"+r.project+"/locations/"+r.region).PageSize(int64(r.maxResults)).Pages(ctx, func(list *composer.ListEnvironmentsResponse) error {
+		for _, res := range list.Environments {
+			resources = append(resources, *res)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "unable to list composer Environment from google APIs")
+	}
+
+	return resources, nil
+}
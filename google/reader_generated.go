@@ -42,6 +42,18 @@ func (r *GCPReader) ListInstances(ctx context.Context, filter string) (map[strin
 
 }
 
+// GetInstance returns the Instance with the name within a project and the zone
+func (r *GCPReader) GetInstance(ctx context.Context, zone, name string) (*compute.Instance, error) {
+	service := compute.NewInstancesService(r.compute)
+
+	res, err := service.Get(r.project, zone, name).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get compute Instance %s from google APIs", name)
+	}
+
+	return res, nil
+}
+
 // ListFirewalls returns a list of Firewalls within a project
 func (r *GCPReader) ListFirewalls(ctx context.Context, filter string) ([]compute.Firewall, error) {
 	service := compute.NewFirewallsService(r.compute)
@@ -64,6 +76,18 @@ func (r *GCPReader) ListFirewalls(ctx context.Context, filter string) ([]compute
 
 }
 
+// GetFirewall returns the Firewall with the name within a project
+func (r *GCPReader) GetFirewall(ctx context.Context, name string) (*compute.Firewall, error) {
+	service := compute.NewFirewallsService(r.compute)
+
+	res, err := service.Get(r.project, name).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get compute Firewall %s from google APIs", name)
+	}
+
+	return res, nil
+}
+
 // ListNetworks returns a list of Networks within a project
 func (r *GCPReader) ListNetworks(ctx context.Context, filter string) ([]compute.Network, error) {
 	service := compute.NewNetworksService(r.compute)
@@ -86,6 +110,18 @@ func (r *GCPReader) ListNetworks(ctx context.Context, filter string) ([]compute.
 
 }
 
+// GetNetwork returns the Network with the name within a project
+func (r *GCPReader) GetNetwork(ctx context.Context, name string) (*compute.Network, error) {
+	service := compute.NewNetworksService(r.compute)
+
+	res, err := service.Get(r.project, name).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get compute Network %s from google APIs", name)
+	}
+
+	return res, nil
+}
+
 // ListInstanceGroups returns a list of InstanceGroups within a project and a zone
 func (r *GCPReader) ListInstanceGroups(ctx context.Context, filter string) (map[string][]compute.InstanceGroup, error) {
 	service := compute.NewInstanceGroupsService(r.compute)
@@ -139,6 +175,28 @@ func (r *GCPReader) ListBackendServices(ctx context.Context, filter string) ([]c
 
 }
 
+// ListBackendBuckets returns a list of BackendBuckets within a project
+func (r *GCPReader) ListBackendBuckets(ctx context.Context, filter string) ([]compute.BackendBucket, error) {
+	service := compute.NewBackendBucketsService(r.compute)
+
+	resources := make([]compute.BackendBucket, 0)
+
+	if err := service.List(r.project).
+		Filter(filter).
+		MaxResults(int64(r.maxResults)).
+		Pages(ctx, func(list *compute.BackendBucketList) error {
+			for _, res := range list.Items {
+				resources = append(resources, *res)
+			}
+			return nil
+		}); err != nil {
+		return nil, errors.Wrap(err, "unable to list compute BackendBucket from google APIs")
+	}
+
+	return resources, nil
+
+}
+
 // ListHealthChecks returns a list of HealthChecks within a project
 func (r *GCPReader) ListHealthChecks(ctx context.Context, filter string) ([]compute.HealthCheck, error) {
 	service := compute.NewHealthChecksService(r.compute)
@@ -293,6 +351,28 @@ func (r *GCPReader) ListForwardingRules(ctx context.Context, filter string) ([]c
 
 }
 
+// ListGlobalAddresses returns a list of GlobalAddresses within a project
+func (r *GCPReader) ListGlobalAddresses(ctx context.Context, filter string) ([]compute.Address, error) {
+	service := compute.NewGlobalAddressesService(r.compute)
+
+	resources := make([]compute.Address, 0)
+
+	if err := service.List(r.project).
+		Filter(filter).
+		MaxResults(int64(r.maxResults)).
+		Pages(ctx, func(list *compute.AddressList) error {
+			for _, res := range list.Items {
+				resources = append(resources, *res)
+			}
+			return nil
+		}); err != nil {
+		return nil, errors.Wrap(err, "unable to list compute Address from google APIs")
+	}
+
+	return resources, nil
+
+}
+
 // ListDisks returns a list of Disks within a project and a zone
 func (r *GCPReader) ListDisks(ctx context.Context, filter string) (map[string][]compute.Disk, error) {
 	service := compute.NewDisksService(r.compute)
@@ -324,6 +404,52 @@ func (r *GCPReader) ListDisks(ctx context.Context, filter string) (map[string][]
 
 }
 
+// GetDisk returns the Disk with the name within a project and the zone
+func (r *GCPReader) GetDisk(ctx context.Context, zone, name string) (*compute.Disk, error) {
+	service := compute.NewDisksService(r.compute)
+
+	res, err := service.Get(r.project, zone, name).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get compute Disk %s from google APIs", name)
+	}
+
+	return res, nil
+}
+
+// ListRouters returns a list of Routers within a project
+func (r *GCPReader) ListRouters(ctx context.Context, filter string) ([]compute.Router, error) {
+	service := compute.NewRoutersService(r.compute)
+
+	resources := make([]compute.Router, 0)
+
+	if err := service.List(r.project, r.region).
+		Filter(filter).
+		MaxResults(int64(r.maxResults)).
+		Pages(ctx, func(list *compute.RouterList) error {
+			for _, res := range list.Items {
+				resources = append(resources, *res)
+			}
+			return nil
+		}); err != nil {
+		return nil, errors.Wrap(err, "unable to list compute Router from google APIs")
+	}
+
+	return resources, nil
+
+}
+
+// GetRouter returns the Router with the name within a project
+func (r *GCPReader) GetRouter(ctx context.Context, name string) (*compute.Router, error) {
+	service := compute.NewRoutersService(r.compute)
+
+	res, err := service.Get(r.project, r.region, name).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get compute Router %s from google APIs", name)
+	}
+
+	return res, nil
+}
+
 // ListBuckets returns a list of Buckets within a project
 func (r *GCPReader) ListBuckets(ctx context.Context) ([]storage.Bucket, error) {
 	service := storage.NewBucketsService(r.storage)
@@ -345,6 +471,18 @@ func (r *GCPReader) ListBuckets(ctx context.Context) ([]storage.Bucket, error) {
 
 }
 
+// GetBucket returns the Bucket with the name within a project
+func (r *GCPReader) GetBucket(ctx context.Context, name string) (*storage.Bucket, error) {
+	service := storage.NewBucketsService(r.storage)
+
+	res, err := service.Get(name).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get storage Bucket %s from google APIs", name)
+	}
+
+	return res, nil
+}
+
 // ListStorageInstances returns a list of StorageInstances within a project
 func (r *GCPReader) ListStorageInstances(ctx context.Context, filter string) ([]sqladmin.DatabaseInstance, error) {
 	service := sqladmin.NewInstancesService(r.sqladmin)
@@ -366,3 +504,15 @@ func (r *GCPReader) ListStorageInstances(ctx context.Context, filter string) ([]
 	return resources, nil
 
 }
+
+// GetStorageInstance returns the DatabaseInstance with the name within a project
+func (r *GCPReader) GetStorageInstance(ctx context.Context, name string) (*sqladmin.DatabaseInstance, error) {
+	service := sqladmin.NewInstancesService(r.sqladmin)
+
+	res, err := service.Get(r.project, name).Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get sqladmin DatabaseInstance %s from google APIs", name)
+	}
+
+	return res, nil
+}
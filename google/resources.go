@@ -3,9 +3,12 @@ package google
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
+	"google.golang.org/api/dataflow/v1b3"
 
 	"github.com/cycloidio/terracognita/provider"
 	"github.com/cycloidio/terracognita/tag"
@@ -17,46 +20,94 @@ type ResourceType int
 
 //go:generate enumer -type ResourceType -addprefix google_ -transform snake -linecomment
 const (
-	ComputeInstance ResourceType = iota
+	ComputeNetwork ResourceType = iota
 	ComputeFirewall
-	ComputeNetwork
+	ComputeInstance
+	StorageBucket
 	// With Google, an HTTP(S) load balancer has 3 parts:
-	// * backend configuration: instance_group, backend_service and health_check
+	// * backend configuration: instance_group, backend_service, backend_bucket and health_check
 	// * host and path rules: url_map
 	// * frontend configuration: target_http(s)_proxy + global_forwarding_rule
+	// They are in this order so each part can reference the previous ones
 	ComputeHealthCheck
 	ComputeInstanceGroup
 	ComputeBackendService
+	ComputeBackendBucket
 	ComputeSSLCertificate
+	ComputeURLMap
 	ComputeTargetHTTPProxy
 	ComputeTargetHTTPSProxy
-	ComputeURLMap
 	ComputeGlobalForwardingRule
 	ComputeForwardingRule
+	ComputeGlobalAddress
 	ComputeDisk
-	StorageBucket
+	ComputeRouter
+	ComputeRouterNat
 	SQLDatabaseInstance
+	SQLDatabase
+	SQLUser
+	SpannerInstance
+	SpannerDatabase
+	CloudbuildTrigger
+	CloudSchedulerJob
+	DataflowJob
+	DataprocCluster
+	ComposerEnvironment
+	ServiceAccount
+	ServiceAccountIAMMember
+	ProjectOrganizationPolicy
+	Project
+	ProjectService
+	// The organization level resources are only
+	// imported if the organization is set
+	OrganizationPolicy
+	AccessContextManagerAccessPolicy
+	AccessContextManagerAccessLevel
+	AccessContextManagerServicePerimeter
 )
 
 type rtFn func(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error)
 
 var (
 	resources = map[ResourceType]rtFn{
-		ComputeInstance:             computeInstance,
-		ComputeFirewall:             computeFirewall,
 		ComputeNetwork:              computeNetwork,
+		ComputeFirewall:             computeFirewall,
+		ComputeInstance:             computeInstance,
+		StorageBucket:               storageBucket,
 		ComputeHealthCheck:          computeHealthCheck,
 		ComputeInstanceGroup:        computeInstanceGroup,
 		ComputeBackendService:       computeBackendService,
+		ComputeBackendBucket:        computeBackendBucket,
 		ComputeSSLCertificate:       computeSSLCertificate,
+		ComputeURLMap:               computeURLMap,
 		ComputeTargetHTTPProxy:      computeTargetHTTPProxy,
 		ComputeTargetHTTPSProxy:     computeTargetHTTPSProxy,
-		ComputeURLMap:               computeURLMap,
 		ComputeGlobalForwardingRule: computeGlobalForwardingRule,
 		ComputeForwardingRule:       computeForwardingRule,
+		ComputeGlobalAddress:        computeGlobalAddress,
 		ComputeDisk:                 computeDisk,
-		StorageBucket:               storageBucket,
+		ComputeRouter:               computeRouter,
+		ComputeRouterNat:            computeRouterNat,
 		SQLDatabaseInstance:         sqlDatabaseInstance,
+		SQLDatabase:                 sqlDatabase,
+		SQLUser:                     sqlUser,
+		SpannerInstance:             spannerInstance,
+		SpannerDatabase:             spannerDatabase,
+		CloudbuildTrigger:           cloudbuildTrigger,
+		CloudSchedulerJob:           cloudSchedulerJob,
+		DataflowJob:                 dataflowJob,
+		DataprocCluster:             dataprocCluster,
+		ComposerEnvironment:         composerEnvironment,
+		ServiceAccount:              serviceAccount,
+		ServiceAccountIAMMember:     serviceAccountIAMMember,
+		ProjectOrganizationPolicy:   projectOrganizationPolicy,
+		Project:                     project,
+		ProjectService:              projectService,
+		OrganizationPolicy:          organizationPolicy,
+
+		AccessContextManagerAccessPolicy:     accessContextManagerAccessPolicy,
+		AccessContextManagerAccessLevel:      accessContextManagerAccessLevel,
+		AccessContextManagerServicePerimeter: accessContextManagerServicePerimeter,
 	}
 )
 
@@ -157,6 +208,20 @@ func computeBackendService(ctx context.Context, g *google, resourceType string,
 	return resources, nil
 }
 
+func computeBackendBucket(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	f := initializeFilter(tags)
+	buckets, err := g.gcpr.ListBackendBuckets(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list backend buckets from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, bucket := range buckets {
+		r := provider.NewResource(bucket.Name, resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
 func computeURLMap(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
 	f := initializeFilter(tags)
 	maps, err := g.gcpr.ListURLMaps(ctx, f)
@@ -257,6 +322,37 @@ func computeDisk(ctx context.Context, g *google, resourceType string, tags []tag
 	return resources, nil
 }
 
+func computeRouter(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	f := initializeFilter(tags)
+	routers, err := g.gcpr.ListRouters(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list routers from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, router := range routers {
+		r := provider.NewResource(fmt.Sprintf("%s/%s", g.gcpr.region, router.Name), resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func computeRouterNat(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	f := initializeFilter(tags)
+	routers, err := g.gcpr.ListRouters(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list routers from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, router := range routers {
+		// The Cloud NATs are configured on the routers
+		for _, nat := range router.Nats {
+			r := provider.NewResource(fmt.Sprintf("%s/%s/%s/%s", g.Project(), g.gcpr.region, router.Name, nat.Name), resourceType, g)
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
 func storageBucket(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
 	rules, err := g.gcpr.ListBuckets(ctx)
 	if err != nil {
@@ -270,6 +366,20 @@ func storageBucket(ctx context.Context, g *google, resourceType string, tags []t
 	return resources, nil
 }
 
+func computeGlobalAddress(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	f := initializeFilter(tags)
+	addresses, err := g.gcpr.ListGlobalAddresses(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list global addresses from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, address := range addresses {
+		r := provider.NewResource(address.Name, resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
 func sqlDatabaseInstance(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
 	f := initializeFilter(tags)
 	instances, err := g.gcpr.ListStorageInstances(ctx, f)
@@ -283,3 +393,397 @@ func sqlDatabaseInstance(ctx context.Context, g *google, resourceType string, ta
 	}
 	return resources, nil
 }
+
+// sqlSystemDatabases are the databases
+// created on the SQL instances by Google
+var sqlSystemDatabases = map[string]struct{}{
+	"information_schema": {},
+	"mysql":              {},
+	"performance_schema": {},
+	"sys":                {},
+}
+
+func sqlDatabase(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	f := initializeFilter(tags)
+	instances, err := g.gcpr.ListStorageInstances(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list sql storage instances rules from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, instance := range instances {
+		databases, err := g.gcpr.ListSQLDatabases(ctx, instance.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to list sql databases from reader")
+		}
+		for _, database := range databases {
+			if _, ok := sqlSystemDatabases[database.Name]; ok {
+				continue
+			}
+			r := provider.NewResource(fmt.Sprintf("%s/%s/%s", g.Project(), instance.Name, database.Name), resourceType, g)
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+func sqlUser(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	f := initializeFilter(tags)
+	instances, err := g.gcpr.ListStorageInstances(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list sql storage instances rules from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, instance := range instances {
+		users, err := g.gcpr.ListSQLUsers(ctx, instance.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to list sql users from reader")
+		}
+		for _, user := range users {
+			// The MySQL users are identified also by the host
+			id := fmt.Sprintf("%s/%s/%s", g.Project(), instance.Name, user.Name)
+			if user.Host != "" {
+				id = fmt.Sprintf("%s/%s/%s/%s", g.Project(), instance.Name, user.Host, user.Name)
+			}
+			r := provider.NewResource(id, resourceType, g)
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+// initializeSpannerFilter returns the filter of the
+// Spanner instances with the tags, which has its
+// own syntax different from the compute one
+func initializeSpannerFilter(tags []tag.Tag) string {
+	filters := make([]string, 0, len(tags))
+	for _, t := range tags {
+		filters = append(filters, fmt.Sprintf("labels.%s:%s", t.Name, t.Value))
+	}
+	return strings.Join(filters, " AND ")
+}
+
+func spannerInstance(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	f := initializeSpannerFilter(tags)
+	instances, err := g.gcpr.ListSpannerInstances(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list spanner instances from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, instance := range instances {
+		// The name is 'projects/PROJECT/instances/NAME'
+		// which is also accepted as import ID
+		r := provider.NewResource(instance.Name, resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func spannerDatabase(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	f := initializeSpannerFilter(tags)
+	instances, err := g.gcpr.ListSpannerInstances(ctx, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list spanner instances from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, instance := range instances {
+		databases, err := g.gcpr.ListSpannerDatabases(ctx, instance.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to list spanner databases from reader")
+		}
+		for _, database := range databases {
+			r := provider.NewResource(database.Name, resourceType, g)
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+func cloudbuildTrigger(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	triggers, err := g.gcpr.ListBuildTriggers(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list build triggers from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, trigger := range triggers {
+		r := provider.NewResource(fmt.Sprintf("%s/%s", g.Project(), trigger.Id), resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func cloudSchedulerJob(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	jobs, err := g.gcpr.ListSchedulerJobs(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list scheduler jobs from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, job := range jobs {
+		// The name is 'projects/PROJECT/locations/REGION/jobs/NAME'
+		r := provider.NewResource(job.Name, resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func dataflowJob(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	jobs, err := g.gcpr.ListDataflowJobs(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list dataflow jobs from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, j := range jobs {
+		job, err := g.gcpr.GetDataflowJob(ctx, j.Id)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to get dataflow job from reader")
+		}
+
+		// The google_dataflow_job is not importable and the TF provider
+		// does not read the template nor the temporary location, so those
+		// are set from the pipeline options and only the jobs launched
+		// from a template are imported
+		template, temp := dataflowJobLocations(job)
+		if template == "" || temp == "" {
+			continue
+		}
+
+		r := provider.NewResource(job.Id, resourceType, g)
+		if err := r.Data().Set("region", g.gcpr.region); err != nil {
+			return nil, err
+		}
+		if err := r.Data().Set("template_gcs_path", template); err != nil {
+			return nil, err
+		}
+		if err := r.Data().Set("temp_gcs_location", temp); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+// dataflowJobLocations returns the template and temporary
+// locations of the job from its pipeline options, the
+// temporary one from the environment if it's not on them
+func dataflowJobLocations(job *dataflow.Job) (string, string) {
+	if job.Environment == nil {
+		return "", ""
+	}
+
+	var po struct {
+		Options struct {
+			TemplateLocation string `json:"templateLocation"`
+			TempLocation     string `json:"tempLocation"`
+		} `json:"options"`
+	}
+	if len(job.Environment.SdkPipelineOptions) != 0 {
+		// If the options can not be decoded
+		// the job is considered not templated
+		_ = json.Unmarshal(job.Environment.SdkPipelineOptions, &po)
+	}
+
+	temp := po.Options.TempLocation
+	if temp == "" && job.Environment.TempStoragePrefix != "" {
+		// The prefix has the format 'storage.googleapis.com/BUCKET/OBJECT'
+		temp = "gs://" + strings.TrimPrefix(job.Environment.TempStoragePrefix, "storage.googleapis.com/")
+	}
+
+	return po.Options.TemplateLocation, temp
+}
+
+func dataprocCluster(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	clusters, err := g.gcpr.ListDataprocClusters(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list dataproc clusters from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, cluster := range clusters {
+		if cluster.Status != nil && cluster.Status.State == "DELETING" {
+			continue
+		}
+
+		// The google_dataproc_cluster is not importable
+		// so the Read needs the name and the region
+		r := provider.NewResource(cluster.ClusterName, resourceType, g)
+		if err := r.Data().Set("name", cluster.ClusterName); err != nil {
+			return nil, err
+		}
+		if err := r.Data().Set("region", g.gcpr.region); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func composerEnvironment(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	envs, err := g.gcpr.ListComposerEnvironments(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list composer environments from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, env := range envs {
+		// The name is 'projects/PROJECT/locations/REGION/environments/NAME'
+		r := provider.NewResource(env.Name, resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func serviceAccount(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	accounts, err := g.gcpr.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list service accounts from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, account := range accounts {
+		// The keys of the service accounts are not imported
+		// as the private key would be written on the TFState
+		r := provider.NewResource(account.Name, resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func serviceAccountIAMMember(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	accounts, err := g.gcpr.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list service accounts from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, account := range accounts {
+		bindings, err := g.gcpr.ListServiceAccountIAMBindings(ctx, account.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to list service account IAM bindings from reader")
+		}
+		for _, binding := range bindings {
+			for _, member := range binding.Members {
+				// The import ID is 'service_account_id role member'
+				id := fmt.Sprintf("%s %s %s", account.Name, binding.Role, member)
+				r := provider.NewResource(id, resourceType, g)
+				resources = append(resources, r)
+			}
+		}
+	}
+	return resources, nil
+}
+
+func projectOrganizationPolicy(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	policies, err := g.gcpr.ListProjectOrganizationPolicies(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list project organization policies from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, policy := range policies {
+		r := provider.NewResource(fmt.Sprintf("%s:%s", g.Project(), policy.Constraint), resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func project(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	return []provider.Resource{provider.NewResource(g.Project(), resourceType, g)}, nil
+}
+
+// ignoredProjectServices are the services that can not be
+// enabled directly, only as a side effect of enabling others,
+// so the TF provider does not allow them
+var ignoredProjectServices = map[string]struct{}{
+	"dataproc-control.googleapis.com":        {},
+	"source.googleapis.com":                  {},
+	"stackdriverprovisioning.googleapis.com": {},
+}
+
+func projectService(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	services, err := g.gcpr.ListProjectServices(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list project services from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, service := range services {
+		// The name is 'projects/NUMBER/services/NAME'
+		name := service.Name[strings.LastIndex(service.Name, "/")+1:]
+		if _, ok := ignoredProjectServices[name]; ok {
+			continue
+		}
+		r := provider.NewResource(fmt.Sprintf("%s/%s", g.Project(), name), resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func organizationPolicy(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	if g.gcpr.organization == "" {
+		return nil, nil
+	}
+	policies, err := g.gcpr.ListOrganizationPolicies(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list organization policies from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, policy := range policies {
+		r := provider.NewResource(fmt.Sprintf("%s:%s", g.gcpr.organization, policy.Constraint), resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func accessContextManagerAccessPolicy(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	if g.gcpr.organization == "" {
+		return nil, nil
+	}
+	policies, err := g.gcpr.ListAccessPolicies(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list access policies from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, policy := range policies {
+		// The name is 'accessPolicies/ID' and the import ID is the ID
+		r := provider.NewResource(strings.TrimPrefix(policy.Name, "accessPolicies/"), resourceType, g)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func accessContextManagerAccessLevel(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	if g.gcpr.organization == "" {
+		return nil, nil
+	}
+	policies, err := g.gcpr.ListAccessPolicies(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list access policies from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, policy := range policies {
+		levels, err := g.gcpr.ListAccessLevels(ctx, policy.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to list access levels from reader")
+		}
+		for _, level := range levels {
+			r := provider.NewResource(level.Name, resourceType, g)
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+func accessContextManagerServicePerimeter(ctx context.Context, g *google, resourceType string, tags []tag.Tag) ([]provider.Resource, error) {
+	if g.gcpr.organization == "" {
+		return nil, nil
+	}
+	policies, err := g.gcpr.ListAccessPolicies(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to list access policies from reader")
+	}
+	resources := make([]provider.Resource, 0)
+	for _, policy := range policies {
+		perimeters, err := g.gcpr.ListServicePerimeters(ctx, policy.Name)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to list service perimeters from reader")
+		}
+		for _, perimeter := range perimeters {
+			r := provider.NewResource(perimeter.Name, resourceType, g)
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
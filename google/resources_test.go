@@ -0,0 +1,54 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/dataflow/v1b3"
+)
+
+func TestDataflowJobLocations(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Job      *dataflow.Job
+		Template string
+		Temp     string
+	}{
+		{
+			Name: "Templated",
+			Job: &dataflow.Job{Environment: &dataflow.Environment{
+				SdkPipelineOptions: []byte(`{"options": {"templateLocation": "gs://templates/wordcount", "tempLocation": "gs://tmp/wordcount"}}`),
+			}},
+			Template: "gs://templates/wordcount",
+			Temp:     "gs://tmp/wordcount",
+		},
+		{
+			Name: "TempStoragePrefix",
+			Job: &dataflow.Job{Environment: &dataflow.Environment{
+				SdkPipelineOptions: []byte(`{"options": {"templateLocation": "gs://templates/wordcount"}}`),
+				TempStoragePrefix:  "storage.googleapis.com/tmp/wordcount",
+			}},
+			Template: "gs://templates/wordcount",
+			Temp:     "gs://tmp/wordcount",
+		},
+		{
+			Name: "NotTemplated",
+			Job: &dataflow.Job{Environment: &dataflow.Environment{
+				SdkPipelineOptions: []byte(`{"options": {"tempLocation": "gs://tmp/wordcount"}}`),
+			}},
+			Temp: "gs://tmp/wordcount",
+		},
+		{
+			Name: "NoEnvironment",
+			Job:  &dataflow.Job{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			template, temp := dataflowJobLocations(tt.Job)
+			assert.Equal(t, tt.Template, template)
+			assert.Equal(t, tt.Temp, temp)
+		})
+	}
+}
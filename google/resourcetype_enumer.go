@@ -6,11 +6,11 @@ import (
 	"fmt"
 )
 
-const _ResourceTypeName = "google_compute_instancegoogle_compute_firewallgoogle_compute_networkgoogle_compute_health_checkgoogle_compute_instance_groupgoogle_compute_backend_servicegoogle_compute_ssl_certificategoogle_compute_target_http_proxygoogle_compute_target_https_proxygoogle_compute_url_mapgoogle_compute_global_forwarding_rulegoogle_compute_forwarding_rulegoogle_compute_diskgoogle_storage_bucketgoogle_sql_database_instance"
+const _ResourceTypeName = "google_compute_networkgoogle_compute_firewallgoogle_compute_instancegoogle_storage_bucketgoogle_compute_health_checkgoogle_compute_instance_groupgoogle_compute_backend_servicegoogle_compute_backend_bucketgoogle_compute_ssl_certificategoogle_compute_url_mapgoogle_compute_target_http_proxygoogle_compute_target_https_proxygoogle_compute_global_forwarding_rulegoogle_compute_forwarding_rulegoogle_compute_global_addressgoogle_compute_diskgoogle_compute_routergoogle_compute_router_natgoogle_sql_database_instancegoogle_sql_databasegoogle_sql_usergoogle_spanner_instancegoogle_spanner_databasegoogle_cloudbuild_triggergoogle_cloud_scheduler_jobgoogle_dataflow_jobgoogle_dataproc_clustergoogle_composer_environmentgoogle_service_accountgoogle_service_account_iam_membergoogle_project_organization_policygoogle_projectgoogle_project_servicegoogle_organization_policygoogle_access_context_manager_access_policygoogle_access_context_manager_access_levelgoogle_access_context_manager_service_perimeter"
 
-var _ResourceTypeIndex = [...]uint16{0, 23, 46, 68, 95, 124, 154, 184, 216, 249, 271, 308, 338, 357, 378, 406}
+var _ResourceTypeIndex = [...]uint16{0, 22, 45, 68, 89, 116, 145, 175, 204, 234, 256, 288, 321, 358, 388, 417, 436, 457, 482, 510, 529, 544, 567, 590, 615, 641, 660, 683, 710, 732, 765, 799, 813, 835, 861, 904, 946, 993}
 
-const _ResourceTypeLowerName = "google_compute_instancegoogle_compute_firewallgoogle_compute_networkgoogle_compute_health_checkgoogle_compute_instance_groupgoogle_compute_backend_servicegoogle_compute_ssl_certificategoogle_compute_target_http_proxygoogle_compute_target_https_proxygoogle_compute_url_mapgoogle_compute_global_forwarding_rulegoogle_compute_forwarding_rulegoogle_compute_diskgoogle_storage_bucketgoogle_sql_database_instance"
+const _ResourceTypeLowerName = "google_compute_networkgoogle_compute_firewallgoogle_compute_instancegoogle_storage_bucketgoogle_compute_health_checkgoogle_compute_instance_groupgoogle_compute_backend_servicegoogle_compute_backend_bucketgoogle_compute_ssl_certificategoogle_compute_url_mapgoogle_compute_target_http_proxygoogle_compute_target_https_proxygoogle_compute_global_forwarding_rulegoogle_compute_forwarding_rulegoogle_compute_global_addressgoogle_compute_diskgoogle_compute_routergoogle_compute_router_natgoogle_sql_database_instancegoogle_sql_databasegoogle_sql_usergoogle_spanner_instancegoogle_spanner_databasegoogle_cloudbuild_triggergoogle_cloud_scheduler_jobgoogle_dataflow_jobgoogle_dataproc_clustergoogle_composer_environmentgoogle_service_accountgoogle_service_account_iam_membergoogle_project_organization_policygoogle_projectgoogle_project_servicegoogle_organization_policygoogle_access_context_manager_access_policygoogle_access_context_manager_access_levelgoogle_access_context_manager_service_perimeter"
 
 func (i ResourceType) String() string {
 	if i < 0 || i >= ResourceType(len(_ResourceTypeIndex)-1) {
@@ -19,57 +19,123 @@ func (i ResourceType) String() string {
 	return _ResourceTypeName[_ResourceTypeIndex[i]:_ResourceTypeIndex[i+1]]
 }
 
-var _ResourceTypeValues = []ResourceType{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}
+var _ResourceTypeValues = []ResourceType{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36}
 
 var _ResourceTypeNameToValueMap = map[string]ResourceType{
-	_ResourceTypeName[0:23]:         0,
-	_ResourceTypeLowerName[0:23]:    0,
-	_ResourceTypeName[23:46]:        1,
-	_ResourceTypeLowerName[23:46]:   1,
-	_ResourceTypeName[46:68]:        2,
-	_ResourceTypeLowerName[46:68]:   2,
-	_ResourceTypeName[68:95]:        3,
-	_ResourceTypeLowerName[68:95]:   3,
-	_ResourceTypeName[95:124]:       4,
-	_ResourceTypeLowerName[95:124]:  4,
-	_ResourceTypeName[124:154]:      5,
-	_ResourceTypeLowerName[124:154]: 5,
-	_ResourceTypeName[154:184]:      6,
-	_ResourceTypeLowerName[154:184]: 6,
-	_ResourceTypeName[184:216]:      7,
-	_ResourceTypeLowerName[184:216]: 7,
-	_ResourceTypeName[216:249]:      8,
-	_ResourceTypeLowerName[216:249]: 8,
-	_ResourceTypeName[249:271]:      9,
-	_ResourceTypeLowerName[249:271]: 9,
-	_ResourceTypeName[271:308]:      10,
-	_ResourceTypeLowerName[271:308]: 10,
-	_ResourceTypeName[308:338]:      11,
-	_ResourceTypeLowerName[308:338]: 11,
-	_ResourceTypeName[338:357]:      12,
-	_ResourceTypeLowerName[338:357]: 12,
-	_ResourceTypeName[357:378]:      13,
-	_ResourceTypeLowerName[357:378]: 13,
-	_ResourceTypeName[378:406]:      14,
-	_ResourceTypeLowerName[378:406]: 14,
+	_ResourceTypeName[0:22]:         0,
+	_ResourceTypeLowerName[0:22]:    0,
+	_ResourceTypeName[22:45]:        1,
+	_ResourceTypeLowerName[22:45]:   1,
+	_ResourceTypeName[45:68]:        2,
+	_ResourceTypeLowerName[45:68]:   2,
+	_ResourceTypeName[68:89]:        3,
+	_ResourceTypeLowerName[68:89]:   3,
+	_ResourceTypeName[89:116]:       4,
+	_ResourceTypeLowerName[89:116]:  4,
+	_ResourceTypeName[116:145]:      5,
+	_ResourceTypeLowerName[116:145]: 5,
+	_ResourceTypeName[145:175]:      6,
+	_ResourceTypeLowerName[145:175]: 6,
+	_ResourceTypeName[175:204]:      7,
+	_ResourceTypeLowerName[175:204]: 7,
+	_ResourceTypeName[204:234]:      8,
+	_ResourceTypeLowerName[204:234]: 8,
+	_ResourceTypeName[234:256]:      9,
+	_ResourceTypeLowerName[234:256]: 9,
+	_ResourceTypeName[256:288]:      10,
+	_ResourceTypeLowerName[256:288]: 10,
+	_ResourceTypeName[288:321]:      11,
+	_ResourceTypeLowerName[288:321]: 11,
+	_ResourceTypeName[321:358]:      12,
+	_ResourceTypeLowerName[321:358]: 12,
+	_ResourceTypeName[358:388]:      13,
+	_ResourceTypeLowerName[358:388]: 13,
+	_ResourceTypeName[388:417]:      14,
+	_ResourceTypeLowerName[388:417]: 14,
+	_ResourceTypeName[417:436]:      15,
+	_ResourceTypeLowerName[417:436]: 15,
+	_ResourceTypeName[436:457]:      16,
+	_ResourceTypeLowerName[436:457]: 16,
+	_ResourceTypeName[457:482]:      17,
+	_ResourceTypeLowerName[457:482]: 17,
+	_ResourceTypeName[482:510]:      18,
+	_ResourceTypeLowerName[482:510]: 18,
+	_ResourceTypeName[510:529]:      19,
+	_ResourceTypeLowerName[510:529]: 19,
+	_ResourceTypeName[529:544]:      20,
+	_ResourceTypeLowerName[529:544]: 20,
+	_ResourceTypeName[544:567]:      21,
+	_ResourceTypeLowerName[544:567]: 21,
+	_ResourceTypeName[567:590]:      22,
+	_ResourceTypeLowerName[567:590]: 22,
+	_ResourceTypeName[590:615]:      23,
+	_ResourceTypeLowerName[590:615]: 23,
+	_ResourceTypeName[615:641]:      24,
+	_ResourceTypeLowerName[615:641]: 24,
+	_ResourceTypeName[641:660]:      25,
+	_ResourceTypeLowerName[641:660]: 25,
+	_ResourceTypeName[660:683]:      26,
+	_ResourceTypeLowerName[660:683]: 26,
+	_ResourceTypeName[683:710]:      27,
+	_ResourceTypeLowerName[683:710]: 27,
+	_ResourceTypeName[710:732]:      28,
+	_ResourceTypeLowerName[710:732]: 28,
+	_ResourceTypeName[732:765]:      29,
+	_ResourceTypeLowerName[732:765]: 29,
+	_ResourceTypeName[765:799]:      30,
+	_ResourceTypeLowerName[765:799]: 30,
+	_ResourceTypeName[799:813]:      31,
+	_ResourceTypeLowerName[799:813]: 31,
+	_ResourceTypeName[813:835]:      32,
+	_ResourceTypeLowerName[813:835]: 32,
+	_ResourceTypeName[835:861]:      33,
+	_ResourceTypeLowerName[835:861]: 33,
+	_ResourceTypeName[861:904]:      34,
+	_ResourceTypeLowerName[861:904]: 34,
+	_ResourceTypeName[904:946]:      35,
+	_ResourceTypeLowerName[904:946]: 35,
+	_ResourceTypeName[946:993]:      36,
+	_ResourceTypeLowerName[946:993]: 36,
 }
 
 var _ResourceTypeNames = []string{
-	_ResourceTypeName[0:23],
-	_ResourceTypeName[23:46],
-	_ResourceTypeName[46:68],
-	_ResourceTypeName[68:95],
-	_ResourceTypeName[95:124],
-	_ResourceTypeName[124:154],
-	_ResourceTypeName[154:184],
-	_ResourceTypeName[184:216],
-	_ResourceTypeName[216:249],
-	_ResourceTypeName[249:271],
-	_ResourceTypeName[271:308],
-	_ResourceTypeName[308:338],
-	_ResourceTypeName[338:357],
-	_ResourceTypeName[357:378],
-	_ResourceTypeName[378:406],
+	_ResourceTypeName[0:22],
+	_ResourceTypeName[22:45],
+	_ResourceTypeName[45:68],
+	_ResourceTypeName[68:89],
+	_ResourceTypeName[89:116],
+	_ResourceTypeName[116:145],
+	_ResourceTypeName[145:175],
+	_ResourceTypeName[175:204],
+	_ResourceTypeName[204:234],
+	_ResourceTypeName[234:256],
+	_ResourceTypeName[256:288],
+	_ResourceTypeName[288:321],
+	_ResourceTypeName[321:358],
+	_ResourceTypeName[358:388],
+	_ResourceTypeName[388:417],
+	_ResourceTypeName[417:436],
+	_ResourceTypeName[436:457],
+	_ResourceTypeName[457:482],
+	_ResourceTypeName[482:510],
+	_ResourceTypeName[510:529],
+	_ResourceTypeName[529:544],
+	_ResourceTypeName[544:567],
+	_ResourceTypeName[567:590],
+	_ResourceTypeName[590:615],
+	_ResourceTypeName[615:641],
+	_ResourceTypeName[641:660],
+	_ResourceTypeName[660:683],
+	_ResourceTypeName[683:710],
+	_ResourceTypeName[710:732],
+	_ResourceTypeName[732:765],
+	_ResourceTypeName[765:799],
+	_ResourceTypeName[799:813],
+	_ResourceTypeName[813:835],
+	_ResourceTypeName[835:861],
+	_ResourceTypeName[861:904],
+	_ResourceTypeName[904:946],
+	_ResourceTypeName[946:993],
 }
 
 // ResourceTypeString retrieves an enum value from the enum constants string name.
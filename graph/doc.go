@@ -0,0 +1,4 @@
+// Package graph builds the dependency graph of the
+// imported resources and renders it as Graphviz DOT
+// or Mermaid
+package graph
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// Node is a resource of the Graph
+type Node struct {
+	// Key is the '<type>.<name>' of the resource
+	Key  string
+	Type string
+	ID   string
+}
+
+// Edge is a dependency from the resource
+// From to the resource To, because the
+// Attribute of From has the ID of To
+type Edge struct {
+	From      string
+	To        string
+	Attribute string
+}
+
+// Graph is the dependency graph of the resources
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// New builds the Graph of the resources, with the keys in
+// the order of the Nodes. The dependencies are calculated by
+// checking which attributes have the ID of other resource
+func New(keys []string, resources map[string]provider.Resource) *Graph {
+	g := &Graph{
+		Nodes: make([]Node, 0, len(keys)),
+		Edges: make([]Edge, 0),
+	}
+
+	ids := make(map[string]string, len(keys))
+	for _, k := range keys {
+		r := resources[k]
+		g.Nodes = append(g.Nodes, Node{
+			Key:  k,
+			Type: r.Type(),
+			ID:   r.ID(),
+		})
+		if r.ID() != "" {
+			ids[r.ID()] = k
+		}
+	}
+
+	for _, k := range keys {
+		deps := make(map[string]string)
+		walk(provider.HCLConfig(resources[k]), "", func(attr, v string) {
+			to, ok := ids[v]
+			if !ok || to == k {
+				return
+			}
+			if _, ok := deps[to]; !ok {
+				deps[to] = attr
+			}
+		})
+
+		tos := make([]string, 0, len(deps))
+		for to := range deps {
+			tos = append(tos, to)
+		}
+		sort.Strings(tos)
+
+		for _, to := range tos {
+			g.Edges = append(g.Edges, Edge{From: k, To: to, Attribute: deps[to]})
+		}
+	}
+
+	return g
+}
+
+// walk calls fn with all the string values of v
+// and the attribute path in which they are
+func walk(v interface{}, attr string, fn func(attr, v string)) {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		for k, e := range vv {
+			k = strings.TrimPrefix(k, "=tc=")
+			if attr != "" {
+				k = attr + "." + k
+			}
+			walk(e, k, fn)
+		}
+	case []interface{}:
+		for _, e := range vv {
+			walk(e, attr, fn)
+		}
+	case string:
+		fn(attr, vv)
+	}
+}
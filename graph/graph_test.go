@@ -0,0 +1,127 @@
+package graph_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cycloidio/terracognita/graph"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var tfr = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name":      &schema.Schema{Type: schema.TypeString, Optional: true},
+		"vpc_id":    &schema.Schema{Type: schema.TypeString, Optional: true},
+		"subnet_id": &schema.Schema{Type: schema.TypeString, Optional: true},
+		"security_groups": &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	},
+}
+
+// resources returns the resources of a VPC,
+// a subnet on it and an instance on the subnet
+func resources(ctrl *gomock.Controller) ([]string, map[string]provider.Resource) {
+	var (
+		vpc    = mock.NewResource(ctrl)
+		subnet = mock.NewResource(ctrl)
+		sg     = mock.NewResource(ctrl)
+		i      = mock.NewResource(ctrl)
+
+		dvpc    = tfr.Data(nil)
+		dsubnet = tfr.Data(nil)
+		dsg     = tfr.Data(nil)
+		di      = tfr.Data(nil)
+	)
+
+	dvpc.Set("name", "vpc-1")
+	dsubnet.Set("vpc_id", "vpc-1")
+	dsg.Set("vpc_id", "vpc-1")
+	di.Set("subnet_id", "subnet-1")
+	di.Set("security_groups", []interface{}{"sg-1"})
+
+	for _, r := range []struct {
+		r  *mock.Resource
+		t  string
+		id string
+		d  *schema.ResourceData
+	}{
+		{r: vpc, t: "aws_vpc", id: "vpc-1", d: dvpc},
+		{r: subnet, t: "aws_subnet", id: "subnet-1", d: dsubnet},
+		{r: sg, t: "aws_security_group", id: "sg-1", d: dsg},
+		{r: i, t: "aws_instance", id: "i-1", d: di},
+	} {
+		r.r.EXPECT().Type().Return(r.t).AnyTimes()
+		r.r.EXPECT().ID().Return(r.id).AnyTimes()
+		r.r.EXPECT().Data().Return(r.d).AnyTimes()
+		r.r.EXPECT().TFResource().Return(tfr).AnyTimes()
+	}
+
+	return []string{"aws_vpc.main", "aws_subnet.private", "aws_security_group.web", "aws_instance.front"}, map[string]provider.Resource{
+		"aws_vpc.main":           vpc,
+		"aws_subnet.private":     subnet,
+		"aws_security_group.web": sg,
+		"aws_instance.front":     i,
+	}
+}
+
+func TestNew(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	keys, rs := resources(ctrl)
+	g := graph.New(keys, rs)
+
+	assert.Equal(t, []graph.Node{
+		{Key: "aws_vpc.main", Type: "aws_vpc", ID: "vpc-1"},
+		{Key: "aws_subnet.private", Type: "aws_subnet", ID: "subnet-1"},
+		{Key: "aws_security_group.web", Type: "aws_security_group", ID: "sg-1"},
+		{Key: "aws_instance.front", Type: "aws_instance", ID: "i-1"},
+	}, g.Nodes)
+	assert.Equal(t, []graph.Edge{
+		{From: "aws_subnet.private", To: "aws_vpc.main", Attribute: "vpc_id"},
+		{From: "aws_security_group.web", To: "aws_vpc.main", Attribute: "vpc_id"},
+		{From: "aws_instance.front", To: "aws_security_group.web", Attribute: "security_groups"},
+		{From: "aws_instance.front", To: "aws_subnet.private", Attribute: "subnet_id"},
+	}, g.Edges)
+}
+
+func TestGraph_Render(t *testing.T) {
+	g := &graph.Graph{
+		Nodes: []graph.Node{
+			{Key: "aws_vpc.main", Type: "aws_vpc", ID: "vpc-1"},
+			{Key: "aws_subnet.private", Type: "aws_subnet", ID: "subnet-1"},
+		},
+		Edges: []graph.Edge{
+			{From: "aws_subnet.private", To: "aws_vpc.main", Attribute: "vpc_id"},
+		},
+	}
+
+	t.Run("DOT", func(t *testing.T) {
+		b := &bytes.Buffer{}
+		require.NoError(t, g.Render(b, graph.DOT))
+		assert.Equal(t, `digraph terracognita {
+  rankdir = "LR";
+  "aws_vpc.main";
+  "aws_subnet.private";
+  "aws_subnet.private" -> "aws_vpc.main" [label="vpc_id"];
+}
+`, b.String())
+	})
+	t.Run("Mermaid", func(t *testing.T) {
+		b := &bytes.Buffer{}
+		require.NoError(t, g.Render(b, graph.Mermaid))
+		assert.Equal(t, `graph LR
+  aws_vpc_main["aws_vpc.main"]
+  aws_subnet_private["aws_subnet.private"]
+  aws_subnet_private -->|vpc_id| aws_vpc_main
+`, b.String())
+	})
+}
@@ -0,0 +1,63 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Format is the format in which
+// the Graph is rendered
+type Format int
+
+// List of all the Formats supported
+const (
+	DOT Format = iota
+	Mermaid
+)
+
+// Render writes the g with the format f to w
+func (g *Graph) Render(w io.Writer, f Format) error {
+	var b strings.Builder
+
+	switch f {
+	case DOT:
+		b.WriteString("digraph terracognita {\n")
+		b.WriteString("  rankdir = \"LR\";\n")
+		for _, n := range g.Nodes {
+			fmt.Fprintf(&b, "  %q;\n", n.Key)
+		}
+		for _, e := range g.Edges {
+			fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", e.From, e.To, e.Attribute)
+		}
+		b.WriteString("}\n")
+	case Mermaid:
+		b.WriteString("graph LR\n")
+		for _, n := range g.Nodes {
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", mermaidID(n.Key), n.Key)
+		}
+		for _, e := range g.Edges {
+			fmt.Fprintf(&b, "  %s -->|%s| %s\n", mermaidID(e.From), e.Attribute, mermaidID(e.To))
+		}
+	default:
+		return errors.Errorf("invalid format %d", f)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	if err != nil {
+		return errors.Wrap(err, "error while writing the graph")
+	}
+
+	return nil
+}
+
+var invalidMermaidIDRe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// mermaidID returns the key as
+// a valid Mermaid node ID
+func mermaidID(key string) string {
+	return invalidMermaidIDRe.ReplaceAllString(key, "_")
+}
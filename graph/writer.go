@@ -0,0 +1,31 @@
+package graph
+
+import (
+	"io"
+
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// Writer is a Writer implementation that renders
+// the Graph of all the resources written on Sync
+type Writer struct {
+	*provider.Collector
+
+	format Format
+	writer io.Writer
+}
+
+// NewWriter returns a Writer initialization
+// that renders the Graph with the format f
+func NewWriter(w io.Writer, f Format) *Writer {
+	return &Writer{
+		Collector: provider.NewCollector("graph"),
+		format:    f,
+		writer:    w,
+	}
+}
+
+// Sync builds the Graph and renders it to the internal w
+func (w *Writer) Sync() error {
+	return New(w.Keys(), w.Resources()).Render(w.writer, w.format)
+}
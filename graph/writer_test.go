@@ -0,0 +1,57 @@
+package graph_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/graph"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl     = gomock.NewController(t)
+			b        = &bytes.Buffer{}
+			gw       = graph.NewWriter(b, graph.Mermaid)
+			keys, rs = resources(ctrl)
+		)
+		defer ctrl.Finish()
+
+		for _, k := range keys[:2] {
+			require.NoError(t, gw.Write(k, rs[k]))
+		}
+
+		ok, err := gw.Has("aws_vpc.main")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, gw.Sync())
+		assert.Equal(t, `graph LR
+  aws_vpc_main["aws_vpc.main"]
+  aws_subnet_private["aws_subnet.private"]
+  aws_subnet_private -->|vpc_id| aws_vpc_main
+`, b.String())
+	})
+	t.Run("Errors", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			gw   = graph.NewWriter(nil, graph.DOT)
+			r    = mock.NewResource(ctrl)
+		)
+		defer ctrl.Finish()
+
+		assert.Equal(t, errcode.ErrWriterRequiredKey, errors.Cause(gw.Write("", r)))
+		assert.Equal(t, errcode.ErrWriterRequiredValue, errors.Cause(gw.Write("type.name", nil)))
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(gw.Write("type", r)))
+		assert.Equal(t, errcode.ErrWriterInvalidTypeValue, errors.Cause(gw.Write("type.name", "value")))
+
+		require.NoError(t, gw.Write("type.name", r))
+		assert.Equal(t, errcode.ErrWriterAlreadyExistsKey, errors.Cause(gw.Write("type.name", r)))
+	})
+}
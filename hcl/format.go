@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 )
 
 var (
@@ -55,15 +57,46 @@ var (
 			match:   regexp.MustCompile("}\n"),
 			replace: []byte("}\n\n"),
 		},
+		{
+			// Replace the multiline strings for heredocs
+			// so they are readable, like 'user_data'
+			match:     regexp.MustCompile(`(?m)=\s"(?:[^"\\\n]|\\.)*\\n(?:[^"\\\n]|\\.)*"$`),
+			replaceFn: heredoc,
+		},
 		{
 			// Remove "" from resources definition like
 			// '"resource" "aws_lb" "front {' -> 'resource "aws_lb" "front" {'
 			match:   regexp.MustCompile(`"([\w\-_\.]+)"\s("(?:[\w\-_\.]+)")\s("(?:[\w\-_\.]+)")\s{`),
 			replace: []byte(`$1 $2 $3 {`),
 		},
+		{
+			// Remove "" from the blocks definition with only
+			// the name like '"variable" "name" {' -> 'variable "name" {'
+			match:   regexp.MustCompile(`(?m)^"(variable|module)"\s("(?:[\w\-_\.]+)")\s{`),
+			replace: []byte(`$1 $2 {`),
+		},
+		{
+			// Unescape the quotes of the paths of the functions
+			// reading the files, as those are on the interpolation,
+			// like '"${file(\"${path.module}/files/policy.json\")}"'
+			match:   regexp.MustCompile(`"\$\{(file|filebase64)\(\\"([^"\\]+)\\"\)\}"`),
+			replace: []byte(`"${${1}("${2}")}"`),
+		},
 	}
 )
 
+// providerReferenceRe matches the provider references of the
+// resources and data sources, like 'provider = "aws.shared"'
+var providerReferenceRe = regexp.MustCompile(`(?m)^(  provider\s+=\s+)"([\w\-]+\.[\w\-]+)"$`)
+
+// unquoteProviders removes the "" from the provider references,
+// like 'provider = "aws.shared"' -> 'provider = aws.shared', as
+// those are references and not strings. It has to be done after
+// the 'fmtcmd' as the HCL1 does not support them
+func unquoteProviders(hcl []byte) []byte {
+	return providerReferenceRe.ReplaceAll(hcl, []byte(`${1}$2`))
+}
+
 // Format formats the hcl to have a better formatter that the default one
 // returned from HCL printer.Fprint
 func Format(hcl []byte) []byte {
@@ -77,3 +110,20 @@ func Format(hcl []byte) []byte {
 
 	return hcl
 }
+
+// heredoc converts the '= "multiline string"' m
+// to a heredoc if it ends with a new line, as the
+// heredocs always have it
+func heredoc(m []byte) []byte {
+	s, err := strconv.Unquote(string(bytes.TrimSpace(bytes.TrimPrefix(m, []byte("=")))))
+	if err != nil || !strings.HasSuffix(s, "\n") {
+		return m
+	}
+
+	marker := "EOF"
+	for i := 0; strings.Contains("\n"+s, "\n"+marker+"\n"); i++ {
+		marker = fmt.Sprintf("EOF%d", i)
+	}
+
+	return []byte(fmt.Sprintf("= <<%s\n%s%s", marker, s, marker))
+}
@@ -128,6 +128,34 @@ func TestFormat(t *testing.T) {
 				role = value
 			}`),
 		},
+		{
+			name: "ReplaceMultilineStringsForHeredocs",
+			in: []byte(`
+				"user_data" = "#!/bin/bash\necho \"\u003cEOF\u003e\"\nEOF\n"
+				"description" = "no\nnew line at the end"
+			`),
+			out: []byte(`
+				user_data = <<EOF0
+#!/bin/bash
+echo "<EOF>"
+EOF
+EOF0
+				description = "no\nnew line at the end"
+			`),
+		},
+		{
+			name: "UnescapeFilePaths",
+			in: []byte(`
+				"dashboard_body" = "${file(\"${path.module}/files/aws_cloudwatch_dashboard.main.dashboard_body.json\")}"
+				"user_data_base64" = "${filebase64(\"${path.module}/files/aws_instance.front.user_data_base64\")}"
+				"description" = "${var.description}"
+			`),
+			out: []byte(`
+				dashboard_body = "${file("${path.module}/files/aws_cloudwatch_dashboard.main.dashboard_body.json")}"
+				user_data_base64 = "${filebase64("${path.module}/files/aws_instance.front.user_data_base64")}"
+				description = "${var.description}"
+			`),
+		},
 	}
 
 	for _, tt := range tests {
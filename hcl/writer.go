@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
+	"sort"
 	"strings"
 
 	kitlog "github.com/go-kit/kit/log"
@@ -14,7 +16,10 @@ import (
 	"github.com/hashicorp/hcl"
 	"github.com/hashicorp/hcl/hcl/fmtcmd"
 	"github.com/hashicorp/hcl/hcl/printer"
+	"github.com/hashicorp/hcl2/hclwrite"
 	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 )
 
 // Writer is a Writer implementation that writes to
@@ -23,20 +28,57 @@ type Writer struct {
 	// TODO: Change it to "map[string]map[string]schema.ResourceData"
 	Config map[string]interface{}
 	writer io.Writer
+
+	// header is written at the top of the HCL and
+	// comments are the comments of the blocks by
+	// the key of them without the 'comment.'
+	header   string
+	comments map[string]string
+
+	// terraform is the 'terraform' block
+	// written after the header
+	terraform string
 }
 
+// commentPrefix is the prefix of the keys
+// of the comments of the blocks
+const commentPrefix = "comment."
+
+// blockLineRe matches the first line of the formatted
+// resources, data sources and modules, with the block,
+// the type and the name of it, the modules have only name
+var blockLineRe = regexp.MustCompile(`^(resource|data|module) "([^"]+)"(?: "([^"]+)")? {$`)
+
 // NewWriter rerturns an Writer initialization
 func NewWriter(w io.Writer) *Writer {
 	cfg := make(map[string]interface{})
 	cfg["resource"] = make(map[string]map[string]interface{})
 	return &Writer{
-		Config: cfg,
-		writer: w,
+		Config:   cfg,
+		writer:   w,
+		comments: make(map[string]string),
 	}
 }
 
-// Write expects a key similar to "aws_instance.your_name"
-// repeated keys will report an error
+// SetHeader sets the h to be written at the top of the
+// HCL on the Sync, the lines of it are commented (see Comment)
+func (w *Writer) SetHeader(h string) {
+	w.header = h
+}
+
+// SetTerraform sets the 'terraform' block t (ex: with the
+// backend) to be written at the top of the HCL on the Sync
+func (w *Writer) SetTerraform(t string) {
+	w.terraform = t
+}
+
+// Write expects a key similar to "aws_instance.your_name",
+// "data.aws_subnet.your_name" for data sources, "variable.your_name"
+// for variables, "module.your_name" for modules or
+// "provider.aws.your_alias" for the aliased providers,
+// repeated keys will report an error.
+// The keys prefixed with "comment." (ex: "comment.aws_instance.your_name")
+// are comments, a string, written before the block of the key
 func (w *Writer) Write(key string, value interface{}) error {
 	if key == "" {
 		return errcode.ErrWriterRequiredKey
@@ -46,57 +88,152 @@ func (w *Writer) Write(key string, value interface{}) error {
 		return errcode.ErrWriterRequiredValue
 	}
 
-	keys := strings.Split(key, ".")
-	if len(keys) != 2 || (keys[0] == "" || keys[1] == "") {
-		return errors.Wrapf(errcode.ErrWriterInvalidKey, "with key %q", key)
+	if strings.HasPrefix(key, commentPrefix) {
+		return w.writeComment(strings.TrimPrefix(key, commentPrefix), value)
 	}
 
-	name := strings.Join(keys[1:], "")
+	block, rt, name, err := splitKey(key)
+	if err != nil {
+		return err
+	}
 
-	if _, ok := w.Config["resource"].(map[string]map[string]interface{})[keys[0]][name]; ok {
+	if _, ok := w.Config[block]; !ok {
+		w.Config[block] = make(map[string]map[string]interface{})
+	}
+
+	blocks := w.Config[block].(map[string]map[string]interface{})
+
+	// The variables and modules have no
+	// type, so the rt is the name of it
+	if block == "variable" || block == "module" {
+		if _, ok := blocks[rt]; ok {
+			return errors.Wrapf(errcode.ErrWriterAlreadyExistsKey, "with key %q", key)
+		}
+
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.Wrapf(errcode.ErrWriterInvalidTypeValue, "expected map[string]interface{}, found %T", value)
+		}
+
+		log.Get().Log("func", "writer.Write(HCL)", "msg", "writing to internal config", "key", key)
+		blocks[rt] = v
+
+		return nil
+	}
+
+	if _, ok := blocks[rt][name]; ok {
 		return errors.Wrapf(errcode.ErrWriterAlreadyExistsKey, "with key %q", key)
 	}
 
-	if _, ok := w.Config["resource"].(map[string]map[string]interface{})[keys[0]]; !ok {
-		w.Config["resource"].(map[string]map[string]interface{})[keys[0]] = make(map[string]interface{})
+	if _, ok := blocks[rt]; !ok {
+		blocks[rt] = make(map[string]interface{})
 	}
 
 	b, err := json.Marshal(value)
 	if err != nil {
 		return err
 	}
-	log.Get().Log("func", "writer.Write(HCL)", "msg", "writing to internal config", "key", keys[0], "content", string(b))
+	log.Get().Log("func", "writer.Write(HCL)", "msg", "writing to internal config", "key", rt, "content", string(b))
+
+	blocks[rt][name] = value
+
+	return nil
+}
+
+// writeComment sets the value as the comment
+// of the resource or data source of the key
+func (w *Writer) writeComment(key string, value interface{}) error {
+	block, _, _, err := splitKey(key)
+	if err != nil {
+		return err
+	}
+	if block != "resource" && block != "data" && block != "module" {
+		return errors.Wrapf(errcode.ErrWriterInvalidKey, "only the resources, data sources and modules can have comments, found %q", key)
+	}
 
-	w.Config["resource"].(map[string]map[string]interface{})[keys[0]][name] = value
+	c, ok := value.(string)
+	if !ok {
+		return errors.Wrapf(errcode.ErrWriterInvalidTypeValue, "expected string, found %T", value)
+	}
+
+	log.Get().Log("func", "writer.Write(HCL)", "msg", "writing comment to internal config", "key", key)
+	w.comments[key] = c
 
 	return nil
 }
 
 // Has checks if the given key is already present or not
 func (w *Writer) Has(key string) (bool, error) {
-	keys := strings.Split(key, ".")
-	if len(keys) != 2 || keys[0] == "" || keys[1] == "" {
-		return false, errors.Wrapf(errcode.ErrWriterInvalidKey, "with key %q", key)
+	block, rt, name, err := splitKey(key)
+	if err != nil {
+		return false, err
 	}
 
-	name := strings.Join(keys[1:], "")
-
-	if _, ok := w.Config["resource"].(map[string]map[string]interface{})[keys[0]][name]; ok {
-		return false, errors.Wrapf(errcode.ErrWriterAlreadyExistsKey, "with key %q", key)
+	if blocks, ok := w.Config[block]; ok {
+		if block == "variable" || block == "module" {
+			if _, ok := blocks.(map[string]map[string]interface{})[rt]; ok {
+				return false, errors.Wrapf(errcode.ErrWriterAlreadyExistsKey, "with key %q", key)
+			}
+		} else if _, ok := blocks.(map[string]map[string]interface{})[rt][name]; ok {
+			return false, errors.Wrapf(errcode.ErrWriterAlreadyExistsKey, "with key %q", key)
+		}
 	}
 
 	return true, nil
 }
 
+// splitKey splits the key into the block ("resource", "data" or
+// "provider"), the resource type and the name. For the "variable"
+// and "module" blocks the name of them is returned as the resource
+// type and for the "provider" the name is the alias
+func splitKey(key string) (string, string, string, error) {
+	block := "resource"
+	keys := strings.Split(key, ".")
+	if len(keys) == 3 && (keys[0] == "data" || keys[0] == "provider") {
+		block = keys[0]
+		keys = keys[1:]
+	} else if len(keys) == 2 && (keys[0] == "variable" || keys[0] == "module") {
+		if keys[1] == "" {
+			return "", "", "", errors.Wrapf(errcode.ErrWriterInvalidKey, "with key %q", key)
+		}
+		return keys[0], keys[1], "", nil
+	}
+
+	if len(keys) != 2 || keys[0] == "" || keys[1] == "" {
+		return "", "", "", errors.Wrapf(errcode.ErrWriterInvalidKey, "with key %q", key)
+	}
+
+	return block, keys[0], keys[1], nil
+}
+
 // Sync writes the content of the Config to the
 // internal w with the correct format
 func (w *Writer) Sync() error {
 	logger := log.Get()
 	logger = kitlog.With(logger, "func", "writer.Write(HCL)")
-	b, err := json.Marshal(w.Config)
-	if err != nil {
+
+	// The empty blocks are not written as
+	// they would be invalid HCL for TF
+	// The providers are written apart as the
+	// aliases of the same provider are
+	// multiple blocks with the same name
+	cfg := make(map[string]interface{})
+	for k, v := range w.Config {
+		if blocks, ok := v.(map[string]map[string]interface{}); (ok && len(blocks) == 0) || k == "provider" {
+			continue
+		}
+		cfg[k] = v
+	}
+
+	// The HTML characters are not escaped so the
+	// values (ex: version = "~> 3.0") are kept as they are
+	jb := &bytes.Buffer{}
+	enc := json.NewEncoder(jb)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(cfg); err != nil {
 		return err
 	}
+	b := jb.Bytes()
 
 	logger.Log("msg", "parsing internal config to HCL", "json", string(b))
 	f, err := hcl.ParseBytes(b)
@@ -115,11 +252,143 @@ func (w *Writer) Sync() error {
 	formattedHCL := Format(buff.Bytes())
 	logger.Log("msg", "formatted HCL", "hcl", formattedHCL)
 
-	buff = bytes.NewBuffer(formattedHCL)
+	buff = &bytes.Buffer{}
+	if w.header != "" {
+		fmt.Fprintf(buff, "%s\n", Comment(w.header))
+	}
+	if w.terraform != "" {
+		fmt.Fprintf(buff, "%s\n", w.terraform)
+	}
+	p, err := w.providers()
+	if err != nil {
+		return err
+	}
+	buff.Write(p)
+	buff.Write(w.withComments(formattedHCL))
 
-	err = fmtcmd.Run(nil, nil, buff, w.writer, fmtcmd.Options{})
+	fmtBuff := &bytes.Buffer{}
+	err = fmtcmd.Run(nil, nil, buff, fmtBuff, fmtcmd.Options{})
 	if err != nil {
 		return fmt.Errorf("error while fmt HCL: %s", err)
 	}
+
+	// The canonical format is the one of the
+	// 'terraform fmt', so it's not needed after
+	_, err = w.writer.Write(hclwrite.Format(unquoteProviders(fmtBuff.Bytes())))
+	if err != nil {
+		return fmt.Errorf("error while writing HCL: %s", err)
+	}
 	return nil
 }
+
+// providers returns the "provider" blocks of the Config,
+// one for each alias with the alias as an attribute
+func (w *Writer) providers() ([]byte, error) {
+	f := hclwrite.NewEmptyFile()
+
+	blocks, ok := w.Config["provider"].(map[string]map[string]interface{})
+	if !ok {
+		return f.Bytes(), nil
+	}
+
+	types := make([]string, 0, len(blocks))
+	for t := range blocks {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	for _, t := range types {
+		for _, alias := range sortedKeys(blocks[t]) {
+			cfg, _ := blocks[t][alias].(map[string]interface{})
+
+			b := f.Body().AppendNewBlock("provider", []string{t}).Body()
+			b.SetAttributeValue("alias", cty.StringVal(alias))
+			for _, k := range sortedKeys(cfg) {
+				if k == "alias" {
+					continue
+				}
+				v, err := ctyValue(cfg[k])
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid value of %q on provider %q with alias %q", k, t, alias)
+				}
+				b.SetAttributeValue(k, v)
+			}
+			f.Body().AppendNewline()
+		}
+	}
+
+	return f.Bytes(), nil
+}
+
+// ctyValue converts the v to the cty.Value with
+// the type implied by the JSON of it, so the lists
+// and maps are written as HCL lists and objects
+func ctyValue(v interface{}) (cty.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	t, err := ctyjson.ImpliedType(b)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	return ctyjson.Unmarshal(b, t)
+}
+
+// withComments returns the b with the comments
+// before the blocks of them
+func (w *Writer) withComments(b []byte) []byte {
+	if len(w.comments) == 0 {
+		return b
+	}
+
+	buff := &bytes.Buffer{}
+	for _, l := range strings.SplitAfter(string(b), "\n") {
+		if m := blockLineRe.FindStringSubmatch(strings.TrimSuffix(l, "\n")); m != nil {
+			key := fmt.Sprintf("%s.%s", m[2], m[3])
+			if m[1] == "data" {
+				key = "data." + key
+			} else if m[1] == "module" {
+				key = "module." + m[2]
+			}
+			buff.WriteString(Comment(w.comments[key]))
+		}
+		buff.WriteString(l)
+	}
+
+	return buff.Bytes()
+}
+
+// Comment returns the text as HCL comments, the lines
+// that are already comments are kept as they are
+func Comment(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	buff := &bytes.Buffer{}
+	for _, l := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if tl := strings.TrimSpace(l); strings.HasPrefix(tl, "#") || strings.HasPrefix(tl, "//") {
+			buff.WriteString(l)
+		} else if tl == "" {
+			buff.WriteString("#")
+		} else {
+			buff.WriteString("# " + l)
+		}
+		buff.WriteString("\n")
+	}
+
+	return buff.String()
+}
+
+// sortedKeys returns the keys of the m sorted
+func sortedKeys(m map[string]interface{}) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
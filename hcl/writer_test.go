@@ -44,6 +44,94 @@ func TestHCLWriter_Write(t *testing.T) {
 			},
 		}, hw.Config)
 	})
+	t.Run("SuccessProvider", func(t *testing.T) {
+		var (
+			b   = &bytes.Buffer{}
+			hw  = hcl.NewWriter(b)
+			hcl = `provider "aws" {
+  alias  = "eu_west_1"
+  region = "eu-west-1"
+}
+
+provider "aws" {
+  alias  = "us_east_1"
+  region = "us-east-1"
+}
+
+resource "aws_instance" "name" {
+  ami      = "ami-123"
+  provider = aws.us_east_1
+}
+`
+		)
+
+		err := hw.Write("aws_instance.name", map[string]interface{}{"ami": "ami-123", "provider": "aws.us_east_1"})
+		require.NoError(t, err)
+
+		err = hw.Write("provider.aws.us_east_1", map[string]interface{}{"region": "us-east-1"})
+		require.NoError(t, err)
+
+		err = hw.Write("provider.aws.eu_west_1", map[string]interface{}{"region": "eu-west-1"})
+		require.NoError(t, err)
+
+		err = hw.Sync()
+		require.NoError(t, err)
+
+		assert.Equal(t, hcl, b.String())
+	})
+	t.Run("SuccessDataSource", func(t *testing.T) {
+		var (
+			b     = &bytes.Buffer{}
+			hw    = hcl.NewWriter(b)
+			value = map[string]interface{}{
+				"id": "value",
+			}
+		)
+
+		err := hw.Write("data.type.name", value)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{
+			"resource": map[string]map[string]interface{}{},
+			"data": map[string]map[string]interface{}{
+				"type": map[string]interface{}{
+					"name": map[string]interface{}{
+						"id": "value",
+					},
+				},
+			},
+		}, hw.Config)
+
+		err = hw.Write("data.type.name", value)
+		assert.Equal(t, errcode.ErrWriterAlreadyExistsKey, errors.Cause(err))
+	})
+	t.Run("SuccessVariable", func(t *testing.T) {
+		var (
+			b     = &bytes.Buffer{}
+			hw    = hcl.NewWriter(b)
+			value = map[string]interface{}{
+				"description": "value",
+			}
+		)
+
+		err := hw.Write("variable.name", value)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{
+			"resource": map[string]map[string]interface{}{},
+			"variable": map[string]map[string]interface{}{
+				"name": map[string]interface{}{
+					"description": "value",
+				},
+			},
+		}, hw.Config)
+
+		err = hw.Write("variable.name", value)
+		assert.Equal(t, errcode.ErrWriterAlreadyExistsKey, errors.Cause(err))
+
+		err = hw.Write("variable.other", "value")
+		assert.Equal(t, errcode.ErrWriterInvalidTypeValue, errors.Cause(err))
+	})
 	t.Run("ErrRequiredKey", func(t *testing.T) {
 		var (
 			b  = &bytes.Buffer{}
@@ -76,6 +164,12 @@ func TestHCLWriter_Write(t *testing.T) {
 
 		err = hw.Write("type.", "")
 		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(err))
+
+		err = hw.Write("data.type.", "")
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(err))
+
+		err = hw.Write("variable.", "")
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(err))
 	})
 	t.Run("ErrAlreadyExistsKey", func(t *testing.T) {
 		var (
@@ -113,4 +207,224 @@ func TestHCLWriter_Sync(t *testing.T) {
 
 		assert.Equal(t, hcl, b.String())
 	})
+	t.Run("SuccessProviderValues", func(t *testing.T) {
+		var (
+			b   = &bytes.Buffer{}
+			hw  = hcl.NewWriter(b)
+			hcl = `provider "aws" {
+  alias               = "us_east_1"
+  allowed_account_ids = ["123456789012"]
+
+  default_tags = {
+    env = "prod"
+  }
+
+  max_retries = 5
+  region      = "us-east-1"
+}
+`
+		)
+
+		err := hw.Write("provider.aws.us_east_1", map[string]interface{}{
+			"region":              "us-east-1",
+			"max_retries":         5,
+			"allowed_account_ids": []string{"123456789012"},
+			"default_tags":        map[string]interface{}{"env": "prod"},
+		})
+		require.NoError(t, err)
+
+		err = hw.Sync()
+		require.NoError(t, err)
+
+		assert.Equal(t, hcl, b.String())
+	})
+	t.Run("SuccessDataSource", func(t *testing.T) {
+		var (
+			b     = &bytes.Buffer{}
+			hw    = hcl.NewWriter(b)
+			value = map[string]interface{}{
+				"id":       "value",
+				"provider": "aws.shared",
+			}
+			hcl = `data "type" "name" {
+  id       = "value"
+  provider = aws.shared
+}
+`
+		)
+
+		err := hw.Write("data.type.name", value)
+		require.NoError(t, err)
+
+		err = hw.Sync()
+		require.NoError(t, err)
+
+		assert.Equal(t, hcl, b.String())
+	})
+	t.Run("SuccessVariable", func(t *testing.T) {
+		var (
+			b     = &bytes.Buffer{}
+			hw    = hcl.NewWriter(b)
+			value = map[string]interface{}{
+				"password": "${var.type_name_password}",
+				"lifecycle": map[string]interface{}{
+					"ignore_changes": []interface{}{"password"},
+				},
+			}
+			hcl = `resource "type" "name" {
+  lifecycle {
+    ignore_changes = ["password"]
+  }
+
+  password = "${var.type_name_password}"
+}
+
+variable "type_name_password" {
+  description = "The password"
+}
+`
+		)
+
+		err := hw.Write("type.name", value)
+		require.NoError(t, err)
+
+		err = hw.Write("variable.type_name_password", map[string]interface{}{"description": "The password"})
+		require.NoError(t, err)
+
+		err = hw.Sync()
+		require.NoError(t, err)
+
+		assert.Equal(t, hcl, b.String())
+	})
+	t.Run("SuccessModule", func(t *testing.T) {
+		var (
+			b   = &bytes.Buffer{}
+			hw  = hcl.NewWriter(b)
+			hcl = `# Move them with:
+#   terraform state mv aws_vpc.main 'module.main.aws_vpc.this[0]'
+module "main" {
+  azs     = ["eu-west-1a", "eu-west-1b"]
+  cidr    = "10.0.0.0/16"
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "~> 3.0"
+}
+
+resource "aws_instance" "name" {
+  subnet_id = "${module.main.private_subnets[0]}"
+}
+`
+		)
+
+		err := hw.Write("module.main", map[string]interface{}{
+			"source":  "terraform-aws-modules/vpc/aws",
+			"version": "~> 3.0",
+			"cidr":    "10.0.0.0/16",
+			"azs":     []interface{}{"eu-west-1a", "eu-west-1b"},
+		})
+		require.NoError(t, err)
+
+		err = hw.Write("comment.module.main", "Move them with:\n  terraform state mv aws_vpc.main 'module.main.aws_vpc.this[0]'")
+		require.NoError(t, err)
+
+		err = hw.Write("aws_instance.name", map[string]interface{}{"subnet_id": "${module.main.private_subnets[0]}"})
+		require.NoError(t, err)
+
+		err = hw.Write("module.main", map[string]interface{}{"source": "other"})
+		assert.Equal(t, errcode.ErrWriterAlreadyExistsKey, errors.Cause(err))
+
+		err = hw.Sync()
+		require.NoError(t, err)
+
+		assert.Equal(t, hcl, b.String())
+	})
+}
+
+func TestHCLWriter_Comments(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			b   = &bytes.Buffer{}
+			hw  = hcl.NewWriter(b)
+			hcl = `# Copyright ACME
+# Generated by terracognita
+
+# Imported from aws eu-west-1 with ID ami-123
+data "aws_ami" "name" {
+  id = "ami-123"
+}
+
+resource "aws_instance" "name" {
+  ami = "ami-123"
+}
+
+# Imported from aws eu-west-1 with ID i-123
+resource "aws_instance" "other" {
+  ami = "ami-123"
+}
+`
+		)
+
+		hw.SetHeader("Copyright ACME\n# Generated by terracognita\n")
+
+		err := hw.Write("aws_instance.name", map[string]interface{}{"ami": "ami-123"})
+		require.NoError(t, err)
+
+		err = hw.Write("aws_instance.other", map[string]interface{}{"ami": "ami-123"})
+		require.NoError(t, err)
+
+		err = hw.Write("comment.aws_instance.other", "Imported from aws eu-west-1 with ID i-123")
+		require.NoError(t, err)
+
+		err = hw.Write("data.aws_ami.name", map[string]interface{}{"id": "ami-123"})
+		require.NoError(t, err)
+
+		err = hw.Write("comment.data.aws_ami.name", "Imported from aws eu-west-1 with ID ami-123")
+		require.NoError(t, err)
+
+		err = hw.Sync()
+		require.NoError(t, err)
+
+		assert.Equal(t, hcl, b.String())
+	})
+	t.Run("ErrInvalidKey", func(t *testing.T) {
+		hw := hcl.NewWriter(nil)
+
+		err := hw.Write("comment.variable.name", "comment")
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(err))
+	})
+	t.Run("ErrInvalidTypeValue", func(t *testing.T) {
+		hw := hcl.NewWriter(nil)
+
+		err := hw.Write("comment.aws_instance.name", 1)
+		assert.Equal(t, errcode.ErrWriterInvalidTypeValue, errors.Cause(err))
+	})
+}
+
+func TestHCLWriter_SetTerraform(t *testing.T) {
+	var (
+		b   = &bytes.Buffer{}
+		hw  = hcl.NewWriter(b)
+		hcl = `# Generated by terracognita
+
+terraform {
+  backend "gcs" {
+    bucket = "my-states"
+  }
+}
+
+resource "aws_instance" "name" {
+  ami = "ami-123"
+}
+`
+	)
+
+	hw.SetHeader("Generated by terracognita")
+	hw.SetTerraform("terraform {\n  backend \"gcs\" {\n    bucket = \"my-states\"\n  }\n}\n")
+
+	err := hw.Write("aws_instance.name", map[string]interface{}{"ami": "ami-123"})
+	require.NoError(t, err)
+
+	err = hw.Sync()
+	require.NoError(t, err)
+
+	assert.Equal(t, hcl, b.String())
 }
@@ -0,0 +1,5 @@
+// Package history records each import on an append-only
+// JSONL file of a directory, with the parameters, filters,
+// counts and hashes of the outputs of it, so teams can audit
+// what was imported, when and by whom
+package history
@@ -0,0 +1,323 @@
+package history
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// File is the name of the file of the
+// History on the directory of it
+const File = "history.jsonl"
+
+// List of the Status of the Run
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+)
+
+// Redacted is the value of the
+// sensitive flags on the Run
+const Redacted = "REDACTED"
+
+// Filters are the filters of the resources of a Run
+type Filters struct {
+	Include   []string `json:"include,omitempty"`
+	Exclude   []string `json:"exclude,omitempty"`
+	Targets   []string `json:"targets,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Rules     []string `json:"rules,omitempty"`
+	NameRegex string   `json:"name_regex,omitempty"`
+	Ignore    []string `json:"ignore,omitempty"`
+}
+
+// NewFilters returns the Filters of the f
+func NewFilters(f *filter.Filter) Filters {
+	var fs Filters
+	if f == nil {
+		return fs
+	}
+
+	fs.Include = f.Include
+	fs.Exclude = f.Exclude
+	fs.Targets = f.Targets
+	fs.Ignore = f.Ignore
+	for _, t := range f.Tags {
+		fs.Tags = append(fs.Tags, fmt.Sprintf("%s:%s", t.Name, t.Value))
+	}
+	for _, r := range f.Rules {
+		fs.Rules = append(fs.Rules, r.String())
+	}
+	if f.NameRegex != nil {
+		fs.NameRegex = f.NameRegex.String()
+	}
+	return fs
+}
+
+// Output is a file written by a Run with the
+// SHA256 of the content of it when it finished
+type Output struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// Run is an import recorded on the History
+type Run struct {
+	ID       string        `json:"id"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+
+	// User and Host are the ones
+	// that ran the import
+	User    string `json:"user"`
+	Host    string `json:"host"`
+	Version string `json:"version"`
+
+	Provider string `json:"provider"`
+	Command  string `json:"command"`
+
+	// Flags are the flags set on the Command, the
+	// sensitive ones with the Redacted value
+	Flags   map[string]string `json:"flags"`
+	Filters Filters           `json:"filters"`
+
+	Status  string           `json:"status"`
+	Error   string           `json:"error,omitempty"`
+	Summary provider.Summary `json:"summary"`
+	Outputs []Output         `json:"outputs"`
+}
+
+// NewRun returns the Run of the import of the provider p with
+// the cmd started at start, with the summary s and the err
+func NewRun(p, cmd string, s provider.Summary, start time.Time, err error) Run {
+	r := Run{
+		Time:     start.UTC(),
+		Duration: time.Since(start),
+		Provider: p,
+		Command:  cmd,
+		Flags:    make(map[string]string),
+		Status:   StatusSuccess,
+		Summary:  s,
+		Outputs:  make([]Output, 0),
+	}
+	if u, err := user.Current(); err == nil {
+		r.User = u.Username
+	}
+	r.Host, _ = os.Hostname()
+	if err != nil {
+		r.Status = StatusFailure
+		r.Error = err.Error()
+	}
+
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s", r.Time.Format(time.RFC3339Nano), r.User, r.Host, cmd)))
+	r.ID = hex.EncodeToString(h[:])[:12]
+
+	return r
+}
+
+// SetOutputs sets the Outputs of the Run with the paths
+// and the SHA256 of them, the one of a directory is
+// the one of the names and contents of all the files
+// on it, and the ones that do not exist have none
+func (r *Run) SetOutputs(paths []string) error {
+	r.Outputs = make([]Output, 0, len(paths))
+	for _, p := range paths {
+		sum, err := hashPath(p)
+		if err != nil {
+			return err
+		}
+		r.Outputs = append(r.Outputs, Output{Path: p, SHA256: sum})
+	}
+	return nil
+}
+
+// hashPath returns the SHA256 of the p
+func hashPath(p string) (string, error) {
+	fi, err := os.Stat(p)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Wrapf(err, "could not stat %s", p)
+	}
+
+	h := sha256.New()
+	if !fi.IsDir() {
+		if err := hashFile(h, p); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+
+	files := make([]string, 0)
+	err = filepath.Walk(p, func(fp string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.IsDir() {
+			files = append(files, fp)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "could not walk %s", p)
+	}
+
+	sort.Strings(files)
+	for _, fp := range files {
+		rel, _ := filepath.Rel(p, fp)
+		fmt.Fprintf(h, "%s\x00", filepath.ToSlash(rel))
+		if err := hashFile(h, fp); err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile writes the content of the file p to the w
+func hashFile(w io.Writer, p string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return errors.Wrapf(err, "could not open %s", p)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(w, f); err != nil {
+		return errors.Wrapf(err, "could not read %s", p)
+	}
+	return nil
+}
+
+// Query filters the Runs of the History
+type Query struct {
+	// Provider, if set, is the one of the Runs
+	Provider string
+
+	// Since, if set, is the minimum Time of the Runs
+	Since time.Time
+
+	// Limit, if set, is the maximum number
+	// of Runs, the most recent ones
+	Limit int
+}
+
+// match checks if the r matches the q
+func (q Query) match(r Run) bool {
+	if q.Provider != "" && q.Provider != r.Provider {
+		return false
+	}
+	if !q.Since.IsZero() && r.Time.Before(q.Since) {
+		return false
+	}
+	return true
+}
+
+// History is the append-only file of
+// the Runs on a directory
+type History struct {
+	dir string
+}
+
+// New returns the History of the dir,
+// which is created on the first Append
+func New(dir string) *History {
+	return &History{dir: dir}
+}
+
+// Path returns the path of the file of the History
+func (h *History) Path() string {
+	return filepath.Join(h.dir, File)
+}
+
+// Append appends the r to the History, the file is
+// only readable by the user as it has the parameters
+func (h *History) Append(r Run) error {
+	if err := os.MkdirAll(h.dir, 0700); err != nil {
+		return errors.Wrapf(err, "could not create the directory %s", h.dir)
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal the run")
+	}
+
+	f, err := os.OpenFile(h.Path(), os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "could not open %s", h.Path())
+	}
+
+	// The line is written with one Write so
+	// concurrent runs do not mix their lines
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "could not write to %s", h.Path())
+	}
+
+	return f.Close()
+}
+
+// List returns the Runs of the History matching the q,
+// from the oldest to the most recent one, none if the
+// History has not been written yet
+func (h *History) List(q Query) ([]Run, error) {
+	runs := make([]Run, 0)
+
+	f, err := os.Open(h.Path())
+	if os.IsNotExist(err) {
+		return runs, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "could not open %s", h.Path())
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	s.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 16*1024*1024)
+	for l := 1; s.Scan(); l++ {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+
+		var r Run
+		if err := json.Unmarshal(s.Bytes(), &r); err != nil {
+			return nil, errors.Wrapf(errcode.ErrHistoryInvalidRun, "on line %d of %s: %s", l, h.Path(), err)
+		}
+		if q.match(r) {
+			runs = append(runs, r)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrapf(err, "could not read %s", h.Path())
+	}
+
+	if q.Limit > 0 && len(runs) > q.Limit {
+		runs = runs[len(runs)-q.Limit:]
+	}
+
+	return runs, nil
+}
+
+// Write writes the runs as a table to the w
+func Write(w io.Writer, runs []Run) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID\tTIME\tUSER\tHOST\tPROVIDER\tSTATUS\tIMPORTED\tFAILED\tDURATION\n")
+	for _, r := range runs {
+		t := r.Summary.Total()
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\n", r.ID, r.Time.Format(time.RFC3339), r.User, r.Host, r.Provider, r.Status, t.Imported, t.Failed, r.Duration.Round(time.Second))
+	}
+	tw.Flush()
+}
@@ -0,0 +1,110 @@
+package history_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/history"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/tag"
+)
+
+func TestHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := provider.Summary{
+		Types: []provider.TypeSummary{
+			{Type: "aws_instance", Discovered: 3, Imported: 2, Failed: 1},
+		},
+	}
+
+	h := history.New(filepath.Join(dir, "state"))
+
+	t.Run("Empty", func(t *testing.T) {
+		runs, err := h.List(history.Query{})
+		require.NoError(t, err)
+		assert.Len(t, runs, 0)
+	})
+
+	t.Run("AppendAndList", func(t *testing.T) {
+		hcl := filepath.Join(dir, "main.tf")
+		require.NoError(t, ioutil.WriteFile(hcl, []byte("resource {}"), 0644))
+
+		r1 := history.NewRun("aws", "terracognita aws", s, time.Now().Add(-48*time.Hour), nil)
+		r1.Flags["region"] = "eu-west-1"
+		r1.Filters = history.NewFilters(&filter.Filter{
+			Include:   []string{"aws_instance"},
+			Tags:      []tag.Tag{{Name: "env", Value: "prod"}},
+			NameRegex: regexp.MustCompile("^web"),
+		})
+		require.NoError(t, r1.SetOutputs([]string{hcl, filepath.Join(dir, "missing.tfstate")}))
+
+		r2 := history.NewRun("google", "terracognita google", s, time.Now().Add(-time.Hour), errors.New("failed"))
+		r3 := history.NewRun("aws", "terracognita aws refresh", s, time.Now(), nil)
+
+		for _, r := range []history.Run{r1, r2, r3} {
+			require.NoError(t, h.Append(r))
+		}
+
+		runs, err := h.List(history.Query{})
+		require.NoError(t, err)
+		require.Len(t, runs, 3)
+		assert.Equal(t, r1.ID, runs[0].ID)
+		assert.Equal(t, "eu-west-1", runs[0].Flags["region"])
+		assert.Equal(t, history.Filters{Include: []string{"aws_instance"}, Tags: []string{"env:prod"}, NameRegex: "^web"}, runs[0].Filters)
+		assert.Equal(t, 2, runs[0].Summary.Total().Imported)
+		require.Len(t, runs[0].Outputs, 2)
+		assert.Empty(t, runs[0].Outputs[1].SHA256)
+		assert.Len(t, runs[0].Outputs[0].SHA256, 64)
+		assert.Equal(t, history.StatusFailure, runs[1].Status)
+		assert.Equal(t, "failed", runs[1].Error)
+
+		runs, err = h.List(history.Query{Provider: "aws", Since: time.Now().Add(-24 * time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.Equal(t, r3.ID, runs[0].ID)
+
+		runs, err = h.List(history.Query{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		assert.Equal(t, r2.ID, runs[0].ID)
+	})
+
+	t.Run("DirectoryOutput", func(t *testing.T) {
+		stacks := filepath.Join(dir, "stacks")
+		require.NoError(t, os.MkdirAll(filepath.Join(stacks, "network"), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(stacks, "network", "main.tf"), []byte("a"), 0644))
+
+		var r1, r2 history.Run
+		require.NoError(t, r1.SetOutputs([]string{stacks}))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(stacks, "network", "main.tf"), []byte("b"), 0644))
+		require.NoError(t, r2.SetOutputs([]string{stacks}))
+
+		assert.Len(t, r1.Outputs[0].SHA256, 64)
+		assert.NotEqual(t, r1.Outputs[0].SHA256, r2.Outputs[0].SHA256)
+	})
+
+	t.Run("InvalidRun", func(t *testing.T) {
+		f, err := os.OpenFile(h.Path(), os.O_APPEND|os.O_WRONLY, 0600)
+		require.NoError(t, err)
+		_, err = f.Write([]byte("{invalid\n"))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		_, err = h.List(history.Query{})
+		assert.Equal(t, errcode.ErrHistoryInvalidRun, pkgerrors.Cause(err))
+	})
+}
@@ -0,0 +1,5 @@
+// Package inventory has the Writer that exports the imported
+// resources as an inventory to CSV, SQLite or JSON, with the
+// references between them, so they can be queried without
+// parsing the HCL
+package inventory
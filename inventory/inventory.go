@@ -0,0 +1,278 @@
+package inventory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/graph"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// Format is the format of the inventory
+type Format int
+
+// List of all the Formats supported
+const (
+	// CSV has one line for each resource
+	// with the tags and attributes as JSON
+	CSV Format = iota
+	// SQL are the statements to create the
+	// 'resources' table, to load with SQLite
+	SQL
+	// JSON has the resources and the Edges
+	// between them, so the topology can be
+	// loaded (ex: on a CMDB or graph database)
+	JSON
+)
+
+// KeyAttributes are the attributes exported of
+// each resource if it has them, as the exported
+// ones have to be the same for all the types
+var KeyAttributes = []string{
+	"arn",
+	"self_link",
+	"availability_zone",
+	"zone",
+	"vpc_id",
+	"subnet_id",
+	"network",
+	"instance_type",
+	"machine_type",
+}
+
+// columns are the columns of the inventory
+var columns = []string{"type", "id", "name", "region", "tags", "attributes"}
+
+// Item is a resource of the inventory
+type Item struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Region     string            `json:"region"`
+	Tags       map[string]string `json:"tags"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// Edge is a reference from the resource From to the resource
+// To, as the Attribute of From has the ID of To, with the
+// 'TYPE.NAME' of them and the IDs
+type Edge struct {
+	From      string `json:"from"`
+	FromID    string `json:"from_id"`
+	To        string `json:"to"`
+	ToID      string `json:"to_id"`
+	Attribute string `json:"attribute"`
+}
+
+// Writer is a Writer implementation that generates an inventory
+// of the resources with the KeyAttributes of them and, on the JSON
+// format, the Edges between them, all calculated on the Sync
+type Writer struct {
+	*provider.Collector
+
+	Items []Item
+	Edges []Edge
+
+	format Format
+	writer io.Writer
+}
+
+// NewWriter returns a Writer initialization
+// that writes the inventory with the format
+func NewWriter(w io.Writer, f Format) *Writer {
+	return &Writer{
+		Collector: provider.NewCollector("inventory"),
+		Items:     make([]Item, 0),
+		Edges:     make([]Edge, 0),
+		format:    f,
+		writer:    w,
+	}
+}
+
+// Sync writes the inventory to the internal w
+// sorted by the type and ID of the resources
+func (w *Writer) Sync() error {
+	w.Items = make([]Item, 0, len(w.Keys()))
+	for _, k := range w.Keys() {
+		w.Items = append(w.Items, newItem(k, w.Resource(k)))
+	}
+
+	sort.SliceStable(w.Items, func(i, j int) bool {
+		if w.Items[i].Type != w.Items[j].Type {
+			return w.Items[i].Type < w.Items[j].Type
+		}
+		return w.Items[i].ID < w.Items[j].ID
+	})
+
+	var err error
+	switch w.format {
+	case CSV:
+		err = w.writeCSV()
+	case SQL:
+		err = w.writeSQL()
+	case JSON:
+		w.edges()
+		err = w.writeJSON()
+	default:
+		err = fmt.Errorf("invalid format %d", w.format)
+	}
+	if err != nil {
+		return errors.Wrap(err, "error while writing the inventory")
+	}
+
+	return nil
+}
+
+// newItem returns the Item of the resource r written with the key
+func newItem(key string, r provider.Resource) Item {
+	keys := strings.Split(key, ".")
+
+	d := r.Data()
+	p := r.Provider()
+	i := Item{
+		Type:       keys[0],
+		ID:         r.ID(),
+		Name:       keys[1],
+		Region:     p.Region(),
+		Tags:       make(map[string]string),
+		Attributes: make(map[string]string),
+	}
+
+	if tk := p.TagKey(); tk != "" {
+		if _, ok := r.TFResource().Schema[tk]; ok {
+			if tags, ok := d.Get(tk).(map[string]interface{}); ok {
+				for k, v := range tags {
+					i.Tags[k] = fmt.Sprint(v)
+				}
+			}
+		}
+	}
+
+	for _, a := range KeyAttributes {
+		s, ok := r.TFResource().Schema[a]
+		if !ok || s.Type != schema.TypeString {
+			continue
+		}
+		if v, ok := d.GetOk(a); ok {
+			i.Attributes[a] = v.(string)
+		}
+	}
+
+	return i
+}
+
+// writeCSV writes the Items as CSV with the header
+func (w *Writer) writeCSV() error {
+	cw := csv.NewWriter(w.writer)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+
+	for _, i := range w.Items {
+		row, err := i.row()
+		if err != nil {
+			return err
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeSQL writes the statements to (re)create the
+// 'resources' table with the Items on a transaction
+func (w *Writer) writeSQL() error {
+	var b strings.Builder
+	b.WriteString("BEGIN TRANSACTION;\n")
+	b.WriteString("DROP TABLE IF EXISTS resources;\n")
+	b.WriteString("CREATE TABLE resources (type TEXT NOT NULL, id TEXT NOT NULL, name TEXT NOT NULL, region TEXT, tags TEXT, attributes TEXT);\n")
+
+	for _, i := range w.Items {
+		row, err := i.row()
+		if err != nil {
+			return err
+		}
+
+		values := make([]string, 0, len(row))
+		for _, v := range row {
+			values = append(values, quote(v))
+		}
+		fmt.Fprintf(&b, "INSERT INTO resources (%s) VALUES (%s);\n", strings.Join(columns, ", "), strings.Join(values, ", "))
+	}
+
+	b.WriteString("COMMIT;\n")
+
+	_, err := io.WriteString(w.writer, b.String())
+	return err
+}
+
+// edges calculates the Edges between the Items, with
+// the references of the graph of the resources of them
+func (w *Writer) edges() {
+	keys := make([]string, 0, len(w.Items))
+	ids := make(map[string]string, len(w.Items))
+	for _, i := range w.Items {
+		k := fmt.Sprintf("%s.%s", i.Type, i.Name)
+		keys = append(keys, k)
+		ids[k] = i.ID
+	}
+
+	g := graph.New(keys, w.Resources())
+
+	w.Edges = make([]Edge, 0, len(g.Edges))
+	for _, e := range g.Edges {
+		w.Edges = append(w.Edges, Edge{
+			From:      e.From,
+			FromID:    ids[e.From],
+			To:        e.To,
+			ToID:      ids[e.To],
+			Attribute: e.Attribute,
+		})
+	}
+}
+
+// writeJSON writes the Items and the Edges as JSON
+func (w *Writer) writeJSON() error {
+	enc := json.NewEncoder(w.writer)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(struct {
+		Resources []Item `json:"resources"`
+		Edges     []Edge `json:"edges"`
+	}{
+		Resources: w.Items,
+		Edges:     w.Edges,
+	})
+}
+
+// row returns the values of the columns of the i,
+// the tags and attributes are encoded as JSON so they
+// can be queried (ex: json_extract(tags, '$.env') on SQLite)
+func (i Item) row() ([]string, error) {
+	tags, err := json.Marshal(i.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs, err := json.Marshal(i.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{i.Type, i.ID, i.Name, i.Region, string(tags), string(attrs)}, nil
+}
+
+// quote returns the s as an SQL string literal
+func quote(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
@@ -0,0 +1,200 @@
+package inventory_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/inventory"
+	"github.com/cycloidio/terracognita/mock"
+)
+
+func TestWriter(t *testing.T) {
+	tfr := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"arn":           &schema.Schema{Type: schema.TypeString, Computed: true},
+			"instance_type": &schema.Schema{Type: schema.TypeString, Optional: true},
+			"tags":          &schema.Schema{Type: schema.TypeMap, Optional: true},
+		},
+	}
+
+	resources := func(ctrl *gomock.Controller) (*mock.Resource, *mock.Resource) {
+		var (
+			p  = mock.NewProvider(ctrl)
+			r1 = mock.NewResource(ctrl)
+			r2 = mock.NewResource(ctrl)
+			d1 = tfr.Data(nil)
+			d2 = tfr.Data(nil)
+		)
+
+		d1.Set("arn", "arn:i-1")
+		d1.Set("instance_type", "t2.micro")
+		d1.Set("tags", map[string]interface{}{"env": "prod"})
+		d2.Set("instance_type", "t2.large")
+
+		p.EXPECT().Region().Return("eu-west-1").AnyTimes()
+		p.EXPECT().TagKey().Return("tags").AnyTimes()
+
+		for _, r := range []*mock.Resource{r1, r2} {
+			r.EXPECT().Provider().Return(p)
+			r.EXPECT().TFResource().Return(tfr).AnyTimes()
+		}
+		r1.EXPECT().ID().Return("i-1")
+		r1.EXPECT().Data().Return(d1)
+		r2.EXPECT().ID().Return("i-2")
+		r2.EXPECT().Data().Return(d2)
+
+		return r1, r2
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		var (
+			ctrl   = gomock.NewController(t)
+			b      = &bytes.Buffer{}
+			iw     = inventory.NewWriter(b, inventory.CSV)
+			r1, r2 = resources(ctrl)
+
+			csv = `type,id,name,region,tags,attributes
+aws_instance,i-1,front,eu-west-1,"{""env"":""prod""}","{""arn"":""arn:i-1"",""instance_type"":""t2.micro""}"
+aws_instance,i-2,back,eu-west-1,{},"{""instance_type"":""t2.large""}"
+`
+		)
+		defer ctrl.Finish()
+
+		require.NoError(t, iw.Write("aws_instance.back", r2))
+		require.NoError(t, iw.Write("aws_instance.front", r1))
+		require.NoError(t, iw.Sync())
+
+		assert.Equal(t, csv, b.String())
+	})
+
+	t.Run("SQL", func(t *testing.T) {
+		var (
+			ctrl   = gomock.NewController(t)
+			b      = &bytes.Buffer{}
+			iw     = inventory.NewWriter(b, inventory.SQL)
+			r1, r2 = resources(ctrl)
+
+			sql = `BEGIN TRANSACTION;
+DROP TABLE IF EXISTS resources;
+CREATE TABLE resources (type TEXT NOT NULL, id TEXT NOT NULL, name TEXT NOT NULL, region TEXT, tags TEXT, attributes TEXT);
+INSERT INTO resources (type, id, name, region, tags, attributes) VALUES ('aws_instance', 'i-1', 'front', 'eu-west-1', '{"env":"prod"}', '{"arn":"arn:i-1","instance_type":"t2.micro"}');
+INSERT INTO resources (type, id, name, region, tags, attributes) VALUES ('aws_instance', 'i-2', 'back', 'eu-west-1', '{}', '{"instance_type":"t2.large"}');
+COMMIT;
+`
+		)
+		defer ctrl.Finish()
+
+		require.NoError(t, iw.Write("aws_instance.front", r1))
+		require.NoError(t, iw.Write("aws_instance.back", r2))
+		require.NoError(t, iw.Sync())
+
+		assert.Equal(t, sql, b.String())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var (
+			ctrl     = gomock.NewController(t)
+			b        = &bytes.Buffer{}
+			iw       = inventory.NewWriter(b, inventory.JSON)
+			p        = mock.NewProvider(ctrl)
+			instance = mock.NewResource(ctrl)
+			subnet   = mock.NewResource(ctrl)
+
+			itfr = &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"subnet_id": &schema.Schema{Type: schema.TypeString, Optional: true},
+				},
+			}
+			stfr = &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cidr_block": &schema.Schema{Type: schema.TypeString, Optional: true},
+				},
+			}
+			id = itfr.Data(nil)
+			sd = stfr.Data(nil)
+
+			js = `{
+  "resources": [
+    {
+      "type": "aws_instance",
+      "id": "i-1",
+      "name": "front",
+      "region": "eu-west-1",
+      "tags": {},
+      "attributes": {
+        "subnet_id": "subnet-1"
+      }
+    },
+    {
+      "type": "aws_subnet",
+      "id": "subnet-1",
+      "name": "main",
+      "region": "eu-west-1",
+      "tags": {},
+      "attributes": {}
+    }
+  ],
+  "edges": [
+    {
+      "from": "aws_instance.front",
+      "from_id": "i-1",
+      "to": "aws_subnet.main",
+      "to_id": "subnet-1",
+      "attribute": "subnet_id"
+    }
+  ]
+}
+`
+		)
+		defer ctrl.Finish()
+
+		id.Set("subnet_id", "subnet-1")
+		sd.Set("cidr_block", "10.0.0.0/24")
+
+		p.EXPECT().Region().Return("eu-west-1").AnyTimes()
+		p.EXPECT().TagKey().Return("tags").AnyTimes()
+
+		instance.EXPECT().Provider().Return(p)
+		instance.EXPECT().ID().Return("i-1").AnyTimes()
+		instance.EXPECT().Type().Return("aws_instance").AnyTimes()
+		instance.EXPECT().Data().Return(id).AnyTimes()
+		instance.EXPECT().TFResource().Return(itfr).AnyTimes()
+
+		subnet.EXPECT().Provider().Return(p)
+		subnet.EXPECT().ID().Return("subnet-1").AnyTimes()
+		subnet.EXPECT().Type().Return("aws_subnet").AnyTimes()
+		subnet.EXPECT().Data().Return(sd).AnyTimes()
+		subnet.EXPECT().TFResource().Return(stfr).AnyTimes()
+
+		require.NoError(t, iw.Write("aws_subnet.main", subnet))
+		require.NoError(t, iw.Write("aws_instance.front", instance))
+		require.NoError(t, iw.Sync())
+
+		assert.Equal(t, js, b.String())
+	})
+
+	t.Run("ErrorRequiredKey", func(t *testing.T) {
+		iw := inventory.NewWriter(&bytes.Buffer{}, inventory.CSV)
+		err := iw.Write("", "")
+		assert.Equal(t, errcode.ErrWriterRequiredKey, errors.Cause(err))
+	})
+
+	t.Run("ErrorInvalidKey", func(t *testing.T) {
+		iw := inventory.NewWriter(&bytes.Buffer{}, inventory.CSV)
+		err := iw.Write("aws_instance", "")
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(err))
+	})
+
+	t.Run("ErrorInvalidTypeValue", func(t *testing.T) {
+		iw := inventory.NewWriter(&bytes.Buffer{}, inventory.CSV)
+		err := iw.Write("aws_instance.front", "")
+		assert.Equal(t, errcode.ErrWriterInvalidTypeValue, errors.Cause(err))
+	})
+}
@@ -0,0 +1,63 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// DefaultBinary is the sqlite3 binary used
+// if none is given, searched on the PATH
+const DefaultBinary = "sqlite3"
+
+// SQLite buffers the SQL statements written to
+// it and on the Close runs them with the sqlite3
+// bin on the database file
+type SQLite struct {
+	bin  string
+	file string
+	buf  bytes.Buffer
+}
+
+// NewSQLite returns a SQLite that runs the
+// statements with the bin on the file
+func NewSQLite(bin, file string) *SQLite {
+	return &SQLite{bin: bin, file: file}
+}
+
+// Write buffers the p to run it on the Close
+func (s *SQLite) Write(p []byte) (int, error) {
+	return s.buf.Write(p)
+}
+
+// Close runs the statements written on the
+// database, which is created if it does not exist
+func (s *SQLite) Close() error {
+	bin := s.bin
+	if bin == "" {
+		bin = DefaultBinary
+	}
+
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return errors.Wrapf(errcode.ErrSQLiteNotFound, "%s: %s", bin, err)
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(context.Background(), path, "-bail", s.file)
+	cmd.Env = os.Environ()
+	cmd.Stdin = &s.buf
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(errcode.ErrSQLiteFailed, "%s\n%s", err, stderr.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package inventory_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/inventory"
+)
+
+// fakeSQLite writes a sqlite3 script to a new directory that
+// writes the arguments and the Stdin to out, or fails with code
+func fakeSQLite(t *testing.T, code string) (string, string, func()) {
+	dir, err := ioutil.TempDir("", "terracognita-sqlite-test")
+	require.NoError(t, err)
+
+	bin := filepath.Join(dir, "sqlite3")
+	out := filepath.Join(dir, "out")
+	script := `#!/bin/sh
+echo "$@" > ` + out + `
+cat >> ` + out + `
+exit ` + code + `
+`
+	require.NoError(t, ioutil.WriteFile(bin, []byte(script), 0755))
+
+	return bin, out, func() { os.RemoveAll(dir) }
+}
+
+func TestSQLite(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		bin, out, clean := fakeSQLite(t, "0")
+		defer clean()
+
+		s := inventory.NewSQLite(bin, "inventory.db")
+		_, err := io.WriteString(s, "COMMIT;\n")
+		require.NoError(t, err)
+		require.NoError(t, s.Close())
+
+		b, err := ioutil.ReadFile(out)
+		require.NoError(t, err)
+		assert.Equal(t, "-bail inventory.db\nCOMMIT;\n", string(b))
+	})
+
+	t.Run("ErrorFailed", func(t *testing.T) {
+		bin, _, clean := fakeSQLite(t, "1")
+		defer clean()
+
+		err := inventory.NewSQLite(bin, "inventory.db").Close()
+		assert.Equal(t, errcode.ErrSQLiteFailed, errors.Cause(err))
+	})
+
+	t.Run("ErrorNotFound", func(t *testing.T) {
+		err := inventory.NewSQLite("/not/found/sqlite3", "inventory.db").Close()
+		assert.Equal(t, errcode.ErrSQLiteNotFound, errors.Cause(err))
+	})
+}
@@ -0,0 +1,5 @@
+// Package lockfile generates the dependency lock file of Terraform
+// (.terraform.lock.hcl) with the versions of the providers the HCL is
+// generated for and the hashes of their packages, so 'terraform init'
+// installs the same ones without a manual lock step
+package lockfile
@@ -0,0 +1,125 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// Filename is the name of the dependency lock file
+const Filename = ".terraform.lock.hcl"
+
+// Versions are the versions of the providers
+// the HCL is generated for, by the name of them
+var Versions = map[string]string{
+	"aws":    "2.31.0",
+	"google": "2.16.0",
+}
+
+// releasesURL is the URL of the releases of the providers
+// with the SHA256SUMS of the packages of each version,
+// it's a var so it can be changed on the tests
+var releasesURL = "https://releases.hashicorp.com"
+
+// Lock is the lock of a provider
+type Lock struct {
+	// Provider is the name of it (ex: aws)
+	Provider string
+	Version  string
+
+	// Hashes are the 'zh:' hashes of the
+	// packages of all the platforms
+	Hashes []string
+}
+
+// Get returns the Lock of the provider with the Version of it
+// and the hashes of the packages of the release of it
+func Get(ctx context.Context, provider string) (*Lock, error) {
+	v, ok := Versions[provider]
+	if !ok {
+		return nil, errors.Errorf("the provider %q has no version to lock", provider)
+	}
+
+	name := fmt.Sprintf("terraform-provider-%s", provider)
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s/%s_%s_SHA256SUMS", releasesURL, name, v, name, v), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create the SHA256SUMS request")
+	}
+
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(errcode.ErrLockfileHashes, "of %s %s: %s", provider, v, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Wrapf(errcode.ErrLockfileHashes, "of %s %s with status %d", provider, v, res.StatusCode)
+	}
+
+	hashes, err := parseSums(res.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read the SHA256SUMS of %s %s", provider, v)
+	}
+	if len(hashes) == 0 {
+		return nil, errors.Wrapf(errcode.ErrLockfileHashes, "of %s %s, the SHA256SUMS has no packages", provider, v)
+	}
+
+	return &Lock{Provider: provider, Version: v, Hashes: hashes}, nil
+}
+
+// parseSums returns the 'zh:' hashes of the packages
+// of the SHA256SUMS r, with a 'HASH  FILE' on each line
+func parseSums(r io.Reader) ([]string, error) {
+	hashes := make([]string, 0)
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) != 2 || !strings.HasSuffix(fields[1], ".zip") {
+			continue
+		}
+		hashes = append(hashes, "zh:"+fields[0])
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(hashes)
+
+	return hashes, nil
+}
+
+// Write writes the dependency lock file
+// with the locks of the providers to the w
+func Write(w io.Writer, locks []*Lock) error {
+	var buff bytes.Buffer
+
+	buff.WriteString("# This file is maintained automatically by \"terraform init\".\n")
+	buff.WriteString("# Manual edits may be lost in future updates.\n")
+
+	sort.Slice(locks, func(i, j int) bool { return locks[i].Provider < locks[j].Provider })
+	for _, l := range locks {
+		fmt.Fprintf(&buff, "\nprovider %q {\n", "registry.terraform.io/hashicorp/"+l.Provider)
+		fmt.Fprintf(&buff, "  version = %q\n", l.Version)
+		buff.WriteString("  hashes = [\n")
+		for _, h := range l.Hashes {
+			fmt.Fprintf(&buff, "    %q,\n", h)
+		}
+		buff.WriteString("  ]\n}\n")
+	}
+
+	_, err := w.Write(buff.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "error while writing the lock file")
+	}
+
+	return nil
+}
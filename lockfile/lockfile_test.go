@@ -0,0 +1,81 @@
+package lockfile
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/terraform-provider-aws/2.31.0/terraform-provider-aws_2.31.0_SHA256SUMS", r.URL.Path)
+			w.Write([]byte("bbb  terraform-provider-aws_2.31.0_linux_amd64.zip\naaa  terraform-provider-aws_2.31.0_darwin_amd64.zip\nccc  terraform-provider-aws_2.31.0_manifest.json\n"))
+		}))
+		defer ts.Close()
+
+		defer func(u string) { releasesURL = u }(releasesURL)
+		releasesURL = ts.URL
+
+		l, err := Get(context.Background(), "aws")
+		require.NoError(t, err)
+		assert.Equal(t, &Lock{Provider: "aws", Version: "2.31.0", Hashes: []string{"zh:aaa", "zh:bbb"}}, l)
+	})
+
+	t.Run("ErrorNotFound", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer ts.Close()
+
+		defer func(u string) { releasesURL = u }(releasesURL)
+		releasesURL = ts.URL
+
+		_, err := Get(context.Background(), "google")
+		assert.Equal(t, errcode.ErrLockfileHashes, pkgerrors.Cause(err))
+	})
+
+	t.Run("ErrorNoVersion", func(t *testing.T) {
+		_, err := Get(context.Background(), "azurerm")
+		assert.Error(t, err)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	var (
+		b    = &bytes.Buffer{}
+		lock = `# This file is maintained automatically by "terraform init".
+# Manual edits may be lost in future updates.
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version = "2.31.0"
+  hashes = [
+    "zh:aaa",
+    "zh:bbb",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/google" {
+  version = "2.16.0"
+  hashes = [
+    "zh:ccc",
+  ]
+}
+`
+	)
+
+	err := Write(b, []*Lock{
+		{Provider: "google", Version: "2.16.0", Hashes: []string{"zh:ccc"}},
+		{Provider: "aws", Version: "2.31.0", Hashes: []string{"zh:aaa", "zh:bbb"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, lock, b.String())
+}
@@ -1,18 +1,39 @@
 package log
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"sync"
 
 	kitlog "github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
 	"github.com/hashicorp/terraform/helper/logging"
 )
 
 var logger kitlog.Logger
 var once sync.Once
 
+// Options are the configurations of the log
+type Options struct {
+	// Out is where the logs are written
+	Out io.Writer
+
+	// Format is the format of the logs, 'logfmt' (default) or 'json'
+	Format string
+
+	// Level is the minimum level of the logs written, 'debug'
+	// (default), 'info', 'warn' or 'error'. The logs without
+	// level are of 'debug'
+	Level string
+
+	// TFLogs sets the level of vebosity of the
+	// Terraform logs, if true it'll use the TF_LOG
+	// env variable to set it to Terraform
+	TFLogs bool
+}
+
 // Init initializes the log, it can only be called once,
 // repetitive calls to it will not change it.
 // It also set the level of vebosity of the
@@ -20,19 +41,60 @@ var once sync.Once
 // use the TF_LOG env variable to set it to
 // Terraform
 func Init(out io.Writer, tflogs bool) {
+	InitWithOptions(Options{Out: out, TFLogs: tflogs})
+}
+
+// InitWithOptions initializes the log with the opt, the same as Init
+// it can only be called once. The opt are validated with ValidateOptions
+// before, if invalid the defaults are used
+func InitWithOptions(opt Options) {
 	once.Do(func() {
-		w := kitlog.NewSyncWriter(out)
-		logger = kitlog.NewLogfmtLogger(w)
+		if ValidateOptions(opt) != nil {
+			opt.Format, opt.Level = "", ""
+		}
+
+		// The SyncWriter is needed so the logs of
+		// the resources imported concurrently
+		// are not interleaved
+		w := kitlog.NewSyncWriter(opt.Out)
+		if opt.Format == "json" {
+			logger = kitlog.NewJSONLogger(w)
+		} else {
+			logger = kitlog.NewLogfmtLogger(w)
+		}
 
-		if !tflogs {
+		if !opt.TFLogs {
 			os.Setenv("TF_LOG", "")
 			logging.SetOutput()
 		}
 
+		logger = level.NewInjector(level.NewFilter(logger, levels[opt.Level]), level.DebugValue())
 		logger = kitlog.With(logger, "ts", kitlog.DefaultTimestampUTC, "caller", kitlog.DefaultCaller)
 	})
 }
 
+// levels are the valid levels with the
+// filter of the logs of each one
+var levels = map[string]level.Option{
+	"":      level.AllowDebug(),
+	"debug": level.AllowDebug(),
+	"info":  level.AllowInfo(),
+	"warn":  level.AllowWarn(),
+	"error": level.AllowError(),
+}
+
+// ValidateOptions checks that the Format
+// and the Level of the opt are valid
+func ValidateOptions(opt Options) error {
+	if opt.Format != "" && opt.Format != "logfmt" && opt.Format != "json" {
+		return fmt.Errorf("invalid log format %q, the valid ones are logfmt and json", opt.Format)
+	}
+	if _, ok := levels[opt.Level]; !ok {
+		return fmt.Errorf("invalid log level %q, the valid ones are debug, info, warn and error", opt.Level)
+	}
+	return nil
+}
+
 // Get returns the initialized logger,
 // if it has not been initialized it'll
 // initialize it with the default values
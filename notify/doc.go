@@ -0,0 +1,4 @@
+// Package notify sends a report when an import finishes,
+// successfully or not, to webhooks so the scheduled runs
+// can be followed without checking their logs
+package notify
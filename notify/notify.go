@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// List of the Status of the Report
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+)
+
+// Report is the information of an import sent
+// to the Notifiers when it finishes
+type Report struct {
+	Provider string           `json:"provider"`
+	Status   string           `json:"status"`
+	Error    string           `json:"error,omitempty"`
+	Summary  provider.Summary `json:"summary"`
+	Duration time.Duration    `json:"duration"`
+
+	// Outputs are the files written
+	// by the import (ex: main.tf)
+	Outputs []string  `json:"outputs"`
+	Time    time.Time `json:"time"`
+}
+
+// NewReport returns the Report of the import of the provider
+// p that started at start with the summary s and the err
+func NewReport(p string, s provider.Summary, outputs []string, start time.Time, err error) Report {
+	r := Report{
+		Provider: p,
+		Status:   StatusSuccess,
+		Summary:  s,
+		Duration: time.Since(start),
+		Outputs:  outputs,
+		Time:     time.Now(),
+	}
+	if err != nil {
+		r.Status = StatusFailure
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// Notifier sends the Report to an external system
+type Notifier interface {
+	Notify(ctx context.Context, r Report) error
+}
+
+// webhook sends the Report as JSON with the
+// 'text' of it so it's compatible with Slack
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook returns a Notifier that POSTs the Report as JSON
+// to the url, with the 'text' of it as Slack markdown so the url
+// can also be a Slack Incoming Webhook
+func NewWebhook(url string) Notifier {
+	return &webhook{url: url, client: http.DefaultClient}
+}
+
+func (w *webhook) Notify(ctx context.Context, r Report) error {
+	b, err := json.Marshal(struct {
+		Text string `json:"text"`
+		Report
+	}{
+		Text:   Text(r),
+		Report: r,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not encode the notification")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrapf(err, "could not create the request to %s", w.url)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := w.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "could not send the notification to %s", w.url)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Wrapf(errcode.ErrNotifyFailed, "to %s with status %d", w.url, res.StatusCode)
+	}
+
+	return nil
+}
+
+// Text formats the r as Slack markdown
+func Text(r Report) string {
+	var b strings.Builder
+
+	if r.Status == StatusFailure {
+		fmt.Fprintf(&b, "*Terracognita* import of *%s* failed after %s\n", r.Provider, r.Duration.Round(time.Second))
+		fmt.Fprintf(&b, "Error: `%s`\n", r.Error)
+	} else {
+		fmt.Fprintf(&b, "*Terracognita* import of *%s* finished in %s\n", r.Provider, r.Duration.Round(time.Second))
+	}
+
+	t := r.Summary.Total()
+	fmt.Fprintf(&b, "Resources: %d discovered, %d imported, %d skipped, %d failed\n", t.Discovered, t.Imported, t.Skipped, t.Failed)
+
+	for _, ts := range r.Summary.Types {
+		if ts.Failed != 0 {
+			fmt.Fprintf(&b, "• `%s`: %d failed\n", ts.Type, ts.Failed)
+		}
+	}
+
+	if len(r.Outputs) != 0 {
+		fmt.Fprintf(&b, "Outputs: %s\n", strings.Join(r.Outputs, ", "))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/notify"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+func TestWebhook(t *testing.T) {
+	s := provider.Summary{
+		Types: []provider.TypeSummary{
+			{Type: "aws_instance", Discovered: 3, Imported: 2, Failed: 1},
+			{Type: "aws_s3_bucket", Discovered: 1, Skipped: 1},
+		},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		var body struct {
+			Text string `json:"text"`
+			notify.Report
+		}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}))
+		defer ts.Close()
+
+		r := notify.NewReport("aws", s, []string{"main.tf"}, time.Now().Add(-time.Minute), nil)
+		r.Duration = time.Minute
+		require.NoError(t, notify.NewWebhook(ts.URL).Notify(context.Background(), r))
+
+		assert.Equal(t, notify.StatusSuccess, body.Status)
+		assert.Equal(t, s, body.Summary)
+		assert.Equal(t, []string{"main.tf"}, body.Outputs)
+		assert.Equal(t, "*Terracognita* import of *aws* finished in 1m0s\nResources: 4 discovered, 2 imported, 1 skipped, 1 failed\n• `aws_instance`: 1 failed\nOutputs: main.tf\n", body.Text)
+	})
+
+	t.Run("SuccessWithFailure", func(t *testing.T) {
+		var body struct {
+			Text string `json:"text"`
+			notify.Report
+		}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}))
+		defer ts.Close()
+
+		r := notify.NewReport("google", provider.Summary{}, nil, time.Now(), errors.New("invalid credentials"))
+		require.NoError(t, notify.NewWebhook(ts.URL).Notify(context.Background(), r))
+
+		assert.Equal(t, notify.StatusFailure, body.Status)
+		assert.Equal(t, "invalid credentials", body.Error)
+		assert.Contains(t, body.Text, "Error: `invalid credentials`")
+	})
+
+	t.Run("ErrNotifyFailed", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+
+		err := notify.NewWebhook(ts.URL).Notify(context.Background(), notify.NewReport("aws", s, nil, time.Now(), nil))
+		assert.Equal(t, errcode.ErrNotifyFailed, pkgerrors.Cause(err))
+	})
+}
@@ -0,0 +1,5 @@
+// Package oidc gets the OIDC ID tokens of the CI jobs (GitHub
+// Actions and GitLab CI) to exchange them for the credentials of
+// the cloud providers, so the scheduled imports do not need
+// long-lived keys
+package oidc
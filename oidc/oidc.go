@@ -0,0 +1,112 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// List of the CIs the tokens can be from
+const (
+	// GitHub is GitHub Actions, the job needs the
+	// 'id-token: write' permission
+	GitHub = "github"
+
+	// GitLab is GitLab CI, the job needs the token
+	// on the id_tokens as GitLabTokenEnv
+	GitLab = "gitlab"
+)
+
+// List of the environment variables of the CIs
+const (
+	// GitHubRequestURLEnv and GitHubRequestTokenEnv are the
+	// URL to request the token to and the bearer token of the
+	// request, set by GitHub Actions with 'id-token: write'
+	GitHubRequestURLEnv   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	GitHubRequestTokenEnv = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+
+	// GitLabTokenEnv is the token of the id_tokens of the job,
+	// with the audience of it, and GitLabLegacyTokenEnv the
+	// one of the previous versions used if it's not set
+	GitLabTokenEnv       = "TERRACOGNITA_ID_TOKEN"
+	GitLabLegacyTokenEnv = "CI_JOB_JWT_V2"
+)
+
+// Token returns the ID token of the job of the ci with the audience,
+// which on GitLab is the one of the id_tokens so it's not used
+func Token(ctx context.Context, ci, audience string) (string, error) {
+	switch ci {
+	case GitHub:
+		return gitHubToken(ctx, audience)
+	case GitLab:
+		return gitLabToken()
+	default:
+		return "", errors.Errorf("invalid CI %q, it has to be one of: %s, %s", ci, GitHub, GitLab)
+	}
+}
+
+// gitHubToken requests the token with the audience
+// to the URL set by GitHub Actions for the job
+func gitHubToken(ctx context.Context, audience string) (string, error) {
+	ru, rt := os.Getenv(GitHubRequestURLEnv), os.Getenv(GitHubRequestTokenEnv)
+	if ru == "" || rt == "" {
+		return "", errors.Wrapf(errcode.ErrOIDCTokenNotFound, "the %s and %s are not set, the job needs the 'id-token: write' permission", GitHubRequestURLEnv, GitHubRequestTokenEnv)
+	}
+
+	u, err := url.Parse(ru)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid %s", GitHubRequestURLEnv)
+	}
+	if audience != "" {
+		q := u.Query()
+		q.Set("audience", audience)
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", errors.Wrap(err, "could not create the OIDC token request")
+	}
+	req.Header.Set("Authorization", "bearer "+rt)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", errors.Wrap(err, "could not request the OIDC token")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", errors.Wrapf(errcode.ErrOIDCToken, "with status %d", res.StatusCode)
+	}
+
+	var body struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", errors.Wrap(err, "could not decode the OIDC token")
+	}
+	if body.Value == "" {
+		return "", errors.Wrap(errcode.ErrOIDCToken, "the response has no token")
+	}
+
+	return body.Value, nil
+}
+
+// gitLabToken returns the token of the id_tokens
+// of the job, or the legacy one if it's not set
+func gitLabToken() (string, error) {
+	for _, e := range []string{GitLabTokenEnv, GitLabLegacyTokenEnv} {
+		if t := os.Getenv(e); t != "" {
+			return t, nil
+		}
+	}
+
+	return "", errors.Wrapf(errcode.ErrOIDCTokenNotFound, "the %s is not set, it has to be on the id_tokens of the job", GitLabTokenEnv)
+}
@@ -0,0 +1,114 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/oidc"
+)
+
+// setenv sets the environment variables
+// and returns a function to unset them
+func setenv(t *testing.T, env map[string]string) func() {
+	for k, v := range env {
+		require.NoError(t, os.Setenv(k, v))
+	}
+	return func() {
+		for k := range env {
+			os.Unsetenv(k)
+		}
+	}
+}
+
+func TestToken(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("SuccessGitHub", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "bearer request-token", r.Header.Get("Authorization"))
+			assert.Equal(t, "sts.amazonaws.com", r.URL.Query().Get("audience"))
+			assert.Equal(t, "1", r.URL.Query().Get("api-version"))
+			w.Write([]byte(`{"value":"id-token"}`))
+		}))
+		defer ts.Close()
+
+		defer setenv(t, map[string]string{
+			oidc.GitHubRequestURLEnv:   ts.URL + "?api-version=1",
+			oidc.GitHubRequestTokenEnv: "request-token",
+		})()
+
+		token, err := oidc.Token(ctx, oidc.GitHub, "sts.amazonaws.com")
+		require.NoError(t, err)
+		assert.Equal(t, "id-token", token)
+	})
+
+	t.Run("ErrorGitHubStatus", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer ts.Close()
+
+		defer setenv(t, map[string]string{
+			oidc.GitHubRequestURLEnv:   ts.URL,
+			oidc.GitHubRequestTokenEnv: "request-token",
+		})()
+
+		_, err := oidc.Token(ctx, oidc.GitHub, "")
+		assert.Equal(t, errcode.ErrOIDCToken, pkgerrors.Cause(err))
+	})
+
+	t.Run("ErrorGitHubNotFound", func(t *testing.T) {
+		defer setenv(t, map[string]string{
+			oidc.GitHubRequestURLEnv:   "",
+			oidc.GitHubRequestTokenEnv: "",
+		})()
+
+		_, err := oidc.Token(ctx, oidc.GitHub, "")
+		assert.Equal(t, errcode.ErrOIDCTokenNotFound, pkgerrors.Cause(err))
+	})
+
+	t.Run("SuccessGitLab", func(t *testing.T) {
+		defer setenv(t, map[string]string{
+			oidc.GitLabTokenEnv:       "id-token",
+			oidc.GitLabLegacyTokenEnv: "legacy-token",
+		})()
+
+		token, err := oidc.Token(ctx, oidc.GitLab, "")
+		require.NoError(t, err)
+		assert.Equal(t, "id-token", token)
+	})
+
+	t.Run("SuccessGitLabLegacy", func(t *testing.T) {
+		defer setenv(t, map[string]string{
+			oidc.GitLabTokenEnv:       "",
+			oidc.GitLabLegacyTokenEnv: "legacy-token",
+		})()
+
+		token, err := oidc.Token(ctx, oidc.GitLab, "")
+		require.NoError(t, err)
+		assert.Equal(t, "legacy-token", token)
+	})
+
+	t.Run("ErrorGitLabNotFound", func(t *testing.T) {
+		defer setenv(t, map[string]string{
+			oidc.GitLabTokenEnv:       "",
+			oidc.GitLabLegacyTokenEnv: "",
+		})()
+
+		_, err := oidc.Token(ctx, oidc.GitLab, "")
+		assert.Equal(t, errcode.ErrOIDCTokenNotFound, pkgerrors.Cause(err))
+	})
+
+	t.Run("ErrorInvalidCI", func(t *testing.T) {
+		_, err := oidc.Token(ctx, "jenkins", "")
+		assert.Error(t, err)
+	})
+}
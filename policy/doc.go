@@ -0,0 +1,4 @@
+// Package policy evaluates the configurations of
+// the resources imported against Rego policies
+// with the OPA binary before those are written
+package policy
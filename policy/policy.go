@@ -0,0 +1,256 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// DefaultBinary is the opa binary used
+// if none is given, searched on the PATH
+const DefaultBinary = "opa"
+
+// Query is the query evaluated for each resource, the
+// rules of the package 'terracognita' have to add the
+// messages of the violations to the 'deny' set
+const Query = "data.terracognita.deny"
+
+// Action is what is done with the
+// resources that violate the policies
+type Action string
+
+// The Actions
+const (
+	// Warn writes the resources and
+	// reports the violations
+	Warn Action = "warn"
+
+	// Exclude skips the resources
+	// and reports the violations
+	Exclude Action = "exclude"
+
+	// Fail fails the import on
+	// the first violation
+	Fail Action = "fail"
+)
+
+// ParseAction returns the Action of the a
+func ParseAction(a string) (Action, error) {
+	switch ac := Action(a); ac {
+	case Warn, Exclude, Fail:
+		return ac, nil
+	default:
+		return "", errors.Errorf("invalid action %q, the valid ones are warn, exclude and fail", a)
+	}
+}
+
+// Violation are the messages of the
+// policies violated by the resource
+type Violation struct {
+	Key      string
+	Messages []string
+}
+
+// Checker evaluates the configurations written
+// to the HCL against the policies with OPA
+type Checker struct {
+	bin    string
+	path   string
+	action Action
+
+	mx         sync.Mutex
+	violations []Violation
+}
+
+// New returns a Checker of the Rego policies on the path (a file or
+// a directory) evaluated with the bin, the action is what is done
+// with the resources that violate them
+func New(bin, path string, action Action) (*Checker, error) {
+	if bin == "" {
+		bin = DefaultBinary
+	}
+
+	p, err := exec.LookPath(bin)
+	if err != nil {
+		return nil, errors.Wrapf(errcode.ErrPolicyOPANotFound, "%s: %s", bin, err)
+	}
+
+	return &Checker{
+		bin:    p,
+		path:   path,
+		action: action,
+	}, nil
+}
+
+// Middleware returns the writer.Middleware of the HCL that evaluates
+// the resources written, the data sources, variables and providers
+// are not evaluated. The excluded ones fail with an
+// errcode.ErrWriterExcludedKey so they are skipped by the Import
+func (c *Checker) Middleware() writer.Middleware {
+	return writer.InterceptWrite(func(key string, value interface{}) (interface{}, error) {
+		cfg, ok := value.(map[string]interface{})
+		keys := strings.Split(key, ".")
+		if !ok || len(keys) != 2 || keys[0] == "variable" {
+			return value, nil
+		}
+
+		msgs, err := c.Evaluate(context.Background(), keys[0], keys[1], cfg)
+		if err != nil {
+			return nil, err
+		}
+		if len(msgs) == 0 {
+			return value, nil
+		}
+
+		switch c.action {
+		case Fail:
+			return nil, errors.Wrapf(errcode.ErrPolicyViolation, "%s: %s", key, strings.Join(msgs, ", "))
+		case Exclude:
+			c.add(key, msgs)
+			return nil, errors.Wrapf(errcode.ErrWriterExcludedKey, "%s violates the policies", key)
+		default:
+			c.add(key, msgs)
+			return value, nil
+		}
+	})
+}
+
+// Evaluate returns the messages of the policies violated by the
+// resource of type t and name with the cfg, the input of the
+// policies has the 'type', 'name' and 'attributes' of it
+func (c *Checker) Evaluate(ctx context.Context, t, name string, cfg map[string]interface{}) ([]string, error) {
+	in, err := json.Marshal(map[string]interface{}{
+		"type":       t,
+		"name":       name,
+		"attributes": attributes(cfg),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.bin, "eval", "--format", "json", "--stdin-input", "--data", c.path, Query)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(errcode.ErrPolicyEvalFailed, "%s: %s\n%s%s", t, err, out.String(), stderr.String())
+	}
+
+	return decodeResult(out.Bytes())
+}
+
+// Violations returns the violations
+// found sorted by the key
+func (c *Checker) Violations() []Violation {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	res := append([]Violation{}, c.violations...)
+	sort.Slice(res, func(i, j int) bool { return res[i].Key < res[j].Key })
+
+	return res
+}
+
+// Report writes the Violations to the w
+func (c *Checker) Report(w io.Writer) {
+	vs := c.Violations()
+	if len(vs) == 0 {
+		return
+	}
+
+	verb := "violate"
+	if c.action == Exclude {
+		verb = "excluded as they violate"
+	}
+
+	fmt.Fprintf(w, "\n%d resources %s the policies:\n", len(vs), verb)
+	for _, v := range vs {
+		for _, m := range v.Messages {
+			fmt.Fprintf(w, "  %s: %s\n", v.Key, m)
+		}
+	}
+}
+
+// add adds the msgs as a Violation of the key
+func (c *Checker) add(key string, msgs []string) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.violations = append(c.violations, Violation{Key: key, Messages: msgs})
+}
+
+// result is the output of 'opa eval --format json'
+type result struct {
+	Result []struct {
+		Expressions []struct {
+			Value interface{} `json:"value"`
+		} `json:"expressions"`
+	} `json:"result"`
+}
+
+// decodeResult returns the messages of the result on b,
+// an undefined result (ex: no 'deny' rule) has none
+func decodeResult(b []byte) ([]string, error) {
+	var r result
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, errors.Wrapf(errcode.ErrPolicyEvalFailed, "invalid output: %s", err)
+	}
+
+	msgs := make([]string, 0)
+	for _, rr := range r.Result {
+		for _, e := range rr.Expressions {
+			switch v := e.Value.(type) {
+			case []interface{}:
+				for _, m := range v {
+					msgs = append(msgs, fmt.Sprint(m))
+				}
+			case nil:
+			default:
+				msgs = append(msgs, fmt.Sprint(v))
+			}
+		}
+	}
+	sort.Strings(msgs)
+
+	return msgs, nil
+}
+
+// attributes returns the cfg without the internal
+// '=tc=' prefix on the keys of the attributes
+func attributes(cfg map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(cfg))
+	for k, v := range cfg {
+		res[strings.TrimPrefix(k, "=tc=")] = attribute(v)
+	}
+	return res
+}
+
+// attribute returns the v with the nested
+// blocks of it without the '=tc=' prefix
+func attribute(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		return attributes(vv)
+	case []interface{}:
+		res := make([]interface{}, 0, len(vv))
+		for _, e := range vv {
+			res = append(res, attribute(e))
+		}
+		return res
+	default:
+		return v
+	}
+}
@@ -0,0 +1,120 @@
+package policy_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/policy"
+)
+
+// fakeOPA writes an opa script to a new directory that
+// denies the inputs with a '0.0.0.0/0' on them
+func fakeOPA(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "terracognita-policy-test")
+	require.NoError(t, err)
+
+	bin := filepath.Join(dir, "opa")
+	script := `#!/bin/sh
+if grep -q '0.0.0.0/0'; then
+	echo '{"result":[{"expressions":[{"value":["open to the world"],"text":"data.terracognita.deny"}]}]}'
+else
+	echo '{}'
+fi
+`
+	require.NoError(t, ioutil.WriteFile(bin, []byte(script), 0755))
+
+	return bin, func() { os.RemoveAll(dir) }
+}
+
+func TestChecker(t *testing.T) {
+	var (
+		open   = map[string]interface{}{"=tc=cidr_blocks": []interface{}{"0.0.0.0/0"}}
+		closed = map[string]interface{}{"cidr_blocks": []interface{}{"10.0.0.0/8"}}
+	)
+
+	t.Run("Warn", func(t *testing.T) {
+		bin, clean := fakeOPA(t)
+		defer clean()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		w := mock.NewWriter(ctrl)
+		w.EXPECT().Write("aws_security_group.open", open).Return(nil)
+		w.EXPECT().Write("aws_security_group.closed", closed).Return(nil)
+
+		c, err := policy.New(bin, "policies", policy.Warn)
+		require.NoError(t, err)
+
+		pw := c.Middleware()(w)
+		require.NoError(t, pw.Write("aws_security_group.open", open))
+		require.NoError(t, pw.Write("aws_security_group.closed", closed))
+
+		assert.Equal(t, []policy.Violation{{Key: "aws_security_group.open", Messages: []string{"open to the world"}}}, c.Violations())
+
+		out := &bytes.Buffer{}
+		c.Report(out)
+		assert.Equal(t, "\n1 resources violate the policies:\n  aws_security_group.open: open to the world\n", out.String())
+	})
+
+	t.Run("Exclude", func(t *testing.T) {
+		bin, clean := fakeOPA(t)
+		defer clean()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		w := mock.NewWriter(ctrl)
+		w.EXPECT().Write("variable.name", open).Return(nil)
+
+		c, err := policy.New(bin, "policies", policy.Exclude)
+		require.NoError(t, err)
+
+		pw := c.Middleware()(w)
+		err = pw.Write("aws_security_group.open", open)
+		assert.Equal(t, errcode.ErrWriterExcludedKey, errors.Cause(err))
+
+		// The variables are not evaluated
+		require.NoError(t, pw.Write("variable.name", open))
+
+		assert.Len(t, c.Violations(), 1)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		bin, clean := fakeOPA(t)
+		defer clean()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		c, err := policy.New(bin, "policies", policy.Fail)
+		require.NoError(t, err)
+
+		err = c.Middleware()(mock.NewWriter(ctrl)).Write("aws_security_group.open", open)
+		assert.Equal(t, errcode.ErrPolicyViolation, errors.Cause(err))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := policy.New("/not/found/opa", "policies", policy.Warn)
+		assert.Equal(t, errcode.ErrPolicyOPANotFound, errors.Cause(err))
+	})
+}
+
+func TestParseAction(t *testing.T) {
+	a, err := policy.ParseAction("exclude")
+	require.NoError(t, err)
+	assert.Equal(t, policy.Exclude, a)
+
+	_, err = policy.ParseAction("ignore")
+	assert.Error(t, err)
+}
@@ -0,0 +1,5 @@
+// Package progress reports the progress of an Import,
+// as the resources of each type are imported, to the
+// CLI (counter or bars) or to anything that tracks it
+// (ex: the server Jobs)
+package progress
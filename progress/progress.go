@@ -0,0 +1,284 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Progress is notified as the resources are imported,
+// the types are imported one after the other
+type Progress interface {
+	// Init is called before the Import
+	// with the number of types imported
+	Init(types int)
+
+	// Start is called when the import of the
+	// total resources of the type t starts
+	Start(t string, total int)
+
+	// Increment is called when a resource
+	// of the current type is imported
+	Increment()
+
+	// Done is called when all the resources
+	// of the current type are imported
+	Done()
+}
+
+// ETA estimates the time to import the left resources
+// from the throughput of the done ones on the elapsed
+func ETA(elapsed time.Duration, done, left int) time.Duration {
+	if done == 0 || left <= 0 {
+		return 0
+	}
+	return time.Duration(int64(elapsed) / int64(done) * int64(left))
+}
+
+// Status is the progress at a moment of the Import
+type Status struct {
+	// Type is the type being imported with the
+	// Current resource of the Total of it
+	Type    string `json:"type"`
+	Current int    `json:"current"`
+	Total   int    `json:"total"`
+
+	// Types is the number of types to import
+	// and TypesDone the ones already imported
+	Types     int `json:"types"`
+	TypesDone int `json:"types_done"`
+
+	// ETA is the estimated time left of all
+	// the Import, the types not imported yet
+	// are estimated with the average of the
+	// resources of the imported ones
+	ETA time.Duration `json:"eta"`
+}
+
+// Tracker is a Progress that keeps the Status of the Import,
+// it's safe to be used concurrently so it can be checked
+// while the Import is running
+type Tracker struct {
+	mx sync.Mutex
+
+	status Status
+
+	// start is when the Import started and done
+	// the resources imported since, finished are
+	// the ones of the types already done
+	start    time.Time
+	done     int
+	finished int
+
+	// running is true while a type is imported
+	running bool
+
+	now func() time.Time
+}
+
+// NewTracker returns a new Tracker
+func NewTracker() *Tracker {
+	return &Tracker{now: time.Now}
+}
+
+// Init initializes the number of types
+func (t *Tracker) Init(types int) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.status = Status{Types: types}
+	t.start = t.now()
+	t.done, t.finished = 0, 0
+}
+
+// Start starts the type rt
+func (t *Tracker) Start(rt string, total int) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.status.Type, t.status.Current, t.status.Total = rt, 0, total
+	t.running = true
+}
+
+// Increment increments the current resource
+func (t *Tracker) Increment() {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.status.Current++
+	t.done++
+	t.status.ETA = t.eta()
+}
+
+// Done ends the current type
+func (t *Tracker) Done() {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.finished += t.status.Current
+	t.status.TypesDone++
+	t.running = false
+	t.status.ETA = t.eta()
+}
+
+// Status returns the current Status
+func (t *Tracker) Status() Status {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	return t.status
+}
+
+// eta estimates the time left of the Import, the
+// lock has to be hold by the caller
+func (t *Tracker) eta() time.Duration {
+	left := t.status.Types - t.status.TypesDone
+	if t.running {
+		left--
+	}
+
+	// The types not started yet are estimated with
+	// the average of the ones already imported
+	var avg int
+	if t.status.TypesDone != 0 {
+		avg = t.finished / t.status.TypesDone
+	}
+
+	resources := avg * left
+	if t.running {
+		resources += t.status.Total - t.status.Current
+	}
+
+	return ETA(t.now().Sub(t.start), t.done, resources)
+}
+
+// counter writes the current resource and the total
+type counter struct {
+	out     io.Writer
+	t       string
+	current int
+	total   int
+}
+
+// NewCounter returns a Progress that writes to the
+// out the current resource of the type being imported
+// (ex: 'Importing aws_instance [1/10]') on the same line
+func NewCounter(out io.Writer) Progress {
+	return &counter{out: out}
+}
+
+func (c *counter) Init(types int) {}
+
+func (c *counter) Start(t string, total int) {
+	c.t, c.current, c.total = t, 0, total
+}
+
+func (c *counter) Increment() {
+	c.current++
+	fmt.Fprintf(c.out, "\rImporting %s [%d/%d]", c.t, c.current, c.total)
+}
+
+func (c *counter) Done() {
+	if c.total > 0 {
+		fmt.Fprintf(c.out, "\rImporting %s [%d/%d] Done!\n", c.t, c.total, c.total)
+	}
+}
+
+// barWidth is the number of characters of the bar
+const barWidth = 30
+
+// bar writes the progress bar of the type being
+// imported with the ETA of all the Import
+type bar struct {
+	*Tracker
+
+	out io.Writer
+}
+
+// NewBar returns a Progress that writes to the out a
+// bar of each type, with the number of types imported
+// and the ETA of all the Import based on the throughput
+// (ex: 'aws_instance [=====>    ] 5/10 types 3/40 ETA 2m10s')
+func NewBar(out io.Writer) Progress {
+	return &bar{Tracker: NewTracker(), out: out}
+}
+
+func (b *bar) Increment() {
+	b.Tracker.Increment()
+	b.write("")
+}
+
+func (b *bar) Done() {
+	b.Tracker.Done()
+	if b.Status().Total > 0 {
+		b.write("\n")
+	}
+}
+
+// write writes the bar of the current Status
+// on the same line followed by the end
+func (b *bar) write(end string) {
+	s := b.Status()
+
+	filled := barWidth
+	if s.Total > 0 {
+		filled = barWidth * s.Current / s.Total
+	}
+
+	fill := strings.Repeat("=", filled)
+	if filled < barWidth {
+		fill += ">" + strings.Repeat(" ", barWidth-filled-1)
+	}
+
+	eta := ""
+	if s.ETA > 0 {
+		eta = fmt.Sprintf(" ETA %s", s.ETA.Round(time.Second))
+	}
+
+	// The trailing spaces clean the previous
+	// line if it was longer
+	fmt.Fprintf(b.out, "\r%s [%s] %d/%d types %d/%d%s    %s", s.Type, fill, s.Current, s.Total, s.TypesDone, s.Types, eta, end)
+}
+
+// quiet does not report anything
+type quiet struct{}
+
+// NewQuiet returns a Progress that ignores the progress
+func NewQuiet() Progress { return quiet{} }
+
+func (quiet) Init(types int)            {}
+func (quiet) Start(t string, total int) {}
+func (quiet) Increment()                {}
+func (quiet) Done()                     {}
+
+// multi notifies all the Progresses
+type multi []Progress
+
+// NewMulti returns a Progress that notifies all the ps
+func NewMulti(ps ...Progress) Progress { return multi(ps) }
+
+func (m multi) Init(types int) {
+	for _, p := range m {
+		p.Init(types)
+	}
+}
+
+func (m multi) Start(t string, total int) {
+	for _, p := range m {
+		p.Start(t, total)
+	}
+}
+
+func (m multi) Increment() {
+	for _, p := range m {
+		p.Increment()
+	}
+}
+
+func (m multi) Done() {
+	for _, p := range m {
+		p.Done()
+	}
+}
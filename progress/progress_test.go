@@ -0,0 +1,84 @@
+package progress_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cycloidio/terracognita/progress"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETA(t *testing.T) {
+	assert.Equal(t, 30*time.Second, progress.ETA(10*time.Second, 5, 15))
+	assert.Equal(t, time.Duration(0), progress.ETA(10*time.Second, 0, 15))
+	assert.Equal(t, time.Duration(0), progress.ETA(10*time.Second, 5, 0))
+}
+
+func TestCounter(t *testing.T) {
+	out := &bytes.Buffer{}
+	c := progress.NewCounter(out)
+
+	c.Init(2)
+	c.Start("aws_instance", 2)
+	c.Increment()
+	c.Increment()
+	c.Done()
+	c.Start("aws_vpc", 0)
+	c.Done()
+
+	assert.Equal(t, "\rImporting aws_instance [1/2]\rImporting aws_instance [2/2]\rImporting aws_instance [2/2] Done!\n", out.String())
+}
+
+func TestTracker(t *testing.T) {
+	tr := progress.NewTracker()
+
+	tr.Init(3)
+	tr.Start("aws_instance", 2)
+	tr.Increment()
+
+	st := tr.Status()
+	assert.Equal(t, "aws_instance", st.Type)
+	assert.Equal(t, 1, st.Current)
+	assert.Equal(t, 2, st.Total)
+	assert.Equal(t, 3, st.Types)
+	assert.Equal(t, 0, st.TypesDone)
+
+	tr.Increment()
+	tr.Done()
+
+	st = tr.Status()
+	assert.Equal(t, 2, st.Current)
+	assert.Equal(t, 1, st.TypesDone)
+}
+
+func TestBar(t *testing.T) {
+	out := &bytes.Buffer{}
+	b := progress.NewBar(out)
+
+	b.Init(1)
+	b.Start("aws_instance", 2)
+	b.Increment()
+	b.Increment()
+	b.Done()
+
+	assert.Contains(t, out.String(), "\raws_instance [===============>              ] 1/2 types 0/1")
+	assert.Contains(t, out.String(), "\raws_instance [==============================] 2/2 types 1/1")
+	assert.True(t, bytes.HasSuffix(out.Bytes(), []byte("\n")))
+}
+
+func TestMulti(t *testing.T) {
+	var (
+		out = &bytes.Buffer{}
+		tr  = progress.NewTracker()
+		m   = progress.NewMulti(progress.NewCounter(out), tr, progress.NewQuiet())
+	)
+
+	m.Init(1)
+	m.Start("aws_vpc", 1)
+	m.Increment()
+	m.Done()
+
+	assert.Equal(t, 1, tr.Status().TypesDone)
+	assert.Contains(t, out.String(), "Importing aws_vpc [1/1] Done!")
+}
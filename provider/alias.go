@@ -0,0 +1,30 @@
+package provider
+
+// TypeAliaser is an optional interface of the Provider for the ones
+// which have resource types renamed/deprecated on the TF provider
+type TypeAliaser interface {
+	// TypeAliases returns the deprecated resource
+	// types with the type that replaces them
+	TypeAliases() map[string]string
+}
+
+// TypeAlias returns the type that replaces the deprecated type t
+// of the p, if it is not deprecated or the new type is not on the
+// TF provider (older versions of it) the t is returned with false
+func TypeAlias(p Provider, t string) (string, bool) {
+	ta, ok := p.(TypeAliaser)
+	if !ok {
+		return t, false
+	}
+
+	nt, ok := ta.TypeAliases()[t]
+	if !ok {
+		return t, false
+	}
+
+	if _, ok := p.TFProvider().ResourcesMap[nt]; !ok {
+		return t, false
+	}
+
+	return nt, true
+}
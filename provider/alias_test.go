@@ -0,0 +1,57 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type aliasProvider struct {
+	*mock.Provider
+}
+
+func (aliasProvider) TypeAliases() map[string]string {
+	return map[string]string{
+		"aws_alb":        "aws_lb",
+		"aws_alb_legacy": "aws_lb_legacy",
+	}
+}
+
+func TestTypeAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mp := mock.NewProvider(ctrl)
+	mp.EXPECT().TFProvider().Return(&schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"aws_lb": &schema.Resource{},
+		},
+	}).AnyTimes()
+	p := aliasProvider{Provider: mp}
+
+	t.Run("Deprecated", func(t *testing.T) {
+		nt, ok := provider.TypeAlias(p, "aws_alb")
+		assert.True(t, ok)
+		assert.Equal(t, "aws_lb", nt)
+		assert.Equal(t, "aws_lb", provider.NewResource("1", "aws_alb", p).Type())
+	})
+	t.Run("NotDeprecated", func(t *testing.T) {
+		nt, ok := provider.TypeAlias(p, "aws_instance")
+		assert.False(t, ok)
+		assert.Equal(t, "aws_instance", nt)
+	})
+	t.Run("NotOnTFProvider", func(t *testing.T) {
+		nt, ok := provider.TypeAlias(p, "aws_alb_legacy")
+		assert.False(t, ok)
+		assert.Equal(t, "aws_alb_legacy", nt)
+	})
+	t.Run("NoAliases", func(t *testing.T) {
+		nt, ok := provider.TypeAlias(mp, "aws_alb")
+		assert.False(t, ok)
+		assert.Equal(t, "aws_alb", nt)
+	})
+}
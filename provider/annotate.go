@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// annotation returns the key of the comment of the HCL written for
+// the r and the comment, with the source of it and when it was
+// imported (at) if imported and the URL of it on the console if
+// console. It's only for the Resources of this package after the
+// HCL has been written, if not or if the comment is empty nothing
+// is returned
+func annotation(r Resource, at time.Time, imported, console bool) (string, string, bool) {
+	res, ok := r.(*resource)
+	if !ok || res.configName == "" {
+		return "", "", false
+	}
+
+	key := fmt.Sprintf("comment.%s.%s", res.resourceType, res.configName)
+	if res.dataSource {
+		key = fmt.Sprintf("comment.data.%s.%s", res.resourceType, res.configName)
+	}
+
+	lines := make([]string, 0, 2)
+	if imported {
+		source := res.provider.String()
+		if region := res.provider.Region(); region != "" {
+			source = fmt.Sprintf("%s %s", source, region)
+		}
+
+		lines = append(lines, fmt.Sprintf("Imported from %s with ID %s at %s", source, res.id, at.UTC().Format(time.RFC3339)))
+	}
+
+	if c, ok := res.provider.(Consoler); ok && console {
+		if u := c.ConsoleURL(res.resourceType, res.id, AttributeGetter(r)); u != "" {
+			lines = append(lines, fmt.Sprintf("Console: %s", u))
+		}
+	}
+
+	if len(lines) == 0 {
+		return "", "", false
+	}
+
+	return key, strings.Join(lines, "\n"), true
+}
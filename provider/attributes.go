@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// AttributeReader is an optional interface of the Provider for the
+// ones that read attributes of the resources that the TF provider
+// does not read (ex: the schema of an aws_appsync_graphql_api)
+type AttributeReader interface {
+	// ReadAttributes returns the attributes, as on the state,
+	// of the resource of type t with the id that are not
+	// read by the TF provider, nil if it has none
+	ReadAttributes(ctx context.Context, t, id string) (map[string]string, error)
+}
+
+// attributeSeeder is implemented by the Resources
+// which state can be seeded with attributes
+type attributeSeeder interface {
+	SeedAttributes(attrs map[string]string)
+}
+
+// SeedAttributes sets the attrs on the state before reading it,
+// so the ones not read by the TF provider are kept on the state
+// and the HCL. It has to be called after the ImportState
+func (r *resource) SeedAttributes(attrs map[string]string) {
+	if len(attrs) == 0 {
+		return
+	}
+
+	if r.state == nil {
+		r.state = &terraform.InstanceState{ID: r.id}
+	}
+	if r.state.Attributes == nil {
+		r.state.Attributes = make(map[string]string)
+	}
+
+	for k, v := range attrs {
+		r.state.Attributes[k] = v
+	}
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// breakerClasses are the classes of the errors counted by the
+// breakers, the ones that will fail all the resources of the service
+var breakerClasses = []string{errcode.ClassPermission, errcode.ClassUnavailable}
+
+// breakers are the circuit breakers of the services of the resource
+// types (ex: elasticache of aws_elasticache_cluster). The breaker of
+// a service is opened after max consecutive errors of breakerClasses
+// on the resources of it, so the rest of them are failed without
+// being read, instead of retrying each one, and the service is
+// reported once. A nil breakers never opens
+type breakers struct {
+	max int
+
+	mu sync.Mutex
+
+	// errs are the consecutive errors
+	// of the services by the key
+	errs map[string]int
+
+	// opened are the keys of the services
+	// opened, with the errors that opened
+	// them on the same order
+	opened []string
+	causes []error
+}
+
+// newBreakers returns the breakers opened after
+// max errors, or nil if the max is not set
+func newBreakers(max int) *breakers {
+	if max <= 0 {
+		return nil
+	}
+
+	return &breakers{
+		max:  max,
+		errs: make(map[string]int),
+	}
+}
+
+// breakerKey returns the key of the breaker of the service of the
+// type t of the p, with the alias of it if aliased as the APIs can
+// fail on one region and not on the rest
+func breakerKey(p Provider, t string) string {
+	service := t
+	if parts := strings.SplitN(t, "_", 3); len(parts) > 1 {
+		service = parts[1]
+	}
+
+	if alias := ProviderAlias(p); alias != "" {
+		return fmt.Sprintf("%s (%s)", service, alias)
+	}
+
+	return service
+}
+
+// counts checks if the err is
+// counted by the breakers
+func (b *breakers) counts(err error) bool {
+	if b == nil {
+		return false
+	}
+
+	class := errcode.Class(err)
+	for _, c := range breakerClasses {
+		if c == class {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isOpen checks if the breaker of the key is open
+func (b *breakers) isOpen(key string) bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.errs[key] >= b.max
+}
+
+// fail adds the err to the breaker of the key, if it's counted,
+// and returns true if the breaker was opened with it
+func (b *breakers) fail(key string, err error) bool {
+	if !b.counts(err) {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.errs[key] >= b.max {
+		return false
+	}
+
+	b.errs[key]++
+	if b.errs[key] < b.max {
+		return false
+	}
+
+	b.opened = append(b.opened, key)
+	b.causes = append(b.causes, err)
+
+	return true
+}
+
+// succeed resets the errors of the breaker
+// of the key, if it's not already open
+func (b *breakers) succeed(key string) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.errs[key] < b.max {
+		delete(b.errs, key)
+	}
+}
+
+// report writes to the out the
+// services which breakers were opened
+func (b *breakers) report(out io.Writer) {
+	if b == nil || len(b.opened) == 0 {
+		return
+	}
+
+	fmt.Fprintf(out, "\nSkipped %d services after %d consecutive errors, the rest of their resources were not read:\n", len(b.opened), b.max)
+	for i, k := range b.opened {
+		fmt.Fprintf(out, "  %s: %s\n", k, strings.SplitN(b.causes[i].Error(), "\n", 2)[0])
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// Collector is a writer.Collector of Resources, the base of the
+// Writers of the formats that render all the Resources on the Sync
+type Collector struct {
+	*writer.Collector
+}
+
+// NewCollector returns a Collector initialization for the format with
+// the name, if types are given only the Resources of those types are
+// collected and the other ones are ignored
+func NewCollector(name string, types ...string) *Collector {
+	ts := make(map[string]struct{}, len(types))
+	for _, t := range types {
+		ts[t] = struct{}{}
+	}
+
+	return &Collector{
+		Collector: writer.NewCollector(name, func(key string, value interface{}) (bool, error) {
+			if _, ok := value.(Resource); !ok {
+				return false, errors.Wrapf(errcode.ErrWriterInvalidTypeValue, "expected provider.Resource, found %T", value)
+			}
+
+			if len(ts) == 0 {
+				return true, nil
+			}
+
+			_, ok := ts[strings.Split(key, ".")[0]]
+			return ok, nil
+		}),
+	}
+}
+
+// Resource returns the Resource written with the key, nil if none
+func (c *Collector) Resource(key string) Resource {
+	r, _ := c.Config[key].(Resource)
+	return r
+}
+
+// Resources returns all the Resources written by the key
+func (c *Collector) Resources() map[string]Resource {
+	res := make(map[string]Resource, len(c.Config))
+	for k, v := range c.Config {
+		res[k] = v.(Resource)
+	}
+	return res
+}
@@ -0,0 +1,42 @@
+package provider
+
+// registry keeps the resources already written on an
+// Import, so the ones found more than once (ex: the
+// global resources on each region) are only written once
+type registry struct {
+	seen map[string]struct{}
+}
+
+// newRegistry returns an empty registry
+func newRegistry() *registry {
+	return &registry{seen: make(map[string]struct{})}
+}
+
+// add registers the r, which is of the type t and id if it's
+// the resource listed, and returns false if it was already
+func (rg *registry) add(r, listed Resource, t, id string) bool {
+	k := registryKey(r, listed, t, id)
+	if _, ok := rg.seen[k]; ok {
+		return false
+	}
+	rg.seen[k] = struct{}{}
+	return true
+}
+
+// registryKey returns the key of the r on the registry, the ARN
+// if it has one as the same ID can be of different resources
+// on each region (ex: the name of an aws_cloudwatch_log_group)
+func registryKey(r, listed Resource, t, id string) string {
+	if res, ok := r.(*resource); ok {
+		t, id = res.resourceType, res.id
+		if res.data != nil {
+			if arn, ok := res.data.Get("arn").(string); ok && arn != "" {
+				return t + "." + arn
+			}
+		}
+	} else if r != listed {
+		t, id = r.Type(), r.ID()
+	}
+
+	return t + "." + id
+}
@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cycloidio/terracognita/filter"
+)
+
+// dependencies keeps the references of the resources imported, so
+// the resources referenced and not imported (ex: the aws_security_group
+// of an aws_instance outside of the filter) can be imported after them
+type dependencies struct {
+	// attributes are the attributes
+	// with the types they reference
+	attributes map[string][]string
+
+	// imported are the values of the references
+	// (ex: aws_iam_role.arn) of the imported resources
+	imported map[string]map[string]struct{}
+
+	// referenced are the values of the references, with the original
+	// value of it, found on the resources imported on the current pass
+	referenced map[string]map[string]string
+
+	// wanted are the referenced of the previous pass that
+	// were not imported, which are imported on the current one
+	wanted map[string]map[string]string
+}
+
+// newDependencies returns the dependencies of the References of the
+// p if it's a Referencer, if not it returns false as there are none
+func newDependencies(p Provider) (*dependencies, bool) {
+	rp, ok := p.(Referencer)
+	if !ok {
+		return nil, false
+	}
+
+	return &dependencies{
+		attributes: rp.References(),
+		imported:   make(map[string]map[string]struct{}),
+		referenced: make(map[string]map[string]string),
+	}, true
+}
+
+// add registers the r as imported and the values
+// of the attributes of it referencing other resources
+func (d *dependencies) add(r Resource) {
+	for ref, v := range resourceValues(d.attributes, r) {
+		if _, ok := d.imported[ref]; !ok {
+			d.imported[ref] = make(map[string]struct{})
+		}
+		d.imported[ref][referenceValue(v)] = struct{}{}
+	}
+
+	for ref, values := range referencedValues(d.attributes, r) {
+		for _, v := range values {
+			if _, ok := d.referenced[ref]; !ok {
+				d.referenced[ref] = make(map[string]string)
+			}
+			d.referenced[ref][referenceValue(v)] = v
+		}
+	}
+}
+
+// next starts the next pass with the resources referenced on
+// the current one that are not imported, and returns the types
+// of those ordered as the types, which is empty if there are none
+func (d *dependencies) next(types []string) []string {
+	d.wanted = make(map[string]map[string]string)
+	for ref, values := range d.referenced {
+		for nv, v := range values {
+			if _, ok := d.imported[ref][nv]; ok {
+				continue
+			}
+			if _, ok := d.wanted[ref]; !ok {
+				d.wanted[ref] = make(map[string]string)
+			}
+			d.wanted[ref][nv] = v
+		}
+	}
+	d.referenced = make(map[string]map[string]string)
+
+	wanted := make(map[string]struct{})
+	for ref := range d.wanted {
+		t, _ := splitReference(ref)
+		wanted[t] = struct{}{}
+	}
+
+	res := make([]string, 0, len(wanted))
+	for _, t := range types {
+		if _, ok := wanted[t]; ok {
+			res = append(res, t)
+			delete(wanted, t)
+		}
+	}
+
+	// The types referenced that are not on the
+	// types (ex: registered ones) are the last
+	rest := make([]string, 0, len(wanted))
+	for t := range wanted {
+		rest = append(rest, t)
+	}
+	sort.Strings(rest)
+
+	return append(res, rest...)
+}
+
+// filter returns the Filter to list the wanted resources of the type t,
+// with the Exclude of the f. If the resources of t are only referenced
+// by ID only those are targeted, if not all of them have to be read
+func (d *dependencies) filter(t string, f *filter.Filter) *filter.Filter {
+	df := &filter.Filter{
+		Exclude: f.Exclude,
+	}
+
+	var targets []string
+	for ref, values := range d.wanted {
+		rt, attr := splitReference(ref)
+		if rt != t {
+			continue
+		}
+		if attr != "id" {
+			return df
+		}
+		for _, v := range values {
+			targets = append(targets, t+"."+v)
+		}
+	}
+	sort.Strings(targets)
+	df.Targets = targets
+
+	return df
+}
+
+// isWanted checks if the r is referenced by the
+// resources imported on the previous pass
+func (d *dependencies) isWanted(r Resource) bool {
+	for ref, v := range resourceValues(d.attributes, r) {
+		if _, ok := d.wanted[ref][referenceValue(v)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceValues returns the values of the r for each
+// reference to the type of it on the attributes
+func resourceValues(attributes map[string][]string, r Resource) map[string]string {
+	t := r.Type()
+	res := make(map[string]string)
+	for _, refs := range attributes {
+		for _, ref := range refs {
+			rt, attr := splitReference(ref)
+			if rt != t {
+				continue
+			}
+
+			v := r.ID()
+			if attr != "id" {
+				if r.Data() == nil {
+					continue
+				}
+				v, _ = r.Data().Get(attr).(string)
+			}
+			if v != "" {
+				res[ref] = v
+			}
+		}
+	}
+	return res
+}
+
+// referencedValues returns the values of the attributes of the r
+// referencing other resources for each reference (ex: aws_vpc)
+func referencedValues(attributes map[string][]string, r Resource) map[string][]string {
+	if r.Data() == nil {
+		return nil
+	}
+	state := r.Data().State()
+	if state == nil {
+		return nil
+	}
+
+	res := make(map[string][]string)
+	for k, v := range state.Attributes {
+		refs, ok := attributes[attributeName(k)]
+		if !ok || v == "" {
+			continue
+		}
+
+		for _, ref := range refs {
+			res[ref] = append(res[ref], v)
+		}
+	}
+	return res
+}
+
+// attributeName returns the name of the attribute of the
+// flatmap key k, the last part of it that is not an index
+// or a hash of a list or set (ex: ec2_attributes.0.subnet_id
+// is subnet_id and security_groups.1234 is security_groups)
+func attributeName(k string) string {
+	parts := strings.Split(k, ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		p := parts[i]
+		if p == "#" || p == "%" {
+			return ""
+		}
+		if _, err := strconv.Atoi(p); err == nil {
+			continue
+		}
+		return p
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// dependents keeps the resources that depend on a root resource, the
+// ones referencing it or other of its dependents, so only those are
+// imported. The resources read before the ones they reference are
+// held until those are imported
+type dependents struct {
+	// attributes are the attributes
+	// with the types they reference
+	attributes map[string][]string
+
+	// t and id are the type and ID of the root
+	t, id string
+	found bool
+
+	// values are the values of the references
+	// (ex: aws_vpc) of the dependents imported
+	values map[string]map[string]struct{}
+
+	// held are the resources that reference other resources
+	// but not yet any of the dependents, with the type
+	// of them to import them later
+	held []heldResource
+}
+
+// heldResource is a resource read that
+// could be one of the dependents
+type heldResource struct {
+	rr readResource
+	t  string
+}
+
+// newDependents returns the dependents of the resource of (with the
+// format 'TYPE:ID') of the p, which has to be a Referencer
+func newDependents(p Provider, of string) (*dependents, error) {
+	parts := strings.SplitN(of, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.Errorf("invalid dependents of %q, the expected format is 'TYPE:ID'", of)
+	}
+
+	if !p.HasResourceType(parts[0]) {
+		return nil, errors.Wrapf(errcode.ErrProviderResourceNotSupported, "type %s on dependents of", parts[0])
+	}
+
+	rp, ok := p.(Referencer)
+	if !ok {
+		return nil, errors.Errorf("the provider %s has no references between the resources to find the dependents of %q", p, of)
+	}
+
+	return &dependents{
+		attributes: rp.References(),
+		t:          parts[0],
+		id:         parts[1],
+		values:     make(map[string]map[string]struct{}),
+	}, nil
+}
+
+// isRoot checks if the r, listed with the id, is the root
+func (d *dependents) isRoot(r Resource, id string) bool {
+	return r.Type() == d.t && (r.ID() == d.id || id == d.id)
+}
+
+// isDependent checks if the r, listed with the id, is
+// the root or references any of the dependents imported
+func (d *dependents) isDependent(r Resource, id string) bool {
+	if d.isRoot(r, id) {
+		return true
+	}
+
+	for ref, values := range referencedValues(d.attributes, r) {
+		for _, v := range values {
+			if _, ok := d.values[ref][referenceValue(v)]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// add registers the r as one of the dependents imported
+func (d *dependents) add(r Resource, id string) {
+	if d.isRoot(r, id) {
+		d.found = true
+	}
+
+	for ref, v := range resourceValues(d.attributes, r) {
+		if _, ok := d.values[ref]; !ok {
+			d.values[ref] = make(map[string]struct{})
+		}
+		d.values[ref][referenceValue(v)] = struct{}{}
+	}
+}
+
+// hold keeps the rr of the type t if it references other
+// resources, so it can be imported if any of those is one of
+// the dependents imported later, and returns if it was held
+func (d *dependents) hold(rr readResource, t string) bool {
+	if len(referencedValues(d.attributes, rr.r)) == 0 {
+		return false
+	}
+	d.held = append(d.held, heldResource{rr: rr, t: t})
+	return true
+}
+
+// release calls the fn with the held resources that are dependents,
+// until no more are, and returns the ones that are not
+func (d *dependents) release(fn func(h heldResource) error) ([]heldResource, error) {
+	for released := true; released; {
+		released = false
+		held := d.held[:0]
+		for _, h := range d.held {
+			if !d.isDependent(h.rr.r, h.rr.id) {
+				held = append(held, h)
+				continue
+			}
+			if err := fn(h); err != nil {
+				return nil, err
+			}
+			released = true
+		}
+		d.held = held
+	}
+
+	held := d.held
+	d.held = nil
+	return held, nil
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/log"
+)
+
+// Discover lists from the Provider p all the resources filtered by f
+// without reading them and returns the IDs grouped by resource type
+func Discover(ctx context.Context, p Provider, f *filter.Filter, out io.Writer) (map[string][]string, error) {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "provider.Discover")
+
+	if f.HasPatterns() {
+		if err := f.Expand(p.ResourceTypes()); err != nil {
+			return nil, err
+		}
+	}
+
+	types := f.Include
+	if len(types) == 0 {
+		types = p.ResourceTypes()
+	}
+
+	inventory := make(map[string][]string)
+	for _, t := range types {
+		logger := kitlog.With(logger, "resource", t)
+
+		if !p.HasResourceType(t) {
+			return nil, errors.Wrapf(errcode.ErrProviderResourceNotSupported, "type %s on Include filter", t)
+		}
+
+		if f.IsExcluded(t) {
+			logger.Log("msg", "excluded")
+			continue
+		}
+
+		fmt.Fprintf(out, "\rDiscovering %s", t)
+		logger.Log("msg", "fetching the list of resources")
+
+		resources, err := p.Resources(ctx, t, f)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		ids := make([]string, 0, len(resources))
+		for _, r := range resources {
+			id := r.ID()
+			if !f.IsTargeted(t, id) || f.IsIgnored(t, id) {
+				continue
+			}
+			ids = append(ids, id)
+		}
+		inventory[t] = ids
+	}
+
+	fmt.Fprintf(out, "\rDiscovering Done!\n")
+
+	return inventory, nil
+}
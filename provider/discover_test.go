@@ -0,0 +1,99 @@
+package provider_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p         = mock.NewProvider(ctrl)
+			instance1 = mock.NewResource(ctrl)
+			instance2 = mock.NewResource(ctrl)
+			iamUser   = mock.NewResource(ctrl)
+
+			f = &filter.Filter{
+				Exclude: []string{"aws_s3_bucket"},
+			}
+		)
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user", "aws_s3_bucket"})
+		p.EXPECT().HasResourceType("aws_instance").Return(true)
+		p.EXPECT().HasResourceType("aws_iam_user").Return(true)
+		p.EXPECT().HasResourceType("aws_s3_bucket").Return(true)
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instance1, instance2}, nil)
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser}, nil)
+
+		instance1.EXPECT().ID().Return("1")
+		instance2.EXPECT().ID().Return("2")
+		iamUser.EXPECT().ID().Return("3")
+
+		inventory, err := provider.Discover(ctx, p, f, ioutil.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{
+			"aws_instance": []string{"1", "2"},
+			"aws_iam_user": []string{"3"},
+		}, inventory)
+	})
+	t.Run("SuccessWithFilterTargets", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p         = mock.NewProvider(ctrl)
+			instance1 = mock.NewResource(ctrl)
+			instance2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{
+				Include: []string{"aws_instance"},
+				Targets: []string{"aws_instance.2"},
+			}
+		)
+		defer ctrl.Finish()
+
+		p.EXPECT().HasResourceType("aws_instance").Return(true)
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instance1, instance2}, nil)
+
+		instance1.EXPECT().ID().Return("1")
+		instance2.EXPECT().ID().Return("2")
+
+		inventory, err := provider.Discover(ctx, p, f, ioutil.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{
+			"aws_instance": []string{"2"},
+		}, inventory)
+	})
+	t.Run("ErrProviderResourceNotSupported", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p = mock.NewProvider(ctrl)
+			f = &filter.Filter{
+				Include: []string{"potato"},
+			}
+		)
+		defer ctrl.Finish()
+
+		p.EXPECT().HasResourceType("potato").Return(false)
+
+		_, err := provider.Discover(ctx, p, f, ioutil.Discard)
+		assert.Equal(t, errcode.ErrProviderResourceNotSupported, errors.Cause(err))
+	})
+}
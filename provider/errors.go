@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/pkg/errors"
+)
+
+// List of the classes of the errors
+// when reading the resources
+const (
+	// ErrorClassNotFound is when the resource
+	// does not exist anymore
+	ErrorClassNotFound = "not-found"
+	// ErrorClassAccessDenied is when the credentials
+	// have no permissions to read the resource
+	ErrorClassAccessDenied = "access-denied"
+	// ErrorClassTimeout is when the resource was not
+	// read on the ImportOptions.ResourceTimeout
+	ErrorClassTimeout = "timeout"
+	// ErrorClassRead is any other error
+	ErrorClassRead = "read"
+)
+
+// ErrorClass returns the class of the err
+// returned when reading a resource
+func ErrorClass(err error) string {
+	switch errors.Cause(err) {
+	case errcode.ErrProviderResourceNotRead:
+		return ErrorClassNotFound
+	case errcode.ErrProviderResourceTimeout:
+		return ErrorClassTimeout
+	}
+
+	if errcode.Class(err) == errcode.ClassPermission {
+		return ErrorClassAccessDenied
+	}
+
+	return ErrorClassRead
+}
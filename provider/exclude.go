@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/cycloidio/terracognita/writer"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// excludeWriter removes from the configurations written
+// to it the attributes matching the patterns
+type excludeWriter struct {
+	writer.Writer
+
+	provider Provider
+
+	// patterns are the TYPE and the path
+	// of the ATTRIBUTE of each pattern
+	patterns [][]string
+}
+
+// NewExcludeWriter returns a writer.Writer that writes to w the configurations
+// without the attributes matching the patterns, with the format TYPE.ATTRIBUTE
+// and the glob patterns of path.Match (ex: *.arn or aws_instance.private_dns).
+// The ATTRIBUTE can be nested with '.' (ex: aws_instance.root_block_device.volume_id).
+// The Required attributes, following the schema of the resources of the p, are
+// never removed so the configurations are still valid. Only the HCL is changed,
+// the attributes are still on the TFState
+func NewExcludeWriter(w writer.Writer, p Provider, patterns []string) (writer.Writer, error) {
+	ps := make([][]string, 0, len(patterns))
+	for _, pt := range patterns {
+		parts := strings.Split(pt, ".")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("invalid attribute pattern %q, expected the format TYPE.ATTRIBUTE", pt)
+		}
+		for _, pp := range parts {
+			if _, err := path.Match(pp, ""); err != nil || pp == "" {
+				return nil, fmt.Errorf("invalid attribute pattern %q, expected the format TYPE.ATTRIBUTE", pt)
+			}
+		}
+		ps = append(ps, parts)
+	}
+
+	return &excludeWriter{
+		Writer:   w,
+		provider: p,
+		patterns: ps,
+	}, nil
+}
+
+// Write removes the matched attributes of the value and writes
+// it, the data sources and variables are written as they are
+func (e *excludeWriter) Write(key string, value interface{}) error {
+	cfg, ok := value.(map[string]interface{})
+	keys := strings.Split(key, ".")
+	if !ok || len(keys) != 2 || keys[0] == "variable" {
+		return e.Writer.Write(key, value)
+	}
+
+	attrs := make([][]string, 0)
+	for _, p := range e.patterns {
+		if ok, _ := path.Match(p[0], keys[0]); ok {
+			attrs = append(attrs, p[1:])
+		}
+	}
+	if len(attrs) == 0 {
+		return e.Writer.Write(key, value)
+	}
+
+	var sch map[string]*schema.Schema
+	if tfr, ok := e.provider.TFProvider().ResourcesMap[keys[0]]; ok {
+		sch = tfr.Schema
+	}
+
+	return e.Writer.Write(key, excludeConfig(sch, cfg, attrs))
+}
+
+// excludeConfig returns the cfg without the attributes matching the
+// attrs paths that are not Required on the sch, which can be nil
+func excludeConfig(sch map[string]*schema.Schema, cfg map[string]interface{}, attrs [][]string) map[string]interface{} {
+	res := make(map[string]interface{}, len(cfg))
+	for k, v := range cfg {
+		s := sch[strings.TrimPrefix(k, "=tc=")]
+
+		var excluded bool
+		nested := make([][]string, 0)
+		for _, a := range attrs {
+			if ok, _ := path.Match(a[0], strings.TrimPrefix(k, "=tc=")); !ok {
+				continue
+			}
+			if len(a) == 1 {
+				excluded = true
+				break
+			}
+			nested = append(nested, a[1:])
+		}
+
+		if excluded && (s == nil || !s.Required) {
+			continue
+		}
+
+		if len(nested) != 0 {
+			var nsch map[string]*schema.Schema
+			if s != nil {
+				if sr, ok := s.Elem.(*schema.Resource); ok {
+					nsch = sr.Schema
+				}
+			}
+			v = excludeBlock(nsch, v, nested)
+		}
+
+		res[k] = v
+	}
+
+	return res
+}
+
+// excludeBlock returns the nested block v
+// without the attributes matching the attrs
+func excludeBlock(sch map[string]*schema.Schema, v interface{}, attrs [][]string) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		return excludeConfig(sch, vv, attrs)
+	case []interface{}:
+		bs := make([]interface{}, 0, len(vv))
+		for _, e := range vv {
+			bs = append(bs, excludeBlock(sch, e, attrs))
+		}
+		return bs
+	default:
+		return v
+	}
+}
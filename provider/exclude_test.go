@@ -0,0 +1,77 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExcludeWriter(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			p    = mock.NewProvider(ctrl)
+			w    = mock.NewWriter(ctrl)
+		)
+		defer ctrl.Finish()
+
+		ew, err := provider.NewExcludeWriter(w, p, []string{"*.arn", "aws_instance.private_*", "aws_instance.ami", "aws_instance.root_block_device.volume_id"})
+		require.NoError(t, err)
+
+		p.EXPECT().TFProvider().Return(&schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{
+				"aws_instance": &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ami":         &schema.Schema{Type: schema.TypeString, Required: true},
+						"arn":         &schema.Schema{Type: schema.TypeString, Computed: true},
+						"private_dns": &schema.Schema{Type: schema.TypeString, Computed: true},
+						"private_ip":  &schema.Schema{Type: schema.TypeString, Optional: true, Computed: true},
+						"root_block_device": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"volume_id":   &schema.Schema{Type: schema.TypeString, Computed: true},
+									"volume_size": &schema.Schema{Type: schema.TypeInt, Optional: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		}).AnyTimes()
+
+		w.EXPECT().Write("aws_instance.front", map[string]interface{}{
+			"ami": "ami-123",
+			"root_block_device": []interface{}{
+				map[string]interface{}{"volume_size": 8},
+			},
+		}).Return(nil)
+		w.EXPECT().Write("aws_iam_user.admin", map[string]interface{}{"name": "admin"}).Return(nil)
+		w.EXPECT().Write("data.aws_subnet.shared", map[string]interface{}{"arn": "arn:subnet"}).Return(nil)
+
+		require.NoError(t, ew.Write("aws_instance.front", map[string]interface{}{
+			"ami":         "ami-123",
+			"arn":         "arn:instance",
+			"private_dns": "ip-10-0-0-1",
+			"private_ip":  "10.0.0.1",
+			"root_block_device": []interface{}{
+				map[string]interface{}{"volume_id": "vol-1", "volume_size": 8},
+			},
+		}))
+		require.NoError(t, ew.Write("aws_iam_user.admin", map[string]interface{}{"name": "admin", "arn": "arn:user"}))
+		require.NoError(t, ew.Write("data.aws_subnet.shared", map[string]interface{}{"arn": "arn:subnet"}))
+	})
+
+	t.Run("ErrorInvalidPattern", func(t *testing.T) {
+		for _, pt := range []string{"arn", "aws_instance.", "[.arn"} {
+			_, err := provider.NewExcludeWriter(nil, nil, []string{pt})
+			assert.Error(t, err, pt)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// ExternalizedDir is the directory, on the one of the HCL,
+// where the large attributes are written, see NewExternalizeWriter
+const ExternalizedDir = "files"
+
+// externalizeWriter writes the large attributes of the configurations
+// written to it to files, replaced by the function that reads them
+type externalizeWriter struct {
+	writer.Writer
+
+	dir  string
+	size int
+}
+
+// NewExternalizeWriter returns a writer.Writer that writes to w the configurations
+// with the string attributes longer than size bytes (ex: the dashboard JSON or
+// the user_data) written to the ExternalizedDir of the dir, the one of the HCL,
+// and replaced by a file() or filebase64() with the path of it relative to the
+// module. The files are named TYPE.NAME.ATTRIBUTE with the extension of the content
+// (ex: .json), the attributes ending with '_base64' are written decoded. Only the
+// attributes of the resources, not of the blocks of them, are written to files
+func NewExternalizeWriter(w writer.Writer, dir string, size int) writer.Writer {
+	return &externalizeWriter{
+		Writer: w,
+		dir:    dir,
+		size:   size,
+	}
+}
+
+// Write writes the large attributes of the value to files and
+// writes it, the data sources and variables are written as they are
+func (e *externalizeWriter) Write(key string, value interface{}) error {
+	cfg, ok := value.(map[string]interface{})
+	keys := strings.Split(key, ".")
+	if !ok || len(keys) != 2 || keys[0] == "variable" || keys[0] == "module" {
+		return e.Writer.Write(key, value)
+	}
+
+	for k, v := range cfg {
+		s, ok := v.(string)
+		if !ok || len(s) <= e.size || strings.HasPrefix(s, "${") {
+			continue
+		}
+
+		fn, name, content := "file", fmt.Sprintf("%s.%s", key, k), []byte(s)
+		if strings.HasSuffix(k, "_base64") {
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				continue
+			}
+			fn, content = "filebase64", b
+		} else {
+			name += externalizedExt(s)
+		}
+
+		if err := os.MkdirAll(filepath.Join(e.dir, ExternalizedDir), 0755); err != nil {
+			return errors.Wrapf(err, "unable to create the directory of the attribute %s of %q", k, key)
+		}
+		if err := ioutil.WriteFile(filepath.Join(e.dir, ExternalizedDir, name), content, 0644); err != nil {
+			return errors.Wrapf(err, "unable to write the attribute %s of %q", k, key)
+		}
+
+		cfg[k] = fmt.Sprintf("${%s(\"${path.module}/%s/%s\")}", fn, ExternalizedDir, name)
+	}
+
+	return e.Writer.Write(key, cfg)
+}
+
+// externalizedExt returns the extension of
+// the file with the content s, if known
+func externalizedExt(s string) string {
+	switch {
+	case json.Valid([]byte(s)) && (strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[")):
+		return ".json"
+	case strings.HasPrefix(s, "#!"):
+		return ".sh"
+	default:
+		return ".txt"
+	}
+}
@@ -0,0 +1,77 @@
+package provider_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalizeWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terracognita-externalize")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		ctrl = gomock.NewController(t)
+		w    = mock.NewWriter(ctrl)
+		ew   = provider.NewExternalizeWriter(w, dir, 16)
+	)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		body := `{"widgets":[{"type":"metric"}]}`
+		w.EXPECT().Write("aws_cloudwatch_dashboard.main", map[string]interface{}{
+			"dashboard_name": "main",
+			"dashboard_body": `${file("${path.module}/files/aws_cloudwatch_dashboard.main.dashboard_body.json")}`,
+		}).Return(nil)
+
+		err := ew.Write("aws_cloudwatch_dashboard.main", map[string]interface{}{
+			"dashboard_name": "main",
+			"dashboard_body": body,
+		})
+		require.NoError(t, err)
+
+		b, err := ioutil.ReadFile(filepath.Join(dir, provider.ExternalizedDir, "aws_cloudwatch_dashboard.main.dashboard_body.json"))
+		require.NoError(t, err)
+		assert.Equal(t, body, string(b))
+	})
+
+	t.Run("SuccessBase64", func(t *testing.T) {
+		w.EXPECT().Write("aws_instance.front", map[string]interface{}{
+			"user_data_base64": `${filebase64("${path.module}/files/aws_instance.front.user_data_base64")}`,
+		}).Return(nil)
+
+		err := ew.Write("aws_instance.front", map[string]interface{}{
+			"user_data_base64": "IyEvYmluL2Jhc2gKZWNobyBoZWxsbwo=",
+		})
+		require.NoError(t, err)
+
+		b, err := ioutil.ReadFile(filepath.Join(dir, provider.ExternalizedDir, "aws_instance.front.user_data_base64"))
+		require.NoError(t, err)
+		assert.Equal(t, "#!/bin/bash\necho hello\n", string(b))
+	})
+
+	t.Run("SuccessWithoutLargeAttributes", func(t *testing.T) {
+		cfg := map[string]interface{}{
+			"ami":       "ami-123",
+			"subnet_id": "${aws_subnet." + strings.Repeat("a", 16) + ".id}",
+		}
+		w.EXPECT().Write("aws_instance.back", cfg).Return(nil)
+		w.EXPECT().Write("variable.password", map[string]interface{}{
+			"description": strings.Repeat("a", 32),
+		}).Return(nil)
+
+		require.NoError(t, ew.Write("aws_instance.back", cfg))
+		require.NoError(t, ew.Write("variable.password", map[string]interface{}{
+			"description": strings.Repeat("a", 32),
+		}))
+	})
+}
@@ -4,25 +4,557 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
 
 	kitlog "github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
 
 	"github.com/cycloidio/terracognita/errcode"
 	"github.com/cycloidio/terracognita/filter"
 	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/progress"
 	"github.com/cycloidio/terracognita/util"
 	"github.com/cycloidio/terracognita/writer"
 	"github.com/pkg/errors"
 )
 
+// ImportOptions are the optional configurations
+// of the Import
+type ImportOptions struct {
+	// Strict fails the Import when a resource could
+	// not be read instead of skipping it
+	Strict bool
+
+	// IgnoreErrors is the list of resource types and/or
+	// error classes (see ErrorClass) that are skipped
+	// even when Strict
+	IgnoreErrors []string
+
+	// MinimalHCL writes to the HCL only the attributes
+	// needed, see NewMinimalWriter
+	MinimalHCL bool
+
+	// RawUserData keeps the user data of the resources
+	// as base64 instead of decoding it to plain text
+	RawUserData bool
+
+	// ExternalizeSize, if set, writes the attributes of the
+	// HCL larger than it (in bytes) to files on the
+	// ExternalizeDir, the directory of the HCL, see
+	// NewExternalizeWriter
+	ExternalizeSize int
+	ExternalizeDir  string
+
+	// ValidateHCL validates the HCL with the schema of the
+	// resources before writing it, the invalid configurations
+	// are warned or fail the Import if Strict, see NewValidateWriter
+	ValidateHCL bool
+
+	// Stack returns the stack of the resource with the key
+	// when the HCL is split in stacks, the resources are
+	// only referenced by the ones on the same stack
+	Stack func(key string) string
+
+	// SkipManaged skips the resources that are managed by
+	// other IaC (ex: CloudFormation) detected by their tags
+	// and reports them at the end of the Import
+	SkipManaged bool
+
+	// Progress is notified as the resources are imported,
+	// if not set a progress.NewCounter of the out is used
+	Progress progress.Progress
+
+	// HCLMiddlewares and StateMiddlewares wrap the hcl and
+	// tfstate writers, the resources are written to them
+	// first (ex: to transform, audit or filter them). If the
+	// Write of a resource to the hcl returns an
+	// errcode.ErrWriterExcludedKey the resource is skipped
+	HCLMiddlewares   []writer.Middleware
+	StateMiddlewares []writer.Middleware
+
+	// ExcludeAttributes are the patterns of the attributes
+	// removed from the HCL, see NewExcludeWriter
+	ExcludeAttributes []string
+
+	// Lifecycles are the lifecycle blocks written to the
+	// HCL of the resources of each type, see NewLifecycleWriter
+	Lifecycles map[string]Lifecycle
+
+	// NamePrefix is the prefix of the names of all the
+	// resources on the HCL and TFState (ex: imported_)
+	NamePrefix string
+
+	// Names are the names on the HCL and TFState of the
+	// resources by 'TYPE.ID' (ex: aws_instance.i-123), the
+	// rest are named by the tags or the ID of them
+	Names map[string]string
+
+	// Name returns the name on the HCL and TFState of the
+	// resources of the type t with the id that are not on
+	// the Names, instead of the tags or the ID of them
+	Name func(t, id string) string
+
+	// Annotate writes a comment before the HCL of each resource
+	// with the provider, region and ID it was imported from and
+	// when, see hcl.Writer.Write
+	Annotate bool
+
+	// Cleanup reports the resources imported that look
+	// unused, as candidates to be cleaned up, if the
+	// Provider is a Janitor
+	Cleanup bool
+
+	// ConsoleURLs writes a comment before the HCL of each
+	// resource with the URL of it on the console of the
+	// cloud, if the Provider is a Consoler
+	ConsoleURLs bool
+
+	// Summary, if set, is filled with the Summary of the
+	// Import, which is also written to the out at the end
+	Summary *Summary
+
+	// ResourceTimeout is the maximum time to read each
+	// resource, the ones not read on time are failed with
+	// an errcode.ErrProviderResourceTimeout (ErrorClassTimeout).
+	// The read of the Terraform provider can not be canceled
+	// so it's left on the background. If not set there
+	// is no timeout
+	ResourceTimeout time.Duration
+
+	// DependenciesDepth, if set, imports the resources referenced
+	// by the imported ones (see Referencer) even if those are not
+	// on the filter (ex: the aws_security_group and aws_vpc of an
+	// aws_instance), up to that depth of references from the ones
+	// on the filter. The references on the HCL are replaced when
+	// it's Sync so the resources can reference the ones after them
+	DependenciesDepth int
+
+	// DependentsOf, if set, is the resource with the format
+	// 'TYPE:ID' (ex: aws_vpc:vpc-123) which dependents are
+	// the only resources imported, the ones referencing it
+	// or other of its dependents, and itself
+	DependentsOf string
+
+	// RegistryModules writes the resources grouped by the
+	// provider, if it's a Modularizer, as calls to modules
+	// of the Terraform Registry, see NewModuleWriter
+	RegistryModules bool
+
+	// Probe detects the empty scopes of the providers
+	// that are Probers before the import, and the empty
+	// providers are skipped, see probe
+	Probe bool
+
+	// Resolve, if set, resolves the raw IDs of the attributes
+	// of the resources (ex: the AMIs) with the resolvers
+	// registered for the provider (see RegisterResolver) with
+	// the mode ResolveComment or ResolveData
+	Resolve string
+
+	// MaxPerType, if set, is the maximum number of resources
+	// of each type imported, once reached the rest are skipped
+	// and not read. The resources are imported sorted by the ID,
+	// so the ones imported are always the same, or shuffled with
+	// the Sample
+	MaxPerType int
+
+	// Sample, if set with the MaxPerType, shuffles the resources
+	// of each type before importing them so the ones imported
+	// are a random sample of them
+	Sample *rand.Rand
+
+	// CircuitBreaker, if set, is the number of consecutive errors
+	// of permissions or of the API (errcode.ClassPermission and
+	// errcode.ClassUnavailable) on the resources of a service (ex:
+	// elasticache) after which the rest of the resources of it are
+	// failed without being read, and the service is reported once
+	// at the end. Those errors when listing the types of the
+	// service are also counted and skip the type, unless Strict
+	CircuitBreaker int
+}
+
+// readBuffer is the number of resources read and not
+// yet written, as the resources are written meanwhile
+// the next ones are read
+const readBuffer = 100
+
+// sample sorts the resources to import with the MaxPerType,
+// by the ID or shuffled with the Sample if set
+func (o ImportOptions) sample(resources []Resource) {
+	if o.MaxPerType <= 0 {
+		return
+	}
+
+	if o.Sample != nil {
+		o.Sample.Shuffle(len(resources), func(i, j int) {
+			resources[i], resources[j] = resources[j], resources[i]
+		})
+		return
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].ID() < resources[j].ID()
+	})
+}
+
+// maxReached checks if the MaxPerType
+// of the ts were already imported
+func (o ImportOptions) maxReached(ts *TypeSummary) bool {
+	return o.MaxPerType > 0 && ts.Imported >= o.MaxPerType
+}
+
+// userDataDecoder is implemented by the
+// Resources which can decode the user data
+type userDataDecoder interface {
+	DecodeUserData() error
+}
+
+// namePrefixer is implemented by the Resources
+// which names can be prefixed
+type namePrefixer interface {
+	SetNamePrefix(p string)
+}
+
+// stateSkipper is implemented by the Resources which
+// state can be skipped on the Read, when only the HCL
+// is written, as building it is expensive
+type stateSkipper interface {
+	SkipState()
+}
+
+// namer is implemented by the Resources
+// which names can be set
+type namer interface {
+	SetName(n string)
+}
+
+// ignoreError checks if the err reading the
+// resource of type t has to be skipped
+func (o ImportOptions) ignoreError(t string, err error) bool {
+	cause := errors.Cause(err)
+
+	// Those are not read errors, are the resources
+	// that have to be skipped
+	if cause == errcode.ErrProviderResourceDoNotMatchTag || cause == errcode.ErrProviderResourceAutogenerated {
+		return true
+	}
+
+	if !o.Strict {
+		return true
+	}
+
+	class := ErrorClass(err)
+	for _, i := range o.IgnoreErrors {
+		if i == t || i == class {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasType checks if the t is on the types
+func hasType(types []string, t string) bool {
+	for _, tt := range types {
+		if tt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// AttributeGetter returns a function that returns
+// the value of the attributes of the r, used to
+// match the filter.Rules
+func AttributeGetter(r Resource) func(string) string {
+	return func(k string) string {
+		if k == "id" {
+			return r.ID()
+		}
+		if r.Data() == nil {
+			return ""
+		}
+		v, ok := r.Data().GetOk(k)
+		if !ok {
+			return ""
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+// withResourceTimeout calls the fn with the ctx limited by the
+// ResourceTimeout, if set, and returns an errcode.ErrProviderResourceTimeout
+// if it's not done on time, or the error of the ctx if it's done before.
+// The fn is left running on the background as not all of them can be
+// canceled (ex: the reads of Terraform)
+func (o ImportOptions) withResourceTimeout(ctx context.Context, fn func(context.Context) error) error {
+	if o.ResourceTimeout <= 0 && ctx.Done() == nil {
+		return fn(ctx)
+	}
+
+	rctx, cancel := ctx, func() {}
+	if o.ResourceTimeout > 0 {
+		rctx, cancel = context.WithTimeout(ctx, o.ResourceTimeout)
+	}
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() { errc <- fn(rctx) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-rctx.Done():
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return errors.Wrapf(errcode.ErrProviderResourceTimeout, "after %s", o.ResourceTimeout)
+	}
+}
+
+// readResource is a resource read by the
+// producer of the Import to be written
+type readResource struct {
+	// r is the resource read and listed the one
+	// listed by the Provider, which is not the r
+	// if it was imported with the ImportState
+	// of the listed one
+	r, listed Resource
+	id        string
+	logger    kitlog.Logger
+}
+
+// readResources reads the resources of the type t, returned by the p,
+// and sends them to the reads as they are read, so they can be written
+// meanwhile the next ones are read. The resources are released from
+// the slice once read so only the ones not yet written are on memory.
+// Once the stop is closed the rest are not read and skipped, and once
+// the breaker of the service of t is open the rest are failed. If not
+// state the state of the resources is not built, as only the HCL is
+// written. The summary of the resources not targeted and failed is
+// added to the ts
+func readResources(ctx context.Context, p Provider, t string, f *filter.Filter, opt ImportOptions, resources []Resource, state bool, reads chan<- readResource, stop <-chan struct{}, br *breakers, pg progress.Progress, ts *TypeSummary, logger kitlog.Logger) error {
+	resourceLen := len(resources)
+	key := breakerKey(p, t)
+	for i, re := range resources {
+		resources[i] = nil
+
+		select {
+		case <-stop:
+			// The MaxPerType were imported
+			ts.Skipped += resourceLen - i
+			return nil
+		default:
+		}
+
+		if br.isOpen(key) {
+			ts.Failed += resourceLen - i
+			return nil
+		}
+
+		// The ctx is also canceled by the
+		// writer if it fails, which has
+		// the error to return
+		if err := ctx.Err(); err != nil {
+			if err == context.DeadlineExceeded {
+				return errors.Wrapf(err, "while reading the resources %q", t)
+			}
+			return nil
+		}
+
+		id := re.ID()
+		logger := kitlog.With(logger, "id", id, "total", resourceLen, "current", i+1)
+		pg.Increment()
+
+		if !f.IsTargeted(t, id) {
+			logger.Log("msg", "not targeted")
+			ts.Skipped++
+			continue
+		}
+
+		if f.IsIgnored(t, id) {
+			logger.Log("msg", "ignored")
+			ts.Skipped++
+			continue
+		}
+
+		logger.Log("msg", "reading from TF")
+		var res []Resource
+		err := opt.withResourceTimeout(ctx, func(context.Context) (err error) {
+			res, err = re.ImportState()
+			return err
+		})
+		if err != nil {
+			if errors.Cause(err) == errcode.ErrProviderResourceTimeout {
+				err = errors.Wrapf(err, "while importing the state of resource %q with id %q", t, id)
+				if !opt.ignoreError(t, err) {
+					return err
+				}
+				level.Warn(logger).Log("error", err, "error-class", ErrorClass(err))
+				ts.Failed++
+				continue
+			}
+			return err
+		}
+		ts.Discovered += len(res)
+
+		if ar, ok := p.(AttributeReader); ok {
+			if as, ok := re.(attributeSeeder); ok {
+				var attrs map[string]string
+				err := opt.withResourceTimeout(ctx, func(ctx context.Context) (err error) {
+					attrs, err = ar.ReadAttributes(ctx, t, id)
+					return err
+				})
+				if err != nil {
+					err = errors.Wrapf(err, "error while reading the attributes of resource %q with id %q", t, id)
+					if !opt.ignoreError(t, err) {
+						return err
+					}
+
+					level.Warn(logger).Log("error", err, "error-class", ErrorClass(err))
+
+					if br.fail(key, err) {
+						level.Warn(logger).Log("msg", "circuit breaker open, the rest of the resources of the service are failed", "service", key)
+					}
+
+					ts.Failed++
+					continue
+				}
+				as.SeedAttributes(attrs)
+			}
+		}
+
+		// In case there is more than one State to import
+		// we create a new slice with those elements and iterate
+		// over it
+		for _, r := range append([]Resource{re}, res...) {
+			if sk, ok := r.(stateSkipper); ok && !state {
+				sk.SkipState()
+			}
+
+			err = opt.withResourceTimeout(ctx, func(context.Context) error {
+				return util.RetryDefault(func() error { return r.Read(f) })
+			})
+			if err != nil {
+				cause := errors.Cause(err)
+
+				// By default errors are ignored. If a resource is invalid we assume it can be skipped, it can be related to inconsistencies in deployed resources.
+				// So instead of failing and stopping execution we ignore them and continue (we log them if -v is specified)
+				// unless it's Strict
+				if !opt.ignoreError(t, err) {
+					return errors.Wrapf(err, "could not read resource %q with id %q (error class %q)", t, id, ErrorClass(err))
+				}
+
+				level.Warn(logger).Log("error", cause, "error-class", ErrorClass(err))
+
+				if br.fail(key, err) {
+					level.Warn(logger).Log("msg", "circuit breaker open, the rest of the resources of the service are failed", "service", key)
+				}
+
+				// The ones not matching the tags or autogenerated
+				// are skipped, not failed to be read
+				if cause == errcode.ErrProviderResourceDoNotMatchTag || cause == errcode.ErrProviderResourceAutogenerated {
+					ts.Skipped++
+				} else {
+					ts.Failed++
+				}
+
+				continue
+			}
+			br.succeed(key)
+
+			select {
+			case reads <- readResource{r: r, listed: re, id: id, logger: logger}:
+			case <-ctx.Done():
+				// Canceled by the writer
+				// which has the error
+				return nil
+			case <-stop:
+				ts.Skipped += resourceLen - i
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeResource writes the r of the type t to the hcl and tfstate, if
+// those are not nil, and returns true if the r was excluded by the hcl
+func writeResource(r Resource, t string, hcl, tfstate writer.Writer, refs *referenceWriter, opt ImportOptions, logger kitlog.Logger) (bool, error) {
+	if hcl != nil {
+		logger.Log("msg", "calculating HCL")
+		err := r.HCL(hcl)
+		if errors.Cause(err) == errcode.ErrWriterExcludedKey {
+			logger.Log("msg", "excluded by the writer", "error", err)
+			return true, nil
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "error while calculating the Config of resource %q", t)
+		}
+
+		if opt.Annotate || opt.ConsoleURLs {
+			if key, c, ok := annotation(r, time.Now(), opt.Annotate, opt.ConsoleURLs); ok {
+				err = hcl.Write(key, c)
+				if err != nil {
+					return false, errors.Wrapf(err, "error while writing the comment of resource %q", t)
+				}
+			}
+		}
+
+		if refs != nil {
+			refs.add(r)
+		}
+	}
+
+	if tfstate != nil {
+		logger.Log("msg", "calculating TFState")
+		err := r.State(tfstate)
+		if err != nil {
+			return false, errors.Wrapf(err, "error while calculating the satate of resource %q", t)
+		}
+	}
+
+	return false, nil
+}
+
 // Import imports from the Provider p all the resources filtered by f and writes
 // the result to the hcl or tfstate if those are not nil
-func Import(ctx context.Context, p Provider, hcl, tfstate writer.Writer, f *filter.Filter, out io.Writer) error {
+func Import(ctx context.Context, p Provider, hcl, tfstate writer.Writer, f *filter.Filter, opt ImportOptions, out io.Writer) error {
+	return ImportProviders(ctx, []Provider{p}, hcl, tfstate, f, opt, out)
+}
+
+// ImportProviders imports from all the ps, like Import, to the same hcl and
+// tfstate. The ps have to be of the same type (ex: multiple regions) and
+// the ones that are an Aliaser are written to the hcl as aliased providers
+func ImportProviders(ctx context.Context, ps []Provider, hcl, tfstate writer.Writer, f *filter.Filter, opt ImportOptions, out io.Writer) error {
 	logger := log.Get()
 	logger = kitlog.With(logger, "func", "provider.Import")
 
+	start := time.Now()
+
+	summary := opt.Summary
+	if summary == nil {
+		summary = &Summary{}
+	}
+	*summary = Summary{}
+
+	// All the providers have the same types
+	// and schema so the first one is used to
+	// validate the filters and configurations
+	p := ps[0]
+
 	var types []string
 
+	if f.HasPatterns() {
+		if err := f.Expand(p.ResourceTypes()); err != nil {
+			return err
+		}
+	}
+
 	// Validate if the Include filter is right
 	if len(f.Include) != 0 {
 		for _, i := range f.Include {
@@ -31,6 +563,13 @@ func Import(ctx context.Context, p Provider, hcl, tfstate writer.Writer, f *filt
 			}
 		}
 		types = f.Include
+	} else if len(f.Targets) != 0 {
+		types = f.TargetTypes()
+		for _, t := range types {
+			if !p.HasResourceType(t) {
+				return errors.Wrapf(errcode.ErrProviderResourceNotSupported, "type %s on Targets filter", t)
+			}
+		}
 	} else {
 		types = p.ResourceTypes()
 	}
@@ -44,72 +583,484 @@ func Import(ctx context.Context, p Provider, hcl, tfstate writer.Writer, f *filt
 		}
 	}
 
+	// It's the closest to the hcl so the values
+	// written to the files are the final ones
+	if hcl != nil && opt.ExternalizeSize > 0 {
+		hcl = NewExternalizeWriter(hcl, opt.ExternalizeDir, opt.ExternalizeSize)
+	}
+
+	// It's the first one so it validates the
+	// configurations as those are written
+	if hcl != nil && opt.ValidateHCL {
+		hcl = NewValidateWriter(hcl, p, out, opt.Strict)
+	}
+
+	if hcl != nil && opt.MinimalHCL {
+		hcl = NewMinimalWriter(hcl, p)
+	}
+
+	// The types of the resources written as
+	// part of others by the Normalizer
+	var normalized []string
+
+	excludeAttributes := opt.ExcludeAttributes
+	if n, ok := p.(Normalizer); ok {
+		normalized = n.SkippedTypes()
+		excludeAttributes = append(append([]string{}, excludeAttributes...), n.InlineAttributes()...)
+	}
+
+	if hcl != nil && len(excludeAttributes) != 0 {
+		ew, err := NewExcludeWriter(hcl, p, excludeAttributes)
+		if err != nil {
+			return err
+		}
+		hcl = ew
+	}
+
+	if hcl != nil && len(opt.Lifecycles) != 0 {
+		hcl = NewLifecycleWriter(hcl, opt.Lifecycles)
+	}
+
+	// It gets the configurations with the references
+	// already replaced, so those IDs are not resolved
+	if hcl != nil && opt.Resolve != "" {
+		if opt.Resolve != ResolveComment && opt.Resolve != ResolveData {
+			return errors.Errorf("invalid Resolve mode %q", opt.Resolve)
+		}
+		if rw, ok := newResolveWriter(ctx, hcl, ps, opt.Resolve, logger); ok {
+			hcl = rw
+		}
+	}
+
+	// It gets the configurations with the references
+	// already replaced, so the ones to the resources grouped
+	// on the modules are replaced with the outputs of them
+	var modules bool
+	if m, ok := p.(Modularizer); hcl != nil && ok && opt.RegistryModules {
+		hcl = NewModuleWriter(hcl, m)
+		modules = true
+	}
+
+	var refs *referenceWriter
+	if hcl != nil {
+		if rw, ok := newReferenceWriter(hcl, p, opt.Stack); ok {
+			// The modules need all the references
+			// so those are replaced on the Sync
+			rw.deferred = opt.DependenciesDepth > 0 || opt.DependentsOf != "" || modules
+			refs = rw
+			hcl = rw
+		}
+	}
+
+	if hcl != nil {
+		hcl = writer.Chain(hcl, opt.HCLMiddlewares...)
+	}
+	if tfstate != nil {
+		tfstate = writer.Chain(tfstate, opt.StateMiddlewares...)
+	}
+
 	fmt.Fprintf(out, "Importing with filters: %s", f)
 	logger.Log("filters", f.String())
 
-	for _, t := range types {
-		logger = kitlog.With(logger, "resource", t)
+	if opt.Probe {
+		ps = probe(ctx, ps, out, logger)
+	}
+
+	pg := opt.Progress
+	if pg == nil {
+		pg = progress.NewCounter(out)
+	}
 
+	// The types skipped on the Import
+	// are not part of the progress
+	var ntypes int
+	for _, t := range types {
 		if f.IsExcluded(t) {
-			logger.Log("msg", "excluded")
 			continue
 		}
+		if nt, ok := TypeAlias(p, t); ok && hasType(types, nt) && !f.IsExcluded(nt) {
+			continue
+		}
+		ntypes++
+	}
+	pg.Init(ntypes * len(ps))
 
-		logger.Log("msg", "fetching the list of resources")
+	// The same resource can be found more than once,
+	// with multiple providers or overlapping filters
+	written := newRegistry()
 
-		resources, err := p.Resources(ctx, t, f)
-		if err != nil {
-			return errors.WithStack(err)
+	// skipped are the resources managed by other
+	// IaC with the reason, if SkipManaged
+	var skipped []string
+
+	// unused are the resources imported that look
+	// unused with the reason, if Cleanup
+	var unused []string
+
+	// foundDependents is true if the resource of the
+	// DependentsOf was found on any of the ps
+	var foundDependents bool
+
+	// br are shared by all the ps, each one
+	// has its own breakers of the services
+	br := newBreakers(opt.CircuitBreaker)
+
+	for _, p := range ps {
+		// The resources of each aliased provider
+		// are written with the alias of it
+		logger := logger
+		if alias := ProviderAlias(p); alias != "" {
+			logger = kitlog.With(logger, "provider", alias)
+			fmt.Fprintf(out, "\nImporting from %s\n", alias)
 		}
 
-		resourceLen := len(resources)
-		for i, re := range resources {
-			logger := kitlog.With(logger, "id", re.ID(), "total", resourceLen, "current", i+1)
-			fmt.Fprintf(out, "\rImporting %s [%d/%d]", t, i+1, resourceLen)
+		// deps has the references of the resources imported, the
+		// first pass imports the types and the next ones the
+		// resources referenced by the previous pass
+		var deps *dependencies
+		if opt.DependenciesDepth > 0 {
+			deps, _ = newDependencies(p)
+		}
 
-			logger.Log("msg", "reading from TF")
-			res, err := re.ImportState()
+		// dpts has the dependents of the resource of the
+		// DependentsOf, the resources are only imported if
+		// those reference it or other of its dependents
+		var dpts *dependents
+		if opt.DependentsOf != "" {
+			var err error
+			dpts, err = newDependents(p, opt.DependentsOf)
 			if err != nil {
 				return err
 			}
+		}
 
-			// In case there is more than one State to import
-			// we create a new slice with those elements and iterate
-			// over it
-			for _, r := range append([]Resource{re}, res...) {
-				err = util.RetryDefault(func() error { return r.Read(f) })
-				if err != nil {
-					cause := errors.Cause(err)
+		// write writes the rr, read from the type t, if it was
+		// not already and adds it to the ts and the dependencies
+		write := func(rr readResource, t string, ts *TypeSummary) error {
+			r, logger := rr.r, rr.logger
+
+			if opt.maxReached(ts) {
+				logger.Log("msg", "over the max per type")
+				ts.Skipped++
+				return nil
+			}
+
+			if !written.add(r, rr.listed, t, rr.id) {
+				logger.Log("msg", "already imported")
+				ts.Skipped++
+				return nil
+			}
+
+			if ud, ok := r.(userDataDecoder); ok && !opt.RawUserData {
+				if err := ud.DecodeUserData(); err != nil {
+					return errors.Wrapf(err, "error while decoding the user data of resource %q", t)
+				}
+			}
 
-					// Errors are ignored. If a resource is invalid we assume it can be skipped, it can be related to inconsistencies in deployed resources.
-					// So instead of failing and stopping execution we ignore them and continue (we log them if -v is specified)
+			if np, ok := r.(namePrefixer); ok && opt.NamePrefix != "" {
+				np.SetNamePrefix(opt.NamePrefix)
+			}
+			if nr, ok := r.(namer); ok && (len(opt.Names) != 0 || opt.Name != nil) {
+				if n, ok := opt.Names[fmt.Sprintf("%s.%s", r.Type(), r.ID())]; ok {
+					nr.SetName(n)
+				} else if opt.Name != nil {
+					nr.SetName(opt.Name(r.Type(), r.ID()))
+				}
+			}
+
+			excluded, err := writeResource(r, t, hcl, tfstate, refs, opt, logger)
+			if err != nil {
+				return err
+			}
+			if excluded {
+				ts.Skipped++
+				return nil
+			}
 
-					logger.Log("error", cause)
+			if deps != nil {
+				deps.add(r)
+			}
+			if dpts != nil {
+				dpts.add(r, rr.id)
+			}
 
+			if j, ok := p.(Janitor); ok && opt.Cleanup {
+				if reason := j.UnusedReason(r.Type(), r.ID(), AttributeGetter(r)); reason != "" {
+					logger.Log("msg", "looks unused", "reason", reason)
+					unused = append(unused, fmt.Sprintf("%s %s: %s", r.Type(), r.ID(), reason))
+				}
+			}
+
+			ts.Imported++
+			return nil
+		}
+
+		ptypes := types
+		for depth := 0; ; depth++ {
+			for _, t := range ptypes {
+				// Each type has its own context so the
+				// previous types are not on the logs
+				logger := kitlog.With(logger, "resource", t)
+
+				f := f
+				if depth > 0 {
+					f = deps.filter(t, f)
+				}
+
+				if f.IsExcluded(t) {
+					logger.Log("msg", "excluded")
 					continue
 				}
 
-				if hcl != nil {
-					logger.Log("msg", "calculating HCL")
-					err = r.HCL(hcl)
-					if err != nil {
-						return errors.Wrapf(err, "error while calculating the Config of resource %q", t)
+				if nt, ok := TypeAlias(p, t); ok {
+					// If the type replacing it is also imported
+					// it's skipped so they are not duplicated
+					if hasType(ptypes, nt) && !f.IsExcluded(nt) {
+						logger.Log("msg", "deprecated resource type imported by the one replacing it", "replaced-by", nt)
+						continue
 					}
+
+					logger.Log("msg", "deprecated resource type", "replaced-by", nt)
+					fmt.Fprintf(out, "\nWarning: %s is deprecated, it will be imported as %s\n", t, nt)
+				}
+
+				key := breakerKey(p, t)
+				if br.isOpen(key) {
+					logger.Log("msg", "circuit breaker of the service open", "service", key)
+					pg.Start(t, 0)
+					pg.Done()
+					continue
+				}
+
+				logger.Log("msg", "fetching the list of resources")
+
+				resources, err := p.Resources(ctx, t, f)
+				if err != nil {
+					// The type is skipped so the
+					// rest of the service are listed
+					if br.counts(err) && opt.ignoreError(t, err) {
+						level.Warn(logger).Log("msg", "could not list the resources", "error", err, "error-class", ErrorClass(err))
+						if br.fail(key, err) {
+							level.Warn(logger).Log("msg", "circuit breaker open, the rest of the types of the service are skipped", "service", key)
+						}
+						pg.Start(t, 0)
+						pg.Done()
+						continue
+					}
+					return errors.WithStack(err)
+				}
+
+				ts := summary.typeSummary(t)
+				ts.Discovered += len(resources)
+
+				opt.sample(resources)
+
+				// The resources are read by a producer and written
+				// as they are read, so only the ones on the buffer
+				// are kept on memory and not all of the type
+				rctx, cancel := context.WithCancel(ctx)
+				reads := make(chan readResource, readBuffer)
+				errc := make(chan error, 1)
+
+				// read is the summary of the producer, added to
+				// the ts once it's done so it's not shared
+				var read TypeSummary
+
+				// stop is closed once the MaxPerType are imported,
+				// or already if those were with other provider
+				stop := make(chan struct{})
+				stopped := opt.maxReached(ts)
+				if stopped {
+					logger.Log("msg", "max per type already imported")
+					close(stop)
 				}
 
-				if tfstate != nil {
-					logger.Log("msg", "calculating TFState")
-					err = r.State(tfstate)
+				resourceLen := len(resources)
+				pg.Start(t, resourceLen)
+				go func() {
+					defer close(reads)
+					errc <- readResources(rctx, p, t, f, opt, resources, tfstate != nil, reads, stop, br, pg, &read, logger)
+				}()
+
+				for rr := range reads {
+					// On error the rest are drained
+					// so the producer is not blocked
 					if err != nil {
-						return errors.Wrapf(err, "error while calculating the satate of resource %q", t)
+						continue
 					}
+
+					r, logger := rr.r, rr.logger
+
+					if depth > 0 && !deps.isWanted(r) {
+						logger.Log("msg", "not referenced by the resources imported")
+						ts.Skipped++
+						continue
+					}
+
+					if len(normalized) != 0 && hasType(normalized, r.Type()) {
+						logger.Log("msg", "written inline on other resource")
+						ts.Skipped++
+						continue
+					}
+
+					if !f.IsMatched(t, AttributeGetter(r)) {
+						logger.Log("msg", "not matched by the filter rules")
+						ts.Skipped++
+						continue
+					}
+
+					if f.NameRegex != nil {
+						get := AttributeGetter(r)
+						if !f.IsNameMatched(get("id"), get("name"), get(fmt.Sprintf("%s.Name", p.TagKey()))) {
+							logger.Log("msg", "not matched by the name regex")
+							ts.Skipped++
+							continue
+						}
+					}
+
+					// The ID was already checked before reading
+					// it but not the name, which is read now
+					if len(f.Ignore) != 0 {
+						get := AttributeGetter(r)
+						if f.IsIgnored(r.Type(), get("id"), get("name"), get(fmt.Sprintf("%s.Name", p.TagKey()))) {
+							logger.Log("msg", "ignored")
+							ts.Skipped++
+							continue
+						}
+					}
+
+					if opt.SkipManaged {
+						if reason := managedReason(r, p.TagKey()); reason != "" {
+							logger.Log("msg", "managed by other IaC", "reason", reason)
+							skipped = append(skipped, fmt.Sprintf("%s %s: %s", t, r.ID(), reason))
+							ts.Skipped++
+							continue
+						}
+					}
+
+					if depth == 0 && dpts != nil && !dpts.isDependent(r, rr.id) {
+						if dpts.hold(rr, t) {
+							logger.Log("msg", "held until the resources it references are imported")
+						} else {
+							logger.Log("msg", "not a dependent")
+							ts.Skipped++
+						}
+						continue
+					}
+
+					if err = write(rr, t, ts); err != nil {
+						cancel()
+						continue
+					}
+
+					if !stopped && opt.maxReached(ts) {
+						logger.Log("msg", "max per type imported, the rest are skipped", "max", opt.MaxPerType)
+						close(stop)
+						stopped = true
+					}
+				}
+				cancel()
+
+				rerr := <-errc
+				ts.Discovered += read.Discovered
+				ts.Skipped += read.Skipped
+				ts.Failed += read.Failed
+
+				if err != nil {
+					return err
 				}
+				if rerr != nil {
+					return rerr
+				}
+
+				pg.Done()
+				level.Info(logger).Log("msg", "importing done")
+			}
+
+			if depth == 0 && dpts != nil {
+				held, err := dpts.release(func(h heldResource) error {
+					return write(h.rr, h.t, summary.typeSummary(h.t))
+				})
+				if err != nil {
+					return err
+				}
+				for _, h := range held {
+					h.rr.logger.Log("msg", "not a dependent")
+					summary.typeSummary(h.t).Skipped++
+				}
+
+				foundDependents = foundDependents || dpts.found
+			}
+
+			if deps == nil || depth == opt.DependenciesDepth {
+				break
+			}
+
+			ptypes = deps.next(p.ResourceTypes())
+			if len(ptypes) == 0 {
+				break
+			}
+
+			fmt.Fprintf(out, "\nImporting the dependencies (depth %d): %s\n", depth+1, strings.Join(ptypes, ", "))
+			logger.Log("msg", "importing the dependencies", "depth", depth+1, "types", strings.Join(ptypes, ","))
+		}
+	}
+
+	if opt.DependentsOf != "" && !foundDependents {
+		return errors.Errorf("the resource %s to import the dependents of was not found", opt.DependentsOf)
+	}
+
+	if len(skipped) != 0 {
+		fmt.Fprintf(out, "\nSkipped %d resources managed by other IaC:\n", len(skipped))
+		for _, sk := range skipped {
+			fmt.Fprintf(out, "  %s\n", sk)
+		}
+	}
+
+	br.report(out)
+
+	if len(unused) != 0 {
+		fmt.Fprintf(out, "\nFound %d cleanup candidates, resources imported that look unused:\n", len(unused))
+		for _, u := range unused {
+			fmt.Fprintf(out, "  %s\n", u)
+		}
+	}
+
+	if hcl != nil {
+		aliases := make(map[string]struct{})
+		for _, p := range ps {
+			alias := ProviderAlias(p)
+			if alias == "" {
+				continue
 			}
+
+			err := hcl.Write(fmt.Sprintf("provider.%s", alias), map[string]interface{}{"region": p.Region()})
+			if err != nil {
+				return errors.Wrapf(err, "error while writing the provider %q", alias)
+			}
+			aliases[alias] = struct{}{}
 		}
-		if resourceLen > 0 {
-			fmt.Fprintf(out, "\rImporting %s [%d/%d] Done!\n", t, resourceLen, resourceLen)
+
+		// The data sources of the shared resources reference
+		// the provider with the shared alias, so it has to be
+		// declared (once) for the configuration to be valid
+		for _, p := range ps {
+			sa, ok := p.(SharedAliaser)
+			if !ok || sa.SharedAlias() == "" {
+				continue
+			}
+
+			alias := fmt.Sprintf("%s.%s", p.String(), sa.SharedAlias())
+			if _, ok := aliases[alias]; ok {
+				continue
+			}
+
+			err := hcl.Write(fmt.Sprintf("provider.%s", alias), map[string]interface{}{"region": p.Region()})
+			if err != nil {
+				return errors.Wrapf(err, "error while writing the provider %q", alias)
+			}
+			aliases[alias] = struct{}{}
 		}
-		logger.Log("msg", "importing done")
 	}
 
 	if hcl != nil {
@@ -122,7 +1073,7 @@ func Import(ctx context.Context, p Provider, hcl, tfstate writer.Writer, f *filt
 		}
 
 		fmt.Fprintf(out, "\rWriting HCL Done!\n")
-		logger.Log("msg", "writing the HCL done")
+		level.Info(logger).Log("msg", "writing the HCL done")
 	}
 
 	if tfstate != nil {
@@ -135,8 +1086,11 @@ func Import(ctx context.Context, p Provider, hcl, tfstate writer.Writer, f *filt
 		}
 
 		fmt.Fprintf(out, "\rWriting TFState Done!\n")
-		logger.Log("msg", "writing the TFState done")
+		level.Info(logger).Log("msg", "writing the TFState done")
 	}
 
+	summary.Elapsed = time.Since(start)
+	summary.Write(out)
+
 	return nil
 }
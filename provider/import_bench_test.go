@@ -0,0 +1,166 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/hcl"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/writer"
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/configs/configschema"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/states"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// benchTypes are the resource types of the benchmarks,
+// the resources are spread on all of them
+var benchTypes = []string{
+	"aws_instance", "aws_security_group", "aws_subnet", "aws_iam_user", "aws_iam_role",
+	"aws_s3_bucket", "aws_db_instance", "aws_lambda_function", "aws_route53_record", "aws_ebs_volume",
+}
+
+// importBudget is the maximum number of allocations per resource of
+// the Import, without the writers, so the regressions of the
+// pipeline (ex: a copy of each resource) are caught by the tests
+const importBudget = 50
+
+// benchResource is a provider.Resource that is already read, so
+// the benchmarks measure the Import and the writers and not the
+// mocks, as a gomock.Controller with 100k resources is too slow
+type benchResource struct {
+	id string
+	tp string
+}
+
+func (r *benchResource) ID() string                                { return r.id }
+func (r *benchResource) Type() string                              { return r.tp }
+func (r *benchResource) TFResource() *schema.Resource              { return nil }
+func (r *benchResource) Data() *schema.ResourceData                { return nil }
+func (r *benchResource) Provider() provider.Provider               { return nil }
+func (r *benchResource) ImportState() ([]provider.Resource, error) { return nil, nil }
+func (r *benchResource) Read(f *filter.Filter) error               { return nil }
+func (r *benchResource) InstanceInfo() *terraform.InstanceInfo     { return nil }
+func (r *benchResource) CoreConfigSchema() *configschema.Block     { return nil }
+func (r *benchResource) ResourceInstanceObject() *states.ResourceInstanceObject {
+	return nil
+}
+
+func (r *benchResource) State(w writer.Writer) error {
+	return w.Write(fmt.Sprintf("%s.%s", r.tp, r.id), r)
+}
+
+func (r *benchResource) HCL(w writer.Writer) error {
+	return w.Write(fmt.Sprintf("%s.%s", r.tp, r.id), map[string]interface{}{
+		"name": r.id,
+		"tags": map[string]interface{}{"Name": r.id, "env": "prod"},
+	})
+}
+
+// benchWriter is a writer.Writer that only keeps the keys written
+type benchWriter map[string]struct{}
+
+func (w benchWriter) Write(key string, value interface{}) error {
+	w[key] = struct{}{}
+	return nil
+}
+
+func (w benchWriter) Has(key string) (bool, error) {
+	_, ok := w[key]
+	return ok, nil
+}
+
+func (w benchWriter) Sync() error { return nil }
+
+// benchProvider returns the mock of the provider.Provider with n
+// resources spread on the benchTypes, a new list on each call
+// as the Import releases the resources once read
+func benchProvider(ctrl *gomock.Controller, n int) provider.Provider {
+	p := mock.NewProvider(ctrl)
+
+	p.EXPECT().ResourceTypes().Return(benchTypes).AnyTimes()
+	p.EXPECT().String().Return("aws").AnyTimes()
+	p.EXPECT().Resources(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, t string, _ *filter.Filter) ([]provider.Resource, error) {
+		per := n / len(benchTypes)
+		res := make([]provider.Resource, 0, per)
+		for i := 0; i < per; i++ {
+			res = append(res, &benchResource{id: fmt.Sprintf("r%d", i), tp: t})
+		}
+		return res, nil
+	}).AnyTimes()
+
+	return p
+}
+
+// BenchmarkImport measures each stage of the Import of 10k and
+// 100k resources: the read pipeline without writers, and with
+// the HCL writer, the TFState writer and both of them
+//
+//	go test ./provider -run none -bench Import -benchmem
+func BenchmarkImport(b *testing.B) {
+	stages := []struct {
+		name      string
+		hcl, tfst bool
+	}{
+		{name: "read"},
+		{name: "hcl", hcl: true},
+		{name: "tfstate", tfst: true},
+		{name: "all", hcl: true, tfst: true},
+	}
+
+	for _, n := range []int{10000, 100000} {
+		for _, s := range stages {
+			b.Run(fmt.Sprintf("%dk/%s", n/1000, s.name), func(b *testing.B) {
+				ctrl := gomock.NewController(b)
+				defer ctrl.Finish()
+
+				p := benchProvider(ctrl, n)
+
+				b.ReportAllocs()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					var hw, sw writer.Writer
+					if s.hcl {
+						hw = hcl.NewWriter(ioutil.Discard)
+					}
+					if s.tfst {
+						sw = make(benchWriter, n)
+					}
+
+					err := provider.Import(context.Background(), p, hw, sw, &filter.Filter{}, provider.ImportOptions{}, ioutil.Discard)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestImportBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the budget of the Import is not checked with -short")
+	}
+
+	const n = 10000
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := benchProvider(ctrl, n)
+
+	allocs := testing.AllocsPerRun(1, func() {
+		err := provider.Import(context.Background(), p, nil, nil, &filter.Filter{}, provider.ImportOptions{}, ioutil.Discard)
+		require.NoError(t, err)
+	})
+
+	assert.LessOrEqual(t, allocs/n, float64(importBudget), "the Import allocates %.0f times per resource, the budget is %d", allocs/n, importBudget)
+}
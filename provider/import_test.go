@@ -1,15 +1,21 @@
 package provider_test
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io/ioutil"
+	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/cycloidio/terracognita/errcode"
 	"github.com/cycloidio/terracognita/filter"
 	"github.com/cycloidio/terracognita/mock"
 	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/writer"
 	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -67,7 +73,7 @@ func TestImport(t *testing.T) {
 		hw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Sync().Return(nil)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
 		require.NoError(t, err)
 	})
 	t.Run("SuccessWithFilterInclude", func(t *testing.T) {
@@ -109,7 +115,95 @@ func TestImport(t *testing.T) {
 		hw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Sync().Return(nil)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithFilterTargets", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                = mock.NewProvider(ctrl)
+			hw               = mock.NewWriter(ctrl)
+			sw               = mock.NewWriter(ctrl)
+			instanceResoure1 = mock.NewResource(ctrl)
+			instanceResoure2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{
+				Targets: []string{"aws_instance.2"},
+			}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().HasResourceType("aws_instance").Return(true)
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResoure1, instanceResoure2}, nil)
+
+		instanceResoure1.EXPECT().ID().Return("1")
+		instanceResoure2.EXPECT().ID().Return("2")
+
+		instanceResoure2.EXPECT().ImportState().Return(nil, nil)
+
+		instanceResoure2.EXPECT().Read(f).Return(nil)
+
+		instanceResoure2.EXPECT().HCL(hw).Return(nil)
+
+		instanceResoure2.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithFilterIgnore", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                = mock.NewProvider(ctrl)
+			hw               = mock.NewWriter(ctrl)
+			sw               = mock.NewWriter(ctrl)
+			instanceResoure1 = mock.NewResource(ctrl)
+			instanceResoure2 = mock.NewResource(ctrl)
+			instanceResoure3 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{
+				Include: []string{"aws_instance"},
+				Ignore:  []string{"aws_instance.1", "aws_instance.web-*"},
+			}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().HasResourceType("aws_instance").Return(true)
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResoure1, instanceResoure2, instanceResoure3}, nil)
+		p.EXPECT().TagKey().Return("tags").AnyTimes()
+
+		instanceResoure1.EXPECT().ID().Return("1")
+		instanceResoure2.EXPECT().ID().Return("2").AnyTimes()
+		instanceResoure3.EXPECT().ID().Return("3").AnyTimes()
+
+		instanceResoure2.EXPECT().ImportState().Return(nil, nil)
+		instanceResoure3.EXPECT().ImportState().Return(nil, nil)
+
+		instanceResoure2.EXPECT().Read(f).Return(nil)
+		instanceResoure3.EXPECT().Read(f).Return(nil)
+
+		instanceResoure2.EXPECT().Type().Return("aws_instance").AnyTimes()
+		instanceResoure3.EXPECT().Type().Return("aws_instance").AnyTimes()
+
+		instanceResoure2.EXPECT().Data().Return(schema.TestResourceDataRaw(t, map[string]*schema.Schema{"name": {Type: schema.TypeString, Optional: true}}, map[string]interface{}{"name": "web-1"})).AnyTimes()
+		instanceResoure3.EXPECT().Data().Return(nil).AnyTimes()
+
+		instanceResoure3.EXPECT().HCL(hw).Return(nil)
+
+		instanceResoure3.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
 		require.NoError(t, err)
 	})
 	t.Run("SuccessWithExclude", func(t *testing.T) {
@@ -153,7 +247,7 @@ func TestImport(t *testing.T) {
 		hw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Sync().Return(nil)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
 		require.NoError(t, err)
 	})
 	t.Run("SuccessWithErrProviderResourceDoNotMatchTag", func(t *testing.T) {
@@ -195,7 +289,7 @@ func TestImport(t *testing.T) {
 		hw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Sync().Return(nil)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
 		require.NoError(t, err)
 	})
 	t.Run("SuccessWithNoHCLWriter", func(t *testing.T) {
@@ -233,7 +327,7 @@ func TestImport(t *testing.T) {
 
 		sw.EXPECT().Sync().Return(nil)
 
-		err := provider.Import(ctx, p, nil, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, nil, sw, f, provider.ImportOptions{}, ioutil.Discard)
 		require.NoError(t, err)
 	})
 	t.Run("SuccessWithNoTFStateWriter", func(t *testing.T) {
@@ -271,7 +365,7 @@ func TestImport(t *testing.T) {
 
 		hw.EXPECT().Sync().Return(nil)
 
-		err := provider.Import(ctx, p, hw, nil, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, nil, f, provider.ImportOptions{}, ioutil.Discard)
 		require.NoError(t, err)
 	})
 	t.Run("ErrorWithErrProviderResourceNotRead", func(t *testing.T) {
@@ -313,7 +407,7 @@ func TestImport(t *testing.T) {
 		hw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Sync().Return(nil)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
 		require.NoError(t, err)
 	})
 	t.Run("ErrorWithErrProviderResourceAutogenerated", func(t *testing.T) {
@@ -355,7 +449,7 @@ func TestImport(t *testing.T) {
 		hw.EXPECT().Sync().Return(nil)
 		sw.EXPECT().Sync().Return(nil)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
 		require.NoError(t, err)
 	})
 	t.Run("ErrorWithIncorrectFilterInclude", func(t *testing.T) {
@@ -377,7 +471,7 @@ func TestImport(t *testing.T) {
 		p.EXPECT().HasResourceType("aws_instance").Return(true)
 		p.EXPECT().HasResourceType("aws_potato").Return(false)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
 		assert.Equal(t, errcode.ErrProviderResourceNotSupported.Error(), errors.Cause(err).Error())
 	})
 
@@ -401,7 +495,1355 @@ func TestImport(t *testing.T) {
 		p.EXPECT().HasResourceType("aws_instance").Return(true)
 		p.EXPECT().HasResourceType("aws_potato").Return(false)
 
-		err := provider.Import(ctx, p, hw, sw, f, ioutil.Discard)
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
 		assert.Equal(t, errcode.ErrProviderResourceNotSupported.Error(), errors.Cause(err).Error())
 	})
-}
+	t.Run("ErrorWithStrict", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_iam_user"})
+
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
+
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser2.EXPECT().ID().Return("2")
+
+		iamUser1.EXPECT().ImportState().Return(nil, nil)
+		iamUser2.EXPECT().ImportState().Return(nil, nil)
+
+		iamUser1.EXPECT().Read(f).Return(errcode.ErrProviderResourceDoNotMatchTag)
+		iamUser2.EXPECT().Read(f).Return(errcode.ErrProviderResourceNotRead)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Strict: true}, ioutil.Discard)
+		assert.Equal(t, errcode.ErrProviderResourceNotRead, errors.Cause(err))
+	})
+	t.Run("SuccessWithStrictAndIgnoreErrors", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p        = mock.NewProvider(ctrl)
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			instance = mock.NewResource(ctrl)
+			iamUser1 = mock.NewResource(ctrl)
+			iamUser2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_iam_user"})
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instance}, nil)
+		p.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{iamUser1, iamUser2}, nil)
+
+		instance.EXPECT().ID().Return("i-1")
+		iamUser1.EXPECT().ID().Return("1")
+		iamUser2.EXPECT().ID().Return("2")
+
+		instance.EXPECT().ImportState().Return(nil, nil)
+		iamUser1.EXPECT().ImportState().Return(nil, nil)
+		iamUser2.EXPECT().ImportState().Return(nil, nil)
+
+		instance.EXPECT().Read(f).Return(fmt.Errorf("UnauthorizedOperation: You are not authorized to perform this operation"))
+		iamUser1.EXPECT().Read(f).Return(fmt.Errorf("throttled"))
+		iamUser2.EXPECT().Read(f).Return(nil)
+
+		iamUser2.EXPECT().HCL(hw).Return(nil)
+
+		iamUser2.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{
+			Strict:       true,
+			IgnoreErrors: []string{provider.ErrorClassAccessDenied, "aws_iam_user"},
+		}, ioutil.Discard)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithAliasedProviders", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p1        = &aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "us_east_1"}
+			p2        = &aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "eu_west_1"}
+			hw        = mock.NewWriter(ctrl)
+			sw        = mock.NewWriter(ctrl)
+			instance1 = mock.NewResource(ctrl)
+			instance2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p1.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+		p1.EXPECT().String().Return("aws").AnyTimes()
+		p2.EXPECT().String().Return("aws").AnyTimes()
+		p1.EXPECT().Region().Return("us-east-1")
+		p2.EXPECT().Region().Return("eu-west-1")
+
+		p1.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instance1}, nil)
+		p2.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instance2}, nil)
+
+		instance1.EXPECT().ID().Return("i-1")
+		instance2.EXPECT().ID().Return("i-2")
+
+		instance1.EXPECT().ImportState().Return(nil, nil)
+		instance2.EXPECT().ImportState().Return(nil, nil)
+
+		instance1.EXPECT().Read(f).Return(nil)
+		instance2.EXPECT().Read(f).Return(nil)
+
+		instance1.EXPECT().HCL(hw).Return(nil)
+		instance2.EXPECT().HCL(hw).Return(nil)
+
+		instance1.EXPECT().State(sw).Return(nil)
+		instance2.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Write("provider.aws.us_east_1", map[string]interface{}{"region": "us-east-1"}).Return(nil)
+		hw.EXPECT().Write("provider.aws.eu_west_1", map[string]interface{}{"region": "eu-west-1"}).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.ImportProviders(ctx, []provider.Provider{p1, p2}, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithSharedAlias", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p1        = &sharedAliasedProvider{aliasedProvider: aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "us_east_1"}, shared: "shared"}
+			p2        = &sharedAliasedProvider{aliasedProvider: aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "eu_west_1"}, shared: "shared"}
+			hw        = mock.NewWriter(ctrl)
+			sw        = mock.NewWriter(ctrl)
+			instance1 = mock.NewResource(ctrl)
+			instance2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p1.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+		p1.EXPECT().String().Return("aws").AnyTimes()
+		p2.EXPECT().String().Return("aws").AnyTimes()
+		p1.EXPECT().Region().Return("us-east-1").Times(2)
+		p2.EXPECT().Region().Return("eu-west-1")
+
+		p1.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instance1}, nil)
+		p2.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instance2}, nil)
+
+		instance1.EXPECT().ID().Return("i-1")
+		instance2.EXPECT().ID().Return("i-2")
+
+		instance1.EXPECT().ImportState().Return(nil, nil)
+		instance2.EXPECT().ImportState().Return(nil, nil)
+
+		instance1.EXPECT().Read(f).Return(nil)
+		instance2.EXPECT().Read(f).Return(nil)
+
+		instance1.EXPECT().HCL(hw).Return(nil)
+		instance2.EXPECT().HCL(hw).Return(nil)
+
+		instance1.EXPECT().State(sw).Return(nil)
+		instance2.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Write("provider.aws.us_east_1", map[string]interface{}{"region": "us-east-1"}).Return(nil)
+		hw.EXPECT().Write("provider.aws.eu_west_1", map[string]interface{}{"region": "eu-west-1"}).Return(nil)
+		hw.EXPECT().Write("provider.aws.shared", map[string]interface{}{"region": "us-east-1"}).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.ImportProviders(ctx, []provider.Provider{p1, p2}, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithProbe", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p1        = &probedProvider{aliasedProvider: aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "us_east_1"}, empty: true}
+			p2        = &probedProvider{aliasedProvider: aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "us_west_1"}, empty: true}
+			p3        = &probedProvider{aliasedProvider: aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "eu_west_1"}}
+			hw        = mock.NewWriter(ctrl)
+			sw        = mock.NewWriter(ctrl)
+			out       = &bytes.Buffer{}
+			instance1 = mock.NewResource(ctrl)
+			instance3 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p1.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+		p1.EXPECT().String().Return("aws").AnyTimes()
+		p2.EXPECT().String().Return("aws").AnyTimes()
+		p3.EXPECT().String().Return("aws").AnyTimes()
+		p1.EXPECT().Region().Return("us-east-1")
+		p3.EXPECT().Region().Return("eu-west-1")
+
+		p1.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instance1}, nil)
+		p3.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instance3}, nil)
+
+		instance1.EXPECT().ID().Return("i-1")
+		instance3.EXPECT().ID().Return("i-3")
+
+		instance1.EXPECT().ImportState().Return(nil, nil)
+		instance3.EXPECT().ImportState().Return(nil, nil)
+
+		instance1.EXPECT().Read(f).Return(nil)
+		instance3.EXPECT().Read(f).Return(nil)
+
+		instance1.EXPECT().HCL(hw).Return(nil)
+		instance3.EXPECT().HCL(hw).Return(nil)
+
+		instance1.EXPECT().State(sw).Return(nil)
+		instance3.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Write("provider.aws.us_east_1", map[string]interface{}{"region": "us-east-1"}).Return(nil)
+		hw.EXPECT().Write("provider.aws.eu_west_1", map[string]interface{}{"region": "eu-west-1"}).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.ImportProviders(ctx, []provider.Provider{p1, p2, p3}, hw, sw, f, provider.ImportOptions{Probe: true}, out)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Skipping aws.us_west_1 as it has no resources\n")
+		assert.NotContains(t, out.String(), "Skipping aws.us_east_1")
+	})
+	t.Run("SuccessWithSkipManaged", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p    = mock.NewProvider(ctrl)
+			hw   = mock.NewWriter(ctrl)
+			sw   = mock.NewWriter(ctrl)
+			out  = &bytes.Buffer{}
+			vpc1 = mock.NewResource(ctrl)
+			vpc2 = mock.NewResource(ctrl)
+
+			f   = &filter.Filter{}
+			sch = map[string]*schema.Schema{
+				"tags": &schema.Schema{Type: schema.TypeMap, Optional: true},
+			}
+			data1 = schema.TestResourceDataRaw(t, sch, map[string]interface{}{
+				"tags": map[string]interface{}{"aws:cloudformation:stack-name": "network"},
+			})
+			data2 = schema.TestResourceDataRaw(t, sch, map[string]interface{}{
+				"tags": map[string]interface{}{"Name": "front"},
+			})
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_vpc"})
+		p.EXPECT().TagKey().Return("tags").Times(2)
+
+		p.EXPECT().Resources(ctx, "aws_vpc", f).Return([]provider.Resource{vpc1, vpc2}, nil)
+
+		vpc1.EXPECT().ID().Return("vpc-1").Times(2)
+		vpc2.EXPECT().ID().Return("vpc-2")
+
+		vpc1.EXPECT().ImportState().Return(nil, nil)
+		vpc2.EXPECT().ImportState().Return(nil, nil)
+
+		vpc1.EXPECT().Read(f).Return(nil)
+		vpc2.EXPECT().Read(f).Return(nil)
+
+		vpc1.EXPECT().Data().Return(data1).AnyTimes()
+		vpc2.EXPECT().Data().Return(data2).AnyTimes()
+
+		vpc2.EXPECT().HCL(hw).Return(nil)
+		vpc2.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{SkipManaged: true}, out)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Skipped 1 resources managed by other IaC:\n  aws_vpc vpc-1: CloudFormation stack network (aws:cloudformation:stack-name=network)\n")
+	})
+	t.Run("SuccessWithCleanup", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p    = &janitorProvider{Provider: mock.NewProvider(ctrl), unused: map[string]string{"vol-1": "not attached to any instance"}}
+			hw   = mock.NewWriter(ctrl)
+			sw   = mock.NewWriter(ctrl)
+			out  = &bytes.Buffer{}
+			vol1 = mock.NewResource(ctrl)
+			vol2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_ebs_volume"})
+
+		p.EXPECT().Resources(ctx, "aws_ebs_volume", f).Return([]provider.Resource{vol1, vol2}, nil)
+
+		for i, r := range []*mock.Resource{vol1, vol2} {
+			r.EXPECT().ID().Return(fmt.Sprintf("vol-%d", i+1)).AnyTimes()
+			r.EXPECT().Type().Return("aws_ebs_volume").AnyTimes()
+			r.EXPECT().ImportState().Return(nil, nil)
+			r.EXPECT().Read(f).Return(nil)
+			r.EXPECT().HCL(hw).Return(nil)
+			r.EXPECT().State(sw).Return(nil)
+		}
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Cleanup: true}, out)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Found 1 cleanup candidates, resources imported that look unused:\n  aws_ebs_volume vol-1: not attached to any instance\n")
+	})
+	t.Run("SuccessWithDuplicatedResources", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p1    = &aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "us_east_1"}
+			p2    = &aliasedProvider{Provider: mock.NewProvider(ctrl), alias: "eu_west_1"}
+			hw    = mock.NewWriter(ctrl)
+			sw    = mock.NewWriter(ctrl)
+			user1 = mock.NewResource(ctrl)
+			user2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p1.EXPECT().ResourceTypes().Return([]string{"aws_iam_user"})
+		p1.EXPECT().String().Return("aws").AnyTimes()
+		p2.EXPECT().String().Return("aws").AnyTimes()
+		p1.EXPECT().Region().Return("us-east-1")
+		p2.EXPECT().Region().Return("eu-west-1")
+
+		// The IAM users are global so
+		// both regions have the same one
+		p1.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{user1}, nil)
+		p2.EXPECT().Resources(ctx, "aws_iam_user", f).Return([]provider.Resource{user2}, nil)
+
+		user1.EXPECT().ID().Return("admin")
+		user2.EXPECT().ID().Return("admin")
+
+		user1.EXPECT().ImportState().Return(nil, nil)
+		user2.EXPECT().ImportState().Return(nil, nil)
+
+		user1.EXPECT().Read(f).Return(nil)
+		user2.EXPECT().Read(f).Return(nil)
+
+		user1.EXPECT().HCL(hw).Return(nil)
+		user1.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Write("provider.aws.us_east_1", map[string]interface{}{"region": "us-east-1"}).Return(nil)
+		hw.EXPECT().Write("provider.aws.eu_west_1", map[string]interface{}{"region": "eu-west-1"}).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.ImportProviders(ctx, []provider.Provider{p1, p2}, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithExcludedResource", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p    = mock.NewProvider(ctrl)
+			hw   = mock.NewWriter(ctrl)
+			sw   = mock.NewWriter(ctrl)
+			vpc1 = mock.NewResource(ctrl)
+			vpc2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_vpc"})
+
+		p.EXPECT().Resources(ctx, "aws_vpc", f).Return([]provider.Resource{vpc1, vpc2}, nil)
+
+		vpc1.EXPECT().ID().Return("vpc-1")
+		vpc2.EXPECT().ID().Return("vpc-2")
+
+		vpc1.EXPECT().ImportState().Return(nil, nil)
+		vpc2.EXPECT().ImportState().Return(nil, nil)
+
+		vpc1.EXPECT().Read(f).Return(nil)
+		vpc2.EXPECT().Read(f).Return(nil)
+
+		// The excluded one has no TFState
+		vpc1.EXPECT().HCL(hw).Return(errors.Wrap(errcode.ErrWriterExcludedKey, "aws_vpc.vpc1"))
+		vpc2.EXPECT().HCL(hw).Return(nil)
+		vpc2.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
+		require.NoError(t, err)
+	})
+	t.Run("SuccessWithNamePrefix", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p   = mock.NewProvider(ctrl)
+			hw  = mock.NewWriter(ctrl)
+			sw  = mock.NewWriter(ctrl)
+			vpc = &prefixedResource{Resource: mock.NewResource(ctrl)}
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_vpc"})
+
+		p.EXPECT().Resources(ctx, "aws_vpc", f).Return([]provider.Resource{vpc}, nil)
+
+		vpc.EXPECT().ID().Return("vpc-1")
+		vpc.EXPECT().ImportState().Return(nil, nil)
+		vpc.EXPECT().Read(f).Return(nil)
+		vpc.EXPECT().HCL(hw).Return(nil)
+		vpc.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{NamePrefix: "imported_"}, ioutil.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, "imported_", vpc.prefix)
+	})
+	t.Run("SuccessWithNames", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p    = mock.NewProvider(ctrl)
+			hw   = mock.NewWriter(ctrl)
+			sw   = mock.NewWriter(ctrl)
+			vpc1 = &namedResource{Resource: mock.NewResource(ctrl)}
+			vpc2 = &namedResource{Resource: mock.NewResource(ctrl)}
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_vpc"})
+
+		p.EXPECT().Resources(ctx, "aws_vpc", f).Return([]provider.Resource{vpc1, vpc2}, nil)
+
+		vpc1.EXPECT().ID().Return("vpc-1").AnyTimes()
+		vpc1.EXPECT().Type().Return("aws_vpc").AnyTimes()
+		vpc1.EXPECT().ImportState().Return(nil, nil)
+		vpc1.EXPECT().Read(f).Return(nil)
+		vpc1.EXPECT().HCL(hw).Return(nil)
+		vpc1.EXPECT().State(sw).Return(nil)
+
+		vpc2.EXPECT().ID().Return("vpc-2").AnyTimes()
+		vpc2.EXPECT().Type().Return("aws_vpc").AnyTimes()
+		vpc2.EXPECT().ImportState().Return(nil, nil)
+		vpc2.EXPECT().Read(f).Return(nil)
+		vpc2.EXPECT().HCL(hw).Return(nil)
+		vpc2.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Names: map[string]string{"aws_vpc.vpc-1": "main"}}, ioutil.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, "main", vpc1.name)
+		assert.Equal(t, "", vpc2.name)
+	})
+
+	t.Run("SuccessWithNameFunc", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p    = mock.NewProvider(ctrl)
+			hw   = mock.NewWriter(ctrl)
+			sw   = mock.NewWriter(ctrl)
+			vpc1 = &namedResource{Resource: mock.NewResource(ctrl)}
+			vpc2 = &namedResource{Resource: mock.NewResource(ctrl)}
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_vpc"})
+
+		p.EXPECT().Resources(ctx, "aws_vpc", f).Return([]provider.Resource{vpc1, vpc2}, nil)
+
+		vpc1.EXPECT().ID().Return("vpc-1").AnyTimes()
+		vpc1.EXPECT().Type().Return("aws_vpc").AnyTimes()
+		vpc1.EXPECT().ImportState().Return(nil, nil)
+		vpc1.EXPECT().Read(f).Return(nil)
+		vpc1.EXPECT().HCL(hw).Return(nil)
+		vpc1.EXPECT().State(sw).Return(nil)
+
+		vpc2.EXPECT().ID().Return("vpc-2").AnyTimes()
+		vpc2.EXPECT().Type().Return("aws_vpc").AnyTimes()
+		vpc2.EXPECT().ImportState().Return(nil, nil)
+		vpc2.EXPECT().Read(f).Return(nil)
+		vpc2.EXPECT().HCL(hw).Return(nil)
+		vpc2.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{
+			Names: map[string]string{"aws_vpc.vpc-1": "main"},
+			Name:  func(t, id string) string { return "r_" + id },
+		}, ioutil.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, "main", vpc1.name)
+		assert.Equal(t, "r_vpc-2", vpc2.name)
+	})
+
+	t.Run("SuccessWithAttributes", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p   = &attributedProvider{Provider: mock.NewProvider(ctrl), attrs: map[string]string{"schema": "type Query {}"}}
+			hw  = mock.NewWriter(ctrl)
+			sw  = mock.NewWriter(ctrl)
+			api = &seededResource{Resource: mock.NewResource(ctrl)}
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.Provider.EXPECT().ResourceTypes().Return([]string{"aws_appsync_graphql_api"})
+
+		p.Provider.EXPECT().Resources(ctx, "aws_appsync_graphql_api", f).Return([]provider.Resource{api}, nil)
+
+		api.EXPECT().ID().Return("api-1")
+		api.EXPECT().ImportState().Return(nil, nil)
+		api.EXPECT().Read(f).Return(nil)
+		api.EXPECT().HCL(hw).Return(nil)
+		api.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"schema": "type Query {}"}, api.attrs)
+	})
+	t.Run("SuccessWithAttributesError", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p   = &attributedProvider{Provider: mock.NewProvider(ctrl), err: errors.New("failed")}
+			hw  = mock.NewWriter(ctrl)
+			sw  = mock.NewWriter(ctrl)
+			api = &seededResource{Resource: mock.NewResource(ctrl)}
+			sum = &provider.Summary{}
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.Provider.EXPECT().ResourceTypes().Return([]string{"aws_appsync_graphql_api"})
+		p.Provider.EXPECT().String().Return("aws").AnyTimes()
+
+		p.Provider.EXPECT().Resources(ctx, "aws_appsync_graphql_api", f).Return([]provider.Resource{api}, nil)
+
+		// It's skipped without --strict
+		api.EXPECT().ID().Return("api-1")
+		api.EXPECT().ImportState().Return(nil, nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Summary: sum}, ioutil.Discard)
+		require.NoError(t, err)
+		assert.Nil(t, api.attrs)
+		assert.Equal(t, []provider.TypeSummary{
+			{Type: "aws_appsync_graphql_api", Discovered: 1, Failed: 1},
+		}, sum.Types)
+	})
+	t.Run("SuccessWithSummary", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                = mock.NewProvider(ctrl)
+			hw               = mock.NewWriter(ctrl)
+			sw               = mock.NewWriter(ctrl)
+			instanceResoure1 = mock.NewResource(ctrl)
+			instanceResoure2 = mock.NewResource(ctrl)
+			instanceResoure3 = mock.NewResource(ctrl)
+
+			f   = &filter.Filter{}
+			out = &bytes.Buffer{}
+			sum = &provider.Summary{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResoure1, instanceResoure2, instanceResoure3}, nil)
+
+		instanceResoure1.EXPECT().ID().Return("1")
+		instanceResoure2.EXPECT().ID().Return("2")
+		instanceResoure3.EXPECT().ID().Return("3")
+
+		instanceResoure1.EXPECT().ImportState().Return(nil, nil)
+		instanceResoure2.EXPECT().ImportState().Return(nil, nil)
+		instanceResoure3.EXPECT().ImportState().Return(nil, nil)
+
+		instanceResoure1.EXPECT().Read(f).Return(nil)
+		instanceResoure2.EXPECT().Read(f).Return(errcode.ErrProviderResourceDoNotMatchTag)
+		instanceResoure3.EXPECT().Read(f).Return(errcode.ErrProviderResourceNotRead)
+
+		instanceResoure1.EXPECT().HCL(hw).Return(nil)
+		instanceResoure1.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Summary: sum}, out)
+		require.NoError(t, err)
+
+		assert.Equal(t, []provider.TypeSummary{
+			{Type: "aws_instance", Discovered: 3, Imported: 1, Skipped: 1, Failed: 1},
+		}, sum.Types)
+		assert.Equal(t, provider.TypeSummary{Type: "total", Discovered: 3, Imported: 1, Skipped: 1, Failed: 1}, sum.Total())
+		assert.Contains(t, out.String(), "\nSummary:\nTYPE          DISCOVERED  IMPORTED  SKIPPED  FAILED\naws_instance  3           1         1        1\ntotal         3           1         1        1\nElapsed: ")
+	})
+
+	t.Run("SuccessWithResourceTimeout", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                = mock.NewProvider(ctrl)
+			hw               = mock.NewWriter(ctrl)
+			sw               = mock.NewWriter(ctrl)
+			instanceResoure1 = mock.NewResource(ctrl)
+			instanceResoure2 = mock.NewResource(ctrl)
+
+			f   = &filter.Filter{}
+			sum = &provider.Summary{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResoure1, instanceResoure2}, nil)
+
+		instanceResoure1.EXPECT().ID().Return("1")
+		instanceResoure2.EXPECT().ID().Return("2")
+
+		instanceResoure1.EXPECT().ImportState().Return(nil, nil)
+		instanceResoure2.EXPECT().ImportState().Return(nil, nil)
+
+		instanceResoure1.EXPECT().Read(f).DoAndReturn(func(*filter.Filter) error {
+			time.Sleep(time.Second)
+			return nil
+		})
+		instanceResoure2.EXPECT().Read(f).Return(nil)
+
+		instanceResoure2.EXPECT().HCL(hw).Return(nil)
+		instanceResoure2.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{ResourceTimeout: 10 * time.Millisecond, Summary: sum}, ioutil.Discard)
+		require.NoError(t, err)
+
+		assert.Equal(t, []provider.TypeSummary{
+			{Type: "aws_instance", Discovered: 2, Imported: 1, Failed: 1},
+		}, sum.Types)
+	})
+
+	t.Run("ErrorWithResourceTimeoutAndStrict", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                = mock.NewProvider(ctrl)
+			hw               = mock.NewWriter(ctrl)
+			sw               = mock.NewWriter(ctrl)
+			instanceResoure1 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResoure1}, nil)
+
+		instanceResoure1.EXPECT().ID().Return("1")
+		instanceResoure1.EXPECT().ImportState().Return(nil, nil)
+		instanceResoure1.EXPECT().Read(f).DoAndReturn(func(*filter.Filter) error {
+			time.Sleep(time.Second)
+			return nil
+		})
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{ResourceTimeout: 10 * time.Millisecond, Strict: true}, ioutil.Discard)
+		require.Error(t, err)
+		assert.Equal(t, errcode.ErrProviderResourceTimeout, errors.Cause(err))
+		assert.Equal(t, provider.ErrorClassTimeout, provider.ErrorClass(err))
+	})
+
+	t.Run("SuccessWithNormalizer", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p    = &normalizedProvider{Provider: mock.NewProvider(ctrl), skipped: []string{"aws_security_group_rule"}}
+			hw   = mock.NewWriter(ctrl)
+			sw   = mock.NewWriter(ctrl)
+			sg   = mock.NewResource(ctrl)
+			rule = mock.NewResource(ctrl)
+
+			f   = &filter.Filter{}
+			sum = &provider.Summary{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_security_group"})
+
+		p.EXPECT().Resources(ctx, "aws_security_group", f).Return([]provider.Resource{sg}, nil)
+
+		sg.EXPECT().ID().Return("sg-1")
+		sg.EXPECT().Type().Return("aws_security_group").AnyTimes()
+		rule.EXPECT().Type().Return("aws_security_group_rule").AnyTimes()
+
+		sg.EXPECT().ImportState().Return([]provider.Resource{rule}, nil)
+
+		sg.EXPECT().Read(f).Return(nil)
+		rule.EXPECT().Read(f).Return(nil)
+
+		sg.EXPECT().HCL(hw).Return(nil)
+		sg.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Summary: sum}, ioutil.Discard)
+		require.NoError(t, err)
+
+		assert.Equal(t, []provider.TypeSummary{
+			{Type: "aws_security_group", Discovered: 2, Imported: 1, Skipped: 1},
+		}, sum.Types)
+	})
+
+	t.Run("SuccessWithDependencies", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p = &referencedProvider{Provider: mock.NewProvider(ctrl), refs: map[string][]string{
+				"vpc_security_group_ids": {"aws_security_group"},
+			}}
+			hw       = mock.NewWriter(ctrl)
+			sw       = mock.NewWriter(ctrl)
+			instance = mock.NewResource(ctrl)
+			sg1      = mock.NewResource(ctrl)
+			sg2      = mock.NewResource(ctrl)
+
+			f = &filter.Filter{
+				Include: []string{"aws_instance"},
+			}
+			sum = &provider.Summary{}
+			sch = map[string]*schema.Schema{
+				"vpc_security_group_ids": &schema.Schema{Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}, Set: schema.HashString},
+			}
+			data = schema.TestResourceDataRaw(t, sch, map[string]interface{}{
+				"vpc_security_group_ids": []interface{}{"sg-1"},
+			})
+		)
+
+		defer ctrl.Finish()
+
+		data.SetId("i-1")
+
+		p.EXPECT().HasResourceType("aws_instance").Return(true)
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance", "aws_security_group"})
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instance}, nil)
+		p.EXPECT().Resources(ctx, "aws_security_group", &filter.Filter{Targets: []string{"aws_security_group.sg-1"}}).Return([]provider.Resource{sg1, sg2}, nil)
+
+		instance.EXPECT().ID().Return("i-1").AnyTimes()
+		instance.EXPECT().Type().Return("aws_instance").AnyTimes()
+		instance.EXPECT().Data().Return(data).AnyTimes()
+		sg1.EXPECT().ID().Return("sg-1").AnyTimes()
+		sg1.EXPECT().Type().Return("aws_security_group").AnyTimes()
+		sg1.EXPECT().Data().Return(nil).AnyTimes()
+		sg2.EXPECT().ID().Return("sg-2").AnyTimes()
+
+		instance.EXPECT().ImportState().Return(nil, nil)
+		sg1.EXPECT().ImportState().Return(nil, nil)
+
+		instance.EXPECT().Read(f).Return(nil)
+		sg1.EXPECT().Read(gomock.Any()).Return(nil)
+
+		instance.EXPECT().HCL(gomock.Any()).Return(nil)
+		sg1.EXPECT().HCL(gomock.Any()).Return(nil)
+
+		instance.EXPECT().State(sw).Return(nil)
+		sg1.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Summary: sum, DependenciesDepth: 1}, ioutil.Discard)
+		require.NoError(t, err)
+
+		assert.Equal(t, []provider.TypeSummary{
+			{Type: "aws_instance", Discovered: 1, Imported: 1},
+			{Type: "aws_security_group", Discovered: 2, Imported: 1, Skipped: 1},
+		}, sum.Types)
+	})
+
+	t.Run("SuccessWithDependentsOf", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p = &referencedProvider{Provider: mock.NewProvider(ctrl), refs: map[string][]string{
+				"vpc_id": {"aws_vpc"},
+			}}
+			hw      = mock.NewWriter(ctrl)
+			sw      = mock.NewWriter(ctrl)
+			subnet1 = mock.NewResource(ctrl)
+			subnet2 = mock.NewResource(ctrl)
+			vpc1    = mock.NewResource(ctrl)
+			vpc2    = mock.NewResource(ctrl)
+
+			f   = &filter.Filter{}
+			sum = &provider.Summary{}
+			sch = map[string]*schema.Schema{
+				"vpc_id": &schema.Schema{Type: schema.TypeString, Optional: true},
+			}
+			data1 = schema.TestResourceDataRaw(t, sch, map[string]interface{}{"vpc_id": "vpc-1"})
+			data2 = schema.TestResourceDataRaw(t, sch, map[string]interface{}{"vpc_id": "vpc-2"})
+		)
+
+		defer ctrl.Finish()
+
+		data1.SetId("subnet-1")
+		data2.SetId("subnet-2")
+
+		p.EXPECT().HasResourceType("aws_vpc").Return(true)
+		p.EXPECT().ResourceTypes().Return([]string{"aws_subnet", "aws_vpc"})
+		p.EXPECT().Resources(ctx, "aws_subnet", f).Return([]provider.Resource{subnet1, subnet2}, nil)
+		p.EXPECT().Resources(ctx, "aws_vpc", f).Return([]provider.Resource{vpc1, vpc2}, nil)
+
+		subnet1.EXPECT().ID().Return("subnet-1").AnyTimes()
+		subnet1.EXPECT().Type().Return("aws_subnet").AnyTimes()
+		subnet1.EXPECT().Data().Return(data1).AnyTimes()
+		subnet2.EXPECT().ID().Return("subnet-2").AnyTimes()
+		subnet2.EXPECT().Type().Return("aws_subnet").AnyTimes()
+		subnet2.EXPECT().Data().Return(data2).AnyTimes()
+		vpc1.EXPECT().ID().Return("vpc-1").AnyTimes()
+		vpc1.EXPECT().Type().Return("aws_vpc").AnyTimes()
+		vpc1.EXPECT().Data().Return(nil).AnyTimes()
+		vpc2.EXPECT().ID().Return("vpc-2").AnyTimes()
+		vpc2.EXPECT().Type().Return("aws_vpc").AnyTimes()
+		vpc2.EXPECT().Data().Return(nil).AnyTimes()
+
+		for _, r := range []*mock.Resource{subnet1, subnet2, vpc1, vpc2} {
+			r.EXPECT().ImportState().Return(nil, nil)
+			r.EXPECT().Read(f).Return(nil)
+		}
+
+		// The subnet-1 is held until the vpc-1 is imported
+		vpc1.EXPECT().HCL(gomock.Any()).Return(nil)
+		subnet1.EXPECT().HCL(gomock.Any()).Return(nil)
+
+		vpc1.EXPECT().State(sw).Return(nil)
+		subnet1.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Summary: sum, DependentsOf: "aws_vpc:vpc-1"}, ioutil.Discard)
+		require.NoError(t, err)
+
+		assert.Equal(t, []provider.TypeSummary{
+			{Type: "aws_subnet", Discovered: 2, Imported: 1, Skipped: 1},
+			{Type: "aws_vpc", Discovered: 2, Imported: 1, Skipped: 1},
+		}, sum.Types)
+	})
+
+	t.Run("SuccessWithMaxPerType", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                = mock.NewProvider(ctrl)
+			hw               = mock.NewWriter(ctrl)
+			sw               = mock.NewWriter(ctrl)
+			instanceResoure1 = mock.NewResource(ctrl)
+			instanceResoure2 = mock.NewResource(ctrl)
+			instanceResoure3 = mock.NewResource(ctrl)
+
+			f   = &filter.Filter{}
+			sum = &provider.Summary{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+
+		// The first one by the ID is imported
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResoure3, instanceResoure1, instanceResoure2}, nil)
+
+		instanceResoure1.EXPECT().ID().Return("1").AnyTimes()
+		instanceResoure1.EXPECT().ImportState().Return(nil, nil)
+		instanceResoure1.EXPECT().Read(f).Return(nil)
+		instanceResoure1.EXPECT().HCL(hw).Return(nil)
+		instanceResoure1.EXPECT().State(sw).Return(nil)
+
+		// The next ones may be read meanwhile the
+		// first one is written, but never written
+		instanceResoure2.EXPECT().ID().Return("2").AnyTimes()
+		instanceResoure3.EXPECT().ID().Return("3").AnyTimes()
+		for _, r := range []*mock.Resource{instanceResoure2, instanceResoure3} {
+			r.EXPECT().ImportState().Return(nil, nil).MaxTimes(1)
+			r.EXPECT().Read(f).Return(nil).MaxTimes(1)
+		}
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{MaxPerType: 1, Summary: sum}, ioutil.Discard)
+		require.NoError(t, err)
+
+		assert.Equal(t, []provider.TypeSummary{
+			{Type: "aws_instance", Discovered: 3, Imported: 1, Skipped: 2},
+		}, sum.Types)
+	})
+
+	t.Run("SuccessWithSample", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p   = mock.NewProvider(ctrl)
+			hw  = mock.NewWriter(ctrl)
+			sw  = mock.NewWriter(ctrl)
+			rs  = make([]provider.Resource, 0, 10)
+			ids = make([]string, 0, 2)
+
+			f   = &filter.Filter{}
+			sum = &provider.Summary{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+
+		for i := 0; i < 10; i++ {
+			id := fmt.Sprint(i)
+			r := mock.NewResource(ctrl)
+			r.EXPECT().ID().Return(id).AnyTimes()
+			r.EXPECT().ImportState().Return(nil, nil).MaxTimes(1)
+			r.EXPECT().Read(f).Return(nil).MaxTimes(1)
+			r.EXPECT().HCL(hw).DoAndReturn(func(writer.Writer) error {
+				ids = append(ids, id)
+				return nil
+			}).MaxTimes(1)
+			r.EXPECT().State(sw).Return(nil).MaxTimes(1)
+			rs = append(rs, r)
+		}
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return(rs, nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{MaxPerType: 2, Sample: rand.New(rand.NewSource(1)), Summary: sum}, ioutil.Discard)
+		require.NoError(t, err)
+
+		assert.Len(t, ids, 2)
+		assert.NotEqual(t, []string{"0", "1"}, ids)
+		assert.Equal(t, []provider.TypeSummary{
+			{Type: "aws_instance", Discovered: 10, Imported: 2, Skipped: 8},
+		}, sum.Types)
+	})
+
+	t.Run("SuccessWithoutState", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p   = mock.NewProvider(ctrl)
+			hw  = mock.NewWriter(ctrl)
+			sw  = mock.NewWriter(ctrl)
+			vpc = &statelessResource{Resource: mock.NewResource(ctrl)}
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_vpc"}).Times(2)
+
+		// Each Import releases the resources read
+		p.EXPECT().Resources(ctx, "aws_vpc", f).Return([]provider.Resource{vpc}, nil)
+		p.EXPECT().Resources(ctx, "aws_vpc", f).Return([]provider.Resource{vpc}, nil)
+
+		vpc.EXPECT().ID().Return("vpc-1").AnyTimes()
+		vpc.EXPECT().ImportState().Return(nil, nil).Times(2)
+		vpc.EXPECT().Read(f).Return(nil).Times(2)
+		vpc.EXPECT().HCL(hw).Return(nil).Times(2)
+		vpc.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil).Times(2)
+		sw.EXPECT().Sync().Return(nil)
+
+		// With the TFState the state is built
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
+		require.NoError(t, err)
+		assert.False(t, vpc.skipped)
+
+		// With only the HCL it's skipped
+		err = provider.Import(ctx, p, hw, nil, f, provider.ImportOptions{}, ioutil.Discard)
+		require.NoError(t, err)
+		assert.True(t, vpc.skipped)
+	})
+
+	t.Run("SuccessWithCircuitBreaker", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                 = mock.NewProvider(ctrl)
+			hw                = mock.NewWriter(ctrl)
+			sw                = mock.NewWriter(ctrl)
+			clusterResource1  = mock.NewResource(ctrl)
+			clusterResource2  = mock.NewResource(ctrl)
+			clusterResource3  = mock.NewResource(ctrl)
+			instanceResoure1  = mock.NewResource(ctrl)
+			accessDeniedError = fmt.Errorf("AccessDenied: User is not authorized to perform: elasticache:DescribeCacheClusters")
+
+			f   = &filter.Filter{}
+			out = &bytes.Buffer{}
+			sum = &provider.Summary{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_elasticache_cluster", "aws_elasticache_replication_group", "aws_instance"})
+		p.EXPECT().String().Return("aws").AnyTimes()
+
+		p.EXPECT().Resources(ctx, "aws_elasticache_cluster", f).Return([]provider.Resource{clusterResource1, clusterResource2, clusterResource3}, nil)
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResoure1}, nil)
+
+		clusterResource1.EXPECT().ID().Return("1")
+		clusterResource2.EXPECT().ID().Return("2")
+
+		clusterResource1.EXPECT().ImportState().Return(nil, nil)
+		clusterResource2.EXPECT().ImportState().Return(nil, nil)
+
+		clusterResource1.EXPECT().Read(f).Return(accessDeniedError)
+		clusterResource2.EXPECT().Read(f).Return(accessDeniedError)
+
+		instanceResoure1.EXPECT().ID().Return("i-1")
+		instanceResoure1.EXPECT().ImportState().Return(nil, nil)
+		instanceResoure1.EXPECT().Read(f).Return(nil)
+		instanceResoure1.EXPECT().HCL(hw).Return(nil)
+		instanceResoure1.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{CircuitBreaker: 2, Summary: sum}, out)
+		require.NoError(t, err)
+
+		assert.Equal(t, []provider.TypeSummary{
+			{Type: "aws_elasticache_cluster", Discovered: 3, Failed: 3},
+			{Type: "aws_instance", Discovered: 1, Imported: 1},
+		}, sum.Types)
+		assert.Contains(t, out.String(), "\nSkipped 1 services after 2 consecutive errors, the rest of their resources were not read:\n  elasticache: AccessDenied: User is not authorized to perform: elasticache:DescribeCacheClusters\n")
+	})
+
+	t.Run("SuccessWithCircuitBreakerOnList", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                = mock.NewProvider(ctrl)
+			hw               = mock.NewWriter(ctrl)
+			sw               = mock.NewWriter(ctrl)
+			instanceResoure1 = mock.NewResource(ctrl)
+
+			f   = &filter.Filter{}
+			out = &bytes.Buffer{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_elasticache_cluster", "aws_elasticache_replication_group", "aws_instance"})
+		p.EXPECT().String().Return("aws").AnyTimes()
+
+		p.EXPECT().Resources(ctx, "aws_elasticache_cluster", f).Return(nil, fmt.Errorf("ServiceUnavailable: Service is unavailable\n\tstatus code: 503"))
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResoure1}, nil)
+
+		instanceResoure1.EXPECT().ID().Return("i-1")
+		instanceResoure1.EXPECT().ImportState().Return(nil, nil)
+		instanceResoure1.EXPECT().Read(f).Return(nil)
+		instanceResoure1.EXPECT().HCL(hw).Return(nil)
+		instanceResoure1.EXPECT().State(sw).Return(nil)
+
+		hw.EXPECT().Sync().Return(nil)
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{CircuitBreaker: 1}, out)
+		require.NoError(t, err)
+
+		assert.Contains(t, out.String(), "\nSkipped 1 services after 1 consecutive errors, the rest of their resources were not read:\n  elasticache: ServiceUnavailable: Service is unavailable\n")
+	})
+
+	t.Run("ErrorWithCircuitBreakerAndStrict", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p  = mock.NewProvider(ctrl)
+			hw = mock.NewWriter(ctrl)
+			sw = mock.NewWriter(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_elasticache_cluster"})
+		p.EXPECT().String().Return("aws").AnyTimes()
+
+		p.EXPECT().Resources(ctx, "aws_elasticache_cluster", f).Return(nil, fmt.Errorf("AccessDenied: User is not authorized"))
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{CircuitBreaker: 1, Strict: true}, ioutil.Discard)
+		assert.EqualError(t, err, "AccessDenied: User is not authorized")
+	})
+
+	t.Run("ErrorWithAttributes", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p   = &attributedProvider{Provider: mock.NewProvider(ctrl), err: errors.New("failed")}
+			hw  = mock.NewWriter(ctrl)
+			sw  = mock.NewWriter(ctrl)
+			api = &seededResource{Resource: mock.NewResource(ctrl)}
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.Provider.EXPECT().ResourceTypes().Return([]string{"aws_appsync_graphql_api"})
+		p.Provider.EXPECT().String().Return("aws").AnyTimes()
+
+		p.Provider.EXPECT().Resources(ctx, "aws_appsync_graphql_api", f).Return([]provider.Resource{api}, nil)
+
+		api.EXPECT().ID().Return("api-1")
+		api.EXPECT().ImportState().Return(nil, nil)
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{Strict: true}, ioutil.Discard)
+		assert.EqualError(t, err, `error while reading the attributes of resource "aws_appsync_graphql_api" with id "api-1": failed`)
+	})
+
+	t.Run("ErrorWhileReading", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p                = mock.NewProvider(ctrl)
+			hw               = mock.NewWriter(ctrl)
+			sw               = mock.NewWriter(ctrl)
+			instanceResoure1 = mock.NewResource(ctrl)
+			instanceResoure2 = mock.NewResource(ctrl)
+			instanceResoure3 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instanceResoure1, instanceResoure2, instanceResoure3}, nil)
+
+		instanceResoure1.EXPECT().ID().Return("1")
+		instanceResoure1.EXPECT().ImportState().Return(nil, nil)
+		instanceResoure1.EXPECT().Read(f).Return(nil)
+		instanceResoure1.EXPECT().HCL(hw).Return(errors.New("failed"))
+
+		// The next ones may be read meanwhile the
+		// first one is written, but never written
+		for _, r := range []*mock.Resource{instanceResoure2, instanceResoure3} {
+			r.EXPECT().ID().Return("2").MaxTimes(1)
+			r.EXPECT().ImportState().Return(nil, nil).MaxTimes(1)
+			r.EXPECT().Read(f).Return(nil).MaxTimes(1)
+		}
+
+		err := provider.Import(ctx, p, hw, sw, f, provider.ImportOptions{}, ioutil.Discard)
+		assert.EqualError(t, err, `error while calculating the Config of resource "aws_instance": failed`)
+	})
+}
+
+// normalizedProvider is a mock.Provider
+// that implements the provider.Normalizer
+type normalizedProvider struct {
+	*mock.Provider
+
+	skipped []string
+	inline  []string
+}
+
+func (p *normalizedProvider) SkippedTypes() []string     { return p.skipped }
+func (p *normalizedProvider) InlineAttributes() []string { return p.inline }
+
+// referencedProvider is a mock.Provider
+// that implements the provider.Referencer
+type referencedProvider struct {
+	*mock.Provider
+
+	refs map[string][]string
+}
+
+func (p *referencedProvider) References() map[string][]string { return p.refs }
+
+// aliasedProvider is a mock.Provider
+// that implements the provider.Aliaser
+type aliasedProvider struct {
+	*mock.Provider
+
+	alias string
+}
+
+func (p *aliasedProvider) Alias() string { return p.alias }
+
+// sharedAliasedProvider is an aliasedProvider
+// that implements the provider.SharedAliaser
+type sharedAliasedProvider struct {
+	aliasedProvider
+
+	shared string
+}
+
+func (p *sharedAliasedProvider) SharedAlias() string { return p.shared }
+
+// probedProvider is an aliasedProvider
+// that implements the provider.Prober
+type probedProvider struct {
+	aliasedProvider
+
+	empty bool
+}
+
+func (p *probedProvider) Probe(ctx context.Context) (bool, error) { return p.empty, nil }
+
+// prefixedResource is a mock.Resource
+// which name can be prefixed
+type prefixedResource struct {
+	*mock.Resource
+
+	prefix string
+}
+
+func (r *prefixedResource) SetNamePrefix(p string) { r.prefix = p }
+
+// namedResource is a mock.Resource
+// which name can be set
+type namedResource struct {
+	*mock.Resource
+
+	name string
+}
+
+func (r *namedResource) SetName(n string) { r.name = n }
+
+// attributedProvider is a mock.Provider that
+// implements the provider.AttributeReader
+type attributedProvider struct {
+	*mock.Provider
+
+	attrs map[string]string
+	err   error
+}
+
+func (p *attributedProvider) ReadAttributes(ctx context.Context, t, id string) (map[string]string, error) {
+	return p.attrs, p.err
+}
+
+// seededResource is a mock.Resource
+// which state can be seeded
+type seededResource struct {
+	*mock.Resource
+
+	attrs map[string]string
+}
+
+func (r *seededResource) SeedAttributes(attrs map[string]string) { r.attrs = attrs }
+
+// janitorProvider is a mock.Provider that
+// implements the provider.Janitor
+type janitorProvider struct {
+	*mock.Provider
+
+	unused map[string]string
+}
+
+func (p *janitorProvider) UnusedReason(t, id string, get func(string) string) string {
+	return p.unused[id]
+}
+
+// statelessResource is a mock.Resource
+// which state can be skipped
+type statelessResource struct {
+	*mock.Resource
+
+	skipped bool
+}
+
+func (r *statelessResource) SkipState() { r.skipped = true }
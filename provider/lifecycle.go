@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// Lifecycle are the settings of the lifecycle
+// block of the resources of a type
+type Lifecycle struct {
+	PreventDestroy      bool     `yaml:"prevent_destroy" json:"prevent_destroy"`
+	CreateBeforeDestroy bool     `yaml:"create_before_destroy" json:"create_before_destroy"`
+	IgnoreChanges       []string `yaml:"ignore_changes" json:"ignore_changes"`
+}
+
+// lifecycleWriter adds the lifecycle block
+// to the configurations written to it
+type lifecycleWriter struct {
+	writer.Writer
+
+	lifecycles map[string]Lifecycle
+}
+
+// NewLifecycleWriter returns a writer.Writer that writes to w the
+// configurations of the resources with the lifecycle block of the
+// lifecycles of the type of them. The ignore_changes are added to
+// the ones already on the configuration (ex: sensitive attributes)
+func NewLifecycleWriter(w writer.Writer, lifecycles map[string]Lifecycle) writer.Writer {
+	return &lifecycleWriter{
+		Writer:     w,
+		lifecycles: lifecycles,
+	}
+}
+
+// Write adds the lifecycle to the value and writes it,
+// the data sources and variables are written as they are
+func (l *lifecycleWriter) Write(key string, value interface{}) error {
+	cfg, ok := value.(map[string]interface{})
+	keys := strings.Split(key, ".")
+	if !ok || len(keys) != 2 || keys[0] == "variable" {
+		return l.Writer.Write(key, value)
+	}
+
+	lc, ok := l.lifecycles[keys[0]]
+	if !ok {
+		return l.Writer.Write(key, value)
+	}
+
+	b, _ := cfg["lifecycle"].(map[string]interface{})
+	if b == nil {
+		b = make(map[string]interface{})
+	}
+
+	if lc.PreventDestroy {
+		b["prevent_destroy"] = true
+	}
+	if lc.CreateBeforeDestroy {
+		b["create_before_destroy"] = true
+	}
+	if len(lc.IgnoreChanges) != 0 {
+		b["ignore_changes"] = mergeIgnoreChanges(b["ignore_changes"], lc.IgnoreChanges)
+	}
+
+	if len(b) != 0 {
+		cfg["lifecycle"] = b
+	}
+
+	return l.Writer.Write(key, cfg)
+}
+
+// mergeIgnoreChanges returns the attributes of the current
+// ignore_changes and the attrs, sorted and without repeated ones
+func mergeIgnoreChanges(current interface{}, attrs []string) []interface{} {
+	set := make(map[string]struct{})
+	if cur, ok := current.([]interface{}); ok {
+		for _, a := range cur {
+			if s, ok := a.(string); ok {
+				set[s] = struct{}{}
+			}
+		}
+	}
+	for _, a := range attrs {
+		set[a] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(set))
+	for a := range set {
+		sorted = append(sorted, a)
+	}
+	sort.Strings(sorted)
+
+	res := make([]interface{}, 0, len(sorted))
+	for _, a := range sorted {
+		res = append(res, a)
+	}
+
+	return res
+}
@@ -0,0 +1,73 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleWriter(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+		w    = mock.NewWriter(ctrl)
+		lw   = provider.NewLifecycleWriter(w, map[string]provider.Lifecycle{
+			"aws_autoscaling_group": provider.Lifecycle{IgnoreChanges: []string{"desired_capacity"}},
+			"aws_db_instance":       provider.Lifecycle{PreventDestroy: true, IgnoreChanges: []string{"password", "engine_version"}},
+		})
+	)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		w.EXPECT().Write("aws_autoscaling_group.name", map[string]interface{}{
+			"max_size": 2,
+			"lifecycle": map[string]interface{}{
+				"ignore_changes": []interface{}{"desired_capacity"},
+			},
+		}).Return(nil)
+
+		err := lw.Write("aws_autoscaling_group.name", map[string]interface{}{
+			"max_size": 2,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("SuccessMergingIgnoreChanges", func(t *testing.T) {
+		w.EXPECT().Write("aws_db_instance.name", map[string]interface{}{
+			"password": "${var.aws_db_instance_name_password}",
+			"lifecycle": map[string]interface{}{
+				"prevent_destroy": true,
+				"ignore_changes":  []interface{}{"engine_version", "password"},
+			},
+		}).Return(nil)
+
+		err := lw.Write("aws_db_instance.name", map[string]interface{}{
+			"password": "${var.aws_db_instance_name_password}",
+			"lifecycle": map[string]interface{}{
+				"ignore_changes": []interface{}{"password"},
+			},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("SuccessWithoutLifecycle", func(t *testing.T) {
+		w.EXPECT().Write("aws_instance.name", map[string]interface{}{
+			"ami": "ami-123",
+		}).Return(nil)
+		w.EXPECT().Write("variable", map[string]interface{}{
+			"aws_db_instance": "value",
+		}).Return(nil)
+
+		err := lw.Write("aws_instance.name", map[string]interface{}{
+			"ami": "ami-123",
+		})
+		require.NoError(t, err)
+
+		err = lw.Write("variable", map[string]interface{}{
+			"aws_db_instance": "value",
+		})
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// managedTags are the keys of the tags (or labels) set by other
+// IaC tools on the resources they manage, with the tool of each
+var managedTags = map[string]string{
+	"aws:cloudformation:stack-name": "CloudFormation stack",
+	"goog-dm":                       "Deployment Manager deployment",
+	"managed-by-cnrm":               "Config Connector",
+}
+
+// managedByKeys are the keys, without separators and
+// lowercased, of the tags naming the tool managing it
+// (ex: 'managed-by=terraform' or 'ManagedBy=pulumi')
+var managedByKeys = map[string]struct{}{
+	"managedby": struct{}{},
+	"terraform": struct{}{},
+}
+
+// managedBy returns why the resource with the tags is considered
+// managed by other IaC, an empty string if it's not
+func managedBy(tags map[string]interface{}) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := fmt.Sprint(tags[k])
+		if tool, ok := managedTags[k]; ok {
+			return fmt.Sprintf("%s %s (%s=%s)", tool, v, k, v)
+		}
+
+		nk := strings.ToLower(strings.NewReplacer("-", "", "_", "", " ", "").Replace(k))
+		if _, ok := managedByKeys[nk]; ok && v != "" && v != "false" {
+			return fmt.Sprintf("tagged as managed (%s=%s)", k, v)
+		}
+	}
+
+	return ""
+}
+
+// managedReason returns why the r is considered managed by
+// other IaC, from its tags on the tagKey, see managedBy
+func managedReason(r Resource, tagKey string) string {
+	if r.Data() == nil {
+		return ""
+	}
+
+	v, ok := r.Data().GetOk(tagKey)
+	if !ok {
+		return ""
+	}
+
+	tags, ok := v.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	return managedBy(tags)
+}
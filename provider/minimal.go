@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/cycloidio/terracognita/writer"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// minimalWriter removes from the configurations
+// written to it the attributes not needed
+type minimalWriter struct {
+	writer.Writer
+
+	provider Provider
+}
+
+// NewMinimalWriter returns a writer.Writer that writes to w only the
+// attributes of the configurations that are Required or have not the
+// default values, using the schema of the resources of the p. The
+// Optional and Computed ones are also removed as TF will keep the
+// value they have
+func NewMinimalWriter(w writer.Writer, p Provider) writer.Writer {
+	return &minimalWriter{
+		Writer:   w,
+		provider: p,
+	}
+}
+
+// Write removes the not needed attributes of the value and
+// writes it, the data sources are written as they are
+func (m *minimalWriter) Write(key string, value interface{}) error {
+	cfg, ok := value.(map[string]interface{})
+	if !ok || strings.HasPrefix(key, "data.") {
+		return m.Writer.Write(key, value)
+	}
+
+	tfr, ok := m.provider.TFProvider().ResourcesMap[strings.Split(key, ".")[0]]
+	if !ok {
+		return m.Writer.Write(key, value)
+	}
+
+	return m.Writer.Write(key, minimalConfig(tfr.Schema, cfg))
+}
+
+// minimalConfig returns the cfg with only
+// the needed attributes following the sch
+func minimalConfig(sch map[string]*schema.Schema, cfg map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(cfg))
+	for k, v := range cfg {
+		s, ok := sch[strings.TrimPrefix(k, "=tc=")]
+		if !ok {
+			res[k] = v
+			continue
+		}
+
+		if s.Required {
+			res[k] = v
+			continue
+		}
+
+		if s.Optional && s.Computed {
+			continue
+		}
+
+		if s.Default != nil && reflect.DeepEqual(s.Default, v) {
+			continue
+		}
+
+		if sr, ok := s.Elem.(*schema.Resource); ok {
+			if v = minimalBlock(sr.Schema, v); v == nil {
+				continue
+			}
+		}
+
+		res[k] = v
+	}
+
+	return res
+}
+
+// minimalBlock returns the nested block v with only the needed
+// attributes, or nil if it has none
+func minimalBlock(sch map[string]*schema.Schema, v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		if b := minimalConfig(sch, vv); len(b) != 0 {
+			return b
+		}
+	case []interface{}:
+		bs := make([]interface{}, 0, len(vv))
+		for _, e := range vv {
+			if b := minimalBlock(sch, e); b != nil {
+				bs = append(bs, b)
+			}
+		}
+		if len(bs) != 0 {
+			return bs
+		}
+	default:
+		return v
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinimalWriter(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+		p    = mock.NewProvider(ctrl)
+		w    = mock.NewWriter(ctrl)
+		mw   = provider.NewMinimalWriter(w, p)
+	)
+	defer ctrl.Finish()
+
+	p.EXPECT().TFProvider().Return(&schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"aws_instance": &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"ami":               &schema.Schema{Type: schema.TypeString, Required: true},
+					"subnet_id":         &schema.Schema{Type: schema.TypeString, Optional: true, Computed: true},
+					"monitoring":        &schema.Schema{Type: schema.TypeBool, Optional: true, Default: true},
+					"source_dest_check": &schema.Schema{Type: schema.TypeBool, Optional: true, Default: true},
+					"tags":              &schema.Schema{Type: schema.TypeMap, Optional: true},
+					"ebs_block_device": &schema.Schema{
+						Type:     schema.TypeSet,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"device_name":           &schema.Schema{Type: schema.TypeString, Required: true},
+								"delete_on_termination": &schema.Schema{Type: schema.TypeBool, Optional: true, Default: true},
+								"iops":                  &schema.Schema{Type: schema.TypeInt, Optional: true, Computed: true},
+							},
+						},
+					},
+					"credit_specification": &schema.Schema{
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"cpu_credits": &schema.Schema{Type: schema.TypeString, Optional: true, Default: "standard"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}).AnyTimes()
+
+	w.EXPECT().Write("aws_instance.front", map[string]interface{}{
+		"ami":        "ami-123",
+		"monitoring": false,
+		"=tc=tags":   map[string]interface{}{"Name": "front"},
+		"ebs_block_device": []interface{}{
+			map[string]interface{}{"device_name": "/dev/sdb"},
+		},
+	}).Return(nil)
+	w.EXPECT().Write("data.aws_subnet.shared", map[string]interface{}{"id": "subnet-1"}).Return(nil)
+
+	require.NoError(t, mw.Write("aws_instance.front", map[string]interface{}{
+		"ami":               "ami-123",
+		"subnet_id":         "subnet-1",
+		"monitoring":        false,
+		"source_dest_check": true,
+		"=tc=tags":          map[string]interface{}{"Name": "front"},
+		"ebs_block_device": []interface{}{
+			map[string]interface{}{"device_name": "/dev/sdb", "delete_on_termination": true, "iops": 100},
+		},
+		"credit_specification": []interface{}{
+			map[string]interface{}{"cpu_credits": "standard"},
+		},
+	}))
+	require.NoError(t, mw.Write("data.aws_subnet.shared", map[string]interface{}{"id": "subnet-1"}))
+}
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// Modularizer is an optional interface of the Provider for the ones
+// that can group the resources on calls to modules of the Terraform
+// Registry (ex: terraform-aws-modules/vpc/aws), see NewModuleWriter
+type Modularizer interface {
+	// Modules returns the calls to the modules grouping the
+	// configurations cfgs, by the key of them (ex: aws_vpc.main)
+	Modules(cfgs map[string]map[string]interface{}) []ModuleCall
+}
+
+// ModuleCall is a call to a module of the Terraform
+// Registry that replaces some of the resources
+type ModuleCall struct {
+	// Name is the name of the module
+	// block (ex: module.NAME)
+	Name string
+
+	// Source and Version are the ones of
+	// the module (ex: terraform-aws-modules/vpc/aws)
+	Source  string
+	Version string
+
+	// Inputs are the variables of the module
+	// computed from the resources replaced
+	Inputs map[string]interface{}
+
+	// Resources are the keys of the resources replaced by
+	// the module with the address of them inside of it
+	// (ex: aws_vpc.main => aws_vpc.this[0])
+	Resources map[string]string
+
+	// Outputs are the references to the resources replaced
+	// (ex: aws_vpc.main.id) with the output of the module
+	// used instead by the other resources (ex: vpc_id)
+	Outputs map[string]string
+}
+
+// moduleWriter keeps the configurations written to
+// it to replace the ones grouped by the Modularizer
+// with the calls to the modules on the Sync
+type moduleWriter struct {
+	writer.Writer
+
+	modularizer Modularizer
+
+	// cfgs are the configurations of the resources
+	// and keys the order on which those were written
+	cfgs map[string]map[string]interface{}
+	keys []string
+}
+
+// NewModuleWriter returns a writer.Writer that writes to w the
+// resources grouped by the m as calls to the modules, the other
+// resources are written referencing the outputs of those instead.
+// The resources are written on the Sync, with a comment on each
+// module with the commands to move them on the TFState
+func NewModuleWriter(w writer.Writer, m Modularizer) writer.Writer {
+	return &moduleWriter{
+		Writer:      w,
+		modularizer: m,
+		cfgs:        make(map[string]map[string]interface{}),
+	}
+}
+
+// Write keeps the configurations of the resources until
+// the Sync, the rest of the keys are written as they are
+func (m *moduleWriter) Write(key string, value interface{}) error {
+	cfg, ok := value.(map[string]interface{})
+	keys := strings.Split(key, ".")
+	if !ok || len(keys) != 2 || keys[0] == "variable" || keys[0] == "module" {
+		return m.Writer.Write(key, value)
+	}
+
+	if _, ok := m.cfgs[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.cfgs[key] = cfg
+
+	return nil
+}
+
+// Has checks if the key is kept or written
+func (m *moduleWriter) Has(key string) (bool, error) {
+	if _, ok := m.cfgs[key]; ok {
+		return true, nil
+	}
+	return m.Writer.Has(key)
+}
+
+// Sync writes the calls to the modules and the resources not
+// grouped on them, with the references replaced, and syncs the writer
+func (m *moduleWriter) Sync() error {
+	grouped := make(map[string]struct{})
+	outputs := make(map[string]string)
+
+	calls := m.modularizer.Modules(m.cfgs)
+	sort.Slice(calls, func(i, j int) bool { return calls[i].Name < calls[j].Name })
+
+	for _, c := range calls {
+		key := fmt.Sprintf("module.%s", c.Name)
+
+		cfg := map[string]interface{}{
+			"source":  c.Source,
+			"version": c.Version,
+		}
+		for k, v := range c.Inputs {
+			cfg[k] = v
+		}
+		if err := m.Writer.Write(key, cfg); err != nil {
+			return err
+		}
+
+		keys := make([]string, 0, len(c.Resources))
+		for k := range c.Resources {
+			keys = append(keys, k)
+			grouped[k] = struct{}{}
+		}
+		sort.Strings(keys)
+
+		comment := []string{"The resources of the module are on the TFState as they were imported, move them with:"}
+		for _, k := range keys {
+			comment = append(comment, fmt.Sprintf("  terraform state mv %s '%s.%s'", k, key, c.Resources[k]))
+		}
+		if err := m.Writer.Write("comment."+key, strings.Join(comment, "\n")); err != nil {
+			return err
+		}
+
+		for ref, o := range c.Outputs {
+			outputs[ref] = fmt.Sprintf("%s.%s", key, o)
+		}
+	}
+
+	for _, k := range m.keys {
+		if _, ok := grouped[k]; ok {
+			continue
+		}
+		if err := m.Writer.Write(k, replaceOutputs(m.cfgs[k], outputs)); err != nil {
+			return err
+		}
+	}
+
+	return m.Writer.Sync()
+}
+
+// replaceOutputs returns the v with the interpolations
+// to the references of the outputs (ex: ${aws_vpc.main.id})
+// replaced with the outputs (ex: ${module.main.vpc_id})
+func replaceOutputs(v interface{}, outputs map[string]string) interface{} {
+	switch vv := v.(type) {
+	case string:
+		if strings.HasPrefix(vv, "${") && strings.HasSuffix(vv, "}") {
+			if o, ok := outputs[vv[2:len(vv)-1]]; ok {
+				return fmt.Sprintf("${%s}", o)
+			}
+		}
+	case map[string]interface{}:
+		for k, e := range vv {
+			vv[k] = replaceOutputs(e, outputs)
+		}
+	case []interface{}:
+		for i, e := range vv {
+			vv[i] = replaceOutputs(e, outputs)
+		}
+	}
+	return v
+}
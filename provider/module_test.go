@@ -0,0 +1,75 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// modularizer is a provider.Modularizer
+// that returns the calls of the fn
+type modularizer func(cfgs map[string]map[string]interface{}) []provider.ModuleCall
+
+func (m modularizer) Modules(cfgs map[string]map[string]interface{}) []provider.ModuleCall {
+	return m(cfgs)
+}
+
+func TestModuleWriter(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+		w    = mock.NewWriter(ctrl)
+		mw   = provider.NewModuleWriter(w, modularizer(func(cfgs map[string]map[string]interface{}) []provider.ModuleCall {
+			return []provider.ModuleCall{
+				{
+					Name:    "main",
+					Source:  "terraform-aws-modules/vpc/aws",
+					Version: "~> 3.0",
+					Inputs:  map[string]interface{}{"cidr": cfgs["aws_vpc.main"]["cidr_block"]},
+					Resources: map[string]string{
+						"aws_vpc.main":    "aws_vpc.this[0]",
+						"aws_subnet.main": "aws_subnet.private[0]",
+					},
+					Outputs: map[string]string{
+						"aws_vpc.main.id":    "vpc_id",
+						"aws_subnet.main.id": "private_subnets[0]",
+					},
+				},
+			}
+		}))
+	)
+	defer ctrl.Finish()
+
+	w.EXPECT().Write("variable.name", map[string]interface{}{"description": "name"}).Return(nil)
+
+	for k, v := range map[string]interface{}{
+		"aws_vpc.main":      map[string]interface{}{"cidr_block": "10.0.0.0/16"},
+		"aws_subnet.main":   map[string]interface{}{"vpc_id": "${aws_vpc.main.id}"},
+		"aws_instance.main": map[string]interface{}{"subnet_id": "${aws_subnet.main.id}", "ami": "ami-123"},
+		"variable.name":     map[string]interface{}{"description": "name"},
+	} {
+		require.NoError(t, mw.Write(k, v))
+	}
+
+	ok, err := mw.Has("aws_subnet.main")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	gomock.InOrder(
+		w.EXPECT().Write("module.main", map[string]interface{}{
+			"source":  "terraform-aws-modules/vpc/aws",
+			"version": "~> 3.0",
+			"cidr":    "10.0.0.0/16",
+		}).Return(nil),
+		w.EXPECT().Write("comment.module.main", "The resources of the module are on the TFState as they were imported, move them with:\n"+
+			"  terraform state mv aws_subnet.main 'module.main.aws_subnet.private[0]'\n"+
+			"  terraform state mv aws_vpc.main 'module.main.aws_vpc.this[0]'").Return(nil),
+		w.EXPECT().Write("aws_instance.main", map[string]interface{}{"subnet_id": "${module.main.private_subnets[0]}", "ami": "ami-123"}).Return(nil),
+		w.EXPECT().Sync().Return(nil),
+	)
+
+	require.NoError(t, mw.Sync())
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	kitlog "github.com/go-kit/kit/log"
+)
+
+// probe returns the ps without the empty ones, detected by the
+// Probers. The first one is never skipped as it's the one with the
+// global resources (ex: IAM), which are on all of them, and the ones
+// that can not be probed are imported as they are
+func probe(ctx context.Context, ps []Provider, out io.Writer, logger kitlog.Logger) []Provider {
+	res := make([]Provider, 0, len(ps))
+	for i, p := range ps {
+		pr, ok := p.(Prober)
+		if !ok {
+			res = append(res, p)
+			continue
+		}
+
+		name := ProviderAlias(p)
+		if name == "" {
+			name = fmt.Sprintf("%s %s", p, p.Region())
+		}
+
+		empty, err := pr.Probe(ctx)
+		if err != nil {
+			logger.Log("msg", "could not probe the provider", "provider", name, "error", err)
+			fmt.Fprintf(out, "Could not probe %s, all of it is imported: %s\n", name, err)
+			res = append(res, p)
+			continue
+		}
+
+		if empty && i != 0 {
+			logger.Log("msg", "skipping the empty provider", "provider", name)
+			fmt.Fprintf(out, "Skipping %s as it has no resources\n", name)
+			continue
+		}
+
+		res = append(res, p)
+	}
+
+	return res
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/cycloidio/terracognita/filter"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -43,3 +44,75 @@ type Provider interface {
 	// tags on the cloud provider
 	TagKey() string
 }
+
+// Aliaser is an optional interface of the Provider for the
+// ones configured with an alias, used when multiple providers
+// of the same type (ex: regions) are imported at once
+type Aliaser interface {
+	// Alias returns the alias of the provider
+	// (ex: us_east_1), empty if it has none
+	Alias() string
+}
+
+// SharedAliaser is an optional interface of the Provider for the
+// ones that write the resources shared with them (ex: via RAM on
+// AWS) as data sources with an aliased provider
+type SharedAliaser interface {
+	// SharedAlias returns the alias of the provider of
+	// the shared resources, empty if it has none
+	SharedAlias() string
+}
+
+// Normalizer is implemented by the Providers which resources have
+// more than one representation (ex: the rules inline on the security
+// groups or as their own resources) so only the canonical one is
+// written and the configuration does not fight itself at plan time
+type Normalizer interface {
+	// SkippedTypes returns the types of the resources
+	// not written as those are inline on others
+	SkippedTypes() []string
+
+	// InlineAttributes returns the attributes, with the
+	// format TYPE.ATTRIBUTE (see NewExcludeWriter), removed
+	// from the HCL as those are written as their own resources
+	InlineAttributes() []string
+}
+
+// Prober is implemented by the Providers that can detect with a
+// few requests the scopes of them (ex: regions or zones) without
+// resources, so those are skipped instead of listing each type
+type Prober interface {
+	// Probe detects the empty scopes of the Provider, which
+	// are not listed after, and returns true if all of it is
+	// empty so the Provider can be skipped
+	Probe(ctx context.Context) (bool, error)
+}
+
+// Janitor is implemented by the Providers which can detect the
+// resources that look unused (ex: volumes not attached), which
+// are reported as candidates to be cleaned up
+type Janitor interface {
+	// UnusedReason returns why the resource of type t with
+	// the id, and the attributes returned by the get, looks
+	// unused, empty if it does not
+	UnusedReason(t, id string, get func(string) string) string
+}
+
+// Consoler is implemented by the Providers which
+// resources can be linked to the console of the cloud
+type Consoler interface {
+	// ConsoleURL returns the URL of the resource of type t with
+	// the id, and the attributes returned by the get, on the
+	// console, empty if the type has none
+	ConsoleURL(t, id string, get func(string) string) string
+}
+
+// ProviderAlias returns the p with the alias (ex: aws.us_east_1)
+// if it's an Aliaser with an alias, if not it's empty
+func ProviderAlias(p Provider) string {
+	a, ok := p.(Aliaser)
+	if !ok || a.Alias() == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s.%s", p.String(), a.Alias())
+}
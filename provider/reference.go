@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// Referencer is an optional interface of the Provider for the
+// ones which resources have attributes referencing other resources
+type Referencer interface {
+	// References returns the attributes which value is the ID of
+	// other resource with the types it can be, or the type and
+	// the attribute (ex: aws_route53_record.fqdn) if the value
+	// is not the ID
+	References() map[string][]string
+}
+
+// referenceWriter replaces on the configurations written to it the
+// IDs of the already written resources with an interpolation to them
+type referenceWriter struct {
+	writer.Writer
+
+	// attributes are the attributes
+	// with the types they reference
+	attributes map[string][]string
+
+	// addresses has the addresses of the resources
+	// on the config by reference and value of it
+	addresses map[string]map[string]string
+
+	// stack returns the stack of the addresses, only
+	// the ones on the same stack are referenced
+	stack func(string) string
+
+	// deferred keeps the configurations written until the Sync,
+	// when the references are replaced, so the resources can
+	// also reference the ones written after them
+	deferred bool
+	pending  []pendingWrite
+	keys     map[string]struct{}
+}
+
+// pendingWrite is a Write deferred to the Sync
+type pendingWrite struct {
+	key   string
+	value interface{}
+}
+
+// newReferenceWriter returns a referenceWriter that writes to w
+// replacing the values of the p References if it's a Referencer,
+// if not the w is returned with false. If the stack is not nil
+// only the resources on the same stack are referenced
+func newReferenceWriter(w writer.Writer, p Provider, stack func(string) string) (*referenceWriter, bool) {
+	rp, ok := p.(Referencer)
+	if !ok {
+		return nil, false
+	}
+
+	return &referenceWriter{
+		Writer:     w,
+		attributes: rp.References(),
+		addresses:  make(map[string]map[string]string),
+		stack:      stack,
+	}, true
+}
+
+// add makes the r available to be referenced
+// by the next configurations written
+func (w *referenceWriter) add(r Resource) {
+	// Only the resources with address can
+	// be referenced as it's the name
+	// they have on the config
+	res, ok := r.(*resource)
+	if !ok || res.configName == "" {
+		return
+	}
+
+	address := fmt.Sprintf("%s.%s", res.resourceType, res.configName)
+	if res.dataSource {
+		address = fmt.Sprintf("data.%s", address)
+	}
+
+	for _, ref := range w.resourceReferences(res.resourceType) {
+		_, attr := splitReference(ref)
+
+		v := res.id
+		if attr != "id" {
+			if res.data == nil {
+				continue
+			}
+			v, _ = res.data.Get(attr).(string)
+		}
+		if v == "" {
+			continue
+		}
+
+		if _, ok := w.addresses[ref]; !ok {
+			w.addresses[ref] = make(map[string]string)
+		}
+		w.addresses[ref][referenceValue(v)] = address
+	}
+}
+
+// resourceReferences returns the unique
+// references to the resources of type t
+func (w *referenceWriter) resourceReferences(t string) []string {
+	res := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, refs := range w.attributes {
+		for _, ref := range refs {
+			if rt, _ := splitReference(ref); rt != t {
+				continue
+			}
+			if _, ok := seen[ref]; ok {
+				continue
+			}
+			seen[ref] = struct{}{}
+			res = append(res, ref)
+		}
+	}
+	return res
+}
+
+// Write replaces the references of the value
+// to other resources and writes it
+func (w *referenceWriter) Write(key string, value interface{}) error {
+	if w.deferred {
+		if w.keys == nil {
+			w.keys = make(map[string]struct{})
+		}
+		w.pending = append(w.pending, pendingWrite{key: key, value: value})
+		w.keys[key] = struct{}{}
+		return nil
+	}
+
+	if cfg, ok := value.(map[string]interface{}); ok {
+		w.replace(key, cfg)
+	}
+
+	return w.Writer.Write(key, value)
+}
+
+// Has checks if the key is written or deferred
+func (w *referenceWriter) Has(key string) (bool, error) {
+	if _, ok := w.keys[key]; ok {
+		return true, nil
+	}
+	return w.Writer.Has(key)
+}
+
+// Sync writes the deferred configurations, with the
+// references replaced, and syncs the writer
+func (w *referenceWriter) Sync() error {
+	pending := w.pending
+	w.deferred, w.pending, w.keys = false, nil, nil
+
+	for _, pw := range pending {
+		if err := w.Write(pw.key, pw.value); err != nil {
+			return err
+		}
+	}
+
+	return w.Writer.Sync()
+}
+
+// replace replaces the references of the cfg of
+// the key and the ones of the nested blocks of it
+func (w *referenceWriter) replace(key string, cfg map[string]interface{}) {
+	for k, v := range cfg {
+		if refs, ok := w.attributes[k]; ok {
+			cfg[k] = w.interpolate(key, refs, v)
+			continue
+		}
+
+		switch vv := v.(type) {
+		case map[string]interface{}:
+			w.replace(key, vv)
+		case []interface{}:
+			for _, e := range vv {
+				if m, ok := e.(map[string]interface{}); ok {
+					w.replace(key, m)
+				}
+			}
+		}
+	}
+}
+
+// interpolate returns the v, or the elements of it if it's a list,
+// as interpolations if those are values of any of the refs
+// on the same stack of the key
+func (w *referenceWriter) interpolate(key string, refs []string, v interface{}) interface{} {
+	switch vv := v.(type) {
+	case string:
+		for _, ref := range refs {
+			if address, ok := w.addresses[ref][referenceValue(vv)]; ok {
+				if w.stack != nil && w.stack(address) != w.stack(key) {
+					continue
+				}
+				_, attr := splitReference(ref)
+				return fmt.Sprintf("${%s.%s}", address, attr)
+			}
+		}
+	case []interface{}:
+		res := make([]interface{}, 0, len(vv))
+		for _, e := range vv {
+			res = append(res, w.interpolate(key, refs, e))
+		}
+		return res
+	}
+
+	return v
+}
+
+// splitReference returns the type and
+// the attribute referenced by ref
+func splitReference(ref string) (string, string) {
+	refs := strings.SplitN(ref, ".", 2)
+	if len(refs) == 1 {
+		return refs[0], "id"
+	}
+	return refs[0], refs[1]
+}
+
+// googleAPIPrefix is the prefix of the Google self links,
+// which on some attributes are relative without it
+const googleAPIPrefix = "https://www.googleapis.com/compute/v1/"
+
+// referenceValue normalizes the v so the DNS names match with
+// or without the trailing dot and the self links being relative
+func referenceValue(v string) string {
+	return strings.TrimPrefix(strings.TrimSuffix(strings.ToLower(v), "."), googleAPIPrefix)
+}
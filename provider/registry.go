@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/filter"
+)
+
+// ReaderFunc returns the resources of the type t of the p,
+// usually initialized with NewResource(id, t, p)
+type ReaderFunc func(ctx context.Context, p Provider, t string, f *filter.Filter) ([]Resource, error)
+
+var (
+	readersMu sync.RWMutex
+	readers   = make(map[string]ReaderFunc)
+)
+
+// RegisterResource registers the fn as the reader of the resources of the
+// type t, so the types not supported by a provider can be imported when
+// Terracognita is used as a library. The provider of the t is the prefix of it
+// (ex: aws for aws_foo), and the t has to be on the schema of the Terraform
+// provider of it. The registered readers have precedence over the ones of the
+// providers, so they can also replace them
+func RegisterResource(t string, fn ReaderFunc) error {
+	if !strings.Contains(t, "_") {
+		return errors.Wrapf(errcode.ErrProviderResourceNotSupported, "invalid type %q, it has to be prefixed with the provider (ex: aws_foo)", t)
+	}
+
+	if fn == nil {
+		return errors.Errorf("the reader of %q is required", t)
+	}
+
+	readersMu.Lock()
+	defer readersMu.Unlock()
+
+	if _, ok := readers[t]; ok {
+		return errors.Errorf("the reader of %q is already registered", t)
+	}
+	readers[t] = fn
+
+	return nil
+}
+
+// RegisteredResource returns the ReaderFunc registered for the t
+func RegisteredResource(t string) (ReaderFunc, bool) {
+	readersMu.RLock()
+	defer readersMu.RUnlock()
+
+	fn, ok := readers[t]
+	return fn, ok
+}
+
+// RegisteredResourceTypes returns the types registered
+// for the provider p (ex: aws) sorted alphabetically
+func RegisteredResourceTypes(p string) []string {
+	readersMu.RLock()
+	defer readersMu.RUnlock()
+
+	types := make([]string, 0)
+	for t := range readers {
+		if strings.HasPrefix(t, p+"_") {
+			types = append(types, t)
+		}
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+// WithRegisteredResourceTypes returns the types with the ones
+// registered for the provider p that are not on them
+func WithRegisteredResourceTypes(p string, types []string) []string {
+	registered := RegisteredResourceTypes(p)
+	if len(registered) == 0 {
+		return types
+	}
+
+	res := append(make([]string, 0, len(types)+len(registered)), types...)
+	for _, t := range registered {
+		if !hasType(res, t) {
+			res = append(res, t)
+		}
+	}
+
+	return res
+}
+
+// UnregisterResource removes the reader registered for the t
+func UnregisterResource(t string) {
+	readersMu.Lock()
+	defer readersMu.Unlock()
+
+	delete(readers, t)
+}
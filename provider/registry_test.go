@@ -0,0 +1,51 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+func TestRegisterResource(t *testing.T) {
+	fn := func(ctx context.Context, p provider.Provider, t string, f *filter.Filter) ([]provider.Resource, error) {
+		return nil, nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		require.NoError(t, provider.RegisterResource("aws_foo", fn))
+		require.NoError(t, provider.RegisterResource("aws_bar", fn))
+		require.NoError(t, provider.RegisterResource("google_foo", fn))
+		defer provider.UnregisterResource("aws_foo")
+		defer provider.UnregisterResource("aws_bar")
+		defer provider.UnregisterResource("google_foo")
+
+		_, ok := provider.RegisteredResource("aws_foo")
+		assert.True(t, ok)
+		_, ok = provider.RegisteredResource("aws_instance")
+		assert.False(t, ok)
+
+		assert.Equal(t, []string{"aws_bar", "aws_foo"}, provider.RegisteredResourceTypes("aws"))
+		assert.Equal(t, []string{"aws_instance", "aws_foo", "aws_bar"}, provider.WithRegisteredResourceTypes("aws", []string{"aws_instance", "aws_foo"}))
+		assert.Equal(t, []string{"aws_instance"}, provider.WithRegisteredResourceTypes("azurerm", []string{"aws_instance"}))
+	})
+
+	t.Run("ErrorAlreadyRegistered", func(t *testing.T) {
+		require.NoError(t, provider.RegisterResource("aws_foo", fn))
+		defer provider.UnregisterResource("aws_foo")
+
+		assert.Error(t, provider.RegisterResource("aws_foo", fn))
+	})
+
+	t.Run("ErrorInvalidType", func(t *testing.T) {
+		assert.Error(t, provider.RegisterResource("foo", fn))
+	})
+
+	t.Run("ErrorRequiredReader", func(t *testing.T) {
+		assert.Error(t, provider.RegisterResource("aws_foo", nil))
+	})
+}
@@ -0,0 +1,377 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// The ImportOptions.Resolve modes
+const (
+	// ResolveComment writes a comment on the resources
+	// with what each one of the resolved IDs is
+	ResolveComment = "comment"
+
+	// ResolveData replaces the resolved IDs with a
+	// reference to a data source that looks them up,
+	// the ones without data source are commented
+	ResolveData = "data"
+)
+
+// Resolution is what a raw ID of an attribute is
+// (ex: the name of an AMI) and, optionally, the
+// data source that looks it up
+type Resolution struct {
+	// Comment describes the ID (ex: the AMI ubuntu-focal)
+	Comment string
+
+	// DataType and DataName are the type and the name of the
+	// data source and Data the configuration of it, the ID is
+	// the DataAttribute of it (ex: aws_ami, ubuntu_focal,
+	// {"most_recent": true, ...} and id)
+	DataType      string
+	DataName      string
+	Data          map[string]interface{}
+	DataAttribute string
+}
+
+// ResolverFunc returns the Resolution of the id on the attribute
+// attr of the resources of the p, nil if it can not be resolved
+type ResolverFunc func(ctx context.Context, p Provider, attr, id string) (*Resolution, error)
+
+// Resolver is an optional interface of the Provider for the ones with
+// built-in resolvers of the IDs of the attributes of their resources
+type Resolver interface {
+	// Resolvers returns the ResolverFunc of the
+	// attributes (ex: ami) by the name of them
+	Resolvers() map[string]ResolverFunc
+}
+
+var (
+	resolversMu         sync.RWMutex
+	registeredResolvers = make(map[string]map[string]ResolverFunc)
+)
+
+// RegisterResolver registers the fn as the resolver of the IDs of the
+// attribute attr (ex: ami) of the resources of the provider p (ex: aws),
+// used when the ImportOptions.Resolve is set. The attributes are matched
+// on any block of the resources. The registered resolvers have precedence
+// over the ones of the Resolver providers, so they can also replace them
+func RegisterResolver(p, attr string, fn ResolverFunc) error {
+	if p == "" || attr == "" {
+		return errors.Errorf("the provider and the attribute of the resolver are required")
+	}
+
+	if fn == nil {
+		return errors.Errorf("the resolver of %s %q is required", p, attr)
+	}
+
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+
+	if _, ok := registeredResolvers[p][attr]; ok {
+		return errors.Errorf("the resolver of %s %q is already registered", p, attr)
+	}
+	if _, ok := registeredResolvers[p]; !ok {
+		registeredResolvers[p] = make(map[string]ResolverFunc)
+	}
+	registeredResolvers[p][attr] = fn
+
+	return nil
+}
+
+// RegisteredResolver returns the ResolverFunc registered
+// for the attr of the provider p
+func RegisteredResolver(p, attr string) (ResolverFunc, bool) {
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+
+	fn, ok := registeredResolvers[p][attr]
+	return fn, ok
+}
+
+// RegisteredResolverAttributes returns the attributes with a
+// resolver registered for the provider p sorted alphabetically
+func RegisteredResolverAttributes(p string) []string {
+	resolversMu.RLock()
+	defer resolversMu.RUnlock()
+
+	attrs := make([]string, 0, len(registeredResolvers[p]))
+	for a := range registeredResolvers[p] {
+		attrs = append(attrs, a)
+	}
+	sort.Strings(attrs)
+
+	return attrs
+}
+
+// UnregisterResolver removes the resolver
+// registered for the attr of the provider p
+func UnregisterResolver(p, attr string) {
+	resolversMu.Lock()
+	defer resolversMu.Unlock()
+
+	delete(registeredResolvers[p], attr)
+}
+
+// invalidNameRe matches the characters
+// not valid on the names of the blocks
+var invalidNameRe = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+// resolveWriter resolves the IDs of the configurations
+// written to it with the registered resolvers
+type resolveWriter struct {
+	writer.Writer
+
+	ctx    context.Context
+	mode   string
+	logger kitlog.Logger
+
+	// providers are the providers by the
+	// alias, the one without alias is "",
+	// and resolvers the ones of them
+	providers map[string]Provider
+	resolvers map[string]ResolverFunc
+
+	// resolutions are the already resolved by
+	// 'ALIAS ATTRIBUTE ID', nil if it could not be,
+	// and data the keys of the data sources written
+	// by the name of them with the 'ALIAS ID'
+	mu          sync.Mutex
+	resolutions map[string]*Resolution
+	data        map[string]string
+	comments    map[string]string
+}
+
+// newResolveWriter returns a resolveWriter that writes to w with the
+// mode and the resolvers of the ps, the ones of the Resolver and the
+// registered for them, if those have none the w is returned with false
+func newResolveWriter(ctx context.Context, w writer.Writer, ps []Provider, mode string, logger kitlog.Logger) (*resolveWriter, bool) {
+	resolvers := make(map[string]ResolverFunc)
+	if r, ok := ps[0].(Resolver); ok {
+		for a, fn := range r.Resolvers() {
+			resolvers[a] = fn
+		}
+	}
+	for _, a := range RegisteredResolverAttributes(ps[0].String()) {
+		resolvers[a], _ = RegisteredResolver(ps[0].String(), a)
+	}
+	if len(resolvers) == 0 {
+		return nil, false
+	}
+
+	providers := make(map[string]Provider)
+	for _, p := range ps {
+		providers[ProviderAlias(p)] = p
+	}
+
+	return &resolveWriter{
+		Writer:      w,
+		ctx:         ctx,
+		mode:        mode,
+		logger:      logger,
+		providers:   providers,
+		resolvers:   resolvers,
+		resolutions: make(map[string]*Resolution),
+		data:        make(map[string]string),
+		comments:    make(map[string]string),
+	}, true
+}
+
+// Write resolves the IDs of the value and writes it with a comment of
+// them, or the data sources of them before it, and merges the comments
+// written for it (ex: the annotations) with the one of the IDs
+func (w *resolveWriter) Write(key string, value interface{}) error {
+	if strings.HasPrefix(key, "comment.") {
+		w.mu.Lock()
+		cmt, ok := w.comments[strings.TrimPrefix(key, "comment.")]
+		w.mu.Unlock()
+
+		if s, isStr := value.(string); isStr && ok {
+			value = s + "\n" + cmt
+		}
+		return w.Writer.Write(key, value)
+	}
+
+	cfg, ok := value.(map[string]interface{})
+	keys := strings.Split(key, ".")
+	if !ok || len(keys) != 2 || keys[0] == "variable" || keys[0] == "module" {
+		return w.Writer.Write(key, value)
+	}
+
+	alias, _ := cfg["provider"].(string)
+	p, ok := w.providers[alias]
+	if !ok {
+		return w.Writer.Write(key, value)
+	}
+
+	lines := make([]string, 0)
+	if err := w.resolve(p, alias, cfg, &lines); err != nil {
+		return err
+	}
+
+	if err := w.Writer.Write(key, cfg); err != nil {
+		return err
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	sort.Strings(lines)
+	cmt := strings.Join(lines, "\n")
+
+	w.mu.Lock()
+	w.comments[key] = cmt
+	w.mu.Unlock()
+
+	return w.Writer.Write("comment."+key, cmt)
+}
+
+// resolve resolves the IDs of the attributes of the cfg, and the
+// blocks of it, with a resolver and adds the comments to the lines,
+// on the ResolveData mode the IDs are replaced with the data sources
+func (w *resolveWriter) resolve(p Provider, alias string, cfg map[string]interface{}, lines *[]string) error {
+	for k, v := range cfg {
+		// The maps (ex: tags) are not
+		// attributes of the resource
+		if strings.HasPrefix(k, "=tc=") {
+			continue
+		}
+
+		switch vv := v.(type) {
+		case map[string]interface{}:
+			if err := w.resolve(p, alias, vv, lines); err != nil {
+				return err
+			}
+		case []interface{}:
+			for i, e := range vv {
+				switch ee := e.(type) {
+				case map[string]interface{}:
+					if err := w.resolve(p, alias, ee, lines); err != nil {
+						return err
+					}
+				case string:
+					nv, err := w.resolveID(p, alias, k, ee, lines)
+					if err != nil {
+						return err
+					}
+					vv[i] = nv
+				}
+			}
+		case string:
+			nv, err := w.resolveID(p, alias, k, vv, lines)
+			if err != nil {
+				return err
+			}
+			cfg[k] = nv
+		}
+	}
+
+	return nil
+}
+
+// resolveID returns the value of the id of the attr, the reference to the
+// data source of it if it's resolved with one on the ResolveData mode and
+// the id if not, and adds the comment of the resolution to the lines
+func (w *resolveWriter) resolveID(p Provider, alias, attr, id string, lines *[]string) (string, error) {
+	fn, ok := w.resolvers[attr]
+	if !ok || id == "" || strings.Contains(id, "${") {
+		return id, nil
+	}
+
+	rkey := fmt.Sprintf("%s %s %s", alias, attr, id)
+
+	w.mu.Lock()
+	res, ok := w.resolutions[rkey]
+	w.mu.Unlock()
+
+	if !ok {
+		var err error
+		res, err = fn(w.ctx, p, attr, id)
+		if err != nil {
+			// It's only to make the configuration
+			// more readable so it's not an error
+			w.logger.Log("msg", "could not resolve the ID", "attribute", attr, "id", id, "error", err)
+			res = nil
+		}
+
+		w.mu.Lock()
+		w.resolutions[rkey] = res
+		w.mu.Unlock()
+	}
+
+	if res == nil {
+		return id, nil
+	}
+
+	if w.mode != ResolveData || res.DataType == "" || res.DataName == "" {
+		if res.Comment != "" {
+			*lines = append(*lines, fmt.Sprintf("%s %s is %s", attr, id, res.Comment))
+		}
+		return id, nil
+	}
+
+	dkey, err := w.writeData(alias, id, res)
+	if err != nil {
+		return "", err
+	}
+
+	if res.Comment != "" {
+		*lines = append(*lines, fmt.Sprintf("%s is %s", attr, res.Comment))
+	}
+
+	return fmt.Sprintf("${%s.%s}", dkey, res.DataAttribute), nil
+}
+
+// writeData writes the data source of the res, if it's not already
+// written, for the id and returns the key of it. The data sources
+// with the same name and different IDs are suffixed with a number
+func (w *resolveWriter) writeData(alias, id string, res *Resolution) (string, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	did := fmt.Sprintf("%s %s", alias, id)
+
+	name := invalidNameRe.ReplaceAllString(res.DataName, "_")
+	if name[0] == '-' || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	if alias != "" {
+		name = fmt.Sprintf("%s_%s", name, alias[strings.Index(alias, ".")+1:])
+	}
+
+	dname := name
+	for i := 2; ; i++ {
+		dkey := fmt.Sprintf("data.%s.%s", res.DataType, dname)
+		written, ok := w.data[dkey]
+		if ok && written == did {
+			return dkey, nil
+		}
+		if !ok {
+			cfg := make(map[string]interface{}, len(res.Data)+1)
+			for k, v := range res.Data {
+				cfg[k] = v
+			}
+			if alias != "" {
+				cfg["provider"] = alias
+			}
+
+			if err := w.Writer.Write(dkey, cfg); err != nil {
+				return "", err
+			}
+			w.data[dkey] = did
+
+			return dkey, nil
+		}
+		dname = fmt.Sprintf("%s_%d", name, i)
+	}
+}
@@ -0,0 +1,210 @@
+package provider_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+func TestRegisterResolver(t *testing.T) {
+	fn := func(ctx context.Context, p provider.Provider, attr, id string) (*provider.Resolution, error) {
+		return nil, nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		require.NoError(t, provider.RegisterResolver("aws", "ami", fn))
+		require.NoError(t, provider.RegisterResolver("aws", "kms_key_id", fn))
+		require.NoError(t, provider.RegisterResolver("google", "image", fn))
+		defer provider.UnregisterResolver("aws", "ami")
+		defer provider.UnregisterResolver("aws", "kms_key_id")
+		defer provider.UnregisterResolver("google", "image")
+
+		_, ok := provider.RegisteredResolver("aws", "ami")
+		assert.True(t, ok)
+		_, ok = provider.RegisteredResolver("google", "ami")
+		assert.False(t, ok)
+
+		assert.Equal(t, []string{"ami", "kms_key_id"}, provider.RegisteredResolverAttributes("aws"))
+		assert.Equal(t, []string{}, provider.RegisteredResolverAttributes("azurerm"))
+	})
+
+	t.Run("ErrorAlreadyRegistered", func(t *testing.T) {
+		require.NoError(t, provider.RegisterResolver("aws", "ami", fn))
+		defer provider.UnregisterResolver("aws", "ami")
+
+		assert.Error(t, provider.RegisterResolver("aws", "ami", fn))
+	})
+
+	t.Run("ErrorRequiredAttribute", func(t *testing.T) {
+		assert.Error(t, provider.RegisterResolver("aws", "", fn))
+	})
+
+	t.Run("ErrorRequiredResolver", func(t *testing.T) {
+		assert.Error(t, provider.RegisterResolver("aws", "ami", nil))
+	})
+}
+
+func TestImportWithResolve(t *testing.T) {
+	resolveAMI := func(ctx context.Context, p provider.Provider, attr, id string) (*provider.Resolution, error) {
+		if id != "ami-1" {
+			return nil, nil
+		}
+		return &provider.Resolution{
+			Comment:       "the AMI ubuntu/focal",
+			DataType:      "aws_ami",
+			DataName:      "ubuntu/focal",
+			Data:          map[string]interface{}{"name_regex": "ubuntu/focal"},
+			DataAttribute: "id",
+		}, nil
+	}
+
+	// importInstances imports 2 aws_instance with the
+	// AMIs ami-1 and ami-2 with the Resolve mode
+	importInstances := func(t *testing.T, ctrl *gomock.Controller, hw writer.Writer, mode string) {
+		var (
+			ctx = context.Background()
+
+			p         = &resolvedProvider{Provider: mock.NewProvider(ctrl), resolvers: map[string]provider.ResolverFunc{"ami": resolveAMI}}
+			sw        = mock.NewWriter(ctrl)
+			instance1 = mock.NewResource(ctrl)
+			instance2 = mock.NewResource(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+		p.EXPECT().String().Return("aws").AnyTimes()
+		p.EXPECT().Resources(ctx, "aws_instance", f).Return([]provider.Resource{instance1, instance2}, nil)
+
+		instance1.EXPECT().ID().Return("i-1").AnyTimes()
+		instance2.EXPECT().ID().Return("i-2").AnyTimes()
+
+		instance1.EXPECT().ImportState().Return(nil, nil)
+		instance2.EXPECT().ImportState().Return(nil, nil)
+
+		instance1.EXPECT().Read(f).Return(nil)
+		instance2.EXPECT().Read(f).Return(nil)
+
+		instance1.EXPECT().HCL(gomock.Any()).DoAndReturn(func(w writer.Writer) error {
+			return w.Write("aws_instance.front", map[string]interface{}{
+				"ami":               "ami-1",
+				"root_block_device": []interface{}{map[string]interface{}{"volume_size": 8}},
+			})
+		})
+		instance2.EXPECT().HCL(gomock.Any()).DoAndReturn(func(w writer.Writer) error {
+			return w.Write("aws_instance.back", map[string]interface{}{
+				"ami": "ami-2",
+			})
+		})
+
+		instance1.EXPECT().State(sw).Return(nil)
+		instance2.EXPECT().State(sw).Return(nil)
+
+		sw.EXPECT().Sync().Return(nil)
+
+		err := provider.ImportProviders(ctx, []provider.Provider{p}, hw, sw, f, provider.ImportOptions{Resolve: mode}, ioutil.Discard)
+		require.NoError(t, err)
+	}
+
+	t.Run("SuccessWithComment", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			hw   = mock.NewWriter(ctrl)
+		)
+
+		defer ctrl.Finish()
+
+		hw.EXPECT().Write("aws_instance.front", map[string]interface{}{
+			"ami":               "ami-1",
+			"root_block_device": []interface{}{map[string]interface{}{"volume_size": 8}},
+		}).Return(nil)
+		hw.EXPECT().Write("comment.aws_instance.front", "ami ami-1 is the AMI ubuntu/focal").Return(nil)
+		hw.EXPECT().Write("aws_instance.back", map[string]interface{}{"ami": "ami-2"}).Return(nil)
+		hw.EXPECT().Sync().Return(nil)
+
+		importInstances(t, ctrl, hw, provider.ResolveComment)
+	})
+
+	t.Run("SuccessWithData", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			hw   = mock.NewWriter(ctrl)
+		)
+
+		defer ctrl.Finish()
+
+		gomock.InOrder(
+			hw.EXPECT().Write("data.aws_ami.ubuntu_focal", map[string]interface{}{"name_regex": "ubuntu/focal"}).Return(nil),
+			hw.EXPECT().Write("aws_instance.front", map[string]interface{}{
+				"ami":               "${data.aws_ami.ubuntu_focal.id}",
+				"root_block_device": []interface{}{map[string]interface{}{"volume_size": 8}},
+			}).Return(nil),
+			hw.EXPECT().Write("comment.aws_instance.front", "ami is the AMI ubuntu/focal").Return(nil),
+		)
+		hw.EXPECT().Write("aws_instance.back", map[string]interface{}{"ami": "ami-2"}).Return(nil)
+		hw.EXPECT().Sync().Return(nil)
+
+		importInstances(t, ctrl, hw, provider.ResolveData)
+	})
+
+	t.Run("SuccessWithRegisteredResolver", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			hw   = mock.NewWriter(ctrl)
+		)
+
+		defer ctrl.Finish()
+
+		require.NoError(t, provider.RegisterResolver("aws", "ami", func(ctx context.Context, p provider.Provider, attr, id string) (*provider.Resolution, error) {
+			return &provider.Resolution{Comment: "a custom AMI"}, nil
+		}))
+		defer provider.UnregisterResolver("aws", "ami")
+
+		hw.EXPECT().Write("aws_instance.front", gomock.Any()).Return(nil)
+		hw.EXPECT().Write("comment.aws_instance.front", "ami ami-1 is a custom AMI").Return(nil)
+		hw.EXPECT().Write("aws_instance.back", gomock.Any()).Return(nil)
+		hw.EXPECT().Write("comment.aws_instance.back", "ami ami-2 is a custom AMI").Return(nil)
+		hw.EXPECT().Sync().Return(nil)
+
+		importInstances(t, ctrl, hw, provider.ResolveData)
+	})
+
+	t.Run("ErrorWithInvalidMode", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			ctx  = context.Background()
+
+			p  = &resolvedProvider{Provider: mock.NewProvider(ctrl)}
+			hw = mock.NewWriter(ctrl)
+			sw = mock.NewWriter(ctrl)
+
+			f = &filter.Filter{}
+		)
+
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+
+		err := provider.ImportProviders(ctx, []provider.Provider{p}, hw, sw, f, provider.ImportOptions{Resolve: "foo"}, ioutil.Discard)
+		assert.Error(t, err)
+	})
+}
+
+// resolvedProvider is a mock.Provider
+// that implements the provider.Resolver
+type resolvedProvider struct {
+	*mock.Provider
+
+	resolvers map[string]provider.ResolverFunc
+}
+
+func (p *resolvedProvider) Resolvers() map[string]provider.ResolverFunc { return p.resolvers }
@@ -1,10 +1,12 @@
 package provider
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -96,6 +98,22 @@ type resource struct {
 	// and State
 	configName string
 
+	// dataSource defines if the Resource has to be
+	// written as a data source instead of a resource,
+	// used for the ones not owned by the account
+	dataSource bool
+
+	// providerAlias is the provider used by the
+	// data source, ex: aws.shared
+	providerAlias string
+
+	// namePrefix is the prefix of the configName
+	namePrefix string
+
+	// skipState skips building the resourceInstanceObject
+	// on the Read, as it's only needed by the TFState
+	skipState bool
+
 	resourceInstanceObject *states.ResourceInstanceObject
 }
 
@@ -105,6 +123,10 @@ var (
 
 // NewResource returns an implementation of the Resource
 func NewResource(id, rt string, p Provider) Resource {
+	// The deprecated types are replaced so the
+	// HCL and TFState have the new ones
+	rt, _ = TypeAlias(p, rt)
+
 	return &resource{
 		id:           id,
 		resourceType: rt,
@@ -112,6 +134,20 @@ func NewResource(id, rt string, p Provider) Resource {
 	}
 }
 
+// NewDataResource returns an implementation of the Resource that
+// is written to the HCL as a data source, using the providerAlias
+// if defined, and it's not written to the TFState. It's meant for
+// the resources that are used but not owned, like shared ones
+func NewDataResource(id, rt string, p Provider, providerAlias string) Resource {
+	return &resource{
+		id:            id,
+		resourceType:  rt,
+		provider:      p,
+		dataSource:    true,
+		providerAlias: providerAlias,
+	}
+}
+
 func (r *resource) ID() string { return r.id }
 
 func (r *resource) Type() string { return r.resourceType }
@@ -182,6 +218,8 @@ func (r *resource) Read(f *filter.Filter) error {
 	// r.provider.TFProvider().RefreshWithoutUpgrade()
 	// but that way we would not have the data and we need
 	// it to generate the HCL
+	seeded := r.seedUserData(r.state)
+
 	newInstanceState, data, err := r.refreshWithoutUpgrade(r.state, r.provider.TFClient())
 	if err != nil {
 		return err
@@ -197,6 +235,12 @@ func (r *resource) Read(f *filter.Filter) error {
 
 	r.data = data
 
+	if seeded {
+		if err := r.cleanUserDataSeed(newInstanceState); err != nil {
+			return err
+		}
+	}
+
 	// Some resources can not be filtered by tags,
 	// so we have to do it manually
 	// it's not all of them though
@@ -215,6 +259,14 @@ func (r *resource) Read(f *filter.Filter) error {
 		}
 	}
 
+	if err := r.removeSensitiveValues(newInstanceState); err != nil {
+		return err
+	}
+
+	if r.skipState {
+		return nil
+	}
+
 	// helper/schema should always copy the ID over, but do it again just to be safe
 	newInstanceState.Attributes["id"] = newInstanceState.ID
 
@@ -340,15 +392,21 @@ func (r *resource) refreshWithoutUpgrade(s *terraform.InstanceState, meta interf
 // State calculates the state of the Resource and
 // writes it to w
 func (r *resource) State(w writer.Writer) error {
+	// The data sources are read by TF
+	// so they have no state
+	if r.dataSource {
+		return nil
+	}
+
 	if importer := r.tfResource.Importer; importer != nil {
 		// If it does not have any configName we will generate one
 		// and store it, so net time it'll use that one on any config
 		if r.configName == "" {
-			configName := tag.GetNameFromTag(r.provider.TagKey(), r.data, r.id)
+			configName := r.namePrefix + tag.GetNameFromTag(r.provider.TagKey(), r.data, r.id)
 			if ok, err := w.Has(configName); err != nil {
 				return err
 			} else if ok {
-				configName = pwgen.Alpha(5)
+				configName = r.namePrefix + pwgen.Alpha(5)
 			}
 
 			err := w.Write(fmt.Sprintf("%s.%s", r.resourceType, configName), r)
@@ -372,34 +430,108 @@ func (r *resource) State(w writer.Writer) error {
 // HCL returns the HCL configuration of the Resource and
 // writes it to HCL
 func (r *resource) HCL(w writer.Writer) error {
+	if r.dataSource {
+		return r.dataSourceHCL(w)
+	}
+
 	cfg := mergeFullConfig(r.data, r.tfResource.Schema, "")
 
 	// If it does not have any configName we will generate one
 	// and store it, so net time it'll use that one on any config
-	if r.configName == "" {
-		configName := fmt.Sprintf("%s.%s", r.resourceType, tag.GetNameFromTag(r.provider.TagKey(), r.data, r.id))
+	configName := r.configName
+	if configName == "" {
+		configName = fmt.Sprintf("%s.%s%s", r.resourceType, r.namePrefix, tag.GetNameFromTag(r.provider.TagKey(), r.data, r.id))
 		if ok, err := w.Has(configName); err != nil {
 			return err
 		} else if ok {
-			configName = pwgen.Alpha(5)
+			configName = r.namePrefix + pwgen.Alpha(5)
 		}
+	}
+
+	if alias := ProviderAlias(r.provider); alias != "" {
+		cfg["provider"] = alias
+	}
+
+	vars := sensitiveVariables(r.resourceType, configName, r.tfResource.Schema, cfg)
+
+	err := w.Write(fmt.Sprintf("%s.%s", r.resourceType, configName), cfg)
+	if err != nil {
+		return err
+	}
 
-		err := w.Write(fmt.Sprintf("%s.%s", r.resourceType, configName), cfg)
+	r.configName = configName
+
+	names := make([]string, 0, len(vars))
+	for n := range vars {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		err = w.Write(fmt.Sprintf("variable.%s", n), vars[n])
 		if err != nil {
 			return err
 		}
+	}
 
-		r.configName = configName
-	} else {
-		err := w.Write(fmt.Sprintf("%s.%s", r.resourceType, r.configName), cfg)
-		if err != nil {
+	return nil
+}
+
+// dataSourceHCL writes the Resource as a data
+// source that references it by the ID
+func (r *resource) dataSourceHCL(w writer.Writer) error {
+	if _, ok := r.provider.TFProvider().DataSourcesMap[r.resourceType]; !ok {
+		return errors.Errorf("the data source %s does not exists on TF", r.resourceType)
+	}
+
+	cfg := map[string]interface{}{
+		"id": r.id,
+	}
+
+	if r.providerAlias != "" {
+		cfg["provider"] = r.providerAlias
+	} else if alias := ProviderAlias(r.provider); alias != "" {
+		cfg["provider"] = alias
+	}
+
+	configName := r.configName
+	if configName == "" {
+		configName = r.namePrefix + tag.GetNameFromTag(r.provider.TagKey(), r.data, r.id)
+		if ok, err := w.Has(fmt.Sprintf("data.%s.%s", r.resourceType, configName)); err != nil {
 			return err
+		} else if ok {
+			configName = r.namePrefix + pwgen.Alpha(5)
 		}
 	}
 
+	err := w.Write(fmt.Sprintf("data.%s.%s", r.resourceType, configName), cfg)
+	if err != nil {
+		return err
+	}
+
+	r.configName = configName
+
 	return nil
 }
 
+// SetNamePrefix sets the p as the prefix of the
+// name of the Resource on the HCL and TFState
+func (r *resource) SetNamePrefix(p string) {
+	r.namePrefix = p
+}
+
+// SkipState skips building the state of the Resource on
+// the Read, so the ResourceInstanceObject is nil
+func (r *resource) SkipState() {
+	r.skipState = true
+}
+
+// SetName sets the n as the name of the Resource on
+// the HCL and TFState, without the name prefix
+func (r *resource) SetName(n string) {
+	r.configName = n
+}
+
 func (r *resource) InstanceInfo() *terraform.InstanceInfo {
 	return &terraform.InstanceInfo{
 		Id:   r.id,
@@ -415,6 +547,13 @@ func (r *resource) ResourceInstanceObject() *states.ResourceInstanceObject {
 	return r.resourceInstanceObject
 }
 
+// HCLConfig returns the configuration of the r, the
+// same one that is written to the HCL, with the
+// TF maps keys prefixed with '=tc='
+func HCLConfig(r Resource) map[string]interface{} {
+	return mergeFullConfig(r.Data(), r.TFResource().Schema, "")
+}
+
 // mergeFullConfig creates the key to the map and if it had a value before set it, if
 func mergeFullConfig(cfgr *schema.ResourceData, sch map[string]*schema.Schema, key string) map[string]interface{} {
 	res := make(map[string]interface{})
@@ -522,7 +661,13 @@ func mergeFullConfig(cfgr *schema.ResourceData, sch map[string]*schema.Schema, k
 		if s, ok := vv.(*schema.Set); ok {
 			res[k] = s.List()
 		} else {
-			res[k] = normalizeInterpolation(normalizeValue(vv))
+			if _, ok := multilineAttributes[k]; ok {
+				res[k] = normalizeInterpolation(vv)
+			} else if isJSONAttribute(v, vv) {
+				res[k] = normalizeInterpolation(indentJSON(vv))
+			} else {
+				res[k] = normalizeInterpolation(normalizeValue(vv))
+			}
 		}
 	}
 	return res
@@ -538,6 +683,53 @@ func hasConflict(res map[string]interface{}, keys []string) bool {
 	return false
 }
 
+// multilineAttributes are the attributes that keep
+// the \n on the value, as those are needed or any
+// change on the value is a change on the plan
+var multilineAttributes = map[string]struct{}{
+	"user_data":  struct{}{},
+	"definition": struct{}{},
+}
+
+// isJSONAttribute checks if the v is a JSON document, object or
+// array, of an attribute that the TF provider normalizes before
+// comparing it (StateFunc or DiffSuppressFunc), so it can be
+// indented and written as heredoc without changing the plan
+func isJSONAttribute(sch *schema.Schema, v interface{}) bool {
+	if sch.Type != schema.TypeString || (sch.StateFunc == nil && sch.DiffSuppressFunc == nil) {
+		return false
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") && !strings.HasPrefix(s, "[") {
+		return false
+	}
+
+	return json.Valid([]byte(s))
+}
+
+// indentJSON indents the v if it's a JSON document,
+// if not it's normalized as any other value
+func indentJSON(v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+
+	var b bytes.Buffer
+	if err := json.Indent(&b, []byte(s), "", "  "); err != nil {
+		return normalizeValue(v)
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
 // normalizeValue removes the \n from the value now
 func normalizeValue(v interface{}) interface{} {
 	if s, ok := v.(string); ok {
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// sensitiveAttributes returns the sorted paths of the attributes of the
+// sch which are sensitive (like passwords or tokens) and configurable.
+// The ones of the nested blocks have the names of the blocks separated
+// by '.' (ex: default_action.authenticate_oidc.client_secret)
+func sensitiveAttributes(sch map[string]*schema.Schema) []string {
+	attrs := make([]string, 0)
+	for k, s := range sch {
+		if !isConfig(s) {
+			continue
+		}
+
+		if sr, ok := s.Elem.(*schema.Resource); ok {
+			for _, a := range sensitiveAttributes(sr.Schema) {
+				attrs = append(attrs, k+"."+a)
+			}
+			continue
+		}
+
+		if s.Sensitive {
+			attrs = append(attrs, k)
+		}
+	}
+	sort.Strings(attrs)
+
+	return attrs
+}
+
+// sensitiveVariables replaces on the cfg the values of the sensitive attributes
+// of the sch with a reference to a variable, so they are not written to the HCL,
+// and adds them to the lifecycle.ignore_changes as the cloud providers do not
+// return most of them so they are not on the state.
+// It returns the variables needed by the cfg of the resource rt with the name
+func sensitiveVariables(rt, name string, sch map[string]*schema.Schema, cfg map[string]interface{}) map[string]interface{} {
+	vars := make(map[string]interface{})
+	ignore := make([]string, 0)
+
+	for _, a := range sensitiveAttributes(sch) {
+		sv := sensitiveValue{
+			variable: fmt.Sprintf("%s_%s", rt, name),
+		}
+		sv.replace(sch, cfg, strings.Split(a, "."), func(v sensitiveValue) {
+			vars[v.variable] = map[string]interface{}{
+				"description": fmt.Sprintf("The %s of the %s.%s", v.attribute, rt, name),
+			}
+			ignore = append(ignore, v.ignore)
+		})
+	}
+
+	if len(ignore) != 0 {
+		cfg["lifecycle"] = map[string]interface{}{
+			"ignore_changes": mergeIgnoreChanges(nil, ignore),
+		}
+	}
+
+	return vars
+}
+
+// sensitiveValue is a sensitive attribute
+// replaced with a variable on the HCL
+type sensitiveValue struct {
+	// variable is the name of the variable, with the index
+	// of the blocks if there are more than one (ex:
+	// aws_lb_listener_front_default_action_authenticate_oidc_client_secret)
+	variable string
+
+	// attribute is the path of the attribute with the index of
+	// the blocks (ex: default_action[0].authenticate_oidc[0].client_secret)
+	attribute string
+
+	// ignore is the path added to the ignore_changes, the
+	// attribute or the first set block of it, marked with
+	// the set, as the elements of the sets have no index
+	// to reference them
+	ignore string
+	set    bool
+}
+
+// replace replaces the attribute of the path on the cfg, which has the
+// sch, with a variable. The nested blocks are the first parts of the path
+// and the attribute is replaced on each of them. It calls the fn with the
+// value of each variable
+func (sv sensitiveValue) replace(sch map[string]*schema.Schema, cfg map[string]interface{}, path []string, fn func(sensitiveValue)) {
+	k := path[0]
+	sv.variable = fmt.Sprintf("%s_%s", sv.variable, k)
+	sv.attribute = joinAttribute(sv.attribute, k)
+	if !sv.set {
+		sv.ignore = joinAttribute(sv.ignore, k)
+	}
+
+	if len(path) == 1 {
+		cfg[k] = fmt.Sprintf("${var.%s}", sv.variable)
+		fn(sv)
+		return
+	}
+
+	s := sch[k]
+	sr := s.Elem.(*schema.Resource)
+
+	switch b := cfg[k].(type) {
+	case map[string]interface{}:
+		sv.replace(sr.Schema, b, path[1:], fn)
+	case []interface{}:
+		for i, e := range b {
+			m, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			esv := sv
+			if len(b) > 1 {
+				esv.variable = fmt.Sprintf("%s_%d", esv.variable, i)
+			}
+			esv.attribute = fmt.Sprintf("%s[%d]", esv.attribute, i)
+			if s.Type == schema.TypeSet {
+				esv.set = true
+			} else if !esv.set {
+				esv.ignore = fmt.Sprintf("%s[%d]", esv.ignore, i)
+			}
+
+			esv.replace(sr.Schema, m, path[1:], fn)
+		}
+	}
+}
+
+// joinAttribute returns the path of the attribute
+// k of the block with the path p, if any
+func joinAttribute(p, k string) string {
+	if p == "" {
+		return k
+	}
+	return p + "." + k
+}
+
+// removeSensitiveValues removes from the s and the data of the
+// resource the values of the sensitive attributes, the ones
+// returned by the cloud provider (ex: aws_ssm_parameter value),
+// so they are not written to the state or any other output
+func (r *resource) removeSensitiveValues(s *terraform.InstanceState) error {
+	for _, a := range sensitiveAttributes(r.tfResource.Schema) {
+		path := strings.Split(a, ".")
+
+		var removed bool
+		for k := range s.Attributes {
+			if isAttributeKey(k, path) {
+				delete(s.Attributes, k)
+				removed = true
+			}
+		}
+		if !removed {
+			continue
+		}
+
+		// The nested ones are removed from
+		// the value of the block they are on
+		var v interface{}
+		if len(path) > 1 {
+			v = withoutAttribute(r.data.Get(path[0]), path[1:])
+		}
+
+		err := r.data.Set(path[0], v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isAttributeKey checks if the k of the attributes of the state
+// is the attribute of the path, in which the blocks have an index
+// (ex: default_action.0.authenticate_oidc.0.client_secret)
+func isAttributeKey(k string, path []string) bool {
+	parts := strings.Split(k, ".")
+	if len(parts) != len(path)*2-1 {
+		return false
+	}
+
+	for i, p := range path {
+		if parts[i*2] != p {
+			return false
+		}
+	}
+
+	return true
+}
+
+// withoutAttribute returns the v, the value of a block, without
+// the attribute of the path on each of the elements of it
+func withoutAttribute(v interface{}, path []string) interface{} {
+	switch b := v.(type) {
+	case *schema.Set:
+		return withoutAttribute(b.List(), path)
+	case []interface{}:
+		for i, e := range b {
+			b[i] = withoutAttribute(e, path)
+		}
+		return b
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(b, path[0])
+		} else if e, ok := b[path[0]]; ok {
+			b[path[0]] = withoutAttribute(e, path[1:])
+		}
+		return b
+	}
+
+	return v
+}
@@ -0,0 +1,152 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+func TestSensitiveAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tfp := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"aws_lb_listener": &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"port":     &schema.Schema{Type: schema.TypeInt, Required: true},
+					"password": &schema.Schema{Type: schema.TypeString, Optional: true, Sensitive: true},
+					"default_action": &schema.Schema{
+						Type:     schema.TypeList,
+						Required: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"type": &schema.Schema{Type: schema.TypeString, Required: true},
+								"authenticate_oidc": &schema.Schema{
+									Type:     schema.TypeList,
+									Optional: true,
+									MaxItems: 1,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"client_id":     &schema.Schema{Type: schema.TypeString, Required: true},
+											"client_secret": &schema.Schema{Type: schema.TypeString, Required: true, Sensitive: true},
+										},
+									},
+								},
+							},
+						},
+					},
+					"replica": &schema.Schema{
+						Type:     schema.TypeSet,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name":     &schema.Schema{Type: schema.TypeString, Required: true},
+								"password": &schema.Schema{Type: schema.TypeString, Optional: true, Sensitive: true},
+							},
+						},
+					},
+				},
+				Importer: &schema.ResourceImporter{
+					State: schema.ImportStatePassthrough,
+				},
+				Read: func(d *schema.ResourceData, meta interface{}) error {
+					d.Set("port", 443)
+					d.Set("default_action", []interface{}{
+						map[string]interface{}{"type": "forward"},
+						map[string]interface{}{
+							"type": "authenticate-oidc",
+							"authenticate_oidc": []interface{}{
+								map[string]interface{}{"client_id": "id", "client_secret": "secret"},
+							},
+						},
+					})
+					d.Set("replica", []interface{}{
+						map[string]interface{}{"name": "replica", "password": "secret"},
+					})
+					return nil
+				},
+			},
+		},
+	}
+
+	p := mock.NewProvider(ctrl)
+	p.EXPECT().TFProvider().Return(tfp).AnyTimes()
+	p.EXPECT().TFClient().Return(nil).AnyTimes()
+	p.EXPECT().TagKey().Return("tags").AnyTimes()
+	p.EXPECT().String().Return("aws").AnyTimes()
+
+	r := provider.NewResource("arn", "aws_lb_listener", p)
+	require.NoError(t, r.Read(&filter.Filter{}))
+
+	t.Run("HCL", func(t *testing.T) {
+		sw := mock.NewWriter(ctrl)
+		sw.EXPECT().Has("arn").Return(false, nil)
+		sw.EXPECT().Write("aws_lb_listener.arn", r).Return(nil)
+		require.NoError(t, r.State(sw))
+
+		w := mock.NewWriter(ctrl)
+
+		w.EXPECT().Write("aws_lb_listener.arn", map[string]interface{}{
+			"port":     443,
+			"password": "${var.aws_lb_listener_arn_password}",
+			"default_action": []interface{}{
+				map[string]interface{}{"type": "forward"},
+				map[string]interface{}{
+					"type": "authenticate-oidc",
+					"authenticate_oidc": []interface{}{
+						map[string]interface{}{
+							"client_id":     "id",
+							"client_secret": "${var.aws_lb_listener_arn_default_action_1_authenticate_oidc_client_secret}",
+						},
+					},
+				},
+			},
+			"replica": []interface{}{
+				map[string]interface{}{
+					"name":     "replica",
+					"password": "${var.aws_lb_listener_arn_replica_password}",
+				},
+			},
+			"lifecycle": map[string]interface{}{
+				// The elements of the sets can not
+				// be referenced so all the set is
+				"ignore_changes": []interface{}{"default_action[1].authenticate_oidc[0].client_secret", "password", "replica"},
+			},
+		}).Return(nil)
+		w.EXPECT().Write("variable.aws_lb_listener_arn_default_action_1_authenticate_oidc_client_secret", map[string]interface{}{
+			"description": "The default_action[1].authenticate_oidc[0].client_secret of the aws_lb_listener.arn",
+		}).Return(nil)
+		w.EXPECT().Write("variable.aws_lb_listener_arn_password", map[string]interface{}{
+			"description": "The password of the aws_lb_listener.arn",
+		}).Return(nil)
+		w.EXPECT().Write("variable.aws_lb_listener_arn_replica_password", map[string]interface{}{
+			"description": "The replica[0].password of the aws_lb_listener.arn",
+		}).Return(nil)
+
+		require.NoError(t, r.HCL(w))
+	})
+
+	t.Run("State", func(t *testing.T) {
+		assert.Equal(t, "", r.Data().Get("default_action.1.authenticate_oidc.0.client_secret"))
+		assert.Equal(t, "id", r.Data().Get("default_action.1.authenticate_oidc.0.client_id"))
+
+		v := r.ResourceInstanceObject().Value
+		oidc := v.GetAttr("default_action").Index(cty.NumberIntVal(1)).GetAttr("authenticate_oidc").Index(cty.NumberIntVal(0))
+		assert.True(t, oidc.GetAttr("client_secret").IsNull())
+		assert.Equal(t, cty.StringVal("id"), oidc.GetAttr("client_id"))
+
+		for _, rp := range v.GetAttr("replica").AsValueSlice() {
+			assert.True(t, rp.GetAttr("password").IsNull())
+			assert.Equal(t, cty.StringVal("replica"), rp.GetAttr("name"))
+		}
+	})
+}
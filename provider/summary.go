@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// TypeSummary is the number of resources
+// of one type on the Summary
+type TypeSummary struct {
+	Type string `json:"type"`
+
+	// Discovered are the resources listed and the ones
+	// returned by their ImportState, Imported the ones
+	// written, Skipped the ones not imported because of the
+	// filters, the writers or already imported, and Failed
+	// the ones that could not be read
+	Discovered int `json:"discovered"`
+	Imported   int `json:"imported"`
+	Skipped    int `json:"skipped"`
+	Failed     int `json:"failed"`
+}
+
+// Summary is the report of an Import with
+// the resources of each type, in the order
+// they were imported, and the time it took
+type Summary struct {
+	Types   []TypeSummary `json:"types"`
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// Total returns the sum of all the Types
+func (s *Summary) Total() TypeSummary {
+	total := TypeSummary{Type: "total"}
+	for _, ts := range s.Types {
+		total.Discovered += ts.Discovered
+		total.Imported += ts.Imported
+		total.Skipped += ts.Skipped
+		total.Failed += ts.Failed
+	}
+	return total
+}
+
+// Write writes the Summary as a table to the w
+func (s *Summary) Write(w io.Writer) {
+	fmt.Fprintf(w, "\nSummary:\n")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "TYPE\tDISCOVERED\tIMPORTED\tSKIPPED\tFAILED\n")
+	for _, ts := range append(s.Types, s.Total()) {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\n", ts.Type, ts.Discovered, ts.Imported, ts.Skipped, ts.Failed)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "Elapsed: %s\n", s.Elapsed.Round(time.Millisecond))
+}
+
+// typeSummary returns the TypeSummary of the t, it's
+// added if it's the first time the t is imported (ex:
+// the same type imported with multiple providers)
+func (s *Summary) typeSummary(t string) *TypeSummary {
+	for i := range s.Types {
+		if s.Types[i].Type == t {
+			return &s.Types[i]
+		}
+	}
+	s.Types = append(s.Types, TypeSummary{Type: t})
+	return &s.Types[len(s.Types)-1]
+}
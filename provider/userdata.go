@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	userDataKey       = "user_data"
+	userDataBase64Key = "user_data_base64"
+
+	// userDataBase64Seed is the value set on the user_data_base64
+	// before reading so TF reads it instead of the user_data
+	userDataBase64Seed = "terracognita"
+)
+
+// seedUserData sets the user_data_base64 on the s if the resource has it, as
+// TF only reads the user_data (instead of the user_data_base64) as a hash of
+// the content if the user_data_base64 is not on the state, which makes the
+// HCL useless. It returns true if the seed was set
+func (r *resource) seedUserData(s *terraform.InstanceState) bool {
+	if s == nil {
+		return false
+	}
+
+	if _, ok := r.TFResource().Schema[userDataBase64Key]; !ok {
+		return false
+	}
+
+	if _, ok := s.Attributes[userDataBase64Key]; ok {
+		return false
+	}
+
+	if s.Attributes == nil {
+		s.Attributes = make(map[string]string)
+	}
+	s.Attributes[userDataBase64Key] = userDataBase64Seed
+
+	return true
+}
+
+// cleanUserDataSeed removes the seed from the s
+// in case that the resource has no user data
+func (r *resource) cleanUserDataSeed(s *terraform.InstanceState) error {
+	if s.Attributes[userDataBase64Key] != userDataBase64Seed {
+		return nil
+	}
+
+	delete(s.Attributes, userDataBase64Key)
+	return r.data.Set(userDataBase64Key, "")
+}
+
+// DecodeUserData sets the user_data_base64 as a plain text user_data
+// so it's readable on the HCL. The binary user data (ex: gzip) are
+// kept as user_data_base64
+func (r *resource) DecodeUserData() error {
+	if r.data == nil || r.state == nil {
+		return nil
+	}
+
+	v, ok := r.data.GetOk(userDataBase64Key)
+	if !ok {
+		return nil
+	}
+
+	ud, err := base64.StdEncoding.DecodeString(v.(string))
+	if err != nil || !utf8.Valid(ud) {
+		return nil
+	}
+
+	err = r.data.Set(userDataKey, string(ud))
+	if err != nil {
+		return err
+	}
+
+	err = r.data.Set(userDataBase64Key, "")
+	if err != nil {
+		return err
+	}
+
+	// The state of the user_data on TF
+	// is the hash of the content
+	hash := sha1.Sum(ud)
+	sum := hex.EncodeToString(hash[:])
+
+	r.state.Attributes[userDataKey] = sum
+	delete(r.state.Attributes, userDataBase64Key)
+
+	if r.resourceInstanceObject != nil && r.resourceInstanceObject.Value.Type().IsObjectType() {
+		vals := r.resourceInstanceObject.Value.AsValueMap()
+		vals[userDataKey] = cty.StringVal(sum)
+		vals[userDataBase64Key] = cty.NullVal(cty.String)
+		r.resourceInstanceObject.Value = cty.ObjectVal(vals)
+	}
+
+	return nil
+}
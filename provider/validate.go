@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/writer"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+)
+
+// validateWriter validates the configurations written
+// to it with the schema of the resources before the Sync
+type validateWriter struct {
+	writer.Writer
+
+	provider Provider
+	out      io.Writer
+	strict   bool
+
+	// invalid are the errors of the
+	// configurations written
+	invalid []string
+}
+
+// NewValidateWriter returns a writer.Writer that validates the
+// configurations written to w with the schema of the resources of p
+// (the required attributes are present and the values have the
+// type of the attribute). On the Sync the invalid configurations
+// are written as warnings to out and, if strict, it fails without
+// syncing the w
+func NewValidateWriter(w writer.Writer, p Provider, out io.Writer, strict bool) writer.Writer {
+	return &validateWriter{
+		Writer:   w,
+		provider: p,
+		out:      out,
+		strict:   strict,
+	}
+}
+
+// Write validates the value and writes it
+func (v *validateWriter) Write(key string, value interface{}) error {
+	if cfg, ok := value.(map[string]interface{}); ok {
+		if sch, ok := v.schema(key); ok {
+			for _, err := range validateConfig(sch, cfg, "") {
+				v.invalid = append(v.invalid, fmt.Sprintf("%s: %s", key, err))
+			}
+		}
+	}
+
+	return v.Writer.Write(key, value)
+}
+
+// Sync warns of the invalid configurations written and
+// syncs the Writer, unless it's strict and there are any
+func (v *validateWriter) Sync() error {
+	for _, i := range v.invalid {
+		fmt.Fprintf(v.out, "\nWarning: invalid configuration %s\n", i)
+	}
+
+	if v.strict && len(v.invalid) != 0 {
+		return errors.Wrapf(errcode.ErrProviderResourceInvalidConfig, "%d invalid configurations", len(v.invalid))
+	}
+
+	return v.Writer.Sync()
+}
+
+// schema returns the schema of the resource or data source
+// of the key, the other blocks (ex: variable) have none
+func (v *validateWriter) schema(key string) (map[string]*schema.Schema, bool) {
+	resources := v.provider.TFProvider().ResourcesMap
+	if strings.HasPrefix(key, "data.") {
+		key = strings.TrimPrefix(key, "data.")
+		resources = v.provider.TFProvider().DataSourcesMap
+	}
+
+	tfr, ok := resources[strings.Split(key, ".")[0]]
+	if !ok {
+		return nil, false
+	}
+
+	return tfr.Schema, true
+}
+
+// metaArguments are the attributes of the
+// configurations that are not on the schema
+var metaArguments = map[string]struct{}{
+	"count":      struct{}{},
+	"depends_on": struct{}{},
+	"for_each":   struct{}{},
+	"lifecycle":  struct{}{},
+	"provider":   struct{}{},
+}
+
+// validateConfig returns the errors of the cfg following
+// the sch, the path is the one of the nested block
+func validateConfig(sch map[string]*schema.Schema, cfg map[string]interface{}, path string) []string {
+	res := make([]string, 0)
+
+	for k, s := range sch {
+		if !s.Required {
+			continue
+		}
+		if _, ok := cfg[k]; ok {
+			continue
+		}
+		if _, ok := cfg["=tc="+k]; ok {
+			continue
+		}
+		res = append(res, fmt.Sprintf("the attribute %q is required", path+k))
+	}
+
+	for k, v := range cfg {
+		k = strings.TrimPrefix(k, "=tc=")
+		if _, ok := metaArguments[k]; ok && path == "" {
+			continue
+		}
+
+		s, ok := sch[k]
+		if !ok {
+			res = append(res, fmt.Sprintf("the attribute %q is not supported", path+k))
+			continue
+		}
+
+		res = append(res, validateValue(s, v, path+k)...)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// validateValue returns the errors of the v
+// following the s of the attribute on path
+func validateValue(s *schema.Schema, v interface{}, path string) []string {
+	if v == nil {
+		return nil
+	}
+
+	// The interpolations are values
+	// only known by TF
+	if sv, ok := v.(string); ok && strings.Contains(sv, "${") {
+		return nil
+	}
+
+	if sr, ok := s.Elem.(*schema.Resource); ok {
+		return validateBlock(s, sr.Schema, v, path)
+	}
+
+	invalid := []string{fmt.Sprintf("the attribute %q has to be a %s", path, typeName(s.Type))}
+
+	switch s.Type {
+	case schema.TypeBool:
+		if _, ok := v.(bool); ok {
+			return nil
+		}
+		if sv, ok := v.(string); ok {
+			if _, err := strconv.ParseBool(sv); err == nil {
+				return nil
+			}
+		}
+		return invalid
+	case schema.TypeInt, schema.TypeFloat:
+		if isNumber(v) {
+			return nil
+		}
+		if sv, ok := v.(string); ok {
+			if _, err := strconv.ParseFloat(sv, 64); err == nil {
+				return nil
+			}
+		}
+		return invalid
+	case schema.TypeString:
+		if _, ok := v.(string); ok || isNumber(v) {
+			return nil
+		}
+		if _, ok := v.(bool); ok {
+			return nil
+		}
+		return invalid
+	case schema.TypeMap:
+		if _, ok := v.(map[string]interface{}); ok {
+			return nil
+		}
+		return invalid
+	case schema.TypeList, schema.TypeSet:
+		l, ok := v.([]interface{})
+		if !ok {
+			return invalid
+		}
+
+		res := validateItems(s, len(l), path)
+		if es, ok := s.Elem.(*schema.Schema); ok {
+			for i, e := range l {
+				res = append(res, validateValue(es, e, fmt.Sprintf("%s.%d", path, i))...)
+			}
+		}
+		return res
+	}
+
+	return nil
+}
+
+// validateBlock returns the errors of the
+// nested block v following the sch
+func validateBlock(s *schema.Schema, sch map[string]*schema.Schema, v interface{}, path string) []string {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		return append(validateItems(s, 1, path), validateConfig(sch, vv, path+".")...)
+	case []interface{}:
+		res := validateItems(s, len(vv), path)
+		for i, e := range vv {
+			b, ok := e.(map[string]interface{})
+			if !ok {
+				res = append(res, fmt.Sprintf("the attribute %q has to be a block", fmt.Sprintf("%s.%d", path, i)))
+				continue
+			}
+			res = append(res, validateConfig(sch, b, fmt.Sprintf("%s.%d.", path, i))...)
+		}
+		return res
+	default:
+		return []string{fmt.Sprintf("the attribute %q has to be a block", path)}
+	}
+}
+
+// validateItems returns the error if the n
+// items are not within the limits of the s
+func validateItems(s *schema.Schema, n int, path string) []string {
+	if s.MaxItems > 0 && n > s.MaxItems {
+		return []string{fmt.Sprintf("the attribute %q has %d items, the maximum is %d", path, n, s.MaxItems)}
+	}
+	if s.MinItems > 0 && n < s.MinItems {
+		return []string{fmt.Sprintf("the attribute %q has %d items, the minimum is %d", path, n, s.MinItems)}
+	}
+	return nil
+}
+
+// isNumber checks if the v is an int or float
+func isNumber(v interface{}) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// typeName returns the name of the t
+// as it's known on the HCL
+func typeName(t schema.ValueType) string {
+	switch t {
+	case schema.TypeBool:
+		return "bool"
+	case schema.TypeInt, schema.TypeFloat:
+		return "number"
+	case schema.TypeString:
+		return "string"
+	case schema.TypeMap:
+		return "map"
+	case schema.TypeList:
+		return "list"
+	case schema.TypeSet:
+		return "set"
+	}
+	return t.String()
+}
@@ -0,0 +1,129 @@
+package provider_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWriter(t *testing.T) {
+	tfp := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			"aws_instance": &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"ami":        &schema.Schema{Type: schema.TypeString, Required: true},
+					"subnet_id":  &schema.Schema{Type: schema.TypeString, Optional: true},
+					"monitoring": &schema.Schema{Type: schema.TypeBool, Optional: true},
+					"tags":       &schema.Schema{Type: schema.TypeMap, Optional: true},
+					"credit_specification": &schema.Schema{
+						Type:     schema.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"cpu_credits": &schema.Schema{Type: schema.TypeString, Required: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			p    = mock.NewProvider(ctrl)
+			w    = mock.NewWriter(ctrl)
+			out  = &bytes.Buffer{}
+			vw   = provider.NewValidateWriter(w, p, out, true)
+			cfg  = map[string]interface{}{
+				"ami":        "ami-123",
+				"subnet_id":  "${aws_subnet.front.id}",
+				"monitoring": "true",
+				"=tc=tags":   map[string]interface{}{"Name": "front"},
+				"lifecycle":  map[string]interface{}{"ignore_changes": []interface{}{"ami"}},
+				"credit_specification": []interface{}{
+					map[string]interface{}{"cpu_credits": "standard"},
+				},
+			}
+		)
+		defer ctrl.Finish()
+
+		p.EXPECT().TFProvider().Return(tfp).AnyTimes()
+		w.EXPECT().Write("aws_instance.front", cfg).Return(nil)
+		w.EXPECT().Write("variable.password", map[string]interface{}{"type": "string"}).Return(nil)
+		w.EXPECT().Sync().Return(nil)
+
+		require.NoError(t, vw.Write("aws_instance.front", cfg))
+		require.NoError(t, vw.Write("variable.password", map[string]interface{}{"type": "string"}))
+		require.NoError(t, vw.Sync())
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			p    = mock.NewProvider(ctrl)
+			w    = mock.NewWriter(ctrl)
+			out  = &bytes.Buffer{}
+			vw   = provider.NewValidateWriter(w, p, out, false)
+			cfg  = map[string]interface{}{
+				"monitoring": "yes",
+				"user":       "admin",
+				"credit_specification": []interface{}{
+					map[string]interface{}{},
+					map[string]interface{}{"cpu_credits": "standard"},
+				},
+			}
+		)
+		defer ctrl.Finish()
+
+		p.EXPECT().TFProvider().Return(tfp).AnyTimes()
+		w.EXPECT().Write("aws_instance.front", cfg).Return(nil)
+		w.EXPECT().Sync().Return(nil)
+
+		require.NoError(t, vw.Write("aws_instance.front", cfg))
+		require.NoError(t, vw.Sync())
+		assert.Equal(t, `
+Warning: invalid configuration aws_instance.front: the attribute "ami" is required
+
+Warning: invalid configuration aws_instance.front: the attribute "credit_specification" has 2 items, the maximum is 1
+
+Warning: invalid configuration aws_instance.front: the attribute "credit_specification.0.cpu_credits" is required
+
+Warning: invalid configuration aws_instance.front: the attribute "monitoring" has to be a bool
+
+Warning: invalid configuration aws_instance.front: the attribute "user" is not supported
+`, out.String())
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			p    = mock.NewProvider(ctrl)
+			w    = mock.NewWriter(ctrl)
+			out  = &bytes.Buffer{}
+			vw   = provider.NewValidateWriter(w, p, out, true)
+			cfg  = map[string]interface{}{"ami": []interface{}{"ami-123"}}
+		)
+		defer ctrl.Finish()
+
+		p.EXPECT().TFProvider().Return(tfp).AnyTimes()
+		w.EXPECT().Write("aws_instance.front", cfg).Return(nil)
+
+		require.NoError(t, vw.Write("aws_instance.front", cfg))
+		err := vw.Sync()
+		require.Error(t, err)
+		assert.Equal(t, errcode.ErrProviderResourceInvalidConfig, errors.Cause(err))
+		assert.Contains(t, out.String(), `the attribute "ami" has to be a string`)
+	})
+}
@@ -0,0 +1,4 @@
+// Package pulumi has the Writers that generate the
+// Pulumi import manifest and the Pulumi YAML program
+// so the resources can be adopted by Pulumi
+package pulumi
@@ -0,0 +1,65 @@
+package pulumi
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// ManifestWriter is a Writer implementation that generates
+// the JSON manifest used by 'pulumi import --file'
+type ManifestWriter struct {
+	*provider.Collector
+
+	writer io.Writer
+}
+
+// manifestResource is each one of the
+// resources of the manifest
+type manifestResource struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+// NewManifestWriter returns a ManifestWriter initialization
+func NewManifestWriter(w io.Writer) *ManifestWriter {
+	return &ManifestWriter{
+		Collector: provider.NewCollector("Pulumi manifest"),
+		writer:    w,
+	}
+}
+
+// Sync writes the manifest to the
+// internal w with the JSON format
+func (w *ManifestWriter) Sync() error {
+	resources := make([]manifestResource, 0, len(w.Keys()))
+	for _, k := range w.Keys() {
+		r := w.Resource(k)
+		resources = append(resources, manifestResource{
+			Type: Type(r.Type()),
+			Name: strings.Split(k, ".")[1],
+			ID:   r.ID(),
+		})
+	}
+
+	b, err := json.MarshalIndent(struct {
+		Resources []manifestResource `json:"resources"`
+	}{
+		Resources: resources,
+	}, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "error while encoding the manifest")
+	}
+
+	_, err = w.writer.Write(append(b, '\n'))
+	if err != nil {
+		return errors.Wrap(err, "error while writing the manifest")
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package pulumi_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/pulumi"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestWriter(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			b    = &bytes.Buffer{}
+			mw   = pulumi.NewManifestWriter(b)
+			r1   = mock.NewResource(ctrl)
+			r2   = mock.NewResource(ctrl)
+
+			manifest = `{
+  "resources": [
+    {
+      "type": "aws:ec2/instance:Instance",
+      "name": "front",
+      "id": "i-123"
+    },
+    {
+      "type": "aws:iam/user:User",
+      "name": "john",
+      "id": "john"
+    }
+  ]
+}
+`
+		)
+		defer ctrl.Finish()
+
+		r1.EXPECT().Type().Return("aws_instance")
+		r1.EXPECT().ID().Return("i-123")
+		r2.EXPECT().Type().Return("aws_iam_user")
+		r2.EXPECT().ID().Return("john")
+
+		require.NoError(t, mw.Write("aws_instance.front", r1))
+		require.NoError(t, mw.Write("aws_iam_user.john", r2))
+
+		ok, err := mw.Has("aws_instance.front")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, mw.Sync())
+		assert.Equal(t, manifest, b.String())
+	})
+	t.Run("Errors", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			mw   = pulumi.NewManifestWriter(nil)
+			r    = mock.NewResource(ctrl)
+		)
+		defer ctrl.Finish()
+
+		assert.Equal(t, errcode.ErrWriterRequiredKey, errors.Cause(mw.Write("", r)))
+		assert.Equal(t, errcode.ErrWriterRequiredValue, errors.Cause(mw.Write("type.name", nil)))
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(mw.Write("type", r)))
+		assert.Equal(t, errcode.ErrWriterInvalidTypeValue, errors.Cause(mw.Write("type.name", "value")))
+
+		require.NoError(t, mw.Write("type.name", r))
+		assert.Equal(t, errcode.ErrWriterAlreadyExistsKey, errors.Cause(mw.Write("type.name", r)))
+	})
+}
@@ -0,0 +1,91 @@
+package pulumi
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	// packages maps the TF provider
+	// to the Pulumi package
+	packages = map[string]string{
+		"google": "gcp",
+	}
+
+	// types are the Pulumi types that do not
+	// follow the '<package>:<module>/<name>:<Name>'
+	// convention from the TF type
+	types = map[string]string{
+		"aws_instance":                    "aws:ec2/instance:Instance",
+		"aws_vpc":                         "aws:ec2/vpc:Vpc",
+		"aws_security_group":              "aws:ec2/securityGroup:SecurityGroup",
+		"aws_subnet":                      "aws:ec2/subnet:Subnet",
+		"aws_launch_configuration":        "aws:ec2/launchConfiguration:LaunchConfiguration",
+		"aws_launch_template":             "aws:ec2/launchTemplate:LaunchTemplate",
+		"aws_ec2_transit_gateway":         "aws:ec2transitgateway/transitGateway:TransitGateway",
+		"aws_elb":                         "aws:elb/loadBalancer:LoadBalancer",
+		"aws_alb":                         "aws:alb/loadBalancer:LoadBalancer",
+		"aws_lb":                          "aws:lb/loadBalancer:LoadBalancer",
+		"aws_db_instance":                 "aws:rds/instance:Instance",
+		"aws_iam_openid_connect_provider": "aws:iam/openIdConnectProvider:OpenIdConnectProvider",
+	}
+)
+
+// Type returns the Pulumi type of the TF resource type t
+// ex: aws_iam_role => aws:iam/role:Role
+func Type(t string) string {
+	if pt, ok := types[t]; ok {
+		return pt
+	}
+
+	pkg, module, name := split(t)
+
+	return fmt.Sprintf("%s:%s/%s:%s", pkg, module, camelCase(name, false), camelCase(name, true))
+}
+
+// Function returns the Pulumi function of the TF data source t
+// ex: aws_subnet => aws:ec2/getSubnet:getSubnet
+func Function(t string) string {
+	parts := strings.Split(Type(t), ":")
+	module := strings.Split(parts[1], "/")[0]
+	name := "get" + parts[2]
+
+	return fmt.Sprintf("%s:%s/%s:%s", parts[0], module, name, name)
+}
+
+// split splits the TF type t into the Pulumi
+// package, module and the rest of the name
+func split(t string) (string, string, string) {
+	parts := strings.SplitN(t, "_", 3)
+
+	pkg := parts[0]
+	if p, ok := packages[pkg]; ok {
+		pkg = p
+	}
+
+	switch len(parts) {
+	case 1:
+		return pkg, "index", parts[0]
+	case 2:
+		return pkg, parts[1], parts[1]
+	default:
+		return pkg, parts[1], parts[2]
+	}
+}
+
+// camelCase converts the snake case s to camel case,
+// with the first letter upper case if upper
+func camelCase(s string, upper bool) string {
+	var b strings.Builder
+	for i, p := range strings.Split(s, "_") {
+		if p == "" {
+			continue
+		}
+		if i == 0 && !upper {
+			b.WriteString(p)
+			continue
+		}
+		b.WriteString(strings.ToUpper(p[:1]) + p[1:])
+	}
+	return b.String()
+}
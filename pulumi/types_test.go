@@ -0,0 +1,30 @@
+package pulumi_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/pulumi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestType(t *testing.T) {
+	tests := map[string]string{
+		"aws_instance":                  "aws:ec2/instance:Instance",
+		"aws_iam_role":                  "aws:iam/role:Role",
+		"aws_route53_resolver_endpoint": "aws:route53/resolverEndpoint:ResolverEndpoint",
+		"aws_s3_bucket":                 "aws:s3/bucket:Bucket",
+		"google_compute_instance":       "gcp:compute/instance:Instance",
+		"google_sql_database_instance":  "gcp:sql/databaseInstance:DatabaseInstance",
+	}
+
+	for tf, p := range tests {
+		t.Run(tf, func(t *testing.T) {
+			assert.Equal(t, p, pulumi.Type(tf))
+		})
+	}
+}
+
+func TestFunction(t *testing.T) {
+	assert.Equal(t, "aws:ec2/getSubnet:getSubnet", pulumi.Function("aws_subnet"))
+	assert.Equal(t, "aws:iam/getRole:getRole", pulumi.Function("aws_iam_role"))
+}
@@ -0,0 +1,174 @@
+package pulumi
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/cycloidio/terracognita/hcl"
+	"github.com/cycloidio/terracognita/log"
+)
+
+// projectName is the name of
+// the generated Pulumi project
+const projectName = "terracognita"
+
+// YAMLWriter is a Writer implementation that stores the
+// configuration the same way the hcl.Writer does but
+// it writes it as a Pulumi YAML program on the Sync
+type YAMLWriter struct {
+	*hcl.Writer
+
+	writer io.Writer
+}
+
+// NewYAMLWriter returns a YAMLWriter initialization
+func NewYAMLWriter(w io.Writer) *YAMLWriter {
+	return &YAMLWriter{
+		Writer: hcl.NewWriter(nil),
+		writer: w,
+	}
+}
+
+// Sync writes the content of the Config to the
+// internal w as a Pulumi YAML program, the data
+// sources are written as variables
+func (w *YAMLWriter) Sync() error {
+	var (
+		variables = yaml.MapSlice{}
+		resources = yaml.MapSlice{}
+		names     = make(map[string]struct{})
+	)
+
+	for _, block := range []string{"data", "resource"} {
+		blocks, ok := w.Config[block].(map[string]map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, t := range sortedTypes(blocks) {
+			for _, n := range sortedNames(blocks[t]) {
+				cfg, _ := blocks[t][n].(map[string]interface{})
+
+				name := n
+				if _, ok := names[name]; ok {
+					name = fmt.Sprintf("%s_%s", t, n)
+				}
+				names[name] = struct{}{}
+
+				if block == "data" {
+					// The provider of the data sources it's a TF
+					// provider alias which does not exist on Pulumi
+					args := properties(cfg)
+					for i, a := range args {
+						if a.Key == "provider" {
+							args = append(args[:i], args[i+1:]...)
+							break
+						}
+					}
+					variables = append(variables, yaml.MapItem{
+						Key: name,
+						Value: yaml.MapSlice{
+							{Key: "fn::invoke", Value: yaml.MapSlice{
+								{Key: "function", Value: Function(t)},
+								{Key: "arguments", Value: args},
+							}},
+						},
+					})
+					continue
+				}
+
+				res := yaml.MapSlice{{Key: "type", Value: Type(t)}}
+				if props := properties(cfg); len(props) != 0 {
+					res = append(res, yaml.MapItem{Key: "properties", Value: props})
+				}
+				resources = append(resources, yaml.MapItem{Key: name, Value: res})
+			}
+		}
+	}
+
+	program := yaml.MapSlice{
+		{Key: "name", Value: projectName},
+		{Key: "runtime", Value: "yaml"},
+	}
+	if len(variables) != 0 {
+		program = append(program, yaml.MapItem{Key: "variables", Value: variables})
+	}
+	program = append(program, yaml.MapItem{Key: "resources", Value: resources})
+
+	b, err := yaml.Marshal(program)
+	if err != nil {
+		return errors.Wrap(err, "error while encoding the Pulumi YAML")
+	}
+
+	log.Get().Log("func", "pulumi.Sync(YAML)", "msg", "writing Pulumi YAML program")
+
+	_, err = w.writer.Write(b)
+	if err != nil {
+		return errors.Wrap(err, "error while writing the Pulumi YAML")
+	}
+
+	return nil
+}
+
+// properties returns the cfg with the keys in camel case
+// and sorted, the TF maps (prefixed with '=tc=') are
+// kept as they are
+func properties(cfg map[string]interface{}) yaml.MapSlice {
+	keys := make([]string, 0, len(cfg))
+	for k := range cfg {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.TrimPrefix(keys[i], "=tc=") < strings.TrimPrefix(keys[j], "=tc=")
+	})
+
+	res := make(yaml.MapSlice, 0, len(keys))
+	for _, k := range keys {
+		if strings.HasPrefix(k, "=tc=") {
+			res = append(res, yaml.MapItem{Key: strings.TrimPrefix(k, "=tc="), Value: cfg[k]})
+			continue
+		}
+		res = append(res, yaml.MapItem{Key: camelCase(k, false), Value: value(cfg[k])})
+	}
+
+	return res
+}
+
+// value converts the nested blocks of v to properties
+func value(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		return properties(vv)
+	case []interface{}:
+		res := make([]interface{}, 0, len(vv))
+		for _, e := range vv {
+			res = append(res, value(e))
+		}
+		return res
+	default:
+		return v
+	}
+}
+
+func sortedTypes(m map[string]map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedNames(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,60 @@
+package pulumi_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cycloidio/terracognita/pulumi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLWriter(t *testing.T) {
+	var (
+		b  = &bytes.Buffer{}
+		yw = pulumi.NewYAMLWriter(b)
+
+		program = `name: terracognita
+runtime: yaml
+variables:
+  shared:
+    fn::invoke:
+      function: aws:ec2/getSubnet:getSubnet
+      arguments:
+        id: subnet-123
+resources:
+  front:
+    type: aws:ec2/instance:Instance
+    properties:
+      ami: ami-123
+      instanceType: t2.micro
+      rootBlockDevice:
+      - volumeSize: 8
+      tags:
+        Name: front
+`
+	)
+
+	err := yw.Write("aws_instance.front", map[string]interface{}{
+		"ami":           "ami-123",
+		"instance_type": "t2.micro",
+		"=tc=tags": map[string]interface{}{
+			"Name": "front",
+		},
+		"root_block_device": []interface{}{
+			map[string]interface{}{
+				"volume_size": 8,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	err = yw.Write("data.aws_subnet.shared", map[string]interface{}{
+		"id":       "subnet-123",
+		"provider": "aws.shared",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, yw.Sync())
+	assert.Equal(t, program, b.String())
+}
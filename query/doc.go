@@ -0,0 +1,4 @@
+// Package query has the Shell to explore the resources of a
+// provider with ad-hoc queries (ex: 'show aws_instance where
+// tags.env=prod') and import the results of them
+package query
@@ -0,0 +1,56 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cycloidio/terracognita/filter"
+)
+
+// and splits the conditions of the where
+var and = regexp.MustCompile(`\s+and\s+`)
+
+// Query is a query of the resources of
+// the Type that match all the Rules
+type Query struct {
+	Type  string
+	Rules []filter.Rule
+}
+
+// Parse parses the q with the format 'TYPE [where CONDITION [and CONDITION]...]'
+// and the CONDITION as the filter.Rule expressions 'ATTRIBUTE=VALUE', 'ATTRIBUTE!=VALUE'
+// or 'ATTRIBUTE~PATTERN' (ex: 'aws_instance where tags.env=prod and instance_type~t3.*')
+func Parse(q string) (Query, error) {
+	parts := strings.Fields(q)
+	if len(parts) == 0 {
+		return Query{}, fmt.Errorf("invalid query %q, the expected format is 'TYPE [where ATTRIBUTE=VALUE [and ...]]'", q)
+	}
+
+	query := Query{Type: parts[0], Rules: make([]filter.Rule, 0)}
+
+	expr := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(q), parts[0]))
+	if expr == "" {
+		return query, nil
+	}
+
+	if len(parts) < 3 || parts[1] != "where" {
+		return Query{}, fmt.Errorf("invalid query %q, the expected format is 'TYPE [where ATTRIBUTE=VALUE [and ...]]'", q)
+	}
+
+	expr = strings.TrimSpace(strings.TrimPrefix(expr, "where"))
+	for _, c := range and.Split(expr, -1) {
+		r, err := filter.ParseRule(fmt.Sprintf("%s: %s", query.Type, c))
+		if err != nil {
+			return Query{}, err
+		}
+		query.Rules = append(query.Rules, r)
+	}
+
+	return query, nil
+}
+
+// Filter returns the filter.Filter with the Rules
+func (q Query) Filter() *filter.Filter {
+	return &filter.Filter{Rules: q.Rules}
+}
@@ -0,0 +1,51 @@
+package query_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/query"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Query string
+		Res   query.Query
+	}{
+		{
+			Name:  "Type",
+			Query: "aws_instance",
+			Res:   query.Query{Type: "aws_instance", Rules: []filter.Rule{}},
+		},
+		{
+			Name:  "Where",
+			Query: "aws_instance where tags.env=prod and instance_type~t3.*",
+			Res: query.Query{
+				Type: "aws_instance",
+				Rules: []filter.Rule{
+					{Type: "aws_instance", Attribute: "tags.env", Operator: filter.Equal, Value: "prod"},
+					{Type: "aws_instance", Attribute: "instance_type", Operator: filter.Like, Value: "t3.*"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			q, err := query.Parse(tt.Query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.Res, q)
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		for _, q := range []string{"", "aws_instance tags.env=prod", "aws_instance where", "aws_instance where tags.env"} {
+			_, err := query.Parse(q)
+			assert.Error(t, err, q)
+		}
+	})
+}
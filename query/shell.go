@@ -0,0 +1,257 @@
+package query
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/cache"
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/progress"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// Loader returns all the resources of the type t read
+type Loader func(ctx context.Context, t string) ([]provider.Resource, error)
+
+// Importer imports the resources of the f to the hcl
+// and tfstate files, one of them can be empty
+type Importer func(ctx context.Context, f *filter.Filter, hcl, tfstate string) error
+
+// help is the usage of the Shell commands
+const help = `Commands:
+  show TYPE [where ATTRIBUTE=VALUE [and ...]]  Lists the resources of the TYPE matching the conditions ('!=' and '~' for glob patterns are also valid)
+  types [PATTERN]                               Lists the types, matching the glob PATTERN if set
+  import HCL [TFSTATE]                          Imports the resources of the last 'show' to the HCL and TFSTATE files ('-' to skip the HCL)
+  refresh                                       Reads again the resources on the next 'show'
+  help                                          Shows this help
+  exit                                          Exits the shell
+`
+
+// Shell runs the queries of the resources, which are read
+// once for each type, and imports the results of them
+type Shell struct {
+	types []string
+	load  Loader
+	imp   Importer
+
+	cache   cache.Cache
+	results []provider.Resource
+}
+
+// NewShell returns a Shell of the resources of the types,
+// read with the l and imported with the i
+func NewShell(types []string, l Loader, i Importer) *Shell {
+	return &Shell{
+		types: types,
+		load:  l,
+		imp:   i,
+		cache: cache.New(),
+	}
+}
+
+// Run executes the commands read from the in, one
+// for each line, until the 'exit' or the end of it.
+// The errors of the commands are written to the out
+func (s *Shell) Run(ctx context.Context, in io.Reader, out io.Writer) error {
+	sc := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "> ")
+		if !sc.Scan() {
+			fmt.Fprintln(out)
+			return sc.Err()
+		}
+
+		line := strings.TrimSpace(sc.Text())
+		if line == "exit" || line == "quit" {
+			return nil
+		}
+
+		if err := s.Exec(ctx, line, out); err != nil {
+			fmt.Fprintf(out, "Error: %s\n", err)
+		}
+	}
+}
+
+// Exec executes the command of the line
+// and writes the result of it to the out
+func (s *Shell) Exec(ctx context.Context, line string, out io.Writer) error {
+	args := strings.Fields(line)
+	if len(args) == 0 {
+		return nil
+	}
+
+	switch args[0] {
+	case "show":
+		return s.show(ctx, strings.TrimSpace(strings.TrimPrefix(line, "show")), out)
+	case "types":
+		pattern := "*"
+		if len(args) > 1 {
+			pattern = args[1]
+		}
+		for _, t := range s.types {
+			if ok, err := path.Match(pattern, t); err != nil {
+				return errors.Wrapf(err, "invalid pattern %q", pattern)
+			} else if ok {
+				fmt.Fprintln(out, t)
+			}
+		}
+		return nil
+	case "import":
+		if len(args) < 2 || len(args) > 3 {
+			return fmt.Errorf("invalid import, the expected format is 'import HCL [TFSTATE]'")
+		}
+		hcl, tfstate := args[1], ""
+		if hcl == "-" {
+			hcl = ""
+		}
+		if len(args) == 3 {
+			tfstate = args[2]
+		}
+		if hcl == "" && tfstate == "" {
+			return fmt.Errorf("one of the HCL or TFSTATE is required")
+		}
+		return s.importResults(ctx, hcl, tfstate, out)
+	case "refresh":
+		s.cache = cache.New()
+		return nil
+	case "help":
+		fmt.Fprint(out, help)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q, use 'help' to see the valid ones", args[0])
+	}
+}
+
+// show keeps and writes the resources that match the q
+func (s *Shell) show(ctx context.Context, q string, out io.Writer) error {
+	query, err := Parse(q)
+	if err != nil {
+		return err
+	}
+
+	if !hasString(s.types, query.Type) {
+		return errors.Wrapf(errcode.ErrProviderResourceNotSupported, "type %s", query.Type)
+	}
+
+	rs, err := s.cache.Get(query.Type)
+	if err != nil {
+		if errors.Cause(err) != errcode.ErrCacheKeyNotFound {
+			return err
+		}
+
+		fmt.Fprintf(out, "Reading %s...\n", query.Type)
+		rs, err = s.load(ctx, query.Type)
+		if err != nil {
+			return errors.Wrapf(err, "could not read %s", query.Type)
+		}
+
+		if err := s.cache.Set(query.Type, rs); err != nil {
+			return err
+		}
+	}
+
+	f := query.Filter()
+	s.results = make([]provider.Resource, 0)
+	for _, r := range rs {
+		if f.IsMatched(query.Type, provider.AttributeGetter(r)) {
+			s.results = append(s.results, r)
+		}
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, r := range s.results {
+		fmt.Fprintf(tw, "%s\t%s\n", r.Type(), r.ID())
+	}
+	tw.Flush()
+	fmt.Fprintf(out, "%d resources\n", len(s.results))
+
+	return nil
+}
+
+// importResults imports the results of the last show
+func (s *Shell) importResults(ctx context.Context, hcl, tfstate string, out io.Writer) error {
+	if len(s.results) == 0 {
+		return fmt.Errorf("no resources to import, use 'show' to select them")
+	}
+
+	f := &filter.Filter{
+		Include: make([]string, 0),
+		Targets: make([]string, 0, len(s.results)),
+	}
+	for _, r := range s.results {
+		if !hasString(f.Include, r.Type()) {
+			f.Include = append(f.Include, r.Type())
+		}
+		f.Targets = append(f.Targets, fmt.Sprintf("%s.%s", r.Type(), r.ID()))
+	}
+
+	if err := s.imp(ctx, f, hcl, tfstate); err != nil {
+		return errors.Wrap(err, "could not import")
+	}
+
+	fmt.Fprintf(out, "Imported %d resources\n", len(s.results))
+	return nil
+}
+
+// Load reads all the resources of the type t of the p,
+// it can be used as the Loader of the Shell
+func Load(ctx context.Context, p provider.Provider, t string) ([]provider.Resource, error) {
+	c := &collector{keys: make(map[string]struct{})}
+	opt := provider.ImportOptions{Progress: progress.NewQuiet()}
+
+	err := provider.Import(ctx, p, nil, c, &filter.Filter{Include: []string{t}}, opt, ioutil.Discard)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(c.resources, func(i, j int) bool { return c.resources[i].ID() < c.resources[j].ID() })
+
+	return c.resources, nil
+}
+
+// collector is a writer.Writer that keeps the
+// provider.Resource written to it, used to read
+// them with the Import
+type collector struct {
+	keys      map[string]struct{}
+	resources []provider.Resource
+}
+
+func (c *collector) Write(key string, value interface{}) error {
+	r, ok := value.(provider.Resource)
+	if !ok {
+		return errors.Wrapf(errcode.ErrWriterInvalidTypeValue, "expected provider.Resource, found %T", value)
+	}
+
+	c.keys[key] = struct{}{}
+	c.resources = append(c.resources, r)
+
+	return nil
+}
+
+func (c *collector) Has(key string) (bool, error) {
+	_, ok := c.keys[key]
+	return ok, nil
+}
+
+func (c *collector) Sync() error { return nil }
+
+// hasString checks if the s is on the ss
+func hasString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package query_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/query"
+)
+
+func TestShell(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+		ctx  = context.Background()
+		tfr  = &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tags": &schema.Schema{Type: schema.TypeMap, Optional: true},
+			},
+		}
+		r1 = mock.NewResource(ctrl)
+		r2 = mock.NewResource(ctrl)
+		d1 = tfr.Data(nil)
+		d2 = tfr.Data(nil)
+
+		loads int
+		imp   *filter.Filter
+	)
+	defer ctrl.Finish()
+
+	d1.Set("tags", map[string]interface{}{"env": "prod"})
+	d2.Set("tags", map[string]interface{}{"env": "dev"})
+
+	r1.EXPECT().ID().Return("i-1").AnyTimes()
+	r1.EXPECT().Type().Return("aws_instance").AnyTimes()
+	r1.EXPECT().Data().Return(d1).AnyTimes()
+	r2.EXPECT().ID().Return("i-2").AnyTimes()
+	r2.EXPECT().Type().Return("aws_instance").AnyTimes()
+	r2.EXPECT().Data().Return(d2).AnyTimes()
+
+	s := query.NewShell(
+		[]string{"aws_instance", "aws_s3_bucket"},
+		func(ctx context.Context, t string) ([]provider.Resource, error) {
+			loads++
+			return []provider.Resource{r1, r2}, nil
+		},
+		func(ctx context.Context, f *filter.Filter, hcl, tfstate string) error {
+			imp = f
+			assert.Equal(t, "main.tf", hcl)
+			assert.Equal(t, "", tfstate)
+			return nil
+		},
+	)
+
+	t.Run("Run", func(t *testing.T) {
+		var (
+			out = &bytes.Buffer{}
+			in  = strings.NewReader(`show aws_instance where tags.env=prod
+show aws_instance
+import main.tf
+show aws_iam_user
+exit
+`)
+		)
+
+		require.NoError(t, s.Run(ctx, in, out))
+
+		assert.Equal(t, 1, loads)
+		assert.Equal(t, []string{"aws_instance"}, imp.Include)
+		assert.Equal(t, []string{"aws_instance.i-1", "aws_instance.i-2"}, imp.Targets)
+		assert.Equal(t, `> Reading aws_instance...
+aws_instance  i-1
+1 resources
+> aws_instance  i-1
+aws_instance  i-2
+2 resources
+> Imported 2 resources
+> Error: type aws_iam_user: the resource type is not supported
+> `, out.String())
+	})
+
+	t.Run("Types", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		require.NoError(t, s.Exec(ctx, "types aws_s3_*", out))
+		assert.Equal(t, "aws_s3_bucket\n", out.String())
+	})
+
+	t.Run("ErrorImportWithoutResults", func(t *testing.T) {
+		s := query.NewShell(nil, nil, nil)
+		assert.Error(t, s.Exec(ctx, "import main.tf", &bytes.Buffer{}))
+	})
+
+	t.Run("ErrorUnknownCommand", func(t *testing.T) {
+		assert.Error(t, s.Exec(ctx, "delete aws_instance", &bytes.Buffer{}))
+	})
+}
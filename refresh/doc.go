@@ -0,0 +1,4 @@
+// Package refresh reads the resources of a TFState
+// generated before so only those are imported again,
+// with the same addresses, to keep it up to date
+package refresh
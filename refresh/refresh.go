@@ -0,0 +1,143 @@
+package refresh
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// Resource is a resource of the TFState to refresh
+type Resource struct {
+	Type string
+	Name string
+	ID   string
+}
+
+// Address returns the address of the r
+// on the HCL (ex: aws_instance.front)
+func (r Resource) Address() string {
+	return fmt.Sprintf("%s.%s", r.Type, r.Name)
+}
+
+// String returns the address and the ID of the r
+func (r Resource) String() string {
+	return fmt.Sprintf("%s (%s)", r.Address(), r.ID)
+}
+
+// Refresh has the resources of a TFState to import again
+// and the ones written, to know the ones not found
+type Refresh struct {
+	Resources []Resource
+
+	mu      sync.Mutex
+	written map[string]struct{}
+}
+
+// tfstate is the part of the TFState (version 4) needed
+// to refresh the resources, like the one of the diff
+type tfstate struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey   interface{}            `json:"index_key"`
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// Read returns the Refresh of the managed resources of the TFState
+// of r, sorted by the address. The ones on modules, with an index
+// or without ID are not refreshed as they were not imported
+func Read(r io.Reader) (*Refresh, error) {
+	var s tfstate
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, errors.Wrap(err, "invalid TFState")
+	}
+	if s.Version != 4 {
+		return nil, errors.Errorf("unsupported TFState version %d, only 4 is supported", s.Version)
+	}
+
+	rf := &Refresh{
+		Resources: make([]Resource, 0),
+		written:   make(map[string]struct{}),
+	}
+	for _, rs := range s.Resources {
+		if rs.Mode != "managed" || rs.Module != "" {
+			continue
+		}
+
+		for _, is := range rs.Instances {
+			id, _ := is.Attributes["id"].(string)
+			if is.IndexKey != nil || id == "" {
+				continue
+			}
+
+			rf.Resources = append(rf.Resources, Resource{Type: rs.Type, Name: rs.Name, ID: id})
+		}
+	}
+
+	sort.Slice(rf.Resources, func(i, j int) bool {
+		return rf.Resources[i].Address() < rf.Resources[j].Address()
+	})
+
+	return rf, nil
+}
+
+// Filter returns the filter.Filter
+// targeting only the Resources
+func (rf *Refresh) Filter() *filter.Filter {
+	targets := make([]string, 0, len(rf.Resources))
+	for _, r := range rf.Resources {
+		targets = append(targets, fmt.Sprintf("%s.%s", r.Type, r.ID))
+	}
+	return &filter.Filter{Targets: targets}
+}
+
+// Names returns the names of the Resources by 'TYPE.ID',
+// see provider.ImportOptions.Names
+func (rf *Refresh) Names() map[string]string {
+	names := make(map[string]string, len(rf.Resources))
+	for _, r := range rf.Resources {
+		names[fmt.Sprintf("%s.%s", r.Type, r.ID)] = r.Name
+	}
+	return names
+}
+
+// Middleware returns the writer.Middleware of the TFState
+// that keeps the resources written, see Missing
+func (rf *Refresh) Middleware() writer.Middleware {
+	return writer.InterceptWrite(func(key string, value interface{}) (interface{}, error) {
+		rf.mu.Lock()
+		defer rf.mu.Unlock()
+
+		rf.written[key] = struct{}{}
+
+		return value, nil
+	})
+}
+
+// Missing returns the Resources not written to the TFState,
+// as they do not exist anymore, see Middleware
+func (rf *Refresh) Missing() []Resource {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	res := make([]Resource, 0)
+	for _, r := range rf.Resources {
+		if _, ok := rf.written[r.Address()]; !ok {
+			res = append(res, r)
+		}
+	}
+	return res
+}
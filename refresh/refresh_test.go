@@ -0,0 +1,88 @@
+package refresh_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/refresh"
+	"github.com/cycloidio/terracognita/writer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tfstate(version int, resources ...string) string {
+	return fmt.Sprintf(`{
+  "version": %d,
+  "terraform_version": "0.13.5",
+  "serial": 1,
+  "lineage": "lineage",
+  "outputs": {},
+  "resources": [%s]
+}`, version, strings.Join(resources, ","))
+}
+
+func resource(mode, t, name, instances string) string {
+	return fmt.Sprintf(`{
+  "mode": %q,
+  "type": %q,
+  "name": %q,
+  "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+  "instances": [%s]
+}`, mode, t, name, instances)
+}
+
+// nopWriter is a writer.Writer that does nothing
+type nopWriter struct{}
+
+func (nopWriter) Write(key string, value interface{}) error { return nil }
+func (nopWriter) Has(key string) (bool, error)              { return false, nil }
+func (nopWriter) Sync() error                               { return nil }
+
+func TestRead(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		s := tfstate(4,
+			resource("managed", "aws_s3_bucket", "logs", `{"attributes": {"id": "logs"}}`),
+			resource("managed", "aws_instance", "front", `{"attributes": {"id": "i-1"}}`),
+			resource("data", "aws_ami", "ubuntu", `{"attributes": {"id": "ami-1"}}`),
+			resource("managed", "aws_instance", "web", `{"index_key": 0, "attributes": {"id": "i-2"}}`),
+			resource("managed", "aws_iam_user", "admin", `{"attributes": {}}`),
+		)
+
+		rf, err := refresh.Read(strings.NewReader(s))
+		require.NoError(t, err)
+
+		assert.Equal(t, []refresh.Resource{
+			{Type: "aws_instance", Name: "front", ID: "i-1"},
+			{Type: "aws_s3_bucket", Name: "logs", ID: "logs"},
+		}, rf.Resources)
+		assert.Equal(t, &filter.Filter{Targets: []string{"aws_instance.i-1", "aws_s3_bucket.logs"}}, rf.Filter())
+		assert.Equal(t, map[string]string{"aws_instance.i-1": "front", "aws_s3_bucket.logs": "logs"}, rf.Names())
+	})
+	t.Run("ErrorVersion", func(t *testing.T) {
+		_, err := refresh.Read(strings.NewReader(tfstate(3)))
+		assert.EqualError(t, err, "unsupported TFState version 3, only 4 is supported")
+	})
+	t.Run("ErrorInvalid", func(t *testing.T) {
+		_, err := refresh.Read(strings.NewReader("{"))
+		assert.Error(t, err)
+	})
+}
+
+func TestMissing(t *testing.T) {
+	s := tfstate(4,
+		resource("managed", "aws_instance", "front", `{"attributes": {"id": "i-1"}}`),
+		resource("managed", "aws_s3_bucket", "logs", `{"attributes": {"id": "logs"}}`),
+	)
+
+	rf, err := refresh.Read(strings.NewReader(s))
+	require.NoError(t, err)
+
+	w := writer.Chain(nopWriter{}, rf.Middleware())
+	require.NoError(t, w.Write("aws_instance.front", nil))
+
+	missing := rf.Missing()
+	assert.Equal(t, []refresh.Resource{{Type: "aws_s3_bucket", Name: "logs", ID: "logs"}}, missing)
+	assert.Equal(t, "aws_s3_bucket.logs (logs)", missing[0].String())
+}
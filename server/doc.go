@@ -0,0 +1,4 @@
+// Package server exposes Terracognita as an HTTP
+// service with a REST API to run the imports as
+// Jobs handled by a queue
+package server
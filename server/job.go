@@ -0,0 +1,502 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/hashicorp/go-uuid"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/hcl"
+	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/progress"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/state"
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// JobStatus is the status in which a Job is
+type JobStatus string
+
+// List of all the possible JobStatus
+const (
+	JobQueued   JobStatus = "queued"
+	JobRunning  JobStatus = "running"
+	JobFinished JobStatus = "finished"
+	JobFailed   JobStatus = "failed"
+)
+
+// JobRequest is the body expected to create a new Job
+type JobRequest struct {
+	// Provider is the name of the provider to import
+	// from (ex: aws)
+	Provider string `json:"provider"`
+
+	// Config are the provider specific configurations
+	// which are the same as the flags of the provider
+	// subcommand (ex: access-key)
+	Config map[string]string `json:"config"`
+
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+
+	// Tags are the list of tags to filter with
+	// the format 'NAME:VALUE'
+	Tags []string `json:"tags"`
+
+	// Targets are the specific resources to import
+	// with the format 'TYPE.ID'
+	Targets []string `json:"targets"`
+
+	// Filters are the filters of the resources of a type
+	// with the format 'TYPE: ATTRIBUTE=VALUE'
+	Filters []string `json:"filters"`
+
+	// NameRegex is the regular expression to filter
+	// the resources by the ID or name of them
+	NameRegex string `json:"name_regex"`
+
+	// Discover only lists the resources of the provider
+	// without importing them, the result can be
+	// fetched from the inventory of the Job
+	Discover bool `json:"discover"`
+
+	// HCL and TFState define which of the outputs
+	// have to be generated, if none is set both
+	// will be
+	HCL     bool `json:"hcl"`
+	TFState bool `json:"tfstate"`
+
+	// Strict fails the Job if any resource could not
+	// be read, except for the IgnoreErrors resource
+	// types and/or error classes
+	Strict       bool     `json:"strict"`
+	IgnoreErrors []string `json:"ignore_errors"`
+
+	// MinimalHCL writes only the needed
+	// attributes to the HCL
+	MinimalHCL bool `json:"minimal_hcl"`
+
+	// ValidateHCL validates the HCL with the
+	// schema of the resources before writing it
+	ValidateHCL bool `json:"validate_hcl"`
+
+	// SkipManaged skips the resources
+	// managed by other IaC
+	SkipManaged bool `json:"skip_managed"`
+}
+
+// Job is an Import that has been requested
+type Job struct {
+	ID        string     `json:"id"`
+	Provider  string     `json:"provider"`
+	Discover  bool       `json:"discover"`
+	Status    JobStatus  `json:"status"`
+	Error     string     `json:"error,omitempty"`
+	Progress  string     `json:"progress"`
+	CreatedAt time.Time  `json:"created_at"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+
+	// ProgressDetail is the progress of the
+	// types imported with the ETA of the Job
+	ProgressDetail *progress.Status `json:"progress_detail,omitempty"`
+
+	request JobRequest
+
+	// out is where the Import writes the progress
+	// and tracker keeps the detail of it
+	out     *output
+	tracker *progress.Tracker
+
+	hcl   *bytes.Buffer
+	state *bytes.Buffer
+
+	// inventory has all the IDs of the resources
+	// found grouped by type when it's a Discover Job
+	inventory map[string][]string
+}
+
+// newJob initializes a new Job from the jr
+func newJob(jr JobRequest) (*Job, error) {
+	if !jr.HCL && !jr.TFState && !jr.Discover {
+		jr.HCL = true
+		jr.TFState = true
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	j := &Job{
+		ID:        id,
+		Provider:  jr.Provider,
+		Discover:  jr.Discover,
+		Status:    JobQueued,
+		CreatedAt: time.Now(),
+		request:   jr,
+		out:       &output{},
+		tracker:   progress.NewTracker(),
+	}
+
+	if jr.Discover {
+		return j, nil
+	}
+
+	if jr.HCL {
+		j.hcl = &bytes.Buffer{}
+	}
+
+	if jr.TFState {
+		j.state = &bytes.Buffer{}
+	}
+
+	return j, nil
+}
+
+// snapshot returns a copy of the j with
+// the current progress of it
+func (j *Job) snapshot() *Job {
+	cj := *j
+	cj.Progress = j.out.String()
+	if !j.Discover && j.Status != JobQueued {
+		st := j.tracker.Status()
+		cj.ProgressDetail = &st
+	}
+	return &cj
+}
+
+// run runs the Import of the Job with the p
+func (j *Job) run(ctx context.Context, p provider.Provider, f *filter.Filter) error {
+	var hclW, stateW writer.Writer
+
+	if j.hcl != nil {
+		hclW = hcl.NewWriter(j.hcl)
+	}
+
+	if j.state != nil {
+		stateW = state.NewWriter(j.state)
+	}
+
+	return provider.Import(ctx, p, hclW, stateW, f, provider.ImportOptions{
+		Strict:       j.request.Strict,
+		IgnoreErrors: j.request.IgnoreErrors,
+		MinimalHCL:   j.request.MinimalHCL,
+		ValidateHCL:  j.request.ValidateHCL,
+		SkipManaged:  j.request.SkipManaged,
+		Progress:     progress.NewMulti(progress.NewCounter(j.out), j.tracker),
+	}, j.out)
+}
+
+// bundle returns a zip with the HCL and
+// TFState generated by the Job
+func (j *Job) bundle() ([]byte, error) {
+	buff := &bytes.Buffer{}
+	zw := zip.NewWriter(buff)
+
+	files := []struct {
+		name    string
+		content *bytes.Buffer
+	}{
+		{name: "terracognita.tf", content: j.hcl},
+		{name: "terraform.tfstate", content: j.state},
+	}
+
+	for _, f := range files {
+		if f.content == nil {
+			continue
+		}
+
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not create %s on the bundle", f.name)
+		}
+
+		if _, err := w.Write(f.content.Bytes()); err != nil {
+			return nil, errors.Wrapf(err, "could not write %s on the bundle", f.name)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, errors.Wrap(err, "could not close the bundle")
+	}
+
+	return buff.Bytes(), nil
+}
+
+// output is an io.Writer that keeps the
+// last line written to it, as the progress
+// of the Import is always rewritten on
+// the same line
+type output struct {
+	mx   sync.Mutex
+	last []byte
+	line []byte
+}
+
+func (o *output) Write(b []byte) (int, error) {
+	o.mx.Lock()
+	defer o.mx.Unlock()
+
+	for _, c := range b {
+		switch c {
+		case '\r', '\n':
+			if len(o.line) != 0 {
+				o.last = o.line
+				o.line = nil
+			}
+		default:
+			o.line = append(o.line, c)
+		}
+	}
+
+	return len(b), nil
+}
+
+// String returns the last line written or the
+// one that is being written
+func (o *output) String() string {
+	o.mx.Lock()
+	defer o.mx.Unlock()
+
+	if len(o.line) != 0 {
+		return string(o.line)
+	}
+
+	return string(o.last)
+}
+
+// queue keeps all the Jobs and runs them
+// on a fixed number of workers
+type queue struct {
+	mx   sync.RWMutex
+	jobs map[string]*Job
+
+	pending chan *Job
+	done    chan struct{}
+
+	providers map[string]Provider
+
+	// ttl and max are the time and number
+	// of the ended Jobs kept (see prune)
+	ttl time.Duration
+	max int
+}
+
+func newQueue(providers map[string]Provider, workers, size int, ttl time.Duration, max int) *queue {
+	q := &queue{
+		jobs:      make(map[string]*Job),
+		pending:   make(chan *Job, size),
+		done:      make(chan struct{}),
+		providers: providers,
+		ttl:       ttl,
+		max:       max,
+	}
+
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+
+	go q.expire()
+
+	return q
+}
+
+// expire prunes the Jobs periodically
+// until the queue is closed
+func (q *queue) expire() {
+	every := time.Minute
+	if q.ttl < every {
+		every = q.ttl
+	}
+
+	t := time.NewTicker(every)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			q.mx.Lock()
+			q.prune(time.Now())
+			q.mx.Unlock()
+		case <-q.done:
+			return
+		}
+	}
+}
+
+// prune removes the Jobs that ended before the
+// ttl and the oldest ones over the max, the
+// queued and running ones are always kept.
+// It has to be called with the lock
+func (q *queue) prune(now time.Time) {
+	ended := make([]*Job, 0)
+	for id, j := range q.jobs {
+		if j.EndedAt == nil {
+			continue
+		}
+		if now.Sub(*j.EndedAt) > q.ttl {
+			delete(q.jobs, id)
+			continue
+		}
+		ended = append(ended, j)
+	}
+
+	if len(ended) <= q.max {
+		return
+	}
+
+	sort.Slice(ended, func(i, k int) bool { return ended[i].EndedAt.Before(*ended[k].EndedAt) })
+	for _, j := range ended[:len(ended)-q.max] {
+		delete(q.jobs, j.ID)
+	}
+}
+
+// add adds the j to the queue, if the queue
+// is full it'll return false
+func (q *queue) add(j *Job) bool {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	q.prune(time.Now())
+
+	select {
+	case q.pending <- j:
+		q.jobs[j.ID] = j
+		return true
+	default:
+		return false
+	}
+}
+
+// get returns a copy of the Job with the id
+func (q *queue) get(id string) (*Job, bool) {
+	q.mx.RLock()
+	defer q.mx.RUnlock()
+
+	j, ok := q.jobs[id]
+	if !ok {
+		return nil, false
+	}
+
+	return j.snapshot(), true
+}
+
+// list returns a copy of all the Jobs
+func (q *queue) list() []*Job {
+	q.mx.RLock()
+	defer q.mx.RUnlock()
+
+	jobs := make([]*Job, 0, len(q.jobs))
+	for _, j := range q.jobs {
+		jobs = append(jobs, j.snapshot())
+	}
+
+	return jobs
+}
+
+// setStatus changes the status of the j and updates the
+// started/ended times
+func (q *queue) setStatus(j *Job, s JobStatus, err error) {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	now := time.Now()
+	j.Status = s
+	switch s {
+	case JobRunning:
+		j.StartedAt = &now
+	case JobFinished, JobFailed:
+		j.EndedAt = &now
+	}
+
+	if err != nil {
+		j.Error = err.Error()
+	}
+}
+
+// work runs the pending Jobs
+func (q *queue) work() {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "server.queue.work")
+
+	for j := range q.pending {
+		logger := kitlog.With(logger, "job", j.ID, "provider", j.Provider)
+		logger.Log("msg", "running job")
+
+		q.setStatus(j, JobRunning, nil)
+
+		err := q.runJob(j)
+		if err != nil {
+			logger.Log("msg", "job failed", "error", err)
+			q.setStatus(j, JobFailed, err)
+			continue
+		}
+
+		logger.Log("msg", "job finished")
+		q.setStatus(j, JobFinished, nil)
+	}
+}
+
+func (q *queue) runJob(j *Job) error {
+	ctx := context.Background()
+
+	p, err := q.providers[j.Provider].New(ctx, j.request.Config)
+	if err != nil {
+		return err
+	}
+
+	tags, err := parseTags(j.request.Tags)
+	if err != nil {
+		return err
+	}
+
+	rules, err := filter.ParseRules(j.request.Filters)
+	if err != nil {
+		return err
+	}
+
+	f := &filter.Filter{
+		Tags:    tags,
+		Include: j.request.Include,
+		Exclude: j.request.Exclude,
+		Targets: j.request.Targets,
+		Rules:   rules,
+	}
+
+	if j.request.NameRegex != "" {
+		f.NameRegex, err = regexp.Compile(j.request.NameRegex)
+		if err != nil {
+			return errors.Wrap(err, "invalid name_regex")
+		}
+	}
+
+	if j.Discover {
+		inventory, err := provider.Discover(ctx, p, f, j.out)
+		if err != nil {
+			return err
+		}
+
+		q.mx.Lock()
+		j.inventory = inventory
+		q.mx.Unlock()
+
+		return nil
+	}
+
+	return j.run(ctx, p, f)
+}
+
+// close stops all the workers
+func (q *queue) close() {
+	close(q.pending)
+	close(q.done)
+}
@@ -0,0 +1,360 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/tag"
+)
+
+// Provider is the definition of a provider that
+// can be used on the Server
+type Provider struct {
+	// New initializes the provider.Provider with the
+	// cfg sent on the JobRequest
+	New func(ctx context.Context, cfg map[string]string) (provider.Provider, error)
+
+	// ResourceTypes returns all the supported resource
+	// types of the provider
+	ResourceTypes func() []string
+
+	// Required is the list of keys that are required
+	// on the JobRequest.Config
+	Required []string
+}
+
+// Server is the HTTP server that exposes the
+// Import via a REST API
+type Server struct {
+	providers map[string]Provider
+	queue     *queue
+	mux       *http.ServeMux
+	token     string
+}
+
+// Options are the options to configure the Server
+type Options struct {
+	// Workers is the number of Jobs that can
+	// run at the same time
+	Workers int
+
+	// QueueSize is the number of Jobs that can
+	// be waiting to be run
+	QueueSize int
+
+	// Token is the token required on the 'Authorization: Bearer'
+	// header of all the requests to the API, if empty the
+	// requests are not authenticated
+	Token string
+
+	// JobsTTL is how long the ended Jobs are kept, 24h by
+	// default, and MaxJobs the number of ended Jobs kept,
+	// 100 by default, the oldest ones are removed first
+	JobsTTL time.Duration
+	MaxJobs int
+}
+
+// New returns a new Server that will be able to
+// import from the providers
+func New(providers map[string]Provider, opt Options) *Server {
+	if opt.Workers <= 0 {
+		opt.Workers = 1
+	}
+
+	if opt.QueueSize <= 0 {
+		opt.QueueSize = 100
+	}
+
+	if opt.JobsTTL <= 0 {
+		opt.JobsTTL = 24 * time.Hour
+	}
+
+	if opt.MaxJobs <= 0 {
+		opt.MaxJobs = 100
+	}
+
+	s := &Server{
+		providers: providers,
+		queue:     newQueue(providers, opt.Workers, opt.QueueSize, opt.JobsTTL, opt.MaxJobs),
+		mux:       http.NewServeMux(),
+		token:     opt.Token,
+	}
+
+	s.mux.HandleFunc("/providers", s.handleProviders)
+	s.mux.HandleFunc("/providers/", s.handleProviders)
+	s.mux.HandleFunc("/jobs", s.handleJobs)
+	s.mux.HandleFunc("/jobs/", s.handleJobs)
+	s.mux.HandleFunc("/", s.handleUI)
+
+	return s
+}
+
+// ServeHTTP implements the http.Handler interface
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := log.Get()
+	logger.Log("func", "server.ServeHTTP", "method", r.Method, "path", r.URL.Path)
+
+	// The UI has no data, it asks
+	// for the token to call the API
+	if r.URL.Path != "/" && !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, errors.WithStack(errcode.ErrServerUnauthorized))
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// authorized checks if the r has the token
+// of the Server, if it has one
+func (s *Server) authorized(r *http.Request) bool {
+	if s.token == "" {
+		return true
+	}
+
+	t := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(t), []byte(s.token)) == 1
+}
+
+// Close stops the workers of the Server
+func (s *Server) Close() {
+	s.queue.close()
+}
+
+// handleProviders handles:
+// * GET /providers
+// * GET /providers/{provider}/resources
+func (s *Server) handleProviders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	parts := splitPath(r.URL.Path, "/providers")
+	switch len(parts) {
+	case 0:
+		names := make([]string, 0, len(s.providers))
+		for n := range s.providers {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		writeJSON(w, http.StatusOK, names)
+	case 2:
+		if parts[1] != "resources" {
+			http.NotFound(w, r)
+			return
+		}
+
+		p, ok := s.providers[parts[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, errors.Wrapf(errcode.ErrServerProviderNotSupported, "with name %q", parts[0]))
+			return
+		}
+
+		writeJSON(w, http.StatusOK, p.ResourceTypes())
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleJobs handles:
+// * GET /jobs
+// * POST /jobs
+// * GET /jobs/{id}
+// * GET /jobs/{id}/hcl
+// * GET /jobs/{id}/tfstate
+// * GET /jobs/{id}/bundle
+// * GET /jobs/{id}/inventory
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	parts := splitPath(r.URL.Path, "/jobs")
+
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, s.queue.list())
+		case http.MethodPost:
+			s.createJob(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, errors.Errorf("method %s not allowed", r.Method))
+		}
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	j, ok := s.queue.get(parts[0])
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.Wrapf(errcode.ErrServerJobNotFound, "with id %q", parts[0]))
+		return
+	}
+
+	switch len(parts) {
+	case 1:
+		writeJSON(w, http.StatusOK, j)
+	case 2:
+		if j.Status != JobFinished && (parts[1] == "hcl" || parts[1] == "tfstate" || parts[1] == "bundle" || parts[1] == "inventory") {
+			writeError(w, http.StatusConflict, errors.Wrapf(errcode.ErrServerJobNotFinished, "with status %q", j.Status))
+			return
+		}
+
+		var (
+			content []byte
+			name    string
+		)
+		switch parts[1] {
+		case "inventory":
+			if !j.Discover {
+				writeError(w, http.StatusBadRequest, errors.WithStack(errcode.ErrServerJobNoOutput))
+				return
+			}
+			writeJSON(w, http.StatusOK, j.inventory)
+			return
+		case "bundle":
+			if j.hcl == nil && j.state == nil {
+				writeError(w, http.StatusBadRequest, errors.WithStack(errcode.ErrServerJobNoOutput))
+				return
+			}
+			b, err := j.bundle()
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			content, name = b, fmt.Sprintf("terracognita-%s.zip", j.ID)
+		case "hcl":
+			if j.hcl == nil {
+				writeError(w, http.StatusBadRequest, errors.WithStack(errcode.ErrServerJobNoOutput))
+				return
+			}
+			content, name = j.hcl.Bytes(), "terracognita.tf"
+		case "tfstate":
+			if j.state == nil {
+				writeError(w, http.StatusBadRequest, errors.WithStack(errcode.ErrServerJobNoOutput))
+				return
+			}
+			content, name = j.state.Bytes(), "terraform.tfstate"
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		w.Write(content)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) createJob(w http.ResponseWriter, r *http.Request) {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "server.createJob")
+
+	var jr JobRequest
+	if err := json.NewDecoder(r.Body).Decode(&jr); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid body"))
+		return
+	}
+
+	p, ok := s.providers[jr.Provider]
+	if !ok {
+		writeError(w, http.StatusBadRequest, errors.Wrapf(errcode.ErrServerProviderNotSupported, "with name %q", jr.Provider))
+		return
+	}
+
+	for _, k := range p.Required {
+		if jr.Config[k] == "" {
+			writeError(w, http.StatusBadRequest, errors.Wrapf(errcode.ErrServerRequiredConfig, "with key %q", k))
+			return
+		}
+	}
+
+	if _, err := parseTags(jr.Tags); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	j, err := newJob(jr)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !s.queue.add(j) {
+		writeError(w, http.StatusServiceUnavailable, errors.WithStack(errcode.ErrServerQueueFull))
+		return
+	}
+
+	logger.Log("msg", "job queued", "job", j.ID, "provider", j.Provider)
+
+	cj, _ := s.queue.get(j.ID)
+	writeJSON(w, http.StatusAccepted, cj)
+}
+
+// handleUI serves the web UI on:
+// * GET /
+func (s *Server) handleUI(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	io.WriteString(w, uiHTML)
+}
+
+// parseTags parses the tags with the format 'NAME:VALUE'
+func parseTags(raw []string) ([]tag.Tag, error) {
+	tags := make([]tag.Tag, 0, len(raw))
+	for _, t := range raw {
+		values := strings.Split(t, ":")
+		if len(values) != 2 {
+			return nil, errors.New("invalid format for tags, the expected format is 'NAME:VALUE'")
+		}
+		tags = append(tags, tag.Tag{Name: values[0], Value: values[1]})
+	}
+
+	return tags, nil
+}
+
+// splitPath removes the prefix from the path and
+// returns the rest of the elements
+func splitPath(path, prefix string) []string {
+	path = strings.Trim(strings.TrimPrefix(path, prefix), "/")
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, "/")
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,320 @@
+package server_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/server"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T, p provider.Provider, err error) (*server.Server, *httptest.Server) {
+	return newServerWithOptions(t, p, err, server.Options{})
+}
+
+func newServerWithOptions(t *testing.T, p provider.Provider, err error, opt server.Options) (*server.Server, *httptest.Server) {
+	s := server.New(map[string]server.Provider{
+		"aws": server.Provider{
+			Required:      []string{"region"},
+			ResourceTypes: func() []string { return []string{"aws_instance"} },
+			New: func(ctx context.Context, cfg map[string]string) (provider.Provider, error) {
+				return p, err
+			},
+		},
+	}, opt)
+
+	return s, httptest.NewServer(s)
+}
+
+func waitJob(t *testing.T, url, id string) server.Job {
+	var j server.Job
+	for i := 0; i < 100; i++ {
+		res, err := http.Get(url + "/jobs/" + id)
+		require.NoError(t, err)
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&j))
+		res.Body.Close()
+
+		if j.Status == server.JobFinished || j.Status == server.JobFailed {
+			return j
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return j
+}
+
+func TestServer(t *testing.T) {
+	t.Run("Providers", func(t *testing.T) {
+		s, ts := newServer(t, nil, nil)
+		defer s.Close()
+		defer ts.Close()
+
+		res, err := http.Get(ts.URL + "/providers")
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var names []string
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&names))
+		assert.Equal(t, []string{"aws"}, names)
+	})
+	t.Run("ProviderResources", func(t *testing.T) {
+		s, ts := newServer(t, nil, nil)
+		defer s.Close()
+		defer ts.Close()
+
+		res, err := http.Get(ts.URL + "/providers/aws/resources")
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		var rts []string
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&rts))
+		assert.Equal(t, []string{"aws_instance"}, rts)
+
+		res, err = http.Get(ts.URL + "/providers/potato/resources")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+	t.Run("Job", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			p    = mock.NewProvider(ctrl)
+		)
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{})
+
+		s, ts := newServer(t, p, nil)
+		defer s.Close()
+		defer ts.Close()
+
+		res, err := http.Post(ts.URL+"/jobs", "application/json", bytes.NewBufferString(`{"provider":"aws","config":{"region":"eu-west-1"},"hcl":true}`))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusAccepted, res.StatusCode)
+
+		var j server.Job
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&j))
+		res.Body.Close()
+
+		j = waitJob(t, ts.URL, j.ID)
+		assert.Equal(t, server.JobFinished, j.Status)
+
+		res, err = http.Get(ts.URL + "/jobs/" + j.ID + "/hcl")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		res, err = http.Get(ts.URL + "/jobs/" + j.ID + "/tfstate")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+	t.Run("JobFailed", func(t *testing.T) {
+		s, ts := newServer(t, nil, errors.New("invalid credentials"))
+		defer s.Close()
+		defer ts.Close()
+
+		res, err := http.Post(ts.URL+"/jobs", "application/json", bytes.NewBufferString(`{"provider":"aws","config":{"region":"eu-west-1"}}`))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusAccepted, res.StatusCode)
+
+		var j server.Job
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&j))
+		res.Body.Close()
+
+		j = waitJob(t, ts.URL, j.ID)
+		assert.Equal(t, server.JobFailed, j.Status)
+		assert.Equal(t, "invalid credentials", j.Error)
+
+		res, err = http.Get(ts.URL + "/jobs/" + j.ID + "/hcl")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, res.StatusCode)
+	})
+	t.Run("Discover", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			p    = mock.NewProvider(ctrl)
+			r    = mock.NewResource(ctrl)
+		)
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{"aws_instance"})
+		p.EXPECT().HasResourceType("aws_instance").Return(true)
+		p.EXPECT().Resources(gomock.Any(), "aws_instance", gomock.Any()).Return([]provider.Resource{r}, nil)
+		r.EXPECT().ID().Return("i-123")
+
+		s, ts := newServer(t, p, nil)
+		defer s.Close()
+		defer ts.Close()
+
+		res, err := http.Post(ts.URL+"/jobs", "application/json", bytes.NewBufferString(`{"provider":"aws","config":{"region":"eu-west-1"},"discover":true}`))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusAccepted, res.StatusCode)
+
+		var j server.Job
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&j))
+		res.Body.Close()
+
+		j = waitJob(t, ts.URL, j.ID)
+		assert.Equal(t, server.JobFinished, j.Status)
+
+		res, err = http.Get(ts.URL + "/jobs/" + j.ID + "/inventory")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var inventory map[string][]string
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&inventory))
+		res.Body.Close()
+		assert.Equal(t, map[string][]string{"aws_instance": []string{"i-123"}}, inventory)
+
+		res, err = http.Get(ts.URL + "/jobs/" + j.ID + "/bundle")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+	t.Run("Bundle", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			p    = mock.NewProvider(ctrl)
+		)
+		defer ctrl.Finish()
+
+		p.EXPECT().ResourceTypes().Return([]string{})
+
+		s, ts := newServer(t, p, nil)
+		defer s.Close()
+		defer ts.Close()
+
+		res, err := http.Post(ts.URL+"/jobs", "application/json", bytes.NewBufferString(`{"provider":"aws","config":{"region":"eu-west-1"}}`))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusAccepted, res.StatusCode)
+
+		var j server.Job
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&j))
+		res.Body.Close()
+
+		j = waitJob(t, ts.URL, j.ID)
+		assert.Equal(t, server.JobFinished, j.Status)
+
+		res, err = http.Get(ts.URL + "/jobs/" + j.ID + "/bundle")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		b, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+
+		zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(zr.File))
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"terracognita.tf", "terraform.tfstate"}, names)
+
+		res, err = http.Get(ts.URL + "/jobs/" + j.ID + "/inventory")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+	t.Run("UI", func(t *testing.T) {
+		s, ts := newServer(t, nil, nil)
+		defer s.Close()
+		defer ts.Close()
+
+		res, err := http.Get(ts.URL + "/")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "text/html; charset=utf-8", res.Header.Get("Content-Type"))
+
+		res, err = http.Get(ts.URL + "/potato")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+	t.Run("ErrRequiredConfig", func(t *testing.T) {
+		s, ts := newServer(t, nil, nil)
+		defer s.Close()
+		defer ts.Close()
+
+		res, err := http.Post(ts.URL+"/jobs", "application/json", bytes.NewBufferString(`{"provider":"aws"}`))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+	t.Run("MaxJobs", func(t *testing.T) {
+		s, ts := newServerWithOptions(t, nil, errors.New("invalid credentials"), server.Options{MaxJobs: 1})
+		defer s.Close()
+		defer ts.Close()
+
+		ids := make([]string, 0, 2)
+		for i := 0; i < 2; i++ {
+			res, err := http.Post(ts.URL+"/jobs", "application/json", bytes.NewBufferString(`{"provider":"aws","config":{"region":"eu-west-1"}}`))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusAccepted, res.StatusCode)
+
+			var j server.Job
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&j))
+			res.Body.Close()
+
+			waitJob(t, ts.URL, j.ID)
+			ids = append(ids, j.ID)
+		}
+
+		// The first one is removed when the third
+		// is added, as only 1 ended job is kept
+		res, err := http.Post(ts.URL+"/jobs", "application/json", bytes.NewBufferString(`{"provider":"aws","config":{"region":"eu-west-1"}}`))
+		require.NoError(t, err)
+		res.Body.Close()
+
+		res, err = http.Get(ts.URL + "/jobs/" + ids[0])
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+		res, err = http.Get(ts.URL + "/jobs/" + ids[1])
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+	t.Run("ErrUnauthorized", func(t *testing.T) {
+		s, ts := newServerWithOptions(t, nil, nil, server.Options{Token: "secret"})
+		defer s.Close()
+		defer ts.Close()
+
+		res, err := http.Get(ts.URL + "/providers")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+		req, err := http.NewRequest(http.MethodGet, ts.URL+"/providers", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer potato")
+		res, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+		req.Header.Set("Authorization", "Bearer secret")
+		res, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		// The UI is served to ask for the token
+		res, err = http.Get(ts.URL + "/")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+	t.Run("ErrJobNotFound", func(t *testing.T) {
+		s, ts := newServer(t, nil, nil)
+		defer s.Close()
+		defer ts.Close()
+
+		res, err := http.Get(ts.URL + "/jobs/potato")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
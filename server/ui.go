@@ -0,0 +1,229 @@
+package server
+
+// uiHTML is the web UI served by the Server, it uses the
+// REST API to discover the resources of a provider, shows
+// them as a tree that can be filtered, and imports the
+// selected ones
+const uiHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Terracognita</title>
+<style>
+  body { font-family: sans-serif; margin: 2em; color: #222; }
+  fieldset { margin-bottom: 1em; }
+  label { display: block; margin: .5em 0 .2em; }
+  textarea, input[type=text] { width: 100%; box-sizing: border-box; font-family: monospace; }
+  #tree ul { list-style: none; padding-left: 1.5em; margin: 0; }
+  #tree summary { cursor: pointer; }
+  #tree .count { color: #888; }
+  .hidden { display: none; }
+  #status { font-family: monospace; white-space: pre; }
+</style>
+</head>
+<body>
+<h1>Terracognita</h1>
+
+<fieldset>
+  <legend>Provider</legend>
+  <label for="provider">Provider</label>
+  <select id="provider"></select>
+  <label for="config">Config (JSON with the same keys as the provider flags)</label>
+  <textarea id="config" rows="5">{}</textarea>
+  <label for="include">Include (comma separated resource types, empty for all)</label>
+  <input type="text" id="include">
+  <label for="tags">Tags (comma separated NAME:VALUE)</label>
+  <input type="text" id="tags">
+  <p><button id="discover">Discover</button></p>
+</fieldset>
+
+<fieldset>
+  <legend>Inventory</legend>
+  <label for="search">Filter</label>
+  <input type="text" id="search" placeholder="aws_instance or an ID">
+  <p>
+    <button id="select-all">Select visible</button>
+    <button id="select-none">Unselect all</button>
+  </p>
+  <div id="tree"></div>
+  <p><button id="generate" disabled>Generate HCL and TFState</button></p>
+</fieldset>
+
+<fieldset>
+  <legend>Status</legend>
+  <div id="status">Idle</div>
+  <div id="downloads" class="hidden">
+    <a id="download-hcl">HCL</a> |
+    <a id="download-tfstate">TFState</a> |
+    <a id="download-bundle">Bundle</a>
+  </div>
+</fieldset>
+
+<script>
+(function() {
+  var $ = function(id) { return document.getElementById(id); };
+  var split = function(v) { return v.split(",").map(function(s) { return s.trim(); }).filter(function(s) { return s !== ""; }); };
+
+  // The token of the API is read from the '#token=' of
+  // the URL, printed by the server, or asked once
+  var token = (location.hash.match(/token=([^&]+)/) || [])[1] || sessionStorage.getItem("token") || prompt("Token of the API") || "";
+  sessionStorage.setItem("token", token);
+  history.replaceState(null, "", location.pathname);
+
+  function send(method, url, body) {
+    return fetch(url, {
+      method: method,
+      headers: { "Content-Type": "application/json", "Authorization": "Bearer " + token },
+      body: body ? JSON.stringify(body) : undefined
+    });
+  }
+
+  function request(method, url, body) {
+    return send(method, url, body).then(function(res) {
+      return res.json().then(function(data) {
+        if (!res.ok) { throw new Error(data.error || res.statusText); }
+        return data;
+      });
+    });
+  }
+
+  function baseRequest() {
+    return {
+      provider: $("provider").value,
+      config: JSON.parse($("config").value || "{}"),
+      include: split($("include").value),
+      tags: split($("tags").value)
+    };
+  }
+
+  function wait(job) {
+    return new Promise(function(resolve, reject) {
+      (function poll() {
+        request("GET", "/jobs/" + job.id).then(function(j) {
+          $("status").textContent = j.status + (j.progress ? ": " + j.progress : "");
+          if (j.status === "finished") { return resolve(j); }
+          if (j.status === "failed") { return reject(new Error(j.error)); }
+          setTimeout(poll, 1000);
+        }, reject);
+      })();
+    });
+  }
+
+  function fail(err) { $("status").textContent = "Error: " + err.message; }
+
+  function renderTree(inventory) {
+    var tree = $("tree");
+    tree.innerHTML = "";
+    Object.keys(inventory).sort().forEach(function(type) {
+      var ids = inventory[type] || [];
+      if (ids.length === 0) { return; }
+
+      var details = document.createElement("details");
+      details.dataset.type = type;
+      var summary = document.createElement("summary");
+      var all = document.createElement("input");
+      all.type = "checkbox";
+      all.addEventListener("change", function() {
+        details.querySelectorAll("li:not(.hidden) input").forEach(function(c) { c.checked = all.checked; });
+      });
+      summary.appendChild(all);
+      summary.appendChild(document.createTextNode(" " + type + " "));
+      var count = document.createElement("span");
+      count.className = "count";
+      count.textContent = "(" + ids.length + ")";
+      summary.appendChild(count);
+      details.appendChild(summary);
+
+      var ul = document.createElement("ul");
+      ids.forEach(function(id) {
+        var li = document.createElement("li");
+        var label = document.createElement("label");
+        var c = document.createElement("input");
+        c.type = "checkbox";
+        c.value = type + "." + id;
+        label.appendChild(c);
+        label.appendChild(document.createTextNode(" " + id));
+        li.dataset.search = (type + " " + id).toLowerCase();
+        li.appendChild(label);
+        ul.appendChild(li);
+      });
+      details.appendChild(ul);
+      tree.appendChild(details);
+    });
+    $("generate").disabled = false;
+    filterTree();
+  }
+
+  function filterTree() {
+    var q = $("search").value.toLowerCase();
+    $("tree").querySelectorAll("details").forEach(function(d) {
+      var visible = 0;
+      d.querySelectorAll("li").forEach(function(li) {
+        var show = q === "" || li.dataset.search.indexOf(q) !== -1;
+        li.classList.toggle("hidden", !show);
+        if (show) { visible++; }
+      });
+      d.classList.toggle("hidden", visible === 0);
+      if (q !== "") { d.open = visible > 0; }
+    });
+  }
+
+  request("GET", "/providers").then(function(names) {
+    names.forEach(function(n) {
+      var o = document.createElement("option");
+      o.value = o.textContent = n;
+      $("provider").appendChild(o);
+    });
+  }, fail);
+
+  $("search").addEventListener("input", filterTree);
+
+  $("select-all").addEventListener("click", function() {
+    $("tree").querySelectorAll("details:not(.hidden) li:not(.hidden) input").forEach(function(c) { c.checked = true; });
+  });
+
+  $("select-none").addEventListener("click", function() {
+    $("tree").querySelectorAll("input").forEach(function(c) { c.checked = false; });
+  });
+
+  $("discover").addEventListener("click", function() {
+    var body;
+    try { body = baseRequest(); } catch (err) { return fail(err); }
+    body.discover = true;
+    $("downloads").classList.add("hidden");
+    request("POST", "/jobs", body).then(wait).then(function(job) {
+      return request("GET", "/jobs/" + job.id + "/inventory");
+    }).then(renderTree).catch(fail);
+  });
+
+  $("generate").addEventListener("click", function() {
+    var targets = [];
+    $("tree").querySelectorAll("li input:checked").forEach(function(c) { targets.push(c.value); });
+    if (targets.length === 0) { return fail(new Error("no resources selected")); }
+
+    var body;
+    try { body = baseRequest(); } catch (err) { return fail(err); }
+    body.include = [];
+    body.targets = targets;
+    body.hcl = true;
+    body.tfstate = true;
+    request("POST", "/jobs", body).then(wait).then(function(job) {
+      // The downloads need the token so
+      // those are fetched as blobs
+      return Promise.all(["hcl", "tfstate", "bundle"].map(function(o) {
+        return send("GET", "/jobs/" + job.id + "/" + o).then(function(res) {
+          return res.blob();
+        }).then(function(b) {
+          $("download-" + o).href = URL.createObjectURL(b);
+          $("download-" + o).download = { hcl: "terracognita.tf", tfstate: "terraform.tfstate", bundle: "terracognita-" + job.id + ".zip" }[o];
+        });
+      }));
+    }).then(function() {
+      $("downloads").classList.remove("hidden");
+    }).catch(fail);
+  });
+})();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,4 @@
+// Package sops encrypts the outputs with the SOPS binary
+// (https://github.com/getsops/sops) so the values read from
+// the providers are never written in plain text to the disk
+package sops
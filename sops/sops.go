@@ -0,0 +1,115 @@
+package sops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// DefaultBinary is the sops binary used
+// if none is given, searched on the PATH
+const DefaultBinary = "sops"
+
+// keyFlags are the flags of sops for each type of key
+var keyFlags = map[string]string{
+	"age":     "--age",
+	"aws-kms": "--kms",
+	"gcp-kms": "--gcp-kms",
+}
+
+// Key is the key used by sops to encrypt
+type Key struct {
+	// Type is one of: age, aws-kms, gcp-kms
+	Type string
+
+	// Value is the recipient of age or the ID
+	// of the KMS key (ex: the ARN on AWS)
+	Value string
+}
+
+// ParseKey parses the s with the format TYPE:VALUE (ex: age:age1...,
+// aws-kms:arn:aws:kms:... or gcp-kms:projects/.../cryptoKeys/...)
+func ParseKey(s string) (Key, error) {
+	kv := strings.SplitN(s, ":", 2)
+	if len(kv) != 2 || kv[1] == "" {
+		return Key{}, fmt.Errorf("invalid key %q, expected the format TYPE:VALUE", s)
+	}
+
+	if _, ok := keyFlags[kv[0]]; !ok {
+		return Key{}, fmt.Errorf("invalid key type %q, the valid ones are: age, aws-kms, gcp-kms", kv[0])
+	}
+
+	return Key{Type: kv[0], Value: kv[1]}, nil
+}
+
+// Encrypt encrypts the b of the format (json, yaml or binary) with the
+// sops bin and the k, the content is given to sops on the Stdin so it's
+// never on the disk in plain text
+func Encrypt(ctx context.Context, bin string, k Key, format string, b []byte) ([]byte, error) {
+	if bin == "" {
+		bin = DefaultBinary
+	}
+
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return nil, errors.Wrapf(errcode.ErrSOPSNotFound, "%s: %s", bin, err)
+	}
+
+	var out, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, path, "--encrypt", keyFlags[k.Type], k.Value, "--input-type", format, "--output-type", format, "/dev/stdin")
+	cmd.Env = os.Environ()
+	cmd.Stdin = bytes.NewReader(b)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(errcode.ErrSOPSFailed, "%s\n%s", err, stderr.String())
+	}
+
+	return out.Bytes(), nil
+}
+
+// Writer buffers the content written to it and on
+// the Close writes it encrypted by sops to the w, so
+// the plain content is only on memory
+type Writer struct {
+	w      io.Writer
+	bin    string
+	k      Key
+	format string
+	buf    bytes.Buffer
+}
+
+// NewWriter returns a Writer that writes to w the
+// content of the format encrypted by the sops bin
+// with the k
+func NewWriter(w io.Writer, bin string, k Key, format string) *Writer {
+	return &Writer{w: w, bin: bin, k: k, format: format}
+}
+
+// Write buffers the p to encrypt it on the Close
+func (w *Writer) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// Close encrypts the content written
+// and writes it to the io.Writer
+func (w *Writer) Close() error {
+	b, err := Encrypt(context.Background(), w.bin, w.k, w.format, w.buf.Bytes())
+	if err != nil {
+		return err
+	}
+	w.buf.Reset()
+
+	_, err = w.w.Write(b)
+	return err
+}
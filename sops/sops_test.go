@@ -0,0 +1,94 @@
+package sops_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/sops"
+)
+
+// fakeSOPS writes a sops script to a new directory that
+// writes the arguments and the Stdin, or fails with code
+func fakeSOPS(t *testing.T, code string) (string, func()) {
+	dir, err := ioutil.TempDir("", "terracognita-sops-test")
+	require.NoError(t, err)
+
+	bin := filepath.Join(dir, "sops")
+	script := `#!/bin/sh
+echo "$@"
+cat
+exit ` + code + `
+`
+	require.NoError(t, ioutil.WriteFile(bin, []byte(script), 0755))
+
+	return bin, func() { os.RemoveAll(dir) }
+}
+
+func TestParseKey(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		k, err := sops.ParseKey("aws-kms:arn:aws:kms:eu-west-1:123456789012:key/abc")
+		require.NoError(t, err)
+		assert.Equal(t, sops.Key{Type: "aws-kms", Value: "arn:aws:kms:eu-west-1:123456789012:key/abc"}, k)
+	})
+
+	t.Run("ErrorFormat", func(t *testing.T) {
+		_, err := sops.ParseKey("age")
+		assert.Error(t, err)
+	})
+
+	t.Run("ErrorType", func(t *testing.T) {
+		_, err := sops.ParseKey("pgp:ABC")
+		assert.Error(t, err)
+	})
+}
+
+func TestEncrypt(t *testing.T) {
+	ctx := context.Background()
+	k := sops.Key{Type: "age", Value: "age1abc"}
+
+	t.Run("Success", func(t *testing.T) {
+		bin, clean := fakeSOPS(t, "0")
+		defer clean()
+
+		b, err := sops.Encrypt(ctx, bin, k, "json", []byte(`{"a":1}`))
+		require.NoError(t, err)
+		assert.Equal(t, "--encrypt --age age1abc --input-type json --output-type json /dev/stdin\n{\"a\":1}", string(b))
+	})
+
+	t.Run("ErrorFailed", func(t *testing.T) {
+		bin, clean := fakeSOPS(t, "1")
+		defer clean()
+
+		_, err := sops.Encrypt(ctx, bin, k, "json", []byte(`{}`))
+		assert.Equal(t, errcode.ErrSOPSFailed, errors.Cause(err))
+	})
+
+	t.Run("ErrorNotFound", func(t *testing.T) {
+		_, err := sops.Encrypt(ctx, "/not/found/sops", k, "json", []byte(`{}`))
+		assert.Equal(t, errcode.ErrSOPSNotFound, errors.Cause(err))
+	})
+}
+
+func TestWriter(t *testing.T) {
+	bin, clean := fakeSOPS(t, "0")
+	defer clean()
+
+	var out bytes.Buffer
+	w := sops.NewWriter(&out, bin, sops.Key{Type: "gcp-kms", Value: "projects/p/locations/global/keyRings/r/cryptoKeys/k"}, "json")
+
+	_, err := w.Write([]byte(`{"a":1}`))
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+
+	require.NoError(t, w.Close())
+	assert.Equal(t, "--encrypt --gcp-kms projects/p/locations/global/keyRings/r/cryptoKeys/k --input-type json --output-type json /dev/stdin\n{\"a\":1}", out.String())
+}
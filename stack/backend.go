@@ -0,0 +1,73 @@
+package stack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Backend is the TF backend configured on each stack
+type Backend struct {
+	// Type is the type of the backend,
+	// 'local', 's3' or 'gcs'
+	Type string
+
+	// Bucket is the bucket of the remote
+	// backends where the TFStates are stored
+	Bucket string
+
+	// Region is the region of the s3 Bucket
+	Region string
+}
+
+// ParseBackend parses the b with the format 'local',
+// 's3:BUCKET' or 'gcs:BUCKET', the region is
+// required for the s3 backend
+func ParseBackend(b, region string) (Backend, error) {
+	if b == "" || b == "local" {
+		return Backend{Type: "local"}, nil
+	}
+
+	parts := strings.SplitN(b, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return Backend{}, fmt.Errorf("invalid stack backend %q, the expected format is 'local', 's3:BUCKET' or 'gcs:BUCKET'", b)
+	}
+
+	bk := Backend{Type: parts[0], Bucket: parts[1]}
+	switch bk.Type {
+	case "s3":
+		if region == "" {
+			return Backend{}, fmt.Errorf("the region is required with the stack backend %q", b)
+		}
+		bk.Region = region
+	case "gcs":
+	default:
+		return Backend{}, fmt.Errorf("invalid stack backend %q, the valid types are local, s3 and gcs", b)
+	}
+
+	return bk, nil
+}
+
+// HCL returns the 'terraform' block with the backend
+// configuration of the stack, the local backend has none
+func (b Backend) HCL(stack string) string {
+	switch b.Type {
+	case "s3":
+		return fmt.Sprintf(`terraform {
+  backend "s3" {
+    bucket = %q
+    key    = %q
+    region = %q
+  }
+}
+`, b.Bucket, stack+"/terraform.tfstate", b.Region)
+	case "gcs":
+		return fmt.Sprintf(`terraform {
+  backend "gcs" {
+    bucket = %q
+    prefix = %q
+  }
+}
+`, b.Bucket, stack)
+	}
+	return ""
+}
@@ -0,0 +1,229 @@
+package stack
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hclwrite"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/hcl"
+)
+
+// component is a component of the Terraform Stacks,
+// which calls the module on the directory of the HCL
+type component struct {
+	source string
+
+	// variables are the variables of the module,
+	// given to it on the inputs of the component
+	variables map[string]interface{}
+}
+
+// tfstacks are the Terraform Stacks of a Layout with the
+// Components, written on the paths of the components and
+// deployments once all the resources are written
+type tfstacks struct {
+	components, deployments string
+
+	// providers are the providers of the types of
+	// the resources written and modules the components
+	// by the name of them
+	providers map[string]struct{}
+	modules   map[string]*component
+}
+
+// newTFStacks returns the tfstacks of the lt
+// with the env, or nil if it has no Components
+func newTFStacks(lt *layoutTemplates, env string) (*tfstacks, error) {
+	if lt.components == nil {
+		return nil, nil
+	}
+
+	var (
+		ts  = &tfstacks{providers: make(map[string]struct{}), modules: make(map[string]*component)}
+		d   = LayoutData{Env: env}
+		err error
+	)
+
+	if ts.components, err = execute(lt.components, d); err != nil {
+		return nil, err
+	}
+	if ts.deployments, err = execute(lt.deployments, d); err != nil {
+		return nil, err
+	}
+
+	return ts, nil
+}
+
+// add adds the component of the p, if it's not already,
+// and the provider of the resource of the key
+func (ts *tfstacks) add(p layoutPaths, key string) {
+	if t := ByType(key); strings.Contains(t, "_") {
+		ts.providers[strings.SplitN(t, "_", 2)[0]] = struct{}{}
+	}
+
+	name := p.module()
+	if _, ok := ts.modules[name]; ok {
+		return
+	}
+
+	src, err := filepath.Rel(filepath.Dir(ts.components), filepath.Dir(p.hcl))
+	if err != nil {
+		src = filepath.Dir(p.hcl)
+	}
+	src = filepath.ToSlash(src)
+	if !strings.HasPrefix(src, ".") {
+		src = "./" + src
+	}
+
+	ts.modules[name] = &component{source: src, variables: make(map[string]interface{})}
+}
+
+// variable adds the variable of the key, written to the
+// module of the p, to the inputs of the component of it
+func (ts *tfstacks) variable(p layoutPaths, key string, value interface{}) {
+	ts.modules[p.module()].variables[strings.TrimPrefix(key, "variable.")] = value
+}
+
+// write writes the components and deployments files to the dir, with
+// the header, and the deployment of the env with the inputs, which are
+// variables of the stack that configure all the providers
+func (ts *tfstacks) write(dir, header, env string, inputs map[string]string) error {
+	for path, b := range map[string][]byte{
+		ts.components:  ts.componentsHCL(inputs),
+		ts.deployments: ts.deploymentsHCL(env, inputs),
+	} {
+		if header != "" {
+			b = append([]byte(hcl.Comment(header)+"\n"), b...)
+		}
+
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755); err != nil {
+			return errors.Wrapf(err, "unable to create the directory of %s", path)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, path), b, 0644); err != nil {
+			return errors.Wrapf(err, "unable to write the %s of the Terraform Stacks", path)
+		}
+	}
+
+	return nil
+}
+
+// componentsHCL returns the .tfstack.hcl with the providers,
+// configured with the inputs, and the components
+func (ts *tfstacks) componentsHCL(inputs map[string]string) []byte {
+	var (
+		buff      bytes.Buffer
+		providers = sortedSet(ts.providers)
+	)
+
+	buff.WriteString("required_providers {\n")
+	for _, p := range providers {
+		fmt.Fprintf(&buff, "  %s = {\n    source = %q\n  }\n", p, "hashicorp/"+p)
+	}
+	buff.WriteString("}\n")
+
+	for _, i := range sortedInputs(inputs) {
+		fmt.Fprintf(&buff, "\nvariable %q {\n  type = string\n}\n", i)
+	}
+
+	// The variables of the modules are of
+	// the sensitive values of the resources
+	variables := make(map[string]interface{})
+	for _, c := range ts.modules {
+		for v, cfg := range c.variables {
+			variables[v] = cfg
+		}
+	}
+	for _, v := range sortedKeys(variables) {
+		fmt.Fprintf(&buff, "\nvariable %q {\n  type = string\n  sensitive = true\n", v)
+		if cfg, ok := variables[v].(map[string]interface{}); ok {
+			if d, ok := cfg["description"].(string); ok {
+				fmt.Fprintf(&buff, "  description = %q\n", d)
+			}
+		}
+		buff.WriteString("}\n")
+	}
+
+	for _, p := range providers {
+		fmt.Fprintf(&buff, "\nprovider %q \"this\" {\n  config {\n", p)
+		for _, i := range sortedInputs(inputs) {
+			fmt.Fprintf(&buff, "    %s = var.%s\n", i, i)
+		}
+		buff.WriteString("  }\n}\n")
+	}
+
+	names := make([]string, 0, len(ts.modules))
+	for n := range ts.modules {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		c := ts.modules[n]
+		fmt.Fprintf(&buff, "\ncomponent %q {\n  source = %q\n", n, c.source)
+		if len(c.variables) != 0 {
+			buff.WriteString("\n  inputs = {\n")
+			for _, v := range sortedKeys(c.variables) {
+				fmt.Fprintf(&buff, "    %s = var.%s\n", v, v)
+			}
+			buff.WriteString("  }\n")
+		}
+		buff.WriteString("\n  providers = {\n")
+		for _, p := range providers {
+			fmt.Fprintf(&buff, "    %s = provider.%s.this\n", p, p)
+		}
+		buff.WriteString("  }\n}\n")
+	}
+
+	return hclwrite.Format(buff.Bytes())
+}
+
+// deploymentsHCL returns the .tfdeploy.hcl with
+// the deployment of the env with the inputs
+func (ts *tfstacks) deploymentsHCL(env string, inputs map[string]string) []byte {
+	var buff bytes.Buffer
+
+	fmt.Fprintf(&buff, "deployment %q {\n  inputs = {\n", env)
+	for _, i := range sortedInputs(inputs) {
+		fmt.Fprintf(&buff, "    %s = %q\n", i, inputs[i])
+	}
+	buff.WriteString("  }\n}\n")
+
+	return hclwrite.Format(buff.Bytes())
+}
+
+// sortedInputs returns the keys of the inputs sorted
+func sortedInputs(inputs map[string]string) []string {
+	res := make([]string, 0, len(inputs))
+	for k := range inputs {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// sortedSet returns the keys of the s sorted
+func sortedSet(s map[string]struct{}) []string {
+	res := make([]string, 0, len(s))
+	for k := range s {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// sortedKeys returns the keys of the m sorted
+func sortedKeys(m map[string]interface{}) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
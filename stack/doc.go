@@ -0,0 +1,4 @@
+// Package stack splits the generated HCL and TFState into
+// multiple stacks (directories) so very large inventories
+// can be managed independently
+package stack
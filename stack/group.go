@@ -0,0 +1,41 @@
+package stack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Group returns the name of the stack
+// of the resource with the key
+// (ex: "aws_instance.front")
+type Group func(key string) string
+
+// ByType groups the resources by the type of them,
+// the data sources are on the stack of the type
+func ByType(key string) string {
+	return strings.Split(strings.TrimPrefix(key, "data."), ".")[0]
+}
+
+// ByService groups the resources by the service
+// of the type of them (ex: aws_iam_user is on "iam")
+func ByService(key string) string {
+	t := ByType(key)
+	parts := strings.SplitN(t, "_", 3)
+	if len(parts) < 2 {
+		return t
+	}
+	return parts[1]
+}
+
+// ParseGroup returns the Group with the name g,
+// the valid ones are 'type' and 'service'
+func ParseGroup(g string) (Group, error) {
+	switch g {
+	case "", "type":
+		return ByType, nil
+	case "service":
+		return ByService, nil
+	default:
+		return nil, fmt.Errorf("invalid stack group %q, the valid ones are type and service", g)
+	}
+}
@@ -0,0 +1,222 @@
+package stack
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Layout is the structure of the files of the Stacks, the
+// paths are templates (see text/template), relative to the
+// directory of the Stacks, of the LayoutData of each resource
+type Layout struct {
+	// HCL is the path of the .tf file of the resource
+	HCL string `yaml:"hcl" json:"hcl"`
+
+	// TFState is the path of the TFState of the resource, the
+	// directory of it is a root module with the backend, and
+	// only the resources on it can reference each other
+	TFState string `yaml:"tfstate" json:"tfstate"`
+
+	// Live, if set, is the path of the .tf file of the root
+	// module of the TFState, which calls a module with the
+	// source on the directory of the HCL. The name of the
+	// module is the name of that directory
+	Live string `yaml:"live" json:"live"`
+
+	// Components, if set, is the path of the .tfstack.hcl file of
+	// Terraform Stacks, with a component calling the module on the
+	// directory of each HCL, and Deployments the .tfdeploy.hcl with
+	// the deployment of the Env. As the Terraform Stacks have no
+	// local state, the TFState is the .tf file of the module with
+	// the 'import' blocks of the resources of it
+	Components  string `yaml:"components" json:"components"`
+	Deployments string `yaml:"deployments" json:"deployments"`
+}
+
+// LayoutData are the values of the templates of the Layout
+type LayoutData struct {
+	// Stack is the name of the stack of the
+	// resource, of the Group or the tag of it
+	Stack string
+
+	// Type is the type of the resource
+	Type string
+
+	// Env is the environment of the import (ex: prod)
+	Env string
+}
+
+// DefaultLayout is the name of the Layout used if none is set
+const DefaultLayout = "stacks"
+
+// Layouts are the built-in Layouts by name
+var Layouts = map[string]Layout{
+	// stacks has a directory with a root module for each stack
+	"stacks": Layout{HCL: "{{.Stack}}/main.tf", TFState: "{{.Stack}}/terraform.tfstate"},
+	// single has all the resources on the same file
+	"single": Layout{HCL: "main.tf", TFState: "terraform.tfstate"},
+	// per-type has one root module with a file for each type
+	"per-type": Layout{HCL: "{{.Type}}.tf", TFState: "terraform.tfstate"},
+	// envs has the stacks on the directory of the environment
+	"envs": Layout{HCL: "envs/{{.Env}}/{{.Stack}}/main.tf", TFState: "envs/{{.Env}}/{{.Stack}}/terraform.tfstate"},
+	// modules-live has a module for each stack and a root
+	// module on live calling it with the TFState of it
+	"modules-live": Layout{HCL: "modules/{{.Stack}}/main.tf", TFState: "live/{{.Stack}}/terraform.tfstate", Live: "live/{{.Stack}}/main.tf"},
+	// tfstacks has a component of Terraform Stacks for each
+	// stack, with the resources and the imports of them
+	"tfstacks": Layout{
+		HCL:         "components/{{.Stack}}/main.tf",
+		TFState:     "components/{{.Stack}}/imports.tf",
+		Components:  "components.tfstack.hcl",
+		Deployments: "deployments.tfdeploy.hcl",
+	},
+}
+
+// LayoutNames returns the names of the built-in Layouts sorted
+func LayoutNames() []string {
+	names := make([]string, 0, len(Layouts))
+	for n := range Layouts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseLayout returns the built-in Layout with the name l, or the
+// one defined on the YAML (or JSON) file l if it's not a built-in one
+func ParseLayout(l string) (Layout, error) {
+	if l == "" {
+		l = DefaultLayout
+	}
+	if lt, ok := Layouts[l]; ok {
+		return lt, nil
+	}
+
+	b, err := ioutil.ReadFile(l)
+	if err != nil {
+		return Layout{}, fmt.Errorf("invalid layout %q, it has to be a file or one of: %s", l, strings.Join(LayoutNames(), ", "))
+	}
+
+	var lt Layout
+	if err := yaml.UnmarshalStrict(b, &lt); err != nil {
+		return Layout{}, errors.Wrapf(err, "invalid layout %s", l)
+	}
+	if lt.HCL == "" || lt.TFState == "" {
+		return Layout{}, fmt.Errorf("invalid layout %s, the hcl and tfstate are required", l)
+	}
+	if (lt.Components == "") != (lt.Deployments == "") {
+		return Layout{}, fmt.Errorf("invalid layout %s, the components and deployments have to be set together", l)
+	}
+	if lt.Components != "" && lt.Live != "" {
+		return Layout{}, fmt.Errorf("invalid layout %s, the live can not be used with the components", l)
+	}
+
+	return lt, nil
+}
+
+// layoutTemplates are the templates of a Layout
+type layoutTemplates struct {
+	hcl, tfstate, live      *template.Template
+	components, deployments *template.Template
+}
+
+// layoutPaths are the paths of the files
+// of a resource following a Layout
+type layoutPaths struct {
+	hcl, tfstate, live string
+}
+
+// module returns the name of the module called by the live,
+// which is the name of the directory of the hcl
+func (p layoutPaths) module() string {
+	return filepath.Base(filepath.Dir(p.hcl))
+}
+
+// source returns the source of the module called by the
+// live, the directory of the hcl relative to the live one
+func (p layoutPaths) source() string {
+	src, err := filepath.Rel(filepath.Dir(p.live), filepath.Dir(p.hcl))
+	if err != nil {
+		return filepath.Dir(p.hcl)
+	}
+	src = filepath.ToSlash(src)
+	if !strings.HasPrefix(src, ".") {
+		src = "./" + src
+	}
+	return src
+}
+
+// parseTemplates parses the templates of the l
+func parseTemplates(l Layout) (*layoutTemplates, error) {
+	var (
+		lt  layoutTemplates
+		err error
+	)
+
+	for _, t := range []struct {
+		name string
+		text string
+		tpl  **template.Template
+	}{
+		{name: "hcl", text: l.HCL, tpl: &lt.hcl},
+		{name: "tfstate", text: l.TFState, tpl: &lt.tfstate},
+		{name: "live", text: l.Live, tpl: &lt.live},
+		{name: "components", text: l.Components, tpl: &lt.components},
+		{name: "deployments", text: l.Deployments, tpl: &lt.deployments},
+	} {
+		if t.text == "" {
+			continue
+		}
+		*t.tpl, err = template.New(t.name).Option("missingkey=error").Parse(t.text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s of the layout", t.name)
+		}
+	}
+
+	return &lt, nil
+}
+
+// paths returns the layoutPaths of the d
+func (lt *layoutTemplates) paths(d LayoutData) (layoutPaths, error) {
+	var (
+		p   layoutPaths
+		err error
+	)
+
+	if p.hcl, err = execute(lt.hcl, d); err != nil {
+		return p, err
+	}
+	if p.tfstate, err = execute(lt.tfstate, d); err != nil {
+		return p, err
+	}
+	if lt.live != nil {
+		if p.live, err = execute(lt.live, d); err != nil {
+			return p, err
+		}
+	}
+
+	return p, nil
+}
+
+// execute returns the clean path of the t with the d
+func execute(t *template.Template, d LayoutData) (string, error) {
+	var b bytes.Buffer
+	if err := t.Execute(&b, d); err != nil {
+		return "", errors.Wrapf(err, "unable to execute the %s of the layout", t.Name())
+	}
+
+	p := filepath.Clean(b.String())
+	if filepath.IsAbs(p) || strings.HasPrefix(p, "..") {
+		return "", fmt.Errorf("invalid %s %q of the layout, it has to be inside of the directory", t.Name(), p)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,405 @@
+package stack_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/stack"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroup(t *testing.T) {
+	tests := []struct {
+		key      string
+		group    stack.Group
+		expStack string
+	}{
+		{key: "aws_instance.front", group: stack.ByType, expStack: "aws_instance"},
+		{key: "data.aws_subnet.front", group: stack.ByType, expStack: "aws_subnet"},
+		{key: "aws_iam_user.admin", group: stack.ByService, expStack: "iam"},
+		{key: "google_compute_instance.front", group: stack.ByService, expStack: "compute"},
+		{key: "aws_vpc.main", group: stack.ByService, expStack: "vpc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.expStack, tt.group(tt.key))
+		})
+	}
+
+	t.Run("ParseGroup", func(t *testing.T) {
+		_, err := stack.ParseGroup("service")
+		require.NoError(t, err)
+
+		_, err = stack.ParseGroup("tag")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseBackend(t *testing.T) {
+	t.Run("Local", func(t *testing.T) {
+		b, err := stack.ParseBackend("", "")
+		require.NoError(t, err)
+		assert.Equal(t, "", b.HCL("iam"))
+	})
+
+	t.Run("S3", func(t *testing.T) {
+		b, err := stack.ParseBackend("s3:my-states", "eu-west-1")
+		require.NoError(t, err)
+		assert.Equal(t, `terraform {
+  backend "s3" {
+    bucket = "my-states"
+    key    = "iam/terraform.tfstate"
+    region = "eu-west-1"
+  }
+}
+`, b.HCL("iam"))
+	})
+
+	t.Run("GCS", func(t *testing.T) {
+		b, err := stack.ParseBackend("gcs:my-states", "")
+		require.NoError(t, err)
+		assert.Equal(t, `terraform {
+  backend "gcs" {
+    bucket = "my-states"
+    prefix = "compute"
+  }
+}
+`, b.HCL("compute"))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := stack.ParseBackend("s3:my-states", "")
+		assert.Error(t, err)
+
+		_, err = stack.ParseBackend("azurerm:my-states", "")
+		assert.Error(t, err)
+
+		_, err = stack.ParseBackend("gcs:", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestStacks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terracognita-stacks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b, err := stack.ParseBackend("gcs:my-states", "")
+	require.NoError(t, err)
+
+	s, err := stack.New(dir, stack.ByService, b)
+	require.NoError(t, err)
+
+	w := s.HCLWriter()
+	require.NoError(t, w.Write("aws_iam_user.admin", map[string]interface{}{"name": "admin"}))
+	require.NoError(t, w.Write("aws_db_instance.main", map[string]interface{}{"engine": "mysql"}))
+	require.NoError(t, w.Write("variable.aws_db_instance_main_password", map[string]interface{}{"type": "string"}))
+
+	ok, err := w.Has("aws_iam_user.admin")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, w.Sync())
+	require.NoError(t, s.Close())
+
+	iam, err := ioutil.ReadFile(filepath.Join(dir, "iam", "main.tf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(iam), `resource "aws_iam_user" "admin"`)
+	assert.NotContains(t, string(iam), "aws_db_instance")
+
+	db, err := ioutil.ReadFile(filepath.Join(dir, "db", "main.tf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(db), `resource "aws_db_instance" "main"`)
+	assert.Contains(t, string(db), `variable "aws_db_instance_main_password"`)
+
+	backend, err := ioutil.ReadFile(filepath.Join(dir, "db", "backend.tf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(backend), `prefix = "db"`)
+}
+
+func TestStacksByTag(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terracognita-stacks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := stack.New(dir, stack.ByType, stack.Backend{})
+	require.NoError(t, err)
+	s.ByTag(stack.CloudFormationTag)
+
+	w := s.HCLWriter()
+	require.NoError(t, w.Write("aws_vpc.main", map[string]interface{}{
+		"cidr_block": "10.0.0.0/16",
+		"=tc=tags":   map[string]interface{}{stack.CloudFormationTag: "network"},
+	}))
+	require.NoError(t, w.Write("aws_iam_user.admin", map[string]interface{}{"name": "admin"}))
+
+	assert.Equal(t, "network", s.Stack("aws_vpc.main"))
+	assert.Equal(t, "aws_iam_user", s.Stack("aws_iam_user.admin"))
+
+	require.NoError(t, w.Sync())
+	require.NoError(t, s.Close())
+
+	network, err := ioutil.ReadFile(filepath.Join(dir, "network", "main.tf"))
+	require.NoError(t, err)
+	assert.Contains(t, string(network), `resource "aws_vpc" "main"`)
+}
+
+func TestStacksHeader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "terracognita-stacks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := stack.New(dir, stack.ByType, stack.Backend{})
+	require.NoError(t, err)
+	s.SetHeader("Generated by terracognita")
+
+	w := s.HCLWriter()
+	require.NoError(t, w.Write("aws_vpc.main", map[string]interface{}{"cidr_block": "10.0.0.0/16"}))
+	require.NoError(t, w.Write("comment.aws_vpc.main", "Imported from aws with ID vpc-123"))
+
+	assert.Equal(t, "aws_vpc", s.Stack("comment.aws_vpc.main"))
+
+	require.NoError(t, w.Sync())
+	require.NoError(t, s.Close())
+
+	vpc, err := ioutil.ReadFile(filepath.Join(dir, "aws_vpc", "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, `# Generated by terracognita
+
+# Imported from aws with ID vpc-123
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
+`, string(vpc))
+}
+
+func TestParseLayout(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		l, err := stack.ParseLayout("")
+		require.NoError(t, err)
+		assert.Equal(t, stack.Layouts[stack.DefaultLayout], l)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		f, err := ioutil.TempFile("", "terracognita-layout")
+		require.NoError(t, err)
+		defer os.Remove(f.Name())
+
+		_, err = f.WriteString("hcl: \"{{.Env}}/{{.Type}}.tf\"\ntfstate: \"{{.Env}}/terraform.tfstate\"\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		l, err := stack.ParseLayout(f.Name())
+		require.NoError(t, err)
+		assert.Equal(t, stack.Layout{HCL: "{{.Env}}/{{.Type}}.tf", TFState: "{{.Env}}/terraform.tfstate"}, l)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := stack.ParseLayout("potato")
+		assert.Error(t, err)
+	})
+
+	t.Run("ErrorComponentsWithoutDeployments", func(t *testing.T) {
+		f, err := ioutil.TempFile("", "terracognita-layout")
+		require.NoError(t, err)
+		defer os.Remove(f.Name())
+
+		_, err = f.WriteString("hcl: \"{{.Stack}}/main.tf\"\ntfstate: \"{{.Stack}}/imports.tf\"\ncomponents: \"stack.tfstack.hcl\"\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		_, err = stack.ParseLayout(f.Name())
+		assert.Error(t, err)
+	})
+}
+
+func TestStacksLayout(t *testing.T) {
+	t.Run("PerType", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "terracognita-stacks")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		s, err := stack.New(dir, stack.ByService, stack.Backend{})
+		require.NoError(t, err)
+		require.NoError(t, s.SetLayout(stack.Layouts["per-type"], ""))
+
+		w := s.HCLWriter()
+		require.NoError(t, w.Write("aws_iam_user.admin", map[string]interface{}{"name": "admin"}))
+		require.NoError(t, w.Write("aws_iam_group.admins", map[string]interface{}{"name": "admins"}))
+
+		assert.Equal(t, "terraform.tfstate", s.Root("aws_iam_user.admin"))
+		assert.Equal(t, s.Root("aws_iam_user.admin"), s.Root("aws_iam_group.admins"))
+
+		require.NoError(t, w.Sync())
+		require.NoError(t, s.Close())
+
+		user, err := ioutil.ReadFile(filepath.Join(dir, "aws_iam_user.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(user), `resource "aws_iam_user" "admin"`)
+		assert.NotContains(t, string(user), "aws_iam_group")
+
+		_, err = os.Stat(filepath.Join(dir, "aws_iam_group.tf"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("ModulesLive", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "terracognita-stacks")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		b, err := stack.ParseBackend("gcs:my-states", "")
+		require.NoError(t, err)
+
+		s, err := stack.New(dir, stack.ByService, b)
+		require.NoError(t, err)
+		require.NoError(t, s.SetLayout(stack.Layouts["modules-live"], ""))
+
+		w := s.HCLWriter()
+		require.NoError(t, w.Write("aws_db_instance.main", map[string]interface{}{"engine": "mysql"}))
+		require.NoError(t, w.Write("variable.aws_db_instance_main_password", map[string]interface{}{"type": "string"}))
+		require.NoError(t, w.Write("provider.aws.eu_west_1", map[string]interface{}{"region": "eu-west-1"}))
+
+		require.NoError(t, w.Sync())
+		require.NoError(t, s.Close())
+
+		db, err := ioutil.ReadFile(filepath.Join(dir, "modules", "db", "main.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(db), `resource "aws_db_instance" "main"`)
+		assert.Contains(t, string(db), `variable "aws_db_instance_main_password"`)
+		assert.NotContains(t, string(db), `provider "aws"`)
+
+		live, err := ioutil.ReadFile(filepath.Join(dir, "live", "db", "main.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(live), `module "db"`)
+		assert.Contains(t, string(live), `"../../modules/db"`)
+		assert.Contains(t, string(live), `aws_db_instance_main_password = "${var.aws_db_instance_main_password}"`)
+		assert.Contains(t, string(live), `provider "aws"`)
+
+		backend, err := ioutil.ReadFile(filepath.Join(dir, "live", "db", "backend.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(backend), `prefix = "live/db"`)
+
+		_, err = os.Stat(filepath.Join(dir, "modules", "db", "backend.tf"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("TFStacks", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			db   = mock.NewResource(ctrl)
+		)
+		defer ctrl.Finish()
+
+		db.EXPECT().ID().Return("main-db")
+
+		dir, err := ioutil.TempDir("", "terracognita-stacks")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		s, err := stack.New(dir, stack.ByService, stack.Backend{})
+		require.NoError(t, err)
+		require.NoError(t, s.SetLayout(stack.Layouts["tfstacks"], "prod"))
+		s.SetDeployment(map[string]string{"region": "eu-west-1"})
+
+		w := s.HCLWriter()
+		require.NoError(t, w.Write("aws_db_instance.main", map[string]interface{}{"engine": "mysql"}))
+		require.NoError(t, w.Write("variable.aws_db_instance_main_password", map[string]interface{}{"description": "The password of the aws_db_instance.main"}))
+		assert.Error(t, w.Write("provider.aws.eu_west_1", map[string]interface{}{"region": "eu-west-1"}))
+
+		sw := s.StateWriter()
+		require.NoError(t, sw.Write("aws_db_instance.main", db))
+
+		require.NoError(t, w.Sync())
+		require.NoError(t, sw.Sync())
+		require.NoError(t, s.Close())
+
+		main, err := ioutil.ReadFile(filepath.Join(dir, "components", "db", "main.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(main), `resource "aws_db_instance" "main"`)
+		assert.Contains(t, string(main), `variable "aws_db_instance_main_password"`)
+
+		imports, err := ioutil.ReadFile(filepath.Join(dir, "components", "db", "imports.tf"))
+		require.NoError(t, err)
+		assert.Equal(t, "import {\n  to = aws_db_instance.main\n  id = \"main-db\"\n}\n", string(imports))
+
+		components, err := ioutil.ReadFile(filepath.Join(dir, "components.tfstack.hcl"))
+		require.NoError(t, err)
+		assert.Equal(t, `required_providers {
+  aws = {
+    source = "hashicorp/aws"
+  }
+}
+
+variable "region" {
+  type = string
+}
+
+variable "aws_db_instance_main_password" {
+  type        = string
+  sensitive   = true
+  description = "The password of the aws_db_instance.main"
+}
+
+provider "aws" "this" {
+  config {
+    region = var.region
+  }
+}
+
+component "db" {
+  source = "./components/db"
+
+  inputs = {
+    aws_db_instance_main_password = var.aws_db_instance_main_password
+  }
+
+  providers = {
+    aws = provider.aws.this
+  }
+}
+`, string(components))
+
+		deployments, err := ioutil.ReadFile(filepath.Join(dir, "deployments.tfdeploy.hcl"))
+		require.NoError(t, err)
+		assert.Equal(t, `deployment "prod" {
+  inputs = {
+    region = "eu-west-1"
+  }
+}
+`, string(deployments))
+
+		_, err = os.Stat(filepath.Join(dir, "components", "db", "backend.tf"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestReportWriter(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+		w    = mock.NewWriter(ctrl)
+		out  = &bytes.Buffer{}
+		vpc  = map[string]interface{}{"=tc=tags": map[string]interface{}{stack.CloudFormationTag: "network"}}
+		sn   = map[string]interface{}{"=tc=tags": map[string]interface{}{stack.CloudFormationTag: "network"}}
+		user = map[string]interface{}{"name": "admin"}
+	)
+	defer ctrl.Finish()
+
+	w.EXPECT().Write("aws_vpc.main", vpc).Return(nil)
+	w.EXPECT().Write("aws_subnet.front", sn).Return(nil)
+	w.EXPECT().Write("aws_iam_user.admin", user).Return(nil)
+
+	rw := stack.NewReportWriter(w, stack.CloudFormationTag)
+	require.NoError(t, rw.Write("aws_vpc.main", vpc))
+	require.NoError(t, rw.Write("aws_subnet.front", sn))
+	require.NoError(t, rw.Write("aws_iam_user.admin", user))
+
+	require.NoError(t, rw.Report(out))
+	assert.JSONEq(t, `{"network": ["aws_subnet.front", "aws_vpc.main"]}`, out.String())
+}
@@ -0,0 +1,507 @@
+package stack
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cycloidio/terracognita/hcl"
+	"github.com/cycloidio/terracognita/state"
+	"github.com/cycloidio/terracognita/tfimport"
+	"github.com/cycloidio/terracognita/writer"
+	"github.com/pkg/errors"
+)
+
+// Stacks writes the HCL and TFState of each stack to the
+// files of the Layout of it, by default the 'main.tf' and
+// 'terraform.tfstate' of the directory of the stack, the
+// files are created when the first resource of them is written
+type Stacks struct {
+	dir     string
+	group   Group
+	backend Backend
+
+	// layout has the paths of the files of each resource,
+	// with the env of them, and live if it has a Live
+	layout *layoutTemplates
+	env    string
+	live   bool
+
+	// tfstacks are the Terraform Stacks if the Layout
+	// has Components, with the inputs of the deployment
+	tfstacks *tfstacks
+	inputs   map[string]string
+
+	// tag groups the resources with it by the value
+	// of it, with the stacks of them on tagged
+	tag    string
+	tagged map[string]string
+
+	// last are the paths of the last resource
+	// written to the HCL, the variables
+	// of it are on the same files
+	last *layoutPaths
+
+	// header is written at the top of
+	// the .tf files of the stacks
+	header string
+
+	// hcl and state are the writers by the path of them,
+	// lives are the writers of the Live with the
+	// configuration of the module of them
+	hcl   map[string]*hcl.Writer
+	state map[string]*state.Writer
+	lives map[string]*hcl.Writer
+	calls map[string]map[string]interface{}
+
+	// imports are the writers of the 'import' blocks,
+	// which replace the TFState of the Terraform Stacks
+	imports map[string]*tfimport.Writer
+
+	// roots are the directories of
+	// the TFStates with the backend
+	roots map[string]struct{}
+	files []io.Closer
+}
+
+// New returns the Stacks on the dir grouped by the g
+// and with the b configured on each one
+func New(dir string, g Group, b Backend) (*Stacks, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "unable to create the directory %s", dir)
+	}
+
+	lt, err := parseTemplates(Layouts[DefaultLayout])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stacks{
+		dir:     dir,
+		group:   g,
+		backend: b,
+		layout:  lt,
+		hcl:     make(map[string]*hcl.Writer),
+		state:   make(map[string]*state.Writer),
+		lives:   make(map[string]*hcl.Writer),
+		calls:   make(map[string]map[string]interface{}),
+		imports: make(map[string]*tfimport.Writer),
+		roots:   make(map[string]struct{}),
+		files:   make([]io.Closer, 0),
+	}, nil
+}
+
+// SetLayout sets the l of the files of the stacks, with the
+// env of the LayoutData, it has to be set before any Write
+func (s *Stacks) SetLayout(l Layout, env string) error {
+	lt, err := parseTemplates(l)
+	if err != nil {
+		return err
+	}
+
+	ts, err := newTFStacks(lt, env)
+	if err != nil {
+		return err
+	}
+
+	s.layout, s.env, s.live, s.tfstacks = lt, env, l.Live != "", ts
+
+	return nil
+}
+
+// SetDeployment sets the inputs of the deployment of the Terraform
+// Stacks, if the Layout has Components, which are the configuration
+// of the providers of them (ex: region)
+func (s *Stacks) SetDeployment(inputs map[string]string) {
+	s.inputs = inputs
+}
+
+// ByTag groups the resources with the tag (ex: CloudFormationTag)
+// on the HCL by the value of it, the rest are grouped by the Group
+func (s *Stacks) ByTag(tag string) {
+	s.tag = tag
+	s.tagged = make(map[string]string)
+}
+
+// SetHeader sets the h to be written at the top of the
+// .tf files of the stacks, see hcl.Writer.SetHeader
+func (s *Stacks) SetHeader(h string) {
+	s.header = h
+}
+
+// Stack returns the name of the stack of the key, the
+// comments are on the stack of the block of them
+func (s *Stacks) Stack(key string) string {
+	key = strings.TrimPrefix(key, "comment.")
+	if st, ok := s.tagged[key]; ok {
+		return st
+	}
+	return s.group(key)
+}
+
+// Root returns the TFState of the key following the Layout,
+// only the resources with the same one can reference each other
+func (s *Stacks) Root(key string) string {
+	p, err := s.paths(key)
+	if err != nil {
+		return s.Stack(key)
+	}
+	return p.tfstate
+}
+
+// HCLWriter returns the writer.Writer of the HCL of the stacks
+func (s *Stacks) HCLWriter() writer.Writer {
+	return &stacksWriter{stacks: s, hcl: true}
+}
+
+// StateWriter returns the writer.Writer of the TFState of the stacks
+func (s *Stacks) StateWriter() writer.Writer {
+	return &stacksWriter{stacks: s}
+}
+
+// Close closes the files of all the stacks
+func (s *Stacks) Close() error {
+	for _, f := range s.files {
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// paths returns the layoutPaths of the key
+func (s *Stacks) paths(key string) (layoutPaths, error) {
+	key = strings.TrimPrefix(key, "comment.")
+	return s.layout.paths(LayoutData{
+		Stack: s.Stack(key),
+		Type:  ByType(key),
+		Env:   s.env,
+	})
+}
+
+// hclWriter returns the HCL writer of the p creating it, and the
+// Live calling the module of it, if it does not exist
+func (s *Stacks) hclWriter(p layoutPaths) (*hcl.Writer, error) {
+	if p.live != "" {
+		if err := s.liveWriter(p); err != nil {
+			return nil, err
+		}
+	}
+
+	if w, ok := s.hcl[p.hcl]; ok {
+		return w, nil
+	}
+
+	f, err := s.create(p.hcl, p.tfstate)
+	if err != nil {
+		return nil, err
+	}
+
+	s.hcl[p.hcl] = hcl.NewWriter(f)
+	s.hcl[p.hcl].SetHeader(s.header)
+
+	return s.hcl[p.hcl], nil
+}
+
+// liveWriter creates the HCL writer of the Live of the p,
+// if it does not exist, and the call to the module of the p
+func (s *Stacks) liveWriter(p layoutPaths) error {
+	w, ok := s.lives[p.live]
+	if !ok {
+		f, err := s.create(p.live, p.tfstate)
+		if err != nil {
+			return err
+		}
+		w = hcl.NewWriter(f)
+		w.SetHeader(s.header)
+		s.lives[p.live] = w
+	}
+
+	key := "module." + p.module()
+	if _, ok := s.calls[key]; ok {
+		return nil
+	}
+
+	// The configuration is kept so the variables
+	// of the module can be added to it after
+	s.calls[key] = map[string]interface{}{"source": p.source()}
+	return w.Write(key, s.calls[key])
+}
+
+// stateWriter returns the TFState writer of the p
+// creating it if it does not exist, with the module
+// of the Live as the one of the resources, or the
+// writer of the 'import' blocks of the Terraform Stacks
+func (s *Stacks) stateWriter(p layoutPaths) (writer.Writer, error) {
+	if s.tfstacks != nil {
+		return s.importsWriter(p)
+	}
+
+	if w, ok := s.state[p.tfstate]; ok {
+		return w, nil
+	}
+
+	f, err := s.create(p.tfstate, p.tfstate)
+	if err != nil {
+		return nil, err
+	}
+
+	s.state[p.tfstate] = state.NewWriter(f)
+	if p.live != "" {
+		s.state[p.tfstate].SetModule(p.module())
+	}
+
+	return s.state[p.tfstate], nil
+}
+
+// importsWriter returns the writer of the 'import'
+// blocks of the p creating it if it does not exist
+func (s *Stacks) importsWriter(p layoutPaths) (*tfimport.Writer, error) {
+	if w, ok := s.imports[p.tfstate]; ok {
+		return w, nil
+	}
+
+	f, err := s.create(p.tfstate, p.tfstate)
+	if err != nil {
+		return nil, err
+	}
+
+	s.imports[p.tfstate] = tfimport.NewWriter(f, tfimport.HCL)
+
+	return s.imports[p.tfstate], nil
+}
+
+// create creates the file of the path, the first time a file
+// of the root module of the tfstate is created it also writes
+// the backend to the directory of it
+func (s *Stacks) create(path, tfstate string) (io.Writer, error) {
+	root := filepath.Dir(tfstate)
+	if _, ok := s.roots[root]; !ok {
+		dir := filepath.Join(s.dir, root)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, errors.Wrapf(err, "unable to create the stack %s", root)
+		}
+
+		// The root module on the directory
+		// has the name of the directory
+		name := filepath.ToSlash(root)
+		if root == "." {
+			name = filepath.Base(s.dir)
+		}
+		// The Terraform Stacks have
+		// no backend, the state is
+		// managed by the deployments
+		if b := s.backend.HCL(name); b != "" && s.tfstacks == nil {
+			if s.header != "" {
+				b = hcl.Comment(s.header) + "\n" + b
+			}
+			if err := ioutil.WriteFile(filepath.Join(dir, "backend.tf"), []byte(b), 0644); err != nil {
+				return nil, errors.Wrapf(err, "unable to write the backend of the stack %s", root)
+			}
+		}
+		s.roots[root] = struct{}{}
+	}
+
+	if err := os.MkdirAll(filepath.Join(s.dir, filepath.Dir(path)), 0755); err != nil {
+		return nil, errors.Wrapf(err, "unable to create the directory of %s", path)
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, path), os.O_TRUNC|os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create the %s of the stack", path)
+	}
+	s.files = append(s.files, f)
+
+	return f, nil
+}
+
+// stacksWriter is the writer.Writer of the HCL
+// or the TFState of all the Stacks
+type stacksWriter struct {
+	stacks *Stacks
+	hcl    bool
+}
+
+// Write writes the key and value to the writer of the stack
+// of the key, the providers are written to all the root
+// modules as the resources of them may use it
+func (w *stacksWriter) Write(key string, value interface{}) error {
+	if w.hcl && strings.HasPrefix(key, "provider.") {
+		if w.stacks.tfstacks != nil {
+			return errors.Errorf("the aliased provider %q can not be written to the Terraform Stacks", key)
+		}
+		for _, ww := range w.providerWriters() {
+			if err := ww.Write(key, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// The HCL is written before the TFState
+	// so the stack of the tag is already known
+	if w.hcl && w.stacks.tag != "" {
+		if v := tagValue(value, w.stacks.tag); v != "" {
+			w.stacks.tagged[key] = v
+		}
+	}
+
+	var p layoutPaths
+	if w.hcl && strings.HasPrefix(key, "variable.") && w.stacks.last != nil {
+		p = *w.stacks.last
+		if err := w.liveVariable(p, key, value); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if p, err = w.stacks.paths(key); err != nil {
+			return err
+		}
+		if w.hcl {
+			w.stacks.last = &p
+			if w.stacks.tfstacks != nil {
+				w.stacks.tfstacks.add(p, strings.TrimPrefix(key, "comment."))
+			}
+		}
+	}
+
+	ww, err := w.writer(p)
+	if err != nil {
+		return err
+	}
+
+	return ww.Write(key, value)
+}
+
+// liveVariable writes the variable of the key, written to
+// the module of the p, also to the Live of it which gives
+// it to the module, if the p has a Live
+func (w *stacksWriter) liveVariable(p layoutPaths, key string, value interface{}) error {
+	if w.stacks.tfstacks != nil {
+		w.stacks.tfstacks.variable(p, key, value)
+		return nil
+	}
+
+	if p.live == "" {
+		return nil
+	}
+
+	if err := w.stacks.lives[p.live].Write(key, value); err != nil {
+		return err
+	}
+
+	name := strings.TrimPrefix(key, "variable.")
+	w.stacks.calls["module."+p.module()][name] = fmt.Sprintf("${var.%s}", name)
+
+	return nil
+}
+
+// Has checks if any of the stacks has the key,
+// so the names are unique on all of them
+func (w *stacksWriter) Has(key string) (bool, error) {
+	for _, ww := range w.writers() {
+		ok, err := ww.Has(key)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Sync syncs all the stacks and stops on the first
+// error, the HCL also writes the Terraform Stacks
+func (w *stacksWriter) Sync() error {
+	for _, ww := range w.writers() {
+		if err := ww.Sync(); err != nil {
+			return err
+		}
+	}
+
+	if w.hcl && w.stacks.tfstacks != nil {
+		return w.stacks.tfstacks.write(w.stacks.dir, w.stacks.header, w.stacks.env, w.stacks.inputs)
+	}
+
+	return nil
+}
+
+// writer returns the writer of the p
+func (w *stacksWriter) writer(p layoutPaths) (writer.Writer, error) {
+	if w.hcl {
+		return w.stacks.hclWriter(p)
+	}
+	return w.stacks.stateWriter(p)
+}
+
+// writers returns the writers of all the
+// stacks sorted by the path of them
+func (w *stacksWriter) writers() []writer.Writer {
+	writers := make(map[string]writer.Writer)
+	if w.hcl {
+		for p, ww := range w.stacks.hcl {
+			writers[p] = ww
+		}
+		for p, ww := range w.stacks.lives {
+			writers[p] = ww
+		}
+	} else {
+		for p, ww := range w.stacks.state {
+			writers[p] = ww
+		}
+		for p, ww := range w.stacks.imports {
+			writers[p] = ww
+		}
+	}
+
+	return sortedWriters(writers)
+}
+
+// providerWriters returns the HCL writers of the root modules,
+// the Lives if the Layout has them or the first writer of
+// each directory, sorted by the path of them
+func (w *stacksWriter) providerWriters() []writer.Writer {
+	if w.stacks.live {
+		writers := make(map[string]writer.Writer)
+		for p, ww := range w.stacks.lives {
+			writers[p] = ww
+		}
+		return sortedWriters(writers)
+	}
+
+	writers := make(map[string]writer.Writer)
+	for p, ww := range w.stacks.hcl {
+		dir := filepath.Dir(p)
+		if cur, ok := writers[dir]; !ok || p < cur.(*pathWriter).path {
+			writers[dir] = &pathWriter{Writer: ww, path: p}
+		}
+	}
+	return sortedWriters(writers)
+}
+
+// pathWriter is a writer.Writer with the path of it
+type pathWriter struct {
+	writer.Writer
+
+	path string
+}
+
+// sortedWriters returns the writers sorted by the key of them
+func sortedWriters(writers map[string]writer.Writer) []writer.Writer {
+	keys := make([]string, 0, len(writers))
+	for k := range writers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := make([]writer.Writer, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, writers[k])
+	}
+	return res
+}
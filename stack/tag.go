@@ -0,0 +1,76 @@
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cycloidio/terracognita/writer"
+)
+
+// CloudFormationTag is the tag set by CloudFormation
+// on the resources with the name of the stack of them
+const CloudFormationTag = "aws:cloudformation:stack-name"
+
+// tagValue returns the value of the tag on
+// the HCL cfg, empty if it's not a config
+func tagValue(cfg interface{}, tag string) string {
+	m, ok := cfg.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	// The map attributes are prefixed on the HCL
+	for _, k := range []string{"=tc=tags", "tags", "=tc=labels", "labels"} {
+		if tags, ok := m[k].(map[string]interface{}); ok {
+			if v, ok := tags[tag]; ok {
+				return fmt.Sprint(v)
+			}
+		}
+	}
+
+	return ""
+}
+
+// ReportWriter is a writer.Writer that keeps which
+// resources written have the tag, grouped by
+// the value of it (ex: the CloudFormation stack)
+type ReportWriter struct {
+	writer.Writer
+
+	tag    string
+	tagged map[string][]string
+}
+
+// NewReportWriter returns a ReportWriter of the
+// tag of the HCL of the resources written to w
+func NewReportWriter(w writer.Writer, tag string) *ReportWriter {
+	return &ReportWriter{
+		Writer: w,
+		tag:    tag,
+		tagged: make(map[string][]string),
+	}
+}
+
+// Write keeps the key if the value has the tag and writes it
+func (r *ReportWriter) Write(key string, value interface{}) error {
+	if v := tagValue(value, r.tag); v != "" {
+		r.tagged[v] = append(r.tagged[v], key)
+	}
+
+	return r.Writer.Write(key, value)
+}
+
+// Report writes to w the JSON of the values of
+// the tag with the keys of the resources of each
+func (r *ReportWriter) Report(w io.Writer) error {
+	for _, keys := range r.tagged {
+		sort.Strings(keys)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r.tagged)
+}
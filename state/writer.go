@@ -1,36 +1,104 @@
 package state
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/cycloidio/terracognita/errcode"
 	"github.com/cycloidio/terracognita/log"
 	"github.com/cycloidio/terracognita/provider"
+	version "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform/addrs"
+	"github.com/hashicorp/terraform/configs/hcl2shim"
 	"github.com/hashicorp/terraform/states"
 	"github.com/hashicorp/terraform/states/statefile"
 	"github.com/hashicorp/terraform/states/statemgr"
+	"github.com/hashicorp/terraform/terraform"
+	tfversion "github.com/hashicorp/terraform/version"
 	"github.com/pkg/errors"
 )
 
+// The Terraform versions that changed the format of the TFState
+var (
+	// v012 is the first one with the version 4,
+	// the previous ones use the legacy version 3
+	v012 = version.Must(version.NewVersion("0.12.0"))
+
+	// v013 is the first one with the provider
+	// addresses of the registry on the version 4
+	v013 = version.Must(version.NewVersion("0.13.0"))
+
+	// v2 is the first major version not supported
+	v2 = version.Must(version.NewVersion("2.0.0"))
+)
+
 // Writer is a Writer implementation
 // that is meant to generate a TFState
 type Writer struct {
 	Config map[string]provider.Resource
 	writer io.Writer
 	state  *states.SyncState
+
+	// version is the Terraform version that uses
+	// the TFState, which defines the format of it
+	version *version.Version
+
+	// legacy is the TFState with the version 3,
+	// only written if the version is before 0.12
+	legacy *terraform.State
+
+	// module is the module of the resources,
+	// nil if those are on the root one
+	module addrs.ModuleInstance
 }
 
 // NewWriter returns a TFStateWriter initialization
 func NewWriter(w io.Writer) *Writer {
 	return &Writer{
-		Config: make(map[string]provider.Resource),
-		writer: w,
-		state:  states.NewState().SyncWrapper(),
+		Config:  make(map[string]provider.Resource),
+		writer:  w,
+		state:   states.NewState().SyncWrapper(),
+		version: tfversion.SemVer,
 	}
 }
 
+// SetVersion sets the Terraform version v (ex: 0.11, 0.12 or 1.5)
+// that uses the TFState, by default the 0.12 one used to read the
+// resources. Before 0.12 the TFState has the legacy version 3, from
+// 0.13 the version 4 has the provider addresses of the registry
+// (registry.terraform.io/hashicorp/aws), it has to be set before
+// any Write
+func (w *Writer) SetVersion(v string) error {
+	sv, err := version.NewVersion(v)
+	if err != nil {
+		return fmt.Errorf("invalid version %q, expected the Terraform version (ex: 0.11, 0.12, 1.5): %s", v, err)
+	}
+
+	if !sv.LessThan(v2) {
+		return fmt.Errorf("invalid version %q, the supported Terraform versions are from 0.11 to 1.x", v)
+	}
+
+	w.version = sv
+	w.legacy = nil
+	if sv.LessThan(v012) {
+		w.legacy = terraform.NewState()
+	}
+
+	return nil
+}
+
+// SetModule sets the name of the module called by the root
+// one that has the resources (ex: module.name.aws_instance.front),
+// the providers are the ones of the root module. It has to be
+// set before any Write
+func (w *Writer) SetModule(name string) {
+	w.module = addrs.RootModuleInstance.Child(name, addrs.NoKey)
+}
+
 // Write expects a key similar to "aws_instance.your_name" and
 // the value to be *terraform.ResourceState repeated keys will report an error
 func (w *Writer) Write(key string, value interface{}) error {
@@ -56,7 +124,7 @@ func (w *Writer) Write(key string, value interface{}) error {
 	}
 
 	absAddr := addrs.AbsResourceInstance{
-		Module: nil,
+		Module: w.module,
 		Resource: addrs.ResourceInstance{
 			Resource: addrs.Resource{
 				Mode: addrs.ManagedResourceMode,
@@ -67,20 +135,49 @@ func (w *Writer) Write(key string, value interface{}) error {
 		},
 	}
 
+	// The resources of an aliased provider
+	// are managed by the alias of it
+	var alias string
+	p := r.Provider()
+	if a, ok := p.(provider.Aliaser); ok {
+		alias = a.Alias()
+	}
+
 	absProviderConf := addrs.AbsProviderConfig{
 		Module: nil,
 		ProviderConfig: addrs.ProviderConfig{
-			Type: r.Provider().String(),
+			Type:  p.String(),
+			Alias: alias,
 		},
 	}
 
-	src, err := r.ResourceInstanceObject().Encode(r.CoreConfigSchema().ImpliedType(), uint64(r.TFResource().SchemaVersion))
+	obj := r.ResourceInstanceObject()
+	src, err := obj.Encode(r.CoreConfigSchema().ImpliedType(), uint64(r.TFResource().SchemaVersion))
 	if err != nil {
 		return err
 	}
 
 	w.state.SetResourceInstanceCurrent(absAddr, src, absProviderConf)
 
+	if w.legacy != nil {
+		attrs := hcl2shim.FlatmapValueFromHCL2(obj.Value)
+		mod := w.legacy.ModuleByPath(w.module)
+		if mod == nil {
+			mod = w.legacy.AddModule(w.module)
+		}
+		mod.Resources[key] = &terraform.ResourceState{
+			Type:     r.Type(),
+			Provider: absProviderConf.ProviderConfig.String(),
+			Primary: &terraform.InstanceState{
+				ID:         attrs["id"],
+				Attributes: attrs,
+				Meta: map[string]interface{}{
+					"schema_version": strconv.Itoa(r.TFResource().SchemaVersion),
+				},
+			},
+		}
+	}
+
 	log.Get().Log("func", "state.Write(State)", "msg", "writing to internal config", "key", key, "content", r)
 	w.Config[key] = r
 
@@ -100,14 +197,82 @@ func (w *Writer) Sync() error {
 	lstate := w.state.Lock()
 	defer w.state.Unlock()
 
-	log.Get().Log("func", "state.Sync(State)", "msg", "writting state to state file")
+	log.Get().Log("func", "state.Sync(State)", "msg", "writting state to state file", "version", w.version)
+
+	if w.legacy != nil {
+		w.legacy.TFVersion = w.version.String()
+		return terraform.WriteState(w.legacy, w.writer)
+	}
+
 	file := statemgr.NewStateFile()
 	file.State = lstate
 
-	err := statefile.Write(file, w.writer)
+	if w.version.LessThan(v013) {
+		return statefile.Write(file, w.writer)
+	}
+
+	var b bytes.Buffer
+	err := statefile.Write(file, &b)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	b2, err := registryProviders(b.Bytes(), w.version)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.writer.Write(b2)
+	return err
+}
+
+// stateV4 is the TFState version 4 with
+// only the provider of the resources decoded
+type stateV4 struct {
+	Version          int             `json:"version"`
+	TerraformVersion string          `json:"terraform_version"`
+	Serial           uint64          `json:"serial"`
+	Lineage          string          `json:"lineage"`
+	Outputs          json.RawMessage `json:"outputs"`
+	Resources        []resourceV4    `json:"resources"`
+}
+
+// resourceV4 is the resource of the stateV4
+type resourceV4 struct {
+	Module    string          `json:"module,omitempty"`
+	Mode      string          `json:"mode"`
+	Type      string          `json:"type"`
+	Name      string          `json:"name"`
+	Each      string          `json:"each,omitempty"`
+	Provider  string          `json:"provider"`
+	Instances json.RawMessage `json:"instances"`
+}
+
+// registryProviders replaces the legacy provider addresses of the TFState
+// b (provider.aws.alias) with the ones of the registry used since 0.13
+// (provider["registry.terraform.io/hashicorp/aws"].alias), and the
+// Terraform version that wrote it with the v
+func registryProviders(b []byte, v *version.Version) ([]byte, error) {
+	var s stateV4
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, errors.Wrap(err, "could not decode the TFState")
+	}
+
+	s.TerraformVersion = v.String()
+
+	for i, r := range s.Resources {
+		parts := strings.SplitN(strings.TrimPrefix(r.Provider, "provider."), ".", 2)
+		p := fmt.Sprintf("provider[%q]", "registry.terraform.io/hashicorp/"+parts[0])
+		if len(parts) == 2 {
+			p += "." + parts[1]
+		}
+		s.Resources[i].Provider = p
+	}
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode the TFState")
+	}
+
+	return append(b, '\n'), nil
 }
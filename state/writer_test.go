@@ -200,4 +200,131 @@ func TestSync(t *testing.T) {
 
 		assert.Equal(t, est, st)
 	})
+	t.Run("SuccessWithModule", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			b    = &bytes.Buffer{}
+			sw   = state.NewWriter(b)
+			prv  = mock.NewProvider(ctrl)
+			res  = mock.NewResource(ctrl)
+			tp   = "aws_iam_user"
+		)
+
+		defer ctrl.Finish()
+
+		sw.SetModule("iam")
+
+		s, err := hcl2shim.HCL2ValueFromFlatmap(map[string]string{"name": "Pepito"}, aws.Provider().(*schema.Provider).ResourcesMap[tp].CoreConfigSchema().ImpliedType())
+		require.NoError(t, err)
+
+		res.EXPECT().Type().Return(tp)
+		res.EXPECT().Provider().Return(prv)
+		res.EXPECT().TFResource().Return(aws.Provider().(*schema.Provider).ResourcesMap[tp])
+		res.EXPECT().CoreConfigSchema().Return(aws.Provider().(*schema.Provider).ResourcesMap[tp].CoreConfigSchema())
+		res.EXPECT().ResourceInstanceObject().Return(providers.ImportedResource{
+			TypeName: tp,
+			State:    s,
+		}.AsInstanceObject())
+
+		prv.EXPECT().String().Return("aws")
+
+		require.NoError(t, sw.Write("aws_iam_user.name", res))
+		require.NoError(t, sw.Sync())
+
+		var st map[string]interface{}
+		require.NoError(t, json.Unmarshal(b.Bytes(), &st))
+
+		r := st["resources"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "module.iam", r["module"])
+		assert.Equal(t, "aws_iam_user", r["type"])
+		assert.Equal(t, "provider.aws", r["provider"])
+	})
+	t.Run("SuccessWithVersion", func(t *testing.T) {
+		tests := []struct {
+			Name     string
+			Version  string
+			Expected map[string]interface{}
+		}{
+			{
+				Name:    "Legacy",
+				Version: "0.11.14",
+				Expected: map[string]interface{}{
+					"version":           float64(3),
+					"terraform_version": "0.11.14",
+				},
+			},
+			{
+				Name:    "Registry",
+				Version: "1.5.0",
+				Expected: map[string]interface{}{
+					"version":           float64(4),
+					"terraform_version": "1.5.0",
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.Name, func(t *testing.T) {
+				var (
+					ctrl = gomock.NewController(t)
+					b    = &bytes.Buffer{}
+					sw   = state.NewWriter(b)
+					prv  = mock.NewProvider(ctrl)
+					res  = mock.NewResource(ctrl)
+					tp   = "aws_iam_user"
+				)
+
+				defer ctrl.Finish()
+
+				require.NoError(t, sw.SetVersion(tt.Version))
+
+				s, err := hcl2shim.HCL2ValueFromFlatmap(map[string]string{"id": "Pepito", "name": "Pepito"}, aws.Provider().(*schema.Provider).ResourcesMap[tp].CoreConfigSchema().ImpliedType())
+				require.NoError(t, err)
+
+				res.EXPECT().Type().Return(tp).AnyTimes()
+				res.EXPECT().Provider().Return(prv)
+				res.EXPECT().TFResource().Return(aws.Provider().(*schema.Provider).ResourcesMap[tp]).AnyTimes()
+				res.EXPECT().CoreConfigSchema().Return(aws.Provider().(*schema.Provider).ResourcesMap[tp].CoreConfigSchema())
+				res.EXPECT().ResourceInstanceObject().Return(providers.ImportedResource{
+					TypeName: tp,
+					State:    s,
+				}.AsInstanceObject())
+
+				prv.EXPECT().String().Return("aws")
+
+				require.NoError(t, sw.Write("aws_iam_user.name", res))
+				require.NoError(t, sw.Sync())
+
+				var st map[string]interface{}
+				require.NoError(t, json.Unmarshal(b.Bytes(), &st))
+
+				for k, v := range tt.Expected {
+					assert.Equal(t, v, st[k], k)
+				}
+
+				if tt.Version == "0.11.14" {
+					r := st["modules"].([]interface{})[0].(map[string]interface{})["resources"].(map[string]interface{})["aws_iam_user.name"].(map[string]interface{})
+					assert.Equal(t, "provider.aws", r["provider"])
+					assert.Equal(t, "Pepito", r["primary"].(map[string]interface{})["id"])
+					assert.Equal(t, "Pepito", r["primary"].(map[string]interface{})["attributes"].(map[string]interface{})["name"])
+				} else {
+					r := st["resources"].([]interface{})[0].(map[string]interface{})
+					assert.Equal(t, `provider["registry.terraform.io/hashicorp/aws"]`, r["provider"])
+					assert.Equal(t, "Pepito", r["instances"].([]interface{})[0].(map[string]interface{})["attributes"].(map[string]interface{})["name"])
+				}
+			})
+		}
+	})
+}
+
+func TestSetVersion(t *testing.T) {
+	t.Run("ErrorInvalid", func(t *testing.T) {
+		err := state.NewWriter(nil).SetVersion("latest")
+		assert.Error(t, err)
+	})
+
+	t.Run("ErrorUnsupported", func(t *testing.T) {
+		err := state.NewWriter(nil).SetVersion("4")
+		assert.Error(t, err)
+	})
 }
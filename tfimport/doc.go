@@ -0,0 +1,6 @@
+// Package tfimport has the Writer that generates the
+// 'terraform import' commands of the imported resources,
+// as a shell script, CSV or 'import' blocks, to import
+// them into a Terraform configuration written by hand
+// instead of the generated one
+package tfimport
@@ -0,0 +1,133 @@
+package tfimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// Format is the format of the imports
+type Format int
+
+// List of all the Formats supported
+const (
+	// Script is a shell script with
+	// a 'terraform import' on each line
+	Script Format = iota
+	// CSV has the address, type,
+	// name and ID of each resource
+	CSV
+	// HCL has an 'import' block of each
+	// resource, of Terraform 1.5 or newer
+	HCL
+)
+
+// Writer is a Writer implementation that generates
+// the 'terraform import ADDRESS ID' of the resources
+type Writer struct {
+	*provider.Collector
+
+	format Format
+	writer io.Writer
+}
+
+// NewWriter returns a Writer initialization
+// that writes the imports with the format
+func NewWriter(w io.Writer, f Format) *Writer {
+	return &Writer{
+		Collector: provider.NewCollector("terraform import"),
+		format:    f,
+		writer:    w,
+	}
+}
+
+// Sync writes the imports to the internal w
+func (w *Writer) Sync() error {
+	var (
+		b   []byte
+		err error
+	)
+	switch w.format {
+	case Script:
+		b = w.script()
+	case CSV:
+		b, err = w.csv()
+	case HCL:
+		b = w.hcl()
+	default:
+		err = fmt.Errorf("invalid format %d", w.format)
+	}
+	if err != nil {
+		return errors.Wrap(err, "error while encoding the imports")
+	}
+
+	_, err = w.writer.Write(b)
+	if err != nil {
+		return errors.Wrap(err, "error while writing the imports")
+	}
+
+	return nil
+}
+
+// script returns the shell script that
+// imports all the resources in order
+func (w *Writer) script() []byte {
+	var buff bytes.Buffer
+
+	buff.WriteString("#!/bin/sh\n")
+	buff.WriteString("# Generated by terracognita, run it on the directory of the\n")
+	buff.WriteString("# Terraform configuration with the resources of the addresses\n")
+	buff.WriteString("set -e\n\n")
+	for _, k := range w.Keys() {
+		fmt.Fprintf(&buff, "terraform import %s %s\n", quote(k), quote(w.Resource(k).ID()))
+	}
+
+	return buff.Bytes()
+}
+
+// csv returns the CSV with the address,
+// type, name and ID of each resource
+func (w *Writer) csv() ([]byte, error) {
+	var buff bytes.Buffer
+
+	cw := csv.NewWriter(&buff)
+	if err := cw.Write([]string{"address", "type", "name", "id"}); err != nil {
+		return nil, err
+	}
+	for _, k := range w.Keys() {
+		keys := strings.Split(k, ".")
+		if err := cw.Write([]string{k, keys[0], keys[1], w.Resource(k).ID()}); err != nil {
+			return nil, err
+		}
+	}
+	cw.Flush()
+
+	return buff.Bytes(), cw.Error()
+}
+
+// hcl returns the 'import' blocks of all the
+// resources, imported on the next plan and apply
+func (w *Writer) hcl() []byte {
+	var buff bytes.Buffer
+
+	for i, k := range w.Keys() {
+		if i != 0 {
+			buff.WriteString("\n")
+		}
+		fmt.Fprintf(&buff, "import {\n  to = %s\n  id = %q\n}\n", k, w.Resource(k).ID())
+	}
+
+	return buff.Bytes()
+}
+
+// quote quotes the s for the shell with single
+// quotes, so no character is interpreted
+func quote(s string) string {
+	return "'" + strings.Replace(s, "'", `'"'"'`, -1) + "'"
+}
@@ -0,0 +1,120 @@
+package tfimport_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/tfimport"
+)
+
+func TestWriter(t *testing.T) {
+	resources := func(ctrl *gomock.Controller) (*mock.Resource, *mock.Resource) {
+		var (
+			r1 = mock.NewResource(ctrl)
+			r2 = mock.NewResource(ctrl)
+		)
+
+		r1.EXPECT().ID().Return("i-1")
+		r2.EXPECT().ID().Return("john's")
+
+		return r1, r2
+	}
+
+	t.Run("Script", func(t *testing.T) {
+		var (
+			ctrl   = gomock.NewController(t)
+			b      = &bytes.Buffer{}
+			tw     = tfimport.NewWriter(b, tfimport.Script)
+			r1, r2 = resources(ctrl)
+
+			script = `#!/bin/sh
+# Generated by terracognita, run it on the directory of the
+# Terraform configuration with the resources of the addresses
+set -e
+
+terraform import 'aws_instance.front' 'i-1'
+terraform import 'aws_iam_user.john' 'john'"'"'s'
+`
+		)
+		defer ctrl.Finish()
+
+		require.NoError(t, tw.Write("aws_instance.front", r1))
+		require.NoError(t, tw.Write("aws_iam_user.john", r2))
+
+		ok, err := tw.Has("aws_iam_user.john")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, tw.Sync())
+		assert.Equal(t, script, b.String())
+	})
+	t.Run("CSV", func(t *testing.T) {
+		var (
+			ctrl   = gomock.NewController(t)
+			b      = &bytes.Buffer{}
+			tw     = tfimport.NewWriter(b, tfimport.CSV)
+			r1, r2 = resources(ctrl)
+
+			imports = `address,type,name,id
+aws_instance.front,aws_instance,front,i-1
+aws_iam_user.john,aws_iam_user,john,john's
+`
+		)
+		defer ctrl.Finish()
+
+		require.NoError(t, tw.Write("aws_instance.front", r1))
+		require.NoError(t, tw.Write("aws_iam_user.john", r2))
+
+		require.NoError(t, tw.Sync())
+		assert.Equal(t, imports, b.String())
+	})
+	t.Run("HCL", func(t *testing.T) {
+		var (
+			ctrl   = gomock.NewController(t)
+			b      = &bytes.Buffer{}
+			tw     = tfimport.NewWriter(b, tfimport.HCL)
+			r1, r2 = resources(ctrl)
+
+			imports = `import {
+  to = aws_instance.front
+  id = "i-1"
+}
+
+import {
+  to = aws_iam_user.john
+  id = "john's"
+}
+`
+		)
+		defer ctrl.Finish()
+
+		require.NoError(t, tw.Write("aws_instance.front", r1))
+		require.NoError(t, tw.Write("aws_iam_user.john", r2))
+
+		require.NoError(t, tw.Sync())
+		assert.Equal(t, imports, b.String())
+	})
+	t.Run("Errors", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			tw   = tfimport.NewWriter(nil, tfimport.Script)
+			r    = mock.NewResource(ctrl)
+		)
+		defer ctrl.Finish()
+
+		assert.Equal(t, errcode.ErrWriterRequiredKey, tw.Write("", r))
+		assert.Equal(t, errcode.ErrWriterRequiredValue, tw.Write("aws_instance.front", nil))
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(tw.Write("aws_instance", r)))
+		assert.Equal(t, errcode.ErrWriterInvalidTypeValue, errors.Cause(tw.Write("aws_instance.front", "value")))
+
+		require.NoError(t, tw.Write("aws_instance.front", r))
+		assert.Equal(t, errcode.ErrWriterAlreadyExistsKey, errors.Cause(tw.Write("aws_instance.front", r)))
+	})
+}
@@ -0,0 +1,5 @@
+// Package tftest has the Writer that generates a Terraform
+// test file (.tftest.hcl) asserting the key attributes of the
+// imported resources, as a starting regression suite of the
+// generated configuration
+package tftest
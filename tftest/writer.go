@@ -0,0 +1,128 @@
+package tftest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// keyAttributes are the attributes asserted of the resources of
+// each type, the ones that define them and should not change
+var keyAttributes = map[string][]string{
+	"aws_vpc":                       {"cidr_block"},
+	"aws_subnet":                    {"cidr_block", "availability_zone"},
+	"aws_instance":                  {"instance_type", "ami"},
+	"aws_security_group":            {"name", "vpc_id"},
+	"aws_s3_bucket":                 {"bucket"},
+	"aws_db_instance":               {"engine", "instance_class"},
+	"aws_rds_cluster":               {"engine", "engine_version"},
+	"aws_elasticache_cluster":       {"engine", "node_type"},
+	"aws_lb":                        {"load_balancer_type", "internal"},
+	"aws_iam_role":                  {"name"},
+	"aws_iam_user":                  {"name"},
+	"aws_route53_zone":              {"name"},
+	"aws_ebs_volume":                {"type", "size"},
+	"aws_eks_cluster":               {"version"},
+	"google_compute_network":        {"name", "auto_create_subnetworks"},
+	"google_compute_subnetwork":     {"ip_cidr_range", "region"},
+	"google_compute_instance":       {"machine_type", "zone"},
+	"google_compute_disk":           {"type", "size"},
+	"google_storage_bucket":         {"location", "storage_class"},
+	"google_sql_database_instance":  {"database_version", "region"},
+	"google_container_cluster":      {"location"},
+	"google_spanner_instance":       {"config", "num_nodes"},
+	"google_compute_global_address": {"address"},
+}
+
+// Writer is a Writer implementation that generates a Terraform
+// test with the assertions of the key attributes of the resources.
+// The resources of types without key attributes are ignored
+type Writer struct {
+	*provider.Collector
+
+	writer io.Writer
+}
+
+// NewWriter returns a Writer initialization
+func NewWriter(w io.Writer) *Writer {
+	types := make([]string, 0, len(keyAttributes))
+	for t := range keyAttributes {
+		types = append(types, t)
+	}
+
+	return &Writer{
+		Collector: provider.NewCollector("terraform test", types...),
+		writer:    w,
+	}
+}
+
+// Sync writes the test to the internal w, with a 'run' block
+// that plans the configuration and asserts the key attributes
+// of the resources with the values they were imported with
+func (w *Writer) Sync() error {
+	var buff bytes.Buffer
+
+	buff.WriteString("# Generated by terracognita, run it with 'terraform test' on the\n")
+	buff.WriteString("# directory of the generated configuration (Terraform 1.6 or newer)\n\n")
+	buff.WriteString("run \"imported\" {\n  command = plan\n")
+
+	for _, k := range w.Keys() {
+		d := w.Resource(k).Data()
+		if d == nil {
+			continue
+		}
+
+		for _, a := range keyAttributes[strings.Split(k, ".")[0]] {
+			v, ok := value(d.Get(a))
+			if !ok {
+				continue
+			}
+
+			fmt.Fprintf(&buff, "\n  assert {\n    condition     = %s.%s == %s\n    error_message = %s\n  }\n", k, a, v, quote(fmt.Sprintf("The %s of %s is not the imported one", a, k)))
+		}
+	}
+
+	buff.WriteString("}\n")
+
+	_, err := w.writer.Write(buff.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "error while writing the test")
+	}
+
+	return nil
+}
+
+// value returns the v as an HCL value, if it's
+// a scalar one and not empty, as those are not
+// set on the configuration
+func value(v interface{}) (string, bool) {
+	switch vv := v.(type) {
+	case string:
+		if vv == "" {
+			return "", false
+		}
+		return quote(vv), true
+	case int:
+		return strconv.Itoa(vv), true
+	case float64:
+		return strconv.FormatFloat(vv, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(vv), true
+	default:
+		return "", false
+	}
+}
+
+// quote quotes the s as an HCL string,
+// escaping the template sequences
+func quote(s string) string {
+	s = strconv.Quote(s)
+	s = strings.Replace(s, "${", "$${", -1)
+	return strings.Replace(s, "%{", "%%{", -1)
+}
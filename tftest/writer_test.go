@@ -0,0 +1,98 @@
+package tftest_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/tftest"
+)
+
+func TestWriter(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl      = gomock.NewController(t)
+			b         = &bytes.Buffer{}
+			tw        = tftest.NewWriter(b)
+			vpc       = mock.NewResource(ctrl)
+			lb        = mock.NewResource(ctrl)
+			dashboard = mock.NewResource(ctrl)
+
+			test = `# Generated by terracognita, run it with 'terraform test' on the
+# directory of the generated configuration (Terraform 1.6 or newer)
+
+run "imported" {
+  command = plan
+
+  assert {
+    condition     = aws_vpc.main.cidr_block == "10.0.0.0/16"
+    error_message = "The cidr_block of aws_vpc.main is not the imported one"
+  }
+
+  assert {
+    condition     = aws_lb.front.load_balancer_type == "application"
+    error_message = "The load_balancer_type of aws_lb.front is not the imported one"
+  }
+
+  assert {
+    condition     = aws_lb.front.internal == false
+    error_message = "The internal of aws_lb.front is not the imported one"
+  }
+}
+`
+		)
+		defer ctrl.Finish()
+
+		vpc.EXPECT().Data().Return(schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			"cidr_block": &schema.Schema{Type: schema.TypeString, Optional: true},
+		}, map[string]interface{}{
+			"cidr_block": "10.0.0.0/16",
+		}))
+		lb.EXPECT().Data().Return(schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			"load_balancer_type": &schema.Schema{Type: schema.TypeString, Optional: true},
+			"internal":           &schema.Schema{Type: schema.TypeBool, Optional: true},
+		}, map[string]interface{}{
+			"load_balancer_type": "application",
+		}))
+
+		require.NoError(t, tw.Write("aws_vpc.main", vpc))
+		require.NoError(t, tw.Write("aws_lb.front", lb))
+
+		// The types without key attributes are ignored
+		require.NoError(t, tw.Write("aws_cloudwatch_dashboard.main", dashboard))
+		ok, err := tw.Has("aws_cloudwatch_dashboard.main")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, tw.Sync())
+		assert.Equal(t, test, b.String())
+	})
+	t.Run("ErrorInvalidKey", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			tw   = tftest.NewWriter(&bytes.Buffer{})
+		)
+		defer ctrl.Finish()
+
+		err := tw.Write("aws_vpc", mock.NewResource(ctrl))
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(err))
+	})
+	t.Run("ErrorAlreadyExistsKey", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			tw   = tftest.NewWriter(&bytes.Buffer{})
+		)
+		defer ctrl.Finish()
+
+		require.NoError(t, tw.Write("aws_vpc.main", mock.NewResource(ctrl)))
+		err := tw.Write("aws_vpc.main", mock.NewResource(ctrl))
+		assert.Equal(t, errcode.ErrWriterAlreadyExistsKey, errors.Cause(err))
+	})
+}
@@ -0,0 +1,285 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/pkg/errors"
+)
+
+// Audit is the audit of the calls to the APIs of the providers
+// done by the HTTPClient and the clients of AuditClient
+type Audit struct {
+	// ReadOnly fails the calls that are not read only (see
+	// IsReadOnly) before sending them
+	ReadOnly bool
+
+	// OnViolation is called with the error of the calls that
+	// are not read only, as the SDKs may retry or ignore it
+	OnViolation func(error)
+
+	// Log is where each call is written, as a JSON line,
+	// nil to not write them
+	Log io.Writer
+}
+
+// AuditEntry is the entry of the Audit.Log of a call
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	Method    string    `json:"method"`
+	Host      string    `json:"host"`
+	Path      string    `json:"path"`
+	Operation string    `json:"operation,omitempty"`
+	ReadOnly  bool      `json:"read_only"`
+	Status    int       `json:"status,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+var (
+	audit   Audit
+	auditMu sync.Mutex
+)
+
+// readOnlyPrefixes are the prefixes of the names of the
+// operations that only read (ex: the AWS DescribeInstances
+// or the Google getIamPolicy), compared in lower case
+var readOnlyPrefixes = []string{"get", "list", "describe", "batchget", "search", "lookup"}
+
+// SetAudit sets the a as the audit of the calls, it has to be
+// set before creating the clients of the providers
+func SetAudit(a Audit) {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	audit = a
+}
+
+// AuditClient wraps the transport of the c with the audit, for
+// the clients of the providers not created with the HTTPClient
+// (ex: the Terraform ones)
+func AuditClient(c *http.Client) {
+	if c == nil {
+		return
+	}
+	c.Transport = auditTransport(c.Transport)
+}
+
+// auditTransport returns the base wrapped with the audit,
+// which is read on each call so it can be set after it
+func auditTransport(base http.RoundTripper) http.RoundTripper {
+	if _, ok := base.(*auditRoundTripper); ok {
+		return base
+	}
+	return &auditRoundTripper{base: base}
+}
+
+type auditRoundTripper struct {
+	base http.RoundTripper
+}
+
+func (a *auditRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	auditMu.Lock()
+	au := audit
+	auditMu.Unlock()
+
+	base := a.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if !au.ReadOnly && au.Log == nil {
+		return base.RoundTrip(r)
+	}
+
+	r, op, err := operation(r)
+	if err != nil {
+		return nil, err
+	}
+
+	e := AuditEntry{
+		Time:      time.Now().UTC(),
+		Method:    r.Method,
+		Host:      r.URL.Host,
+		Path:      r.URL.Path,
+		Operation: op,
+		ReadOnly:  IsReadOnly(r.Method, op, r.URL.Path),
+	}
+
+	var res *http.Response
+	if au.ReadOnly && !e.ReadOnly {
+		err = errors.Wrapf(errcode.ErrAuditNotReadOnly, "%s %s%s", r.Method, r.URL.Host, r.URL.Path)
+		if op != "" {
+			err = errors.Wrapf(err, "operation %s", op)
+		}
+	} else {
+		res, err = base.RoundTrip(r)
+	}
+
+	if err != nil {
+		e.Error = err.Error()
+	} else {
+		e.Status = res.StatusCode
+	}
+	au.write(e)
+
+	if errors.Cause(err) == errcode.ErrAuditNotReadOnly && au.OnViolation != nil {
+		au.OnViolation(err)
+	}
+
+	return res, err
+}
+
+// write writes the e to the Log, if any
+func (a Audit) write(e AuditEntry) {
+	if a.Log == nil {
+		return
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	_, _ = a.Log.Write(append(b, '\n'))
+}
+
+// IsReadOnly returns if the call with the method, the name of
+// the operation op (empty if unknown) and the path only reads.
+// The PUT, PATCH and DELETE are never read only. The ones without
+// operation are read only if they are GET or HEAD, and for the
+// POST of the RPC-style APIs the last segment of the path is the
+// operation (ex: the AWS Batch POST /v1/describejobqueues). It's
+// best-effort as it's only the name, not a list of the operations
+// of each service, so the unknown ones are not read only
+func IsReadOnly(method, op, p string) bool {
+	switch method {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return false
+	}
+
+	if op == "" {
+		switch method {
+		case http.MethodGet, http.MethodHead:
+			return true
+		case http.MethodPost:
+			op = path.Base(p)
+		default:
+			return false
+		}
+	}
+
+	op = strings.ToLower(op)
+	for _, p := range readOnlyPrefixes {
+		if strings.HasPrefix(op, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// operation returns the name of the operation of the r, empty if
+// it has none, from the target of the AWS JSON APIs, the Action of
+// the AWS query APIs or the custom method of the Google APIs. As
+// the body of r may be read the returned request has to be used
+func operation(r *http.Request) (*http.Request, string, error) {
+	if t := r.Header.Get("X-Amz-Target"); t != "" {
+		return r, t[strings.LastIndex(t, ".")+1:], nil
+	}
+
+	if a := r.URL.Query().Get("Action"); a != "" {
+		return r, a, nil
+	}
+
+	if r.Body != nil && strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "could not read the body of the request")
+		}
+		r.Body.Close()
+
+		// The body is read so a copy of
+		// the request is made with it
+		cr := new(http.Request)
+		*cr = *r
+		cr.Body = ioutil.NopCloser(bytes.NewReader(b))
+		r = cr
+
+		if vs, err := url.ParseQuery(string(b)); err == nil && vs.Get("Action") != "" {
+			return r, vs.Get("Action"), nil
+		}
+	}
+
+	if s := path.Base(r.URL.Path); strings.Contains(s, ":") {
+		return r, s[strings.LastIndex(s, ":")+1:], nil
+	}
+
+	return r, "", nil
+}
+
+// Auditing returns if the calls are audited, with
+// the Audit.ReadOnly or the Audit.Log set
+func Auditing() bool {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	return audit.ReadOnly || audit.Log != nil
+}
+
+// UnexportedField returns the value of the unexported field with
+// the name of the struct pointed by v. It's used to reach the
+// HTTP clients of the Terraform providers, which are not exported
+// nor configurable, so any change of them on a new version of the
+// providers is an ErrAuditNoClient instead of not auditing them
+func UnexportedField(v interface{}, name string) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, errors.Wrapf(errcode.ErrAuditNoClient, "expected a pointer to a struct, found %T", v)
+	}
+
+	f := rv.Elem().FieldByName(name)
+	if !f.IsValid() {
+		return nil, errors.Wrapf(errcode.ErrAuditNoClient, "the %T has no field %q", v, name)
+	}
+
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem().Interface(), nil
+}
+
+// SetUnexportedField sets the value to the unexported field with
+// the name of the struct pointed by v, like UnexportedField it's
+// used on the clients of the Terraform providers that are not
+// configurable (ex: to refresh the credentials of them)
+func SetUnexportedField(v interface{}, name string, value interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.Wrapf(errcode.ErrAuditNoClient, "expected a pointer to a struct, found %T", v)
+	}
+
+	f := rv.Elem().FieldByName(name)
+	if !f.IsValid() {
+		return errors.Wrapf(errcode.ErrAuditNoClient, "the %T has no field %q", v, name)
+	}
+
+	val := reflect.ValueOf(value)
+	if !val.IsValid() || !val.Type().AssignableTo(f.Type()) {
+		return errors.Wrapf(errcode.ErrAuditNoClient, "the field %q of the %T is a %s, found %T", name, v, f.Type(), value)
+	}
+
+	reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem().Set(val)
+
+	return nil
+}
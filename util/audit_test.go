@@ -0,0 +1,148 @@
+package util_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	defer util.SetAudit(util.Audit{})
+
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer ts.Close()
+
+	var (
+		b          bytes.Buffer
+		violations []error
+	)
+	util.SetAudit(util.Audit{
+		ReadOnly:    true,
+		Log:         &b,
+		OnViolation: func(err error) { violations = append(violations, err) },
+	})
+
+	t.Run("SuccessReadOnly", func(t *testing.T) {
+		b.Reset()
+
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/", strings.NewReader("{}"))
+		require.NoError(t, err)
+		req.Header.Set("X-Amz-Target", "DynamoDB_20120810.DescribeTable")
+
+		res, err := util.HTTPClient().Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+
+		var e util.AuditEntry
+		require.NoError(t, json.Unmarshal(b.Bytes(), &e))
+		assert.Equal(t, "DescribeTable", e.Operation)
+		assert.Equal(t, http.MethodPost, e.Method)
+		assert.True(t, e.ReadOnly)
+		assert.Equal(t, http.StatusOK, e.Status)
+		assert.Equal(t, 1, calls)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("ErrorNotReadOnly", func(t *testing.T) {
+		b.Reset()
+
+		res, err := util.HTTPClient().PostForm(ts.URL+"/", url.Values{"Action": {"RunInstances"}})
+		if res != nil {
+			res.Body.Close()
+		}
+		require.IsType(t, &url.Error{}, err)
+		assert.Equal(t, errcode.ErrAuditNotReadOnly, errors.Cause(err.(*url.Error).Err))
+
+		var e util.AuditEntry
+		require.NoError(t, json.Unmarshal(b.Bytes(), &e))
+		assert.Equal(t, "RunInstances", e.Operation)
+		assert.False(t, e.ReadOnly)
+		assert.NotEmpty(t, e.Error)
+		assert.Equal(t, 1, calls)
+		require.Len(t, violations, 1)
+		assert.Equal(t, errcode.ErrAuditNotReadOnly, errors.Cause(violations[0]))
+	})
+}
+
+func TestIsReadOnly(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Method   string
+		Op       string
+		Path     string
+		ReadOnly bool
+	}{
+		{Name: "GET", Method: http.MethodGet, Path: "/compute/v1/projects/p/zones/z/instances", ReadOnly: true},
+		{Name: "DELETE", Method: http.MethodDelete, Path: "/compute/v1/projects/p/zones/z/instances/i"},
+		{Name: "Describe", Method: http.MethodPost, Op: "DescribeInstances", ReadOnly: true},
+		{Name: "Terminate", Method: http.MethodPost, Op: "TerminateInstances"},
+		{Name: "GoogleCustomMethod", Method: http.MethodPost, Op: "getIamPolicy", ReadOnly: true},
+		{Name: "PathOperation", Method: http.MethodPost, Path: "/v1/describejobqueues", ReadOnly: true},
+		{Name: "PathCreate", Method: http.MethodPost, Path: "/v1/createjobqueue"},
+		{Name: "DeletePathOperation", Method: http.MethodDelete, Path: "/bucket/listing"},
+		{Name: "PutPathOperation", Method: http.MethodPut, Path: "/bucket/get-config"},
+		{Name: "PatchPathOperation", Method: http.MethodPatch, Path: "/v1/describejobqueues"},
+		{Name: "DeleteOperation", Method: http.MethodDelete, Op: "DescribeInstances"},
+		{Name: "OptionsPathOperation", Method: http.MethodOptions, Path: "/v1/describejobqueues"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			assert.Equal(t, tt.ReadOnly, util.IsReadOnly(tt.Method, tt.Op, tt.Path))
+		})
+	}
+}
+
+func TestUnexportedField(t *testing.T) {
+	c := &http.Client{}
+	s := struct{ client *http.Client }{client: c}
+
+	t.Run("Success", func(t *testing.T) {
+		f, err := util.UnexportedField(&s, "client")
+		require.NoError(t, err)
+		assert.Same(t, c, f)
+	})
+
+	t.Run("ErrorNoField", func(t *testing.T) {
+		_, err := util.UnexportedField(&s, "conn")
+		assert.Equal(t, errcode.ErrAuditNoClient, errors.Cause(err))
+	})
+
+	t.Run("ErrorNoPointer", func(t *testing.T) {
+		_, err := util.UnexportedField(s, "client")
+		assert.Equal(t, errcode.ErrAuditNoClient, errors.Cause(err))
+	})
+}
+
+func TestSetUnexportedField(t *testing.T) {
+	s := struct{ client *http.Client }{}
+
+	t.Run("Success", func(t *testing.T) {
+		c := &http.Client{}
+		require.NoError(t, util.SetUnexportedField(&s, "client", c))
+		assert.Same(t, c, s.client)
+	})
+
+	t.Run("ErrorNoField", func(t *testing.T) {
+		err := util.SetUnexportedField(&s, "conn", &http.Client{})
+		assert.Equal(t, errcode.ErrAuditNoClient, errors.Cause(err))
+	})
+
+	t.Run("ErrorType", func(t *testing.T) {
+		err := util.SetUnexportedField(&s, "client", "client")
+		assert.Equal(t, errcode.ErrAuditNoClient, errors.Cause(err))
+	})
+}
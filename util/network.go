@@ -0,0 +1,120 @@
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Network is the configuration of the network of the
+// HTTP clients to the APIs of the providers
+type Network struct {
+	// CABundle is a PEM file with the certificates of the
+	// CAs trusted instead of the ones of the system (ex: with
+	// the one of a corporate proxy that intercepts the TLS)
+	CABundle string
+
+	// HTTPProxy and HTTPSProxy are the URLs of the proxies of
+	// the HTTP and HTTPS requests and NoProxy the hosts requested
+	// without them, with the same format as the NO_PROXY. The
+	// ones not set are read from the environment
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+}
+
+// networkEnv are the environment variables read by the SDKs that
+// create their own HTTP clients (ex: the Terraform AWS provider),
+// with the values of them when started so those are restored if
+// the Network does not set them
+var networkEnv = map[string]string{
+	"AWS_CA_BUNDLE": os.Getenv("AWS_CA_BUNDLE"),
+	"HTTP_PROXY":    os.Getenv("HTTP_PROXY"),
+	"HTTPS_PROXY":   os.Getenv("HTTPS_PROXY"),
+	"NO_PROXY":      os.Getenv("NO_PROXY"),
+}
+
+// envProxy are the proxies of the environment when started,
+// as it's changed by the SetNetwork
+var envProxy = httpproxy.FromEnvironment()
+
+// SetNetwork configures with the n the transport of the HTTPClient
+// and the http.DefaultTransport, used by the clients of the Terraform
+// Google provider, and exports it to the environment for the clients
+// that create their own transport. The proxies of those are read only
+// once from the environment so they can not be changed after the
+// first request
+func SetNetwork(n Network) error {
+	tr, err := n.transport()
+	if err != nil {
+		return err
+	}
+
+	for k, v := range map[string]string{
+		"AWS_CA_BUNDLE": n.CABundle,
+		"HTTP_PROXY":    n.HTTPProxy,
+		"HTTPS_PROXY":   n.HTTPSProxy,
+		"NO_PROXY":      n.NoProxy,
+	} {
+		if v == "" {
+			v = networkEnv[k]
+		}
+		if err := os.Setenv(k, v); err != nil {
+			return fmt.Errorf("could not set the %s because: %s", k, err)
+		}
+	}
+
+	HTTPClient().Transport = auditTransport(tr)
+	http.DefaultTransport = tr
+
+	return nil
+}
+
+// transport returns a NewTransport configured with the n
+func (n Network) transport() (*http.Transport, error) {
+	tr := NewTransport()
+
+	if n.CABundle != "" {
+		b, err := ioutil.ReadFile(n.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the CA bundle %s because: %s", n.CABundle, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("the CA bundle %s has no PEM certificates", n.CABundle)
+		}
+		tr.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	cfg := *envProxy
+	for _, p := range []struct {
+		name, value string
+		field       *string
+	}{
+		{"HTTP", n.HTTPProxy, &cfg.HTTPProxy},
+		{"HTTPS", n.HTTPSProxy, &cfg.HTTPSProxy},
+	} {
+		if p.value == "" {
+			continue
+		}
+		if u, err := url.Parse(p.value); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid URL of the %s proxy %q, it has to be absolute (ex: http://proxy:3128)", p.name, p.value)
+		}
+		*p.field = p.value
+	}
+	if n.NoProxy != "" {
+		cfg.NoProxy = n.NoProxy
+	}
+
+	proxy := cfg.ProxyFunc()
+	tr.Proxy = func(r *http.Request) (*url.URL, error) { return proxy(r.URL) }
+
+	return tr, nil
+}
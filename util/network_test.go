@@ -0,0 +1,74 @@
+package util_test
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cycloidio/terracognita/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetNetwork(t *testing.T) {
+	defer util.SetNetwork(util.Network{})
+
+	t.Run("SuccessCABundle", func(t *testing.T) {
+		ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer ts.Close()
+
+		dir, err := ioutil.TempDir("", "terracognita-network")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		bundle := filepath.Join(dir, "ca.pem")
+		require.NoError(t, ioutil.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}), 0644))
+
+		_, err = util.HTTPClient().Get(ts.URL)
+		require.Error(t, err)
+
+		require.NoError(t, util.SetNetwork(util.Network{CABundle: bundle}))
+		assert.Equal(t, bundle, os.Getenv("AWS_CA_BUNDLE"))
+
+		res, err := util.HTTPClient().Get(ts.URL)
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("SuccessProxy", func(t *testing.T) {
+		var host string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			host = r.Host
+		}))
+		defer ts.Close()
+
+		require.NoError(t, util.SetNetwork(util.Network{HTTPProxy: ts.URL, NoProxy: "internal.example.com"}))
+		assert.Equal(t, ts.URL, os.Getenv("HTTP_PROXY"))
+
+		res, err := http.Get("http://api.example.com/")
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, "api.example.com", host)
+	})
+
+	t.Run("ErrorCABundle", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "terracognita-network")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		bundle := filepath.Join(dir, "ca.pem")
+		require.NoError(t, ioutil.WriteFile(bundle, []byte("not a certificate"), 0644))
+
+		assert.Error(t, util.SetNetwork(util.Network{CABundle: bundle}))
+		assert.Error(t, util.SetNetwork(util.Network{CABundle: filepath.Join(dir, "missing.pem")}))
+	})
+
+	t.Run("ErrorProxy", func(t *testing.T) {
+		assert.Error(t, util.SetNetwork(util.Network{HTTPSProxy: "proxy:3128"}))
+	})
+}
@@ -0,0 +1,44 @@
+package util
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	httpClient     *http.Client
+	httpClientOnce sync.Once
+)
+
+// NewTransport returns an http.Transport tuned for the calls to the
+// cloud providers APIs: the connections are kept alive and pooled
+// per host, as the default one only keeps 2 idle connections per
+// host which are not enough for the concurrent calls to the same API
+func NewTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          200,
+		MaxIdleConnsPerHost:   50,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// HTTPClient returns the http.Client shared by all the readers of
+// the providers, with a transport of NewTransport created once so
+// the connections are reused between all the API clients. The
+// calls of it are audited (see SetAudit)
+func HTTPClient() *http.Client {
+	httpClientOnce.Do(func() {
+		httpClient = &http.Client{Transport: auditTransport(NewTransport())}
+	})
+
+	return httpClient
+}
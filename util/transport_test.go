@@ -0,0 +1,22 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransport(t *testing.T) {
+	tr := util.NewTransport()
+
+	assert.Equal(t, 50, tr.MaxIdleConnsPerHost)
+	assert.NotNil(t, tr.Proxy)
+}
+
+func TestHTTPClient(t *testing.T) {
+	c := util.HTTPClient()
+
+	assert.Same(t, c, util.HTTPClient())
+	assert.NotNil(t, c.Transport)
+}
@@ -0,0 +1,4 @@
+// Package validate checks offline the consistency of the
+// HCL and TFState generated, without running Terraform nor
+// calling the providers, so it can run before committing them
+package validate
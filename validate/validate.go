@@ -0,0 +1,544 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl2/hcl"
+	"github.com/hashicorp/hcl2/hcl/hclsyntax"
+	"github.com/hashicorp/hcl2/hclparse"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ignoredRoots are the roots of the references
+// that are not declared by any block
+var ignoredRoots = map[string]struct{}{
+	"path":      struct{}{},
+	"terraform": struct{}{},
+	"count":     struct{}{},
+	"each":      struct{}{},
+	"self":      struct{}{},
+}
+
+// Issue is an inconsistency found on the output
+type Issue struct {
+	// File is the file with the issue,
+	// relative to the directory validated
+	File    string `json:"file"`
+	Address string `json:"address,omitempty"`
+	Message string `json:"message"`
+}
+
+// String returns the Issue as 'FILE: ADDRESS: MESSAGE'
+func (i Issue) String() string {
+	if i.Address == "" {
+		return fmt.Sprintf("%s: %s", i.File, i.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.File, i.Address, i.Message)
+}
+
+// Result is the result of the validation of a directory
+type Result struct {
+	// Modules is the number of directories
+	// with .tf or .tfstate files validated
+	Modules int `json:"modules"`
+
+	// Resources is the number of
+	// resource blocks validated
+	Resources int `json:"resources"`
+
+	Issues []Issue `json:"issues"`
+}
+
+// IsValid checks if the Result has no Issues
+func (r *Result) IsValid() bool {
+	return len(r.Issues) == 0
+}
+
+// Write writes the r to the w
+func (r *Result) Write(w io.Writer) {
+	if r.IsValid() {
+		fmt.Fprintf(w, "Validated %d directories with %d resources, no issues found\n", r.Modules, r.Resources)
+		return
+	}
+
+	fmt.Fprintf(w, "Validated %d directories with %d resources, found %d issues:\n", r.Modules, r.Resources, len(r.Issues))
+	for _, i := range r.Issues {
+		fmt.Fprintf(w, "  %s\n", i)
+	}
+}
+
+// module is a directory with .tf files
+type module struct {
+	dir string
+
+	// resources are the files of the resource
+	// and data blocks by the address of them
+	resources map[string]string
+
+	variables map[string]struct{}
+	locals    map[string]struct{}
+
+	// modules are the sources of the
+	// module calls by the name of them
+	modules map[string]string
+
+	// iterators are the names of the
+	// iterators of the dynamic blocks
+	iterators map[string]struct{}
+
+	references []reference
+}
+
+// reference is a reference of a
+// block to other value of the module
+type reference struct {
+	file      string
+	address   string
+	traversal hcl.Traversal
+}
+
+// validator validates a directory
+// and the modules called by it
+type validator struct {
+	dir string
+
+	result  *Result
+	modules map[string]*module
+
+	// states are the addresses on the TFStates
+	// of the resources of the directories of the
+	// modules, the ones without any are not checked
+	states map[string]map[string]struct{}
+}
+
+// Dir validates the .tf and .tfstate files of the dir, and of all the
+// directories in it (ex: the --stacks). Each directory is a module in
+// which all the references have to be declared, the resources of the
+// TFStates have to have a block, on the directory or on the module
+// called for the ones of modules, and the blocks have to be on the
+// TFStates of the directory, if it has any
+func Dir(dir string) (*Result, error) {
+	v := &validator{
+		dir:     dir,
+		result:  &Result{Issues: make([]Issue, 0)},
+		modules: make(map[string]*module),
+		states:  make(map[string]map[string]struct{}),
+	}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != dir && strings.HasPrefix(info.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		return v.validateDir(path)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not validate %s", dir)
+	}
+
+	v.checkStates()
+
+	return v.result, nil
+}
+
+// validateDir validates the dir if it
+// has any .tf or .tfstate file
+func (v *validator) validateDir(dir string) error {
+	tfs, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return err
+	}
+	states, err := filepath.Glob(filepath.Join(dir, "*.tfstate"))
+	if err != nil {
+		return err
+	}
+	if len(tfs) == 0 && len(states) == 0 {
+		return nil
+	}
+
+	m, err := v.module(dir)
+	if err != nil {
+		return err
+	}
+
+	v.result.Modules++
+	v.result.Resources += m.resourcesCount()
+	v.checkReferences(m)
+
+	sort.Strings(states)
+	for _, s := range states {
+		if err := v.readState(m, s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// module returns the module of the dir, parsed only once
+func (v *validator) module(dir string) (*module, error) {
+	dir = filepath.Clean(dir)
+	if m, ok := v.modules[dir]; ok {
+		return m, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	m := &module{
+		dir:        dir,
+		resources:  make(map[string]string),
+		variables:  make(map[string]struct{}),
+		locals:     make(map[string]struct{}),
+		modules:    make(map[string]string),
+		iterators:  make(map[string]struct{}),
+		references: make([]reference, 0),
+	}
+	v.modules[dir] = m
+
+	p := hclparse.NewParser()
+	for _, file := range files {
+		rel := v.rel(file)
+
+		f, diags := p.ParseHCLFile(file)
+		if diags.HasErrors() {
+			v.issue(rel, "", fmt.Sprintf("could not be parsed: %s", diags.Error()))
+			continue
+		}
+
+		body, ok := f.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, b := range body.Blocks {
+			var address string
+			switch {
+			case b.Type == "resource" && len(b.Labels) == 2:
+				address = strings.Join(b.Labels, ".")
+			case b.Type == "data" && len(b.Labels) == 2:
+				address = "data." + strings.Join(b.Labels, ".")
+			case b.Type == "variable" && len(b.Labels) == 1:
+				m.variables[b.Labels[0]] = struct{}{}
+			case b.Type == "module" && len(b.Labels) == 1:
+				m.modules[b.Labels[0]] = moduleSource(b.Body)
+			case b.Type == "locals":
+				for n := range b.Body.Attributes {
+					m.locals[n] = struct{}{}
+				}
+			}
+
+			if address != "" {
+				if prev, ok := m.resources[address]; ok {
+					v.issue(rel, address, fmt.Sprintf("is declared more than once, also on %s", prev))
+				} else {
+					m.resources[address] = rel
+				}
+			}
+
+			m.addReferences(rel, address, b.Body)
+		}
+	}
+
+	return m, nil
+}
+
+// moduleSource returns the source of the body of
+// a module call, if it's a string, or empty
+func moduleSource(body *hclsyntax.Body) string {
+	a, ok := body.Attributes["source"]
+	if !ok {
+		return ""
+	}
+
+	v, diags := a.Expr.Value(nil)
+	if diags.HasErrors() || !v.IsWhollyKnown() || v.IsNull() || v.Type() != cty.String {
+		return ""
+	}
+
+	return v.AsString()
+}
+
+// addReferences adds the references of all the attributes of
+// the body, and of the nested blocks, of the block of the address
+func (m *module) addReferences(file, address string, body *hclsyntax.Body) {
+	names := make([]string, 0, len(body.Attributes))
+	for n := range body.Attributes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		for _, t := range body.Attributes[n].Expr.Variables() {
+			m.references = append(m.references, reference{file: file, address: address, traversal: t})
+		}
+	}
+
+	for _, b := range body.Blocks {
+		if b.Type == "dynamic" && len(b.Labels) == 1 {
+			it := b.Labels[0]
+			if a, ok := b.Body.Attributes["iterator"]; ok {
+				if t := hcl.ExprAsKeyword(a.Expr); t != "" {
+					it = t
+				}
+			}
+			m.iterators[it] = struct{}{}
+		}
+		m.addReferences(file, address, b.Body)
+	}
+}
+
+// resourcesCount returns the number of resource blocks
+func (m *module) resourcesCount() int {
+	var c int
+	for a := range m.resources {
+		if !strings.HasPrefix(a, "data.") {
+			c++
+		}
+	}
+	return c
+}
+
+// checkReferences checks that all the
+// references of the m are declared on it
+func (v *validator) checkReferences(m *module) {
+	for _, r := range m.references {
+		root := r.traversal.RootName()
+		if _, ok := ignoredRoots[root]; ok {
+			continue
+		}
+		if _, ok := m.iterators[root]; ok {
+			continue
+		}
+
+		names := traversalNames(r.traversal)
+
+		var declared bool
+		switch root {
+		case "var":
+			declared = len(names) > 1 && has(m.variables, names[1])
+		case "local":
+			declared = len(names) > 1 && has(m.locals, names[1])
+		case "module":
+			if len(names) > 1 {
+				_, declared = m.modules[names[1]]
+			}
+		case "data":
+			if len(names) > 2 {
+				_, declared = m.resources[strings.Join(names, ".")]
+			}
+		default:
+			if len(names) > 1 {
+				_, declared = m.resources[strings.Join(names, ".")]
+			}
+		}
+
+		if !declared {
+			v.issue(r.file, r.address, fmt.Sprintf("the reference to %s is not declared", strings.Join(names, ".")))
+		}
+	}
+}
+
+// traversalNames returns the names of the traversal that
+// identify what is referenced, the root and the attributes
+// up to 3 (ex: data.aws_ami.ubuntu of data.aws_ami.ubuntu.id)
+func traversalNames(t hcl.Traversal) []string {
+	max := 2
+	if t.RootName() == "data" {
+		max = 3
+	}
+
+	names := []string{t.RootName()}
+	for _, s := range t[1:] {
+		if len(names) == max {
+			break
+		}
+		a, ok := s.(hcl.TraverseAttr)
+		if !ok {
+			break
+		}
+		names = append(names, a.Name)
+	}
+
+	return names
+}
+
+// tfstate is the part of the TFState (version 4)
+// needed to validate the addresses of the resources
+type tfstate struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// readState checks that the managed resources of the TFState
+// file have a block on the m, or on the module called on it
+func (v *validator) readState(m *module, file string) error {
+	rel := v.rel(file)
+
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var s tfstate
+	if err := json.Unmarshal(b, &s); err != nil {
+		v.issue(rel, "", fmt.Sprintf("is not a valid TFState: %s", err))
+		return nil
+	}
+	if s.Version != 4 {
+		v.issue(rel, "", fmt.Sprintf("has the unsupported version %d, only 4 is supported", s.Version))
+		return nil
+	}
+
+	addresses := make(map[string]struct{})
+	for _, rs := range s.Resources {
+		if rs.Mode != "managed" {
+			continue
+		}
+
+		address := fmt.Sprintf("%s.%s", rs.Type, rs.Name)
+		full := address
+		if rs.Module != "" {
+			full = rs.Module + "." + address
+		}
+
+		if _, ok := addresses[full]; ok {
+			v.issue(rel, full, "is more than once on the TFState")
+			continue
+		}
+		addresses[full] = struct{}{}
+
+		for _, is := range rs.Instances {
+			if id, _ := is.Attributes["id"].(string); id == "" {
+				v.issue(rel, full, "has no ID on the TFState")
+				break
+			}
+		}
+
+		bm := m
+		if rs.Module != "" {
+			bm, err = v.calledModule(m, rel, rs.Module)
+			if err != nil {
+				return err
+			}
+			if bm == nil {
+				continue
+			}
+		}
+
+		if _, ok := v.states[bm.dir]; !ok {
+			v.states[bm.dir] = make(map[string]struct{})
+		}
+		v.states[bm.dir][address] = struct{}{}
+
+		if _, ok := bm.resources[address]; !ok {
+			if bm != m {
+				v.issue(rel, full, fmt.Sprintf("is on the TFState but has no block on the module %s", v.rel(bm.dir)))
+			} else {
+				v.issue(rel, full, "is on the TFState but has no block on the .tf files")
+			}
+		}
+	}
+
+	return nil
+}
+
+// calledModule returns the module called on the m with the
+// address (ex: module.vpc) of the TFState file, or nil if it
+// can not be checked as it's nested or the source is not local
+func (v *validator) calledModule(m *module, file, address string) (*module, error) {
+	parts := strings.Split(address, ".")
+	if len(parts) != 2 || parts[0] != "module" {
+		return nil, nil
+	}
+
+	src, ok := m.modules[parts[1]]
+	if !ok {
+		v.issue(file, address, "is on the TFState but the module is not declared")
+		return nil, nil
+	}
+	if !strings.HasPrefix(src, "./") && !strings.HasPrefix(src, "../") {
+		return nil, nil
+	}
+
+	dir := filepath.Join(m.dir, filepath.FromSlash(src))
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		v.issue(file, address, fmt.Sprintf("has the source %q which is not a directory", src))
+		return nil, nil
+	}
+
+	return v.module(dir)
+}
+
+// checkStates checks that the resource blocks of the
+// modules with TFStates are on them
+func (v *validator) checkStates() {
+	dirs := make([]string, 0, len(v.states))
+	for d := range v.states {
+		dirs = append(dirs, d)
+	}
+	sort.Strings(dirs)
+
+	for _, d := range dirs {
+		m := v.modules[d]
+
+		addresses := make([]string, 0, len(m.resources))
+		for a := range m.resources {
+			addresses = append(addresses, a)
+		}
+		sort.Strings(addresses)
+
+		for _, a := range addresses {
+			if strings.HasPrefix(a, "data.") {
+				continue
+			}
+			if _, ok := v.states[d][a]; !ok {
+				v.issue(m.resources[a], a, "has a block but is not on the TFState")
+			}
+		}
+	}
+}
+
+// issue adds the Issue to the result
+func (v *validator) issue(file, address, msg string) {
+	v.result.Issues = append(v.result.Issues, Issue{File: file, Address: address, Message: msg})
+}
+
+// rel returns the path relative to the dir validated
+func (v *validator) rel(path string) string {
+	if r, err := filepath.Rel(v.dir, path); err == nil {
+		return filepath.ToSlash(r)
+	}
+	return path
+}
+
+// has checks if the s has the k
+func has(s map[string]struct{}, k string) bool {
+	_, ok := s[k]
+	return ok
+}
@@ -0,0 +1,166 @@
+package validate_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/validate"
+)
+
+const tfstate = `{
+  "version": 4,
+  "terraform_version": "0.13.5",
+  "serial": 1,
+  "lineage": "lineage",
+  "outputs": {},
+  "resources": [
+    {"mode": "managed", "type": "aws_instance", "name": "front", "instances": [{"attributes": {"id": "i-1"}}]},
+    {"mode": "managed", "type": "aws_vpc", "name": "main", "instances": [{"attributes": {"id": "vpc-1"}}]}
+  ]
+}`
+
+// writeFiles writes the files, by the path
+// relative to the dir, creating the directories
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for p, c := range files {
+		p = filepath.Join(dir, p)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, ioutil.WriteFile(p, []byte(c), 0644))
+	}
+}
+
+func TestDir(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "terracognita-validate")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		writeFiles(t, dir, map[string]string{
+			"main.tf": `
+variable "password" {}
+
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
+
+resource "aws_instance" "front" {
+  subnet_id = "${aws_vpc.main.id}"
+  ami       = data.aws_ami.ubuntu.id
+  user_data = var.password
+
+  dynamic "ebs_block_device" {
+    for_each = []
+    content {
+      device_name = ebs_block_device.value
+    }
+  }
+}
+
+data "aws_ami" "ubuntu" {
+  most_recent = true
+}
+`,
+			"terraform.tfstate": tfstate,
+		})
+
+		res, err := validate.Dir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, res.Issues)
+		assert.Equal(t, 1, res.Modules)
+		assert.Equal(t, 2, res.Resources)
+
+		var b bytes.Buffer
+		res.Write(&b)
+		assert.Equal(t, "Validated 1 directories with 2 resources, no issues found\n", b.String())
+	})
+
+	t.Run("SuccessModulesLive", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "terracognita-validate")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		writeFiles(t, dir, map[string]string{
+			"modules/aws/main.tf": `
+resource "aws_vpc" "main" {
+  cidr_block = "10.0.0.0/16"
+}
+`,
+			"live/aws/main.tf": `
+module "aws" {
+  source = "../../modules/aws"
+}
+`,
+			"live/aws/terraform.tfstate": `{
+  "version": 4,
+  "resources": [
+    {"module": "module.aws", "mode": "managed", "type": "aws_vpc", "name": "main", "instances": [{"attributes": {"id": "vpc-1"}}]}
+  ]
+}`,
+		})
+
+		res, err := validate.Dir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, res.Issues)
+		assert.Equal(t, 2, res.Modules)
+	})
+
+	t.Run("Issues", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "terracognita-validate")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		writeFiles(t, dir, map[string]string{
+			"main.tf": `
+resource "aws_instance" "front" {
+  subnet_id = aws_subnet.main.id
+  ami       = var.ami
+}
+
+resource "aws_s3_bucket" "logs" {
+  bucket = "logs"
+}
+`,
+			"other.tf": `
+resource "aws_s3_bucket" "logs" {
+  bucket = "logs"
+}
+`,
+			"terraform.tfstate": tfstate,
+		})
+
+		res, err := validate.Dir(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []validate.Issue{
+			{File: "other.tf", Address: "aws_s3_bucket.logs", Message: "is declared more than once, also on main.tf"},
+			{File: "main.tf", Address: "aws_instance.front", Message: "the reference to var.ami is not declared"},
+			{File: "main.tf", Address: "aws_instance.front", Message: "the reference to aws_subnet.main is not declared"},
+			{File: "terraform.tfstate", Address: "aws_vpc.main", Message: "is on the TFState but has no block on the .tf files"},
+			{File: "main.tf", Address: "aws_s3_bucket.logs", Message: "has a block but is not on the TFState"},
+		}, res.Issues)
+
+		var b bytes.Buffer
+		res.Write(&b)
+		assert.Contains(t, b.String(), "found 5 issues:\n  other.tf: aws_s3_bucket.logs: is declared more than once, also on main.tf\n")
+	})
+
+	t.Run("IssueInvalidTFState", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "terracognita-validate")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		writeFiles(t, dir, map[string]string{
+			"terraform.tfstate": "ENC[...]",
+		})
+
+		res, err := validate.Dir(dir)
+		require.NoError(t, err)
+		require.Len(t, res.Issues, 1)
+		assert.Equal(t, "terraform.tfstate", res.Issues[0].File)
+	})
+}
@@ -0,0 +1,3 @@
+// Package verify checks that the HCL and TFState generated
+// are in sync by running a 'terraform plan' with them
+package verify
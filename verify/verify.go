@@ -0,0 +1,110 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// DefaultBinary is the terraform binary used
+// if none is given, searched on the PATH
+const DefaultBinary = "terraform"
+
+// Result is the result of the plan
+type Result struct {
+	// Empty is true if the plan has no changes
+	Empty bool
+
+	// Plan is the output of the plan
+	Plan string
+}
+
+// Plan copies the hcl and tfstate files to a temporal workspace and runs
+// 'terraform init' and 'terraform plan' on it with the bin, the env is
+// the environment of the commands (ex: the credentials of the provider)
+func Plan(ctx context.Context, bin, hcl, tfstate string, env []string) (*Result, error) {
+	if bin == "" {
+		bin = DefaultBinary
+	}
+
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return nil, errors.Wrapf(errcode.ErrVerifyTerraformNotFound, "%s: %s", bin, err)
+	}
+
+	dir, err := ioutil.TempDir("", "terracognita-verify")
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the workspace")
+	}
+	defer os.RemoveAll(dir)
+
+	if err := copyFile(hcl, filepath.Join(dir, "main.tf")); err != nil {
+		return nil, err
+	}
+	if err := copyFile(tfstate, filepath.Join(dir, "terraform.tfstate")); err != nil {
+		return nil, err
+	}
+
+	if out, err := run(ctx, path, dir, env, "init", "-input=false", "-no-color"); err != nil {
+		return nil, errors.Wrapf(errcode.ErrVerifyFailed, "init: %s\n%s", err, out)
+	}
+
+	// With -detailed-exitcode the exit code
+	// is 2 if the plan has changes
+	out, err := run(ctx, path, dir, env, "plan", "-input=false", "-no-color", "-lock=false", "-detailed-exitcode")
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && exitCode(ee) == 2 {
+			return &Result{Plan: out}, nil
+		}
+		return nil, errors.Wrapf(errcode.ErrVerifyFailed, "plan: %s\n%s", err, out)
+	}
+
+	return &Result{Empty: true, Plan: out}, nil
+}
+
+// run runs the bin with the args on the dir
+// and returns the combined output of it
+func run(ctx context.Context, bin, dir string, env []string, args ...string) (string, error) {
+	var out bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, bin, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	err := cmd.Run()
+
+	return out.String(), err
+}
+
+// exitCode returns the exit code of the
+// process that ended with the err
+func exitCode(err *exec.ExitError) int {
+	if ws, ok := err.Sys().(syscall.WaitStatus); ok {
+		return ws.ExitStatus()
+	}
+	return -1
+}
+
+// copyFile copies the src file to the dst
+func copyFile(src, dst string) error {
+	b, err := ioutil.ReadFile(src)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read %s", src)
+	}
+
+	if err := ioutil.WriteFile(dst, b, 0644); err != nil {
+		return errors.Wrapf(err, "unable to write %s", dst)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package verify_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/verify"
+)
+
+// fakeTerraform writes a terraform script to a new
+// directory that exits with the code on the plan
+func fakeTerraform(t *testing.T, code string) (string, func()) {
+	dir, err := ioutil.TempDir("", "terracognita-verify-test")
+	require.NoError(t, err)
+
+	bin := filepath.Join(dir, "terraform")
+	script := `#!/bin/sh
+if [ "$1" = "plan" ]; then
+	test -f main.tf && test -f terraform.tfstate || exit 1
+	echo "plan $VERIFY"
+	exit ` + code + `
+fi
+echo "init"
+`
+	require.NoError(t, ioutil.WriteFile(bin, []byte(script), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "aws_vpc" "main" {}`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "terraform.tfstate"), []byte(`{}`), 0644))
+
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestPlan(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Empty", func(t *testing.T) {
+		dir, clean := fakeTerraform(t, "0")
+		defer clean()
+
+		res, err := verify.Plan(ctx, filepath.Join(dir, "terraform"), filepath.Join(dir, "main.tf"), filepath.Join(dir, "terraform.tfstate"), []string{"VERIFY=env"})
+		require.NoError(t, err)
+		assert.True(t, res.Empty)
+		assert.Equal(t, "plan env\n", res.Plan)
+	})
+
+	t.Run("Changes", func(t *testing.T) {
+		dir, clean := fakeTerraform(t, "2")
+		defer clean()
+
+		res, err := verify.Plan(ctx, filepath.Join(dir, "terraform"), filepath.Join(dir, "main.tf"), filepath.Join(dir, "terraform.tfstate"), nil)
+		require.NoError(t, err)
+		assert.False(t, res.Empty)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		dir, clean := fakeTerraform(t, "1")
+		defer clean()
+
+		_, err := verify.Plan(ctx, filepath.Join(dir, "terraform"), filepath.Join(dir, "main.tf"), filepath.Join(dir, "terraform.tfstate"), nil)
+		assert.Equal(t, errcode.ErrVerifyFailed, errors.Cause(err))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := verify.Plan(ctx, "/not/found/terraform", "main.tf", "terraform.tfstate", nil)
+		assert.Equal(t, errcode.ErrVerifyTerraformNotFound, errors.Cause(err))
+	})
+}
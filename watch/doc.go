@@ -0,0 +1,4 @@
+// Package watch periodically scans a Provider and
+// notifies when resources appear or disappear
+// between two scans
+package watch
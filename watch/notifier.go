@@ -0,0 +1,126 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+)
+
+// Event is the information sent to the
+// Notifiers when a Drift is detected
+type Event struct {
+	Provider string    `json:"provider"`
+	Region   string    `json:"region"`
+	Time     time.Time `json:"time"`
+	Drift
+}
+
+// Notifier sends the Event to an external system
+type Notifier interface {
+	Notify(ctx context.Context, e Event) error
+}
+
+// webhook sends the Event as JSON
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook returns a Notifier that POSTs the
+// Event as JSON to the url
+func NewWebhook(url string) Notifier {
+	return &webhook{url: url, client: http.DefaultClient}
+}
+
+func (w *webhook) Notify(ctx context.Context, e Event) error {
+	return post(ctx, w.client, w.url, e)
+}
+
+// slack sends the Event as a Slack message
+type slack struct {
+	url    string
+	client *http.Client
+}
+
+// NewSlack returns a Notifier that sends the Event
+// to the Slack Incoming Webhook url
+func NewSlack(url string) Notifier {
+	return &slack{url: url, client: http.DefaultClient}
+}
+
+func (s *slack) Notify(ctx context.Context, e Event) error {
+	return post(ctx, s.client, s.url, struct {
+		Text string `json:"text"`
+	}{
+		Text: slackText(e),
+	})
+}
+
+// slackText formats the e as Slack markdown
+func slackText(e Event) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "*Terracognita* detected changes on *%s* (%s)\n", e.Provider, e.Region)
+	for _, s := range []struct {
+		title     string
+		resources Snapshot
+	}{
+		{title: "New resources", resources: e.Added},
+		{title: "Removed resources", resources: e.Removed},
+	} {
+		if len(s.resources) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "%s:\n", s.title)
+
+		types := make([]string, 0, len(s.resources))
+		for t := range s.resources {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+
+		for _, t := range types {
+			for _, id := range s.resources[t] {
+				fmt.Fprintf(&b, "• `%s.%s`\n", t, id)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// post sends the body as JSON to the url
+func post(ctx context.Context, c *http.Client, url string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "could not encode the notification")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return errors.Wrapf(err, "could not create the request to %s", url)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "could not send the notification to %s", url)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Wrapf(errcode.ErrWatchNotifyFailed, "to %s with status %d", url, res.StatusCode)
+	}
+
+	return nil
+}
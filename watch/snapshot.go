@@ -0,0 +1,90 @@
+package watch
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Snapshot has all the IDs of the resources
+// found on a scan grouped by resource type
+type Snapshot map[string][]string
+
+// Drift it's the difference between
+// two Snapshots
+type Drift struct {
+	Added   Snapshot `json:"added"`
+	Removed Snapshot `json:"removed"`
+}
+
+// IsEmpty checks if the Drift has no changes
+func (d Drift) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// Diff returns the Drift from the prev Snapshot to the cur one
+func Diff(prev, cur Snapshot) Drift {
+	return Drift{
+		Added:   subtract(cur, prev),
+		Removed: subtract(prev, cur),
+	}
+}
+
+// subtract returns the IDs on a that are not on b
+func subtract(a, b Snapshot) Snapshot {
+	res := make(Snapshot)
+	for t, ids := range a {
+		found := make(map[string]struct{}, len(b[t]))
+		for _, id := range b[t] {
+			found[id] = struct{}{}
+		}
+
+		for _, id := range ids {
+			if _, ok := found[id]; !ok {
+				res[t] = append(res[t], id)
+			}
+		}
+
+		if len(res[t]) != 0 {
+			sort.Strings(res[t])
+		}
+	}
+
+	return res
+}
+
+// readSnapshot reads the Snapshot from the path, if
+// the file does not exist it returns a nil Snapshot
+func readSnapshot(path string) (Snapshot, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "could not read the snapshot %s", path)
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, errors.Wrapf(err, "could not decode the snapshot %s", path)
+	}
+
+	return s, nil
+}
+
+// writeSnapshot writes the s to the path
+func writeSnapshot(path string, s Snapshot) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not encode the snapshot")
+	}
+
+	if err := ioutil.WriteFile(path, b, 0644); err != nil {
+		return errors.Wrapf(err, "could not write the snapshot %s", path)
+	}
+
+	return nil
+}
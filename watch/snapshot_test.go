@@ -0,0 +1,40 @@
+package watch_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/watch"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Prev, Cur watch.Snapshot
+		Drift     watch.Drift
+	}{
+		{
+			Name:  "Equal",
+			Prev:  watch.Snapshot{"aws_instance": []string{"1", "2"}},
+			Cur:   watch.Snapshot{"aws_instance": []string{"2", "1"}},
+			Drift: watch.Drift{Added: watch.Snapshot{}, Removed: watch.Snapshot{}},
+		},
+		{
+			Name: "AddedAndRemoved",
+			Prev: watch.Snapshot{"aws_instance": []string{"1", "2"}, "aws_iam_user": []string{"a"}},
+			Cur:  watch.Snapshot{"aws_instance": []string{"3", "1"}, "aws_s3_bucket": []string{"b"}},
+			Drift: watch.Drift{
+				Added:   watch.Snapshot{"aws_instance": []string{"3"}, "aws_s3_bucket": []string{"b"}},
+				Removed: watch.Snapshot{"aws_instance": []string{"2"}, "aws_iam_user": []string{"a"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			d := watch.Diff(tt.Prev, tt.Cur)
+			assert.Equal(t, tt.Drift, d)
+			assert.Equal(t, len(tt.Drift.Added) == 0 && len(tt.Drift.Removed) == 0, d.IsEmpty())
+		})
+	}
+}
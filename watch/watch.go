@@ -0,0 +1,120 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	kitlog "github.com/go-kit/kit/log"
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/log"
+	"github.com/cycloidio/terracognita/provider"
+)
+
+// Options are the configuration of Watch
+type Options struct {
+	// Interval is the time between scans
+	Interval time.Duration
+
+	// Snapshot is the path of the file in which the
+	// last Snapshot is stored, so the changes are
+	// detected even between restarts. If empty
+	// the first scan is used as the starting point
+	Snapshot string
+
+	// Notifiers are called each time a Drift is
+	// detected
+	Notifiers []Notifier
+}
+
+// Watch scans the Provider p filtered by f each opt.Interval and
+// notifies opt.Notifiers when the resources change from the
+// previous scan. It only returns when the ctx is canceled or
+// the Snapshot can not be read or written
+func Watch(ctx context.Context, p provider.Provider, f *filter.Filter, opt Options, out io.Writer) error {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "watch.Watch")
+
+	var (
+		prev Snapshot
+		err  error
+	)
+
+	if opt.Snapshot != "" {
+		prev, err = readSnapshot(opt.Snapshot)
+		if err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(opt.Interval)
+	defer ticker.Stop()
+
+	for {
+		fmt.Fprintf(out, "Scanning %s at %s\n", p, time.Now().Format(time.RFC3339))
+		logger.Log("msg", "scanning")
+
+		cur, err := provider.Discover(ctx, p, f, out)
+		if err != nil {
+			// A failed scan is retried on the next tick instead
+			// of stopping the watch, as most of them are
+			// temporary errors from the Provider
+			fmt.Fprintf(out, "Scan failed: %s\n", err)
+			logger.Log("msg", "scan failed", "error", err)
+		} else {
+			if prev != nil {
+				drift := Diff(prev, Snapshot(cur))
+				if !drift.IsEmpty() {
+					notify(ctx, Event{
+						Provider: p.String(),
+						Region:   p.Region(),
+						Time:     time.Now(),
+						Drift:    drift,
+					}, opt.Notifiers, out)
+				}
+			}
+
+			prev = Snapshot(cur)
+
+			if opt.Snapshot != "" {
+				if err := writeSnapshot(opt.Snapshot, prev); err != nil {
+					return err
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.WithStack(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// notify sends the e to all the ns, the errors
+// are only logged so one failing Notifier does not
+// stop the rest
+func notify(ctx context.Context, e Event, ns []Notifier, out io.Writer) {
+	logger := log.Get()
+	logger = kitlog.With(logger, "func", "watch.notify")
+
+	var added, removed int
+	for _, ids := range e.Added {
+		added += len(ids)
+	}
+	for _, ids := range e.Removed {
+		removed += len(ids)
+	}
+
+	fmt.Fprintf(out, "Changes detected: %d new and %d removed resources\n", added, removed)
+	logger.Log("msg", "changes detected", "added", added, "removed", removed)
+
+	for _, n := range ns {
+		if err := n.Notify(ctx, e); err != nil {
+			logger.Log("msg", "notification failed", "error", err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package watch_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/filter"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/watch"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type notifier struct {
+	events []watch.Event
+	cancel context.CancelFunc
+}
+
+func (n *notifier) Notify(ctx context.Context, e watch.Event) error {
+	n.events = append(n.events, e)
+	n.cancel()
+	return nil
+}
+
+func TestWatch(t *testing.T) {
+	var (
+		ctrl        = gomock.NewController(t)
+		ctx, cancel = context.WithCancel(context.Background())
+
+		p  = mock.NewProvider(ctrl)
+		i1 = mock.NewResource(ctrl)
+		i2 = mock.NewResource(ctrl)
+
+		f = &filter.Filter{Include: []string{"aws_instance"}}
+		n = &notifier{cancel: cancel}
+	)
+	defer ctrl.Finish()
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "terracognita-watch")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	snapshot := filepath.Join(dir, "snapshot.json")
+
+	p.EXPECT().String().Return("aws").AnyTimes()
+	p.EXPECT().Region().Return("eu-west-1")
+	p.EXPECT().HasResourceType("aws_instance").Return(true).Times(2)
+	gomock.InOrder(
+		p.EXPECT().Resources(gomock.Any(), "aws_instance", f).Return([]provider.Resource{i1}, nil),
+		p.EXPECT().Resources(gomock.Any(), "aws_instance", f).Return([]provider.Resource{i2}, nil),
+	)
+
+	i1.EXPECT().ID().Return("1")
+	i2.EXPECT().ID().Return("2")
+
+	err = watch.Watch(ctx, p, f, watch.Options{
+		Interval:  10 * time.Millisecond,
+		Snapshot:  snapshot,
+		Notifiers: []watch.Notifier{n},
+	}, ioutil.Discard)
+	assert.Equal(t, context.Canceled, errors.Cause(err))
+
+	require.Len(t, n.events, 1)
+	assert.Equal(t, "aws", n.events[0].Provider)
+	assert.Equal(t, "eu-west-1", n.events[0].Region)
+	assert.Equal(t, watch.Snapshot{"aws_instance": []string{"2"}}, n.events[0].Added)
+	assert.Equal(t, watch.Snapshot{"aws_instance": []string{"1"}}, n.events[0].Removed)
+
+	b, err := ioutil.ReadFile(snapshot)
+	require.NoError(t, err)
+
+	var s watch.Snapshot
+	require.NoError(t, json.Unmarshal(b, &s))
+	assert.Equal(t, watch.Snapshot{"aws_instance": []string{"2"}}, s)
+}
+
+func TestNotifiers(t *testing.T) {
+	e := watch.Event{
+		Provider: "aws",
+		Region:   "eu-west-1",
+		Drift: watch.Drift{
+			Added: watch.Snapshot{"aws_instance": []string{"i-123"}},
+		},
+	}
+
+	t.Run("Webhook", func(t *testing.T) {
+		var body watch.Event
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}))
+		defer ts.Close()
+
+		err := watch.NewWebhook(ts.URL).Notify(context.Background(), e)
+		require.NoError(t, err)
+		assert.Equal(t, e.Added, body.Added)
+	})
+	t.Run("Slack", func(t *testing.T) {
+		var body struct {
+			Text string `json:"text"`
+		}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}))
+		defer ts.Close()
+
+		err := watch.NewSlack(ts.URL).Notify(context.Background(), e)
+		require.NoError(t, err)
+		assert.True(t, strings.Contains(body.Text, "`aws_instance.i-123`"))
+	})
+	t.Run("ErrWatchNotifyFailed", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+
+		err := watch.NewWebhook(ts.URL).Notify(context.Background(), e)
+		assert.Equal(t, errcode.ErrWatchNotifyFailed, errors.Cause(err))
+	})
+}
@@ -0,0 +1,89 @@
+package writer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/log"
+)
+
+// Collector keeps the values written in the order they were
+// written, it's the base of the Writers of the formats that
+// render all the values at once on the Sync (ex: manifests or
+// inventories), which only have to implement the Sync
+type Collector struct {
+	// Config has the values written by the key
+	Config map[string]interface{}
+
+	// keys keeps the order in
+	// which they were written
+	keys []string
+
+	// name is the name of the
+	// format used on the logs
+	name string
+
+	// accept checks the values written and returns
+	// false for the ones to ignore, see NewCollector
+	accept func(key string, value interface{}) (bool, error)
+}
+
+// NewCollector returns a Collector initialization for the format
+// with the name, the accept is called with each value written
+// and returns an error if it's invalid or false to ignore it
+// (ex: the types not supported by the format)
+func NewCollector(name string, accept func(key string, value interface{}) (bool, error)) *Collector {
+	return &Collector{
+		Config: make(map[string]interface{}),
+		name:   name,
+		accept: accept,
+	}
+}
+
+// Write expects a key similar to "aws_instance.your_name"
+// and the value to be accepted, repeated keys will report an error
+func (c *Collector) Write(key string, value interface{}) error {
+	if key == "" {
+		return errcode.ErrWriterRequiredKey
+	}
+
+	if value == nil {
+		return errcode.ErrWriterRequiredValue
+	}
+
+	if _, ok := c.Config[key]; ok {
+		return errors.Wrapf(errcode.ErrWriterAlreadyExistsKey, "with key %q", key)
+	}
+
+	if len(strings.Split(key, ".")) != 2 {
+		return errors.Wrapf(errcode.ErrWriterInvalidKey, "with key %q", key)
+	}
+
+	ok, err := c.accept(key, value)
+	if err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+
+	log.Get().Log("func", fmt.Sprintf("writer.Write(%s)", c.name), "msg", "writing to internal config", "key", key)
+	c.Config[key] = value
+	c.keys = append(c.keys, key)
+
+	return nil
+}
+
+// Has checks if the given key it's already present or not
+func (c *Collector) Has(key string) (bool, error) {
+	_, ok := c.Config[key]
+	return ok, nil
+}
+
+// Keys returns the keys of the
+// values in the order they were written
+func (c *Collector) Keys() []string {
+	return c.keys
+}
@@ -0,0 +1,54 @@
+package writer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cycloidio/terracognita/errcode"
+	"github.com/cycloidio/terracognita/writer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	accept := func(key string, value interface{}) (bool, error) {
+		if _, ok := value.(string); !ok {
+			return false, errors.Wrapf(errcode.ErrWriterInvalidTypeValue, "expected string, found %T", value)
+		}
+		return !strings.HasPrefix(key, "aws_iam_user."), nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		c := writer.NewCollector("test", accept)
+
+		require.NoError(t, c.Write("aws_instance.front", "i-123"))
+		require.NoError(t, c.Write("aws_iam_user.john", "john"))
+		require.NoError(t, c.Write("aws_instance.back", "i-456"))
+
+		ok, err := c.Has("aws_instance.front")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = c.Has("aws_iam_user.john")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		assert.Equal(t, []string{"aws_instance.front", "aws_instance.back"}, c.Keys())
+		assert.Equal(t, map[string]interface{}{
+			"aws_instance.front": "i-123",
+			"aws_instance.back":  "i-456",
+		}, c.Config)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		c := writer.NewCollector("test", accept)
+
+		assert.Equal(t, errcode.ErrWriterRequiredKey, errors.Cause(c.Write("", "value")))
+		assert.Equal(t, errcode.ErrWriterRequiredValue, errors.Cause(c.Write("type.name", nil)))
+		assert.Equal(t, errcode.ErrWriterInvalidKey, errors.Cause(c.Write("type", "value")))
+		assert.Equal(t, errcode.ErrWriterInvalidTypeValue, errors.Cause(c.Write("type.name", 1)))
+
+		require.NoError(t, c.Write("type.name", "value"))
+		assert.Equal(t, errcode.ErrWriterAlreadyExistsKey, errors.Cause(c.Write("type.name", "value")))
+	})
+}
@@ -0,0 +1,43 @@
+package writer
+
+// Middleware wraps a Writer to intercept the writes
+// to it (ex: to transform, audit or filter them)
+type Middleware func(Writer) Writer
+
+// Chain returns the w wrapped by all the ms, the first
+// one is the outermost so it's the first to get the writes
+func Chain(w Writer, ms ...Middleware) Writer {
+	for i := len(ms) - 1; i >= 0; i-- {
+		w = ms[i](w)
+	}
+	return w
+}
+
+// interceptWriter calls the fn before each Write
+type interceptWriter struct {
+	Writer
+
+	fn func(key string, value interface{}) (interface{}, error)
+}
+
+// InterceptWrite returns a Middleware that calls the fn with each
+// key and value written and writes the value returned instead,
+// if it's nil the write is skipped and if there is an error
+// it's returned by the Write
+func InterceptWrite(fn func(key string, value interface{}) (interface{}, error)) Middleware {
+	return func(w Writer) Writer {
+		return &interceptWriter{Writer: w, fn: fn}
+	}
+}
+
+// Write writes the value returned by the fn
+func (i *interceptWriter) Write(key string, value interface{}) error {
+	v, err := i.fn(key, value)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+	return i.Writer.Write(key, v)
+}
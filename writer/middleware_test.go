@@ -0,0 +1,59 @@
+package writer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/writer"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl  = gomock.NewController(t)
+			w     = mock.NewWriter(ctrl)
+			order = make([]string, 0)
+			audit = func(name string) writer.Middleware {
+				return writer.InterceptWrite(func(key string, value interface{}) (interface{}, error) {
+					order = append(order, name)
+					return value, nil
+				})
+			}
+			skip = writer.InterceptWrite(func(key string, value interface{}) (interface{}, error) {
+				if strings.HasPrefix(key, "aws_iam_user.") {
+					return nil, nil
+				}
+				return strings.ToUpper(value.(string)), nil
+			})
+			cw = writer.Chain(w, audit("first"), audit("second"), skip)
+		)
+		defer ctrl.Finish()
+
+		w.EXPECT().Write("aws_instance.front", "VALUE").Return(nil)
+		w.EXPECT().Sync().Return(nil)
+
+		require.NoError(t, cw.Write("aws_instance.front", "value"))
+		require.NoError(t, cw.Write("aws_iam_user.admin", "value"))
+		require.NoError(t, cw.Sync())
+
+		assert.Equal(t, []string{"first", "second", "first", "second"}, order)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			w    = mock.NewWriter(ctrl)
+			cw   = writer.Chain(w, writer.InterceptWrite(func(key string, value interface{}) (interface{}, error) {
+				return nil, errors.New("denied")
+			}))
+		)
+		defer ctrl.Finish()
+
+		assert.EqualError(t, cw.Write("aws_instance.front", "value"), "denied")
+	})
+}
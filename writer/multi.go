@@ -0,0 +1,48 @@
+package writer
+
+// multiWriter writes to all the writers
+type multiWriter struct {
+	writers []Writer
+}
+
+// NewMultiWriter returns a Writer that duplicates the writes
+// to all the ws, similar to io.MultiWriter
+func NewMultiWriter(ws ...Writer) Writer {
+	return &multiWriter{writers: ws}
+}
+
+// Write writes the key and value to all the writers
+// and stops on the first error
+func (m *multiWriter) Write(key string, value interface{}) error {
+	for _, w := range m.writers {
+		if err := w.Write(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Has checks if any of the writers has the key
+func (m *multiWriter) Has(key string) (bool, error) {
+	for _, w := range m.writers {
+		ok, err := w.Has(key)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Sync syncs all the writers
+// and stops on the first error
+func (m *multiWriter) Sync() error {
+	for _, w := range m.writers {
+		if err := w.Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package writer_test
+
+import (
+	"testing"
+
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/writer"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiWriter(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			w1   = mock.NewWriter(ctrl)
+			w2   = mock.NewWriter(ctrl)
+			mw   = writer.NewMultiWriter(w1, w2)
+		)
+		defer ctrl.Finish()
+
+		w1.EXPECT().Write("type.name", "value").Return(nil)
+		w2.EXPECT().Write("type.name", "value").Return(nil)
+
+		w1.EXPECT().Has("type.name").Return(false, nil)
+		w2.EXPECT().Has("type.name").Return(true, nil)
+
+		w1.EXPECT().Sync().Return(nil)
+		w2.EXPECT().Sync().Return(nil)
+
+		require.NoError(t, mw.Write("type.name", "value"))
+
+		ok, err := mw.Has("type.name")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		require.NoError(t, mw.Sync())
+	})
+	t.Run("Error", func(t *testing.T) {
+		var (
+			ctrl = gomock.NewController(t)
+			w1   = mock.NewWriter(ctrl)
+			w2   = mock.NewWriter(ctrl)
+			mw   = writer.NewMultiWriter(w1, w2)
+			e    = errors.New("error")
+		)
+		defer ctrl.Finish()
+
+		w1.EXPECT().Write("type.name", "value").Return(e)
+		w1.EXPECT().Sync().Return(e)
+
+		assert.Equal(t, e, mw.Write("type.name", "value"))
+		assert.Equal(t, e, mw.Sync())
+	})
+}
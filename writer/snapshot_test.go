@@ -0,0 +1,235 @@
+package writer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/cycloidio/terracognita/hcl"
+	"github.com/cycloidio/terracognita/mock"
+	"github.com/cycloidio/terracognita/provider"
+	"github.com/cycloidio/terracognita/state"
+	"github.com/golang/mock/gomock"
+	"github.com/hashicorp/terraform/configs/hcl2shim"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/providers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-aws/aws"
+	"github.com/terraform-providers/terraform-provider-google/google"
+)
+
+// update updates the golden files of the snapshots with
+// the current output, see 'make update-snapshots'
+var update = flag.Bool("update", false, "update the golden files of the snapshots")
+
+// snapshotResource is a resource of a snapshot
+// with the raw configuration of it
+type snapshotResource struct {
+	tp   string
+	name string
+	id   string
+	raw  map[string]interface{}
+}
+
+// TestSnapshots renders the HCL and TFState of representative resources
+// of each provider and compares them with the golden files on testdata/,
+// so any change on the output of the writers has to be reviewed
+func TestSnapshots(t *testing.T) {
+	tests := []struct {
+		name       string
+		tfProvider *schema.Provider
+		resources  []snapshotResource
+	}{
+		{
+			name:       "aws",
+			tfProvider: aws.Provider().(*schema.Provider),
+			resources: []snapshotResource{
+				{
+					tp: "aws_instance", name: "front", id: "i-0123456789",
+					raw: map[string]interface{}{
+						"ami":           "ami-123",
+						"instance_type": "t2.micro",
+						"subnet_id":     "subnet-123",
+						"tags":          map[string]interface{}{"Name": "front", "env": "prod"},
+					},
+				},
+				{
+					tp: "aws_security_group", name: "front", id: "sg-123",
+					raw: map[string]interface{}{
+						"name":        "front",
+						"description": "Front access",
+						"vpc_id":      "vpc-123",
+						"ingress": []interface{}{
+							map[string]interface{}{
+								"from_port":   443,
+								"to_port":     443,
+								"protocol":    "tcp",
+								"cidr_blocks": []interface{}{"0.0.0.0/0"},
+							},
+						},
+					},
+				},
+				{
+					tp: "aws_iam_user", name: "admin", id: "admin",
+					raw: map[string]interface{}{
+						"name": "admin",
+						"path": "/ops/",
+					},
+				},
+			},
+		},
+		{
+			name:       "aws_heredoc",
+			tfProvider: aws.Provider().(*schema.Provider),
+			resources: []snapshotResource{
+				{
+					tp: "aws_iam_role", name: "ecs", id: "ecs",
+					raw: map[string]interface{}{
+						"name":               "ecs",
+						"assume_role_policy": `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"ecs.amazonaws.com"},"Action":"sts:AssumeRole"}]}`,
+					},
+				},
+				{
+					tp: "aws_iam_policy", name: "home", id: "arn:aws:iam::123456789012:policy/home",
+					raw: map[string]interface{}{
+						"name":   "home",
+						"policy": `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:*","Resource":"arn:aws:s3:::home/${aws:username}/*"}]}`,
+					},
+				},
+				{
+					tp: "aws_cloudwatch_event_rule", name: "ec2", id: "ec2",
+					raw: map[string]interface{}{
+						"name":          "ec2",
+						"event_pattern": `{"source":["aws.ec2"]}`,
+					},
+				},
+				{
+					tp: "aws_sfn_state_machine", name: "flow", id: "arn:aws:states:eu-west-1:123456789012:stateMachine:flow",
+					raw: map[string]interface{}{
+						"name":       "flow",
+						"role_arn":   "arn:aws:iam::123456789012:role/flow",
+						"definition": "{\n  \"StartAt\": \"Done\",\n  \"States\": {\"Done\": {\"Type\": \"Succeed\"}}\n}\n",
+					},
+				},
+				{
+					tp: "aws_ssm_parameter", name: "config", id: "config",
+					raw: map[string]interface{}{
+						"name":  "config",
+						"type":  "String",
+						"value": `{"key":"value"}`,
+					},
+				},
+			},
+		},
+		{
+			name:       "google",
+			tfProvider: google.Provider().(*schema.Provider),
+			resources: []snapshotResource{
+				{
+					tp: "google_compute_network", name: "main", id: "main",
+					raw: map[string]interface{}{
+						"name":                    "main",
+						"auto_create_subnetworks": false,
+					},
+				},
+				{
+					tp: "google_storage_bucket", name: "assets", id: "assets",
+					raw: map[string]interface{}{
+						"name":          "assets",
+						"location":      "EU",
+						"storage_class": "MULTI_REGIONAL",
+						"labels":        map[string]interface{}{"env": "prod"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				ctrl = gomock.NewController(t)
+				prv  = mock.NewProvider(ctrl)
+				hb   = &bytes.Buffer{}
+				sb   = &bytes.Buffer{}
+				hw   = hcl.NewWriter(hb)
+				sw   = state.NewWriter(sb)
+			)
+			defer ctrl.Finish()
+
+			prv.EXPECT().String().Return(tt.name).AnyTimes()
+
+			for _, sr := range tt.resources {
+				res := snapshotMock(t, ctrl, prv, tt.tfProvider, sr)
+				key := sr.tp + "." + sr.name
+
+				require.NoError(t, hw.Write(key, provider.HCLConfig(res)))
+				require.NoError(t, sw.Write(key, res))
+			}
+
+			require.NoError(t, hw.Sync())
+			require.NoError(t, sw.Sync())
+
+			golden(t, tt.name+".tf", hb.Bytes())
+			golden(t, tt.name+".tfstate", snapshotState(t, sb.Bytes()))
+		})
+	}
+}
+
+// snapshotMock returns the mock of the provider.Resource
+// of the sr with the state of the raw configuration
+func snapshotMock(t *testing.T, ctrl *gomock.Controller, prv provider.Provider, tfp *schema.Provider, sr snapshotResource) provider.Resource {
+	tfr := tfp.ResourcesMap[sr.tp]
+	require.NotNil(t, tfr, "the resource %s does not exist", sr.tp)
+
+	data := schema.TestResourceDataRaw(t, tfr.Schema, sr.raw)
+	data.SetId(sr.id)
+
+	s, err := hcl2shim.HCL2ValueFromFlatmap(data.State().Attributes, tfr.CoreConfigSchema().ImpliedType())
+	require.NoError(t, err)
+
+	res := mock.NewResource(ctrl)
+	res.EXPECT().Type().Return(sr.tp).AnyTimes()
+	res.EXPECT().Provider().Return(prv).AnyTimes()
+	res.EXPECT().Data().Return(data).AnyTimes()
+	res.EXPECT().TFResource().Return(tfr).AnyTimes()
+	res.EXPECT().CoreConfigSchema().Return(tfr.CoreConfigSchema()).AnyTimes()
+	res.EXPECT().ResourceInstanceObject().Return(providers.ImportedResource{
+		TypeName: sr.tp,
+		State:    s,
+	}.AsInstanceObject()).AnyTimes()
+
+	return res
+}
+
+// snapshotState returns the TFState b with a fixed
+// lineage, as it's random, and indented
+func snapshotState(t *testing.T, b []byte) []byte {
+	var st map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &st))
+
+	st["lineage"] = "lineage"
+
+	b, err := json.MarshalIndent(st, "", "  ")
+	require.NoError(t, err)
+
+	return append(b, '\n')
+}
+
+// golden compares the b with the golden file of the name
+// on testdata/, or updates it with the b with -update
+func golden(t *testing.T, name string, b []byte) {
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, ioutil.WriteFile(path, b, 0644))
+	}
+
+	exp, err := ioutil.ReadFile(path)
+	require.NoError(t, err, "run the tests with -update to create the golden files")
+
+	assert.Equal(t, string(exp), string(b), "the output changed, if it's expected run the tests with -update and review the golden files")
+}